@@ -0,0 +1,177 @@
+package animation_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/badu/term"
+	"github.com/badu/term/animation"
+	"github.com/badu/term/color"
+	"github.com/badu/term/geom"
+	"github.com/badu/term/style"
+)
+
+// fakeDispatcher hands the registered listeners to the test, which sends the ticks
+type fakeDispatcher struct {
+	listeners chan term.TimerListener
+}
+
+func (d *fakeDispatcher) Register(r term.TimerListener) {
+	d.listeners <- r
+}
+
+type fakeTick struct {
+	when time.Time
+}
+
+func (e *fakeTick) When() time.Time        { return e.when }
+func (e *fakeTick) Elapsed() time.Duration { return 0 }
+
+func TestEasings(t *testing.T) {
+	for name, easing := range map[string]animation.Easing{
+		"Linear":     animation.Linear,
+		"QuadIn":     animation.QuadIn,
+		"QuadOut":    animation.QuadOut,
+		"QuadInOut":  animation.QuadInOut,
+		"CubicIn":    animation.CubicIn,
+		"CubicOut":   animation.CubicOut,
+		"CubicInOut": animation.CubicInOut,
+		"SineInOut":  animation.SineInOut,
+		"BackOut":    animation.BackOut,
+		"ElasticOut": animation.ElasticOut,
+		"BounceOut":  animation.BounceOut,
+	} {
+		if v := easing(0); math.Abs(v) > 1e-9 {
+			t.Errorf("error : %s(0) should be zero, got %f", name, v)
+		}
+		if v := easing(1); math.Abs(v-1) > 1e-9 {
+			t.Errorf("error : %s(1) should be one, got %f", name, v)
+		}
+	}
+	if v := animation.QuadInOut(0.5); v != 0.5 {
+		t.Errorf("error : the middle of QuadInOut should be the half, got %f", v)
+	}
+}
+
+func TestTween(t *testing.T) {
+	tween := animation.NewTween(100*time.Millisecond, func(float64) {}, animation.WithEasing(animation.Linear), animation.WithDelay(50*time.Millisecond))
+	for _, test := range []struct {
+		elapsed  time.Duration
+		progress float64
+		done     bool
+	}{
+		{0, 0, false},
+		{50 * time.Millisecond, 0, false},
+		{100 * time.Millisecond, 0.5, false},
+		{150 * time.Millisecond, 1, true},
+		{time.Second, 1, true},
+	} {
+		progress, done := tween.At(test.elapsed)
+		if progress != test.progress || done != test.done {
+			t.Errorf("error : at %v : expecting %f %v, got %f %v", test.elapsed, test.progress, test.done, progress, done)
+		}
+	}
+	if d := tween.Duration(); d != 150*time.Millisecond {
+		t.Errorf("error : expecting the duration to include the delay, got %v", d)
+	}
+
+	tween = animation.NewTween(100*time.Millisecond, func(float64) {}, animation.WithEasing(animation.Linear), animation.WithRepeat(1), animation.WithAlternate())
+	if progress, done := tween.At(125 * time.Millisecond); progress != 0.75 || done {
+		t.Errorf("error : expecting the second play backwards, got %f %v", progress, done)
+	}
+	if progress, done := tween.At(200 * time.Millisecond); progress != 0 || !done {
+		t.Errorf("error : expecting the alternating tween to end at its start, got %f %v", progress, done)
+	}
+
+	tween = animation.NewTween(100*time.Millisecond, func(float64) {}, animation.WithEasing(animation.Linear), animation.WithRepeat(animation.Forever))
+	if progress, done := tween.At(10*time.Second + 25*time.Millisecond); progress != 0.25 || done {
+		t.Errorf("error : expecting the tween to repeat forever, got %f %v", progress, done)
+	}
+}
+
+func TestInterpolation(t *testing.T) {
+	if v := animation.Int(10, 20, 0.54); v != 15 {
+		t.Errorf("error : expecting 15, got %d", v)
+	}
+	from, to := geom.Bounds{Left: 0, Top: 0, Right: 10, Bottom: 4}, geom.Bounds{Left: 10, Top: 2, Right: 30, Bottom: 4}
+	if b := animation.Bounds(from, to, 0.5); b != (geom.Bounds{Left: 5, Top: 1, Right: 20, Bottom: 4}) {
+		t.Errorf("error : unexpected bounds %+v", b)
+	}
+	st := animation.Style(style.Style{Fg: color.Red, Bg: color.Black}, style.Style{Fg: color.Blue, Bg: color.White, Attrs: style.Bold}, 0.5)
+	if st.Fg != color.Blend(color.Red, color.Blue, 0.5) || st.Attrs != style.Bold {
+		t.Errorf("error : unexpected style %+v", st)
+	}
+}
+
+func TestAnimator(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dispatcher := &fakeDispatcher{listeners: make(chan term.TimerListener, 2)}
+	frames := make(chan struct{}, 16)
+	animator := animation.NewAnimator(ctx, dispatcher, animation.WithOnFrame(func() { frames <- struct{}{} }))
+
+	grid, _ := geom.NewPixelGrid(term.NewSize(2, 1))
+	pixels := []term.Pixel{grid[0][0], grid[1][0]}
+	for _, pixel := range pixels {
+		pixel.SetFgBg(color.Red, color.Black)
+	}
+	done := make(chan struct{})
+	tween := animation.Recolor(pixels, style.Style{Fg: color.Blue, Bg: color.White, Attrs: style.Underline}, time.Second,
+		animation.WithEasing(animation.Linear), animation.WithOnDone(func() { close(done) }))
+	start := time.Now()
+	animator.PlayAt(tween, start)
+	<-frames // the starting values
+	if fg, _, _ := pixels[0].Style(); color.Hex(fg) != color.Hex(color.Red) {
+		t.Errorf("error : expecting the starting color, got %v", fg)
+	}
+	listener := <-dispatcher.listeners
+	if listener.TickInterval() != 33*time.Millisecond {
+		t.Errorf("error : expecting the default frame interval, got %v", listener.TickInterval())
+	}
+
+	listener.TickListen() <- &fakeTick{when: start.Add(500 * time.Millisecond)}
+	<-frames
+	if fg, _, attrs := pixels[1].Style(); fg != color.Blend(color.Red, color.Blue, 0.5) || attrs != style.Underline {
+		t.Errorf("error : expecting the colors halfway, got %v %v", fg, attrs)
+	}
+	if animator.Playing() != 1 {
+		t.Errorf("error : expecting the tween to be played")
+	}
+
+	listener.TickListen() <- &fakeTick{when: start.Add(2 * time.Second)}
+	<-frames
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("error : the tween should have ended")
+	}
+	if _, bg, _ := pixels[0].Style(); bg != color.White {
+		t.Errorf("error : expecting the final background, got %v", bg)
+	}
+	select {
+	case <-listener.DyingChan():
+	default:
+		t.Errorf("error : the ticks should stop once no tween is played")
+	}
+	if animator.Playing() != 0 {
+		t.Errorf("error : expecting no tween to be played")
+	}
+}
+
+func TestMove(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	askCh := make(chan term.Position, 1024)
+	r, err := geom.NewRectangle(ctx, geom.WithAcquisitionChan(askCh), geom.WithTopCorner(0, 0), geom.WithBottomCorner(3, 1))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	to := geom.Bounds{Left: 10, Top: 4, Right: 15, Bottom: 5}
+	dispatcher := &fakeDispatcher{listeners: make(chan term.TimerListener, 2)}
+	animation.NewAnimator(ctx, dispatcher).PlayAt(animation.Move(r, to, time.Second), time.Now().Add(-2*time.Second))
+	if b := r.Bounds(); b != to {
+		t.Errorf("error : expecting the rectangle to be moved to %+v, got %+v", to, b)
+	}
+}
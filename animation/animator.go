@@ -0,0 +1,178 @@
+package animation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/badu/term"
+)
+
+const defaultFrameInterval = 33 * time.Millisecond // about thirty frames per second
+
+// AnimatorOption configures the Animator
+type AnimatorOption func(a *Animator)
+
+// WithFrameInterval sets the interval of the ticks, while tweens are played. Default is 33 milliseconds.
+func WithFrameInterval(interval time.Duration) AnimatorOption {
+	return func(a *Animator) {
+		if interval > 0 {
+			a.interval = interval
+		}
+	}
+}
+
+// WithOnFrame sets the hook called after the tweens have changed their values on each frame, e.g. for drawing a chart or a banner again
+func WithOnFrame(hook func()) AnimatorOption {
+	return func(a *Animator) {
+		a.onFrame = hook
+	}
+}
+
+// playing is a tween being played
+type playing struct {
+	tween *Tween    //
+	start time.Time //
+}
+
+// frameTicker receives the ticks while tweens are played, dying when the last one ends
+type frameTicker struct {
+	ch       chan term.TickEvent //
+	died     chan struct{}       //
+	interval time.Duration       //
+}
+
+// DyingChan implements term.Death interface
+func (k *frameTicker) DyingChan() chan struct{} {
+	return k.died
+}
+
+// TickListen implements term.TimerListener interface
+func (k *frameTicker) TickListen() chan term.TickEvent {
+	return k.ch
+}
+
+// TickInterval implements term.TimerListener interface
+func (k *frameTicker) TickInterval() time.Duration {
+	return k.interval
+}
+
+// Animator plays the tweens on the ticks of the engine timer (see term.TimerDispatcher), which run only while tweens are played.
+type Animator struct {
+	sync.Mutex                      //
+	ctx        context.Context      //
+	dispatcher term.TimerDispatcher // of the engine
+	playing    []*playing           // in the order they were played
+	ticker     *frameTicker         // nil while no tweens are played
+	interval   time.Duration        // set by WithFrameInterval
+	onFrame    func()               // set by WithOnFrame
+}
+
+// NewAnimator returns an animator, registering with the timer dispatcher of the engine (engine.TimerDispatcher()) while it plays tweens
+func NewAnimator(ctx context.Context, dispatcher term.TimerDispatcher, opts ...AnimatorOption) *Animator {
+	res := &Animator{ctx: ctx, dispatcher: dispatcher, interval: defaultFrameInterval}
+	for _, opt := range opts {
+		opt(res)
+	}
+	return res
+}
+
+// Play starts the tween, applying its starting values at once. A tween which is already played starts again.
+func (a *Animator) Play(t *Tween) {
+	a.PlayAt(t, time.Now())
+}
+
+// PlayAt starts the tween as if it had started at that time, e.g. for synchronizing several tweens
+func (a *Animator) PlayAt(t *Tween, start time.Time) {
+	a.Lock()
+	a.remove(t)
+	a.playing = append(a.playing, &playing{tween: t, start: start})
+	if a.ticker == nil {
+		a.ticker = &frameTicker{ch: make(chan term.TickEvent), died: make(chan struct{}), interval: a.interval}
+		go a.animate(a.ticker)
+		a.dispatcher.Register(a.ticker)
+	}
+	a.Unlock()
+	a.frame(time.Now())
+}
+
+// Stop removes the tween, leaving its values as they are
+func (a *Animator) Stop(t *Tween) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.remove(t)
+	a.idle()
+}
+
+// Playing returns the number of tweens being played
+func (a *Animator) Playing() int {
+	a.Lock()
+	defer a.Unlock()
+
+	return len(a.playing)
+}
+
+// animate plays a frame on each tick, until the last tween has ended
+func (a *Animator) animate(ticker *frameTicker) {
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ticker.died:
+			return
+		case ev := <-ticker.ch:
+			a.frame(ev.When())
+		}
+	}
+}
+
+// frame applies the values of the tweens at that time, then calls the hooks of the ended ones, which can play other tweens
+func (a *Animator) frame(now time.Time) {
+	a.Lock()
+	var ended []*Tween
+	running := a.playing[:0]
+	for _, item := range a.playing {
+		progress, done := item.tween.At(now.Sub(item.start))
+		item.tween.apply(progress)
+		if done {
+			ended = append(ended, item.tween)
+			continue
+		}
+		running = append(running, item)
+	}
+	for idx := len(running); idx < len(a.playing); idx++ {
+		a.playing[idx] = nil
+	}
+	a.playing = running
+	a.idle()
+	onFrame := a.onFrame
+	a.Unlock()
+
+	if onFrame != nil {
+		onFrame()
+	}
+	for _, tween := range ended {
+		if tween.onDone != nil {
+			tween.onDone()
+		}
+	}
+}
+
+// remove forgets the tween - locked inside caller function
+func (a *Animator) remove(t *Tween) {
+	for idx, item := range a.playing {
+		if item.tween == t {
+			a.playing = append(a.playing[:idx], a.playing[idx+1:]...)
+			return
+		}
+	}
+}
+
+// idle stops the ticks when no tween is left - locked inside caller function
+func (a *Animator) idle() {
+	if len(a.playing) == 0 && a.ticker != nil {
+		close(a.ticker.died)
+		a.ticker = nil
+	}
+}
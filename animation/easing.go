@@ -0,0 +1,87 @@
+package animation
+
+import (
+	"math"
+)
+
+// Easing maps the progress of a tween, from zero to one, to the progress of its values, so they accelerate or slow down.
+// It returns zero for zero and one for one, and it can go beyond them in between (e.g. BackOut, ElasticOut).
+type Easing func(t float64) float64
+
+// Linear moves at a constant speed
+func Linear(t float64) float64 {
+	return t
+}
+
+// QuadIn starts slowly and accelerates
+func QuadIn(t float64) float64 {
+	return t * t
+}
+
+// QuadOut starts fast and slows down
+func QuadOut(t float64) float64 {
+	return t * (2 - t)
+}
+
+// QuadInOut accelerates until the middle, then slows down
+func QuadInOut(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return -1 + (4-2*t)*t
+}
+
+// CubicIn starts slowly and accelerates, more than QuadIn
+func CubicIn(t float64) float64 {
+	return t * t * t
+}
+
+// CubicOut starts fast and slows down, more than QuadOut. It's the easing of the toasts (see geom.Toasts).
+func CubicOut(t float64) float64 {
+	return 1 - math.Pow(1-t, 3)
+}
+
+// CubicInOut accelerates until the middle, then slows down, more than QuadInOut
+func CubicInOut(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 3)/2
+}
+
+// SineInOut accelerates and slows down gently
+func SineInOut(t float64) float64 {
+	return -(math.Cos(math.Pi*t) - 1) / 2
+}
+
+// BackOut goes a little beyond the end, then comes back
+func BackOut(t float64) float64 {
+	const overshoot = 1.70158
+	return 1 + (overshoot+1)*math.Pow(t-1, 3) + overshoot*math.Pow(t-1, 2)
+}
+
+// ElasticOut oscillates around the end, like a spring
+func ElasticOut(t float64) float64 {
+	if t <= 0 || t >= 1 {
+		return t
+	}
+	return math.Pow(2, -10*t)*math.Sin((t*10-0.75)*(2*math.Pi/3)) + 1
+}
+
+// BounceOut bounces on the end, like a falling ball
+func BounceOut(t float64) float64 {
+	const n, d = 7.5625, 2.75
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	default:
+		t -= 2.625 / d
+		return n*t*t + 0.984375
+	}
+}
@@ -0,0 +1,41 @@
+package animation
+
+import (
+	"time"
+
+	"github.com/badu/term"
+	"github.com/badu/term/geom"
+	"github.com/badu/term/style"
+)
+
+// Move returns a tween moving and resizing the rectangle from its current bounds to the ones given (see geom.Rectangle SetBounds)
+func Move(r *geom.Rectangle, to geom.Bounds, duration time.Duration, opts ...Option) *Tween {
+	from := r.Bounds()
+	last := from
+	return NewTween(duration, func(progress float64) {
+		if current := Bounds(from, to, progress); current != last {
+			r.SetBounds(current)
+			last = current
+		}
+	}, opts...)
+}
+
+// Recolor returns a tween changing the colors of the pixels, e.g. a run of text, from their current ones to the style given. The attributes change in the middle.
+func Recolor(pixels []term.Pixel, to style.Style, duration time.Duration, opts ...Option) *Tween {
+	from := make([]style.Style, len(pixels))
+	for idx, pixel := range pixels {
+		from[idx].Fg, from[idx].Bg, from[idx].Attrs = pixel.Style()
+	}
+	return NewTween(duration, func(progress float64) {
+		for idx, pixel := range pixels {
+			current := Style(from[idx], to, progress)
+			fg, bg, attrs := pixel.Style()
+			if current.Fg != fg || current.Bg != bg {
+				pixel.SetFgBg(current.Fg, current.Bg)
+			}
+			if current.Attrs != attrs {
+				pixel.SetAttrs(current.Attrs)
+			}
+		}
+	}, opts...)
+}
@@ -0,0 +1,149 @@
+package animation
+
+import (
+	"math"
+	"time"
+
+	"github.com/badu/term/color"
+	"github.com/badu/term/geom"
+	"github.com/badu/term/style"
+)
+
+// Forever repeats a tween until it's stopped (see WithRepeat)
+const Forever = -1
+
+// Option for functional options
+type Option func(t *Tween)
+
+// WithEasing sets the easing of the tween. Default is CubicInOut.
+func WithEasing(easing Easing) Option {
+	return func(t *Tween) {
+		if easing != nil {
+			t.easing = easing
+		}
+	}
+}
+
+// WithDelay sets how long the tween waits, at its start, before changing the values
+func WithDelay(delay time.Duration) Option {
+	return func(t *Tween) {
+		if delay >= 0 {
+			t.delay = delay
+		}
+	}
+}
+
+// WithRepeat plays the tween that many more times, or until it's stopped for Forever
+func WithRepeat(times int) Option {
+	return func(t *Tween) {
+		if times >= Forever {
+			t.repeat = times
+		}
+	}
+}
+
+// WithAlternate plays the repetitions backwards every other time, e.g. for pulsing colors
+func WithAlternate() Option {
+	return func(t *Tween) {
+		t.alternate = true
+	}
+}
+
+// WithOnDone sets the hook called once the tween has ended, e.g. for chaining another one
+func WithOnDone(hook func()) Option {
+	return func(t *Tween) {
+		t.onDone = hook
+	}
+}
+
+// Tween changes values over time : its apply function receives the eased progress, from zero to one, on each frame of the Animator playing it.
+// It describes the animation, so it can be played several times, each time from its start.
+type Tween struct {
+	duration  time.Duration          // of a single play, without the delay
+	apply     func(progress float64) //
+	easing    Easing                 // set by WithEasing
+	delay     time.Duration          // set by WithDelay
+	repeat    int                    // set by WithRepeat
+	alternate bool                   // set by WithAlternate
+	onDone    func()                 // set by WithOnDone
+}
+
+// NewTween returns a tween lasting that long, calling apply with the eased progress of the values (see Float, Int, Color, Style and Bounds)
+func NewTween(duration time.Duration, apply func(progress float64), opts ...Option) *Tween {
+	res := &Tween{duration: duration, apply: apply, easing: CubicInOut}
+	for _, opt := range opts {
+		opt(res)
+	}
+	return res
+}
+
+// At returns the eased progress after that much time since the tween has started, and true once it has ended
+func (t *Tween) At(elapsed time.Duration) (float64, bool) {
+	elapsed -= t.delay
+	if elapsed < 0 {
+		return t.easing(0), false
+	}
+	plays := t.repeat + 1
+	if t.duration <= 0 {
+		if t.repeat == Forever {
+			return t.easing(1), false
+		}
+		return t.easing(t.direction(1, plays-1)), true
+	}
+	play := int(elapsed / t.duration)
+	if t.repeat != Forever && play >= plays {
+		return t.easing(t.direction(1, plays-1)), true
+	}
+	progress := float64(elapsed%t.duration) / float64(t.duration)
+	return t.easing(t.direction(progress, play)), false
+}
+
+// Duration returns how long the tween lasts, including the delay and the repetitions, or a negative duration if it repeats forever
+func (t *Tween) Duration() time.Duration {
+	if t.repeat == Forever {
+		return -1
+	}
+	return t.delay + t.duration*time.Duration(t.repeat+1)
+}
+
+// direction reverses the progress of every other play, if the tween alternates
+func (t *Tween) direction(progress float64, play int) float64 {
+	if t.alternate && play%2 == 1 {
+		return 1 - progress
+	}
+	return progress
+}
+
+// Float returns the value between from and to, at the progress
+func Float(from, to, progress float64) float64 {
+	return from + (to-from)*progress
+}
+
+// Int returns the value between from and to, at the progress, rounded
+func Int(from, to int, progress float64) int {
+	return int(math.Round(Float(float64(from), float64(to), progress)))
+}
+
+// Color returns the color between from and to, at the progress, blended in OKLab space (see color.Blend)
+func Color(from, to color.Color, progress float64) color.Color {
+	return color.Blend(from, to, progress)
+}
+
+// Style returns the style between from and to, at the progress : the colors are blended, the attributes change in the middle
+func Style(from, to style.Style, progress float64) style.Style {
+	result := style.Style{Fg: Color(from.Fg, to.Fg, progress), Bg: Color(from.Bg, to.Bg, progress), Attrs: from.Attrs}
+	if progress >= 0.5 {
+		result.Attrs = to.Attrs
+	}
+	return result
+}
+
+// Bounds returns the bounds between from and to, at the progress, each edge being rounded
+func Bounds(from, to geom.Bounds, progress float64) geom.Bounds {
+	return geom.Bounds{
+		Left:   Int(from.Left, to.Left, progress),
+		Top:    Int(from.Top, to.Top, progress),
+		Right:  Int(from.Right, to.Right, progress),
+		Bottom: Int(from.Bottom, to.Bottom, progress),
+	}
+}
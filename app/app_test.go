@@ -1,9 +1,188 @@
 package app_test
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/badu/term"
+	"github.com/badu/term/app"
+	"github.com/badu/term/color"
+	"github.com/badu/term/core"
+	"github.com/badu/term/geom"
+	"github.com/badu/term/style"
 )
 
 func Test(t *testing.T) {
 
 }
+
+// fakeEngine records the registered pixels and the redraws, the rest of term.Engine being unused by the pages
+type fakeEngine struct {
+	term.Engine
+	sync.Mutex
+	size      *term.Size
+	active    []term.PixelGetter
+	frames    [][]term.PixelGetter
+	listeners []chan term.ResizeEvent
+}
+
+type fakeResizeDispatcher struct {
+	term.ResizeDispatcher
+	engine *fakeEngine
+}
+
+func (d fakeResizeDispatcher) Register(l term.ResizeListener) {
+	d.engine.Lock()
+	defer d.engine.Unlock()
+	d.engine.listeners = append(d.engine.listeners, l.ResizeListen())
+}
+
+type fakeKeyDispatcher struct{ term.KeyDispatcher }
+
+func (fakeKeyDispatcher) Register(term.KeyListener) {}
+
+func (e *fakeEngine) Size() *term.Size                        { return e.size }
+func (e *fakeEngine) HasMouse() bool                          { return false }
+func (e *fakeEngine) ResizeDispatcher() term.ResizeDispatcher { return fakeResizeDispatcher{engine: e} }
+func (e *fakeEngine) KeyDispatcher() term.KeyDispatcher       { return fakeKeyDispatcher{} }
+
+func (e *fakeEngine) ActivePixels(pixels []term.PixelGetter) {
+	e.Lock()
+	defer e.Unlock()
+	e.active = pixels
+}
+
+func (e *fakeEngine) Redraw(pixels []term.PixelGetter) {
+	e.Lock()
+	defer e.Unlock()
+	e.frames = append(e.frames, pixels)
+}
+
+// resize tells the pages about the new size, as the engine does
+func (e *fakeEngine) resize(columns, rows int) {
+	e.Lock()
+	e.size = term.NewSize(columns, rows)
+	listeners := e.listeners
+	e.Unlock()
+	for _, listener := range listeners {
+		listener <- core.NewResizeEvent(columns, rows)
+	}
+}
+
+// runes returns the first row of pixels, as text
+func runes(pixels []term.PixelGetter, columns int) string {
+	result := make([]rune, columns)
+	for _, pixel := range pixels {
+		column, row := term.UnHash(pixel.PositionHash())
+		if row == 0 && column < columns {
+			result[column] = pixel.Rune()
+		}
+	}
+	return string(result)
+}
+
+func TestNavigation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	engine := &fakeEngine{size: term.NewSize(8, 2)}
+	newPage := func(r rune) *geom.Page {
+		page, err := geom.NewPage(ctx, geom.WithEngine(engine))
+		if err != nil {
+			t.Fatalf("error : %v", err)
+		}
+		for _, pixel := range page.Pixels() {
+			pixel.(term.Pixel).SetAll(color.Default, color.Default, style.None, r, nil)
+		}
+		return page
+	}
+	list, detail := newPage('a'), newPage('b')
+
+	application := app.NewApplication(ctx, app.WithEngine(engine), app.WithTransitionStep(3), app.WithFrameInterval(time.Millisecond))
+	if _, err := application.PopPage(app.TransitionNone); err != app.ErrLastPage {
+		t.Errorf("error : popping an empty stack should fail, got %v", err)
+	}
+	if err := application.PushPage(list, app.TransitionSlide); err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	if len(engine.frames) != 0 || runes(engine.active, 8) != "aaaaaaaa" {
+		t.Errorf("error : the first page should be shown at once")
+	}
+
+	if err := application.PushPage(detail, app.TransitionWipe); err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	if application.Top() != detail || runes(engine.active, 8) != "bbbbbbbb" {
+		t.Errorf("error : the pushed page should be registered with the engine")
+	}
+	if len(engine.frames) != 2 || runes(engine.frames[0], 8) != "bbbaaaaa" || runes(engine.frames[1], 8) != "bbbbbbaa" {
+		t.Errorf("error : unexpected wipe frames %d", len(engine.frames))
+	}
+
+	engine.frames = nil
+	for _, pixel := range list.Pixels() {
+		column, _ := term.UnHash(pixel.PositionHash())
+		pixel.(term.Pixel).SetAll(color.Default, color.Default, style.None, rune('0'+column), nil) // while it's not on top
+	}
+	popped, err := application.PopPage(app.TransitionSlide)
+	if err != nil || popped != detail || application.Top() != list {
+		t.Fatalf("error : popping should return the detail page, got %v", err)
+	}
+	// the previous page enters from the left, the popped one being pushed out to the right
+	if len(engine.frames) != 2 {
+		t.Fatalf("error : expecting two slide frames, got %d", len(engine.frames))
+	}
+	if runes(engine.frames[0], 8) != "567bbbbb" || runes(engine.frames[1], 8) != "234567bb" {
+		t.Errorf("error : unexpected slide frames %q %q", runes(engine.frames[0], 8), runes(engine.frames[1], 8))
+	}
+	if runes(engine.active, 8) != "01234567" {
+		t.Errorf("error : the previous page should be registered again")
+	}
+}
+
+func TestNavigationAfterResize(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	engine := &fakeEngine{size: term.NewSize(8, 2)}
+	list, err := geom.NewPage(ctx, geom.WithEngine(engine))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	detail, err := geom.NewPage(ctx, geom.WithEngine(engine))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	application := app.NewApplication(ctx, app.WithEngine(engine), app.WithTransitionStep(3), app.WithFrameInterval(time.Millisecond))
+	if err := application.PushPage(list, app.TransitionNone); err != nil {
+		t.Fatalf("error : %v", err)
+	}
+
+	// the pages are resized while the transition reads their pixels
+	engine.resize(10, 2)
+	deadline := time.After(2 * time.Second)
+	for len(list.Pixels()) != 20 || len(detail.Pixels()) != 20 {
+		select {
+		case <-deadline:
+			t.Fatalf("error : expecting the pages to cover 10 x 2, got %d and %d pixels", len(list.Pixels()), len(detail.Pixels()))
+		case <-time.After(time.Millisecond):
+		}
+	}
+	for page, r := range map[*geom.Page]rune{list: 'a', detail: 'b'} {
+		for _, pixel := range page.Pixels() {
+			pixel.(term.Pixel).SetAll(color.Default, color.Default, style.None, r, nil)
+		}
+	}
+
+	if err := application.PushPage(detail, app.TransitionWipe); err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	engine.Lock()
+	defer engine.Unlock()
+	if len(engine.frames) != 3 || runes(engine.frames[2], 10) != "bbbbbbbbba" {
+		t.Errorf("error : the frames should cover the new size, got %d frames", len(engine.frames))
+	}
+	if runes(engine.active, 10) != "bbbbbbbbbb" {
+		t.Errorf("error : the pushed page should be registered with its resized pixels, got %q", runes(engine.active, 10))
+	}
+}
@@ -3,7 +3,9 @@ package app
 import (
 	"context"
 	"sync"
+	"time"
 
+	"github.com/badu/term"
 	"github.com/badu/term/geom"
 )
 
@@ -13,9 +15,12 @@ type ApplicationOption func(a *Application)
 // Application
 type Application struct {
 	sync.RWMutex
-	ctx     context.Context
-	cancels map[*geom.Page]func()
-	pages   []geom.Page
+	ctx           context.Context
+	cancels       map[*geom.Page]func()
+	pages         []*geom.Page  // the navigation stack, the last one being shown
+	engine        term.Engine   // set by WithEngine
+	step          int           // set by WithTransitionStep
+	frameInterval time.Duration // set by WithFrameInterval
 }
 
 // WithPage
@@ -27,7 +32,10 @@ func WithPage(p *geom.Page) ApplicationOption {
 
 // NewApplication
 func NewApplication(ctx context.Context, opts ...ApplicationOption) *Application {
-	res := &Application{}
+	res := &Application{
+		step:          defaultTransitionStep,
+		frameInterval: defaultFrameInterval,
+	}
 	for _, opt := range opts {
 		opt(res)
 	}
@@ -37,7 +45,9 @@ func NewApplication(ctx context.Context, opts ...ApplicationOption) *Application
 
 // Start
 func (a *Application) Start(ctx context.Context) {
-
+	a.Lock()
+	defer a.Unlock()
+	a.ctx = ctx
 }
 
 // AddPage
@@ -45,7 +55,7 @@ func (a *Application) AddPage(p *geom.Page) {
 	// pageCtx, cancel := context.WithCancel(a.ctx)
 	// a.cancels[p] = cancel
 	// geom.WithEngine(pageCtx)
-	a.pages = append(a.pages, *p)
+	a.pages = append(a.pages, p)
 }
 
 // DeactivatePage
@@ -0,0 +1,204 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/badu/term"
+	"github.com/badu/term/geom"
+)
+
+const (
+	defaultTransitionStep = 4                     // columns per frame
+	defaultFrameInterval  = 16 * time.Millisecond // about 60 frames per second
+)
+
+var (
+	// ErrNoEngine is returned when navigating, if the application was created without WithEngine
+	ErrNoEngine = errors.New("application requires Engine to show pages")
+	// ErrLastPage is returned by PopPage when only one page is left, since there would be nothing to show
+	ErrLastPage = errors.New("cannot pop the last page")
+)
+
+// Transition is the effect used when a page replaces another one on the screen
+type Transition int
+
+const (
+	TransitionNone  Transition = iota // the page is shown at once
+	TransitionWipe                    // the page is revealed over the previous one, a few columns each frame
+	TransitionSlide                   // the page slides in, pushing the previous one out, a few columns each frame
+)
+
+// String implements fmt.Stringer interface
+func (t Transition) String() string {
+	switch t {
+	case TransitionWipe:
+		return "Wipe"
+	case TransitionSlide:
+		return "Slide"
+	default:
+		return "None"
+	}
+}
+
+// WithEngine sets the engine which draws the pages
+func WithEngine(engine term.Engine) ApplicationOption {
+	return func(a *Application) {
+		a.engine = engine
+	}
+}
+
+// WithTransitionStep sets the number of columns a transition advances with each frame. Default is four columns.
+func WithTransitionStep(columns int) ApplicationOption {
+	return func(a *Application) {
+		if columns > 0 {
+			a.step = columns
+		}
+	}
+}
+
+// WithFrameInterval sets the time between the frames of a transition. Default is 16 milliseconds.
+func WithFrameInterval(interval time.Duration) ApplicationOption {
+	return func(a *Application) {
+		if interval > 0 {
+			a.frameInterval = interval
+		}
+	}
+}
+
+// PushPage shows the page over the current one, using the transition. Only the page on top of the stack receives the key and mouse events and has its pixels registered with the engine.
+// It returns when the transition is done.
+func (a *Application) PushPage(p *geom.Page, transition Transition) error {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.engine == nil {
+		return ErrNoEngine
+	}
+	var previous *geom.Page
+	if len(a.pages) > 0 {
+		previous = a.pages[len(a.pages)-1]
+	}
+	a.pages = append(a.pages, p)
+	a.show(previous, p, transition, true)
+	return nil
+}
+
+// PopPage removes the page on top of the stack, showing the previous one using the transition (played backwards), and returns the removed page.
+// It returns when the transition is done.
+func (a *Application) PopPage(transition Transition) (*geom.Page, error) {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.engine == nil {
+		return nil, ErrNoEngine
+	}
+	if len(a.pages) < 2 {
+		return nil, ErrLastPage
+	}
+	popped := a.pages[len(a.pages)-1]
+	a.pages[len(a.pages)-1] = nil
+	a.pages = a.pages[:len(a.pages)-1]
+	a.show(popped, a.pages[len(a.pages)-1], transition, false)
+	return popped, nil
+}
+
+// Top returns the page on top of the stack, or nil
+func (a *Application) Top() *geom.Page {
+	a.RLock()
+	defer a.RUnlock()
+
+	if len(a.pages) == 0 {
+		return nil
+	}
+	return a.pages[len(a.pages)-1]
+}
+
+// show replaces the page on the screen, giving the input to the new one - locked inside caller function
+func (a *Application) show(from, to *geom.Page, transition Transition, forward bool) {
+	if from != nil {
+		from.Deactivate()
+	}
+	if from != nil && transition != TransitionNone {
+		to.Deactivate()            // no input until the transition is done
+		a.engine.ActivePixels(nil) // the changes of the previous page would draw over the frames
+		a.playTransition(from, to, transition, forward)
+	}
+	a.engine.ActivePixels(to.Pixels()) // draws the page
+	to.Activate()
+}
+
+// playTransition draws the frames of the transition - locked inside caller function
+func (a *Application) playTransition(from, to *geom.Page, transition Transition, forward bool) {
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	size := a.engine.Size()
+	fromPixels, toPixels := byPosition(from.Pixels()), byPosition(to.Pixels())
+
+	ticker := time.NewTicker(a.frameInterval)
+	defer ticker.Stop()
+	for progress := a.step; progress < size.Columns; progress += a.step {
+		a.engine.Redraw(frame(transition, forward, fromPixels, toPixels, size, progress))
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// frame composes the pixels of both pages, once the transition has advanced the number of columns. Pages are pushed forward and popped backwards.
+func frame(transition Transition, forward bool, from, to map[int]term.PixelGetter, size *term.Size, progress int) []term.PixelGetter {
+	result := make([]term.PixelGetter, 0, size.Columns*size.Rows)
+	for row := 0; row < size.Rows; row++ {
+		for column := 0; column < size.Columns; column++ {
+			source, sourceColumn := from, column
+			switch {
+			case transition == TransitionWipe && forward && column < progress:
+				source = to
+			case transition == TransitionWipe && !forward && column >= size.Columns-progress:
+				source = to
+			case transition == TransitionSlide && forward && column >= size.Columns-progress:
+				source, sourceColumn = to, column-size.Columns+progress // entering from the right
+			case transition == TransitionSlide && forward:
+				sourceColumn = column + progress
+			case transition == TransitionSlide && column < progress:
+				source, sourceColumn = to, column+size.Columns-progress // entering from the left
+			case transition == TransitionSlide:
+				sourceColumn = column - progress
+			}
+			pixel, ok := source[term.Hash(sourceColumn, row)]
+			if !ok {
+				continue
+			}
+			if sourceColumn != column {
+				pixel = &movedPixel{PixelGetter: pixel, hash: term.Hash(column, row)}
+			}
+			result = append(result, pixel)
+		}
+	}
+	return result
+}
+
+// byPosition indexes the pixels by their position hash
+func byPosition(pixels []term.PixelGetter) map[int]term.PixelGetter {
+	result := make(map[int]term.PixelGetter, len(pixels))
+	for _, pixel := range pixels {
+		result[pixel.PositionHash()] = pixel
+	}
+	return result
+}
+
+// movedPixel draws a pixel of a page at another position, while the page slides
+type movedPixel struct {
+	term.PixelGetter
+	hash int
+}
+
+// PositionHash implements term.PixelGetter interface
+func (p *movedPixel) PositionHash() int {
+	return p.hash
+}
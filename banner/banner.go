@@ -0,0 +1,165 @@
+package banner
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+)
+
+// Align tells where the lines of text are placed in the width of the grid
+type Align int
+
+const (
+	Left   Align = iota // the default
+	Center              //
+	Right               //
+)
+
+// Option for functional options
+type Option func(b *Banner)
+
+// WithGradient colors the text with the gradient (see color.Gradient), from the left to the right of the text. Default is the terminal color.
+func WithGradient(stops ...color.Color) Option {
+	return func(b *Banner) {
+		b.gradient = stops
+	}
+}
+
+// WithVerticalGradient applies the gradient from the top to the bottom of the text, instead of from the left to the right
+func WithVerticalGradient() Option {
+	return func(b *Banner) {
+		b.vertical = true
+	}
+}
+
+// WithBackground sets the background of all the cells. Default is the terminal background.
+func WithBackground(c color.Color) Option {
+	return func(b *Banner) {
+		b.bg = c
+	}
+}
+
+// WithAlign places the lines of text in the width of the grid. Default is Left.
+func WithAlign(align Align) Option {
+	return func(b *Banner) {
+		b.align = align
+	}
+}
+
+// Banner draws text with big glyphs made of blocks (see Block, HalfBlock and LoadFIGlet), e.g. for splash screens and clocks.
+// It's drawn into pixels like the charts (Draw(grid), or chart.RenderIn(banner, rectangle)), the text being changed by SetText, e.g. every second for a clock.
+type Banner struct {
+	sync.Mutex                //
+	text       string         // the lines are separated by '\n'
+	font       *Font          //
+	gradient   color.Gradient // set by WithGradient
+	vertical   bool           // set by WithVerticalGradient
+	bg         color.Color    // set by WithBackground
+	align      Align          // set by WithAlign
+}
+
+// New creates a banner, drawing the text with the font (Block if nil)
+func New(text string, font *Font, opts ...Option) *Banner {
+	if font == nil {
+		font = Block()
+	}
+	res := &Banner{text: text, font: font, bg: color.Default}
+	for _, opt := range opts {
+		opt(res)
+	}
+	return res
+}
+
+// SetText replaces the text, which is drawn on the next Draw
+func (b *Banner) SetText(text string) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.text = text
+}
+
+// Text returns the text
+func (b *Banner) Text() string {
+	b.Lock()
+	defer b.Unlock()
+
+	return b.text
+}
+
+// Size returns the columns and rows needed to draw the text, e.g. for the minimum size of a rectangle
+func (b *Banner) Size() *term.Size {
+	b.Lock()
+	defer b.Unlock()
+
+	rows := b.render()
+	columns := 0
+	for _, row := range rows {
+		columns = term.Max(columns, len(row))
+	}
+	return term.NewSize(columns, len(rows))
+}
+
+// Draw fills the grid (indexed [column][row]) with the text, from the top, the cells outside the text being cleared.
+// The glyphs which don't fit are cut.
+func (b *Banner) Draw(grid [][]term.Pixel) {
+	b.Lock()
+	defer b.Unlock()
+
+	rows := b.render()
+	width := 0
+	for _, row := range rows {
+		width = term.Max(width, len(row))
+	}
+	for column := range grid {
+		for row := range grid[column] {
+			grid[column][row].Set(' ', color.Default, b.bg)
+		}
+	}
+	for row, runes := range rows {
+		offset := 0
+		switch b.align {
+		case Center:
+			offset = (len(grid) - len(runes)) / 2
+		case Right:
+			offset = len(grid) - len(runes)
+		}
+		for idx, r := range runes {
+			column := offset + idx
+			if column < 0 || column >= len(grid) || row >= len(grid[column]) {
+				continue
+			}
+			position := float64(idx + (width-len(runes))/2) // the lines shorter than the text are centered in the gradient
+			total := float64(width - 1)
+			if b.vertical {
+				position, total = float64(row), float64(len(rows)-1)
+			}
+			fg := color.Default
+			if len(b.gradient) > 0 {
+				t := 0.0
+				if total > 0 {
+					t = position / total
+				}
+				fg = b.gradient.At(t)
+			}
+			grid[column][row].Set(r, fg, b.bg)
+		}
+	}
+}
+
+// render returns the rows of all the lines of text, the trailing blank columns of each line removed - locked inside caller function
+func (b *Banner) render() [][]rune {
+	var result [][]rune
+	for _, line := range strings.Split(b.text, "\n") {
+		rendered := b.font.Render(line)
+		width := 0
+		for _, row := range rendered {
+			width = term.Max(width, len([]rune(strings.TrimRight(row, " "))))
+		}
+		for _, row := range rendered {
+			result = append(result, []rune(row)[:width])
+		}
+	}
+	return result
+}
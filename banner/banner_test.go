@@ -0,0 +1,117 @@
+package banner_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/banner"
+	"github.com/badu/term/color"
+	"github.com/badu/term/geom"
+)
+
+func rows(grid [][]term.Pixel) []string {
+	result := make([]string, len(grid[0]))
+	for row := range result {
+		var sb strings.Builder
+		for column := range grid {
+			sb.WriteRune(grid[column][row].Rune())
+		}
+		result[row] = sb.String()
+	}
+	return result
+}
+
+func TestBlock(t *testing.T) {
+	b := banner.New("hi!", nil)
+	if size := b.Size(); size.Columns != 9 || size.Rows != 5 {
+		t.Fatalf("error : expecting 9x5, got %dx%d", size.Columns, size.Rows)
+	}
+	grid, _ := geom.NewPixelGrid(term.NewSize(10, 5))
+	b.Draw(grid)
+	want := []string{
+		"█ █ ███ █ ",
+		"█ █  █  █ ",
+		"███  █  █ ",
+		"█ █  █    ",
+		"█ █ ███ █ ",
+	}
+	for idx, got := range rows(grid) {
+		if got != want[idx] {
+			t.Errorf("error : row %d : expecting %q, got %q", idx, want[idx], got)
+		}
+	}
+
+	b = banner.New("1:", banner.HalfBlock(), banner.WithAlign(banner.Right))
+	grid, _ = geom.NewPixelGrid(term.NewSize(7, 3))
+	b.Draw(grid)
+	want = []string{
+		"  ▄█  ▄",
+		"   █  ▄",
+		"  ▀▀▀  ",
+	}
+	for idx, got := range rows(grid) {
+		if got != want[idx] {
+			t.Errorf("error : half block row %d : expecting %q, got %q", idx, want[idx], got)
+		}
+	}
+}
+
+func TestGradient(t *testing.T) {
+	b := banner.New("HH", nil, banner.WithGradient(color.Red, color.Blue))
+	grid, _ := geom.NewPixelGrid(term.NewSize(7, 5))
+	b.Draw(grid)
+	if fg, _, _ := grid[0][0].Style(); fg != color.Red {
+		t.Errorf("error : expecting the first column red, got %v", fg)
+	}
+	if fg, _, _ := grid[6][0].Style(); fg != color.Blue {
+		t.Errorf("error : expecting the last column blue, got %v", fg)
+	}
+
+	b = banner.New("H", nil, banner.WithGradient(color.Red, color.Blue), banner.WithVerticalGradient())
+	b.Draw(grid)
+	if fg, _, _ := grid[0][0].Style(); fg != color.Red {
+		t.Errorf("error : expecting the first row red, got %v", fg)
+	}
+	if fg, _, _ := grid[0][4].Style(); fg != color.Blue {
+		t.Errorf("error : expecting the last row blue, got %v", fg)
+	}
+}
+
+func TestFIGlet(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("flf2a$ 2 1 4 -1 1\n")
+	sb.WriteString("a tiny font\n")
+	for code := ' '; code <= '~'; code++ {
+		switch code {
+		case ' ':
+			sb.WriteString("$$@\n$$@@\n")
+		case 'A':
+			sb.WriteString("/\\@\n/\\@@\n")
+		default:
+			sb.WriteString("?@\n?@@\n")
+		}
+	}
+	for range "ÄÖÜäöüß" {
+		sb.WriteString("?@\n?@@\n")
+	}
+	sb.WriteString("0x263A smiley\n:)#\n  ##\n")
+	font, err := banner.LoadFIGlet(strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	if font.Height() != 2 {
+		t.Fatalf("error : expecting two rows, got %d", font.Height())
+	}
+	got := font.Render("A A☺")
+	if got[0] != "/\\  /\\:)" || got[1] != "/\\  /\\  " {
+		t.Errorf("error : unexpected rendering %q", got)
+	}
+
+	if _, err := banner.LoadFIGlet(strings.NewReader("flf2a$ 2 1 4 -1 0\n$@\n$@@\n")); err == nil {
+		t.Errorf("error : a truncated font should be refused")
+	}
+	if _, err := banner.LoadFIGlet(strings.NewReader("not a font\n")); err == nil {
+		t.Errorf("error : a file which isn't a font should be refused")
+	}
+}
@@ -0,0 +1,270 @@
+package banner
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/badu/term"
+)
+
+// Font holds the rows of the glyphs, all having the same height
+type Font struct {
+	height  int               //
+	glyphs  map[rune][]string // rows of each glyph, having the same width
+	missing rune              // drawn instead of the runes which have no glyph, skipped if zero
+	upper   bool              // the lower case runes are drawn with the upper case glyphs, if they have none
+}
+
+// Height returns the rows of the glyphs
+func (f *Font) Height() int {
+	return f.height
+}
+
+// Render returns the rows of a line of text, the runes which have no glyph being skipped
+func (f *Font) Render(text string) []string {
+	result := make([]string, f.height)
+	for _, r := range text {
+		glyph := f.glyph(r)
+		for row := range result {
+			result[row] += glyph[row]
+		}
+	}
+	return result
+}
+
+// glyph returns the rows of the rune, which are empty if the font has no glyph for it
+func (f *Font) glyph(r rune) []string {
+	if glyph, ok := f.glyphs[r]; ok {
+		return glyph
+	}
+	if f.upper {
+		if glyph, ok := f.glyphs[unicode.ToUpper(r)]; ok {
+			return glyph
+		}
+	}
+	if glyph, ok := f.glyphs[f.missing]; ok && f.missing != 0 {
+		return glyph
+	}
+	return make([]string, f.height)
+}
+
+// bitmaps are the glyphs of the built-in fonts, five dots high, '#' being a dot
+var bitmaps = map[rune][5]string{
+	'A':  {".#.", "#.#", "###", "#.#", "#.#"},
+	'B':  {"##.", "#.#", "##.", "#.#", "##."},
+	'C':  {".##", "#..", "#..", "#..", ".##"},
+	'D':  {"##.", "#.#", "#.#", "#.#", "##."},
+	'E':  {"###", "#..", "##.", "#..", "###"},
+	'F':  {"###", "#..", "##.", "#..", "#.."},
+	'G':  {".##", "#..", "#.#", "#.#", ".##"},
+	'H':  {"#.#", "#.#", "###", "#.#", "#.#"},
+	'I':  {"###", ".#.", ".#.", ".#.", "###"},
+	'J':  {"..#", "..#", "..#", "#.#", ".#."},
+	'K':  {"#.#", "#.#", "##.", "#.#", "#.#"},
+	'L':  {"#..", "#..", "#..", "#..", "###"},
+	'M':  {"#...#", "##.##", "#.#.#", "#...#", "#...#"},
+	'N':  {"#..#", "##.#", "#.##", "#..#", "#..#"},
+	'O':  {".#.", "#.#", "#.#", "#.#", ".#."},
+	'P':  {"##.", "#.#", "##.", "#..", "#.."},
+	'Q':  {".#.", "#.#", "#.#", "##.", ".##"},
+	'R':  {"##.", "#.#", "##.", "#.#", "#.#"},
+	'S':  {".##", "#..", ".#.", "..#", "##."},
+	'T':  {"###", ".#.", ".#.", ".#.", ".#."},
+	'U':  {"#.#", "#.#", "#.#", "#.#", "###"},
+	'V':  {"#.#", "#.#", "#.#", "#.#", ".#."},
+	'W':  {"#...#", "#...#", "#.#.#", "##.##", "#...#"},
+	'X':  {"#.#", "#.#", ".#.", "#.#", "#.#"},
+	'Y':  {"#.#", "#.#", ".#.", ".#.", ".#."},
+	'Z':  {"###", "..#", ".#.", "#..", "###"},
+	'0':  {"###", "#.#", "#.#", "#.#", "###"},
+	'1':  {".#.", "##.", ".#.", ".#.", "###"},
+	'2':  {"##.", "..#", ".#.", "#..", "###"},
+	'3':  {"##.", "..#", ".#.", "..#", "##."},
+	'4':  {"#.#", "#.#", "###", "..#", "..#"},
+	'5':  {"###", "#..", "##.", "..#", "##."},
+	'6':  {".##", "#..", "###", "#.#", "###"},
+	'7':  {"###", "..#", ".#.", ".#.", ".#."},
+	'8':  {"###", "#.#", "###", "#.#", "###"},
+	'9':  {"###", "#.#", "###", "..#", "##."},
+	' ':  {"..", "..", "..", "..", ".."},
+	':':  {".", "#", ".", "#", "."},
+	'.':  {".", ".", ".", ".", "#"},
+	',':  {"..", "..", "..", ".#", "#."},
+	'!':  {"#", "#", "#", ".", "#"},
+	'?':  {"##.", "..#", ".#.", "...", ".#."},
+	'\'': {"#", "#", ".", ".", "."},
+	'-':  {"...", "...", "###", "...", "..."},
+	'+':  {"...", ".#.", "###", ".#.", "..."},
+	'=':  {"...", "###", "...", "###", "..."},
+	'_':  {"...", "...", "...", "...", "###"},
+	'/':  {"..#", "..#", ".#.", "#..", "#.."},
+	'%':  {"#.#", "..#", ".#.", "#..", "#.#"},
+	'(':  {".#", "#.", "#.", "#.", ".#"},
+	')':  {"#.", ".#", ".#", ".#", "#."},
+}
+
+var (
+	blockFont     = bitmapFont(false) // returned by Block
+	halfBlockFont = bitmapFont(true)  // returned by HalfBlock
+)
+
+// Block returns the built-in font drawing each dot with a full block, five rows high. It has the upper case letters, the digits and some punctuation.
+func Block() *Font {
+	return blockFont
+}
+
+// HalfBlock returns the built-in font drawing two dots in each cell with the half blocks, three rows high, e.g. for clocks. It has the same glyphs as Block.
+func HalfBlock() *Font {
+	return halfBlockFont
+}
+
+// bitmapFont builds a built-in font, separating the glyphs by a column
+func bitmapFont(half bool) *Font {
+	res := &Font{height: 5, glyphs: make(map[rune][]string), missing: '?', upper: true}
+	if half {
+		res.height = 3
+	}
+	for r, bitmap := range bitmaps {
+		rows := make([]string, res.height)
+		for row := range rows {
+			var sb strings.Builder
+			for column := 0; column <= len(bitmap[0]); column++ { // the last one is the separator
+				switch {
+				case !half:
+					sb.WriteRune(dotRune(dot(bitmap, row, column), dot(bitmap, row, column)))
+				default:
+					sb.WriteRune(dotRune(dot(bitmap, row*2, column), dot(bitmap, row*2+1, column)))
+				}
+			}
+			rows[row] = sb.String()
+		}
+		res.glyphs[r] = rows
+	}
+	return res
+}
+
+// dot returns true if the bitmap has a dot there
+func dot(bitmap [5]string, row, column int) bool {
+	return row < len(bitmap) && column < len(bitmap[row]) && bitmap[row][column] == '#'
+}
+
+// dotRune returns the block drawing the upper and lower dots of a cell
+func dotRune(upper, lower bool) rune {
+	switch {
+	case upper && lower:
+		return '█'
+	case upper:
+		return '▀'
+	case lower:
+		return '▄'
+	default:
+		return ' '
+	}
+}
+
+// figletGerman are the runes following the ASCII ones in the FIGlet fonts
+var figletGerman = []rune{'Ä', 'Ö', 'Ü', 'ä', 'ö', 'ü', 'ß'}
+
+// LoadFIGlet reads a FIGlet font (.flf). The glyphs are drawn next to each other as they are, without the kerning and smushing of FIGlet.
+func LoadFIGlet(r io.Reader) (*Font, error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("banner: empty FIGlet font")
+	}
+	header := strings.Fields(scanner.Text())
+	if len(header) < 6 || !strings.HasPrefix(header[0], "flf2a") || len(header[0]) < 6 {
+		return nil, fmt.Errorf("banner: not a FIGlet font")
+	}
+	hardBlank := []rune(header[0])[5]
+	height, err := strconv.Atoi(header[1])
+	if err != nil || height <= 0 {
+		return nil, fmt.Errorf("banner: invalid FIGlet height %q", header[1])
+	}
+	comments, err := strconv.Atoi(header[5])
+	if err != nil || comments < 0 {
+		return nil, fmt.Errorf("banner: invalid FIGlet comment lines %q", header[5])
+	}
+	for idx := 0; idx < comments; idx++ {
+		if !scanner.Scan() {
+			return nil, fmt.Errorf("banner: FIGlet font ends in its comments")
+		}
+	}
+
+	res := &Font{height: height, glyphs: make(map[rune][]string)}
+	readGlyph := func(code rune) (bool, error) {
+		rows := make([]string, height)
+		width := 0
+		for row := range rows {
+			if !scanner.Scan() {
+				if row == 0 {
+					return false, nil
+				}
+				return false, fmt.Errorf("banner: FIGlet glyph %d is truncated", code)
+			}
+			line := strings.TrimRight(scanner.Text(), "\r")
+			if line != "" {
+				line = strings.TrimRight(line, line[len(line)-1:]) // the end marks
+			}
+			rows[row] = strings.Replace(line, string(hardBlank), " ", -1)
+			width = term.Max(width, len([]rune(rows[row])))
+		}
+		for row := range rows {
+			rows[row] += strings.Repeat(" ", width-len([]rune(rows[row])))
+		}
+		if code >= 0 {
+			res.glyphs[code] = rows
+		}
+		return true, nil
+	}
+
+	for code := rune(' '); code <= '~'; code++ {
+		ok, err := readGlyph(code)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("banner: FIGlet font ends at glyph %d", code)
+		}
+	}
+	for _, code := range figletGerman {
+		ok, err := readGlyph(code)
+		if err != nil || !ok {
+			return res, err
+		}
+	}
+	for scanner.Scan() { // the code tagged glyphs
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		code, err := strconv.ParseInt(fields[0], 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("banner: invalid FIGlet code tag %q", fields[0])
+		}
+		if code < 0 {
+			code = -1 // the negative codes are not runes, their glyph is skipped
+		}
+		if _, err := readGlyph(rune(code)); err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// LoadFIGletFile reads a FIGlet font file (.flf)
+func LoadFIGletFile(path string) (*Font, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadFIGlet(f)
+}
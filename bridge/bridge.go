@@ -0,0 +1,241 @@
+// Package bridge serves the applications built with badu/term to the browsers, over a WebSocket : each connection gets its own engine, whose terminal is a xterm.js instance.
+//
+// The protocol is small. The server sends binary messages holding the output of the engine, which only draws the cells that changed.
+// The browser sends messages starting with a kind byte : 'i' followed by the input (keys and mouse reports, as the terminal encodes them) and 'r' followed by "columns,rows" when resized.
+// The first message has to be a resize, so the engine starts with the size of the browser terminal. See Script for the browser side.
+package bridge
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/badu/term"
+	"github.com/badu/term/core"
+	"github.com/badu/term/info"
+)
+
+const (
+	defaultTerminal = "xterm-256color" // what xterm.js emulates
+	startTimeout    = 10 * time.Second // the first resize message has to arrive before this
+	dieTimeout      = 5 * time.Second  // waiting for the engine shutdown, once the application has returned
+
+	kindInput  = 'i' // the message holds input
+	kindResize = 'r' // the message holds the size, as "columns,rows"
+)
+
+// Application is run for each connection, having the engine of the browser terminal. It has to start the engine and return once the context is done (the browser has disconnected).
+type Application func(ctx context.Context, engine term.Engine)
+
+// Option configures the handler
+type Option func(h *handler)
+
+// WithTerminal is a functional option for the terminal definition used by the engines. Default is "xterm-256color", matching xterm.js.
+func WithTerminal(name string) Option {
+	return func(h *handler) {
+		h.terminal = name
+	}
+}
+
+// WithEngineOptions is a functional option for creating the engines with more options (e.g. core.WithBracketedPaste).
+// The transport is set by the handler, and the true color is enabled by default.
+func WithEngineOptions(opts ...core.Option) Option {
+	return func(h *handler) {
+		h.engineOpts = append(h.engineOpts, opts...)
+	}
+}
+
+// WithOriginCheck is a functional option for deciding which pages can connect. By default, the Origin header sent by the browsers has to match the host, so other sites can't drive the application.
+func WithOriginCheck(fn func(r *http.Request) bool) Option {
+	return func(h *handler) {
+		if fn != nil {
+			h.checkOrigin = fn
+		}
+	}
+}
+
+// handler upgrades the requests, running the application for each connection
+type handler struct {
+	app         Application                //
+	terminal    string                     // set by WithTerminal
+	engineOpts  []core.Option              // set by WithEngineOptions
+	checkOrigin func(r *http.Request) bool // set by WithOriginCheck
+	ti          *info.Term                 // the definition of the terminal, looked up once, since creating an engine forgets the registered ones
+}
+
+// Handler returns the http.Handler which accepts the WebSocket connections of the browsers
+func Handler(app Application, opts ...Option) http.Handler {
+	res := &handler{app: app, terminal: defaultTerminal, checkOrigin: sameOrigin}
+	for _, o := range opts {
+		o(res)
+	}
+	if ti, err := info.LookupTerminfo(res.terminal); err == nil {
+		res.ti = ti
+	}
+	return res
+}
+
+// ServeHTTP implements http.Handler interface. It returns once the application has returned.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ws, err := upgrade(w, r, h.checkOrigin)
+	if err != nil {
+		if Debug.Enabled() {
+			Debug.Printf("refused %s : %v", r.RemoteAddr, err)
+		}
+		return
+	}
+	defer ws.Close()
+
+	ctx, cancel := context.WithCancel(context.Background()) // the request context is done once the connection is hijacked, on some servers
+	defer cancel()
+	c := newConn(ws)
+	go c.readLoop(ctx, cancel)
+
+	select {
+	case <-c.started:
+	case <-ctx.Done():
+		return
+	case <-time.After(startTimeout):
+		if Debug.Enabled() {
+			Debug.Printf("%s didn't report its size", r.RemoteAddr)
+		}
+		return
+	}
+
+	opts := []core.Option{core.WithTrueColor("enable")}
+	if h.ti != nil {
+		opts = append(opts, core.WithTerminfo(h.ti))
+	}
+	opts = append(opts, h.engineOpts...)
+	engine, err := core.NewCore(h.terminal, append(opts, core.WithTransport(c))...)
+	if err != nil {
+		if Debug.Enabled() {
+			Debug.Printf("error creating engine : %v", err)
+		}
+		return
+	}
+	h.app(ctx, engine)
+	cancel()
+	select {
+	case <-engine.DyingChan(): // the engine has written its shutdown sequences
+	case <-time.After(dieTimeout):
+	}
+}
+
+// conn is the transport of the engine, a browser terminal
+type conn struct {
+	sync.Mutex               // guards other properties
+	ws         *wsConn       //
+	input      chan []byte   // the input messages, waiting to be read
+	pending    []byte        // the part of the last input message which didn't fit the buffer of Read
+	closed     chan struct{} // closed when the connection is gone
+	started    chan struct{} // closed when the first size has arrived
+	columns    int           //
+	rows       int           //
+	notify     func()        // set by the engine, called on resize
+	once       sync.Once     // closes started
+}
+
+func newConn(ws *wsConn) *conn {
+	return &conn{
+		ws:      ws,
+		input:   make(chan []byte, 16),
+		closed:  make(chan struct{}),
+		started: make(chan struct{}),
+	}
+}
+
+// readLoop reads the messages of the browser, until the connection is gone
+func (c *conn) readLoop(ctx context.Context, cancel context.CancelFunc) {
+	defer cancel()
+	defer close(c.closed)
+	for {
+		message, err := c.ws.readMessage()
+		if err != nil {
+			if Debug.Enabled() {
+				Debug.Printf("connection closed : %v", err)
+			}
+			return
+		}
+		if len(message) == 0 {
+			continue
+		}
+		switch message[0] {
+		case kindInput:
+			select {
+			case c.input <- message[1:]:
+			case <-ctx.Done(): // the engine doesn't read anymore
+				return
+			}
+		case kindResize:
+			c.resize(string(message[1:]))
+		}
+	}
+}
+
+// resize remembers the size and tells the engine about it
+func (c *conn) resize(size string) {
+	parts := strings.Split(size, ",")
+	if len(parts) != 2 {
+		return
+	}
+	columns, err := strconv.Atoi(parts[0])
+	if err != nil || columns <= 0 {
+		return
+	}
+	rows, err := strconv.Atoi(parts[1])
+	if err != nil || rows <= 0 {
+		return
+	}
+	c.Lock()
+	c.columns, c.rows = columns, rows
+	notify := c.notify
+	c.Unlock()
+	c.once.Do(func() { close(c.started) })
+	if notify != nil {
+		notify()
+	}
+}
+
+// Read implements io.Reader interface, returning io.EOF once the connection is gone
+func (c *conn) Read(p []byte) (int, error) {
+	if len(c.pending) == 0 {
+		select {
+		case message := <-c.input:
+			c.pending = message
+		case <-c.closed:
+			return 0, io.EOF
+		}
+	}
+	n := copy(p, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+// Write implements io.Writer interface, sending the output as a binary message
+func (c *conn) Write(p []byte) (int, error) {
+	if err := c.ws.writeFrame(opBinary, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// WindowSize implements core.Transport interface
+func (c *conn) WindowSize() (int, int, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.columns, c.rows, nil
+}
+
+// NotifyResize implements core.Transport interface
+func (c *conn) NotifyResize(fn func()) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.notify = fn
+}
@@ -0,0 +1,152 @@
+package bridge
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/badu/term"
+)
+
+// keyListener receives the key events of the engine
+type keyListener struct {
+	ch   chan term.KeyEvent
+	died chan struct{}
+}
+
+func (l *keyListener) KeyListen() chan term.KeyEvent { return l.ch }
+func (l *keyListener) DyingChan() chan struct{}      { return l.died }
+
+// dial performs the handshake, returning the connection and its reader
+func dial(t *testing.T, server *httptest.Server, origin string) (net.Conn, *bufio.Reader, string) {
+	t.Helper()
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("error dialing : %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	request := "GET /term HTTP/1.1\r\nHost: " + server.Listener.Addr().String() + "\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\nOrigin: " + origin + "\r\n\r\n"
+	if _, err := conn.Write([]byte(request)); err != nil {
+		t.Fatalf("error writing handshake : %v", err)
+	}
+	r := bufio.NewReader(conn)
+	response, err := http.ReadResponse(r, nil)
+	if err != nil {
+		t.Fatalf("error reading handshake : %v", err)
+	}
+	return conn, r, response.Status + " " + response.Header.Get("Sec-Websocket-Accept")
+}
+
+// send writes a masked binary frame, as the browsers do
+func send(t *testing.T, conn net.Conn, payload string) {
+	t.Helper()
+	mask := []byte{1, 2, 3, 4}
+	frame := append([]byte{0x82, 0x80 | byte(len(payload))}, mask...)
+	for idx := range payload {
+		frame = append(frame, payload[idx]^mask[idx%4])
+	}
+	if _, err := conn.Write(frame); err != nil {
+		t.Fatalf("error writing frame : %v", err)
+	}
+}
+
+// receive reads the output frames until the server closes the connection
+func receive(t *testing.T, conn net.Conn, r *bufio.Reader) string {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var result strings.Builder
+	for {
+		header := make([]byte, 2)
+		if _, err := r.Read(header[:1]); err != nil {
+			t.Fatalf("error reading frame : %v", err)
+		}
+		if _, err := r.Read(header[1:]); err != nil {
+			t.Fatalf("error reading frame : %v", err)
+		}
+		length := int(header[1] & 0x7f)
+		switch length {
+		case 126:
+			extended := make([]byte, 2)
+			_, _ = r.Read(extended[:1])
+			_, _ = r.Read(extended[1:])
+			length = int(binary.BigEndian.Uint16(extended))
+		case 127:
+			t.Fatalf("error : unexpected frame length")
+		}
+		payload := make([]byte, length)
+		for read := 0; read < length; {
+			n, err := r.Read(payload[read:])
+			if err != nil {
+				t.Fatalf("error reading payload : %v", err)
+			}
+			read += n
+		}
+		if header[0]&0x0f == opClose {
+			return result.String()
+		}
+		result.Write(payload)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	sizes := make(chan *term.Size, 1)
+	keys := make(chan string, 1)
+	handler := Handler(func(ctx context.Context, engine term.Engine) {
+		if err := engine.Start(ctx); err != nil {
+			t.Errorf("error starting engine : %v", err)
+			return
+		}
+		listener := &keyListener{ch: make(chan term.KeyEvent, 1), died: make(chan struct{})}
+		engine.KeyDispatcher().Register(listener)
+		sizes <- engine.Size() // the keys are typed after the listener is registered
+		select {
+		case ev := <-listener.ch:
+			keys <- ev.Name()
+		case <-ctx.Done():
+		}
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	for connection := 0; connection < 2; connection++ { // each connection gets its own engine
+		conn, r, status := dial(t, server, "http://"+server.Listener.Addr().String())
+		if status != "101 Switching Protocols s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+			t.Fatalf("error : unexpected handshake %q for connection %d", status, connection)
+		}
+		send(t, conn, "r100,30")
+		select {
+		case size := <-sizes:
+			if size.Columns != 100 || size.Rows != 30 {
+				t.Errorf("error : the engine should have the size of the browser, got %d x %d", size.Columns, size.Rows)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("error : the application didn't start")
+		}
+		send(t, conn, "ia")
+		output := receive(t, conn, r)
+		if name := <-keys; name != "Rune[a]" {
+			t.Errorf("error : unexpected key %s", name)
+		}
+		if !strings.Contains(output, "\x1b[?1049h") || !strings.Contains(output, "\x1b[?1049l") {
+			t.Errorf("error : the output should enter and leave the alternate screen, got %q", output)
+		}
+	}
+}
+
+func TestHandlerOrigin(t *testing.T) {
+	server := httptest.NewServer(Handler(func(ctx context.Context, engine term.Engine) {
+		t.Errorf("error : the application should not run")
+	}))
+	defer server.Close()
+
+	if _, _, status := dial(t, server, "http://evil.example"); !strings.HasPrefix(status, "403") {
+		t.Errorf("error : other sites should be refused, got %q", status)
+	}
+}
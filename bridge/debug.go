@@ -0,0 +1,6 @@
+package bridge
+
+import "github.com/badu/term/debug"
+
+// Debug is the diagnosis log of the package, enabled at runtime with TERM_DEBUG=bridge or core.WithDebug("bridge") (see package debug)
+var Debug = debug.For("bridge")
@@ -0,0 +1,47 @@
+package bridge
+
+import (
+	"io"
+	"net/http"
+)
+
+// Script is the browser side of the bridge : baduTermConnect(terminal, url) connects a xterm.js Terminal (already opened in the page) to the handler,
+// sending its size first, then its input and resizes, while writing the output to it. It returns the WebSocket.
+//
+//	<script src="xterm.js"></script>
+//	<script src="/bridge.js"></script>
+//	<script>
+//		const terminal = new Terminal();
+//		terminal.open(document.getElementById("terminal"));
+//		baduTermConnect(terminal, "ws://" + location.host + "/term");
+//	</script>
+const Script = `function baduTermConnect(terminal, url) {
+	const socket = new WebSocket(url);
+	socket.binaryType = "arraybuffer";
+	const encoder = new TextEncoder();
+	const send = (kind, bytes) => {
+		if (socket.readyState !== WebSocket.OPEN) {
+			return;
+		}
+		const message = new Uint8Array(bytes.length + 1);
+		message[0] = kind.charCodeAt(0);
+		message.set(bytes, 1);
+		socket.send(message);
+	};
+	socket.onopen = () => send("r", encoder.encode(terminal.cols + "," + terminal.rows));
+	socket.onmessage = (event) => terminal.write(new Uint8Array(event.data));
+	socket.onclose = () => terminal.write("\r\n[disconnected]\r\n");
+	terminal.onData((data) => send("i", encoder.encode(data)));
+	terminal.onBinary((data) => send("i", Uint8Array.from(data, (c) => c.charCodeAt(0) & 0xff)));
+	terminal.onResize((size) => send("r", encoder.encode(size.cols + "," + size.rows)));
+	return socket;
+}
+`
+
+// ScriptHandler serves the Script
+func ScriptHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/javascript; charset=utf-8")
+		_, _ = io.WriteString(w, Script)
+	})
+}
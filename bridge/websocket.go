@@ -0,0 +1,207 @@
+package bridge
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const (
+	acceptGUID     = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11" // RFC 6455, appended to the key of the client
+	maxMessageSize = 1 << 20                                // messages longer than this close the connection, so a client can't exhaust the memory
+
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+var (
+	// ErrNotWebSocket is returned when the request is not a WebSocket handshake
+	ErrNotWebSocket = errors.New("not a websocket handshake")
+	// ErrBadOrigin is returned when the origin of the request is refused, see WithOriginCheck
+	ErrBadOrigin = errors.New("websocket origin not allowed")
+	// ErrProtocol is returned when the client breaks the WebSocket protocol
+	ErrProtocol = errors.New("websocket protocol error")
+)
+
+// wsConn is the server side of a WebSocket connection (RFC 6455), just enough for the bridge : no extensions, no subprotocols
+type wsConn struct {
+	sync.Mutex               // guards the writes
+	conn       net.Conn      //
+	r          *bufio.Reader // the hijacked reader, which might hold bytes sent right after the handshake
+}
+
+// sameOrigin is the default origin check : browsers always send the Origin header, which has to match the host, so other sites can't drive the application
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true // not a browser
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+// headerHas returns true if the comma separated header contains the token
+func headerHas(r *http.Request, name, token string) bool {
+	for _, value := range r.Header[name] {
+		for _, field := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(field), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// acceptKey computes the Sec-WebSocket-Accept header
+func acceptKey(key string) string {
+	h := sha1.New()
+	_, _ = io.WriteString(h, key+acceptGUID)
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// upgrade completes the handshake, taking over the connection. The errors are replied to the client.
+func upgrade(w http.ResponseWriter, r *http.Request, checkOrigin func(*http.Request) bool) (*wsConn, error) {
+	key := r.Header.Get("Sec-Websocket-Key")
+	if r.Method != http.MethodGet || !headerHas(r, "Connection", "upgrade") || !headerHas(r, "Upgrade", "websocket") || key == "" {
+		http.Error(w, ErrNotWebSocket.Error(), http.StatusBadRequest)
+		return nil, ErrNotWebSocket
+	}
+	if r.Header.Get("Sec-Websocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported websocket version", http.StatusUpgradeRequired)
+		return nil, ErrNotWebSocket
+	}
+	if !checkOrigin(r) {
+		http.Error(w, ErrBadOrigin.Error(), http.StatusForbidden)
+		return nil, ErrBadOrigin
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket not supported by the server", http.StatusInternalServerError)
+		return nil, ErrNotWebSocket
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	response := "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: " + acceptKey(key) + "\r\n\r\n"
+	if _, err := conn.Write([]byte(response)); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return &wsConn{conn: conn, r: rw.Reader}, nil
+}
+
+// readMessage returns the next text or binary message, answering the pings. The fragmented messages are assembled.
+func (c *wsConn) readMessage() ([]byte, error) {
+	var message []byte
+	for {
+		var header [2]byte
+		if _, err := io.ReadFull(c.r, header[:]); err != nil {
+			return nil, err
+		}
+		final, opcode := header[0]&0x80 != 0, header[0]&0x0f
+		if header[0]&0x70 != 0 || header[1]&0x80 == 0 {
+			return nil, ErrProtocol // no extensions were negotiated, and the clients must mask their frames
+		}
+		length := uint64(header[1] & 0x7f)
+		switch length {
+		case 126:
+			var extended [2]byte
+			if _, err := io.ReadFull(c.r, extended[:]); err != nil {
+				return nil, err
+			}
+			length = uint64(binary.BigEndian.Uint16(extended[:]))
+		case 127:
+			var extended [8]byte
+			if _, err := io.ReadFull(c.r, extended[:]); err != nil {
+				return nil, err
+			}
+			length = binary.BigEndian.Uint64(extended[:])
+		}
+		if length > maxMessageSize || uint64(len(message))+length > maxMessageSize {
+			return nil, ErrProtocol
+		}
+		var mask [4]byte
+		if _, err := io.ReadFull(c.r, mask[:]); err != nil {
+			return nil, err
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(c.r, payload); err != nil {
+			return nil, err
+		}
+		for idx := range payload {
+			payload[idx] ^= mask[idx%4]
+		}
+
+		switch opcode {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return nil, err
+			}
+		case opPong:
+		case opClose:
+			_ = c.writeFrame(opClose, nil)
+			return nil, io.EOF
+		case opText, opBinary, opContinuation:
+			if (opcode == opContinuation) != (message != nil) {
+				return nil, ErrProtocol // a continuation without a start, or a new message before the end of the previous one
+			}
+			message = append(message, payload...)
+			if message == nil {
+				message = []byte{}
+			}
+			if final {
+				return message, nil
+			}
+		default:
+			return nil, ErrProtocol
+		}
+	}
+}
+
+// writeFrame sends a single, unmasked frame
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	c.Lock()
+	defer c.Unlock()
+
+	header := make([]byte, 2, 10)
+	header[0] = 0x80 | opcode
+	switch {
+	case len(payload) < 126:
+		header[1] = byte(len(payload))
+	case len(payload) <= 0xffff:
+		header[1] = 126
+		header = append(header, byte(len(payload)>>8), byte(len(payload)))
+	default:
+		header[1] = 127
+		var extended [8]byte
+		binary.BigEndian.PutUint64(extended[:], uint64(len(payload)))
+		header = append(header, extended[:]...)
+	}
+	if _, err := c.conn.Write(append(header, payload...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Close closes the connection, telling the client first
+func (c *wsConn) Close() error {
+	_ = c.writeFrame(opClose, nil)
+	return c.conn.Close()
+}
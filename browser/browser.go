@@ -0,0 +1,156 @@
+//go:build js && wasm
+// +build js,wasm
+
+package browser
+
+import (
+	"io"
+	"sync"
+	"syscall/js"
+
+	"github.com/badu/term"
+	"github.com/badu/term/core"
+)
+
+const defaultTerminal = "xterm-truecolor" // what xterm.js emulates : the xterm-256color entry, with the 24-bit color sequences (the page has no $COLORTERM to request them)
+
+// NewEngine returns the engine drawing on the xterm.js Terminal (the JavaScript object, already opened in the page). The options are the ones of core.NewCore, the true color being enabled by default.
+// The listeners added to the terminal are disposed once the engine has shut down.
+func NewEngine(xterm js.Value, opts ...core.Option) (term.Engine, error) {
+	t := newTerminal(xterm)
+	engine, err := core.NewCore(defaultTerminal, append(append([]core.Option{core.WithTrueColor("enable")}, opts...), core.WithTransport(t))...)
+	if err != nil {
+		t.dispose()
+		return nil, err
+	}
+	go func() {
+		<-engine.DyingChan()
+		t.dispose()
+	}()
+	return engine, nil
+}
+
+// terminal is the transport of the engine, a xterm.js Terminal
+type terminal struct {
+	sync.Mutex               // guards other properties
+	xterm      js.Value      //
+	pending    []byte        // the input which wasn't read yet
+	arrived    chan struct{} // signaled when input was added to pending
+	closed     chan struct{} // closed by dispose
+	notify     func()        // set by the engine, called on resize
+	listeners  []js.Value    // the IDisposable objects returned by xterm.js, for the listeners we've added
+	funcs      []js.Func     // released by dispose
+}
+
+func newTerminal(xterm js.Value) *terminal {
+	t := &terminal{
+		xterm:   xterm,
+		arrived: make(chan struct{}, 1),
+		closed:  make(chan struct{}),
+	}
+	// the callbacks run on the event loop of the page, so they must not block
+	t.listen("onData", func(args []js.Value) {
+		t.received([]byte(args[0].String()))
+	})
+	t.listen("onBinary", func(args []js.Value) {
+		data := args[0].String() // one character per byte
+		bytes := make([]byte, 0, len(data))
+		for _, r := range data {
+			bytes = append(bytes, byte(r))
+		}
+		t.received(bytes)
+	})
+	t.listen("onResize", func(args []js.Value) {
+		t.Lock()
+		notify := t.notify
+		t.Unlock()
+		if notify != nil {
+			notify()
+		}
+	})
+	return t
+}
+
+// listen adds the callback to the xterm.js event
+func (t *terminal) listen(event string, fn func(args []js.Value)) {
+	f := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) > 0 {
+			fn(args)
+		}
+		return nil
+	})
+	t.funcs = append(t.funcs, f)
+	t.listeners = append(t.listeners, t.xterm.Call(event, f))
+}
+
+// received queues the input, for Read
+func (t *terminal) received(b []byte) {
+	t.Lock()
+	t.pending = append(t.pending, b...)
+	t.Unlock()
+	select {
+	case t.arrived <- struct{}{}:
+	default: // the reader has already been signaled
+	}
+}
+
+// Read implements io.Reader interface, returning io.EOF once the listeners were disposed
+func (t *terminal) Read(p []byte) (int, error) {
+	for {
+		t.Lock()
+		if len(t.pending) > 0 {
+			n := copy(p, t.pending)
+			t.pending = t.pending[n:]
+			t.Unlock()
+			return n, nil
+		}
+		t.Unlock()
+		select {
+		case <-t.arrived:
+		case <-t.closed:
+			return 0, io.EOF
+		}
+	}
+}
+
+// Write implements io.Writer interface
+func (t *terminal) Write(p []byte) (int, error) {
+	data := js.Global().Get("Uint8Array").New(len(p))
+	js.CopyBytesToJS(data, p)
+	t.xterm.Call("write", data)
+	return len(p), nil
+}
+
+// WindowSize implements core.Transport interface
+func (t *terminal) WindowSize() (int, int, error) {
+	return t.xterm.Get("cols").Int(), t.xterm.Get("rows").Int(), nil
+}
+
+// NotifyResize implements core.Transport interface
+func (t *terminal) NotifyResize(fn func()) {
+	t.Lock()
+	defer t.Unlock()
+	t.notify = fn
+}
+
+// dispose removes the listeners from the terminal
+func (t *terminal) dispose() {
+	t.Lock()
+	defer t.Unlock()
+	select {
+	case <-t.closed:
+		return
+	default:
+	}
+	close(t.closed)
+	for _, listener := range t.listeners {
+		listener.Call("dispose")
+	}
+	for _, f := range t.funcs {
+		f.Release()
+	}
+	t.listeners, t.funcs, t.notify = nil, nil, nil
+	if Debug.Enabled() {
+		Debug.Println("listeners disposed")
+	}
+}
@@ -0,0 +1,206 @@
+//go:build js && wasm
+// +build js,wasm
+
+package browser
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"syscall/js"
+	"testing"
+	"time"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+// pixel is drawn by the engine
+type pixel struct {
+	column, row int
+	r           rune
+	fg          color.Color
+}
+
+func (p *pixel) DrawCh() chan term.PixelGetter { return nil }
+func (p *pixel) PositionHash() int             { return term.Hash(p.column, p.row) }
+func (p *pixel) Style() (color.Color, color.Color, style.Mask) {
+	return p.fg, color.Default, style.None
+}
+func (p *pixel) Rune() rune             { return p.r }
+func (p *pixel) Width() int             { return 1 }
+func (p *pixel) HasUnicode() bool       { return false }
+func (p *pixel) Unicode() *term.Unicode { return nil }
+
+// fakeXterm plays the xterm.js Terminal : it records what is written, and calls the listeners when the tests type or resize
+type fakeXterm struct {
+	sync.Mutex                       // guards other properties
+	value      js.Value              // the JavaScript object given to the engine
+	written    strings.Builder       // everything written to the terminal
+	listeners  map[string][]js.Value // by event name
+	disposed   int                   // how many listeners were disposed
+	funcs      []js.Func             // released when the test ends
+}
+
+func newFakeXterm(t *testing.T, columns, rows int) *fakeXterm {
+	x := &fakeXterm{value: js.Global().Get("Object").New(), listeners: make(map[string][]js.Value)}
+	x.value.Set("cols", columns)
+	x.value.Set("rows", rows)
+	for _, event := range []string{"onData", "onBinary", "onResize"} {
+		event := event
+		x.method(event, func(args []js.Value) interface{} {
+			x.Lock()
+			x.listeners[event] = append(x.listeners[event], args[0])
+			x.Unlock()
+			disposable := js.Global().Get("Object").New()
+			disposable.Set("dispose", x.funcOf(func([]js.Value) interface{} {
+				x.Lock()
+				x.disposed++
+				x.Unlock()
+				return nil
+			}))
+			return disposable
+		})
+	}
+	x.method("write", func(args []js.Value) interface{} {
+		data := make([]byte, args[0].Get("length").Int())
+		js.CopyBytesToGo(data, args[0])
+		x.Lock()
+		x.written.Write(data)
+		x.Unlock()
+		return nil
+	})
+	t.Cleanup(func() {
+		for _, f := range x.funcs {
+			f.Release()
+		}
+	})
+	return x
+}
+
+func (x *fakeXterm) funcOf(fn func(args []js.Value) interface{}) js.Func {
+	f := js.FuncOf(func(this js.Value, args []js.Value) interface{} { return fn(args) })
+	x.funcs = append(x.funcs, f)
+	return f
+}
+
+func (x *fakeXterm) method(name string, fn func(args []js.Value) interface{}) {
+	x.value.Set(name, x.funcOf(fn))
+}
+
+// emit calls the listeners of the event, as xterm.js does
+func (x *fakeXterm) emit(event string, args ...interface{}) {
+	x.Lock()
+	listeners := append([]js.Value(nil), x.listeners[event]...)
+	x.Unlock()
+	for _, listener := range listeners {
+		listener.Invoke(args...)
+	}
+}
+
+// waitWritten waits until the sequences were written to the terminal, returning what was written
+func (x *fakeXterm) waitWritten(t *testing.T, sequences ...string) string {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		x.Lock()
+		out := x.written.String()
+		x.Unlock()
+		found := true
+		for _, seq := range sequences {
+			found = found && strings.Contains(out, seq)
+		}
+		if found {
+			return out
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("error : waited for %q, the engine wrote %q", sequences, out)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestTerminal(t *testing.T) {
+	x := newFakeXterm(t, 100, 30)
+	tr := newTerminal(x.value)
+
+	// the input of both events is read, in order
+	x.emit("onData", "ab")
+	x.emit("onBinary", "\x1b\u00ff") // one character per byte
+	buf := make([]byte, 16)
+	n, err := tr.Read(buf)
+	if err != nil || string(buf[:n]) != "ab\x1b\xff" {
+		t.Errorf("error : expecting the typed input, got %q (%v)", buf[:n], err)
+	}
+
+	if _, err := tr.Write([]byte("\x1b[1;1Hx")); err != nil {
+		t.Errorf("error writing : %v", err)
+	}
+	if out := x.waitWritten(t); out != "\x1b[1;1Hx" {
+		t.Errorf("error : expecting the bytes to be written to the terminal, got %q", out)
+	}
+
+	if columns, rows, err := tr.WindowSize(); err != nil || columns != 100 || rows != 30 {
+		t.Errorf("error : expecting 100 x 30, got %d x %d (%v)", columns, rows, err)
+	}
+	resized := 0
+	tr.NotifyResize(func() { resized++ })
+	x.emit("onResize", js.Global().Get("Object").New())
+	if resized != 1 {
+		t.Errorf("error : the resize should be notified once, got %d", resized)
+	}
+
+	// once disposed, the listeners are removed and the reads end
+	tr.dispose()
+	tr.dispose()
+	if x.disposed != 3 {
+		t.Errorf("error : expecting the 3 listeners to be disposed, got %d", x.disposed)
+	}
+	if _, err := tr.Read(buf); err != io.EOF {
+		t.Errorf("error : reading after dispose should return io.EOF, got %v", err)
+	}
+}
+
+func TestNewEngine(t *testing.T) {
+	x := newFakeXterm(t, 100, 30)
+	engine, err := NewEngine(x.value)
+	if err != nil {
+		t.Fatalf("error creating the engine : %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := engine.Start(ctx); err != nil {
+		t.Fatalf("error starting the engine : %v", err)
+	}
+	x.waitWritten(t, "\x1b[?1049h", "\x1b[?25l") // the alternate screen, the hidden cursor
+	if size := engine.Size(); size.Columns != 100 || size.Rows != 30 {
+		t.Errorf("error : the size should be the one of the terminal, got %d x %d", size.Columns, size.Rows)
+	}
+
+	// the true color is enabled by default
+	engine.Redraw([]term.PixelGetter{&pixel{column: 2, row: 1, r: 'x', fg: color.NewHexColor(0xFF1010)}})
+	x.waitWritten(t, "\x1b[2;3H\x1b(B\x1b[m\x1b[38;2;255;16;16mx")
+
+	cancel()
+	select {
+	case <-engine.DyingChan():
+	case <-time.After(2 * time.Second):
+		t.Fatalf("error : the engine didn't shut down")
+	}
+	x.waitWritten(t, "\x1b[?1049l")
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		x.Lock()
+		disposed := x.disposed
+		x.Unlock()
+		if disposed == 3 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("error : the listeners should be disposed after shutdown, got %d", disposed)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
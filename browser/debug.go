@@ -0,0 +1,6 @@
+package browser
+
+import "github.com/badu/term/debug"
+
+// Debug is the diagnosis log of the package, enabled at runtime with TERM_DEBUG=browser or core.WithDebug("browser") (see package debug)
+var Debug = debug.For("browser")
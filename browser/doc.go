@@ -0,0 +1,5 @@
+// Package browser runs the applications built with badu/term entirely in the browser, compiled with GOOS=js GOARCH=wasm : the terminal of the engine is a xterm.js instance of the same page.
+//
+// The engine is the usual one (the same dispatchers and pixel model), only its input and output go through syscall/js instead of /dev/tty.
+// For serving the applications from a server process instead, see package bridge.
+package browser
@@ -0,0 +1,241 @@
+package canvas
+
+import (
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/geom"
+)
+
+// Mode is the way dots are packed into cells
+type Mode int
+
+const (
+	Braille  Mode = iota // 2x4 dots per cell, using the braille patterns (U+2800 - U+28FF)
+	Quadrant             // 2x2 dots per cell, using the quadrant blocks (e.g. '▚'), for fonts lacking braille
+)
+
+// DotsPerCell returns the number of dots of a cell, horizontally and vertically
+func (m Mode) DotsPerCell() (int, int) {
+	if m == Quadrant {
+		return 2, 2
+	}
+	return 2, 4
+}
+
+const brailleBase = 0x2800
+
+var (
+	// brailleBits maps [row][column] of the dot inside the cell to the braille pattern bit
+	brailleBits = [4][2]uint8{{0x01, 0x08}, {0x02, 0x10}, {0x04, 0x20}, {0x40, 0x80}}
+	// quadrantBits maps [row][column] of the dot inside the cell to the index in quadrantRunes
+	quadrantBits  = [2][2]uint8{{0x01, 0x02}, {0x04, 0x08}}
+	quadrantRunes = [16]rune{' ', '▘', '▝', '▀', '▖', '▌', '▞', '▛', '▗', '▚', '▐', '▜', '▄', '▙', '▟', '█'}
+)
+
+// Option for functional options
+type Option func(c *Canvas)
+
+// WithMode sets the dots packing. Default is Braille.
+func WithMode(m Mode) Option {
+	return func(c *Canvas) {
+		c.mode = m
+	}
+}
+
+// WithColor sets the initial pen color. Default is color.Default.
+func WithColor(fg color.Color) Option {
+	return func(c *Canvas) {
+		c.pen = fg
+	}
+}
+
+// WithBackground sets the background color of all cells. Default is color.Default.
+func WithBackground(bg color.Color) Option {
+	return func(c *Canvas) {
+		c.bg = bg
+	}
+}
+
+// Canvas is a drawing surface with sub-cell resolution. The dots are addressed with x (left to right) and y (top to bottom), from 0,0.
+// A cell can have a single foreground color, so the last dot drawn in a cell sets its color.
+// Canvas is not safe for concurrent use.
+type Canvas struct {
+	mode    Mode          // dots packing
+	columns int           // size in cells
+	rows    int           // size in cells
+	dots    []uint8       // bits of each cell, row by row
+	colors  []color.Color // foreground of each cell, row by row
+	pen     color.Color   // color of the next dots
+	bg      color.Color   // background of all cells
+}
+
+// New creates a canvas covering size cells
+func New(size term.Size, opts ...Option) *Canvas {
+	res := &Canvas{
+		columns: term.Max(0, size.Columns),
+		rows:    term.Max(0, size.Rows),
+		pen:     color.Default,
+		bg:      color.Default,
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+	res.dots = make([]uint8, res.columns*res.rows)
+	res.colors = make([]color.Color, res.columns*res.rows)
+	return res
+}
+
+// Size returns the size of the canvas, in cells
+func (c *Canvas) Size() term.Size {
+	return term.Size{Columns: c.columns, Rows: c.rows}
+}
+
+// Width returns the number of horizontal dots
+func (c *Canvas) Width() int {
+	w, _ := c.mode.DotsPerCell()
+	return c.columns * w
+}
+
+// Height returns the number of vertical dots
+func (c *Canvas) Height() int {
+	_, h := c.mode.DotsPerCell()
+	return c.rows * h
+}
+
+// SetColor changes the pen color, used by the next drawing operations
+func (c *Canvas) SetColor(fg color.Color) {
+	c.pen = fg
+}
+
+// cell returns the index of the cell and the bit of the dot. Outside dots return false.
+func (c *Canvas) cell(x, y int) (int, uint8, bool) {
+	if x < 0 || y < 0 || x >= c.Width() || y >= c.Height() {
+		return 0, 0, false
+	}
+	w, h := c.mode.DotsPerCell()
+	idx := (y/h)*c.columns + x/w
+	if c.mode == Quadrant {
+		return idx, quadrantBits[y%h][x%w], true
+	}
+	return idx, brailleBits[y%h][x%w], true
+}
+
+// SetPoint turns on the dot, using the pen color. Dots outside the canvas are ignored.
+func (c *Canvas) SetPoint(x, y int) {
+	idx, bit, ok := c.cell(x, y)
+	if !ok {
+		return
+	}
+	c.dots[idx] |= bit
+	c.colors[idx] = c.pen
+}
+
+// UnsetPoint turns off the dot
+func (c *Canvas) UnsetPoint(x, y int) {
+	if idx, bit, ok := c.cell(x, y); ok {
+		c.dots[idx] &^= bit
+	}
+}
+
+// Point reports if the dot is on
+func (c *Canvas) Point(x, y int) bool {
+	idx, bit, ok := c.cell(x, y)
+	return ok && c.dots[idx]&bit != 0
+}
+
+// Clear turns off all dots
+func (c *Canvas) Clear() {
+	for idx := range c.dots {
+		c.dots[idx] = 0
+		c.colors[idx] = color.Default
+	}
+}
+
+// Line draws a line between the two dots (inclusive), using Bresenham's algorithm
+func (c *Canvas) Line(x0, y0, x1, y1 int) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		c.SetPoint(x0, y0)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// Circle draws the outline of a circle, using the midpoint algorithm
+func (c *Canvas) Circle(cx, cy, radius int) {
+	if radius < 0 {
+		return
+	}
+	x, y, err := radius, 0, 1-radius
+	for x >= y {
+		for _, p := range [8][2]int{{x, y}, {y, x}, {-y, x}, {-x, y}, {-x, -y}, {-y, -x}, {y, -x}, {x, -y}} {
+			c.SetPoint(cx+p[0], cy+p[1])
+		}
+		y++
+		if err < 0 {
+			err += 2*y + 1
+		} else {
+			x--
+			err += 2*(y-x) + 1
+		}
+	}
+}
+
+// Cell returns the rune and the foreground color of the cell. Empty cells are spaces.
+func (c *Canvas) Cell(column, row int) (rune, color.Color) {
+	if column < 0 || row < 0 || column >= c.columns || row >= c.rows {
+		return ' ', color.Default
+	}
+	idx := row*c.columns + column
+	bits := c.dots[idx]
+	if bits == 0 {
+		return ' ', color.Default
+	}
+	if c.mode == Quadrant {
+		return quadrantRunes[bits], c.colors[idx]
+	}
+	return rune(brailleBase + int(bits)), c.colors[idx]
+}
+
+// Draw writes the cells into the grid (indexed [column][row], as returned by Render or geom.NewPixelGrid). The grid can be larger or smaller than the canvas.
+func (c *Canvas) Draw(grid [][]term.Pixel) {
+	for column := 0; column < term.Min(len(grid), c.columns); column++ {
+		for row := 0; row < term.Min(len(grid[column]), c.rows); row++ {
+			r, fg := c.Cell(column, row)
+			grid[column][row].Set(r, fg, c.bg)
+		}
+	}
+}
+
+// Render returns the pixels of the canvas, positioned from 0,0. The grid is indexed [column][row].
+func (c *Canvas) Render() ([][]term.Pixel, []term.PixelGetter) {
+	size := c.Size()
+	grid, getters := geom.NewPixelGrid(&size, geom.WithBackground(c.bg))
+	c.Draw(grid)
+	return grid, getters
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
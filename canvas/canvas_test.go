@@ -0,0 +1,48 @@
+package canvas_test
+
+import (
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/canvas"
+	"github.com/badu/term/color"
+)
+
+func TestCanvas(t *testing.T) {
+	c := canvas.New(term.Size{Columns: 2, Rows: 1}, canvas.WithColor(color.Red))
+	if c.Width() != 4 || c.Height() != 4 {
+		t.Fatalf("expecting 4x4 dots, got %dx%d", c.Width(), c.Height())
+	}
+	c.SetPoint(0, 0)
+	c.SetPoint(1, 3)
+	c.SetPoint(10, 10) // ignored
+	if r, fg := c.Cell(0, 0); r != '⢁' || fg != color.Red {
+		t.Fatalf("expecting red '⢁', got %q %v", r, fg)
+	}
+	if r, _ := c.Cell(1, 0); r != ' ' {
+		t.Fatalf("expecting empty cell, got %q", r)
+	}
+
+	c.Clear()
+	c.Line(0, 0, 3, 3)
+	for i := 0; i < 4; i++ {
+		if !c.Point(i, i) {
+			t.Fatalf("expecting dot %d,%d on the diagonal", i, i)
+		}
+	}
+	if c.Point(1, 0) || c.Point(0, 1) {
+		t.Fatalf("expecting diagonal steps")
+	}
+
+	q := canvas.New(term.Size{Columns: 3, Rows: 3}, canvas.WithMode(canvas.Quadrant))
+	q.Circle(2, 2, 2)
+	if q.Point(2, 2) || !q.Point(0, 2) || !q.Point(2, 4) || !q.Point(4, 2) || !q.Point(2, 0) {
+		t.Fatalf("expecting circle outline")
+	}
+	q.Clear()
+	q.Line(0, 0, 1, 0)
+	grid, getters := q.Render()
+	if len(getters) != 9 || grid[0][0].Rune() != '▀' || grid[1][1].Rune() != ' ' {
+		t.Fatalf("expecting '▀' in the first cell, got %q", grid[0][0].Rune())
+	}
+}
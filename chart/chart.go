@@ -0,0 +1,397 @@
+package chart
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/badu/term"
+	"github.com/badu/term/canvas"
+	"github.com/badu/term/color"
+	"github.com/badu/term/draw"
+	"github.com/badu/term/geom"
+)
+
+// Chart is implemented by all charts : Draw fills the grid (indexed [column][row]), using all its size
+type Chart interface {
+	Draw(grid [][]term.Pixel)
+}
+
+// Render returns new pixels of the given size, positioned from 0,0, with the chart drawn into them
+func Render(c Chart, size term.Size) ([][]term.Pixel, []term.PixelGetter) {
+	grid, getters := geom.NewPixelGrid(&size)
+	c.Draw(grid)
+	return grid, getters
+}
+
+// RenderIn returns new pixels covering the rectangle (positioned at its top corner), with the chart drawn into them
+func RenderIn(c Chart, r *geom.Rectangle) ([][]term.Pixel, []term.PixelGetter) {
+	size, top := r.Size(), r.Top()
+	grid := make([][]term.Pixel, size.Columns)
+	getters := make([]term.PixelGetter, 0, size.Columns*size.Rows)
+	for column := range grid {
+		grid[column] = make([]term.Pixel, size.Rows)
+	}
+	for row := 0; row < size.Rows; row++ {
+		for column := 0; column < size.Columns; column++ {
+			px, _ := geom.NewPixel(geom.WithPosition(term.NewPosition(top.Column+column, top.Row+row)))
+			grid[column][row] = px
+			getters = append(getters, px)
+		}
+	}
+	c.Draw(grid)
+	return grid, getters
+}
+
+// Option for functional options
+type Option func(o *options)
+
+type options struct {
+	fixed    bool         // if true, min and max are not computed from values
+	min      float64      // lowest value of the scale
+	max      float64      // highest value of the scale
+	from     color.Color  // color of the lowest values
+	to       color.Color  // color of the highest values
+	bg       color.Color  // background of all cells
+	showAxes bool         // draw the axes and the min / max labels
+	history  int          // how many values are kept by Push
+	barWidth int          // width of a bar, in cells
+	gap      int          // space between bars, in cells
+	labels   []string     // labels under the bars
+	mode     canvas.Mode  // dots packing of the line chart
+	axisFg   color.Color  // color of axes and labels
+	blocks   draw.Eighths // runes of the bars, from empty to full
+}
+
+func defaultOptions() options {
+	return options{
+		from:     color.Default,
+		to:       color.Default,
+		bg:       color.Default,
+		axisFg:   color.Default,
+		showAxes: true,
+		history:  512,
+		barWidth: 1,
+		gap:      1,
+		blocks:   draw.VerticalEighths,
+	}
+}
+
+// WithRange fixes the scale, otherwise it's computed from the values (including zero)
+func WithRange(min, max float64) Option {
+	return func(o *options) {
+		o.fixed, o.min, o.max = true, min, max
+	}
+}
+
+// WithColor draws everything using a single color
+func WithColor(c color.Color) Option {
+	return func(o *options) {
+		o.from, o.to = c, c
+	}
+}
+
+// WithGradient colors the values according to their level, from the lowest to the highest
+func WithGradient(from, to color.Color) Option {
+	return func(o *options) {
+		o.from, o.to = from, to
+	}
+}
+
+// WithBackground sets the background of all cells
+func WithBackground(c color.Color) Option {
+	return func(o *options) {
+		o.bg = c
+	}
+}
+
+// WithAxes draws (default) or not the axes and the min / max labels. Sparklines have no axes.
+func WithAxes(show bool) Option {
+	return func(o *options) {
+		o.showAxes = show
+	}
+}
+
+// WithAxisColor sets the color of the axes and labels
+func WithAxisColor(c color.Color) Option {
+	return func(o *options) {
+		o.axisFg = c
+	}
+}
+
+// WithHistory sets how many values are kept when using Push. Default is 512.
+func WithHistory(n int) Option {
+	return func(o *options) {
+		o.history = term.Max(1, n)
+	}
+}
+
+// WithBarWidth sets the width of the bars and the gap between them, in cells. Defaults are 1 and 1.
+func WithBarWidth(width, gap int) Option {
+	return func(o *options) {
+		o.barWidth, o.gap = term.Max(1, width), term.Max(0, gap)
+	}
+}
+
+// WithLabels sets the labels written under the bars (truncated to the bar width)
+func WithLabels(labels ...string) Option {
+	return func(o *options) {
+		o.labels = labels
+	}
+}
+
+// WithDisplayer draws the bars of the sparklines and bar charts with eighth blocks (default), or with their ASCII replacements when the terminal can't display them (see draw.BlocksFor)
+func WithDisplayer(d draw.Displayer) Option {
+	return func(o *options) {
+		_, o.blocks = draw.BlocksFor(d)
+	}
+}
+
+// WithMode sets the dots packing of the line chart. Default is canvas.Braille.
+func WithMode(m canvas.Mode) Option {
+	return func(o *options) {
+		o.mode = m
+	}
+}
+
+// series holds the values and the options, shared by all charts
+type series struct {
+	options
+	values []float64
+}
+
+// SetValues replaces the values
+func (s *series) SetValues(values []float64) {
+	s.values = append(s.values[:0], values...)
+}
+
+// Push appends a value, dropping the oldest one when the history is full
+func (s *series) Push(v float64) {
+	s.values = append(s.values, v)
+	if len(s.values) > s.history {
+		s.values = append(s.values[:0], s.values[len(s.values)-s.history:]...)
+	}
+}
+
+// Values returns the values
+func (s *series) Values() []float64 {
+	return s.values
+}
+
+// scale returns the range of the values
+func (s *series) scale(values []float64) (float64, float64) {
+	if s.fixed {
+		return s.min, s.max
+	}
+	lo, hi := 0.0, 0.0
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	return lo, hi
+}
+
+// level returns the value position in the range, in [0..1]
+func level(v, lo, hi float64) float64 {
+	if hi <= lo || math.IsNaN(v) {
+		return 0
+	}
+	return math.Max(0, math.Min(1, (v-lo)/(hi-lo)))
+}
+
+// color returns the gradient color at the level
+func (s *series) color(l float64) color.Color {
+	if s.from == s.to {
+		return s.from
+	}
+	return color.Blend(s.from, s.to, l)
+}
+
+// clear fills the grid with spaces
+func (s *series) clear(grid [][]term.Pixel) {
+	for column := range grid {
+		for row := range grid[column] {
+			grid[column][row].Set(' ', color.Default, s.bg)
+		}
+	}
+}
+
+// set writes a rune into the grid, ignoring the cells outside
+func (s *series) set(grid [][]term.Pixel, column, row int, r rune, fg color.Color) {
+	if column < 0 || column >= len(grid) || row < 0 || row >= len(grid[column]) {
+		return
+	}
+	grid[column][row].Set(r, fg, s.bg)
+}
+
+// columns draws the values as vertical bars of eighth blocks, from the bottom of the area
+func (s *series) columns(grid [][]term.Pixel, left, top, height int, values []float64, width, gap int, lo, hi float64) {
+	for idx, v := range values {
+		l := level(v, lo, hi)
+		eighthsUp := int(math.Round(l * float64(height*8)))
+		fg := s.color(l)
+		for row := 0; row < height; row++ {
+			fill := term.Max(0, term.Min(8, eighthsUp-(height-1-row)*8))
+			for w := 0; w < width; w++ {
+				s.set(grid, left+idx*(width+gap)+w, top+row, s.blocks[fill], fg)
+			}
+		}
+	}
+}
+
+// axes draws the vertical axis with the min and max labels and the horizontal axis, returning the plot area
+func (s *series) axes(grid [][]term.Pixel, lo, hi float64, bottomRows int) (left, top, width, height int) {
+	width, height = len(grid), 0
+	if width > 0 {
+		height = len(grid[0])
+	}
+	height -= bottomRows
+	if !s.showAxes || height < 2 {
+		return 0, 0, width, term.Max(0, height)
+	}
+	loLabel, hiLabel := []rune(formatValue(lo)), []rune(formatValue(hi))
+	labelWidth := term.Max(len(loLabel), len(hiLabel))
+	if labelWidth+2 > width {
+		return 0, 0, width, height
+	}
+	for idx, r := range hiLabel {
+		s.set(grid, labelWidth-len(hiLabel)+idx, 0, r, s.axisFg)
+	}
+	for idx, r := range loLabel {
+		s.set(grid, labelWidth-len(loLabel)+idx, height-2, r, s.axisFg)
+	}
+	for row := 0; row < height-1; row++ {
+		axis := '│'
+		if row == 0 || row == height-2 {
+			axis = '┤'
+		}
+		s.set(grid, labelWidth, row, axis, s.axisFg)
+	}
+	s.set(grid, labelWidth, height-1, '└', s.axisFg)
+	for column := labelWidth + 1; column < width; column++ {
+		s.set(grid, column, height-1, '─', s.axisFg)
+	}
+	return labelWidth + 1, 0, width - labelWidth - 1, height - 1
+}
+
+// formatValue is used for the axis labels
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', 4, 64)
+}
+
+// Sparkline is a compact chart of the latest values, one value per column, without axes
+type Sparkline struct {
+	series
+}
+
+// NewSparkline creates a sparkline
+func NewSparkline(values []float64, opts ...Option) *Sparkline {
+	res := &Sparkline{series: series{options: defaultOptions()}}
+	for _, opt := range opts {
+		opt(&res.options)
+	}
+	res.SetValues(values)
+	return res
+}
+
+// Draw implements Chart : the latest values are drawn, right aligned
+func (s *Sparkline) Draw(grid [][]term.Pixel) {
+	s.clear(grid)
+	if len(grid) == 0 {
+		return
+	}
+	values := s.values[term.Max(0, len(s.values)-len(grid)):]
+	lo, hi := s.scale(values)
+	s.columns(grid, len(grid)-len(values), 0, len(grid[0]), values, 1, 0, lo, hi)
+}
+
+// BarChart draws each value as a vertical bar, with optional labels under the bars
+type BarChart struct {
+	series
+}
+
+// NewBarChart creates a bar chart
+func NewBarChart(values []float64, opts ...Option) *BarChart {
+	res := &BarChart{series: series{options: defaultOptions()}}
+	for _, opt := range opts {
+		opt(&res.options)
+	}
+	res.SetValues(values)
+	return res
+}
+
+// Draw implements Chart. The bars which don't fit are not drawn.
+func (b *BarChart) Draw(grid [][]term.Pixel) {
+	b.clear(grid)
+	labelRows := 0
+	if len(b.labels) > 0 {
+		labelRows = 1
+	}
+	lo, hi := b.scale(b.values)
+	left, top, width, height := b.axes(grid, lo, hi, labelRows)
+	count := term.Min(len(b.values), (width+b.gap)/(b.barWidth+b.gap))
+	b.columns(grid, left, top, height, b.values[:count], b.barWidth, b.gap, lo, hi)
+	if labelRows == 0 || len(grid) == 0 {
+		return
+	}
+	row := len(grid[0]) - 1
+	for idx := 0; idx < term.Min(count, len(b.labels)); idx++ {
+		for w, r := range []rune(b.labels[idx]) {
+			if w >= b.barWidth {
+				break
+			}
+			b.set(grid, left+idx*(b.barWidth+b.gap)+w, row, r, b.axisFg)
+		}
+	}
+}
+
+// LineChart draws the values as a line, using a canvas for sub-cell resolution. The values are stretched over the whole width.
+type LineChart struct {
+	series
+}
+
+// NewLineChart creates a line chart
+func NewLineChart(values []float64, opts ...Option) *LineChart {
+	res := &LineChart{series: series{options: defaultOptions()}}
+	for _, opt := range opts {
+		opt(&res.options)
+	}
+	res.SetValues(values)
+	return res
+}
+
+// Draw implements Chart
+func (l *LineChart) Draw(grid [][]term.Pixel) {
+	l.clear(grid)
+	lo, hi := l.scale(l.values)
+	left, top, width, height := l.axes(grid, lo, hi, 0)
+	if width <= 0 || height <= 0 || len(l.values) == 0 {
+		return
+	}
+	c := canvas.New(term.Size{Columns: width, Rows: height}, canvas.WithMode(l.mode))
+	point := func(idx int) (int, int, float64) {
+		x := 0
+		if len(l.values) > 1 {
+			x = int(math.Round(float64(idx*(c.Width()-1)) / float64(len(l.values)-1)))
+		}
+		lv := level(l.values[idx], lo, hi)
+		return x, int(math.Round((1 - lv) * float64(c.Height()-1))), lv
+	}
+	x0, y0, lv := point(0)
+	c.SetColor(l.color(lv))
+	c.SetPoint(x0, y0)
+	for idx := 1; idx < len(l.values); idx++ {
+		x1, y1, lv := point(idx)
+		c.SetColor(l.color(lv))
+		c.Line(x0, y0, x1, y1)
+		x0, y0 = x1, y1
+	}
+	for column := 0; column < width; column++ {
+		for row := 0; row < height; row++ {
+			if r, fg := c.Cell(column, row); r != ' ' {
+				l.set(grid, left+column, top+row, r, fg)
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package chart_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/chart"
+	"github.com/badu/term/color"
+)
+
+// rows returns the runes of the grid, row by row
+func rows(grid [][]term.Pixel) []string {
+	var result []string
+	for row := 0; row < len(grid[0]); row++ {
+		var sb strings.Builder
+		for column := range grid {
+			sb.WriteRune(grid[column][row].Rune())
+		}
+		result = append(result, sb.String())
+	}
+	return result
+}
+
+func TestSparkline(t *testing.T) {
+	s := chart.NewSparkline([]float64{0, 1, 2, 3, 4, 5, 6, 7, 8}, chart.WithGradient(color.Green, color.Red))
+	grid, _ := chart.Render(s, term.Size{Columns: 9, Rows: 1})
+	if got := rows(grid)[0]; got != " ▁▂▃▄▅▆▇█" {
+		t.Fatalf("unexpected sparkline %q", got)
+	}
+	if fg, _, _ := grid[8][0].Style(); fg != color.Red {
+		t.Fatalf("expecting highest value red, got %v", fg)
+	}
+
+	s = chart.NewSparkline(nil, chart.WithHistory(3))
+	for v := 1; v <= 5; v++ {
+		s.Push(float64(v))
+	}
+	if values := s.Values(); len(values) != 3 || values[0] != 3 {
+		t.Fatalf("expecting the last 3 values, got %v", values)
+	}
+	grid, _ = chart.Render(s, term.Size{Columns: 4, Rows: 2})
+	if got := rows(grid); got[0] != " ▂▅█" || got[1] != " ███" {
+		t.Fatalf("expecting right aligned two rows sparkline, got %q", got)
+	}
+
+	s = chart.NewSparkline([]float64{0, 2, 4, 8}, chart.WithDisplayer(asciiOnly{}))
+	grid, _ = chart.Render(s, term.Size{Columns: 4, Rows: 1})
+	if got := rows(grid)[0]; got != " .:#" {
+		t.Fatalf("expecting an ASCII sparkline, got %q", got)
+	}
+}
+
+// asciiOnly is a draw.Displayer of a terminal which can't display anything above ASCII
+type asciiOnly struct{}
+
+func (asciiOnly) CanDisplay(r rune, checkFallbacks bool) bool { return r < 0x80 }
+
+func TestBarChart(t *testing.T) {
+	b := chart.NewBarChart([]float64{10, 5}, chart.WithBarWidth(2, 1), chart.WithLabels("a", "bc"))
+	grid, _ := chart.Render(b, term.Size{Columns: 8, Rows: 5})
+	want := []string{
+		"10┤██   ",
+		"  │██ ▄▄",
+		" 0┤██ ██",
+		"  └─────",
+		"   a  bc",
+	}
+	if got := rows(grid); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected bar chart :\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestLineChart(t *testing.T) {
+	l := chart.NewLineChart([]float64{0, 1}, chart.WithAxes(false))
+	grid, _ := chart.Render(l, term.Size{Columns: 2, Rows: 1})
+	// a diagonal from bottom left to top right
+	if got := rows(grid)[0]; got != "⣀⠔" && got != "⡠⠊" {
+		t.Fatalf("unexpected line %q", got)
+	}
+}
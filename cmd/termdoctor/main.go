@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/core"
+	"github.com/badu/term/encoding"
+	"github.com/badu/term/geom"
+	"github.com/badu/term/key"
+	initLog "github.com/badu/term/log"
+	"github.com/badu/term/runewidth"
+	"github.com/badu/term/style"
+)
+
+// environment variables which are relevant for bug reports
+var envNames = []string{"TERM", "COLORTERM", "TERM_PROGRAM", "TERM_PROGRAM_VERSION", "LANG", "LC_ALL", "LC_CTYPE", "NO_COLOR", "CLICOLOR", "CLICOLOR_FORCE", "FORCE_COLOR"}
+
+// attributes rendered by the test pattern
+var attrs = []style.Mask{style.Bold, style.Dim, style.Italic, style.Underline, style.Blink, style.Reverse, style.StrikeThrough}
+
+// printReport writes what was detected about the terminal, so it can be pasted into a bug report
+func printReport(w io.Writer, engine term.Engine) {
+	fmt.Fprintln(w, "Environment")
+	for _, name := range envNames {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			value = "(not set)"
+		}
+		fmt.Fprintf(w, "  %-22s %s\n", name, value)
+	}
+
+	fmt.Fprintln(w, "Capabilities")
+	fmt.Fprintf(w, "  %-22s %s\n", "character set", engine.CharacterSet())
+	fmt.Fprintf(w, "  %-22s %d\n", "colors", engine.NumColors())
+	fmt.Fprintf(w, "  %-22s %t\n", "true color", engine.HasTrueColor())
+	fmt.Fprintf(w, "  %-22s %s\n", "color profile", engine.ColorProfile())
+	fmt.Fprintf(w, "  %-22s %t\n", "mouse", engine.HasMouse())
+	fmt.Fprintf(w, "  %-22s %s\n", "attributes", engine.Attributes())
+	if cw, ok := engine.(term.CapabilityWriter); ok {
+		var found []string
+		for _, name := range []string{"smcup", "civis", "cup", "el", "ed", "il1", "dl1", "ich1", "dch1", "bold", "dim", "sitm", "smul", "blink", "rev", "smxx", "flash", "bel"} {
+			if cw.HasCapability(name) {
+				found = append(found, name)
+			}
+		}
+		fmt.Fprintf(w, "  %-22s %s\n", "capabilities", strings.Join(found, " "))
+	}
+
+	var found, missing []string
+	for k := key.Up; k <= key.F12; k++ {
+		if engine.KeyDispatcher().HasKey(k) {
+			found = append(found, k.String())
+		} else {
+			missing = append(missing, k.String())
+		}
+	}
+	fmt.Fprintln(w, "Keys")
+	fmt.Fprintf(w, "  %-22s %s\n", "found", strings.Join(found, " "))
+	fmt.Fprintf(w, "  %-22s %s\n", "missing", strings.Join(missing, " "))
+}
+
+// printDegradations writes why the test patterns weren't rendered as requested
+func printDegradations(w io.Writer, engine term.Engine) {
+	reporter, ok := engine.(term.DegradationReporter)
+	if !ok {
+		return
+	}
+	fmt.Fprintln(w, "Degradations")
+	degradations := reporter.Degradations()
+	if len(degradations) == 0 {
+		fmt.Fprintln(w, "  (none)")
+	}
+	for _, d := range degradations {
+		fmt.Fprintf(w, "  %s (%d times)\n", d.Reason, d.Count)
+	}
+}
+
+type listener struct {
+	incomingMouse  chan term.MouseEvent  // We need a channel on which we will listen for incoming events
+	incomingKey    chan term.KeyEvent    // We need a channel on which we will listen for incoming events
+	incomingResize chan term.ResizeEvent // We need a channel on which we will listen for incoming events
+	died           chan struct{}         // this is a buffered channel of size one
+	engine         term.Engine           //
+	refs           [][]term.Pixel        // the screen, by column and row
+	size           *term.Size            // the screen size
+}
+
+func (r *listener) MouseListen() chan term.MouseEvent {
+	return r.incomingMouse
+}
+
+func (r *listener) KeyListen() chan term.KeyEvent {
+	return r.incomingKey
+}
+
+func (r *listener) ResizeListen() chan term.ResizeEvent {
+	return r.incomingResize
+}
+
+func (r *listener) DyingChan() chan struct{} {
+	return r.died
+}
+
+// set changes a pixel, ignoring the positions outside the screen
+func (r *listener) set(column, row int, ch rune, fg, bg color.Color, attrs style.Mask) {
+	if column < 0 || row < 0 || column >= r.size.Columns || row >= r.size.Rows {
+		return
+	}
+	r.refs[column][row].SetAll(bg, fg, attrs, ch, nil)
+}
+
+// emitStr writes a string starting with the position, returning the column where it ended
+func (r *listener) emitStr(column, row int, st *style.Style, str string) int {
+	for _, c := range str {
+		w := runewidth.RuneWidth(c)
+		if w == 0 {
+			c = encoding.Space
+			w = 1
+		}
+		r.set(column, row, c, st.Fg, st.Bg, st.Attrs)
+		column += w
+	}
+	return column
+}
+
+// emitLine writes a string, then clears the rest of the row
+func (r *listener) emitLine(column, row int, st *style.Style, str string) {
+	for column = r.emitStr(column, row, st, str); column < r.size.Columns; column++ {
+		r.set(column, row, encoding.Space, color.Reset, color.Reset, style.None)
+	}
+}
+
+// drawPatterns renders the color and attributes test patterns, returning the first row below them
+func (r *listener) drawPatterns() int {
+	normal := style.NewStyle()
+	header := style.NewStyle(style.WithBold(true))
+
+	r.emitLine(0, 0, header, fmt.Sprintf("termdoctor - TERM=%s, %dx%d. Press Esc twice or Ctrl+C to exit.", os.Getenv("TERM"), r.size.Columns, r.size.Rows))
+
+	row := 2
+	r.emitLine(0, row, normal, "16 colors")
+	for i := 0; i < 16; i++ {
+		r.set(12+i*2, row, encoding.Space, color.Reset, color.PaletteColor(i), style.None)
+		r.set(13+i*2, row, encoding.Space, color.Reset, color.PaletteColor(i), style.None)
+	}
+	row++
+
+	if r.engine.NumColors() >= 256 {
+		r.emitLine(0, row, normal, "256 colors")
+		for i := 16; i < 256; i++ {
+			idx := i - 16
+			r.set(12+idx%48, row+idx/48, encoding.Space, color.Reset, color.PaletteColor(i), style.None)
+		}
+		row += 5
+	}
+
+	r.emitLine(0, row, normal, "true color")
+	width := r.size.Columns - 13
+	for i := 0; i < width; i++ {
+		v := int32(i * 255 / width)
+		r.set(12+i, row, encoding.Space, color.Reset, color.NewRGBColor(v, 0, 255-v), style.None)
+	}
+	row++
+
+	r.emitLine(0, row, normal, "attributes")
+	column := 12
+	for _, attr := range attrs {
+		column = r.emitStr(column, row, style.NewStyle(style.WithAttrs(attr)), attr.String())
+		column = r.emitStr(column, row, normal, " ")
+	}
+	return row + 2
+}
+
+// lifeCycle echoes the events, until the user asks to quit
+func (r *listener) lifeCycle(ctx context.Context, cancel func()) {
+	go func() {
+		normal := style.NewStyle()
+		escapeCount := 0
+		keyInfo, mouseInfo := "(press a key)", "(move or click)"
+		if !r.engine.HasMouse() {
+			mouseInfo = "(not supported)"
+		}
+
+		redraw := func() {
+			var getters []term.PixelGetter
+			r.size = r.engine.Size()
+			r.refs, getters = geom.NewPixelGrid(r.size)
+			r.engine.ActivePixels(getters)
+		}
+		redraw()
+		echoRow := r.drawPatterns()
+		for {
+			r.emitLine(0, echoRow, normal, "key      "+keyInfo)
+			r.emitLine(0, echoRow+1, normal, "mouse    "+mouseInfo)
+
+			select {
+			case <-ctx.Done():
+				r.died <- struct{}{}
+				return
+			case <-r.engine.InterruptChan():
+				log.Println("[doctor] interrupted")
+				cancel()
+			case ev := <-r.incomingKey:
+				if ev.Key() == key.Escape {
+					escapeCount++
+					if escapeCount > 1 {
+						cancel()
+					}
+				} else {
+					escapeCount = 0
+				}
+				keyInfo = fmt.Sprintf("name=%s key=%d rune=%q mod=%s", ev.Name(), ev.Key(), ev.Rune(), ev.ModName())
+				log.Printf("[doctor] key : %s", keyInfo)
+			case ev := <-r.incomingMouse:
+				x, y := ev.Position()
+				mouseInfo = fmt.Sprintf("x=%d y=%d buttons=%s mod=%s", x, y, ev.ButtonNames(), ev.ModName())
+			case ev := <-r.incomingResize:
+				log.Printf("[doctor] resize : %dx%d", ev.Size().Columns, ev.Size().Rows)
+				redraw()
+				echoRow = r.drawPatterns()
+			}
+		}
+	}()
+}
+
+func NewReceiver(ctx context.Context, engine term.Engine, cancel func()) *listener {
+	receiver := &listener{
+		died:           make(chan struct{}),        // init of died channel, a buffered channel of exactly one
+		incomingMouse:  make(chan term.MouseEvent), // init of incoming channel
+		incomingKey:    make(chan term.KeyEvent),   // init of incoming channel
+		incomingResize: make(chan term.ResizeEvent),
+		engine:         engine,
+	}
+	receiver.lifeCycle(ctx, cancel)
+	return receiver
+}
+
+// go run main.go -report
+var reportOnly = flag.Bool("report", false, "print the detected terminal and capabilities, without the interactive test")
+
+func main() {
+	flag.Parse()
+	encoding.Register()
+	initLog.InitLogger()
+
+	engine, err := core.NewCore(os.Getenv("TERM"), core.WithDiagnostics(true), core.WithFinalizer(func() {
+		log.Println("[doctor] core finalizer called")
+	}))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error : %v\n", err)
+		os.Exit(1)
+	}
+
+	printReport(os.Stdout, engine)
+	if *reportOnly {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := engine.Start(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "error : %v\n", err)
+		os.Exit(1)
+	}
+
+	receiver := NewReceiver(ctx, engine, cancel)
+	engine.KeyDispatcher().Register(receiver)
+	engine.ResizeDispatcher().Register(receiver)
+	if engine.HasMouse() {
+		engine.MouseDispatcher().Register(receiver)
+	}
+
+	<-engine.DyingChan()
+	log.Println("[doctor] done.")
+	printDegradations(os.Stdout, engine)
+}
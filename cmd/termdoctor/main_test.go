@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/geom"
+	"github.com/badu/term/key"
+	"github.com/badu/term/style"
+)
+
+// fakeEngine answers what the report asks, the rest of term.Engine being unused
+type fakeEngine struct {
+	term.Engine
+	degradations []term.Degradation
+}
+
+type fakeKeyDispatcher struct{ term.KeyDispatcher }
+
+func (fakeKeyDispatcher) HasKey(k term.Key) bool { return k == key.Up || k == key.F1 }
+
+func (e *fakeEngine) CharacterSet() string                   { return "UTF-8" }
+func (e *fakeEngine) NumColors() int                         { return 256 }
+func (e *fakeEngine) HasTrueColor() bool                     { return false }
+func (e *fakeEngine) ColorProfile() term.ColorProfile        { return term.ProfileANSI256 }
+func (e *fakeEngine) HasMouse() bool                         { return true }
+func (e *fakeEngine) Attributes() style.Mask                 { return style.Bold | style.Underline }
+func (e *fakeEngine) KeyDispatcher() term.KeyDispatcher      { return fakeKeyDispatcher{} }
+func (e *fakeEngine) HasCapability(name string) bool         { return name == "cup" || name == "sitm" }
+func (e *fakeEngine) PutCapability(string, ...int) error     { return nil }
+func (e *fakeEngine) Degradations() []term.Degradation       { return e.degradations }
+func (e *fakeEngine) ResetDegradations()                     {}
+func (e *fakeEngine) Size() *term.Size                       { return term.NewSize(80, 24) }
+func (e *fakeEngine) ActivePixels(pixels []term.PixelGetter) {}
+
+func TestPrintReport(t *testing.T) {
+	for name, value := range map[string]string{"TERM": "xterm-256color", "COLORTERM": ""} {
+		previous, wasSet := os.LookupEnv(name)
+		if value == "" {
+			_ = os.Unsetenv(name)
+		} else {
+			_ = os.Setenv(name, value)
+		}
+		defer func(name string) {
+			if wasSet {
+				_ = os.Setenv(name, previous)
+				return
+			}
+			_ = os.Unsetenv(name)
+		}(name)
+	}
+
+	out := &bytes.Buffer{}
+	printReport(out, &fakeEngine{})
+	report := out.String()
+	for _, expected := range []string{
+		"  TERM                   xterm-256color\n",
+		"  COLORTERM              (not set)\n",
+		"  colors                 256\n",
+		"  true color             false\n",
+		"  color profile          " + term.ProfileANSI256.String() + "\n",
+		"  attributes             " + (style.Bold | style.Underline).String() + "\n",
+		"  capabilities           cup sitm\n",
+		"  found                  Up F1\n",
+	} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("error : expecting %q in the report, got\n%s", expected, report)
+		}
+	}
+	if strings.Contains(report, "  missing                Up") {
+		t.Errorf("error : the found keys should not be missing, got\n%s", report)
+	}
+}
+
+func TestPrintDegradations(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		degradations []term.Degradation
+		expected     string
+	}{
+		{name: "none", expected: "Degradations\n  (none)\n"},
+		{
+			name:         "recorded",
+			degradations: []term.Degradation{{Reason: "italic unsupported on this terminal, dropped", Count: 3}},
+			expected:     "Degradations\n  italic unsupported on this terminal, dropped (3 times)\n",
+		},
+	} {
+		out := &bytes.Buffer{}
+		printDegradations(out, &fakeEngine{degradations: tc.degradations})
+		if out.String() != tc.expected {
+			t.Errorf("error : %s : expecting %q, got %q", tc.name, tc.expected, out.String())
+		}
+	}
+}
+
+func TestEmitLine(t *testing.T) {
+	size := term.NewSize(8, 2)
+	refs, _ := geom.NewPixelGrid(size)
+	r := &listener{engine: &fakeEngine{}, refs: refs, size: size}
+
+	bold := style.NewStyle(style.WithBold(true))
+	for column := range refs {
+		refs[column][1].SetAll(color.Default, color.Default, style.None, 'x', nil)
+	}
+	r.emitLine(2, 1, bold, "ab́cdefgh") // the combining accent takes a column, the runes past the screen being ignored
+	expected := []rune("xxab cde")
+	for column := range refs {
+		pixel := refs[column][1]
+		if pixel.Rune() != expected[column] {
+			t.Errorf("error : column %d : expecting %q, got %q", column, expected[column], pixel.Rune())
+		}
+		if _, _, attrs := pixel.Style(); column >= 2 && attrs != style.Bold {
+			t.Errorf("error : column %d should be bold", column)
+		}
+	}
+
+	// the rest of the row is cleared
+	r.emitLine(0, 1, bold, "ab")
+	if got := refs[5][1].Rune(); got != ' ' {
+		t.Errorf("error : the rest of the row should be cleared, got %q", got)
+	}
+	if got := r.emitStr(7, 0, bold, "wide 世"); got != 14 {
+		t.Errorf("error : expecting the column after the wide rune, got %d", got)
+	}
+}
+
+func TestDrawPatterns(t *testing.T) {
+	size := term.NewSize(80, 24)
+	refs, _ := geom.NewPixelGrid(size)
+	r := &listener{engine: &fakeEngine{}, refs: refs, size: size}
+
+	// 256 colors : the header, a blank row, the 16 colors, five rows of 256 colors, the true color and the attributes rows, then a blank row
+	if row := r.drawPatterns(); row != 11 {
+		t.Errorf("error : expecting the patterns to end on row 11, got %d", row)
+	}
+	if _, bg, _ := refs[12][2].Style(); bg != color.PaletteColor(0) {
+		t.Errorf("error : the 16 colors should start on column 12, got %v", bg)
+	}
+	if _, bg, _ := refs[12][3].Style(); bg != color.PaletteColor(16) {
+		t.Errorf("error : the 256 colors should start below the 16 colors, got %v", bg)
+	}
+	if _, _, attrs := refs[12][9].Style(); attrs != style.Bold {
+		t.Errorf("error : the attributes should start with bold, got %v", attrs)
+	}
+}
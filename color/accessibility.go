@@ -0,0 +1,154 @@
+package color
+
+// WCAG 2 minimum contrast ratios
+const (
+	ContrastAALarge = 3.0 // AA level, for large text (or bold)
+	ContrastAA      = 4.5 // AA level, for normal text
+	ContrastAAA     = 7.0 // AAA level, for normal text
+)
+
+// Deficiency is a type of color blindness, used for simulating how colors are perceived
+type Deficiency int
+
+const (
+	Protanopia   Deficiency = iota // no red cones
+	Deuteranopia                   // no green cones
+	Tritanopia                     // no blue cones
+)
+
+// Stringer implementation
+func (d Deficiency) String() string {
+	switch d {
+	case Protanopia:
+		return "protanopia"
+	case Deuteranopia:
+		return "deuteranopia"
+	case Tritanopia:
+		return "tritanopia"
+	default:
+		return "unknown"
+	}
+}
+
+// deficiencyMatrices are the full severity matrices from Machado, Oliveira and Fernandes (2009), applied in linear RGB
+var deficiencyMatrices = map[Deficiency][3][3]float64{
+	Protanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	Deuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	Tritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+// toRGB converts a palette or RGB color into the RGB space. Default, Reset and invalid colors return false.
+func toRGB(c Color) (RGB, bool) {
+	if !Valid(c) || c&Special != 0 {
+		return RGB{}, false
+	}
+	r, g, b := ToRGB(c)
+	if r < 0 {
+		return RGB{}, false
+	}
+	return RGB{R: float64(r) / 255.0, G: float64(g) / 255.0, B: float64(b) / 255.0}, true
+}
+
+// fromRGB converts back into an RGB Color
+func fromRGB(c RGB) Color {
+	r, g, b := RGB255(NewRGBFromClamped(c))
+	return NewRGBColor(int32(r), int32(g), int32(b))
+}
+
+// RelativeLuminance returns the WCAG relative luminance of the color, from 0 (black) to 1 (white). Unknown colors (e.g. Default) return false.
+func RelativeLuminance(c Color) (float64, bool) {
+	rgb, ok := toRGB(c)
+	if !ok {
+		return 0, false
+	}
+	r, g, b := ToLinearRGB(rgb)
+	return 0.2126*r + 0.7152*g + 0.0722*b, true
+}
+
+// ContrastRatio returns the WCAG contrast ratio between two colors, from 1 (no contrast) to 21 (black on white).
+// Since the terminal default colors are unknown, the ratio is 1 if any of them is not a palette or RGB color.
+func ContrastRatio(c1, c2 Color) float64 {
+	l1, ok1 := RelativeLuminance(c1)
+	l2, ok2 := RelativeLuminance(c2)
+	if !ok1 || !ok2 {
+		return 1
+	}
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+	return (l1 + 0.05) / (l2 + 0.05)
+}
+
+// ContrastingTextColor returns Black or White, whichever is more readable on the background. Default is returned for unknown backgrounds.
+func ContrastingTextColor(bg Color) Color {
+	if _, ok := RelativeLuminance(bg); !ok {
+		return Default
+	}
+	if ContrastRatio(Black, bg) >= ContrastRatio(White, bg) {
+		return Black
+	}
+	return White
+}
+
+// EnsureContrast returns fg unchanged if it reaches the ratio against bg, otherwise fg is blended (in Lab space) towards
+// the contrasting text color, until the ratio is reached. Unknown colors are returned unchanged.
+func EnsureContrast(fg, bg Color, ratio float64) Color {
+	if ContrastRatio(fg, bg) >= ratio {
+		return fg
+	}
+	from, ok := toRGB(fg)
+	if !ok {
+		return fg
+	}
+	target := ContrastingTextColor(bg)
+	to, ok := toRGB(target)
+	if !ok {
+		return fg
+	}
+	for step := 1; step < 20; step++ {
+		candidate := fromRGB(NewRGBFromBlendLab(from, to, float64(step)/20))
+		if ContrastRatio(candidate, bg) >= ratio {
+			return candidate
+		}
+	}
+	return TrueColor(target)
+}
+
+// Simulate returns how the color is perceived by a person having the deficiency. Unknown colors are returned unchanged.
+func Simulate(c Color, d Deficiency) Color {
+	m, ok := deficiencyMatrices[d]
+	if !ok {
+		return c
+	}
+	rgb, ok := toRGB(c)
+	if !ok {
+		return c
+	}
+	r, g, b := ToLinearRGB(rgb)
+	return fromRGB(NewRGBFromLinearRGB(
+		clamp01(m[0][0]*r+m[0][1]*g+m[0][2]*b),
+		clamp01(m[1][0]*r+m[1][1]*g+m[1][2]*b),
+		clamp01(m[2][0]*r+m[2][1]*g+m[2][2]*b),
+	))
+}
+
+// SimulatePalette returns the palette as perceived by a person having the deficiency, e.g. to check that theme colors remain distinguishable
+func SimulatePalette(palette []Color, d Deficiency) []Color {
+	result := make([]Color, len(palette))
+	for idx, c := range palette {
+		result[idx] = Simulate(c, d)
+	}
+	return result
+}
@@ -0,0 +1,53 @@
+package color_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/badu/term/color"
+)
+
+func TestContrast(t *testing.T) {
+	if ratio := color.ContrastRatio(color.Black, color.White); math.Abs(ratio-21) > 0.01 {
+		t.Fatalf("error : black on white should be 21, got %f", ratio)
+	}
+	if ratio := color.ContrastRatio(color.Red, color.Default); ratio != 1 {
+		t.Fatalf("error : unknown colors should have no contrast, got %f", ratio)
+	}
+	if c := color.ContrastingTextColor(color.Yellow); c != color.Black {
+		t.Fatalf("error : expecting black on yellow, got %s", c)
+	}
+	if c := color.ContrastingTextColor(color.Navy); c != color.White {
+		t.Fatalf("error : expecting white on navy, got %s", c)
+	}
+	gray := color.NewRGBColor(120, 120, 120)
+	fg := color.EnsureContrast(color.NewRGBColor(140, 140, 140), gray, color.ContrastAA)
+	if ratio := color.ContrastRatio(fg, gray); ratio < color.ContrastAA {
+		t.Fatalf("error : expecting at least %f, got %f", color.ContrastAA, ratio)
+	}
+	if fg := color.EnsureContrast(color.White, color.Black, color.ContrastAAA); fg != color.White {
+		t.Fatalf("error : enough contrast should not change the color, got %s", fg)
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	red, green := color.NewRGBColor(255, 0, 0), color.NewRGBColor(0, 128, 0)
+	for _, d := range []color.Deficiency{color.Protanopia, color.Deuteranopia} {
+		before := color.DistanceCIE76(rgb(red), rgb(green))
+		after := color.DistanceCIE76(rgb(color.Simulate(red, d)), rgb(color.Simulate(green, d)))
+		if after >= before {
+			t.Fatalf("error : %s should make red and green harder to tell apart", d)
+		}
+	}
+	if c := color.Simulate(color.NewRGBColor(128, 128, 128), color.Tritanopia); c != color.NewRGBColor(128, 128, 128) {
+		t.Fatalf("error : grays should not change, got %s", c)
+	}
+	if c := color.Simulate(color.Default, color.Protanopia); c != color.Default {
+		t.Fatal("error : default color should not change")
+	}
+}
+
+func rgb(c color.Color) color.RGB {
+	r, g, b := color.ToRGB(c)
+	return color.RGB{R: float64(r) / 255, G: float64(g) / 255, B: float64(b) / 255}
+}
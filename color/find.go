@@ -35,3 +35,16 @@ func FindColor(c Color, palette []Color) Color {
 	}
 	return match
 }
+
+// FindIndexColor is a cheap alternative to FindColor : palette (named) colors are returned as they are, if the palette
+// can hold them, while everything else (RGB colors included) resolves to Default, leaving the terminal defaults in place.
+func FindIndexColor(c Color, palette []Color) Color {
+	if c&valid == 0 || c&isRGB != 0 {
+		return Default
+	}
+	idx := int(c &^ valid)
+	if idx < 0 || idx >= len(palette) {
+		return Default
+	}
+	return palette[idx]
+}
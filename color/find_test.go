@@ -0,0 +1,42 @@
+package color_test
+
+import (
+	"testing"
+
+	"github.com/badu/term/color"
+)
+
+func TestFindIndexColor(t *testing.T) {
+	palette := []color.Color{color.Black, color.Maroon, color.Green, color.Olive}
+	for _, tc := range []struct {
+		name     string
+		c        color.Color
+		expected color.Color
+	}{
+		{name: "in palette", c: color.Maroon, expected: color.Maroon},
+		{name: "outside palette", c: color.Blue, expected: color.Default},
+		{name: "rgb", c: color.NewRGBColor(0x80, 0, 0), expected: color.Default},
+		{name: "default", c: color.Default, expected: color.Default},
+	} {
+		if got := color.FindIndexColor(tc.c, palette); got != tc.expected {
+			t.Errorf("error : %s : expecting %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestFindColor(t *testing.T) {
+	palette := []color.Color{color.Black, color.Maroon, color.Green, color.Olive}
+	for _, tc := range []struct {
+		name     string
+		c        color.Color
+		expected color.Color
+	}{
+		{name: "in palette", c: color.Green, expected: color.Green},
+		{name: "nearest", c: color.NewRGBColor(0x90, 0x10, 0x08), expected: color.Maroon},
+		{name: "dark", c: color.NewRGBColor(0x08, 0x08, 0x08), expected: color.Black},
+	} {
+		if got := color.FindColor(tc.c, palette); got != tc.expected {
+			t.Errorf("error : %s : expecting %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+//go:build ignore
+// +build ignore
+
+// This program generates tables.go : the palette and W3C colors constants, with their values and names.
+// Run it with "go generate" inside the color package.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+)
+
+type namedColor struct {
+	ident string // the Go constant
+	name  string // the W3C name
+	hex   int32  // the RGB value
+}
+
+// ansi are the first 16 colors of the palette, as defined by ECMA-48
+var ansi = []namedColor{
+	{"Black", "black", 0x000000},
+	{"Maroon", "maroon", 0x800000},
+	{"Green", "green", 0x008000},
+	{"Olive", "olive", 0x808000},
+	{"Navy", "navy", 0x000080},
+	{"Purple", "purple", 0x800080},
+	{"Teal", "teal", 0x008080},
+	{"Silver", "silver", 0xC0C0C0},
+	{"Gray", "gray", 0x808080},
+	{"Red", "red", 0xFF0000},
+	{"Lime", "lime", 0x00FF00},
+	{"Yellow", "yellow", 0xFFFF00},
+	{"Blue", "blue", 0x0000FF},
+	{"Fuchsia", "fuchsia", 0xFF00FF},
+	{"Aqua", "aqua", 0x00FFFF},
+	{"White", "white", 0xFFFFFF},
+}
+
+// w3c are the W3C approved colors which are not in the ansi list, following the palette (starting with 256)
+var w3c = []namedColor{
+	{"AliceBlue", "aliceblue", 0xF0F8FF},
+	{"AntiqueWhite", "antiquewhite", 0xFAEBD7},
+	{"AquaMarine", "aquamarine", 0x7FFFD4},
+	{"Azure", "azure", 0xF0FFFF},
+	{"Beige", "beige", 0xF5F5DC},
+	{"Bisque", "bisque", 0xFFE4C4},
+	{"BlanchedAlmond", "blanchedalmond", 0xFFEBCD},
+	{"BlueViolet", "blueviolet", 0x8A2BE2},
+	{"Brown", "brown", 0xA52A2A},
+	{"BurlyWood", "burlywood", 0xDEB887},
+	{"CadetBlue", "cadetblue", 0x5F9EA0},
+	{"Chartreuse", "chartreuse", 0x7FFF00},
+	{"Chocolate", "chocolate", 0xD2691E},
+	{"Coral", "coral", 0xFF7F50},
+	{"CornflowerBlue", "cornflowerblue", 0x6495ED},
+	{"CornSilk", "cornsilk", 0xFFF8DC},
+	{"Crimson", "crimson", 0xDC143C},
+	{"DarkBlue", "darkblue", 0x00008B},
+	{"DarkCyan", "darkcyan", 0x008B8B},
+	{"DarkGoldenrod", "darkgoldenrod", 0xB8860B},
+	{"DarkGray", "darkgray", 0xA9A9A9},
+	{"DarkGreen", "darkgreen", 0x006400},
+	{"DarkKhaki", "darkkhaki", 0xBDB76B},
+	{"DarkMagenta", "darkmagenta", 0x8B008B},
+	{"DarkOliveGreen", "darkolivegreen", 0x556B2F},
+	{"DarkOrange", "darkorange", 0xFF8C00},
+	{"DarkOrchid", "darkorchid", 0x9932CC},
+	{"DarkRed", "darkred", 0x8B0000},
+	{"DarkSalmon", "darksalmon", 0xE9967A},
+	{"DarkSeaGreen", "darkseagreen", 0x8FBC8F},
+	{"DarkSlateBlue", "darkslateblue", 0x483D8B},
+	{"DarkSlateGray", "darkslategray", 0x2F4F4F},
+	{"DarkTurquoise", "darkturquoise", 0x00CED1},
+	{"DarkViolet", "darkviolet", 0x9400D3},
+	{"DeepPink", "deeppink", 0xFF1493},
+	{"DeepSkyBlue", "deepskyblue", 0x00BFFF},
+	{"DimGray", "dimgray", 0x696969},
+	{"DodgerBlue", "dodgerblue", 0x1E90FF},
+	{"FireBrick", "firebrick", 0xB22222},
+	{"FloralWhite", "floralwhite", 0xFFFAF0},
+	{"ForestGreen", "forestgreen", 0x228B22},
+	{"GainsBoro", "gainsboro", 0xDCDCDC},
+	{"GhostWhite", "ghostwhite", 0xF8F8FF},
+	{"Gold", "gold", 0xFFD700},
+	{"Goldenrod", "goldenrod", 0xDAA520},
+	{"GreenYellow", "greenyellow", 0xADFF2F},
+	{"Honeydew", "honeydew", 0xF0FFF0},
+	{"HotPink", "hotpink", 0xFF69B4},
+	{"IndianRed", "indianred", 0xCD5C5C},
+	{"Indigo", "indigo", 0x4B0082},
+	{"Ivory", "ivory", 0xFFFFF0},
+	{"Khaki", "khaki", 0xF0E68C},
+	{"Lavender", "lavender", 0xE6E6FA},
+	{"LavenderBlush", "lavenderblush", 0xFFF0F5},
+	{"LawnGreen", "lawngreen", 0x7CFC00},
+	{"LemonChiffon", "lemonchiffon", 0xFFFACD},
+	{"LightBlue", "lightblue", 0xADD8E6},
+	{"LightCoral", "lightcoral", 0xF08080},
+	{"LightCyan", "lightcyan", 0xE0FFFF},
+	{"LightGoldenrodYellow", "lightgoldenrodyellow", 0xFAFAD2},
+	{"LightGray", "lightgray", 0xD3D3D3},
+	{"LightGreen", "lightgreen", 0x90EE90},
+	{"LightPink", "lightpink", 0xFFB6C1},
+	{"LightSalmon", "lightsalmon", 0xFFA07A},
+	{"LightSeaGreen", "lightseagreen", 0x20B2AA},
+	{"LightSkyBlue", "lightskyblue", 0x87CEFA},
+	{"LightSlateGray", "lightslategray", 0x778899},
+	{"LightSteelBlue", "lightsteelblue", 0xB0C4DE},
+	{"LightYellow", "lightyellow", 0xFFFFE0},
+	{"LimeGreen", "limegreen", 0x32CD32},
+	{"Linen", "linen", 0xFAF0E6},
+	{"MediumAquamarine", "mediumaquamarine", 0x66CDAA},
+	{"MediumBlue", "mediumblue", 0x0000CD},
+	{"MediumOrchid", "mediumorchid", 0xBA55D3},
+	{"MediumPurple", "mediumpurple", 0x9370DB},
+	{"MediumSeaGreen", "mediumseagreen", 0x3CB371},
+	{"MediumSlateBlue", "mediumslateblue", 0x7B68EE},
+	{"MediumSpringGreen", "mediumspringgreen", 0x00FA9A},
+	{"MediumTurquoise", "mediumturquoise", 0x48D1CC},
+	{"MediumVioletRed", "mediumvioletred", 0xC71585},
+	{"MidnightBlue", "midnightblue", 0x191970},
+	{"MintCream", "mintcream", 0xF5FFFA},
+	{"MistyRose", "mistyrose", 0xFFE4E1},
+	{"Moccasin", "moccasin", 0xFFE4B5},
+	{"NavajoWhite", "navajowhite", 0xFFDEAD},
+	{"OldLace", "oldlace", 0xFDF5E6},
+	{"OliveDrab", "olivedrab", 0x6B8E23},
+	{"Orange", "orange", 0xFFA500},
+	{"OrangeRed", "orangered", 0xFF4500},
+	{"Orchid", "orchid", 0xDA70D6},
+	{"PaleGoldenrod", "palegoldenrod", 0xEEE8AA},
+	{"PaleGreen", "palegreen", 0x98FB98},
+	{"PaleTurquoise", "paleturquoise", 0xAFEEEE},
+	{"PaleVioletRed", "palevioletred", 0xDB7093},
+	{"PapayaWhip", "papayawhip", 0xFFEFD5},
+	{"PeachPuff", "peachpuff", 0xFFDAB9},
+	{"Peru", "peru", 0xCD853F},
+	{"Pink", "pink", 0xFFC0CB},
+	{"Plum", "plum", 0xDDA0DD},
+	{"PowderBlue", "powderblue", 0xB0E0E6},
+	{"RebeccaPurple", "rebeccapurple", 0x663399},
+	{"RosyBrown", "rosybrown", 0xBC8F8F},
+	{"RoyalBlue", "royalblue", 0x4169E1},
+	{"SaddleBrown", "saddlebrown", 0x8B4513},
+	{"Salmon", "salmon", 0xFA8072},
+	{"SandyBrown", "sandybrown", 0xF4A460},
+	{"SeaGreen", "seagreen", 0x2E8B57},
+	{"Seashell", "seashell", 0xFFF5EE},
+	{"Sienna", "sienna", 0xA0522D},
+	{"SkyBlue", "skyblue", 0x87CEEB},
+	{"SlateBlue", "slateblue", 0x6A5ACD},
+	{"SlateGray", "slategray", 0x708090},
+	{"Snow", "snow", 0xFFFAFA},
+	{"SpringGreen", "springgreen", 0x00FF7F},
+	{"SteelBlue", "steelblue", 0x4682B4},
+	{"Tan", "tan", 0xD2B48C},
+	{"Thistle", "thistle", 0xD8BFD8},
+	{"Tomato", "tomato", 0xFF6347},
+	{"Turquoise", "turquoise", 0x40E0D0},
+	{"Violet", "violet", 0xEE82EE},
+	{"Wheat", "wheat", 0xF5DEB3},
+	{"WhiteSmoke", "whitesmoke", 0xF5F5F5},
+	{"YellowGreen", "yellowgreen", 0x9ACD32},
+}
+
+// aliases are the alternate spellings accepted by NewColor : the name and the Go constant
+var aliases = [][2]string{
+	{"grey", "Gray"},
+	{"dimgrey", "DimGray"},
+	{"darkgrey", "DarkGray"},
+	{"darkslategrey", "DarkSlateGray"},
+	{"lightgrey", "LightGray"},
+	{"lightslategrey", "LightSlateGray"},
+	{"slategrey", "SlateGray"},
+}
+
+// xterm returns the RGB value of the palette colors 16 to 255 : a 6x6x6 color cube, followed by a grayscale ramp
+func xterm(index int) int32 {
+	if index >= 232 {
+		v := int32(8 + 10*(index-232))
+		return v<<16 | v<<8 | v
+	}
+	levels := [6]int32{0x00, 0x5F, 0x87, 0xAF, 0xD7, 0xFF}
+	index -= 16
+	return levels[index/36]<<16 | levels[index/6%6]<<8 | levels[index%6]
+}
+
+func main() {
+	colors := make([]namedColor, 0, 256+len(w3c))
+	colors = append(colors, ansi...)
+	for index := 16; index < 256; index++ {
+		colors = append(colors, namedColor{ident: fmt.Sprintf("Noname%d", index), hex: xterm(index)})
+	}
+	colors = append(colors, w3c...)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen_tables.go; DO NOT EDIT.\n\n")
+	buf.WriteString("package color\n\n")
+
+	buf.WriteString("// Note that the order of these options is important -- it follows the definitions used by ECMA and XTerm.\n")
+	buf.WriteString("// Hence any further named colors must begin at a value not less than 256.\n")
+	buf.WriteString("const (\n")
+	for idx, c := range colors {
+		if idx == 0 {
+			fmt.Fprintf(&buf, "\t%s = valid + iota\n", c.ident)
+			continue
+		}
+		fmt.Fprintf(&buf, "\t%s\n", c.ident)
+	}
+	buf.WriteString(")\n\n")
+
+	buf.WriteString("// hexes are the RGB values of the colors above, by their index\n")
+	buf.WriteString("var hexes = [...]int32{\n")
+	for _, c := range colors {
+		fmt.Fprintf(&buf, "\t0x%06X, // %s\n", c.hex, c.ident)
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// names are the W3C names of the colors above, by their index. The palette colors 16 to 255 have no W3C name\n")
+	buf.WriteString("var names = [...]string{\n")
+	for _, c := range colors {
+		fmt.Fprintf(&buf, "\t%q, // %s\n", c.name, c.ident)
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// byName maps the lower case W3C names (and their alternate spellings) to colors\n")
+	buf.WriteString("var byName = map[string]Color{\n")
+	for _, c := range colors {
+		if c.name != "" {
+			fmt.Fprintf(&buf, "\t%q: %s,\n", c.name, c.ident)
+		}
+	}
+	for _, alias := range aliases {
+		fmt.Fprintf(&buf, "\t%q: %s,\n", alias[0], alias[1])
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("error formatting : %v", err)
+	}
+	if err := ioutil.WriteFile("tables.go", src, 0644); err != nil {
+		log.Fatalf("error writing : %v", err)
+	}
+}
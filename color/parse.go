@@ -0,0 +1,128 @@
+package color
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParseColor creates a Color from a CSS like string : a W3C name ("teal"), "default", "reset",
+// a hex value ("#abc", "#aabbcc" or "0xaabbcc"), "rgb(12,34,56)" (components can be percents) or "hsl(120,50%,40%)".
+// The palette colors can be referred as "color<index>" (e.g. "color23", as returned by Color.String) or by their xterm name ("deepskyblue4").
+// The alpha component of "rgba()" and "hsla()" is accepted, but ignored.
+func ParseColor(s string) (Color, error) {
+	spec := strings.ToLower(strings.TrimSpace(s))
+	switch spec {
+	case "":
+		return Default, fmt.Errorf("color: empty color")
+	case "default":
+		return Default, nil
+	case "reset":
+		return Reset, nil
+	}
+	if c, ok := parseNumeric(spec); ok {
+		return c, nil
+	}
+	if c := NewColor(spec); c != Default {
+		return c, nil
+	}
+	if c, ok := parsePaletteName(spec); ok {
+		return c, nil
+	}
+	return Default, fmt.Errorf("color: %q is not a valid color", s)
+}
+
+// parseNumeric parses the forms which are not names - lower case and trimmed string is expected
+func parseNumeric(spec string) (Color, bool) {
+	switch {
+	case strings.HasPrefix(spec, "#"):
+		return parseHex(spec[1:])
+	case strings.HasPrefix(spec, "0x"):
+		return parseHex(spec[2:])
+	case strings.HasPrefix(spec, "rgb"):
+		args, ok := functionArgs(spec, "rgb", "rgba")
+		if !ok {
+			return Default, false
+		}
+		var components [3]int32
+		for idx := range components {
+			v, ok := parseComponent(args[idx], 255)
+			if !ok {
+				return Default, false
+			}
+			components[idx] = int32(math.Round(v))
+		}
+		return NewRGBColor(components[0], components[1], components[2]), true
+	case strings.HasPrefix(spec, "hsl"):
+		args, ok := functionArgs(spec, "hsl", "hsla")
+		if !ok {
+			return Default, false
+		}
+		h, err := strconv.ParseFloat(strings.TrimSuffix(args[0], "deg"), 64)
+		if err != nil || math.IsNaN(h) || math.IsInf(h, 0) {
+			return Default, false
+		}
+		h = math.Mod(math.Mod(h, 360)+360, 360)
+		sat, okS := parseComponent(args[1], 1)
+		light, okL := parseComponent(args[2], 1)
+		if !okS || !okL || !strings.HasSuffix(args[1], "%") || !strings.HasSuffix(args[2], "%") {
+			return Default, false
+		}
+		r, g, b := RGB255(NewRGBFromHSL(h, sat, light))
+		return NewRGBColor(int32(r), int32(g), int32(b)), true
+	}
+	return Default, false
+}
+
+// parseHex parses 3 or 6 hex digits
+func parseHex(digits string) (Color, bool) {
+	if len(digits) != 3 && len(digits) != 6 {
+		return Default, false
+	}
+	v, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return Default, false
+	}
+	if len(digits) == 3 { // each digit is doubled : #abc is #aabbcc
+		r, g, b := v>>8&0xF, v>>4&0xF, v&0xF
+		v = r<<20 | r<<16 | g<<12 | g<<8 | b<<4 | b
+	}
+	return NewHexColor(int32(v)), true
+}
+
+// functionArgs returns the three (or four, the last one being alpha) arguments of "name(a,b,c)" or "alphaName(a,b,c,d)"
+func functionArgs(spec, name, alphaName string) ([]string, bool) {
+	open, end := strings.IndexByte(spec, '('), len(spec)-1
+	if open < 0 || spec[end] != ')' {
+		return nil, false
+	}
+	function := strings.TrimSpace(spec[:open])
+	args := strings.Split(spec[open+1:end], ",")
+	switch {
+	case function == name && len(args) == 3:
+	case function == alphaName && len(args) == 4:
+	default:
+		return nil, false
+	}
+	for idx := range args {
+		args[idx] = strings.TrimSpace(args[idx])
+	}
+	return args, true
+}
+
+// parseComponent parses a finite number in [0..max] or a percent of max
+func parseComponent(arg string, max float64) (float64, bool) {
+	percent := strings.HasSuffix(arg, "%")
+	v, err := strconv.ParseFloat(strings.TrimSuffix(arg, "%"), 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) { // ParseFloat accepts "nan" and "inf", which the range check below can't catch
+		return 0, false
+	}
+	if percent {
+		v = v * max / 100
+	}
+	if v < 0 || v > max {
+		return 0, false
+	}
+	return v, true
+}
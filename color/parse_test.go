@@ -0,0 +1,73 @@
+package color_test
+
+import (
+	"testing"
+
+	"github.com/badu/term/color"
+)
+
+func TestParseColor(t *testing.T) {
+	for spec, want := range map[string]color.Color{
+		"Teal":                  color.Teal,
+		"default":               color.Default,
+		"#abc":                  color.NewHexColor(0xAABBCC),
+		"#0080ff":               color.NewHexColor(0x0080FF),
+		"0x0080FF":              color.NewHexColor(0x0080FF),
+		"rgb(12, 34, 56)":       color.NewRGBColor(12, 34, 56),
+		"rgba(12,34,56,0.5)":    color.NewRGBColor(12, 34, 56),
+		"rgb(100%,0%,50%)":      color.NewRGBColor(255, 0, 128),
+		"hsl(120,100%,25%)":     color.NewRGBColor(0, 128, 0),
+		" HSLA(0, 0%, 100%, 1)": color.NewRGBColor(255, 255, 255),
+	} {
+		got, err := color.ParseColor(spec)
+		if err != nil {
+			t.Fatalf("error parsing %q : %v", spec, err)
+		}
+		if got != want {
+			t.Fatalf("error parsing %q : expecting %s, got %s", spec, want, got)
+		}
+	}
+	for _, spec := range []string{"", "#abcd", "rgb(1,2)", "rgb(256,0,0)", "hsl(10,20,30)", "nocolor", "0xZZZZZZ",
+		"rgb(nan,0,0)", "rgb(0,nan%,0)", "rgb(inf,0,0)", "rgb(0,0,-inf)", "hsl(nan,50%,50%)", "hsl(inf,50%,50%)", "hsl(-infinity,50%,50%)", "hsl(0,nan%,50%)"} {
+		if _, err := color.ParseColor(spec); err == nil {
+			t.Fatalf("error : %q should not be parsed", spec)
+		}
+	}
+	if color.NewColor("#abc") != color.NewHexColor(0xAABBCC) {
+		t.Fatal("error : NewColor should accept short hex")
+	}
+}
+
+func TestAnsi256(t *testing.T) {
+	if c := color.Xterm("DeepSkyBlue4"); c != color.Noname23 {
+		t.Fatalf("error : expecting the first DeepSkyBlue4 (23), got %s", c)
+	}
+	if name := color.XtermName(color.Noname25); name != "DeepSkyBlue4" {
+		t.Fatalf("error : expecting DeepSkyBlue4, got %q", name)
+	}
+	for index := 0; index < 256; index++ {
+		if got, ok := color.Ansi256(color.FromAnsi256(index)); !ok || got != index {
+			t.Fatalf("error : expecting index %d, got %d", index, got)
+		}
+	}
+	for rgb, want := range map[color.Color]int{
+		color.NewRGBColor(0, 0, 0):       16,
+		color.NewRGBColor(255, 255, 255): 231,
+		color.NewRGBColor(0, 95, 135):    24,
+		color.NewRGBColor(128, 128, 128): 244,
+		color.NewRGBColor(250, 10, 10):   196,
+	} {
+		if got, ok := color.Ansi256(rgb); !ok || got != want {
+			t.Fatalf("error : expecting %s to be %d, got %d", rgb, want, got)
+		}
+	}
+	if _, ok := color.Ansi256(color.Default); ok {
+		t.Fatal("error : default color has no index")
+	}
+	if c, err := color.ParseColor(color.Noname100.String()); err != nil || c != color.Noname100 {
+		t.Fatalf("error : palette color should parse back : %v", err)
+	}
+	if c, err := color.ParseColor("grey93"); err != nil || c != color.FromAnsi256(255) {
+		t.Fatalf("error : xterm names should be parsed : %v", err)
+	}
+}
@@ -0,0 +1,265 @@
+package color
+
+import (
+	"math"
+)
+
+/// OKLab ///
+/////////////
+// https://bottosson.github.io/posts/oklab/
+// A perceptual color space, which keeps the hue steady when blending (no purple shift between blue and white, as in Lab).
+
+// ToOkLab converts the given color to OKLab space. L is in [0..1], a and b are in about [-0.4..0.4]
+func ToOkLab(c RGB) (l, a, b float64) {
+	r, g, bl := ToLinearRGB(c)
+	lc := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*bl)
+	mc := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*bl)
+	sc := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*bl)
+	l = 0.2104542553*lc + 0.7936177850*mc - 0.0040720468*sc
+	a = 1.9779984951*lc - 2.4285922050*mc + 0.4505937099*sc
+	b = 0.0259040371*lc + 0.7827717662*mc - 0.8086757660*sc
+	return
+}
+
+// NewRGBFromOkLab generates a color by using data given in OKLab space.
+// WARNING: many combinations of `l`, `a`, and `b` values do not have corresponding valid RGB values, use NewRGBFromClamped if needed.
+func NewRGBFromOkLab(l, a, b float64) RGB {
+	lc := cub(l + 0.3963377774*a + 0.2158037573*b)
+	mc := cub(l - 0.1055613458*a - 0.0638541728*b)
+	sc := cub(l - 0.0894841775*a - 1.2914855480*b)
+	return NewRGBFromLinearRGB(
+		4.0767416621*lc-3.3077115913*mc+0.2309699292*sc,
+		-1.2684380046*lc+2.6097574011*mc-0.3413193965*sc,
+		-0.0041960863*lc-0.7034186147*mc+1.7076147010*sc,
+	)
+}
+
+// DistanceOkLab is the euclidean distance in OKLab space, a good measure of visual similarity : 0 means identical colors
+func DistanceOkLab(c1, c2 RGB) float64 {
+	l1, a1, b1 := ToOkLab(c1)
+	l2, a2, b2 := ToOkLab(c2)
+	return math.Sqrt(sq(l1-l2) + sq(a1-a2) + sq(b1-b2))
+}
+
+// NewRGBFromBlendOkLab blends two colors in the OKLab color-space.
+// t == 0 results in c1, t == 1 results in c2
+func NewRGBFromBlendOkLab(c1, c2 RGB, t float64) RGB {
+	l1, a1, b1 := ToOkLab(c1)
+	l2, a2, b2 := ToOkLab(c2)
+	return NewRGBFromOkLab(l1+t*(l2-l1), a1+t*(a2-a1), b1+t*(b2-b1))
+}
+
+/// OKLCH ///
+/////////////
+// OKLab in cylindrical coordinates
+
+// ToOkLch converts the given color to OKLCH space. L is in [0..1], C is in about [0..0.4] and H is in [0..360]
+func ToOkLch(c RGB) (l, ch, h float64) {
+	l, a, b := ToOkLab(c)
+	ch = math.Sqrt(sq(a) + sq(b))
+	if ch > 1e-7 {
+		h = math.Mod(math.Atan2(b, a)*180/math.Pi+360, 360)
+	}
+	return
+}
+
+// NewRGBFromOkLch generates a color by using data given in OKLCH space.
+func NewRGBFromOkLch(l, ch, h float64) RGB {
+	hr := h * math.Pi / 180
+	return NewRGBFromOkLab(l, ch*math.Cos(hr), ch*math.Sin(hr))
+}
+
+// NewRGBFromBlendOkLch blends two colors in the OKLCH color-space, going the short way around the hue circle.
+// t == 0 results in c1, t == 1 results in c2
+func NewRGBFromBlendOkLch(c1, c2 RGB, t float64) RGB {
+	l1, ch1, h1 := ToOkLch(c1)
+	l2, ch2, h2 := ToOkLch(c2)
+	return NewRGBFromOkLch(l1+t*(l2-l1), ch1+t*(ch2-ch1), interpBetwAng(h1, h2, t))
+}
+
+/// HSLuv ///
+/////////////
+// https://www.hsluv.org
+// HSLuv is CIE LCh(uv) where the chroma is stretched, so every saturation is valid for any hue and lightness.
+// HPLuv keeps the chroma proportional, limiting it to the largest one which is valid for all hues (pastel colors only).
+
+var (
+	hsluvD65 = [3]float64{0.95047, 1.00000, 1.08883} // same white as the other conversions, so grays have no chroma
+	hsluvM   = [3][3]float64{
+		{3.240969941904521, -1.537383177570093, -0.498610760293},
+		{-0.96924363628087, 1.87596750150772, 0.041555057407175},
+		{0.055630079696993, -0.20397695888897, 1.056971514242878},
+	}
+)
+
+const (
+	hsluvKappa   = 903.2962962
+	hsluvEpsilon = 0.0088564516
+)
+
+// hsluvBounds returns the six lines (slope, intercept) which limit the sRGB gamut in the chroma plane, for the lightness (in [0..100])
+func hsluvBounds(l float64) [6][2]float64 {
+	var result [6][2]float64
+	sub1 := math.Pow(l+16, 3) / 1560896
+	sub2 := sub1
+	if sub1 <= hsluvEpsilon {
+		sub2 = l / hsluvKappa
+	}
+	for c := 0; c < 3; c++ {
+		m1, m2, m3 := hsluvM[c][0], hsluvM[c][1], hsluvM[c][2]
+		for t := 0; t < 2; t++ {
+			top1 := (284517*m1 - 94839*m3) * sub2
+			top2 := (838422*m3+769860*m2+731718*m1)*l*sub2 - 769860*float64(t)*l
+			bottom := (632260*m3-126452*m2)*sub2 + 126452*float64(t)
+			result[c*2+t] = [2]float64{top1 / bottom, top2 / bottom}
+		}
+	}
+	return result
+}
+
+// hsluvMaxChroma returns the largest valid chroma for the lightness and hue
+func hsluvMaxChroma(l, h float64) float64 {
+	hr := h * math.Pi / 180
+	result := math.MaxFloat64
+	for _, line := range hsluvBounds(l) {
+		length := line[1] / (math.Sin(hr) - line[0]*math.Cos(hr))
+		if length >= 0 && length < result {
+			result = length
+		}
+	}
+	return result
+}
+
+// hpluvMaxChroma returns the largest chroma which is valid for the lightness, whatever the hue
+func hpluvMaxChroma(l float64) float64 {
+	result := math.MaxFloat64
+	for _, line := range hsluvBounds(l) {
+		distance := math.Abs(line[1]) / math.Sqrt(sq(line[0])+1)
+		if distance < result {
+			result = distance
+		}
+	}
+	return result
+}
+
+// toLuvLCh returns the CIE LCh(uv) of the color, with L and C in [0..100] as used by HSLuv
+func toLuvLCh(c RGB) (l, ch, h float64) {
+	l, u, v := ToLuvWhiteRef(c, hsluvD65)
+	l, u, v = l*100, u*100, v*100
+	ch = math.Sqrt(sq(u) + sq(v))
+	if ch > 1e-8 {
+		h = math.Mod(math.Atan2(v, u)*180/math.Pi+360, 360)
+	}
+	return
+}
+
+// fromLuvLCh generates a color from CIE LCh(uv), with L and C in [0..100]
+func fromLuvLCh(l, ch, h float64) RGB {
+	hr := h * math.Pi / 180
+	return NewRGBFromLuvWhiteRef(l/100, ch*math.Cos(hr)/100, ch*math.Sin(hr)/100, hsluvD65)
+}
+
+// ToHSLuv converts the given color to HSLuv space. H is in [0..360], S and L are in [0..1]
+func ToHSLuv(c RGB) (h, s, l float64) {
+	return toLuvSpace(c, true)
+}
+
+// NewRGBFromHSLuv generates a color by using data given in HSLuv space : H in [0..360], S and L in [0..1]. Every combination is a valid color.
+func NewRGBFromHSLuv(h, s, l float64) RGB {
+	return fromLuvSpace(h, s, l, hsluvMaxChroma)
+}
+
+// ToHPLuv converts the given color to HPLuv space. H is in [0..360], P and L are in [0..1], but P can overshoot 1 for saturated colors
+func ToHPLuv(c RGB) (h, p, l float64) {
+	return toLuvSpace(c, false)
+}
+
+// NewRGBFromHPLuv generates a color by using data given in HPLuv space : H in [0..360], P and L in [0..1]
+func NewRGBFromHPLuv(h, p, l float64) RGB {
+	return fromLuvSpace(h, p, l, func(l, _ float64) float64 { return hpluvMaxChroma(l) })
+}
+
+// toLuvSpace is the common conversion of HSLuv and HPLuv
+func toLuvSpace(c RGB, hsluv bool) (h, s, l float64) {
+	lum, ch, hue := toLuvLCh(c)
+	switch {
+	case lum > 99.9999999:
+		return hue, 0, 1
+	case lum < 1e-8:
+		return hue, 0, 0
+	}
+	max := hpluvMaxChroma(lum)
+	if hsluv {
+		max = hsluvMaxChroma(lum, hue)
+	}
+	return hue, ch / max, lum / 100
+}
+
+// fromLuvSpace is the common conversion of HSLuv and HPLuv
+func fromLuvSpace(h, s, l float64, maxChroma func(l, h float64) float64) RGB {
+	lum := l * 100
+	switch {
+	case lum > 99.9999999:
+		return NewRGBFromClamped(fromLuvLCh(100, 0, h))
+	case lum < 1e-8:
+		return RGB{}
+	}
+	return NewRGBFromClamped(fromLuvLCh(lum, maxChroma(lum, h)*s, h))
+}
+
+// DistanceHSLuv measures the difference of two colors in HSLuv space, where the hue difference weights less
+func DistanceHSLuv(c1, c2 RGB) float64 {
+	h1, s1, l1 := ToHSLuv(c1)
+	h2, s2, l2 := ToHSLuv(c2)
+	dh := math.Abs(h1 - h2)
+	if dh > 180 {
+		dh = 360 - dh
+	}
+	return math.Sqrt(sq(dh/360) + sq(s1-s2) + sq(l1-l2))
+}
+
+// NewRGBFromBlendHSLuv blends two colors in the HSLuv color-space, going the short way around the hue circle.
+// t == 0 results in c1, t == 1 results in c2
+func NewRGBFromBlendHSLuv(c1, c2 RGB, t float64) RGB {
+	h1, s1, l1 := ToHSLuv(c1)
+	h2, s2, l2 := ToHSLuv(c2)
+	return NewRGBFromHSLuv(interpBetwAng(h1, h2, t), s1+t*(s2-s1), l1+t*(l2-l1))
+}
+
+// Blend mixes two palette or RGB colors in OKLab space, which is suited for gradients (e.g. charts, progress bars).
+// t == 0 results in c1, t == 1 results in c2. If any of them can't be converted to RGB (e.g. Default), the closest one is returned.
+func Blend(c1, c2 Color, t float64) Color {
+	switch {
+	case t <= 0:
+		return c1
+	case t >= 1:
+		return c2
+	}
+	from, ok1 := toRGB(c1)
+	to, ok2 := toRGB(c2)
+	if !ok1 || !ok2 {
+		if t < 0.5 {
+			return c1
+		}
+		return c2
+	}
+	return fromRGB(NewRGBFromBlendOkLab(from, to, t))
+}
+
+// Gradient is a list of color stops, spread evenly from 0 to 1 and blended in OKLab space (see Blend)
+type Gradient []Color
+
+// At returns the color of the gradient at t, in [0..1]. An empty gradient returns Default.
+func (g Gradient) At(t float64) Color {
+	switch {
+	case len(g) == 0:
+		return Default
+	case len(g) == 1 || t <= 0:
+		return g[0]
+	case t >= 1:
+		return g[len(g)-1]
+	}
+	scaled := t * float64(len(g)-1)
+	idx := int(scaled)
+	return Blend(g[idx], g[idx+1], scaled-float64(idx))
+}
@@ -0,0 +1,83 @@
+package color_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/badu/term/color"
+)
+
+func TestPerceptualRoundTrip(t *testing.T) {
+	colors := []color.RGB{
+		{R: 1, G: 0, B: 0},
+		{R: 0.2, G: 0.6, B: 0.9},
+		{R: 0.5, G: 0.5, B: 0.5},
+		{R: 0.95, G: 0.85, B: 0.1},
+	}
+	near := func(c1, c2 color.RGB) bool {
+		return math.Abs(c1.R-c2.R) < 1e-3 && math.Abs(c1.G-c2.G) < 1e-3 && math.Abs(c1.B-c2.B) < 1e-3
+	}
+	for _, c := range colors {
+		if got := color.NewRGBFromOkLab(color.ToOkLab(c)); !near(got, c) {
+			t.Fatalf("error : OKLab round trip of %s gave %s", c, got)
+		}
+		if got := color.NewRGBFromOkLch(color.ToOkLch(c)); !near(got, c) {
+			t.Fatalf("error : OKLCH round trip of %s gave %s", c, got)
+		}
+		if got := color.NewRGBFromHSLuv(color.ToHSLuv(c)); !near(got, c) {
+			t.Fatalf("error : HSLuv round trip of %s gave %s", c, got)
+		}
+	}
+	// reference values from https://bottosson.github.io/posts/oklab/ and https://www.hsluv.org
+	if l, a, b := color.ToOkLab(color.RGB{R: 1, G: 1, B: 1}); math.Abs(l-1) > 1e-3 || math.Abs(a) > 1e-3 || math.Abs(b) > 1e-3 {
+		t.Fatalf("error : white should be (1,0,0) in OKLab, got (%f,%f,%f)", l, a, b)
+	}
+	if h, s, l := color.ToHSLuv(color.RGB{R: 1, G: 0, B: 0}); math.Abs(h-12.177) > 0.1 || math.Abs(s-1) > 1e-3 || math.Abs(l-0.5323) > 1e-3 {
+		t.Fatalf("error : red should be (12.177,1,0.5323) in HSLuv, got (%f,%f,%f)", h, s, l)
+	}
+	// every HSLuv saturation is a valid color
+	for h := 0.0; h < 360; h += 30 {
+		if c := color.NewRGBFromHSLuv(h, 1, 0.6); !c.IsValid() {
+			t.Fatalf("error : HSLuv(%f,1,0.6) is not valid : %s", h, c)
+		}
+	}
+	if _, p, _ := color.ToHPLuv(color.RGB{R: 0.5, G: 0.5, B: 0.5}); p > 1e-6 {
+		t.Fatalf("error : gray should have no HPLuv saturation, got %f", p)
+	}
+}
+
+func TestPerceptualBlend(t *testing.T) {
+	blue, white := color.RGB{R: 0, G: 0, B: 1}, color.RGB{R: 1, G: 1, B: 1}
+	mid := color.NewRGBFromBlendOkLab(blue, white, 0.5)
+	if mid.B < mid.R || mid.B < mid.G {
+		t.Fatalf("error : blending blue and white should stay blue, got %s", mid)
+	}
+	if d := color.DistanceOkLab(blue, blue); d != 0 {
+		t.Fatalf("error : distance to itself should be zero, got %f", d)
+	}
+	if color.DistanceOkLab(blue, mid) >= color.DistanceOkLab(blue, white) {
+		t.Fatal("error : the middle should be closer than the end")
+	}
+	if color.DistanceHSLuv(blue, white) <= 0 {
+		t.Fatal("error : blue and white should have a HSLuv distance")
+	}
+}
+
+func TestGradient(t *testing.T) {
+	g := color.Gradient{color.Red, color.Green, color.Blue}
+	if c := g.At(0); c != color.Red {
+		t.Fatalf("error : expecting the first stop, got %v", c)
+	}
+	if c := g.At(0.5); c != color.Green {
+		t.Fatalf("error : expecting the middle stop, got %v", c)
+	}
+	if c := g.At(2); c != color.Blue {
+		t.Fatalf("error : expecting the last stop, got %v", c)
+	}
+	if c := g.At(0.25); c != color.Blend(color.Red, color.Green, 0.5) {
+		t.Fatalf("error : expecting the blend of the first stops, got %v", c)
+	}
+	if c := (color.Gradient{}).At(0.5); c != color.Default {
+		t.Fatalf("error : expecting Default for an empty gradient, got %v", c)
+	}
+}
@@ -0,0 +1,162 @@
+package color
+
+import (
+	"math"
+	"sort"
+)
+
+// SortMode is the criteria used by Sort
+type SortMode int
+
+const (
+	ByHue       SortMode = iota // by hue (HCL), grays first, then by lightness
+	ByLuminance                 // from dark to light (WCAG relative luminance)
+	ByDistance                  // a path through the Lab space, where each color is close to the previous one, starting with the darker end
+)
+
+// Stringer implementation
+func (m SortMode) String() string {
+	switch m {
+	case ByHue:
+		return "hue"
+	case ByLuminance:
+		return "luminance"
+	case ByDistance:
+		return "distance"
+	default:
+		return "unknown"
+	}
+}
+
+// grayChroma is the chroma (HCL) below which a color is considered a gray, having no meaningful hue
+const grayChroma = 0.05
+
+// sortable is a color and its coordinates, computed once
+type sortable struct {
+	c     Color
+	rgb   RGB
+	known bool
+	hue   float64
+	light float64
+	gray  bool
+}
+
+// Sort orders the colors in place. The sort is stable and the colors which can't be converted to RGB (e.g. Default) are moved at the end.
+// ByDistance solves the traveling salesman problem approximately (nearest neighbour, then 2-opt improvements), which makes smooth gradients and legends out of arbitrary palettes.
+func Sort(colors []Color, by SortMode) {
+	items := make([]sortable, len(colors))
+	for idx, c := range colors {
+		item := sortable{c: c}
+		item.rgb, item.known = toRGB(c)
+		if item.known {
+			var ch float64
+			item.hue, ch, item.light = ToHCL(item.rgb)
+			item.gray = ch < grayChroma
+			if by == ByLuminance {
+				item.light, _ = RelativeLuminance(c)
+			}
+		}
+		items[idx] = item
+	}
+
+	switch by {
+	case ByHue:
+		sort.SliceStable(items, func(i, j int) bool {
+			a, b := items[i], items[j]
+			if a.known != b.known {
+				return a.known
+			}
+			if a.gray != b.gray {
+				return a.gray
+			}
+			if !a.gray && a.hue != b.hue {
+				return a.hue < b.hue
+			}
+			return a.light < b.light
+		})
+	case ByLuminance:
+		sort.SliceStable(items, func(i, j int) bool {
+			a, b := items[i], items[j]
+			if a.known != b.known {
+				return a.known
+			}
+			return a.light < b.light
+		})
+	case ByDistance:
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].known && !items[j].known
+		})
+		known := 0
+		for known < len(items) && items[known].known {
+			known++
+		}
+		travel(items[:known])
+	}
+
+	for idx, item := range items {
+		colors[idx] = item.c
+	}
+}
+
+// travel orders the items as a short path : nearest neighbour from the darkest, then 2-opt until no segment reversal shortens it.
+// Finally, the path is reversed if its last color is darker than the first one.
+func travel(items []sortable) {
+	if len(items) < 3 {
+		sort.SliceStable(items, func(i, j int) bool { return items[i].light < items[j].light })
+		return
+	}
+	darkest := 0
+	for idx, item := range items {
+		if item.light < items[darkest].light {
+			darkest = idx
+		}
+	}
+	items[0], items[darkest] = items[darkest], items[0]
+	for idx := 1; idx < len(items); idx++ {
+		nearest, best := idx, math.Inf(1)
+		for candidate := idx; candidate < len(items); candidate++ {
+			if d := DistanceLab(items[idx-1].rgb, items[candidate].rgb); d < best {
+				nearest, best = candidate, d
+			}
+		}
+		items[idx], items[nearest] = items[nearest], items[idx]
+	}
+
+	// 2-opt on an open path : the ends can move too
+	dist := func(i, j int) float64 { return DistanceLab(items[i].rgb, items[j].rgb) }
+	for improved, rounds := true, 0; improved && rounds < 100; rounds++ {
+		improved = false
+		for i := 0; i < len(items)-1; i++ {
+			for j := i + 1; j < len(items); j++ {
+				before, after := 0.0, 0.0
+				if i > 0 {
+					before += dist(i-1, i)
+					after += dist(i-1, j)
+				}
+				if j+1 < len(items) {
+					before += dist(j, j+1)
+					after += dist(i, j+1)
+				}
+				if after < before-1e-9 {
+					for l, r := i, j; l < r; l, r = l+1, r-1 {
+						items[l], items[r] = items[r], items[l]
+					}
+					improved = true
+				}
+			}
+		}
+	}
+	if items[len(items)-1].light < items[0].light {
+		for l, r := 0, len(items)-1; l < r; l, r = l+1, r-1 {
+			items[l], items[r] = items[r], items[l]
+		}
+	}
+}
+
+// Sorted returns an ordered copy of the colors, leaving them unchanged
+func Sorted(colors []Color, by SortMode) []Color {
+	result := make([]Color, len(colors))
+	copy(result, colors)
+	Sort(result, by)
+	return result
+}
@@ -0,0 +1,47 @@
+package color_test
+
+import (
+	"testing"
+
+	"github.com/badu/term/color"
+)
+
+func TestSort(t *testing.T) {
+	colors := []color.Color{color.White, color.Default, color.Blue, color.Black, color.Red, color.Gray, color.Lime}
+
+	byLuminance := color.Sorted(colors, color.ByLuminance)
+	if byLuminance[0] != color.Black || byLuminance[5] != color.White || byLuminance[6] != color.Default {
+		t.Fatalf("error : unexpected order by luminance %v", byLuminance)
+	}
+	if colors[0] != color.White {
+		t.Fatal("error : Sorted should not change the colors")
+	}
+
+	byHue := color.Sorted(colors, color.ByHue)
+	want := []color.Color{color.Black, color.Gray, color.White, color.Red, color.Lime, color.Blue, color.Default}
+	for idx := range want {
+		if byHue[idx] != want[idx] {
+			t.Fatalf("error : expecting %v by hue, got %v", want, byHue)
+		}
+	}
+
+	// a shuffled gradient should be put back in order
+	var gradient, shuffled []color.Color
+	for step := int32(0); step < 16; step++ {
+		gradient = append(gradient, color.NewRGBColor(step*16, 0, 255-step*16))
+	}
+	for _, idx := range []int{7, 2, 15, 0, 11, 4, 9, 13, 1, 6, 14, 3, 10, 8, 5, 12} {
+		shuffled = append(shuffled, gradient[idx])
+	}
+	color.Sort(shuffled, color.ByDistance)
+	reversed := shuffled[0] == gradient[15]
+	for idx := range gradient {
+		expected := gradient[idx]
+		if reversed {
+			expected = gradient[len(gradient)-1-idx]
+		}
+		if shuffled[idx] != expected {
+			t.Fatalf("error : gradient not restored at %d : %v", idx, shuffled)
+		}
+	}
+}
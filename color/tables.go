@@ -0,0 +1,1303 @@
+// Code generated by gen_tables.go; DO NOT EDIT.
+
+package color
+
+// Note that the order of these options is important -- it follows the definitions used by ECMA and XTerm.
+// Hence any further named colors must begin at a value not less than 256.
+const (
+	Black = valid + iota
+	Maroon
+	Green
+	Olive
+	Navy
+	Purple
+	Teal
+	Silver
+	Gray
+	Red
+	Lime
+	Yellow
+	Blue
+	Fuchsia
+	Aqua
+	White
+	Noname16
+	Noname17
+	Noname18
+	Noname19
+	Noname20
+	Noname21
+	Noname22
+	Noname23
+	Noname24
+	Noname25
+	Noname26
+	Noname27
+	Noname28
+	Noname29
+	Noname30
+	Noname31
+	Noname32
+	Noname33
+	Noname34
+	Noname35
+	Noname36
+	Noname37
+	Noname38
+	Noname39
+	Noname40
+	Noname41
+	Noname42
+	Noname43
+	Noname44
+	Noname45
+	Noname46
+	Noname47
+	Noname48
+	Noname49
+	Noname50
+	Noname51
+	Noname52
+	Noname53
+	Noname54
+	Noname55
+	Noname56
+	Noname57
+	Noname58
+	Noname59
+	Noname60
+	Noname61
+	Noname62
+	Noname63
+	Noname64
+	Noname65
+	Noname66
+	Noname67
+	Noname68
+	Noname69
+	Noname70
+	Noname71
+	Noname72
+	Noname73
+	Noname74
+	Noname75
+	Noname76
+	Noname77
+	Noname78
+	Noname79
+	Noname80
+	Noname81
+	Noname82
+	Noname83
+	Noname84
+	Noname85
+	Noname86
+	Noname87
+	Noname88
+	Noname89
+	Noname90
+	Noname91
+	Noname92
+	Noname93
+	Noname94
+	Noname95
+	Noname96
+	Noname97
+	Noname98
+	Noname99
+	Noname100
+	Noname101
+	Noname102
+	Noname103
+	Noname104
+	Noname105
+	Noname106
+	Noname107
+	Noname108
+	Noname109
+	Noname110
+	Noname111
+	Noname112
+	Noname113
+	Noname114
+	Noname115
+	Noname116
+	Noname117
+	Noname118
+	Noname119
+	Noname120
+	Noname121
+	Noname122
+	Noname123
+	Noname124
+	Noname125
+	Noname126
+	Noname127
+	Noname128
+	Noname129
+	Noname130
+	Noname131
+	Noname132
+	Noname133
+	Noname134
+	Noname135
+	Noname136
+	Noname137
+	Noname138
+	Noname139
+	Noname140
+	Noname141
+	Noname142
+	Noname143
+	Noname144
+	Noname145
+	Noname146
+	Noname147
+	Noname148
+	Noname149
+	Noname150
+	Noname151
+	Noname152
+	Noname153
+	Noname154
+	Noname155
+	Noname156
+	Noname157
+	Noname158
+	Noname159
+	Noname160
+	Noname161
+	Noname162
+	Noname163
+	Noname164
+	Noname165
+	Noname166
+	Noname167
+	Noname168
+	Noname169
+	Noname170
+	Noname171
+	Noname172
+	Noname173
+	Noname174
+	Noname175
+	Noname176
+	Noname177
+	Noname178
+	Noname179
+	Noname180
+	Noname181
+	Noname182
+	Noname183
+	Noname184
+	Noname185
+	Noname186
+	Noname187
+	Noname188
+	Noname189
+	Noname190
+	Noname191
+	Noname192
+	Noname193
+	Noname194
+	Noname195
+	Noname196
+	Noname197
+	Noname198
+	Noname199
+	Noname200
+	Noname201
+	Noname202
+	Noname203
+	Noname204
+	Noname205
+	Noname206
+	Noname207
+	Noname208
+	Noname209
+	Noname210
+	Noname211
+	Noname212
+	Noname213
+	Noname214
+	Noname215
+	Noname216
+	Noname217
+	Noname218
+	Noname219
+	Noname220
+	Noname221
+	Noname222
+	Noname223
+	Noname224
+	Noname225
+	Noname226
+	Noname227
+	Noname228
+	Noname229
+	Noname230
+	Noname231
+	Noname232
+	Noname233
+	Noname234
+	Noname235
+	Noname236
+	Noname237
+	Noname238
+	Noname239
+	Noname240
+	Noname241
+	Noname242
+	Noname243
+	Noname244
+	Noname245
+	Noname246
+	Noname247
+	Noname248
+	Noname249
+	Noname250
+	Noname251
+	Noname252
+	Noname253
+	Noname254
+	Noname255
+	AliceBlue
+	AntiqueWhite
+	AquaMarine
+	Azure
+	Beige
+	Bisque
+	BlanchedAlmond
+	BlueViolet
+	Brown
+	BurlyWood
+	CadetBlue
+	Chartreuse
+	Chocolate
+	Coral
+	CornflowerBlue
+	CornSilk
+	Crimson
+	DarkBlue
+	DarkCyan
+	DarkGoldenrod
+	DarkGray
+	DarkGreen
+	DarkKhaki
+	DarkMagenta
+	DarkOliveGreen
+	DarkOrange
+	DarkOrchid
+	DarkRed
+	DarkSalmon
+	DarkSeaGreen
+	DarkSlateBlue
+	DarkSlateGray
+	DarkTurquoise
+	DarkViolet
+	DeepPink
+	DeepSkyBlue
+	DimGray
+	DodgerBlue
+	FireBrick
+	FloralWhite
+	ForestGreen
+	GainsBoro
+	GhostWhite
+	Gold
+	Goldenrod
+	GreenYellow
+	Honeydew
+	HotPink
+	IndianRed
+	Indigo
+	Ivory
+	Khaki
+	Lavender
+	LavenderBlush
+	LawnGreen
+	LemonChiffon
+	LightBlue
+	LightCoral
+	LightCyan
+	LightGoldenrodYellow
+	LightGray
+	LightGreen
+	LightPink
+	LightSalmon
+	LightSeaGreen
+	LightSkyBlue
+	LightSlateGray
+	LightSteelBlue
+	LightYellow
+	LimeGreen
+	Linen
+	MediumAquamarine
+	MediumBlue
+	MediumOrchid
+	MediumPurple
+	MediumSeaGreen
+	MediumSlateBlue
+	MediumSpringGreen
+	MediumTurquoise
+	MediumVioletRed
+	MidnightBlue
+	MintCream
+	MistyRose
+	Moccasin
+	NavajoWhite
+	OldLace
+	OliveDrab
+	Orange
+	OrangeRed
+	Orchid
+	PaleGoldenrod
+	PaleGreen
+	PaleTurquoise
+	PaleVioletRed
+	PapayaWhip
+	PeachPuff
+	Peru
+	Pink
+	Plum
+	PowderBlue
+	RebeccaPurple
+	RosyBrown
+	RoyalBlue
+	SaddleBrown
+	Salmon
+	SandyBrown
+	SeaGreen
+	Seashell
+	Sienna
+	SkyBlue
+	SlateBlue
+	SlateGray
+	Snow
+	SpringGreen
+	SteelBlue
+	Tan
+	Thistle
+	Tomato
+	Turquoise
+	Violet
+	Wheat
+	WhiteSmoke
+	YellowGreen
+)
+
+// hexes are the RGB values of the colors above, by their index
+var hexes = [...]int32{
+	0x000000, // Black
+	0x800000, // Maroon
+	0x008000, // Green
+	0x808000, // Olive
+	0x000080, // Navy
+	0x800080, // Purple
+	0x008080, // Teal
+	0xC0C0C0, // Silver
+	0x808080, // Gray
+	0xFF0000, // Red
+	0x00FF00, // Lime
+	0xFFFF00, // Yellow
+	0x0000FF, // Blue
+	0xFF00FF, // Fuchsia
+	0x00FFFF, // Aqua
+	0xFFFFFF, // White
+	0x000000, // Noname16
+	0x00005F, // Noname17
+	0x000087, // Noname18
+	0x0000AF, // Noname19
+	0x0000D7, // Noname20
+	0x0000FF, // Noname21
+	0x005F00, // Noname22
+	0x005F5F, // Noname23
+	0x005F87, // Noname24
+	0x005FAF, // Noname25
+	0x005FD7, // Noname26
+	0x005FFF, // Noname27
+	0x008700, // Noname28
+	0x00875F, // Noname29
+	0x008787, // Noname30
+	0x0087AF, // Noname31
+	0x0087D7, // Noname32
+	0x0087FF, // Noname33
+	0x00AF00, // Noname34
+	0x00AF5F, // Noname35
+	0x00AF87, // Noname36
+	0x00AFAF, // Noname37
+	0x00AFD7, // Noname38
+	0x00AFFF, // Noname39
+	0x00D700, // Noname40
+	0x00D75F, // Noname41
+	0x00D787, // Noname42
+	0x00D7AF, // Noname43
+	0x00D7D7, // Noname44
+	0x00D7FF, // Noname45
+	0x00FF00, // Noname46
+	0x00FF5F, // Noname47
+	0x00FF87, // Noname48
+	0x00FFAF, // Noname49
+	0x00FFD7, // Noname50
+	0x00FFFF, // Noname51
+	0x5F0000, // Noname52
+	0x5F005F, // Noname53
+	0x5F0087, // Noname54
+	0x5F00AF, // Noname55
+	0x5F00D7, // Noname56
+	0x5F00FF, // Noname57
+	0x5F5F00, // Noname58
+	0x5F5F5F, // Noname59
+	0x5F5F87, // Noname60
+	0x5F5FAF, // Noname61
+	0x5F5FD7, // Noname62
+	0x5F5FFF, // Noname63
+	0x5F8700, // Noname64
+	0x5F875F, // Noname65
+	0x5F8787, // Noname66
+	0x5F87AF, // Noname67
+	0x5F87D7, // Noname68
+	0x5F87FF, // Noname69
+	0x5FAF00, // Noname70
+	0x5FAF5F, // Noname71
+	0x5FAF87, // Noname72
+	0x5FAFAF, // Noname73
+	0x5FAFD7, // Noname74
+	0x5FAFFF, // Noname75
+	0x5FD700, // Noname76
+	0x5FD75F, // Noname77
+	0x5FD787, // Noname78
+	0x5FD7AF, // Noname79
+	0x5FD7D7, // Noname80
+	0x5FD7FF, // Noname81
+	0x5FFF00, // Noname82
+	0x5FFF5F, // Noname83
+	0x5FFF87, // Noname84
+	0x5FFFAF, // Noname85
+	0x5FFFD7, // Noname86
+	0x5FFFFF, // Noname87
+	0x870000, // Noname88
+	0x87005F, // Noname89
+	0x870087, // Noname90
+	0x8700AF, // Noname91
+	0x8700D7, // Noname92
+	0x8700FF, // Noname93
+	0x875F00, // Noname94
+	0x875F5F, // Noname95
+	0x875F87, // Noname96
+	0x875FAF, // Noname97
+	0x875FD7, // Noname98
+	0x875FFF, // Noname99
+	0x878700, // Noname100
+	0x87875F, // Noname101
+	0x878787, // Noname102
+	0x8787AF, // Noname103
+	0x8787D7, // Noname104
+	0x8787FF, // Noname105
+	0x87AF00, // Noname106
+	0x87AF5F, // Noname107
+	0x87AF87, // Noname108
+	0x87AFAF, // Noname109
+	0x87AFD7, // Noname110
+	0x87AFFF, // Noname111
+	0x87D700, // Noname112
+	0x87D75F, // Noname113
+	0x87D787, // Noname114
+	0x87D7AF, // Noname115
+	0x87D7D7, // Noname116
+	0x87D7FF, // Noname117
+	0x87FF00, // Noname118
+	0x87FF5F, // Noname119
+	0x87FF87, // Noname120
+	0x87FFAF, // Noname121
+	0x87FFD7, // Noname122
+	0x87FFFF, // Noname123
+	0xAF0000, // Noname124
+	0xAF005F, // Noname125
+	0xAF0087, // Noname126
+	0xAF00AF, // Noname127
+	0xAF00D7, // Noname128
+	0xAF00FF, // Noname129
+	0xAF5F00, // Noname130
+	0xAF5F5F, // Noname131
+	0xAF5F87, // Noname132
+	0xAF5FAF, // Noname133
+	0xAF5FD7, // Noname134
+	0xAF5FFF, // Noname135
+	0xAF8700, // Noname136
+	0xAF875F, // Noname137
+	0xAF8787, // Noname138
+	0xAF87AF, // Noname139
+	0xAF87D7, // Noname140
+	0xAF87FF, // Noname141
+	0xAFAF00, // Noname142
+	0xAFAF5F, // Noname143
+	0xAFAF87, // Noname144
+	0xAFAFAF, // Noname145
+	0xAFAFD7, // Noname146
+	0xAFAFFF, // Noname147
+	0xAFD700, // Noname148
+	0xAFD75F, // Noname149
+	0xAFD787, // Noname150
+	0xAFD7AF, // Noname151
+	0xAFD7D7, // Noname152
+	0xAFD7FF, // Noname153
+	0xAFFF00, // Noname154
+	0xAFFF5F, // Noname155
+	0xAFFF87, // Noname156
+	0xAFFFAF, // Noname157
+	0xAFFFD7, // Noname158
+	0xAFFFFF, // Noname159
+	0xD70000, // Noname160
+	0xD7005F, // Noname161
+	0xD70087, // Noname162
+	0xD700AF, // Noname163
+	0xD700D7, // Noname164
+	0xD700FF, // Noname165
+	0xD75F00, // Noname166
+	0xD75F5F, // Noname167
+	0xD75F87, // Noname168
+	0xD75FAF, // Noname169
+	0xD75FD7, // Noname170
+	0xD75FFF, // Noname171
+	0xD78700, // Noname172
+	0xD7875F, // Noname173
+	0xD78787, // Noname174
+	0xD787AF, // Noname175
+	0xD787D7, // Noname176
+	0xD787FF, // Noname177
+	0xD7AF00, // Noname178
+	0xD7AF5F, // Noname179
+	0xD7AF87, // Noname180
+	0xD7AFAF, // Noname181
+	0xD7AFD7, // Noname182
+	0xD7AFFF, // Noname183
+	0xD7D700, // Noname184
+	0xD7D75F, // Noname185
+	0xD7D787, // Noname186
+	0xD7D7AF, // Noname187
+	0xD7D7D7, // Noname188
+	0xD7D7FF, // Noname189
+	0xD7FF00, // Noname190
+	0xD7FF5F, // Noname191
+	0xD7FF87, // Noname192
+	0xD7FFAF, // Noname193
+	0xD7FFD7, // Noname194
+	0xD7FFFF, // Noname195
+	0xFF0000, // Noname196
+	0xFF005F, // Noname197
+	0xFF0087, // Noname198
+	0xFF00AF, // Noname199
+	0xFF00D7, // Noname200
+	0xFF00FF, // Noname201
+	0xFF5F00, // Noname202
+	0xFF5F5F, // Noname203
+	0xFF5F87, // Noname204
+	0xFF5FAF, // Noname205
+	0xFF5FD7, // Noname206
+	0xFF5FFF, // Noname207
+	0xFF8700, // Noname208
+	0xFF875F, // Noname209
+	0xFF8787, // Noname210
+	0xFF87AF, // Noname211
+	0xFF87D7, // Noname212
+	0xFF87FF, // Noname213
+	0xFFAF00, // Noname214
+	0xFFAF5F, // Noname215
+	0xFFAF87, // Noname216
+	0xFFAFAF, // Noname217
+	0xFFAFD7, // Noname218
+	0xFFAFFF, // Noname219
+	0xFFD700, // Noname220
+	0xFFD75F, // Noname221
+	0xFFD787, // Noname222
+	0xFFD7AF, // Noname223
+	0xFFD7D7, // Noname224
+	0xFFD7FF, // Noname225
+	0xFFFF00, // Noname226
+	0xFFFF5F, // Noname227
+	0xFFFF87, // Noname228
+	0xFFFFAF, // Noname229
+	0xFFFFD7, // Noname230
+	0xFFFFFF, // Noname231
+	0x080808, // Noname232
+	0x121212, // Noname233
+	0x1C1C1C, // Noname234
+	0x262626, // Noname235
+	0x303030, // Noname236
+	0x3A3A3A, // Noname237
+	0x444444, // Noname238
+	0x4E4E4E, // Noname239
+	0x585858, // Noname240
+	0x626262, // Noname241
+	0x6C6C6C, // Noname242
+	0x767676, // Noname243
+	0x808080, // Noname244
+	0x8A8A8A, // Noname245
+	0x949494, // Noname246
+	0x9E9E9E, // Noname247
+	0xA8A8A8, // Noname248
+	0xB2B2B2, // Noname249
+	0xBCBCBC, // Noname250
+	0xC6C6C6, // Noname251
+	0xD0D0D0, // Noname252
+	0xDADADA, // Noname253
+	0xE4E4E4, // Noname254
+	0xEEEEEE, // Noname255
+	0xF0F8FF, // AliceBlue
+	0xFAEBD7, // AntiqueWhite
+	0x7FFFD4, // AquaMarine
+	0xF0FFFF, // Azure
+	0xF5F5DC, // Beige
+	0xFFE4C4, // Bisque
+	0xFFEBCD, // BlanchedAlmond
+	0x8A2BE2, // BlueViolet
+	0xA52A2A, // Brown
+	0xDEB887, // BurlyWood
+	0x5F9EA0, // CadetBlue
+	0x7FFF00, // Chartreuse
+	0xD2691E, // Chocolate
+	0xFF7F50, // Coral
+	0x6495ED, // CornflowerBlue
+	0xFFF8DC, // CornSilk
+	0xDC143C, // Crimson
+	0x00008B, // DarkBlue
+	0x008B8B, // DarkCyan
+	0xB8860B, // DarkGoldenrod
+	0xA9A9A9, // DarkGray
+	0x006400, // DarkGreen
+	0xBDB76B, // DarkKhaki
+	0x8B008B, // DarkMagenta
+	0x556B2F, // DarkOliveGreen
+	0xFF8C00, // DarkOrange
+	0x9932CC, // DarkOrchid
+	0x8B0000, // DarkRed
+	0xE9967A, // DarkSalmon
+	0x8FBC8F, // DarkSeaGreen
+	0x483D8B, // DarkSlateBlue
+	0x2F4F4F, // DarkSlateGray
+	0x00CED1, // DarkTurquoise
+	0x9400D3, // DarkViolet
+	0xFF1493, // DeepPink
+	0x00BFFF, // DeepSkyBlue
+	0x696969, // DimGray
+	0x1E90FF, // DodgerBlue
+	0xB22222, // FireBrick
+	0xFFFAF0, // FloralWhite
+	0x228B22, // ForestGreen
+	0xDCDCDC, // GainsBoro
+	0xF8F8FF, // GhostWhite
+	0xFFD700, // Gold
+	0xDAA520, // Goldenrod
+	0xADFF2F, // GreenYellow
+	0xF0FFF0, // Honeydew
+	0xFF69B4, // HotPink
+	0xCD5C5C, // IndianRed
+	0x4B0082, // Indigo
+	0xFFFFF0, // Ivory
+	0xF0E68C, // Khaki
+	0xE6E6FA, // Lavender
+	0xFFF0F5, // LavenderBlush
+	0x7CFC00, // LawnGreen
+	0xFFFACD, // LemonChiffon
+	0xADD8E6, // LightBlue
+	0xF08080, // LightCoral
+	0xE0FFFF, // LightCyan
+	0xFAFAD2, // LightGoldenrodYellow
+	0xD3D3D3, // LightGray
+	0x90EE90, // LightGreen
+	0xFFB6C1, // LightPink
+	0xFFA07A, // LightSalmon
+	0x20B2AA, // LightSeaGreen
+	0x87CEFA, // LightSkyBlue
+	0x778899, // LightSlateGray
+	0xB0C4DE, // LightSteelBlue
+	0xFFFFE0, // LightYellow
+	0x32CD32, // LimeGreen
+	0xFAF0E6, // Linen
+	0x66CDAA, // MediumAquamarine
+	0x0000CD, // MediumBlue
+	0xBA55D3, // MediumOrchid
+	0x9370DB, // MediumPurple
+	0x3CB371, // MediumSeaGreen
+	0x7B68EE, // MediumSlateBlue
+	0x00FA9A, // MediumSpringGreen
+	0x48D1CC, // MediumTurquoise
+	0xC71585, // MediumVioletRed
+	0x191970, // MidnightBlue
+	0xF5FFFA, // MintCream
+	0xFFE4E1, // MistyRose
+	0xFFE4B5, // Moccasin
+	0xFFDEAD, // NavajoWhite
+	0xFDF5E6, // OldLace
+	0x6B8E23, // OliveDrab
+	0xFFA500, // Orange
+	0xFF4500, // OrangeRed
+	0xDA70D6, // Orchid
+	0xEEE8AA, // PaleGoldenrod
+	0x98FB98, // PaleGreen
+	0xAFEEEE, // PaleTurquoise
+	0xDB7093, // PaleVioletRed
+	0xFFEFD5, // PapayaWhip
+	0xFFDAB9, // PeachPuff
+	0xCD853F, // Peru
+	0xFFC0CB, // Pink
+	0xDDA0DD, // Plum
+	0xB0E0E6, // PowderBlue
+	0x663399, // RebeccaPurple
+	0xBC8F8F, // RosyBrown
+	0x4169E1, // RoyalBlue
+	0x8B4513, // SaddleBrown
+	0xFA8072, // Salmon
+	0xF4A460, // SandyBrown
+	0x2E8B57, // SeaGreen
+	0xFFF5EE, // Seashell
+	0xA0522D, // Sienna
+	0x87CEEB, // SkyBlue
+	0x6A5ACD, // SlateBlue
+	0x708090, // SlateGray
+	0xFFFAFA, // Snow
+	0x00FF7F, // SpringGreen
+	0x4682B4, // SteelBlue
+	0xD2B48C, // Tan
+	0xD8BFD8, // Thistle
+	0xFF6347, // Tomato
+	0x40E0D0, // Turquoise
+	0xEE82EE, // Violet
+	0xF5DEB3, // Wheat
+	0xF5F5F5, // WhiteSmoke
+	0x9ACD32, // YellowGreen
+}
+
+// names are the W3C names of the colors above, by their index. The palette colors 16 to 255 have no W3C name
+var names = [...]string{
+	"black",                // Black
+	"maroon",               // Maroon
+	"green",                // Green
+	"olive",                // Olive
+	"navy",                 // Navy
+	"purple",               // Purple
+	"teal",                 // Teal
+	"silver",               // Silver
+	"gray",                 // Gray
+	"red",                  // Red
+	"lime",                 // Lime
+	"yellow",               // Yellow
+	"blue",                 // Blue
+	"fuchsia",              // Fuchsia
+	"aqua",                 // Aqua
+	"white",                // White
+	"",                     // Noname16
+	"",                     // Noname17
+	"",                     // Noname18
+	"",                     // Noname19
+	"",                     // Noname20
+	"",                     // Noname21
+	"",                     // Noname22
+	"",                     // Noname23
+	"",                     // Noname24
+	"",                     // Noname25
+	"",                     // Noname26
+	"",                     // Noname27
+	"",                     // Noname28
+	"",                     // Noname29
+	"",                     // Noname30
+	"",                     // Noname31
+	"",                     // Noname32
+	"",                     // Noname33
+	"",                     // Noname34
+	"",                     // Noname35
+	"",                     // Noname36
+	"",                     // Noname37
+	"",                     // Noname38
+	"",                     // Noname39
+	"",                     // Noname40
+	"",                     // Noname41
+	"",                     // Noname42
+	"",                     // Noname43
+	"",                     // Noname44
+	"",                     // Noname45
+	"",                     // Noname46
+	"",                     // Noname47
+	"",                     // Noname48
+	"",                     // Noname49
+	"",                     // Noname50
+	"",                     // Noname51
+	"",                     // Noname52
+	"",                     // Noname53
+	"",                     // Noname54
+	"",                     // Noname55
+	"",                     // Noname56
+	"",                     // Noname57
+	"",                     // Noname58
+	"",                     // Noname59
+	"",                     // Noname60
+	"",                     // Noname61
+	"",                     // Noname62
+	"",                     // Noname63
+	"",                     // Noname64
+	"",                     // Noname65
+	"",                     // Noname66
+	"",                     // Noname67
+	"",                     // Noname68
+	"",                     // Noname69
+	"",                     // Noname70
+	"",                     // Noname71
+	"",                     // Noname72
+	"",                     // Noname73
+	"",                     // Noname74
+	"",                     // Noname75
+	"",                     // Noname76
+	"",                     // Noname77
+	"",                     // Noname78
+	"",                     // Noname79
+	"",                     // Noname80
+	"",                     // Noname81
+	"",                     // Noname82
+	"",                     // Noname83
+	"",                     // Noname84
+	"",                     // Noname85
+	"",                     // Noname86
+	"",                     // Noname87
+	"",                     // Noname88
+	"",                     // Noname89
+	"",                     // Noname90
+	"",                     // Noname91
+	"",                     // Noname92
+	"",                     // Noname93
+	"",                     // Noname94
+	"",                     // Noname95
+	"",                     // Noname96
+	"",                     // Noname97
+	"",                     // Noname98
+	"",                     // Noname99
+	"",                     // Noname100
+	"",                     // Noname101
+	"",                     // Noname102
+	"",                     // Noname103
+	"",                     // Noname104
+	"",                     // Noname105
+	"",                     // Noname106
+	"",                     // Noname107
+	"",                     // Noname108
+	"",                     // Noname109
+	"",                     // Noname110
+	"",                     // Noname111
+	"",                     // Noname112
+	"",                     // Noname113
+	"",                     // Noname114
+	"",                     // Noname115
+	"",                     // Noname116
+	"",                     // Noname117
+	"",                     // Noname118
+	"",                     // Noname119
+	"",                     // Noname120
+	"",                     // Noname121
+	"",                     // Noname122
+	"",                     // Noname123
+	"",                     // Noname124
+	"",                     // Noname125
+	"",                     // Noname126
+	"",                     // Noname127
+	"",                     // Noname128
+	"",                     // Noname129
+	"",                     // Noname130
+	"",                     // Noname131
+	"",                     // Noname132
+	"",                     // Noname133
+	"",                     // Noname134
+	"",                     // Noname135
+	"",                     // Noname136
+	"",                     // Noname137
+	"",                     // Noname138
+	"",                     // Noname139
+	"",                     // Noname140
+	"",                     // Noname141
+	"",                     // Noname142
+	"",                     // Noname143
+	"",                     // Noname144
+	"",                     // Noname145
+	"",                     // Noname146
+	"",                     // Noname147
+	"",                     // Noname148
+	"",                     // Noname149
+	"",                     // Noname150
+	"",                     // Noname151
+	"",                     // Noname152
+	"",                     // Noname153
+	"",                     // Noname154
+	"",                     // Noname155
+	"",                     // Noname156
+	"",                     // Noname157
+	"",                     // Noname158
+	"",                     // Noname159
+	"",                     // Noname160
+	"",                     // Noname161
+	"",                     // Noname162
+	"",                     // Noname163
+	"",                     // Noname164
+	"",                     // Noname165
+	"",                     // Noname166
+	"",                     // Noname167
+	"",                     // Noname168
+	"",                     // Noname169
+	"",                     // Noname170
+	"",                     // Noname171
+	"",                     // Noname172
+	"",                     // Noname173
+	"",                     // Noname174
+	"",                     // Noname175
+	"",                     // Noname176
+	"",                     // Noname177
+	"",                     // Noname178
+	"",                     // Noname179
+	"",                     // Noname180
+	"",                     // Noname181
+	"",                     // Noname182
+	"",                     // Noname183
+	"",                     // Noname184
+	"",                     // Noname185
+	"",                     // Noname186
+	"",                     // Noname187
+	"",                     // Noname188
+	"",                     // Noname189
+	"",                     // Noname190
+	"",                     // Noname191
+	"",                     // Noname192
+	"",                     // Noname193
+	"",                     // Noname194
+	"",                     // Noname195
+	"",                     // Noname196
+	"",                     // Noname197
+	"",                     // Noname198
+	"",                     // Noname199
+	"",                     // Noname200
+	"",                     // Noname201
+	"",                     // Noname202
+	"",                     // Noname203
+	"",                     // Noname204
+	"",                     // Noname205
+	"",                     // Noname206
+	"",                     // Noname207
+	"",                     // Noname208
+	"",                     // Noname209
+	"",                     // Noname210
+	"",                     // Noname211
+	"",                     // Noname212
+	"",                     // Noname213
+	"",                     // Noname214
+	"",                     // Noname215
+	"",                     // Noname216
+	"",                     // Noname217
+	"",                     // Noname218
+	"",                     // Noname219
+	"",                     // Noname220
+	"",                     // Noname221
+	"",                     // Noname222
+	"",                     // Noname223
+	"",                     // Noname224
+	"",                     // Noname225
+	"",                     // Noname226
+	"",                     // Noname227
+	"",                     // Noname228
+	"",                     // Noname229
+	"",                     // Noname230
+	"",                     // Noname231
+	"",                     // Noname232
+	"",                     // Noname233
+	"",                     // Noname234
+	"",                     // Noname235
+	"",                     // Noname236
+	"",                     // Noname237
+	"",                     // Noname238
+	"",                     // Noname239
+	"",                     // Noname240
+	"",                     // Noname241
+	"",                     // Noname242
+	"",                     // Noname243
+	"",                     // Noname244
+	"",                     // Noname245
+	"",                     // Noname246
+	"",                     // Noname247
+	"",                     // Noname248
+	"",                     // Noname249
+	"",                     // Noname250
+	"",                     // Noname251
+	"",                     // Noname252
+	"",                     // Noname253
+	"",                     // Noname254
+	"",                     // Noname255
+	"aliceblue",            // AliceBlue
+	"antiquewhite",         // AntiqueWhite
+	"aquamarine",           // AquaMarine
+	"azure",                // Azure
+	"beige",                // Beige
+	"bisque",               // Bisque
+	"blanchedalmond",       // BlanchedAlmond
+	"blueviolet",           // BlueViolet
+	"brown",                // Brown
+	"burlywood",            // BurlyWood
+	"cadetblue",            // CadetBlue
+	"chartreuse",           // Chartreuse
+	"chocolate",            // Chocolate
+	"coral",                // Coral
+	"cornflowerblue",       // CornflowerBlue
+	"cornsilk",             // CornSilk
+	"crimson",              // Crimson
+	"darkblue",             // DarkBlue
+	"darkcyan",             // DarkCyan
+	"darkgoldenrod",        // DarkGoldenrod
+	"darkgray",             // DarkGray
+	"darkgreen",            // DarkGreen
+	"darkkhaki",            // DarkKhaki
+	"darkmagenta",          // DarkMagenta
+	"darkolivegreen",       // DarkOliveGreen
+	"darkorange",           // DarkOrange
+	"darkorchid",           // DarkOrchid
+	"darkred",              // DarkRed
+	"darksalmon",           // DarkSalmon
+	"darkseagreen",         // DarkSeaGreen
+	"darkslateblue",        // DarkSlateBlue
+	"darkslategray",        // DarkSlateGray
+	"darkturquoise",        // DarkTurquoise
+	"darkviolet",           // DarkViolet
+	"deeppink",             // DeepPink
+	"deepskyblue",          // DeepSkyBlue
+	"dimgray",              // DimGray
+	"dodgerblue",           // DodgerBlue
+	"firebrick",            // FireBrick
+	"floralwhite",          // FloralWhite
+	"forestgreen",          // ForestGreen
+	"gainsboro",            // GainsBoro
+	"ghostwhite",           // GhostWhite
+	"gold",                 // Gold
+	"goldenrod",            // Goldenrod
+	"greenyellow",          // GreenYellow
+	"honeydew",             // Honeydew
+	"hotpink",              // HotPink
+	"indianred",            // IndianRed
+	"indigo",               // Indigo
+	"ivory",                // Ivory
+	"khaki",                // Khaki
+	"lavender",             // Lavender
+	"lavenderblush",        // LavenderBlush
+	"lawngreen",            // LawnGreen
+	"lemonchiffon",         // LemonChiffon
+	"lightblue",            // LightBlue
+	"lightcoral",           // LightCoral
+	"lightcyan",            // LightCyan
+	"lightgoldenrodyellow", // LightGoldenrodYellow
+	"lightgray",            // LightGray
+	"lightgreen",           // LightGreen
+	"lightpink",            // LightPink
+	"lightsalmon",          // LightSalmon
+	"lightseagreen",        // LightSeaGreen
+	"lightskyblue",         // LightSkyBlue
+	"lightslategray",       // LightSlateGray
+	"lightsteelblue",       // LightSteelBlue
+	"lightyellow",          // LightYellow
+	"limegreen",            // LimeGreen
+	"linen",                // Linen
+	"mediumaquamarine",     // MediumAquamarine
+	"mediumblue",           // MediumBlue
+	"mediumorchid",         // MediumOrchid
+	"mediumpurple",         // MediumPurple
+	"mediumseagreen",       // MediumSeaGreen
+	"mediumslateblue",      // MediumSlateBlue
+	"mediumspringgreen",    // MediumSpringGreen
+	"mediumturquoise",      // MediumTurquoise
+	"mediumvioletred",      // MediumVioletRed
+	"midnightblue",         // MidnightBlue
+	"mintcream",            // MintCream
+	"mistyrose",            // MistyRose
+	"moccasin",             // Moccasin
+	"navajowhite",          // NavajoWhite
+	"oldlace",              // OldLace
+	"olivedrab",            // OliveDrab
+	"orange",               // Orange
+	"orangered",            // OrangeRed
+	"orchid",               // Orchid
+	"palegoldenrod",        // PaleGoldenrod
+	"palegreen",            // PaleGreen
+	"paleturquoise",        // PaleTurquoise
+	"palevioletred",        // PaleVioletRed
+	"papayawhip",           // PapayaWhip
+	"peachpuff",            // PeachPuff
+	"peru",                 // Peru
+	"pink",                 // Pink
+	"plum",                 // Plum
+	"powderblue",           // PowderBlue
+	"rebeccapurple",        // RebeccaPurple
+	"rosybrown",            // RosyBrown
+	"royalblue",            // RoyalBlue
+	"saddlebrown",          // SaddleBrown
+	"salmon",               // Salmon
+	"sandybrown",           // SandyBrown
+	"seagreen",             // SeaGreen
+	"seashell",             // Seashell
+	"sienna",               // Sienna
+	"skyblue",              // SkyBlue
+	"slateblue",            // SlateBlue
+	"slategray",            // SlateGray
+	"snow",                 // Snow
+	"springgreen",          // SpringGreen
+	"steelblue",            // SteelBlue
+	"tan",                  // Tan
+	"thistle",              // Thistle
+	"tomato",               // Tomato
+	"turquoise",            // Turquoise
+	"violet",               // Violet
+	"wheat",                // Wheat
+	"whitesmoke",           // WhiteSmoke
+	"yellowgreen",          // YellowGreen
+}
+
+// byName maps the lower case W3C names (and their alternate spellings) to colors
+var byName = map[string]Color{
+	"black":                Black,
+	"maroon":               Maroon,
+	"green":                Green,
+	"olive":                Olive,
+	"navy":                 Navy,
+	"purple":               Purple,
+	"teal":                 Teal,
+	"silver":               Silver,
+	"gray":                 Gray,
+	"red":                  Red,
+	"lime":                 Lime,
+	"yellow":               Yellow,
+	"blue":                 Blue,
+	"fuchsia":              Fuchsia,
+	"aqua":                 Aqua,
+	"white":                White,
+	"aliceblue":            AliceBlue,
+	"antiquewhite":         AntiqueWhite,
+	"aquamarine":           AquaMarine,
+	"azure":                Azure,
+	"beige":                Beige,
+	"bisque":               Bisque,
+	"blanchedalmond":       BlanchedAlmond,
+	"blueviolet":           BlueViolet,
+	"brown":                Brown,
+	"burlywood":            BurlyWood,
+	"cadetblue":            CadetBlue,
+	"chartreuse":           Chartreuse,
+	"chocolate":            Chocolate,
+	"coral":                Coral,
+	"cornflowerblue":       CornflowerBlue,
+	"cornsilk":             CornSilk,
+	"crimson":              Crimson,
+	"darkblue":             DarkBlue,
+	"darkcyan":             DarkCyan,
+	"darkgoldenrod":        DarkGoldenrod,
+	"darkgray":             DarkGray,
+	"darkgreen":            DarkGreen,
+	"darkkhaki":            DarkKhaki,
+	"darkmagenta":          DarkMagenta,
+	"darkolivegreen":       DarkOliveGreen,
+	"darkorange":           DarkOrange,
+	"darkorchid":           DarkOrchid,
+	"darkred":              DarkRed,
+	"darksalmon":           DarkSalmon,
+	"darkseagreen":         DarkSeaGreen,
+	"darkslateblue":        DarkSlateBlue,
+	"darkslategray":        DarkSlateGray,
+	"darkturquoise":        DarkTurquoise,
+	"darkviolet":           DarkViolet,
+	"deeppink":             DeepPink,
+	"deepskyblue":          DeepSkyBlue,
+	"dimgray":              DimGray,
+	"dodgerblue":           DodgerBlue,
+	"firebrick":            FireBrick,
+	"floralwhite":          FloralWhite,
+	"forestgreen":          ForestGreen,
+	"gainsboro":            GainsBoro,
+	"ghostwhite":           GhostWhite,
+	"gold":                 Gold,
+	"goldenrod":            Goldenrod,
+	"greenyellow":          GreenYellow,
+	"honeydew":             Honeydew,
+	"hotpink":              HotPink,
+	"indianred":            IndianRed,
+	"indigo":               Indigo,
+	"ivory":                Ivory,
+	"khaki":                Khaki,
+	"lavender":             Lavender,
+	"lavenderblush":        LavenderBlush,
+	"lawngreen":            LawnGreen,
+	"lemonchiffon":         LemonChiffon,
+	"lightblue":            LightBlue,
+	"lightcoral":           LightCoral,
+	"lightcyan":            LightCyan,
+	"lightgoldenrodyellow": LightGoldenrodYellow,
+	"lightgray":            LightGray,
+	"lightgreen":           LightGreen,
+	"lightpink":            LightPink,
+	"lightsalmon":          LightSalmon,
+	"lightseagreen":        LightSeaGreen,
+	"lightskyblue":         LightSkyBlue,
+	"lightslategray":       LightSlateGray,
+	"lightsteelblue":       LightSteelBlue,
+	"lightyellow":          LightYellow,
+	"limegreen":            LimeGreen,
+	"linen":                Linen,
+	"mediumaquamarine":     MediumAquamarine,
+	"mediumblue":           MediumBlue,
+	"mediumorchid":         MediumOrchid,
+	"mediumpurple":         MediumPurple,
+	"mediumseagreen":       MediumSeaGreen,
+	"mediumslateblue":      MediumSlateBlue,
+	"mediumspringgreen":    MediumSpringGreen,
+	"mediumturquoise":      MediumTurquoise,
+	"mediumvioletred":      MediumVioletRed,
+	"midnightblue":         MidnightBlue,
+	"mintcream":            MintCream,
+	"mistyrose":            MistyRose,
+	"moccasin":             Moccasin,
+	"navajowhite":          NavajoWhite,
+	"oldlace":              OldLace,
+	"olivedrab":            OliveDrab,
+	"orange":               Orange,
+	"orangered":            OrangeRed,
+	"orchid":               Orchid,
+	"palegoldenrod":        PaleGoldenrod,
+	"palegreen":            PaleGreen,
+	"paleturquoise":        PaleTurquoise,
+	"palevioletred":        PaleVioletRed,
+	"papayawhip":           PapayaWhip,
+	"peachpuff":            PeachPuff,
+	"peru":                 Peru,
+	"pink":                 Pink,
+	"plum":                 Plum,
+	"powderblue":           PowderBlue,
+	"rebeccapurple":        RebeccaPurple,
+	"rosybrown":            RosyBrown,
+	"royalblue":            RoyalBlue,
+	"saddlebrown":          SaddleBrown,
+	"salmon":               Salmon,
+	"sandybrown":           SandyBrown,
+	"seagreen":             SeaGreen,
+	"seashell":             Seashell,
+	"sienna":               Sienna,
+	"skyblue":              SkyBlue,
+	"slateblue":            SlateBlue,
+	"slategray":            SlateGray,
+	"snow":                 Snow,
+	"springgreen":          SpringGreen,
+	"steelblue":            SteelBlue,
+	"tan":                  Tan,
+	"thistle":              Thistle,
+	"tomato":               Tomato,
+	"turquoise":            Turquoise,
+	"violet":               Violet,
+	"wheat":                Wheat,
+	"whitesmoke":           WhiteSmoke,
+	"yellowgreen":          YellowGreen,
+	"grey":                 Gray,
+	"dimgrey":              DimGray,
+	"darkgrey":             DarkGray,
+	"darkslategrey":        DarkSlateGray,
+	"lightgrey":            LightGray,
+	"lightslategrey":       LightSlateGray,
+	"slategrey":            SlateGray,
+}
@@ -0,0 +1,112 @@
+package color_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/badu/term/color"
+)
+
+// the number of palette colors, followed by the W3C ones
+const knownColors = 256 + 123
+
+// xterm returns the RGB value of a palette color, computed independently of the generated tables
+func xterm(index int) int32 {
+	ansi := [16]int32{
+		0x000000, 0x800000, 0x008000, 0x808000, 0x000080, 0x800080, 0x008080, 0xC0C0C0,
+		0x808080, 0xFF0000, 0x00FF00, 0xFFFF00, 0x0000FF, 0xFF00FF, 0x00FFFF, 0xFFFFFF,
+	}
+	switch {
+	case index < 16:
+		return ansi[index]
+	case index >= 232:
+		v := int32(8 + 10*(index-232))
+		return v<<16 | v<<8 | v
+	}
+	level := func(v int) int32 {
+		if v == 0 {
+			return 0
+		}
+		return int32(55 + 40*v)
+	}
+	index -= 16
+	return level(index/36)<<16 | level(index/6%6)<<8 | level(index%6)
+}
+
+func TestPaletteHex(t *testing.T) {
+	for index := 0; index < 256; index++ {
+		if got, want := color.Hex(color.PaletteColor(index)), xterm(index); got != want {
+			t.Errorf("error : palette color %d should be %06X, got %06X", index, want, got)
+		}
+	}
+}
+
+func TestKnownColors(t *testing.T) {
+	count := 0
+	for color.Hex(color.PaletteColor(count)) >= 0 {
+		count++
+	}
+	if count != knownColors {
+		t.Fatalf("error : expecting %d known colors, got %d", knownColors, count)
+	}
+	for _, c := range []color.Color{color.PaletteColor(knownColors), color.Default, color.Reset} {
+		if hex := color.Hex(c); hex != -1 {
+			t.Errorf("error : %d should have no value, got %06X", uint64(c), hex)
+		}
+		if name := color.Name(c); name != "noname" {
+			t.Errorf("error : %d should have no name, got %q", uint64(c), name)
+		}
+	}
+}
+
+func TestNamesRoundTrip(t *testing.T) {
+	seen := make(map[string]color.Color)
+	for index := 0; index < knownColors; index++ {
+		c := color.PaletteColor(index)
+		name := color.Name(c)
+		if name == "noname" {
+			if index < 16 || index >= 256 {
+				t.Errorf("error : color %d should have a name", index)
+			}
+		} else {
+			if other, ok := seen[name]; ok {
+				t.Errorf("error : %q is the name of both %d and %d", name, uint64(other), uint64(c))
+			}
+			seen[name] = c
+			if got := color.NewColor(name); got != c {
+				t.Errorf("error : NewColor(%q) should be %d, got %d", name, uint64(c), uint64(got))
+			}
+		}
+
+		parsed, err := color.ParseColor(c.String())
+		if err != nil || parsed != c {
+			t.Errorf("error : ParseColor(%q) should be %d, got %d (%v)", c.String(), uint64(c), uint64(parsed), err)
+		}
+
+		hex := fmt.Sprintf("#%06x", color.Hex(c))
+		if got := color.NewColor(hex); got != color.TrueColor(c) || color.Hex(got) != color.Hex(c) {
+			t.Errorf("error : NewColor(%q) should be the true color of %s, got %s", hex, c, got)
+		}
+	}
+	if len(seen) != knownColors-240 {
+		t.Errorf("error : expecting %d names, got %d", knownColors-240, len(seen))
+	}
+}
+
+func TestNameAliases(t *testing.T) {
+	for name, want := range map[string]color.Color{
+		"grey":           color.Gray,
+		"DimGrey":        color.DimGray,
+		"darkgrey":       color.DarkGray,
+		"darkslategrey":  color.DarkSlateGray,
+		"LIGHTGREY":      color.LightGray,
+		"lightslategrey": color.LightSlateGray,
+		"slategrey":      color.SlateGray,
+		"AliceBlue":      color.AliceBlue,
+		"nosuchcolor":    color.Default,
+	} {
+		if got := color.NewColor(name); got != want {
+			t.Errorf("error : NewColor(%q) should be %s, got %s", name, want, got)
+		}
+	}
+}
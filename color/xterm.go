@@ -0,0 +1,155 @@
+package color
+
+import (
+	"strconv"
+	"strings"
+)
+
+// xtermNames are the names of the palette colors 16 to 255, as they are known by xterm users (see https://jonasjacek.github.io/colors/).
+// Some names are used by more than one color : the lookup returns the first one.
+var xtermNames = [240]string{
+	"Grey0", "NavyBlue", "DarkBlue", "Blue3", "Blue3", "Blue1", // 16-21
+	"DarkGreen", "DeepSkyBlue4", "DeepSkyBlue4", "DeepSkyBlue4", "DodgerBlue3", "DodgerBlue2", // 22-27
+	"Green4", "SpringGreen4", "Turquoise4", "DeepSkyBlue3", "DeepSkyBlue3", "DodgerBlue1", // 28-33
+	"Green3", "SpringGreen3", "DarkCyan", "LightSeaGreen", "DeepSkyBlue2", "DeepSkyBlue1", // 34-39
+	"Green3", "SpringGreen3", "SpringGreen2", "Cyan3", "DarkTurquoise", "Turquoise2", // 40-45
+	"Green1", "SpringGreen2", "SpringGreen1", "MediumSpringGreen", "Cyan2", "Cyan1", // 46-51
+	"DarkRed", "DeepPink4", "Purple4", "Purple4", "Purple3", "BlueViolet", // 52-57
+	"Orange4", "Grey37", "MediumPurple4", "SlateBlue3", "SlateBlue3", "RoyalBlue1", // 58-63
+	"Chartreuse4", "DarkSeaGreen4", "PaleTurquoise4", "SteelBlue", "SteelBlue3", "CornflowerBlue", // 64-69
+	"Chartreuse3", "DarkSeaGreen4", "CadetBlue", "CadetBlue", "SkyBlue3", "SteelBlue1", // 70-75
+	"Chartreuse3", "PaleGreen3", "SeaGreen3", "Aquamarine3", "MediumTurquoise", "SteelBlue1", // 76-81
+	"Chartreuse2", "SeaGreen2", "SeaGreen1", "SeaGreen1", "Aquamarine1", "DarkSlateGray2", // 82-87
+	"DarkRed", "DeepPink4", "DarkMagenta", "DarkMagenta", "DarkViolet", "Purple", // 88-93
+	"Orange4", "LightPink4", "Plum4", "MediumPurple3", "MediumPurple3", "SlateBlue1", // 94-99
+	"Yellow4", "Wheat4", "Grey53", "LightSlateGrey", "MediumPurple", "LightSlateBlue", // 100-105
+	"Yellow4", "DarkOliveGreen3", "DarkSeaGreen", "LightSkyBlue3", "LightSkyBlue3", "SkyBlue2", // 106-111
+	"Chartreuse2", "DarkOliveGreen3", "PaleGreen3", "DarkSeaGreen3", "DarkSlateGray3", "SkyBlue1", // 112-117
+	"Chartreuse1", "LightGreen", "LightGreen", "PaleGreen1", "Aquamarine1", "DarkSlateGray1", // 118-123
+	"Red3", "DeepPink4", "MediumVioletRed", "Magenta3", "DarkViolet", "Purple", // 124-129
+	"DarkOrange3", "IndianRed", "HotPink3", "MediumOrchid3", "MediumOrchid", "MediumPurple2", // 130-135
+	"DarkGoldenrod", "LightSalmon3", "RosyBrown", "Grey63", "MediumPurple2", "MediumPurple1", // 136-141
+	"Gold3", "DarkKhaki", "NavajoWhite3", "Grey69", "LightSteelBlue3", "LightSteelBlue", // 142-147
+	"Yellow3", "DarkOliveGreen3", "DarkSeaGreen3", "DarkSeaGreen2", "LightCyan3", "LightSkyBlue1", // 148-153
+	"GreenYellow", "DarkOliveGreen2", "PaleGreen1", "DarkSeaGreen2", "DarkSeaGreen1", "PaleTurquoise1", // 154-159
+	"Red3", "DeepPink3", "DeepPink3", "Magenta3", "Magenta3", "Magenta2", // 160-165
+	"DarkOrange3", "IndianRed", "HotPink3", "HotPink2", "Orchid", "MediumOrchid1", // 166-171
+	"Orange3", "LightSalmon3", "LightPink3", "Pink3", "Plum3", "Violet", // 172-177
+	"Gold3", "LightGoldenrod3", "Tan", "MistyRose3", "Thistle3", "Plum2", // 178-183
+	"Yellow3", "Khaki3", "LightGoldenrod2", "LightYellow3", "Grey84", "LightSteelBlue1", // 184-189
+	"Yellow2", "DarkOliveGreen1", "DarkOliveGreen1", "DarkSeaGreen1", "Honeydew2", "LightCyan1", // 190-195
+	"Red1", "DeepPink2", "DeepPink1", "DeepPink1", "Magenta2", "Magenta1", // 196-201
+	"OrangeRed1", "IndianRed1", "IndianRed1", "HotPink", "HotPink", "MediumOrchid1", // 202-207
+	"DarkOrange", "Salmon1", "LightCoral", "PaleVioletRed1", "Orchid2", "Orchid1", // 208-213
+	"Orange1", "SandyBrown", "LightSalmon1", "LightPink1", "Pink1", "Plum1", // 214-219
+	"Gold1", "LightGoldenrod2", "LightGoldenrod2", "NavajoWhite1", "MistyRose1", "Thistle1", // 220-225
+	"Yellow1", "LightGoldenrod1", "Khaki1", "Wheat1", "Cornsilk1", "Grey100", // 226-231
+	"Grey3", "Grey7", "Grey11", "Grey15", "Grey19", "Grey23", // 232-237
+	"Grey27", "Grey30", "Grey35", "Grey39", "Grey42", "Grey46", // 238-243
+	"Grey50", "Grey54", "Grey58", "Grey62", "Grey66", "Grey70", // 244-249
+	"Grey74", "Grey78", "Grey82", "Grey85", "Grey89", "Grey93", // 250-255
+}
+
+// XtermNames maps the lower case names of the xterm palette (e.g. "deepskyblue4") to colors, so they can be used in configuration files
+var XtermNames = func() map[string]Color {
+	result := make(map[string]Color, len(xtermNames))
+	for idx := len(xtermNames) - 1; idx >= 0; idx-- { // backwards, so the first color having that name wins
+		result[strings.ToLower(xtermNames[idx])] = PaletteColor(idx + 16)
+	}
+	return result
+}()
+
+// Xterm returns the palette color having the xterm name (case insensitive), e.g. Xterm("DeepSkyBlue4"), or Default if the name is unknown
+func Xterm(name string) Color {
+	if c, ok := XtermNames[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return c
+	}
+	return Default
+}
+
+// XtermName returns the xterm name of a palette color from 16 to 255, or an empty string
+func XtermName(c Color) string {
+	idx, ok := Ansi256(c)
+	if !ok || idx < 16 || IsRGB(c) {
+		return ""
+	}
+	return xtermNames[idx-16]
+}
+
+// FromAnsi256 returns the palette color at index, or Default if the index is not in [0..255]
+func FromAnsi256(index int) Color {
+	if index < 0 || index > 255 {
+		return Default
+	}
+	return PaletteColor(index)
+}
+
+// Ansi256 returns the index of the color in the 256 colors palette. RGB colors are mapped to the nearest color of the 6x6x6 cube
+// or of the grayscale ramp (the first 16 colors are skipped, since terminals use to redefine them). Default, Reset and invalid colors return false.
+func Ansi256(c Color) (int, bool) {
+	if !Valid(c) || c&Special != 0 {
+		return -1, false
+	}
+	if !IsRGB(c) {
+		index := int(c &^ valid)
+		if index > 255 {
+			return -1, false
+		}
+		return index, true
+	}
+	r, g, b := ToRGB(c)
+	cube := func(v int) int { // index of the nearest level among 0, 95, 135, 175, 215, 255
+		if v < 48 {
+			return 0
+		}
+		if v < 115 {
+			return 1
+		}
+		return (v - 35) / 40
+	}
+	level := func(i int) int {
+		if i == 0 {
+			return 0
+		}
+		return 55 + i*40
+	}
+	cr, cg, cb := cube(r), cube(g), cube(b)
+	cubeIndex := 16 + 36*cr + 6*cg + cb
+	cubeDist := sqDist(r, g, b, level(cr), level(cg), level(cb))
+
+	average := (r + g + b) / 3
+	gray := 23
+	if average < 238 {
+		gray = term256Gray(average)
+	}
+	grayLevel := 8 + gray*10
+	if sqDist(r, g, b, grayLevel, grayLevel, grayLevel) < cubeDist {
+		return 232 + gray, true
+	}
+	return cubeIndex, true
+}
+
+// term256Gray returns the index (0 to 23) of the nearest gray in the ramp 8, 18, ..., 238
+func term256Gray(v int) int {
+	if v < 8 {
+		return 0
+	}
+	return (v - 3) / 10
+}
+
+func sqDist(r1, g1, b1, r2, g2, b2 int) int {
+	return (r1-r2)*(r1-r2) + (g1-g2)*(g1-g2) + (b1-b2)*(b1-b2)
+}
+
+// parsePaletteName parses "colorN" (as returned by Color.String) and the xterm names - lower case and trimmed string is expected
+func parsePaletteName(spec string) (Color, bool) {
+	if strings.HasPrefix(spec, "color") {
+		if index, err := strconv.Atoi(spec[len("color"):]); err == nil && index >= 0 && index <= 255 {
+			return PaletteColor(index), true
+		}
+	}
+	if c, ok := XtermNames[spec]; ok {
+		return c, true
+	}
+	return Default, false
+}
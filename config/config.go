@@ -0,0 +1,409 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/key"
+	"github.com/badu/term/style"
+)
+
+// Kind is the type of a value, checked by Validate
+type Kind int
+
+const (
+	String   Kind = iota //
+	Int                  //
+	Float                // integers are accepted too
+	Bool                 //
+	Duration             // a string, e.g. "250ms"
+	Color                // a string, e.g. "teal" or "#008080" (see color.ParseColor)
+	Binding              // a key stroke, e.g. "Ctrl+S" (see key.ParseBinding), or an array of them
+	Strings              // an array of strings
+)
+
+// String implements fmt.Stringer interface
+func (k Kind) String() string {
+	switch k {
+	case String:
+		return "string"
+	case Int:
+		return "integer"
+	case Float:
+		return "number"
+	case Bool:
+		return "boolean"
+	case Duration:
+		return "duration"
+	case Color:
+		return "color"
+	case Binding:
+		return "key binding"
+	case Strings:
+		return "array of strings"
+	default:
+		return "unknown"
+	}
+}
+
+// Schema declares the keys which a configuration can have and their kinds, e.g. {"theme.text": config.Color, "tree.indent": config.Int}.
+// A key ending with ".*" declares all the keys of a table, e.g. {"keymap.*": config.Binding}.
+type Schema map[string]Kind
+
+// Error is a problem found in the configuration : a syntax error, or a value which doesn't match the schema
+type Error struct {
+	Line    int    // of the configuration file, zero if unknown
+	Key     string // empty for the syntax errors
+	Message string //
+}
+
+// Error implements error interface
+func (e *Error) Error() string {
+	var sb strings.Builder
+	sb.WriteString("config:")
+	if e.Line > 0 {
+		sb.WriteString(fmt.Sprintf(" line %d:", e.Line))
+	}
+	if e.Key != "" {
+		sb.WriteString(" " + e.Key + ":")
+	}
+	sb.WriteString(" " + e.Message)
+	return sb.String()
+}
+
+// Errors are all the problems found by Validate
+type Errors []*Error
+
+// Error implements error interface
+func (e Errors) Error() string {
+	lines := make([]string, len(e))
+	for idx, err := range e {
+		lines[idx] = err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// value is a parsed value, with the line where it was found
+type value struct {
+	raw  interface{} // string, int64, float64, bool or []interface{}
+	line int         //
+}
+
+// Config holds the values read from a configuration file, by their dotted keys (the table and the key, e.g. "theme.text").
+// The typed accessors return the default value when the key is missing or has another type, so Validate should be called once, after loading.
+type Config struct {
+	values map[string]*value //
+}
+
+// Load reads the configuration, written in a subset of TOML : tables, key = value pairs and comments, the values being strings, integers, floats, booleans,
+// and arrays of them on a single line. Dates, inline tables, arrays of tables and multi-line values are not supported.
+func Load(r io.Reader) (*Config, error) {
+	values, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+	return &Config{values: values}, nil
+}
+
+// LoadFile reads the configuration file
+func LoadFile(path string) (*Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// Has returns true if the key is set
+func (c *Config) Has(key string) bool {
+	_, ok := c.values[key]
+	return ok
+}
+
+// Keys returns the keys of the table (without its name), sorted, or all the keys if the table is empty
+func (c *Config) Keys(table string) []string {
+	var result []string
+	for name := range c.values {
+		switch {
+		case table == "":
+			result = append(result, name)
+		case strings.HasPrefix(name, table+"."):
+			result = append(result, strings.TrimPrefix(name, table+"."))
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// String returns the string value of the key, or the default
+func (c *Config) String(key, def string) string {
+	if s, ok := c.raw(key).(string); ok {
+		return s
+	}
+	return def
+}
+
+// Int returns the integer value of the key, or the default
+func (c *Config) Int(key string, def int) int {
+	if n, ok := c.raw(key).(int64); ok {
+		return int(n)
+	}
+	return def
+}
+
+// Float returns the number value of the key, or the default
+func (c *Config) Float(key string, def float64) float64 {
+	switch n := c.raw(key).(type) {
+	case float64:
+		return n
+	case int64:
+		return float64(n)
+	}
+	return def
+}
+
+// Bool returns the boolean value of the key, or the default
+func (c *Config) Bool(key string, def bool) bool {
+	if b, ok := c.raw(key).(bool); ok {
+		return b
+	}
+	return def
+}
+
+// Duration returns the duration value of the key (e.g. "250ms"), or the default
+func (c *Config) Duration(key string, def time.Duration) time.Duration {
+	if s, ok := c.raw(key).(string); ok {
+		if d, err := time.ParseDuration(s); err == nil {
+			return d
+		}
+	}
+	return def
+}
+
+// Color returns the color value of the key (e.g. "teal" or "#008080"), or the default
+func (c *Config) Color(key string, def color.Color) color.Color {
+	if s, ok := c.raw(key).(string); ok {
+		if parsed, err := color.ParseColor(s); err == nil {
+			return parsed
+		}
+	}
+	return def
+}
+
+// Strings returns the array of strings of the key (a single string being an array of one), or the default
+func (c *Config) Strings(key string, def []string) []string {
+	if strs, err := toStrings(c.raw(key)); err == nil {
+		return strs
+	}
+	return def
+}
+
+// Bindings returns the key strokes of the key (a single one or an array of them), or the default
+func (c *Config) Bindings(key string, def []key.Binding) []key.Binding {
+	if bindings, err := toBindings(c.raw(key)); err == nil {
+		return bindings
+	}
+	return def
+}
+
+// Validate checks the keys and their values against the schema, returning all the problems found as Errors
+func (c *Config) Validate(schema Schema) error {
+	var result Errors
+	for _, name := range c.Keys("") {
+		v := c.values[name]
+		kind, ok := schema[name]
+		if !ok {
+			if idx := strings.LastIndexByte(name, '.'); idx >= 0 {
+				kind, ok = schema[name[:idx]+".*"]
+			}
+		}
+		if !ok {
+			result = append(result, &Error{Line: v.line, Key: name, Message: "unknown key"})
+			continue
+		}
+		if err := check(v.raw, kind); err != nil {
+			result = append(result, &Error{Line: v.line, Key: name, Message: err.Error()})
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// Theme returns the base theme, having the colors set in the [theme] table replaced : the keys are the names of the Theme fields, without the "Color" suffix,
+// in snake case (e.g. "text", "main_background", "keyword"). The unknown keys and invalid colors are reported as Errors.
+func (c *Config) Theme(base style.Theme) (style.Theme, error) {
+	fields := map[string]*color.Color{
+		"border":              &base.BorderColor,
+		"main_background":     &base.MainBackgroundColor,
+		"hover_background":    &base.HoverBackgroundColor,
+		"selected_background": &base.SelectedBackgroundColor,
+		"inverse_background":  &base.InverseBackgroundColor,
+		"text":                &base.TextColor,
+		"hover_text":          &base.HoverTextColor,
+		"selected_text":       &base.SelectedTextColor,
+		"inverse_text":        &base.InverseTextColor,
+		"keyword":             &base.KeywordColor,
+		"type":                &base.TypeColor,
+		"literal":             &base.LiteralColor,
+		"string":              &base.StringColor,
+		"number":              &base.NumberColor,
+		"comment":             &base.CommentColor,
+		"operator":            &base.OperatorColor,
+		"invalid":             &base.InvalidColor,
+	}
+	var problems Errors
+	for _, name := range c.Keys("theme") {
+		v := c.values["theme."+name]
+		field, ok := fields[name]
+		if !ok {
+			problems = append(problems, &Error{Line: v.line, Key: "theme." + name, Message: "unknown theme color"})
+			continue
+		}
+		if err := check(v.raw, Color); err != nil {
+			problems = append(problems, &Error{Line: v.line, Key: "theme." + name, Message: err.Error()})
+			continue
+		}
+		*field, _ = color.ParseColor(v.raw.(string))
+	}
+	if len(problems) > 0 {
+		return base, problems
+	}
+	return base, nil
+}
+
+// Keymap returns the actions of the [keymap] table, each key being the name of an action and its value the key strokes triggering it, e.g. save = ["Ctrl+S", "F2"].
+// The invalid key strokes are reported as Errors.
+func (c *Config) Keymap() (Keymap, error) {
+	result := make(Keymap)
+	var problems Errors
+	for _, action := range c.Keys("keymap") {
+		v := c.values["keymap."+action]
+		bindings, err := toBindings(v.raw)
+		if err != nil {
+			problems = append(problems, &Error{Line: v.line, Key: "keymap." + action, Message: err.Error()})
+			continue
+		}
+		result[action] = bindings
+	}
+	if len(problems) > 0 {
+		return result, problems
+	}
+	return result, nil
+}
+
+// Keymap maps the names of the actions to their key strokes
+type Keymap map[string][]key.Binding
+
+// Action returns the name of the action triggered by the key event, or false if there is none. When several actions have the same key stroke, the first one in alphabetical order wins.
+func (k Keymap) Action(ev term.KeyEvent) (string, bool) {
+	actions := make([]string, 0, len(k))
+	for action := range k {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	for _, action := range actions {
+		for _, binding := range k[action] {
+			if binding.Matches(ev) {
+				return action, true
+			}
+		}
+	}
+	return "", false
+}
+
+// raw returns the parsed value of the key, nil if it's missing
+func (c *Config) raw(key string) interface{} {
+	if v, ok := c.values[key]; ok {
+		return v.raw
+	}
+	return nil
+}
+
+// check returns an error if the value isn't of that kind
+func check(raw interface{}, kind Kind) error {
+	ok := false
+	switch kind {
+	case String:
+		_, ok = raw.(string)
+	case Int:
+		_, ok = raw.(int64)
+	case Float:
+		switch raw.(type) {
+		case int64, float64:
+			ok = true
+		}
+	case Bool:
+		_, ok = raw.(bool)
+	case Duration:
+		if s, isString := raw.(string); isString {
+			if _, err := time.ParseDuration(s); err != nil {
+				return fmt.Errorf("invalid duration %q", s)
+			}
+			ok = true
+		}
+	case Color:
+		if s, isString := raw.(string); isString {
+			if _, err := color.ParseColor(s); err != nil {
+				return fmt.Errorf("invalid color %q", s)
+			}
+			ok = true
+		}
+	case Binding:
+		if _, err := toBindings(raw); err != nil {
+			return err
+		}
+		ok = true
+	case Strings:
+		_, err := toStrings(raw)
+		ok = err == nil
+	}
+	if !ok {
+		return fmt.Errorf("expecting a %s", kind)
+	}
+	return nil
+}
+
+// toStrings converts a string or an array of strings
+func toStrings(raw interface{}) ([]string, error) {
+	switch v := raw.(type) {
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expecting an array of strings")
+			}
+			result = append(result, s)
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("expecting an array of strings")
+}
+
+// toBindings converts a key stroke or an array of them
+func toBindings(raw interface{}) ([]key.Binding, error) {
+	specs, err := toStrings(raw)
+	if err != nil {
+		return nil, fmt.Errorf("expecting a key binding or an array of them")
+	}
+	result := make([]key.Binding, 0, len(specs))
+	for _, spec := range specs {
+		binding, err := key.ParseBinding(spec)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, binding)
+	}
+	return result, nil
+}
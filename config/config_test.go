@@ -0,0 +1,256 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/key"
+	"github.com/badu/term/style"
+)
+
+const sample = `# application settings
+title = "Editor \"one\"" # a comment
+path = 'C:\temp'
+tabs = 4
+ratio = 0.5
+wrap = true
+blink = "500ms"
+files = ["a.go", "b.go"]
+
+[theme]
+text = "white"
+main_background = "#102030"
+
+[keymap]
+save = ["Ctrl+S", "F2"]
+quit = "Ctrl+Q"
+
+[tree]
+indent = 1_0
+`
+
+func TestLoad(t *testing.T) {
+	cfg, err := Load(strings.NewReader(sample))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	if s := cfg.String("title", ""); s != `Editor "one"` {
+		t.Errorf("error : expecting the title, got %q", s)
+	}
+	if s := cfg.String("path", ""); s != `C:\temp` {
+		t.Errorf("error : expecting the literal string, got %q", s)
+	}
+	if n := cfg.Int("tabs", 0); n != 4 {
+		t.Errorf("error : expecting 4 tabs, got %d", n)
+	}
+	if f := cfg.Float("ratio", 0); f != 0.5 {
+		t.Errorf("error : expecting the ratio 0.5, got %f", f)
+	}
+	if f := cfg.Float("tabs", 0); f != 4 {
+		t.Errorf("error : integers should be read as floats, got %f", f)
+	}
+	if !cfg.Bool("wrap", false) {
+		t.Errorf("error : expecting wrap to be true")
+	}
+	if d := cfg.Duration("blink", 0); d != 500*time.Millisecond {
+		t.Errorf("error : expecting the blink duration, got %v", d)
+	}
+	if files := cfg.Strings("files", nil); len(files) != 2 || files[1] != "b.go" {
+		t.Errorf("error : expecting the files, got %v", files)
+	}
+	if n := cfg.Int("tree.indent", 0); n != 10 {
+		t.Errorf("error : expecting the indent of the tree, got %d", n)
+	}
+	if n := cfg.Int("missing", 7); n != 7 {
+		t.Errorf("error : expecting the default for a missing key, got %d", n)
+	}
+	if n := cfg.Int("title", 7); n != 7 {
+		t.Errorf("error : expecting the default for another type, got %d", n)
+	}
+	if c := cfg.Color("theme.text", color.Default); c != color.White {
+		t.Errorf("error : expecting white text, got %v", c)
+	}
+	if keys := cfg.Keys("keymap"); len(keys) != 2 || keys[0] != "quit" || keys[1] != "save" {
+		t.Errorf("error : expecting the sorted keys of the keymap, got %v", keys)
+	}
+}
+
+func TestSyntaxErrors(t *testing.T) {
+	for _, test := range []struct {
+		source string
+		line   int
+	}{
+		{"a = 1\nb = \"unterminated", 2},
+		{"a = 1\na = 2", 2},
+		{"[theme\ntext = 1", 1},
+		{"\n\nnothing", 3},
+		{"a = [1, 2", 1},
+		{"a = 1 2", 1},
+		{"a = yes", 1},
+	} {
+		_, err := Load(strings.NewReader(test.source))
+		var cfgErr *Error
+		if !errors.As(err, &cfgErr) {
+			t.Errorf("error : %q : expecting a config error, got %v", test.source, err)
+			continue
+		}
+		if cfgErr.Line != test.line {
+			t.Errorf("error : %q : expecting the error on line %d, got %d (%v)", test.source, test.line, cfgErr.Line, err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg, err := Load(strings.NewReader(sample))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	schema := Schema{
+		"title":       String,
+		"path":        String,
+		"tabs":        Int,
+		"ratio":       Float,
+		"wrap":        Bool,
+		"blink":       Duration,
+		"files":       Strings,
+		"theme.*":     Color,
+		"keymap.*":    Binding,
+		"tree.indent": Int,
+	}
+	if err := cfg.Validate(schema); err != nil {
+		t.Errorf("error : the sample should be valid : %v", err)
+	}
+
+	cfg, err = Load(strings.NewReader("tabs = \"four\"\nunknown = 1\n[theme]\ntext = \"nocolor\"\n[keymap]\nsave = \"Hyper+S\""))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	err = cfg.Validate(schema)
+	var problems Errors
+	if !errors.As(err, &problems) {
+		t.Fatalf("error : expecting Errors, got %v", err)
+	}
+	lines := map[string]int{}
+	for _, problem := range problems {
+		lines[problem.Key] = problem.Line
+	}
+	for name, line := range map[string]int{"tabs": 1, "unknown": 2, "theme.text": 4, "keymap.save": 6} {
+		if lines[name] != line {
+			t.Errorf("error : expecting a problem with %s on line %d, got %v", name, line, err)
+		}
+	}
+}
+
+func TestTheme(t *testing.T) {
+	cfg, err := Load(strings.NewReader(sample))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	base := style.Theme{TextColor: color.Black, BorderColor: color.Red}
+	theme, err := cfg.Theme(base)
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	if theme.TextColor != color.White {
+		t.Errorf("error : expecting the text color from the file, got %v", theme.TextColor)
+	}
+	if theme.BorderColor != color.Red {
+		t.Errorf("error : expecting the border color from the base theme, got %v", theme.BorderColor)
+	}
+	if theme.MainBackgroundColor != color.NewRGBColor(0x10, 0x20, 0x30) {
+		t.Errorf("error : expecting the main background from the file, got %v", theme.MainBackgroundColor)
+	}
+
+	cfg, _ = Load(strings.NewReader("[theme]\nforeground = \"red\""))
+	if _, err := cfg.Theme(base); err == nil {
+		t.Errorf("error : unknown theme colors should be reported")
+	}
+}
+
+func TestKeymap(t *testing.T) {
+	cfg, err := Load(strings.NewReader(sample))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	keymap, err := cfg.Keymap()
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	for _, test := range []struct {
+		ev     term.KeyEvent
+		action string
+	}{
+		{key.NewEvent(key.CtrlS, 0, key.ModNone), "save"},
+		{key.NewEvent(key.F2, 0, key.ModNone), "save"},
+		{key.NewEvent(key.CtrlQ, 0, key.ModNone), "quit"},
+		{key.NewEvent(key.F3, 0, key.ModNone), ""},
+	} {
+		action, ok := keymap.Action(test.ev)
+		if action != test.action || ok != (test.action != "") {
+			t.Errorf("error : %s : expecting %q, got %q", test.ev.Name(), test.action, action)
+		}
+	}
+}
+
+func TestWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "app.toml")
+	if err := ioutil.WriteFile(path, []byte("tabs = 4\n"), 0644); err != nil {
+		t.Fatalf("error : %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan *Config, 1)
+	failures := make(chan error, 1)
+	cfg, err := Watch(ctx, path, func(cfg *Config, err error) {
+		if err != nil {
+			failures <- err
+			return
+		}
+		changes <- cfg
+	}, WithPollInterval(5*time.Millisecond), WithoutSignal())
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	if n := cfg.Int("tabs", 0); n != 4 {
+		t.Errorf("error : expecting 4 tabs, got %d", n)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("tabs = 8 # wider\n"), 0644); err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	select {
+	case cfg := <-changes:
+		if n := cfg.Int("tabs", 0); n != 8 {
+			t.Errorf("error : expecting 8 tabs after the change, got %d", n)
+		}
+	case err := <-failures:
+		t.Errorf("error : %v", err)
+	case <-time.After(2 * time.Second):
+		t.Errorf("error : the change was not noticed")
+	}
+
+	if err := ioutil.WriteFile(path, []byte("tabs = = 8\n"), 0644); err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	select {
+	case <-changes:
+		t.Errorf("error : an invalid file should be reported as an error")
+	case <-failures:
+	case <-time.After(2 * time.Second):
+		t.Errorf("error : the invalid change was not noticed")
+	}
+}
@@ -0,0 +1,214 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// parser reads the subset of TOML used by the configuration files : tables ([section] and [section.sub]), key = value pairs, comments,
+// and the values being strings ("basic" with escapes, or 'literal'), integers, floats, booleans and arrays of them on a single line.
+type parser struct {
+	values  map[string]*value // by full key, e.g. "theme.text"
+	section string            // the current table
+	line    int               //
+}
+
+// parse reads the configuration, returning the first syntax error
+func parse(r io.Reader) (map[string]*value, error) {
+	p := &parser{values: make(map[string]*value)}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		p.line++
+		if err := p.parseLine(scanner.Text()); err != nil {
+			return nil, &Error{Line: p.line, Message: err.Error()}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p.values, nil
+}
+
+// parseLine reads a table header, a key = value pair, a comment or an empty line
+func (p *parser) parseLine(line string) error {
+	line = strings.TrimSpace(line)
+	switch {
+	case line == "" || line[0] == '#':
+		return nil
+	case line[0] == '[':
+		end := strings.IndexByte(line, ']')
+		if end < 0 || strings.TrimSpace(stripComment(line[end+1:])) != "" {
+			return fmt.Errorf("malformed table header")
+		}
+		section, err := parseKey(line[1:end])
+		if err != nil {
+			return err
+		}
+		p.section = section
+		return nil
+	}
+
+	eq := strings.IndexByte(line, '=')
+	if eq < 0 {
+		return fmt.Errorf("expecting key = value")
+	}
+	name, err := parseKey(line[:eq])
+	if err != nil {
+		return err
+	}
+	if p.section != "" {
+		name = p.section + "." + name
+	}
+	if _, ok := p.values[name]; ok {
+		return fmt.Errorf("%s is defined twice", name)
+	}
+	parsed, rest, err := parseValue(strings.TrimSpace(line[eq+1:]))
+	if err != nil {
+		return fmt.Errorf("%s : %v", name, err)
+	}
+	if strings.TrimSpace(stripComment(rest)) != "" {
+		return fmt.Errorf("%s : unexpected %q after the value", name, strings.TrimSpace(rest))
+	}
+	p.values[name] = &value{raw: parsed, line: p.line}
+	return nil
+}
+
+// parseKey reads a dotted key, whose parts are bare (letters, digits, "_" and "-") or quoted
+func parseKey(s string) (string, error) {
+	var parts []string
+	for _, part := range strings.Split(strings.TrimSpace(s), ".") {
+		part = strings.TrimSpace(part)
+		if len(part) >= 2 && (part[0] == '"' || part[0] == '\'') && part[len(part)-1] == part[0] {
+			parts = append(parts, part[1:len(part)-1])
+			continue
+		}
+		if part == "" {
+			return "", fmt.Errorf("empty key")
+		}
+		for _, r := range part {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+				return "", fmt.Errorf("invalid key %q", part)
+			}
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// parseValue reads the value at the start of s, returning what follows it
+func parseValue(s string) (interface{}, string, error) {
+	switch {
+	case s == "":
+		return nil, "", fmt.Errorf("missing value")
+	case s[0] == '"':
+		return parseBasicString(s)
+	case s[0] == '\'':
+		end := strings.IndexByte(s[1:], '\'')
+		if end < 0 {
+			return nil, "", fmt.Errorf("unterminated string")
+		}
+		return s[1 : end+1], s[end+2:], nil
+	case s[0] == '[':
+		return parseArray(s)
+	}
+
+	end := strings.IndexAny(s, " \t,]#")
+	if end < 0 {
+		end = len(s)
+	}
+	token, rest := s[:end], s[end:]
+	switch token {
+	case "true":
+		return true, rest, nil
+	case "false":
+		return false, rest, nil
+	}
+	number := strings.Replace(token, "_", "", -1)
+	if n, err := strconv.ParseInt(number, 0, 64); err == nil {
+		return n, rest, nil
+	}
+	if f, err := strconv.ParseFloat(number, 64); err == nil {
+		return f, rest, nil
+	}
+	return nil, "", fmt.Errorf("invalid value %q", token)
+}
+
+// parseBasicString reads a double quoted string, with its escapes
+func parseBasicString(s string) (interface{}, string, error) {
+	var sb strings.Builder
+	for idx := 1; idx < len(s); idx++ {
+		switch c := s[idx]; c {
+		case '"':
+			return sb.String(), s[idx+1:], nil
+		case '\\':
+			idx++
+			if idx >= len(s) {
+				return nil, "", fmt.Errorf("unterminated string")
+			}
+			switch s[idx] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			case '"', '\\':
+				sb.WriteByte(s[idx])
+			case 'u', 'U':
+				size := 4
+				if s[idx] == 'U' {
+					size = 8
+				}
+				if idx+size >= len(s) {
+					return nil, "", fmt.Errorf("invalid unicode escape")
+				}
+				code, err := strconv.ParseUint(s[idx+1:idx+1+size], 16, 32)
+				if err != nil {
+					return nil, "", fmt.Errorf("invalid unicode escape")
+				}
+				sb.WriteRune(rune(code))
+				idx += size
+			default:
+				return nil, "", fmt.Errorf("invalid escape \\%c", s[idx])
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return nil, "", fmt.Errorf("unterminated string")
+}
+
+// parseArray reads the values between brackets, separated by commas
+func parseArray(s string) (interface{}, string, error) {
+	result := []interface{}{}
+	rest := strings.TrimSpace(s[1:])
+	for {
+		if rest != "" && rest[0] == ']' {
+			return result, rest[1:], nil
+		}
+		item, after, err := parseValue(rest)
+		if err != nil {
+			return nil, "", err
+		}
+		result = append(result, item)
+		rest = strings.TrimSpace(after)
+		switch {
+		case rest != "" && rest[0] == ',':
+			rest = strings.TrimSpace(rest[1:])
+		case rest != "" && rest[0] == ']':
+		default:
+			return nil, "", fmt.Errorf("unterminated array")
+		}
+	}
+}
+
+// stripComment removes the comment at the end of a line, which has no strings left
+func stripComment(s string) string {
+	if idx := strings.IndexByte(s, '#'); idx >= 0 {
+		return s[:idx]
+	}
+	return s
+}
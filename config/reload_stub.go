@@ -0,0 +1,11 @@
+//go:build js || nacl || plan9 || windows
+// +build js nacl plan9 windows
+
+package config
+
+import (
+	"os"
+)
+
+// reloadSignals are empty, since there is no SIGHUP : the file is only polled
+var reloadSignals []os.Signal
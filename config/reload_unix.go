@@ -0,0 +1,12 @@
+//go:build !js && !nacl && !plan9 && !windows
+// +build !js,!nacl,!plan9,!windows
+
+package config
+
+import (
+	"os"
+	"syscall"
+)
+
+// reloadSignals are the signals asking to read the configuration file again
+var reloadSignals = []os.Signal{syscall.SIGHUP}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"time"
+)
+
+const defaultPollInterval = time.Second // how often the file is checked for changes
+
+// WatchOption configures Watch
+type WatchOption func(w *watcher)
+
+// WithPollInterval sets how often the modification time and the size of the file are checked. Zero disables the polling, leaving SIGHUP only. Default is one second.
+func WithPollInterval(interval time.Duration) WatchOption {
+	return func(w *watcher) {
+		if interval >= 0 {
+			w.interval = interval
+		}
+	}
+}
+
+// WithoutSignal disables the reloading on SIGHUP, e.g. when the application handles that signal itself
+func WithoutSignal() WatchOption {
+	return func(w *watcher) {
+		w.signals = nil
+	}
+}
+
+// watcher reads the configuration file again when it changes
+type watcher struct {
+	path     string                       //
+	onChange func(cfg *Config, err error) //
+	modTime  time.Time                    // of the last read
+	size     int64                        // of the last read
+	interval time.Duration                // set by WithPollInterval
+	signals  []os.Signal                  // SIGHUP, unless WithoutSignal
+}
+
+// Watch reads the configuration file, then reads it again each time it changes or the process receives SIGHUP, until the context is done.
+// The first configuration is returned, the next ones are passed to onChange, which is called from another goroutine. When the changed file is invalid,
+// onChange receives the error instead, so the application can keep its current configuration and report the problem.
+func Watch(ctx context.Context, path string, onChange func(cfg *Config, err error), opts ...WatchOption) (*Config, error) {
+	w := &watcher{path: path, onChange: onChange, interval: defaultPollInterval, signals: reloadSignals}
+	for _, opt := range opts {
+		opt(w)
+	}
+	w.modTime, w.size = w.stat()
+	cfg, err := LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	go w.run(ctx)
+	return cfg, nil
+}
+
+// run polls the file and listens for the signals, until the context is done
+func (w *watcher) run(ctx context.Context) {
+	var sigCh chan os.Signal
+	if len(w.signals) > 0 {
+		sigCh = make(chan os.Signal, 1)
+		signal.Notify(sigCh, w.signals...)
+		defer signal.Stop(sigCh)
+	}
+	var tickCh <-chan time.Time
+	if w.interval > 0 {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		tickCh = ticker.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigCh:
+			w.modTime, w.size = w.stat()
+			w.reload()
+		case <-tickCh:
+			modTime, size := w.stat()
+			if modTime.Equal(w.modTime) && size == w.size {
+				continue
+			}
+			w.modTime, w.size = modTime, size
+			w.reload()
+		}
+	}
+}
+
+// reload reads the file and notifies the result
+func (w *watcher) reload() {
+	cfg, err := LoadFile(w.path)
+	if w.onChange != nil {
+		w.onChange(cfg, err)
+	}
+}
+
+// stat returns the modification time and the size of the file, zero when it's missing
+func (w *watcher) stat() (time.Time, int64) {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return time.Time{}, 0
+	}
+	return info.ModTime(), info.Size()
+}
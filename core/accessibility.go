@@ -0,0 +1,93 @@
+package core
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+// announceStyle is used for the announcements, unless the application has set a status having its own style
+var announceStyle = style.Style{Fg: color.Default, Bg: color.Default, Attrs: style.Reverse}
+
+// WithHighContrast is a functional option for starting in high contrast mode, see term.HighContraster. Default is disabled.
+func WithHighContrast(enabled bool) Option {
+	return func(c *core) {
+		c.highContrast = enabled
+	}
+}
+
+// Announce implements term.Announcer interface
+func (c *core) Announce(text string, priority term.AnnouncePriority) {
+	text = printable(text)
+	if text == "" {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if c.out == nil {
+		return
+	}
+	if c.plain {
+		c.writeOut(bytes.NewBufferString(text + "\n")) // a line of its own, read as any other
+		return
+	}
+	st := announceStyle
+	if c.status.shown {
+		st = c.status.st
+	}
+	c.setStatus(text, st)
+	if priority == term.AnnounceAssertive {
+		c.notify(printable(filepath.Base(os.Args[0])), text)
+	}
+}
+
+// SetHighContrast implements term.HighContraster interface
+func (c *core) SetHighContrast(enabled bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.highContrast == enabled {
+		return
+	}
+	c.highContrast = enabled
+	if c.out == nil || c.plain {
+		return
+	}
+	buf := bytes.NewBuffer(nil)
+	c.comm.PutAttrOff(buf)
+	c.cachedFG, c.cachedBG, c.cachedAttrs = color.Default, color.Default, style.None
+	pixels := make([]term.PixelGetter, 0, len(c.content))
+	for _, pixel := range c.content {
+		pixels = append(pixels, pixel)
+	}
+	c.drawPixels(buf, pixels...)
+	c.writeOut(buf)
+	c.drawChrome()
+	c.restoreCursor()
+}
+
+// HighContrast implements term.HighContraster interface
+func (c *core) HighContrast() bool {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.highContrast
+}
+
+// contrasted returns the colors and the attributes used in high contrast mode
+func contrasted(fg, bg color.Color, attrs style.Mask) (color.Color, color.Color, style.Mask) {
+	attrs &^= style.Dim | style.Blink
+	if !color.Valid(bg) {
+		return fg, bg, attrs // the background of the terminal is unknown
+	}
+	if !color.Valid(fg) {
+		return color.ContrastingTextColor(bg), bg, attrs
+	}
+	return color.EnsureContrast(fg, bg, color.ContrastAAA), bg, attrs
+}
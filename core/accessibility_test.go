@@ -0,0 +1,82 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+// activePixel is a regionPixel which can be registered as active, the setters being unused
+type activePixel struct {
+	term.Pixel
+	*regionPixel
+}
+
+func (p *activePixel) DrawCh() chan term.PixelGetter { return nil }
+func (p *activePixel) Style() (color.Color, color.Color, style.Mask) {
+	return p.regionPixel.Style()
+}
+func (p *activePixel) HasUnicode() bool       { return false }
+func (p *activePixel) Unicode() *term.Unicode { return nil }
+func (p *activePixel) Rune() rune             { return p.r }
+func (p *activePixel) Width() int             { return 1 }
+func (p *activePixel) PositionHash() int      { return p.hash }
+
+func TestAnnounce(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+
+	c.Announce("3 files\x1b[2J copied", term.AnnouncePolite)
+	out := written()
+	if strings.Contains(out, "\x1b[2J") || !strings.Contains(out, "3 files [2J copied") {
+		t.Errorf("error : the announcement should be shown without its control characters, got %q", out)
+	}
+	if !strings.HasPrefix(out, "\x1b[50;1H") || strings.Contains(out, "\x1b]777") {
+		t.Errorf("error : a polite announcement should be shown on the status row only, got %q", out)
+	}
+	if rows := c.Size().Rows; rows != benchRows-1 {
+		t.Errorf("error : the status row should be reserved, got %d rows", rows)
+	}
+
+	c.notifications = NotifyOSC777
+	c.Announce("connection lost", term.AnnounceAssertive)
+	if out := written(); !strings.Contains(out, "connection lost") || !strings.Contains(out, ";connection lost\x1b\\") || !strings.Contains(out, "\x1b]777;notify;") {
+		t.Errorf("error : an assertive announcement should be sent as a notification too, got %q", out)
+	}
+
+	c.plain = true
+	c.Announce("done", term.AnnouncePolite)
+	if out := written(); out != "done\n" {
+		t.Errorf("error : in plain mode, the announcement should be a line, got %q", out)
+	}
+}
+
+func TestHighContrast(t *testing.T) {
+	fg, bg, attrs := contrasted(color.NewRGBColor(0x40, 0x40, 0x40), color.Black, style.Dim|style.Bold)
+	if color.ContrastRatio(fg, bg) < color.ContrastAAA || attrs != style.Bold {
+		t.Errorf("error : the foreground should reach the AAA ratio and dim should be dropped, got %v %v", color.ContrastRatio(fg, bg), attrs)
+	}
+	if fg, _, _ := contrasted(color.Default, color.White, style.None); fg != color.Black {
+		t.Errorf("error : the default foreground should contrast with the background, got %v", fg)
+	}
+	if fg, _, _ := contrasted(color.Gray, color.Default, style.None); fg != color.Gray {
+		t.Errorf("error : the colors should be kept when the background is unknown")
+	}
+
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+	pixel := &activePixel{regionPixel: &regionPixel{hash: term.Hash(0, 0), r: 'x', st: style.Style{Fg: color.NewRGBColor(0x30, 0x30, 0x30), Bg: color.Black}}}
+	c.content = map[int]term.Pixel{pixel.hash: pixel}
+	c.drawPixels(c.out, pixel)
+	normal := written()
+	c.SetHighContrast(true)
+	if !c.HighContrast() {
+		t.Errorf("error : high contrast should be enabled")
+	}
+	if out := written(); out == "" || out == normal || !strings.Contains(out, "x") {
+		t.Errorf("error : the active pixels should be drawn again, with other colors, got %q", out)
+	}
+}
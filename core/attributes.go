@@ -0,0 +1,97 @@
+package core
+
+import (
+	"github.com/badu/term/style"
+)
+
+// BlinkPolicy tells how the Blink attribute is rendered, since some terminals render it poorly or not at all (see WithBlinkPolicy)
+type BlinkPolicy struct {
+	allow       bool       // blink is sent to the terminal
+	replacement style.Mask // otherwise, these attributes are rendered instead
+}
+
+// AllowBlink renders Blink, if the terminal can. This is the default.
+var AllowBlink = BlinkPolicy{allow: true}
+
+// ReplaceBlinkWith renders the attributes instead of Blink. Use style.None for dropping it.
+func ReplaceBlinkWith(m style.Mask) BlinkPolicy {
+	return BlinkPolicy{replacement: m &^ style.Blink}
+}
+
+// WithBlinkPolicy is a functional option for globally disabling or substituting the Blink attribute, regardless of what the pixels ask for. Default is AllowBlink.
+func WithBlinkPolicy(policy BlinkPolicy) Option {
+	return func(c *core) {
+		c.blinkPolicy = policy
+	}
+}
+
+// attrTable maps single attributes to the ones rendered instead
+type attrTable map[style.Mask]style.Mask
+
+// DefaultAttributeFallbacks is a suggested table for WithAttributeFallbacks
+var DefaultAttributeFallbacks = map[style.Mask]style.Mask{
+	style.Italic:        style.Underline,
+	style.StrikeThrough: style.Dim,
+	style.Blink:         style.Bold,
+	style.Dim:           style.None,
+}
+
+// WithAttributeFallbacks is a functional option for rendering other attributes instead of the ones the terminal definition lacks (e.g. sitm for style.Italic, smxx for style.StrikeThrough),
+// which are otherwise silently dropped. The keys are single attributes, see DefaultAttributeFallbacks. Default is no fallbacks.
+func WithAttributeFallbacks(fallbacks map[style.Mask]style.Mask) Option {
+	return func(c *core) {
+		c.attrFallbacks = make(attrTable, len(fallbacks))
+		for attr, fallback := range fallbacks {
+			c.attrFallbacks[attr] = fallback
+		}
+	}
+}
+
+// Attributes implements the term.Engine interface, returning the text attributes which the terminal can render
+func (c *core) Attributes() style.Mask {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.supportedAttrs()
+}
+
+// supportedAttrs returns the attributes having a capability in the terminal definition - locked inside caller function
+func (c *core) supportedAttrs() style.Mask {
+	result := style.None
+	for _, attr := range []struct {
+		mask style.Mask
+		cap  string
+	}{
+		{style.Bold, c.comm.Bold},
+		{style.Underline, c.comm.Underline},
+		{style.Reverse, c.comm.Reverse},
+		{style.Blink, c.comm.Blink},
+		{style.Dim, c.comm.Dim},
+		{style.Italic, c.comm.Italic},
+		{style.StrikeThrough, c.comm.StrikeThrough},
+	} {
+		if attr.cap != "" {
+			result |= attr.mask
+		}
+	}
+	return result
+}
+
+// renderedAttrs applies the attribute policies, returning the attributes which should be written - locked inside caller function
+func (c *core) renderedAttrs(attrs style.Mask) style.Mask {
+	if attrs&style.Blink != 0 && !c.blinkPolicy.allow {
+		attrs = attrs&^style.Blink | c.blinkPolicy.replacement
+		c.degrade("blink replaced with %s by the blink policy", c.blinkPolicy.replacement)
+	}
+	if len(c.attrFallbacks) == 0 {
+		return attrs
+	}
+	missing := attrs &^ c.supportedAttrs()
+	for attr := style.Bold; attr < style.Invalid; attr <<= 1 {
+		if fallback, ok := c.attrFallbacks[attr]; ok && missing&attr != 0 {
+			attrs = attrs&^attr | fallback
+			c.degrade("%s unsupported on this terminal, replaced with %s", attr, fallback)
+		}
+	}
+	return attrs
+}
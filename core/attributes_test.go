@@ -0,0 +1,166 @@
+package core
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+// drawnStyle returns what is written between the attributes being turned off and the rune of a pixel having the attributes
+func drawnStyle(t *testing.T, c *core, attrs style.Mask) string {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	c.drawIn(buf, c.area, &regionPixel{hash: term.Hash(0, 0), r: 'x', st: style.Style{Fg: color.Default, Bg: color.Default, Attrs: attrs}})
+	out := buf.String()
+	start, end := strings.Index(out, "\x1b(B\x1b[m"), strings.LastIndex(out, "x")
+	if start < 0 || end < start {
+		t.Fatalf("error : the pixel wasn't drawn, got %q", out)
+	}
+	return out[start+len("\x1b(B\x1b[m") : end]
+}
+
+func TestAttributes(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		overrides map[string]string
+		policy    BlinkPolicy
+		attrs     style.Mask
+		supported style.Mask
+		expected  string
+		reasons   []string
+	}{
+		{
+			name:      "all rendered",
+			policy:    AllowBlink,
+			attrs:     style.Italic | style.StrikeThrough | style.Blink,
+			supported: style.Bold | style.Underline | style.Reverse | style.Blink | style.Dim | style.Italic | style.StrikeThrough,
+			expected:  "\x1b[5m\x1b[3m\x1b[9m",
+		},
+		{
+			name:      "italic and strikethrough unsupported",
+			overrides: map[string]string{"sitm": "", "smxx": ""},
+			policy:    AllowBlink,
+			attrs:     style.Italic | style.StrikeThrough | style.Bold,
+			supported: style.Bold | style.Underline | style.Reverse | style.Blink | style.Dim,
+			expected:  "\x1b[1m",
+			reasons:   []string{"italic unsupported on this terminal, dropped", "strikethrough unsupported on this terminal, dropped"},
+		},
+		{
+			name:      "blink replaced",
+			policy:    ReplaceBlinkWith(style.Bold | style.Blink),
+			attrs:     style.Blink,
+			supported: style.Bold | style.Underline | style.Reverse | style.Blink | style.Dim | style.Italic | style.StrikeThrough,
+			expected:  "\x1b[1m",
+			reasons:   []string{"blink replaced with bold by the blink policy"},
+		},
+		{
+			name:      "blink dropped",
+			policy:    ReplaceBlinkWith(style.None),
+			attrs:     style.Blink | style.Underline,
+			supported: style.Bold | style.Underline | style.Reverse | style.Blink | style.Dim | style.Italic | style.StrikeThrough,
+			expected:  "\x1b[4m",
+			reasons:   []string{"blink replaced with none by the blink policy"},
+		},
+		{
+			name:      "blink unsupported",
+			overrides: map[string]string{"blink": ""},
+			policy:    AllowBlink,
+			attrs:     style.Blink,
+			supported: style.Bold | style.Underline | style.Reverse | style.Dim | style.Italic | style.StrikeThrough,
+			reasons:   []string{"blink unsupported on this terminal, dropped"},
+		},
+	} {
+		c := newBenchCore(t, WithDiagnostics(true), WithBlinkPolicy(tc.policy), WithCapabilityOverrides(tc.overrides))
+		if supported := c.Attributes(); supported != tc.supported {
+			t.Errorf("error : %s : expecting %s to be supported, got %s", tc.name, tc.supported, supported)
+		}
+		if out := drawnStyle(t, c, tc.attrs); out != tc.expected {
+			t.Errorf("error : %s : expecting %q, got %q", tc.name, tc.expected, out)
+		}
+		var reasons []string
+		for _, degradation := range c.Degradations() {
+			reasons = append(reasons, degradation.Reason)
+		}
+		if !reflect.DeepEqual(reasons, tc.reasons) {
+			t.Errorf("error : %s : expecting %q to be reported, got %q", tc.name, tc.reasons, reasons)
+		}
+	}
+}
+
+func TestAttributeFallbacks(t *testing.T) {
+	fallbacks := map[style.Mask]style.Mask{
+		style.Italic:        style.Underline,
+		style.StrikeThrough: style.Dim,
+		style.Dim:           style.None,
+	}
+	for _, tc := range []struct {
+		name      string
+		overrides map[string]string
+		attrs     style.Mask
+		expected  string
+		reasons   []string
+	}{
+		{
+			name:     "supported",
+			attrs:    style.Italic | style.StrikeThrough,
+			expected: "\x1b[3m\x1b[9m",
+		},
+		{
+			name:      "italic",
+			overrides: map[string]string{"sitm": ""},
+			attrs:     style.Italic,
+			expected:  "\x1b[4m",
+			reasons:   []string{"italic unsupported on this terminal, replaced with underline"},
+		},
+		{
+			name:      "strikethrough",
+			overrides: map[string]string{"smxx": ""},
+			attrs:     style.StrikeThrough | style.Bold,
+			expected:  "\x1b[1m\x1b[2m",
+			reasons:   []string{"strikethrough unsupported on this terminal, replaced with dim"},
+		},
+		{
+			name:      "fallback unsupported",
+			overrides: map[string]string{"smxx": "", "dim": ""},
+			attrs:     style.StrikeThrough,
+			reasons:   []string{"strikethrough unsupported on this terminal, replaced with dim", "dim unsupported on this terminal, dropped"},
+		},
+		{
+			name:      "dropped",
+			overrides: map[string]string{"dim": ""},
+			attrs:     style.Dim | style.Underline,
+			expected:  "\x1b[4m",
+			reasons:   []string{"dim unsupported on this terminal, replaced with none"},
+		},
+		{
+			name:      "no fallback",
+			overrides: map[string]string{"blink": ""},
+			attrs:     style.Blink,
+			reasons:   []string{"blink unsupported on this terminal, dropped"},
+		},
+	} {
+		c := newBenchCore(t, WithDiagnostics(true), WithAttributeFallbacks(fallbacks), WithCapabilityOverrides(tc.overrides))
+		if out := drawnStyle(t, c, tc.attrs); out != tc.expected {
+			t.Errorf("error : %s : expecting %q, got %q", tc.name, tc.expected, out)
+		}
+		var reasons []string
+		for _, degradation := range c.Degradations() {
+			reasons = append(reasons, degradation.Reason)
+		}
+		if !reflect.DeepEqual(reasons, tc.reasons) {
+			t.Errorf("error : %s : expecting %q to be reported, got %q", tc.name, tc.reasons, reasons)
+		}
+	}
+
+	// the table is copied by the option
+	c := newBenchCore(t, WithAttributeFallbacks(fallbacks), WithCapabilityOverrides(map[string]string{"sitm": ""}))
+	fallbacks[style.Italic] = style.Reverse
+	if out := drawnStyle(t, c, style.Italic); out != "\x1b[4m" {
+		t.Errorf("error : changing the table after creating the engine should have no effect, got %q", out)
+	}
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/badu/term"
+)
+
+const (
+	bitsPerCharacter = 10 // start, eight data bits and stop
+	pacingChunks     = 50 // the output is written in chunks of a fiftieth of a second, so the flow control of the terminal can stop it in time
+)
+
+// WithBaud is a functional option for the speed of the serial line, in bits per second, for the hardware terminals and the serial consoles.
+// The padding asked by the terminal definition is then sent as pad characters (unless the terminal uses the XON/XOFF flow control), instead of sleeping, and the output is written no faster than the line can carry it.
+// Default is zero, unknown, which is right for the terminal emulators.
+func WithBaud(bitsPerSecond int) Option {
+	return func(c *core) {
+		if bitsPerSecond > 0 {
+			c.baud = bitsPerSecond
+		}
+	}
+}
+
+// paced returns the writer used for the terminal : the output is paced if the speed of the line is known
+func (c *core) paced(out io.Writer) io.Writer {
+	if c.baud <= 0 {
+		return out
+	}
+	return newPacedWriter(out, term.Max(c.baud/bitsPerCharacter, 1))
+}
+
+// pacedWriter writes no faster than the given characters per second, in small chunks, waiting for the line to carry the previous ones
+type pacedWriter struct {
+	sync.Mutex           // guards other properties
+	out        io.Writer //
+	rate       int       // characters per second
+	chunk      int       // the characters written at once
+	free       time.Time // when the characters written so far have been carried
+}
+
+func newPacedWriter(out io.Writer, rate int) *pacedWriter {
+	return &pacedWriter{out: out, rate: rate, chunk: (rate + pacingChunks - 1) / pacingChunks}
+}
+
+// Write implements io.Writer
+func (p *pacedWriter) Write(b []byte) (int, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	written := 0
+	for len(b) > 0 {
+		if wait := time.Until(p.free); wait > 0 {
+			time.Sleep(wait)
+		}
+		chunk := b
+		if len(chunk) > p.chunk {
+			chunk = chunk[:p.chunk]
+		}
+		n, err := p.out.Write(chunk)
+		written += n
+		if now := time.Now(); p.free.Before(now) {
+			p.free = now
+		}
+		p.free = p.free.Add(time.Duration(n) * time.Second / time.Duration(p.rate))
+		if err != nil {
+			return written, err
+		}
+		b = b[n:]
+	}
+	return written, nil
+}
@@ -0,0 +1,34 @@
+package core
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestPacedWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := newPacedWriter(buf, 1000) // 9600 bauds, roughly
+	started := time.Now()
+	if n, err := w.Write(make([]byte, 100)); err != nil || n != 100 {
+		t.Fatalf("error : write failed %d %v", n, err)
+	}
+	if _, err := w.Write(make([]byte, 50)); err != nil {
+		t.Fatalf("error : write failed %v", err)
+	}
+	// the last chunk is written once the previous ones were carried
+	if elapsed := time.Since(started); elapsed < 120*time.Millisecond {
+		t.Errorf("error : 150 characters should take about 140 milliseconds at 1000 per second, took %v", elapsed)
+	}
+	if buf.Len() != 150 {
+		t.Errorf("error : expecting 150 bytes written, got %d", buf.Len())
+	}
+
+	c := newBenchCore(t, WithBaud(9600))
+	if c.comm.Baud != 9600 {
+		t.Errorf("error : the commander should know the speed of the line")
+	}
+	if _, ok := c.paced(buf).(*pacedWriter); !ok {
+		t.Errorf("error : the output should be paced")
+	}
+}
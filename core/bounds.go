@@ -0,0 +1,102 @@
+package core
+
+import (
+	"github.com/badu/term"
+)
+
+// BoundsMode tells what happens with the pixels whose positions are outside the screen (see WithBoundsMode)
+type BoundsMode int
+
+const (
+	// ClipOutOfBounds ignores the pixels outside the screen. This is the default.
+	ClipOutOfBounds BoundsMode = iota
+	// ReportOutOfBounds ignores the pixels outside the screen, recording the last one, which is returned as error by OutOfBounds (see term.BoundsChecker)
+	ReportOutOfBounds
+	// WrapOutOfBounds draws the pixels past the right edge on the following rows, like the terminal does with the text written past it. The other ones are ignored.
+	WrapOutOfBounds
+)
+
+// WithBoundsMode is a functional option for choosing what happens with the pixels drawn outside the screen. Default is ClipOutOfBounds.
+func WithBoundsMode(mode BoundsMode) Option {
+	return func(c *core) {
+		c.boundsMode = mode
+	}
+}
+
+// wrappedPixel draws a pixel past the right edge at its wrapped position
+type wrappedPixel struct {
+	term.PixelGetter
+	hash int
+}
+
+// PositionHash implements term.PixelGetter interface
+func (p *wrappedPixel) PositionHash() int {
+	return p.hash
+}
+
+// CheckBounds implements term.BoundsChecker interface
+func (c *core) CheckBounds(pixels []term.PixelGetter) error {
+	c.Lock()
+	defer c.Unlock()
+
+	for _, pixel := range pixels {
+		if err := c.outside(pixel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OutOfBounds implements term.BoundsChecker interface. It returns nil unless the engine was created WithBoundsMode(ReportOutOfBounds).
+func (c *core) OutOfBounds() error {
+	c.Lock()
+	defer c.Unlock()
+
+	err := c.boundsErr
+	c.boundsErr = nil
+	return err
+}
+
+// outside returns the error describing the pixel, if it's outside the area left to the pages - locked inside caller function
+func (c *core) outside(pixel term.PixelGetter) *term.OutOfBoundsError {
+	column, row := term.UnHashNeg(pixel.PositionHash())
+	if c.area.contains(column, row) {
+		return nil
+	}
+	return &term.OutOfBoundsError{Column: column, Row: row, Size: term.Size{Columns: c.area.columns, Rows: c.area.rows}}
+}
+
+// bound applies the bounds mode to the pixels about to be drawn, the ones left outside being ignored when drawn - locked inside caller function
+func (c *core) bound(pixels []term.PixelGetter) []term.PixelGetter {
+	switch c.boundsMode {
+	case ReportOutOfBounds:
+		for _, pixel := range pixels {
+			if err := c.outside(pixel); err != nil {
+				if Debug.Enabled() {
+					Debug.Printf("%v", err)
+				}
+				c.boundsErr = err
+			}
+		}
+	case WrapOutOfBounds:
+		var result []term.PixelGetter // allocated for the first wrapped pixel
+		for idx, pixel := range pixels {
+			column, row := term.UnHashNeg(pixel.PositionHash())
+			if column < c.area.columns || c.area.columns <= 0 || row < 0 {
+				if result != nil {
+					result = append(result, pixel)
+				}
+				continue
+			}
+			if result == nil {
+				result = make([]term.PixelGetter, idx, len(pixels))
+				copy(result, pixels[:idx])
+			}
+			result = append(result, &wrappedPixel{PixelGetter: pixel, hash: term.Hash(column%c.area.columns, row+column/c.area.columns)})
+		}
+		if result != nil {
+			return result
+		}
+	}
+	return pixels
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+)
+
+func TestBoundsMode(t *testing.T) {
+	outside := []term.PixelGetter{
+		&regionPixel{hash: term.Hash(benchColumns+2, 0), r: 'w'},
+		&regionPixel{hash: term.Hash(-1, 3), r: 'n'},
+	}
+
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+	err := c.CheckBounds(append([]term.PixelGetter{&regionPixel{hash: term.Hash(1, 1), r: 'x'}}, outside...))
+	bounds, ok := err.(*term.OutOfBoundsError)
+	if !ok || bounds.Column != benchColumns+2 || bounds.Row != 0 || bounds.Size.Columns != benchColumns {
+		t.Errorf("error : expecting the first pixel outside the screen, got %v", err)
+	}
+	c.drawPixels(c.out, outside...)
+	if out := written(); out != "" {
+		t.Errorf("error : pixels outside the screen should be clipped, got %q", out)
+	}
+	if err := c.OutOfBounds(); err != nil {
+		t.Errorf("error : clipped pixels should not be reported, got %v", err)
+	}
+
+	c = newBenchCore(t, WithBoundsMode(ReportOutOfBounds))
+	written = captureOut(t, c)
+	c.drawPixels(c.out, outside...)
+	if out := written(); out != "" {
+		t.Errorf("error : pixels outside the screen should not be drawn, got %q", out)
+	}
+	bounds, ok = c.OutOfBounds().(*term.OutOfBoundsError)
+	if !ok || bounds.Column != -1 || bounds.Row != 3 {
+		t.Errorf("error : expecting the last pixel outside the screen to be reported, got %v", bounds)
+	}
+	if err := c.OutOfBounds(); err != nil {
+		t.Errorf("error : the report should be forgotten once returned, got %v", err)
+	}
+
+	c = newBenchCore(t, WithBoundsMode(WrapOutOfBounds))
+	written = captureOut(t, c)
+	c.drawPixels(c.out, outside...)
+	if out := written(); !strings.Contains(out, "\x1b[2;3Hw") || strings.Contains(out, "n") {
+		t.Errorf("error : pixels past the right edge should continue on the next row, got %q", out)
+	}
+}
@@ -0,0 +1,22 @@
+package core
+
+import (
+	"encoding/base64"
+)
+
+const (
+	clipboardStart = "\x1b]52;c;" // OSC 52 : sets the clipboard selection, e.g. ESC ] 52 ; c ; base64 ESC \
+	clipboardEnd   = "\x1b\\"     //
+)
+
+// SetClipboard implements term.Clipboard interface. The text is sent base64 encoded, so it can hold any character.
+func (c *core) SetClipboard(text string) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.out == nil || c.plain {
+		return false
+	}
+	c.comm.WriteString(c.out, clipboardStart+base64.StdEncoding.EncodeToString([]byte(text))+clipboardEnd)
+	return true
+}
@@ -0,0 +1,16 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestSetClipboard(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+	if !c.SetClipboard("héllo\n") {
+		t.Fatalf("error : the clipboard should be set")
+	}
+	if out := written(); out != "\x1b]52;c;aMOpbGxvCg==\x1b\\" {
+		t.Errorf("error : unexpected OSC 52 sequence %q", out)
+	}
+}
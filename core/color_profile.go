@@ -0,0 +1,93 @@
+package core
+
+import (
+	"strconv"
+
+	"github.com/badu/term"
+)
+
+// detectProfile returns the color profile supported by the terminal definition
+func detectProfile(colors int, hasTrueColor bool) term.ColorProfile {
+	switch {
+	case hasTrueColor:
+		return term.ProfileTrueColor
+	case colors > 16:
+		return term.ProfileANSI256
+	case colors > 0:
+		return term.ProfileANSI
+	default:
+		return term.ProfileNone
+	}
+}
+
+// envProfile applies the environment conventions over the detected profile. It returns true if the profile was forced.
+// The precedence is : FORCE_COLOR, then CLICOLOR_FORCE, then NO_COLOR, then CLICOLOR.
+// FORCE_COLOR levels are 0 (no colors), 1 (16 colors), 2 (256 colors) and 3 (true color); an empty or "true" value enables at least the 16 colors, while an invalid one is ignored.
+// See https://no-color.org and https://bixense.com/clicolors
+func envProfile(detected term.ColorProfile, lookupEnv func(string) (string, bool)) (term.ColorProfile, bool) {
+	getenv := func(name string) string {
+		value, _ := lookupEnv(name)
+		return value
+	}
+
+	if force, ok := lookupEnv("FORCE_COLOR"); ok {
+		switch force {
+		case "", "true":
+			return maxProfile(detected, term.ProfileANSI), true
+		case "false":
+			return term.ProfileNone, true
+		}
+		if level, err := strconv.Atoi(force); err == nil {
+			switch {
+			case level <= 0:
+				return term.ProfileNone, true
+			case level >= int(term.ProfileTrueColor):
+				return term.ProfileTrueColor, true
+			default:
+				return term.ColorProfile(level), true
+			}
+		}
+		// an invalid value is ignored, the other variables deciding
+	}
+
+	if force := getenv("CLICOLOR_FORCE"); len(force) > 0 && force != "0" {
+		return maxProfile(detected, term.ProfileANSI), true
+	}
+
+	if len(getenv("NO_COLOR")) > 0 {
+		return term.ProfileNone, false
+	}
+
+	if getenv("CLICOLOR") == "0" {
+		return term.ProfileNone, false
+	}
+
+	return detected, false
+}
+
+// ansiColorCapabilities are used when colors are forced on a terminal definition which declares fewer of them
+var ansiColorCapabilities = map[string]string{
+	"setaf": "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;m",
+	"setab": "\x1b[%?%p1%{8}%<%t4%p1%d%e%p1%{16}%<%t10%p1%{8}%-%d%e48;5;%p1%d%;m",
+	"op":    "\x1b[39;49m",
+}
+
+// paletteSize returns the number of palette colors which will be used : the terminal ones, limited by the profile.
+// When the profile was forced, the palette is extended to the profile size.
+func paletteSize(colors int, profile term.ColorProfile, forced bool) int {
+	limit := profile.Colors()
+	if profile == term.ProfileTrueColor {
+		limit = 256 // true color profile still uses the palette for non RGB colors
+	}
+	if colors > limit || (forced && colors < limit) {
+		return limit
+	}
+	return colors
+}
+
+func maxProfile(a, b term.ColorProfile) term.ColorProfile {
+	if a > b {
+		return a
+	}
+	return b
+}
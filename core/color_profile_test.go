@@ -0,0 +1,64 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+func TestEnvProfile(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		env      map[string]string
+		detected term.ColorProfile
+		expected term.ColorProfile
+		forced   bool
+	}{
+		{name: "nothing set", detected: term.ProfileANSI256, expected: term.ProfileANSI256},
+		{name: "no color", env: map[string]string{"NO_COLOR": "1"}, detected: term.ProfileTrueColor, expected: term.ProfileNone},
+		{name: "empty no color", env: map[string]string{"NO_COLOR": ""}, detected: term.ProfileTrueColor, expected: term.ProfileTrueColor},
+		{name: "clicolor off", env: map[string]string{"CLICOLOR": "0"}, detected: term.ProfileANSI, expected: term.ProfileNone},
+		{name: "force level", env: map[string]string{"FORCE_COLOR": "2"}, detected: term.ProfileNone, expected: term.ProfileANSI256, forced: true},
+		{name: "force above true color", env: map[string]string{"FORCE_COLOR": "7"}, detected: term.ProfileNone, expected: term.ProfileTrueColor, forced: true},
+		{name: "force zero", env: map[string]string{"FORCE_COLOR": "0"}, detected: term.ProfileTrueColor, expected: term.ProfileNone, forced: true},
+		{name: "force empty", env: map[string]string{"FORCE_COLOR": ""}, detected: term.ProfileNone, expected: term.ProfileANSI, forced: true},
+		{name: "force true keeps more", env: map[string]string{"FORCE_COLOR": "true"}, detected: term.ProfileTrueColor, expected: term.ProfileTrueColor, forced: true},
+		{name: "force false", env: map[string]string{"FORCE_COLOR": "false"}, detected: term.ProfileANSI, expected: term.ProfileNone, forced: true},
+		{name: "force wins over no color", env: map[string]string{"FORCE_COLOR": "1", "NO_COLOR": "1"}, detected: term.ProfileNone, expected: term.ProfileANSI, forced: true},
+		{name: "invalid force with no color", env: map[string]string{"FORCE_COLOR": "yes please", "NO_COLOR": "1"}, detected: term.ProfileTrueColor, expected: term.ProfileNone},
+		{name: "invalid force with clicolor force", env: map[string]string{"FORCE_COLOR": "always", "CLICOLOR_FORCE": "1"}, detected: term.ProfileNone, expected: term.ProfileANSI, forced: true},
+		{name: "invalid force alone", env: map[string]string{"FORCE_COLOR": "always"}, detected: term.ProfileANSI256, expected: term.ProfileANSI256},
+		{name: "clicolor force wins over no color", env: map[string]string{"CLICOLOR_FORCE": "1", "NO_COLOR": "1"}, detected: term.ProfileNone, expected: term.ProfileANSI, forced: true},
+		{name: "clicolor force off", env: map[string]string{"CLICOLOR_FORCE": "0", "NO_COLOR": "1"}, detected: term.ProfileANSI, expected: term.ProfileNone},
+	} {
+		lookupEnv := func(name string) (string, bool) {
+			value, ok := tc.env[name]
+			return value, ok
+		}
+		profile, forced := envProfile(tc.detected, lookupEnv)
+		if profile != tc.expected || forced != tc.forced {
+			t.Errorf("error : %s : expecting %v (forced %t), got %v (forced %t)", tc.name, tc.expected, tc.forced, profile, forced)
+		}
+	}
+}
+
+func TestColorMatcher(t *testing.T) {
+	rgb := color.NewRGBColor(0x80, 0, 0)
+	for _, tc := range []struct {
+		name     string
+		opts     []Option
+		expected string
+	}{
+		{name: "nearest", expected: "\x1b[1;1H\x1b(B\x1b[m\x1b[31mx"},
+		{name: "index", opts: []Option{WithColorMatcher(color.FindIndexColor)}, expected: "\x1b[1;1H\x1b(B\x1b[mx"},
+	} {
+		c := newBenchCore(t, append(tc.opts, WithTrueColor("disable"))...)
+		written := captureOut(t, c)
+		c.drawPixels(c.out, &regionPixel{hash: term.Hash(0, 0), r: 'x', st: style.Style{Fg: rgb, Bg: color.Default}})
+		if out := written(); out != tc.expected {
+			t.Errorf("error : %s : expecting %q, got %q", tc.name, tc.expected, out)
+		}
+	}
+}
@@ -1,5 +1,14 @@
 package core
 
-const (
-	Debug = false // if set to false, the compiler will cleanup/remove the lines inside functions
-)
+import "github.com/badu/term/debug"
+
+// Debug is the diagnosis log of the package, enabled at runtime with TERM_DEBUG=core or core.WithDebug("core") (see package debug)
+var Debug = debug.For("core")
+
+// WithDebug is a functional option for enabling the diagnosis logs of the components, e.g. WithDebug("core", "mouse") or WithDebug(debug.All), as TERM_DEBUG does.
+// The logs are switched for the whole process, not only for this engine.
+func WithDebug(components ...string) Option {
+	return func(c *core) {
+		debug.Enable(components...)
+	}
+}
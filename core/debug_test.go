@@ -0,0 +1,54 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/debug"
+)
+
+func TestRegisterDebug(t *testing.T) {
+	logged := &bytes.Buffer{}
+	debug.SetOutput(logged)
+	defer debug.SetOutput(nil)
+	c := newBenchCore(t, WithDebug("core"))
+	defer debug.Disable("core")
+
+	// registering before Start is logged, without stopping the program
+	resize := &resizeListener{ch: make(chan term.ResizeEvent, 1), died: make(chan struct{})}
+	c.Register(resize)
+	c.ThemeDispatcher().Register(&themeListener{ch: make(chan term.ThemeEvent), died: make(chan struct{})})
+	c.timers.Register(&tickListener{ch: make(chan term.TickEvent), died: make(chan struct{})})
+	if count := strings.Count(logged.String(), "context not set"); count != 3 {
+		t.Errorf("error : expecting the 3 registrations to be logged, got %q", logged.String())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c.Lock()
+	c.ctx = ctx
+	c.Unlock()
+	c.theme.Lock()
+	c.theme.ctx = ctx
+	c.theme.Unlock()
+	c.timers.Lock()
+	c.timers.ctx = ctx
+	c.timers.Unlock()
+	logged.Reset()
+	c.Register(resize)
+	c.Register(resize)
+	c.Register(&resizeListener{died: make(chan struct{})})
+	c.ThemeDispatcher().Register(&themeListener{died: make(chan struct{})})
+	c.timers.Register(&tickListener{died: make(chan struct{})})
+	for _, expected := range []string{"ResizeListen chan is already registered", "ResizeListen chan is nil", "ThemeListen chan is nil", "TickListen chan is nil"} {
+		if !strings.Contains(logged.String(), expected) {
+			t.Errorf("error : expecting %q to be logged, got %q", expected, logged.String())
+		}
+	}
+	if len(c.receivers) != 1 {
+		t.Errorf("error : expecting only the first listener to be registered, got %d", len(c.receivers))
+	}
+}
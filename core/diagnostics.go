@@ -0,0 +1,94 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+// WithDiagnostics is a functional option for recording the rendering decisions made because the terminal lacks a capability (e.g. "italic unsupported on this terminal, dropped"),
+// so developers can understand why their UI looks different across terminals. The summary is returned by Degradations (see term.DegradationReporter). Default is disabled.
+func WithDiagnostics(enabled bool) Option {
+	return func(c *core) {
+		if enabled {
+			c.diagnostics = &diagnostics{counts: make(map[string]int)}
+		} else {
+			c.diagnostics = nil
+		}
+	}
+}
+
+// diagnostics holds the degradations, by their reason
+type diagnostics struct {
+	reasons []string       // in the order they were first recorded
+	counts  map[string]int // how many times each reason was recorded
+}
+
+// degrade records a rendering decision, if the diagnostics mode is enabled - locked inside caller function
+func (c *core) degrade(format string, args ...interface{}) {
+	if c.diagnostics == nil {
+		return
+	}
+	reason := fmt.Sprintf(format, args...)
+	if _, ok := c.diagnostics.counts[reason]; !ok {
+		c.diagnostics.reasons = append(c.diagnostics.reasons, reason)
+	}
+	c.diagnostics.counts[reason]++
+}
+
+// findColor looks up the color in the terminal palette, recording the approximation - locked inside caller function
+func (c *core) findColor(orig color.Color) color.Color {
+	found := c.style.FindColor(orig)
+	if c.diagnostics == nil || found == orig {
+		return found
+	}
+	switch {
+	case !color.Valid(found):
+		c.degrade("color %s unsupported on this terminal, dropped", orig)
+	case color.IsRGB(orig):
+		c.degrade("RGB %s downsampled to %d-color index %d", orig, c.colors, uint64(found&^color.ValidConst))
+	default:
+		c.degrade("%s mapped to %d-color index %d", orig, c.colors, uint64(found&^color.ValidConst))
+	}
+	return found
+}
+
+// checkAttrs records the attributes which the terminal can't render - locked inside caller function
+func (c *core) checkAttrs(attrs style.Mask) {
+	if c.diagnostics == nil {
+		return
+	}
+	missing := attrs &^ c.supportedAttrs()
+	for attr := style.Bold; attr < style.Invalid; attr <<= 1 {
+		if missing&attr != 0 {
+			c.degrade("%s unsupported on this terminal, dropped", attr)
+		}
+	}
+}
+
+// Degradations implements term.DegradationReporter interface, returning nil unless the diagnostics mode is enabled (see WithDiagnostics)
+func (c *core) Degradations() []term.Degradation {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.diagnostics == nil {
+		return nil
+	}
+	result := make([]term.Degradation, len(c.diagnostics.reasons))
+	for idx, reason := range c.diagnostics.reasons {
+		result[idx] = term.Degradation{Reason: reason, Count: c.diagnostics.counts[reason]}
+	}
+	return result
+}
+
+// ResetDegradations implements term.DegradationReporter interface
+func (c *core) ResetDegradations() {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.diagnostics != nil {
+		c.diagnostics = &diagnostics{counts: make(map[string]int)}
+	}
+}
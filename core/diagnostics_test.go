@@ -0,0 +1,105 @@
+package core
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+func TestDiagnostics(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		opts     []Option
+		st       style.Style
+		expected []term.Degradation
+		absent   string
+	}{
+		{
+			name: "italic dropped",
+			opts: []Option{WithCapabilityOverrides(map[string]string{"sitm": ""})},
+			st:   style.Style{Fg: color.Default, Bg: color.Default, Attrs: style.Italic | style.Bold},
+			expected: []term.Degradation{
+				{Reason: "italic unsupported on this terminal, dropped", Count: 1},
+			},
+			absent: "\x1b[3m",
+		},
+		{
+			name: "rgb downsampled",
+			opts: []Option{WithTrueColor("disable")},
+			st:   style.Style{Fg: color.NewRGBColor(0xFF, 0x10, 0x10), Bg: color.Default},
+			expected: []term.Degradation{
+				{Reason: "RGB #FF1010 downsampled to 256-color index 9", Count: 1},
+			},
+		},
+		{
+			name: "palette mapped",
+			opts: []Option{WithCapabilityOverrides(map[string]string{"colors": "8"})},
+			st:   style.Style{Fg: color.ValidConst | 196, Bg: color.ValidConst | 21},
+			expected: []term.Degradation{
+				{Reason: "color196 mapped to 8-color index 1", Count: 1},
+				{Reason: "color21 mapped to 8-color index 4", Count: 1},
+			},
+		},
+		{
+			name: "no colors",
+			opts: []Option{WithCapabilityOverrides(map[string]string{"colors": "0"})},
+			st:   style.Style{Fg: color.Red, Bg: color.Default},
+			expected: []term.Degradation{
+				{Reason: "colors unsupported on this terminal, dropped", Count: 1},
+			},
+			absent: "\x1b[31m",
+		},
+		{
+			name: "blink replaced",
+			opts: []Option{WithBlinkPolicy(ReplaceBlinkWith(style.Bold))},
+			st:   style.Style{Fg: color.Default, Bg: color.Default, Attrs: style.Blink},
+			expected: []term.Degradation{
+				{Reason: "blink replaced with bold by the blink policy", Count: 1},
+			},
+			absent: "\x1b[5m",
+		},
+		{
+			name: "rendered as requested",
+			st:   style.Style{Fg: color.Red, Bg: color.Default, Attrs: style.Italic},
+		},
+	} {
+		c := newBenchCore(t, append([]Option{WithDiagnostics(true)}, tc.opts...)...)
+		buf := &bytes.Buffer{}
+		c.drawIn(buf, c.area, rowPixels(tc.st, "ab", "cd")...)
+		if got := c.Degradations(); len(got)+len(tc.expected) > 0 && !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("error : %s : expecting %v, got %v", tc.name, tc.expected, got)
+		}
+		if tc.absent != "" && strings.Contains(buf.String(), tc.absent) {
+			t.Errorf("error : %s : %q should not be written, got %q", tc.name, tc.absent, buf.String())
+		}
+	}
+}
+
+func TestDegradationsCount(t *testing.T) {
+	c := newBenchCore(t, WithDiagnostics(true), WithCapabilityOverrides(map[string]string{"sitm": ""}))
+	italic := style.Style{Fg: color.Red, Bg: color.Default, Attrs: style.Italic}
+	other := style.Style{Fg: color.Blue, Bg: color.Default, Attrs: style.Italic}
+	pixels := append(rowPixels(italic, "ab"), &regionPixel{hash: term.Hash(2, 0), r: 'c', st: other})
+	c.drawIn(&bytes.Buffer{}, c.area, pixels...)
+	expected := []term.Degradation{{Reason: "italic unsupported on this terminal, dropped", Count: 2}}
+	if got := c.Degradations(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("error : expecting %v, got %v", expected, got)
+	}
+
+	c.ResetDegradations()
+	if got := c.Degradations(); got == nil || len(got) != 0 {
+		t.Errorf("error : the degradations should be forgotten, got %v", got)
+	}
+
+	c = newBenchCore(t, WithCapabilityOverrides(map[string]string{"sitm": ""}))
+	c.drawIn(&bytes.Buffer{}, c.area, pixels...)
+	c.ResetDegradations()
+	if got := c.Degradations(); got != nil {
+		t.Errorf("error : nothing should be recorded outside the diagnostics mode, got %v", got)
+	}
+}
@@ -0,0 +1,250 @@
+package core
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/geom"
+	"github.com/badu/term/info"
+	"github.com/badu/term/style"
+)
+
+const (
+	benchColumns = 200
+	benchRows    = 50
+)
+
+var (
+	benchOnce sync.Once
+	benchInfo *info.Term // looked up once, since NewCore clears the terminal registry
+)
+
+// countingWriter counts the bytes the engine writes, instead of sending them to a terminal
+type countingWriter struct {
+	count int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.count += len(p)
+	return len(p), nil
+}
+
+// newBenchCore returns an engine drawing on a simulated xterm-256color screen
+func newBenchCore(tb testing.TB, opts ...Option) *core {
+	tb.Helper()
+	benchOnce.Do(func() {
+		benchInfo, _ = info.LookupTerminfo("xterm-256color")
+	})
+	if benchInfo == nil {
+		tb.Fatal("error : xterm-256color is not registered")
+	}
+	setEnv(tb, "LANG", "en_US.UTF-8")
+	setEnv(tb, "COLORTERM", "truecolor")
+	setEnv(tb, "NO_COLOR", "")
+	opts = append([]Option{WithTerminfo(benchInfo), WithPlainOutput(false)}, opts...)
+	engine, err := NewCore("xterm-256color", opts...)
+	if err != nil {
+		tb.Fatalf("error creating engine : %v", err)
+	}
+	c := engine.(*core)
+	c.resize(benchColumns, benchRows, false)
+	return c
+}
+
+// setEnv changes an environment variable for the duration of the test
+func setEnv(tb testing.TB, name, value string) {
+	old, ok := os.LookupEnv(name)
+	if err := os.Setenv(name, value); err != nil {
+		tb.Fatalf("error setting %s : %v", name, err)
+	}
+	tb.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(name, old)
+		} else {
+			_ = os.Unsetenv(name)
+		}
+	})
+}
+
+// newBenchScreen returns a grid of pixels having text and a few colors
+func newBenchScreen() ([][]term.Pixel, []term.PixelGetter) {
+	grid, getters := geom.NewPixelGrid(term.NewSize(benchColumns, benchRows))
+	for column := range grid {
+		for row, pixel := range grid[column] {
+			pixel.SetAll(color.PaletteColor(row%8), color.PaletteColor(column%16), style.None, rune('a'+(column+row)%26), nil)
+		}
+	}
+	return grid, getters
+}
+
+// draw writes the pixels, as the engine does on redraw, returning the number of bytes written
+func (c *core) benchDraw(pixels []term.PixelGetter) int {
+	w := &countingWriter{}
+	c.cachedAttrs = style.Invalid // each frame starts from an unknown terminal state
+	c.drawPixels(w, pixels...)
+	return w.count
+}
+
+func reportBytes(b *testing.B, total int) {
+	b.ReportMetric(float64(total)/float64(b.N), "bytes/op")
+}
+
+func BenchmarkFullRedraw(b *testing.B) {
+	c := newBenchCore(b)
+	_, getters := newBenchScreen()
+	b.ReportAllocs()
+	b.ResetTimer()
+	total := 0
+	for i := 0; i < b.N; i++ {
+		total += c.benchDraw(getters)
+	}
+	reportBytes(b, total)
+}
+
+func BenchmarkSparseUpdate(b *testing.B) {
+	c := newBenchCore(b)
+	_, getters := newBenchScreen()
+	rnd := rand.New(rand.NewSource(1))
+	changed := make([]term.PixelGetter, len(getters)/100) // 1% of the pixels
+	for idx := range changed {
+		changed[idx] = getters[rnd.Intn(len(getters))]
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	total := 0
+	for i := 0; i < b.N; i++ {
+		total += c.benchDraw(changed)
+	}
+	reportBytes(b, total)
+}
+
+func BenchmarkImageFrame(b *testing.B) {
+	for _, profile := range []string{"truecolor", "256color"} {
+		b.Run(profile, func(b *testing.B) {
+			var opts []Option
+			if profile != "truecolor" {
+				opts = append(opts, WithTrueColor("disable"))
+			}
+			c := newBenchCore(b, opts...)
+			grid, getters := geom.NewPixelGrid(term.NewSize(benchColumns, benchRows))
+			for column := range grid {
+				for row, pixel := range grid[column] {
+					top := color.NewRGBColor(int32(column*255/benchColumns), int32(row*255/benchRows), 128)
+					bottom := color.NewRGBColor(128, int32(column*255/benchColumns), int32(row*255/benchRows))
+					pixel.SetAll(bottom, top, style.None, '▀', nil) // half blocks, as images are rendered
+				}
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			total := 0
+			for i := 0; i < b.N; i++ {
+				total += c.benchDraw(getters)
+			}
+			reportBytes(b, total)
+		})
+	}
+}
+
+func BenchmarkTextScroll(b *testing.B) {
+	lines := make([]string, benchRows*2)
+	for idx := range lines {
+		lines[idx] = fmt.Sprintf("%05d the quick brown fox jumps over the lazy dog, while the logs keep on scrolling", idx)
+	}
+	setRow := func(grid [][]term.Pixel, row int, line string) {
+		runes := []rune(line)
+		for column := range grid {
+			r := ' '
+			if column < len(runes) {
+				r = runes[column]
+			}
+			grid[column][row].SetAll(color.Default, color.Default, style.None, r, nil)
+		}
+	}
+
+	b.Run("redraw", func(b *testing.B) {
+		c := newBenchCore(b)
+		grid, getters := geom.NewPixelGrid(term.NewSize(benchColumns, benchRows))
+		b.ReportAllocs()
+		b.ResetTimer()
+		total := 0
+		for i := 0; i < b.N; i++ {
+			for row := 0; row < benchRows; row++ {
+				setRow(grid, row, lines[(i+row)%len(lines)])
+			}
+			total += c.benchDraw(getters)
+		}
+		reportBytes(b, total)
+	})
+
+	b.Run("delete-line", func(b *testing.B) {
+		c := newBenchCore(b)
+		grid, getters := geom.NewPixelGrid(term.NewSize(benchColumns, benchRows))
+		lastRow := getters[(benchRows-1)*benchColumns:]
+		b.ReportAllocs()
+		b.ResetTimer()
+		total := 0
+		for i := 0; i < b.N; i++ {
+			w := &countingWriter{}
+			c.comm.GoTo(w, term.Hash(0, 0))
+			c.comm.PutDeleteLines(w, 1) // the terminal moves the content up
+			total += w.count
+			setRow(grid, benchRows-1, lines[i%len(lines)])
+			total += c.benchDraw(lastRow)
+		}
+		reportBytes(b, total)
+	})
+
+	b.Run("scrolled-redraw", func(b *testing.B) {
+		c := newBenchCore(b)
+		w := &countingWriter{}
+		c.out = w
+		grid, getters := geom.NewPixelGrid(term.NewSize(benchColumns, benchRows))
+		for row := 0; row < benchRows; row++ {
+			setRow(grid, row, lines[row])
+		}
+		c.Redraw(getters)
+		b.ReportAllocs()
+		b.ResetTimer()
+		w.count = 0
+		for i := 0; i < b.N; i++ {
+			for row := 0; row < benchRows; row++ {
+				setRow(grid, row, lines[(i+1+row)%len(lines)])
+			}
+			c.Redraw(getters) // the engine finds the rows which have moved, deleting a line
+		}
+		reportBytes(b, w.count)
+	})
+}
+
+// TestDrawBytesBudget guards the draw path against regressions of the output size, which are usually caused by cache misses or missing optimizations
+func TestDrawBytesBudget(t *testing.T) {
+	c := newBenchCore(t)
+	grid, getters := newBenchScreen()
+	for _, tc := range []struct {
+		name   string
+		pixels []term.PixelGetter
+		budget int
+	}{
+		{"full redraw", getters, 256000},
+		{"single pixel", getters[:1], 32},
+	} {
+		if got := c.benchDraw(tc.pixels); got > tc.budget {
+			t.Errorf("error : %s should write at most %d bytes, got %d", tc.name, tc.budget, got)
+		}
+	}
+
+	// a blank screen is erased, instead of being written
+	for column := range grid {
+		for _, pixel := range grid[column] {
+			pixel.SetAll(color.Default, color.Default, style.None, ' ', nil)
+		}
+	}
+	if got := c.benchDraw(getters); got > 32 {
+		t.Errorf("error : blank screen should write at most 32 bytes, got %d", got)
+	}
+}
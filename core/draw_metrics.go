@@ -0,0 +1,126 @@
+package core
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/badu/term"
+)
+
+// metricsWindow is how long the per second and per frame metrics are averaged over
+const metricsWindow = time.Second
+
+// drawMeter records the frames drawn and the bytes written to the terminal
+type drawMeter struct {
+	sync.Mutex                    // guards other properties
+	metrics      term.DrawMetrics // the totals since start and the averages of the previous window
+	windowStart  time.Time        // when the current window started
+	windowFrames int              // drawn in the current window
+	windowCells  int              // drawn in the current window
+	windowBytes  int              // written in the current window
+	input        time.Time        // the first input read since the last frame, zero if there is none
+}
+
+// frame records a frame of that many cells, measuring the latency if input was read since the previous frame
+func (m *drawMeter) frame(cells int) {
+	m.Lock()
+	defer m.Unlock()
+
+	now := time.Now()
+	m.roll(now)
+	m.metrics.Frames++
+	m.metrics.Cells += cells
+	m.windowFrames++
+	m.windowCells += cells
+	if !m.input.IsZero() {
+		m.metrics.Latency = now.Sub(m.input)
+		m.input = time.Time{}
+	}
+}
+
+// wrote records the bytes written to the terminal
+func (m *drawMeter) wrote(n int) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.roll(time.Now())
+	m.metrics.Bytes += n
+	m.windowBytes += n
+}
+
+// exclude takes back the bytes written by the debug overlay, so it doesn't measure itself
+func (m *drawMeter) exclude(n int) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.metrics.Bytes = term.Max(m.metrics.Bytes-n, 0)
+	m.windowBytes = term.Max(m.windowBytes-n, 0)
+}
+
+// inputRead records that input was read, the latency being measured up to the next frame
+func (m *drawMeter) inputRead() {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.input.IsZero() {
+		m.input = time.Now()
+	}
+}
+
+// snapshot returns the metrics
+func (m *drawMeter) snapshot() term.DrawMetrics {
+	m.Lock()
+	defer m.Unlock()
+
+	m.roll(time.Now())
+	return m.metrics
+}
+
+// roll computes the averages once the window is over, then starts a new one - locked inside caller function
+func (m *drawMeter) roll(now time.Time) {
+	if m.windowStart.IsZero() {
+		m.windowStart = now
+		return
+	}
+	elapsed := now.Sub(m.windowStart)
+	if elapsed < metricsWindow {
+		return
+	}
+	m.metrics.FPS = float64(m.windowFrames) / elapsed.Seconds() // lower after an idle period, the window being longer
+	m.metrics.CellsPerFrame, m.metrics.BytesPerFrame = 0, 0
+	if m.windowFrames > 0 {
+		m.metrics.CellsPerFrame = float64(m.windowCells) / float64(m.windowFrames)
+		m.metrics.BytesPerFrame = float64(m.windowBytes) / float64(m.windowFrames)
+	}
+	m.windowStart, m.windowFrames, m.windowCells, m.windowBytes = now, 0, 0, 0
+}
+
+// metered returns the writer used for displaying, counting the bytes written
+func (c *core) metered(out io.Writer) io.Writer {
+	return &meteredWriter{out: out, meter: c.meter}
+}
+
+// meteredWriter writes to the terminal, counting the bytes for DrawMetrics
+type meteredWriter struct {
+	out   io.Writer  //
+	meter *drawMeter //
+}
+
+// Write implements io.Writer interface
+func (w *meteredWriter) Write(p []byte) (int, error) {
+	n, err := w.out.Write(p)
+	w.meter.wrote(n)
+	return n, err
+}
+
+// scanInput is called with every chunk of input, before it's dispatched
+func (c *core) scanInput(in []byte) {
+	c.meter.inputRead()
+	c.scanInterrupts(in)
+}
+
+// DrawMetrics implements term.DrawMonitor interface
+func (c *core) DrawMetrics() term.DrawMetrics {
+	return c.meter.snapshot()
+}
@@ -0,0 +1,200 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/info"
+	"github.com/badu/term/style"
+)
+
+// ecmaProfiles are looked up before any engine is created, since creating one forgets the registered terminals
+var ecmaProfiles = func() map[string]*info.Term {
+	result := make(map[string]*info.Term)
+	for _, name := range []string{"xterm-256color", "vt100", "linux"} {
+		if ti, err := info.LookupTerminfo(name); err == nil {
+			result[name] = ti
+		}
+	}
+	return result
+}()
+
+// ecmaScene renders the known scene through the draw path of the engine, returning what was written to the terminal
+func ecmaScene(t *testing.T, ti *info.Term) []byte {
+	t.Helper()
+	c := newBenchCore(t, WithTerminfo(ti))
+	c.resize(10, 3, false)
+	buf := &bytes.Buffer{}
+	c.comm.PutEnterCA(buf)
+	c.comm.PutHideCursor(buf)
+	c.comm.PutClear(buf)
+	plain := style.Style{Fg: color.Default, Bg: color.Default}
+	c.drawPixels(buf,
+		&regionPixel{hash: term.Hash(0, 0), r: 'a', st: style.Style{Fg: color.Red, Bg: color.Default, Attrs: style.Bold}},
+		&regionPixel{hash: term.Hash(1, 0), r: 'b', st: style.Style{Fg: color.Red, Bg: color.Default, Attrs: style.Bold}},
+		&regionPixel{hash: term.Hash(4, 1), r: 'c', st: style.Style{Fg: color.Default, Bg: color.Blue, Attrs: style.Underline | style.Reverse}},
+		&regionPixel{hash: term.Hash(5, 1), r: ' ', st: plain},
+		&regionPixel{hash: term.Hash(6, 1), r: ' ', st: plain},
+		&regionPixel{hash: term.Hash(7, 1), r: ' ', st: plain},
+		&regionPixel{hash: term.Hash(8, 1), r: ' ', st: plain},
+		&regionPixel{hash: term.Hash(9, 1), r: ' ', st: plain},
+		&regionPixel{hash: term.Hash(0, 2), r: 'd', st: plain},
+	)
+	c.comm.PutAttrOff(buf)
+	c.comm.PutShowCursor(buf)
+	c.comm.PutExitCA(buf)
+	return buf.Bytes()
+}
+
+// ecmaTokens splits the output into control functions, as ECMA-48 (5.4) defines them, and runs of text.
+// The tokens are written as "CSI <parameters> <intermediates> <final>", "ESC <bytes>", "C0 <byte>", "OSC <string>" or "TEXT <runes>", the bytes of the sequences being shown as they are.
+// An error is returned for the sequences which are not well formed.
+func ecmaTokens(b []byte) ([]string, error) {
+	var result []string
+	text := &strings.Builder{}
+	flush := func() {
+		if text.Len() > 0 {
+			result = append(result, "TEXT "+text.String())
+			text.Reset()
+		}
+	}
+	for i := 0; i < len(b); {
+		switch {
+		case b[i] == 0x1b && i+1 < len(b) && b[i+1] == '[': // control sequence introducer
+			flush()
+			start := i + 2
+			end := start
+			for end < len(b) && b[end] >= 0x30 && b[end] <= 0x3f { // parameter bytes
+				end++
+			}
+			params := end
+			for end < len(b) && b[end] >= 0x20 && b[end] <= 0x2f { // intermediate bytes
+				end++
+			}
+			if end >= len(b) || b[end] < 0x40 || b[end] > 0x7e {
+				return result, fmt.Errorf("control sequence without final byte at %d : %q", i, b[i:])
+			}
+			if err := ecmaParameters(b[start:params]); err != nil {
+				return result, fmt.Errorf("control sequence at %d : %v", i, err)
+			}
+			result = append(result, strings.TrimSpace(fmt.Sprintf("CSI %s %s", b[start:params], b[params:end]))+" "+string(b[end]))
+			i = end + 1
+		case b[i] == 0x1b && i+1 < len(b) && b[i+1] == ']': // operating system command, up to the string terminator
+			flush()
+			end := bytes.IndexAny(b[i+2:], "\x07\x1b")
+			if end < 0 || (b[i+2+end] == 0x1b && (i+3+end >= len(b) || b[i+3+end] != '\\')) {
+				return result, fmt.Errorf("operating system command without terminator at %d", i)
+			}
+			result = append(result, "OSC "+string(b[i+2:i+2+end]))
+			i += 2 + end + 1
+			if b[i-1] == 0x1b {
+				i++
+			}
+		case b[i] == 0x1b: // escape sequence : intermediate bytes, then the final one
+			flush()
+			end := i + 1
+			for end < len(b) && b[end] >= 0x20 && b[end] <= 0x2f {
+				end++
+			}
+			if end >= len(b) || b[end] < 0x30 || b[end] > 0x7e {
+				return result, fmt.Errorf("escape sequence without final byte at %d : %q", i, b[i:])
+			}
+			result = append(result, "ESC "+string(b[i+1:end+1]))
+			i = end + 1
+		case b[i] < 0x20 || b[i] == 0x7f: // the other control functions
+			flush()
+			result = append(result, fmt.Sprintf("C0 %02x", b[i]))
+			i++
+		default:
+			text.WriteByte(b[i])
+			i++
+		}
+	}
+	flush()
+	return result, nil
+}
+
+// ecmaParameters checks the parameter string of a control sequence : numbers and sub-strings separated by ';' (or ':'), optionally starting with a private marker (one of "<=>?")
+func ecmaParameters(p []byte) error {
+	if len(p) > 0 && p[0] >= '<' && p[0] <= '?' {
+		p = p[1:]
+	}
+	for _, part := range bytes.FieldsFunc(p, func(r rune) bool { return r == ';' || r == ':' }) {
+		for _, ch := range part {
+			if ch < '0' || ch > '9' {
+				return fmt.Errorf("parameter %q is not a number", part)
+			}
+		}
+		if len(part) > 1 && part[0] == '0' {
+			return fmt.Errorf("parameter %q is not in the canonical form", part)
+		}
+	}
+	return nil
+}
+
+func TestECMA48Tokens(t *testing.T) {
+	tokens, err := ecmaTokens([]byte("a\x1b[1;31mb\x1b(B\x1b]2;title\x07\r"))
+	if err != nil || strings.Join(tokens, "|") != "TEXT a|CSI 1;31 m|TEXT b|ESC (B|OSC 2;title|C0 0d" {
+		t.Errorf("error : unexpected tokens %q %v", tokens, err)
+	}
+	for _, malformed := range []string{"\x1b[1;", "\x1b[1;\x07", "\x1b[01m", "\x1b]2;title", "\x1b("} {
+		if _, err := ecmaTokens([]byte(malformed)); err == nil {
+			t.Errorf("error : %q should not be well formed", malformed)
+		}
+	}
+}
+
+// TestECMA48Compliance renders the same scene for each profile, the output having to be made of well formed sequences, in their canonical forms.
+// A change here means the Commander or the draw path writes something else : check it on the terminal, then update the expected tokens.
+func TestECMA48Compliance(t *testing.T) {
+	expected := map[string][]string{
+		"xterm-256color": {
+			"CSI ?1049 h", "CSI 22;0;0 t", "CSI ?25 l", "CSI H", "CSI 2 J",
+			"CSI 1;1 H", "ESC (B", "CSI m", "CSI 91 m", "CSI 1 m", "TEXT ab",
+			"CSI 2;5 H", "ESC (B", "CSI m", "CSI 104 m", "CSI 4 m", "CSI 7 m", "TEXT c",
+			"ESC (B", "CSI m", "CSI K",
+			"CSI 3;1 H", "TEXT d",
+			"ESC (B", "CSI m", "CSI ?12 l", "CSI ?25 h", "CSI ?1049 l", "CSI 23;0;0 t",
+		},
+		"vt100": {
+			"CSI H", "CSI J",
+			"CSI 1;1 H", "CSI m", "C0 0f", "CSI 1 m", "TEXT ab",
+			"CSI 2;5 H", "CSI m", "C0 0f", "CSI 4 m", "CSI 7 m", "TEXT c",
+			"CSI m", "C0 0f", "CSI K",
+			"CSI 3;1 H", "TEXT d",
+			"CSI m", "C0 0f",
+		},
+		"linux": {
+			"CSI ?25 l", "CSI ?1 c", "CSI H", "CSI J",
+			"CSI 1;1 H", "CSI m", "C0 0f", "CSI 31 m", "CSI 1 m", "TEXT ab",
+			"CSI 2;5 H", "CSI m", "C0 0f", "CSI 44 m", "CSI 4 m", "CSI 7 m", "TEXT c",
+			"CSI m", "C0 0f", "CSI K",
+			"CSI 3;1 H", "TEXT d",
+			"CSI m", "C0 0f", "CSI ?25 h", "CSI ?0 c",
+		},
+	}
+	for name, want := range expected {
+		ti, ok := ecmaProfiles[name]
+		if !ok {
+			t.Errorf("error : %s is not registered", name)
+			continue
+		}
+		tokens, err := ecmaTokens(ecmaScene(t, ti))
+		if err != nil {
+			t.Errorf("error : %s output is not well formed : %v", name, err)
+			continue
+		}
+		for _, token := range tokens {
+			if strings.HasPrefix(token, "TEXT") && strings.Contains(token, "$<") {
+				t.Errorf("error : %s output holds a padding indication as text : %q", name, token)
+			}
+		}
+		if got := strings.Join(tokens, "|"); got != strings.Join(want, "|") {
+			t.Errorf("error : %s output changed\ngot  %s\nwant %s", name, got, strings.Join(want, "|"))
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/badu/term"
+)
+
+// area is a part of the screen, the pixels drawn inside it having positions relative to its top left corner
+type area struct {
+	left    int  // the first column on the screen
+	top     int  // the first row on the screen
+	columns int  //
+	rows    int  //
+	toEOL   bool // the area reaches the right edge of the screen, so blank runs can be erased up to the end of the line
+	toEOS   bool // the area reaches the bottom right corner of the screen, so blank runs can be erased up to the end of the screen
+}
+
+// contains returns true if the position, relative to the area, is inside it
+func (a area) contains(column, row int) bool {
+	return column >= 0 && row >= 0 && column < a.columns && row < a.rows
+}
+
+// ReserveEdge implements term.EdgeReserver interface
+func (c *core) ReserveEdge(edge term.Edge, cells int) {
+	c.Lock()
+	defer c.Unlock()
+
+	cells = term.Max(cells, 0)
+	reserved := &c.edges.Top
+	switch edge {
+	case term.EdgeBottom:
+		reserved = &c.edges.Bottom
+	case term.EdgeLeft:
+		reserved = &c.edges.Left
+	case term.EdgeRight:
+		reserved = &c.edges.Right
+	}
+	if *reserved == cells {
+		return
+	}
+	*reserved = cells
+	c.relayout()
+	c.drawChrome() // the status and the overlay rows don't move, but the pages might have drawn over them
+}
+
+// Margins implements term.EdgeReserver interface. The rows reserved for the status (see SetStatus) and for the debug overlay (see ShowDebugOverlay) are included.
+func (c *core) Margins() term.Margins {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.margins()
+}
+
+// DrawEdge implements term.EdgeReserver interface. The pixels are drawn once : they have to be drawn again after the resize events.
+func (c *core) DrawEdge(edge term.Edge, pixels []term.PixelGetter) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.out == nil || c.plain || c.size == nil {
+		return
+	}
+	a := c.edgeArea(edge)
+	inside := make([]term.PixelGetter, 0, len(pixels))
+	for _, pixel := range pixels {
+		if a.contains(term.UnHash(pixel.PositionHash())) {
+			inside = append(inside, pixel)
+		}
+	}
+	if len(inside) == 0 {
+		return
+	}
+	buf := bytes.NewBuffer(nil)
+	c.drawIn(buf, a, inside...)
+	c.writeOut(buf)
+	c.restoreCursor()
+}
+
+// margins returns the reserved cells, the status and the debug overlay rows included - locked inside caller function
+func (c *core) margins() term.Margins {
+	result := c.edges
+	if c.status.reserved {
+		result.Bottom++
+	}
+	if c.overlay.shown && !c.plain {
+		result.Top++
+	}
+	return result
+}
+
+// edgeArea returns the cells reserved by the application at the edge. The corners belong to the top and bottom edges - locked inside caller function
+func (c *core) edgeArea(edge term.Edge) area {
+	m := c.margins()
+	switch edge {
+	case term.EdgeBottom:
+		return area{left: 0, top: c.screenRows - m.Bottom, columns: c.screenColumns, rows: c.edges.Bottom, toEOL: true, toEOS: !c.status.reserved}
+	case term.EdgeLeft:
+		return area{left: 0, top: m.Top, columns: m.Left, rows: c.area.rows}
+	case term.EdgeRight:
+		return area{left: c.screenColumns - m.Right, top: m.Top, columns: m.Right, rows: c.area.rows, toEOL: true}
+	default:
+		return area{left: 0, top: m.Top - c.edges.Top, columns: c.screenColumns, rows: c.edges.Top, toEOL: true} // below the debug overlay
+	}
+}
+
+// relayout computes the area left to the pages again, after the reserved cells have changed, dispatching the new size - locked inside caller function
+func (c *core) relayout() {
+	if c.size == nil {
+		return // computed when the size is known
+	}
+	c.size = nil // forces the resize
+	c.resize(c.screenColumns, c.screenRows, false)
+
+	// dispatched by another goroutine, since the caller might be one of the listeners
+	go func(ev term.ResizeEvent) {
+		c.Lock()
+		defer c.Unlock()
+		for _, cons := range c.receivers {
+			cons.ch <- ev
+		}
+	}(newResizeEvent(c.size, c.margins()))
+}
+
+// goTo moves the cursor to the position, relative to the area left to the pages - locked inside caller function
+func (c *core) goTo(w io.Writer, pos *term.Position) {
+	c.comm.GoToXY(w, pos.Column+c.area.left, pos.Row+c.area.top)
+}
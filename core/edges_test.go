@@ -0,0 +1,110 @@
+package core
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/badu/term"
+	"github.com/badu/term/style"
+)
+
+func TestReserveEdge(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+
+	c.ReserveEdge(term.EdgeTop, 1)
+	c.ReserveEdge(term.EdgeLeft, 2)
+	if size := c.Size(); size.Columns != benchColumns-2 || size.Rows != benchRows-1 {
+		t.Errorf("error : the reserved cells should be excluded from the size, got %d x %d", size.Columns, size.Rows)
+	}
+	if margins := c.Margins(); margins != (term.Margins{Top: 1, Left: 2}) {
+		t.Errorf("error : unexpected margins %+v", margins)
+	}
+
+	// the pixels of the pages are translated
+	c.drawPixels(c.out, &regionPixel{hash: term.Hash(0, 0), r: 'x'})
+	if out := written(); !strings.HasPrefix(out, "\x1b[2;3H") {
+		t.Errorf("error : the top left pixel should be drawn after the reserved cells, got %q", out)
+	}
+
+	// while the ones of the chrome are relative to the edge, the ones outside it being ignored
+	c.DrawEdge(term.EdgeTop, []term.PixelGetter{
+		&regionPixel{hash: term.Hash(5, 0), r: 'x'},
+		&regionPixel{hash: term.Hash(5, 1), r: 'y'},
+	})
+	if out := written(); !strings.HasPrefix(out, "\x1b[1;6H") || strings.Contains(out, "y") {
+		t.Errorf("error : the chrome should be drawn on the top row only, got %q", out)
+	}
+	c.DrawEdge(term.EdgeLeft, []term.PixelGetter{&regionPixel{hash: term.Hash(1, 0), r: 'x'}})
+	if out := written(); !strings.HasPrefix(out, "\x1b[2;2H") {
+		t.Errorf("error : the left chrome should start below the top one, got %q", out)
+	}
+
+	// erasing to the end of the line would wipe the chrome on the right
+	blanks := make([]term.PixelGetter, 0, benchColumns)
+	for column := 0; column < benchColumns-3; column++ {
+		blanks = append(blanks, &regionPixel{hash: term.Hash(column, 0), r: ' ', st: style.Style{}})
+	}
+	c.ReserveEdge(term.EdgeRight, 1)
+	c.drawPixels(c.out, blanks...)
+	if out := written(); strings.Contains(out, "\x1b[K") {
+		t.Errorf("error : blank runs should not be erased, having a right margin")
+	}
+	if !c.DeleteLines(0, 1) {
+		t.Errorf("error : lines should be redrawn, having margins on the sides")
+	}
+	if out := written(); strings.Contains(out, "\x1b[M") {
+		t.Errorf("error : lines should not be deleted by the terminal, having margins on the sides")
+	}
+
+	c.ReserveEdge(term.EdgeTop, 0)
+	c.ReserveEdge(term.EdgeLeft, 0)
+	c.ReserveEdge(term.EdgeRight, 0)
+	if size := c.Size(); size.Columns != benchColumns || size.Rows != benchRows {
+		t.Errorf("error : the cells should be given back, got %d x %d", size.Columns, size.Rows)
+	}
+}
+
+// resizeListener receives the resize events of the engine
+type resizeListener struct {
+	ch   chan term.ResizeEvent
+	died chan struct{}
+}
+
+func (l *resizeListener) ResizeListen() chan term.ResizeEvent { return l.ch }
+func (l *resizeListener) DyingChan() chan struct{}            { return l.died }
+
+func TestSizeReportWithEdges(t *testing.T) {
+	in, _ := io.Pipe()
+	c := newBenchCore(t, WithTransport(&pipeTransport{PipeReader: in, Writer: ioutil.Discard}))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("error starting : %v", err)
+	}
+	listener := &resizeListener{ch: make(chan term.ResizeEvent, 4), died: make(chan struct{})}
+	defer close(listener.died)
+	c.ResizeDispatcher().Register(listener)
+	c.ReserveEdge(term.EdgeRight, 3)
+	select {
+	case <-listener.ch: // the one of the relayout
+	case <-time.After(time.Second):
+		t.Fatalf("error : reserving an edge should resize the pages")
+	}
+
+	// the terminal reports the size of the whole screen, which didn't change
+	c.sizeReportCh <- &term.Size{Columns: benchColumns, Rows: benchRows}
+	c.sizeReportCh <- &term.Size{Columns: benchColumns + 1, Rows: benchRows}
+	select {
+	case ev := <-listener.ch:
+		if size := ev.Size(); size.Columns != benchColumns+1-3 || size.Rows != benchRows {
+			t.Errorf("error : the same screen size should not resize the pages, got %d x %d", size.Columns, size.Rows)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("error : the changed size should resize the pages")
+	}
+}
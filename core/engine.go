@@ -9,6 +9,8 @@ import (
 	"os"
 	"runtime"
 	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/badu/term"
 	"github.com/badu/term/color"
@@ -17,6 +19,7 @@ import (
 	_ "github.com/badu/term/info/base" // import the stock terminals
 	"github.com/badu/term/key"
 	"github.com/badu/term/mouse"
+	"github.com/badu/term/runewidth"
 	"github.com/badu/term/style"
 )
 
@@ -29,6 +32,23 @@ var (
 	// ErrNoCharset indicates that the locale environment the program is not supported by the program, because no suitable encoding was found for it.
 	// This problem never occurs if the environment is UTF-8 or UTF-16.
 	ErrNoCharset = errors.New("character set not supported")
+
+	// ErrNotStarted is returned by the methods which need the context passed to Start (e.g. PollEvent), when they are called before it.
+	ErrNotStarted = errors.New("engine not started")
+
+	// ErrNoReply is returned by the queries which the terminal didn't answer in time, usually because it doesn't support them, or because they are disallowed (e.g. the window operations of xterm).
+	ErrNoReply = errors.New("the terminal did not reply")
+
+	// ErrInputRedirected is returned by Start when the standard input is not a terminal (e.g. the program reads piped data), while WithTTYFallback(false) forbids reading the controlling terminal instead.
+	ErrInputRedirected = errors.New("the standard input is not a terminal")
+)
+
+const (
+	minBlankRun = 4          // minimum number of blank pixels, up to the end of the line, which are erased instead of being written
+	defaultTTY  = "/dev/tty" // the controlling terminal, on POSIX platforms
+
+	enableKittyKeyboard  = "\x1b[>3u" // kitty keyboard protocol : pushes the flags for disambiguating the escape codes (1) and reporting the event types (2)
+	disableKittyKeyboard = "\x1b[<u"  // pops the flags pushed on start
 )
 
 type Option func(core *core)
@@ -52,17 +72,9 @@ func WithWinSizeBufferedChannelSize(size int) Option {
 // WithRunesFallback is a functional option to set a different runes fallback equivalence. See defaultRunesFallback for current defaults.
 func WithRunesFallback(fallback map[rune]string) Option {
 	return func(c *core) {
-		if c.encoder == nil {
-			if Debug {
-				log.Printf("[core] encoder is NIL : should not happen!")
-			}
-			return
-		}
-		c.encoder.Lock()
-		defer c.encoder.Unlock()
-		c.encoder.fallback = make(map[rune]string)
+		c.runesFallback = make(map[rune]string)
 		for k, v := range fallback {
-			c.encoder.fallback[k] = v
+			c.runesFallback[k] = v
 		}
 	}
 }
@@ -70,7 +82,67 @@ func WithRunesFallback(fallback map[rune]string) Option {
 // WithTrueColor is a functional option to disable true color, if needed. Just set the trueColor to "disable".
 func WithTrueColor(trueColor string) Option {
 	return func(c *core) {
-		c.hasTrueColor = trueColor != "disable"
+		c.trueColor = trueColor
+	}
+}
+
+// WithColorMatcher is a functional option to replace the strategy used when matching colors against the terminal palette. Default is color.FindColor.
+func WithColorMatcher(fn style.FindColorFunc) Option {
+	return func(c *core) {
+		c.matcher = fn
+	}
+}
+
+// WithSizePolling is a functional option for terminals which never send SIGWINCH (some serial consoles, Windows SSH).
+// The terminal is asked for the text area size (CSI 18 t) at the given interval, and resize events are dispatched when the reported size changes.
+// Default is zero, meaning no polling.
+func WithSizePolling(interval time.Duration) Option {
+	return func(c *core) {
+		c.sizePolling = interval
+	}
+}
+
+// WithTerminfo is a functional option to force a terminal definition, instead of looking up the one named by $TERM
+func WithTerminfo(ti *info.Term) Option {
+	return func(c *core) {
+		c.ti = ti
+	}
+}
+
+// WithCapabilityOverrides is a functional option to patch individual capabilities (by their terminfo names, e.g. "smcup") of the terminal definition, see info.Term Patch.
+// An empty value removes the capability (e.g. a broken smcup on some emulator). NewCore fails on an unknown name (e.g. misspelled), returning info.ErrUnknownCapability.
+func WithCapabilityOverrides(overrides map[string]string) Option {
+	return func(c *core) {
+		if c.capOverrides == nil {
+			c.capOverrides = make(map[string]string)
+		}
+		for k, v := range overrides {
+			c.capOverrides[k] = v
+		}
+	}
+}
+
+// WithAltNormalization is a functional option for turning the Alt key encodings (ESC prefix, 8th bit set, CSI 1;3X) into key events having ModAlt. Default is enabled.
+// Disabling it delivers the ESC prefix as an Esc key event and the 8th bit bytes as Latin-1 runes, for applications which want the raw distinction.
+func WithAltNormalization(enabled bool) Option {
+	return func(c *core) {
+		c.rawAlt = !enabled
+	}
+}
+
+// WithKittyKeyboard is a functional option for enabling the kitty keyboard protocol, on the terminals which support it (kitty, foot, ghostty, wezterm, etc.).
+// The terminal reports which key events are auto-repeats (see term.KeyEvent Repeat), and the Alt and Ctrl combinations can't be confused with the legacy sequences. Default is disabled.
+func WithKittyKeyboard(enabled bool) Option {
+	return func(c *core) {
+		c.kittyKeyboard = enabled
+	}
+}
+
+// WithKeyRepeatInterval is a functional option for guessing the auto-repeated keys by timing, on terminals which don't report them : the same key arriving again within the interval is marked as repeat.
+// Default is zero, which disables the guessing. A value slightly over the keyboard repeat rate (e.g. 50 milliseconds) works for most setups.
+func WithKeyRepeatInterval(interval time.Duration) Option {
+	return func(c *core) {
+		c.repeatInterval = interval
 	}
 }
 
@@ -82,7 +154,12 @@ type core struct {
 	comm            *info.Commander      // terminal Commander
 	termIOSPrv      *termiosPrivate      // required by internalStart
 	in              *os.File             // input, acquired in internalStart, released in internalShutdown
-	out             *os.File             // output, acquired in internalStart, released in internalShutdown, used for displaying
+	stdin           *os.File             // the standard input, checked by openInput
+	ttyFallback     bool                 // set by WithTTYFallback, the controlling terminal is read when the standard input is redirected
+	ttyPath         string               // the controlling terminal, opened by internalStart
+	tty             *os.File             // output, acquired in internalStart, released in internalShutdown
+	outFile         *os.File             // set by WithOutputFile, used as the output instead of the controlling terminal, never closed
+	out             io.Writer            // the output, teeing to the mirror if set by WithMirror, used for displaying
 	died            chan struct{}        // this is a buffered channel of size one
 	winSizeCh       chan os.Signal       // listens for resize signals and transforms them into resize events in the dispatcher section
 	mouseSwitch     chan bool            // listens for mouse enable/disable requests
@@ -105,41 +182,136 @@ type core struct {
 	canSetBgFg      bool                 // true if len(comm.Term.SetFgBg) > 0
 	canSetFg        bool                 // true if len(comm.Term.SetFg) > 0
 	canSetBg        bool                 // true if len(comm.Term.SetBg) > 0
+	canClearToEOL   bool                 // true if len(comm.Term.ClearToEOL) > 0
+	canClearToEOS   bool                 // true if len(comm.Term.ClearToEOS) > 0
+	ti              *info.Term           // set by WithTerminfo, forced terminal definition
+	capOverrides    map[string]string    // set by WithCapabilityOverrides, patches the terminal definition
+	runesFallback   map[rune]string      // set by WithRunesFallback, replaces the default runes fallback
+	matcher         style.FindColorFunc  // set by WithColorMatcher, strategy for matching colors against the palette
+	trueColor       string               // set by WithTrueColor, "disable" disables true color
+	profile         term.ColorProfile    // color profile, after applying the environment conventions
+	colors          int                  // number of palette colors in use, zero if colors are disabled
+	plain           bool                 // true if the output is rendered as plain lines of text, see WithPlainOutput
+	plainSet        bool                 // true if the plain mode was set by WithPlainOutput, instead of being detected
+	screen          *plainScreen         // in plain mode, holds the content of the screen
+	theme           *themeWatcher        // handles background reports, dispatches theme events
+	timers          *timers              // dispatches tick events
+	jobs            *jobs                // the functions queued by Post
+	modes           *modeWatcher         // the states of the DEC private modes, reported by the terminal
+	inputBuffer     int                  // set by WithInputBuffer, the buffer size of the dispatchers input channels
+	keyMeter        *inputMeter          // the backpressure of the key dispatcher input
+	mouseMeter      *inputMeter          // the backpressure of the mouse dispatcher input
+	meter           *drawMeter           // the frames drawn and the bytes written, see DrawMetrics
+	lost            *lostTerminal        // the first write failure or the end of the input, see TerminalErr
+	stop            context.CancelFunc   // cancels the context derived in Start, when the engine stops by itself
+	reports         *reportFilter        // removes the terminal reports from input
+	sizePolling     time.Duration        // set by WithSizePolling, interval for querying the text area size
+	forcedColumns   int                  // set by WithSize, overrides the number of columns reported by the terminal
+	forcedRows      int                  // set by WithSize, overrides the number of rows reported by the terminal
+	sizeReportCh    chan *term.Size      // the text area sizes reported by the terminal
+	content         map[int]term.Pixel   // the active pixels, by position hash (see GetContent)
+	frame           map[int]uint64       // the content hashes of the cells drawn in the area, by position hash, while known (see scrollFrame)
+	pointerShape    string               // the mouse pointer shape set by SetPointerShape, restored on shutdown
+	interrupts      InterruptMode        // set by WithInterrupts, how Ctrl+C and Ctrl+\ are handled
+	interruptScan   interruptScanner     // the state of scanInterrupts, used by the input reader goroutine only
+	interruptCancel context.CancelFunc   // set by WithCancelOnInterrupt, called on every interrupt
+	interruptCh     chan struct{}        // notified each time the user asks to quit
+	rawAlt          bool                 // set by WithAltNormalization(false), the Alt key encodings are delivered as they are
+	kittyKeyboard   bool                 // set by WithKittyKeyboard, the kitty keyboard protocol is pushed on start and popped on shutdown
+	repeatInterval  time.Duration        // set by WithKeyRepeatInterval, passed to the key dispatcher
+	poller          *poller              // queues the events for PollEvent
+	bracketedPaste  bool                 // set by WithBracketedPaste, the pasted text is delivered as a single event
+	focusReports    bool                 // set by WithFocusReports, the terminal reports focus changes
+	diagnostics     *diagnostics         // set by WithDiagnostics, the rendering decisions made because of missing capabilities
+	blinkPolicy     BlinkPolicy          // set by WithBlinkPolicy, how the Blink attribute is rendered
+	attrFallbacks   attrTable            // set by WithAttributeFallbacks, rendered instead of the attributes the terminal lacks
+	fallbackTerm    string               // set by WithFallbackTerminal, used when the terminal named by $TERM can't be found
+	status          statusLine           // set by SetStatus, shown outside the pixels grid
+	edges           term.Margins         // set by ReserveEdge, the cells reserved by the application
+	area            area                 // the part of the screen left to the pages, after the reserved cells
+	screenColumns   int                  // the columns of the terminal, the reserved ones included
+	screenRows      int                  // the rows of the terminal, the reserved ones included
+	boundsMode      BoundsMode           // set by WithBoundsMode, what happens with the pixels drawn outside the screen
+	boundsErr       error                // the last pixel drawn outside the screen, in ReportOutOfBounds mode
+	restoreModes    bool                 // set by WithModeRestore, the DEC private modes are saved on start and restored on shutdown
+	mirror          io.Writer            // set by WithMirror, receives a copy of the output
+	mirrorStrip     bool                 // set by WithMirrorStripping, the mirror doesn't receive the cursor movements and the erasing sequences
+	transport       Transport            // set by WithTransport, used instead of the controlling terminal
+	baud            int                  // set by WithBaud, the speed of the serial line, used for the padding and for pacing the output
+	highContrast    bool                 // set by WithHighContrast or SetHighContrast, the colors are changed to reach the AAA contrast ratio
+	notifications   NotificationMode     // set by WithNotifications, detected if not set
+	windows         *windowWatcher       // the callers waiting for the replies to the window queries
+	search          searchState          // set by Search, the matches being highlighted when drawn
+	layers          layerState           // set by SetLayer, drawn above the active pixels
+	overlay         debugOverlay         // set by WithDebugOverlay or ShowDebugOverlay, the draw metrics shown on the top row
 }
 
 // NewCore returns a Engine that uses the stock TTY interface and POSIX termios, combined with a comm description taken from the $TERM environment variable.
 // It returns an error if the terminal is not supported for any reason.
 // For terminals that do not support dynamic resize events, the $LINES $COLUMNS environment variables can be set to the actual window size, otherwise defaults taken from the terminal database are used.
 func NewCore(termEnv string, options ...Option) (term.Engine, error) {
-	ti, err := info.LookupTerminfo(termEnv)
-	if err != nil {
-		ti, err = loadDynamicTerminfo(termEnv)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	hasTrueColor := false
-	if len(ti.SetFgBgRGB) > 0 || len(ti.SetFgRGB) > 0 || len(ti.SetBgRGB) > 0 {
-		hasTrueColor = true
-	}
-
 	res := &core{
-		comm:         info.NewCommander(ti),                  // terminal comm
 		died:         make(chan struct{}),                    // init of died channel, a buffered channel of exactly one
 		mouseSwitch:  make(chan bool, 1),                     // listens incoming requests from mouse
 		receivers:    make(channels, 0),                      // init the receivers slice of channels which will register themselves for resizing events
 		winSizeCh:    make(chan os.Signal, runtime.NumCPU()), // listening resize events (OS specific)
-		style:        style.NewTermStyle(ti.Colors),
 		charset:      getCharset(),
-		hasTrueColor: hasTrueColor,
-		canSetRGB:    len(ti.SetFgRGB) > 0,
-		canSetBgFg:   len(ti.SetFgBg) > 0,
-		canSetBg:     len(ti.SetBg) > 0,
-		canSetFg:     len(ti.SetFg) > 0,
 		cachedBG:     color.Default,
 		cachedFG:     color.Default,
 		cachedAttrs:  style.None,
+		theme:        &themeWatcher{},
+		timers:       &timers{},
+		jobs:         newJobs(),
+		modes:        &modeWatcher{},
+		windows:      &windowWatcher{},
+		restoreModes: true,
+		keyMeter:     &inputMeter{},
+		mouseMeter:   &inputMeter{},
+		meter:        &drawMeter{},
+		lost:         newLostTerminal(),
+		reports:      &reportFilter{},
+		sizeReportCh: make(chan *term.Size, 1),
+		interruptCh:  make(chan struct{}, 1),
+		poller:       newPoller(),
+		blinkPolicy:  AllowBlink,
+		fallbackTerm: defaultFallbackTerm,
+		ttyPath:      defaultTTY,
+		stdin:        os.Stdin,
+		ttyFallback:  true,
+	}
+	res.theme.requery = res.queryBackground
+	res.reports.add(backgroundReport, res.theme.parseBackground)
+	res.reports.add(themeReport, res.theme.parseThemeChange)
+	res.reports.add(sizeReport, res.parseSizeReport)
+	res.reports.add(windowPositionReport, res.windows.parseWindowReport)
+	res.reports.add(windowPixelsReport, res.windows.parseWindowReport)
+	res.reports.addWithLimit(pasteStart, maxPaste, res.poller.parsePaste)
+	res.reports.add(focusIn, res.poller.parseFocus)
+	res.reports.add(focusOut, res.poller.parseFocus)
+	res.reports.add(modeReport, res.modes.parseModeReport) // after the other CSI ? reports, since it's a prefix of theirs
+
+	for _, o := range options {
+		o(res)
+	}
+
+	if !res.plainSet {
+		res.plain = !isTTY(res.outputFile()) // output is piped to a file or to another program, or it's a character device which is not a terminal (e.g. /dev/null)
+	}
+	if res.plain {
+		res.screen = &plainScreen{}
+	}
+
+	ti, err := res.setupTerminal(termEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case res.notifications != NotifyDetect:
+	case res.transport != nil:
+		res.notifications = NotifyNone // the environment is not the one of the remote terminal
+	default:
+		res.notifications = detectNotifications(termEnv, os.LookupEnv)
 	}
 
 	info.RemoveAllInfos() // Commander was built, delete info map to free some RAM
@@ -148,32 +320,37 @@ func NewCore(termEnv string, options ...Option) (term.Engine, error) {
 		res.encoder = newEncoder(e.NewEncoder())
 		res.encoder.buildAlternateRunesMap(res.comm.AltChars, res.comm.EnterAcs, res.comm.ExitAcs)
 		res.encoder.defaultRunesFallback()
+		if res.runesFallback != nil {
+			res.encoder.fallback = res.runesFallback
+		}
 	} else {
 		return nil, ErrNoCharset
 	}
 
-	for _, o := range options {
-		o(res)
-	}
-
 	if res.comm.HasMouse {
 		// creating dispatchers for key and mouse
 		res.mouseDispatcher, err = mouse.NewEventDispatcher(
 			mouse.WithTerminalInfo(ti),
 			mouse.WithSwitchChannel(res.mouseSwitch),
+			mouse.WithInputBuffer(res.inputBuffer),
 		)
 		if err != nil {
-			if Debug {
-				log.Printf("error creating mouse dispatcher : %v", err)
+			if Debug.Enabled() {
+				Debug.Printf("error creating mouse dispatcher : %v", err)
 			}
 			return nil, err
 		}
 	}
 
-	res.keyDispatcher, err = key.NewEventDispatcher(key.WithTerminalInfo(ti))
+	res.keyDispatcher, err = key.NewEventDispatcher(
+		key.WithTerminalInfo(ti),
+		key.WithAltNormalization(!res.rawAlt),
+		key.WithRepeatInterval(res.repeatInterval),
+		key.WithInputBuffer(res.inputBuffer),
+	)
 	if err != nil {
-		if Debug {
-			log.Printf("error creating key dispatcher : %v", err)
+		if Debug.Enabled() {
+			Debug.Printf("error creating key dispatcher : %v", err)
 		}
 		return nil, err
 	}
@@ -185,46 +362,154 @@ func NewCore(termEnv string, options ...Option) (term.Engine, error) {
 func (c *core) Start(ctx context.Context) error {
 	var err error
 	c.Once.Do(func() {
+		ctx, c.stop = context.WithCancel(ctx) // the engine stops when the caller says so, or when the terminal is gone
 		c.ctx = ctx
-
-		if err = c.internalStart(); err != nil {
-			if Debug {
-				log.Printf("error while internal starting : %v", err)
+		c.theme.Lock()
+		c.theme.ctx = ctx
+		c.theme.Unlock()
+		c.timers.Lock()
+		c.timers.ctx = ctx
+		c.timers.Unlock()
+
+		switch {
+		case c.plain:
+			err = c.plainStart()
+		case c.transport != nil:
+			err = c.transportStart()
+		default:
+			err = c.internalStart()
+		}
+		if err != nil {
+			if Debug.Enabled() {
+				Debug.Printf("error while internal starting : %v", err)
 			}
 			return
 		}
+		if !c.plain {
+			c.Lock()
+			c.saveModes() // before the mouse is enabled, by the lifecycle
+			c.comm.PutInit(c.out)
+			c.Unlock()
+		}
 
 		c.lifeCycle(ctx) // mounting context cancel listener
+		c.watchSignals(ctx)
 		c.keyDispatcher.LifeCycle(ctx)
-		if c.comm.HasMouse { // if we have mouse support
+		c.listenOverlayKey(ctx)
+		if c.comm.HasMouse && !c.plain { // if we have mouse support
 			c.Register(c.mouseDispatcher) // register resize listening
 			c.mouseDispatcher.LifeCycle(ctx)
 			c.mouseDispatcher.Enable()
 		}
-		if Debug {
-			log.Println("[START] multiplexer mounted.")
+		if Debug.Enabled() {
+			Debug.Println("[START] multiplexer mounted.")
 		}
 
-		c.comm.PutEnterCA(c.out)
-		c.comm.PutHideCursor(c.out)
-		c.comm.GoTo(c.out, c.maximumPosition.Hash()) // put cursor outside screen
-		c.comm.PutEnableAcs(c.out)
-		c.comm.PutClear(c.out)
+		if !c.plain {
+			c.comm.PutEnterCA(c.out)
+			c.comm.PutHideCursor(c.out)
+			c.comm.GoTo(c.out, c.maximumPosition.Hash()) // put cursor outside screen
+			c.comm.PutEnableAcs(c.out)
+			c.comm.PutClear(c.out)
+			c.forgetFrame()
+			c.comm.WriteString(c.out, enableThemeReports)
+			c.comm.WriteString(c.out, backgroundQuery) // the reply is handled by themeWatcher
+			if c.kittyKeyboard {
+				c.comm.WriteString(c.out, enableKittyKeyboard)
+			}
+			if c.bracketedPaste {
+				c.comm.WriteString(c.out, enableBracketedPaste)
+			}
+			if c.focusReports {
+				c.comm.WriteString(c.out, enableFocusReports)
+			}
+			c.Lock()
+			// after enabling them, the replies confirming they took effect
+			c.queryModes(term.ModeThemeReports, term.ModeSynchronizedOutput)
+			c.drawChrome() // set before start
+			if c.overlay.shown {
+				c.refreshOverlay(ctx)
+			}
+			c.Unlock()
+		}
 
-		ev := &EventResize{size: c.size}   // create one event for everyone
-		for _, cons := range c.receivers { // dispatch initial resize event, to inform listeners about width and height
-			cons <- ev
+		c.Lock()
+		ev := newResizeEvent(c.size, c.margins()) // create one event for everyone
+		receivers := make(channels, len(c.receivers))
+		copy(receivers, c.receivers) // the listeners can register or die meanwhile
+		c.Unlock()
+		for _, cons := range receivers { // dispatch initial resize event, to inform listeners about width and height
+			cons.ch <- ev
 		}
 	})
 	return err
 }
 
+// setupTerminal looks up the terminal definition, then builds the Commander and the palette, honoring the color options and the environment (see envProfile)
+func (c *core) setupTerminal(termEnv string) (*info.Term, error) {
+	var err error
+	ti := c.ti
+	if ti == nil {
+		ti, err = lookupTerminfo(termEnv)
+		if err != nil {
+			if c.plain {
+				ti = plainTerminfo(termEnv)
+			} else if ti, err = c.fallbackTerminfo(termEnv, err); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(c.capOverrides) > 0 {
+		patched, err := ti.Patch(c.capOverrides)
+		if err != nil {
+			return nil, err
+		}
+		ti = patched
+	}
+
+	hasTrueColor := false
+	if len(ti.SetFgBgRGB) > 0 || len(ti.SetFgRGB) > 0 || len(ti.SetBgRGB) > 0 {
+		hasTrueColor = true
+	}
+	if len(c.trueColor) > 0 {
+		hasTrueColor = c.trueColor != "disable"
+	}
+
+	var forced bool
+	c.profile, forced = envProfile(detectProfile(ti.Colors, hasTrueColor), os.LookupEnv)
+	if c.plain && !forced {
+		c.profile = term.ProfileNone // plain text, unless colors are forced
+	}
+	hasTrueColor = c.profile == term.ProfileTrueColor
+	c.colors = paletteSize(ti.Colors, c.profile, forced)
+	if forced && c.colors > ti.Colors {
+		patched, err := ti.Patch(ansiColorCapabilities) // the terminal declares fewer colors than forced, so we're using the ANSI sequences
+		if err != nil {
+			return nil, err
+		}
+		ti = patched
+		ti.Colors = c.colors
+	}
+
+	c.comm = info.NewCommander(ti) // terminal comm
+	c.comm.Baud = c.baud
+	c.style = style.NewTermStyle(c.colors, style.WithMatcher(c.matcher))
+	c.hasTrueColor = hasTrueColor
+	c.canSetRGB = len(ti.SetFgRGB) > 0
+	c.canSetBgFg = len(ti.SetFgBg) > 0
+	c.canSetBg = len(ti.SetBg) > 0
+	c.canSetFg = len(ti.SetFg) > 0
+	c.canClearToEOL = len(ti.ClearToEOL) > 0
+	c.canClearToEOS = len(ti.ClearToEOS) > 0
+	return ti, nil
+}
+
 // HasMouse returns true if there is any mouse support
 func (c *core) HasMouse() bool {
 	c.Lock()
 	defer c.Unlock()
 
-	return c.comm.HasMouse
+	return c.comm.HasMouse && !c.plain
 }
 
 // ResizeDispatcher implements the term.Engine interface, exposes so call to Register(r Receiver) method
@@ -290,15 +575,23 @@ func (c *core) NumColors() int {
 	if c.hasTrueColor {
 		return 1 << 24
 	}
-	return c.comm.Colors
+	return c.colors
+}
+
+// ColorProfile returns the color profile in use, after the environment conventions (NO_COLOR, CLICOLOR, CLICOLOR_FORCE, FORCE_COLOR) were applied
+func (c *core) ColorProfile() term.ColorProfile {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.profile
 }
 
-// Out returns the output file
+// Out returns the output file. Writing to it directly races with the pixels being drawn : use Writer instead
 func (c *core) Out() *os.File {
 	c.Lock()
 	defer c.Unlock()
 
-	return c.out
+	return c.tty
 }
 
 // HasTrueColor
@@ -313,39 +606,77 @@ func (c *core) HasTrueColor() bool {
 func (c *core) ActivePixels(pixels []term.PixelGetter) {
 	c.Lock()
 	if c.pixCancel != nil {
-		c.pixCancel() // shutdownPixel previous context, so we exit "main" goroutine
+		c.pixCancel() // cancel the previous context, so the goroutines of the previous pixels exit
 	}
 
 	var ctx context.Context
 	ctx, c.pixCancel = context.WithCancel(context.Background()) // create a new context, to allow cancellation
 
-	shutdownPixel := newCancellationPixel() // create one cancellation pixel, it will be used for sending shutdown message to all goroutines below
+	c.content = make(map[int]term.Pixel, len(pixels))
+	for _, pixel := range pixels {
+		if settable, ok := pixel.(term.Pixel); ok {
+			c.content[pixel.PositionHash()] = settable
+		}
+	}
 
 	for _, pixel := range pixels {
-		// mount a goroutine for each pixel. The exit mechanism is a convention: a pixel that has -1,-1 coordinates
-		go func(out *os.File, pix term.PixelGetter) {
-			for msg := range pix.DrawCh() { // listen incoming messages over the pixel draw request channel
-				if msg.PositionHash() == term.MinusOneMinusOne { // check if this is the cancellation pixel, we're exiting the goroutine
+		// mount a goroutine for each pixel, which exits when the pixels are replaced (or forgotten, on shutdown)
+		go func(out io.Writer, pix term.PixelGetter, last int, done <-chan struct{}) {
+			for {
+				select {
+				case <-done:
 					return
+				case msg := <-pix.DrawCh(): // listen incoming messages over the pixel draw request channel
+					previous, current := last, msg.PositionHash()
+					last = current
+					go func(o io.Writer, p term.PixelGetter, previous, current int) { // running in a separate goroutine, because it blocks reading new messages
+						c.Lock()
+						defer c.Unlock()
+						select {
+						case <-done:
+							return // replaced while waiting for the lock
+						default:
+						}
+						if previous != current {
+							c.movePixel(o, p, previous)
+						}
+						c.drawPixels(o, p)
+					}(out, msg, previous, current)
 				}
-				go func(o *os.File, p term.PixelGetter) { // running in a separate goroutine, because it blocks reading new messages
-					c.Lock()
-					defer c.Unlock()
-					c.drawPixels(o, p)
-				}(out, msg)
 			}
-		}(c.out, pixel)
-		// for each pixel, mounting a kill switch, which will write the shutdown message when the context is done
-		go func(pixCh chan term.PixelGetter, done <-chan struct{}, shutdownPix term.PixelGetter) {
-			<-done               // blocking wait for done
-			pixCh <- shutdownPix // write shutdownPixel pixel, so go routine above exits
-		}(pixel.DrawCh(), ctx.Done(), shutdownPixel) // observe that all parameters are passed to the goroutine
+		}(c.out, pixel, pixel.PositionHash(), ctx.Done()) // observe that all parameters are passed to the goroutine. The position is tracked, since the pixels implementing term.Mover can change it
 	}
 	c.Unlock() // Redraw locks it again
 	c.Redraw(pixels)
-	if Debug {
-		log.Printf("[core] %d pixels were drawn [%03d x %03d]", len(pixels), c.size.Columns, c.size.Rows)
+	if Debug.Enabled() {
+		Debug.Printf("%d pixels were drawn [%03d x %03d]", len(pixels), c.size.Columns, c.size.Rows)
+	}
+}
+
+// movePixel updates the active pixels after the pixel has moved, erasing it from the previous position - locked inside caller function
+func (c *core) movePixel(w io.Writer, pixel term.PixelGetter, previous int) {
+	if registered, ok := c.content[previous]; ok && term.PixelGetter(registered) == pixel {
+		delete(c.content, previous)
+	}
+	if settable, ok := pixel.(term.Pixel); ok {
+		if _, taken := c.content[pixel.PositionHash()]; !taken {
+			c.content[pixel.PositionHash()] = settable // unless another active pixel is registered there
+		}
+	}
+	if under, ok := c.content[previous]; ok {
+		c.drawPixels(w, under) // the pixel has moved away from over another one
+		return
 	}
+	c.drawPixels(w, &regionPixel{hash: previous, r: ' ', st: style.Style{Fg: color.Default, Bg: color.Default}})
+}
+
+// GetContent implements term.ContentGetter interface, returning the active pixel at that position
+func (c *core) GetContent(column, row int) (term.Pixel, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	pixel, ok := c.content[term.Hash(column, row)]
+	return pixel, ok
 }
 
 // Redraw immediately draws all the pixels
@@ -354,11 +685,22 @@ func (c *core) Redraw(cells []term.PixelGetter) {
 	c.Lock()
 	defer c.Unlock()
 	buf := bytes.NewBuffer(nil)
-	c.drawPixels(buf, cells...) // we use buffering, since we're redrawing everything
+	if c.search.pattern != nil {
+		c.searchAgain(buf) // the content might have scrolled
+	}
+	pixels := c.uncovered(c.bound(cells))
+	if c.canScroll() {
+		pixels = c.scrollFrame(buf, pixels) // the terminal moves the rows which have scrolled, so they are not written again
+	}
+	c.drawBounded(buf, pixels) // we use buffering, since we're redrawing everything
+	c.drawLayers(buf)
+	if c.plain {
+		c.flushPlain(buf) // in plain mode, each redraw writes a frame
+	}
 
 	if _, err := buf.WriteTo(c.out); err != nil { // writing buffer content to out
-		if Debug {
-			log.Printf("error writing to out : " + err.Error())
+		if Debug.Enabled() {
+			Debug.Printf("error writing to out : " + err.Error())
 		}
 	}
 }
@@ -376,6 +718,11 @@ func (c *core) ShowCursor(where *term.Position) {
 	c.Lock()
 	defer c.Unlock()
 
+	if c.plain {
+		c.cursorPosition = where
+		return
+	}
+
 	if where.Hash() > term.MinusOneMinusOne || where.Hash() > c.maximumPosition.Hash() {
 		// does not update cursor position
 		if c.comm.HasHideCursor {
@@ -387,7 +734,7 @@ func (c *core) ShowCursor(where *term.Position) {
 		return
 	}
 	c.cursorPosition = where
-	c.comm.GoTo(c.out, c.cursorPosition.Hash())
+	c.goTo(c.out, c.cursorPosition)
 	c.comm.PutShowCursor(c.out)
 }
 
@@ -397,6 +744,9 @@ func (c *core) HideCursor() {
 	defer c.Unlock()
 
 	c.cursorPosition = nil
+	if c.plain {
+		return
+	}
 	// does not update cursor position
 	if c.comm.HasHideCursor {
 		log.Println("has hide cursor")
@@ -412,115 +762,226 @@ func (c *core) HideCursor() {
 func (c *core) Clear() {
 	c.Lock()
 	defer c.Unlock()
+	if c.plain {
+		c.screen.clear()
+		return
+	}
 	c.comm.PutClear(c.out)
+	c.forgetFrame()
+	c.drawChrome()
 }
 
-// Style
-func (c *core) Style() term.Style {
-	return c.style
-}
+// InsertLines implements term.LineEditor interface
+func (c *core) InsertLines(row, count int) bool {
+	c.Lock()
+	defer c.Unlock()
 
-// resize remembers the width and height of the terminal. if a shutdown flag is set, the pixels listeners are forgot
-func (c *core) resize(w, h int, shutdown bool) {
-	if shutdown && c.pixCancel != nil {
-		c.pixCancel() // cancel context if we're shutting down and cancellation was declared
+	if count <= 0 || !c.prepareEdit(0, row) {
+		return false
 	}
-	if c.size != nil && (c.size.Rows == h && c.size.Columns == w) {
-		return
+	if c.comm.CanEditLines() && c.canEditLines() {
+		c.forgetFrame() // the content has moved
+		return c.comm.PutInsertLines(c.out, count) == nil && c.restoreCursor()
 	}
-	c.size = &term.Size{Columns: w, Rows: h}
-	mp := term.NewPosition(w, h)
-	c.maximumPosition = mp
-	c.comm.MakeGoToCache(c.size, term.Hash)
+	return c.redrawShifted(0, row, c.size.Columns, c.size.Rows-row, 0, count)
 }
 
-// drawPixels - locked inside caller function
-func (c *core) drawPixels(w io.Writer, pixels ...term.PixelGetter) {
-	for _, pixel := range pixels {
-		c.comm.GoTo(w, pixel.PositionHash()) // first we go to
-		fg, bg, attrs := pixel.Style()       // read pixel colors and attributes
-		if fg == c.cachedFG && bg == c.cachedBG && c.cachedAttrs == attrs {
-			goto cachedStyle // if the previous pixel had the same attributes and colors, we jump to displaying runes
-		}
+// DeleteLines implements term.LineEditor interface
+func (c *core) DeleteLines(row, count int) bool {
+	c.Lock()
+	defer c.Unlock()
 
-		if c.comm.Colors > 0 {
-			c.comm.PutAttrOff(w) // about to send colors
+	if count <= 0 || !c.prepareEdit(0, row) {
+		return false
+	}
+	if c.comm.CanEditLines() && c.canEditLines() {
+		c.forgetFrame() // the content has moved
+		return c.comm.PutDeleteLines(c.out, count) == nil && c.restoreCursor()
+	}
+	return c.redrawShifted(0, row, c.size.Columns, c.size.Rows-row, 0, -count)
+}
 
-			if fg == color.Reset || bg == color.Reset {
-				c.comm.PutResetFgBg(w)
-			}
+// InsertChars implements term.LineEditor interface
+func (c *core) InsertChars(where *term.Position, count int) bool {
+	c.Lock()
+	defer c.Unlock()
 
-			if c.hasTrueColor && c.canSetRGB { // we can use SetFgRGB
-				if color.IsRGB(fg) && color.IsRGB(bg) { // both are RGB
-					c.comm.WriteBothColors(w, fg, bg, false)
-					goto colorDone
-				}
+	if where == nil || count <= 0 || !c.prepareEdit(where.Column, where.Row) {
+		return false
+	}
+	if c.comm.CanEditChars() && c.margins().Right == 0 {
+		c.forgetFrame() // the content has moved
+		return c.comm.PutInsertChars(c.out, count) == nil && c.restoreCursor()
+	}
+	return c.redrawShifted(where.Column, where.Row, c.size.Columns-where.Column, 1, count, 0)
+}
 
-				// not both are RGB
-				if color.IsRGB(fg) {
-					c.comm.WriteColor(w, fg, true, false)
-					fg = color.Default //  resets cache
-				}
+// DeleteChars implements term.LineEditor interface
+func (c *core) DeleteChars(where *term.Position, count int) bool {
+	c.Lock()
+	defer c.Unlock()
 
-				if color.IsRGB(bg) {
-					c.comm.WriteColor(w, bg, false, false)
-					bg = color.Default // resets cache
+	if where == nil || count <= 0 || !c.prepareEdit(where.Column, where.Row) {
+		return false
+	}
+	if c.comm.CanEditChars() && c.margins().Right == 0 {
+		c.forgetFrame() // the content has moved
+		return c.comm.PutDeleteChars(c.out, count) == nil && c.restoreCursor()
+	}
+	return c.redrawShifted(where.Column, where.Row, c.size.Columns-where.Column, 1, -count, 0)
+}
+
+// redrawShifted draws the rectangle as the terminal would show it after moving its content by the offsets, when the edit capability is missing (or it would move the reserved cells too).
+// The content is the one of the active pixels, the positions without one being blank - locked inside caller function
+func (c *core) redrawShifted(left, top, columns, rows, dx, dy int) bool {
+	blank := style.Style{Fg: color.Default, Bg: color.Default}
+	pixels := make([]term.PixelGetter, 0, columns*rows)
+	for row := top; row < top+rows; row++ {
+		for column := left; column < left+columns; column++ {
+			hash := term.Hash(column, row)
+			fromColumn, fromRow := column-dx, row-dy
+			if fromColumn >= left && fromColumn < left+columns && fromRow >= top && fromRow < top+rows {
+				if pixel, ok := c.content[term.Hash(fromColumn, fromRow)]; ok {
+					pixels = append(pixels, &wrappedPixel{PixelGetter: pixel, hash: hash})
+					continue
 				}
 			}
+			pixels = append(pixels, &regionPixel{hash: hash, r: ' ', st: blank})
+		}
+	}
+	buf := bytes.NewBuffer(nil)
+	c.drawPixels(buf, pixels...)
+	if _, err := buf.WriteTo(c.out); err != nil {
+		return false
+	}
+	return c.restoreCursor()
+}
 
-			if color.Valid(fg) {
-				fg = c.style.FindColor(fg) // attempt to find the color from comm.Term colors
-			}
+// prepareEdit resets the attributes (inserted blanks get the default colors) and moves the cursor - locked inside caller function
+func (c *core) prepareEdit(column, row int) bool {
+	if c.plain || c.size == nil || column < 0 || row < 0 || column >= c.size.Columns || row >= c.size.Rows {
+		return false
+	}
+	c.comm.PutAttrOff(c.out)
+	c.cachedFG, c.cachedBG, c.cachedAttrs = color.Default, color.Default, style.None
+	c.goTo(c.out, &term.Position{Column: column, Row: row})
+	return true
+}
 
-			if color.Valid(bg) {
-				bg = c.style.FindColor(bg) // same as above
-			}
+// canEditLines returns false if moving the lines would move the reserved cells too - locked inside caller function
+func (c *core) canEditLines() bool {
+	m := c.margins()
+	return m.Left == 0 && m.Right == 0 && m.Bottom == 0
+}
 
-			if color.Valid(fg) && color.Valid(bg) && c.canSetBgFg {
-				c.comm.WriteBothColors(w, fg, bg, true)
-				goto colorDone
-			}
+// restoreCursor puts back the cursor where it was before editing - locked inside caller function
+func (c *core) restoreCursor() bool {
+	if c.cursorPosition != nil {
+		c.goTo(c.out, c.cursorPosition)
+	} else {
+		c.comm.GoTo(c.out, c.maximumPosition.Hash())
+	}
+	return true
+}
 
-			if color.Valid(fg) && c.canSetFg {
-				c.comm.WriteColor(w, fg, true, true)
-			}
+// HasCapability implements term.CapabilityWriter interface
+func (c *core) HasCapability(name string) bool {
+	c.Lock()
+	defer c.Unlock()
 
-			if color.Valid(bg) && c.canSetBg {
-				c.comm.WriteColor(w, bg, false, true)
-			}
-		}
+	return c.comm.Has(name)
+}
 
-	colorDone:
+// PutCapability implements term.CapabilityWriter interface
+func (c *core) PutCapability(name string, params ...int) error {
+	c.Lock()
+	defer c.Unlock()
 
-		if attrs&style.Bold != 0 {
-			c.comm.PutBold(w)
-		}
-		if attrs&style.Underline != 0 {
-			c.comm.PutUnderline(w)
-		}
-		if attrs&style.Reverse != 0 {
-			c.comm.PutReverse(w)
-		}
-		if attrs&style.Blink != 0 {
-			c.comm.PutBlink(w)
-		}
-		if attrs&style.Dim != 0 {
-			c.comm.PutDim(w)
+	if c.out == nil || c.plain {
+		return ErrNoScreen
+	}
+	return c.comm.Put(c.out, name, params...)
+}
+
+// Style
+func (c *core) Style() term.Style {
+	return c.style
+}
+
+// resize remembers the width and height of the terminal. if a shutdown flag is set, the pixels listeners are forgot
+func (c *core) resize(w, h int, shutdown bool) {
+	if shutdown && c.pixCancel != nil {
+		c.pixCancel() // cancel context if we're shutting down and cancellation was declared
+	}
+	if c.size != nil && c.screenColumns == w && c.screenRows == h {
+		return
+	}
+	c.screenColumns, c.screenRows = w, h
+	c.forgetFrame()
+	mp := term.NewPosition(w, h)
+	c.maximumPosition = mp
+	c.comm.ResizeGoToCache(&term.Size{Columns: w, Rows: h}) // the reserved cells included
+	m := c.margins()
+	c.area = area{
+		left:    m.Left,
+		top:     m.Top,
+		columns: term.Max(w-m.Left-m.Right, 0),
+		rows:    term.Max(h-m.Top-m.Bottom, 0),
+		toEOL:   m.Right == 0,
+		toEOS:   m.Right == 0 && m.Bottom == 0,
+	}
+	c.size = &term.Size{Columns: c.area.columns, Rows: c.area.rows}
+	if c.screen != nil {
+		c.screen.resize(c.size)
+	}
+}
+
+// drawPixels draws in the area left to the pages - locked inside caller function
+func (c *core) drawPixels(w io.Writer, pixels ...term.PixelGetter) {
+	c.drawBounded(w, c.uncovered(c.bound(pixels)))
+}
+
+// drawBounded draws the pixels which went through the bounds mode - locked inside caller function
+func (c *core) drawBounded(w io.Writer, pixels []term.PixelGetter) {
+	if c.plain {
+		c.screen.set(pixels...) // written when flushed
+		return
+	}
+	if len(pixels) > 0 {
+		c.meter.frame(len(pixels))
+	}
+	pixels = c.searched(pixels)
+	c.recordFrame(pixels)
+	c.drawIn(w, c.area, pixels...)
+}
+
+// drawIn draws the pixels, whose positions are relative to the area - locked inside caller function
+func (c *core) drawIn(w io.Writer, a area, pixels ...term.PixelGetter) {
+	next := term.MinusOneMinusOne // where the cursor is after the previous rune, so contiguous pixels are written after a single goto
+	for idx := 0; idx < len(pixels); idx++ {
+		pixel := pixels[idx]
+		column, row := term.UnHash(pixel.PositionHash())
+		if !a.contains(column, row) {
+			continue // e.g. a pixel having a negative position
 		}
-		if attrs&style.Italic != 0 {
-			c.comm.PutItalic(w)
+		if hash := term.Hash(column, row); hash != next {
+			c.comm.GoToXY(w, column+a.left, row+a.top) // first we go to
 		}
-		if attrs&style.StrikeThrough != 0 {
-			c.comm.PutStrikeThrough(w)
+		next = term.MinusOneMinusOne
+		fg, bg, attrs := pixel.Style() // read pixel colors and attributes
+		c.putStyle(w, fg, bg, attrs)
+
+		// a run of blank pixels sharing the same style, up to the end of the line (or screen), gets erased instead of being written
+		if count, toEOS := c.blankRun(pixels[idx:], a); count > 0 {
+			if toEOS {
+				c.comm.PutClearToEOS(w)
+			} else {
+				c.comm.PutClearToEOL(w)
+			}
+			idx += count - 1
+			continue
 		}
 
-		// cache for speeding up display same pixels (like a bunch of black background with white text)
-		c.cachedAttrs = attrs
-		c.cachedBG = bg
-		c.cachedFG = fg
-
-	cachedStyle:
-
 		runes := make([]byte, 0, 6)
 		runes = c.encoder.encodeRune(pixel.Rune(), runes)
 		if pixel.HasUnicode() {
@@ -544,9 +1005,175 @@ func (c *core) drawPixels(w io.Writer, pixels ...term.PixelGetter) {
 		// }
 
 		if _, err := w.Write(runes); err != nil {
-			if Debug {
-				log.Printf("error writing to io : " + err.Error())
+			if Debug.Enabled() {
+				Debug.Printf("error writing to io : " + err.Error())
+			}
+		}
+		if advancesOne(pixel, runes) {
+			next = term.Hash(column+1, row)
+		}
+	}
+}
+
+// advancesOne returns true if the terminal moves the cursor exactly one column after writing the rune of the pixel : the rune was written as it is (no fallback) and it's not a wide one
+func advancesOne(pixel term.PixelGetter, written []byte) bool {
+	if pixel.HasUnicode() {
+		return false
+	}
+	r, size := utf8.DecodeRune(written)
+	if r != pixel.Rune() || size != len(written) {
+		return false
+	}
+	if r >= ' ' && r < 0x7f {
+		return true // printable ASCII, the most common
+	}
+	return r >= utf8.RuneSelf && runewidth.RuneWidth(r) == 1
+}
+
+// putStyle writes colors and attributes, unless they are the same as the previous pixel ones - locked inside caller function
+func (c *core) putStyle(w io.Writer, fg, bg color.Color, attrs style.Mask) {
+	if c.highContrast {
+		fg, bg, attrs = contrasted(fg, bg, attrs)
+	}
+	if fg == c.cachedFG && bg == c.cachedBG && c.cachedAttrs == attrs {
+		return // if the previous pixel had the same attributes and colors, we're done
+	}
+
+	requestedFG, requestedBG := fg, bg
+	c.comm.PutAttrOff(w) // about to send colors and attributes, the ones of the previous pixel being turned off
+
+	if c.colors > 0 {
+
+		if fg == color.Reset || bg == color.Reset {
+			c.comm.PutResetFgBg(w)
+		}
+
+		if c.hasTrueColor && c.canSetRGB { // we can use SetFgRGB
+			if color.IsRGB(fg) && color.IsRGB(bg) { // both are RGB
+				c.comm.WriteBothColors(w, fg, bg, false)
+				goto colorDone
+			}
+
+			// not both are RGB
+			if color.IsRGB(fg) {
+				c.comm.WriteColor(w, fg, true, false)
+				fg = color.Default //  resets cache
+			}
+
+			if color.IsRGB(bg) {
+				c.comm.WriteColor(w, bg, false, false)
+				bg = color.Default // resets cache
+			}
+		}
+
+		if color.Valid(fg) {
+			fg = c.findColor(fg) // attempt to find the color from comm.Term colors
+		}
+
+		if color.Valid(bg) {
+			bg = c.findColor(bg) // same as above
+		}
+
+		if color.Valid(fg) && color.Valid(bg) && c.canSetBgFg {
+			c.comm.WriteBothColors(w, fg, bg, true)
+			goto colorDone
+		}
+
+		if color.Valid(fg) && c.canSetFg {
+			c.comm.WriteColor(w, fg, true, true)
+		}
+
+		if color.Valid(bg) && c.canSetBg {
+			c.comm.WriteColor(w, bg, false, true)
+		}
+	} else if color.Valid(fg) || color.Valid(bg) {
+		c.degrade("colors unsupported on this terminal, dropped")
+	}
+
+colorDone:
+	rendered := c.renderedAttrs(attrs) // the cache holds the requested ones
+	c.checkAttrs(rendered)
+
+	if rendered&style.Bold != 0 {
+		c.comm.PutBold(w)
+	}
+	if rendered&style.Underline != 0 {
+		c.comm.PutUnderline(w)
+	}
+	if rendered&style.Reverse != 0 {
+		c.comm.PutReverse(w)
+	}
+	if rendered&style.Blink != 0 {
+		c.comm.PutBlink(w)
+	}
+	if rendered&style.Dim != 0 {
+		c.comm.PutDim(w)
+	}
+	if rendered&style.Italic != 0 {
+		c.comm.PutItalic(w)
+	}
+	if rendered&style.StrikeThrough != 0 {
+		c.comm.PutStrikeThrough(w)
+	}
+
+	// cache for speeding up display same pixels (like a bunch of black background with white text)
+	c.cachedAttrs = attrs
+	c.cachedBG = requestedBG // the requested ones, since the palette might have replaced them
+	c.cachedFG = requestedFG
+}
+
+// isBlank returns true if the pixel is a space which can be erased using the given style
+func isBlank(pixel term.PixelGetter, fg, bg color.Color, attrs style.Mask) bool {
+	if pixel.Rune() != ' ' || pixel.HasUnicode() {
+		return false
+	}
+	pfg, pbg, pattrs := pixel.Style()
+	return pfg == fg && pbg == bg && pattrs == attrs
+}
+
+// blankRun counts the blank pixels, starting with the first one, which can be erased with el (true if ed can be used instead).
+// Pixels have to be consecutive (same row, next column, wrapping to the next row) and the run has to reach the end of the line, which has to be the end of the area as well.
+// Without bce, the terminal erases using the default background, so only default background can be erased this way.
+func (c *core) blankRun(pixels []term.PixelGetter, a area) (int, bool) {
+	if !c.canClearToEOL || !a.toEOL || a.columns <= 0 || len(pixels) < minBlankRun {
+		return 0, false
+	}
+	fg, bg, attrs := pixels[0].Style()
+	if attrs&(style.Reverse|style.Underline|style.StrikeThrough) != 0 {
+		return 0, false
+	}
+	if !c.comm.BackColorErase && bg != color.Default && bg != color.Reset {
+		return 0, false
+	}
+	if !isBlank(pixels[0], fg, bg, attrs) {
+		return 0, false
+	}
+	lastColumn, lastRow := a.columns-1, a.rows-1
+	column, row := term.UnHash(pixels[0].PositionHash())
+	lineRun := 0
+	count := 0
+	for _, pixel := range pixels {
+		if pixel.PositionHash() != term.Hash(column, row) || !isBlank(pixel, fg, bg, attrs) {
+			break
+		}
+		count++
+		if column < lastColumn {
+			column++
+			continue
+		}
+		if lineRun == 0 {
+			lineRun = count // we've reached the end of the first line
+		}
+		if row == lastRow {
+			if c.canClearToEOS && a.toEOS && count > lineRun {
+				return count, true // we've reached the end of the screen
 			}
+			break
 		}
+		column, row = 0, row+1
+	}
+	if lineRun < minBlankRun {
+		return 0, false
 	}
+	return lineRun, false
 }
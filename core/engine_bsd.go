@@ -1,3 +1,4 @@
+//go:build freebsd || netbsd || openbsd || dragonfly
 // +build freebsd netbsd openbsd dragonfly
 
 package core
@@ -6,57 +7,68 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
-	"unsafe"
+
+	"golang.org/x/sys/unix"
 )
 
-type termiosPrivate syscall.Termios
+type termiosPrivate struct {
+	tio *unix.Termios
+}
 
 func (c *core) internalStart() error {
 	var (
-		e       error
-		newtios termiosPrivate
-		fd      uintptr
-		tios    uintptr
-		ioc     uintptr
+		err error
+		raw *unix.Termios
+		tio *unix.Termios
 	)
 
-	c.termIOSPrv = &termiosPrivate{}
-
-	if c.in, e = os.OpenFile("/dev/tty", os.O_RDONLY, 0); e != nil {
+	if c.in, err = c.openInput(); err != nil {
 		goto failed
 	}
-	if c.out, e = os.OpenFile("/dev/tty", os.O_WRONLY, 0); e != nil {
+	if c.tty, err = c.openOutput(); err != nil {
 		goto failed
 	}
+	c.out = c.mirrored(c.metered(c.paced(c.guarded(c.tty))))
 
-	tios = uintptr(unsafe.Pointer(c.termIOSPrv))
-	ioc = uintptr(syscall.TIOCGETA)
-	fd = uintptr(c.out.Fd())
-	if _, _, e1 := syscall.Syscall6(syscall.SYS_IOCTL, fd, ioc, tios, 0, 0, 0); e1 != 0 {
-		e = e1
+	tio, err = unix.IoctlGetTermios(int(c.tty.Fd()), unix.TIOCGETA)
+	if err != nil {
 		goto failed
 	}
 
-	newtios = *c.termIOSPrv
-	newtios.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
-	newtios.Oflag &^= syscall.OPOST
-	newtios.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
-	newtios.Cflag &^= syscall.CSIZE | syscall.PARENB
-	newtios.Cflag |= syscall.CS8
+	c.termIOSPrv = &termiosPrivate{tio: tio}
+
+	// make a local copy, to make it raw
+	raw = &unix.Termios{
+		Cflag:  tio.Cflag,
+		Oflag:  tio.Oflag,
+		Iflag:  tio.Iflag,
+		Lflag:  tio.Lflag,
+		Cc:     tio.Cc,
+		Ispeed: tio.Ispeed,
+		Ospeed: tio.Ospeed,
+	}
+	raw.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	raw.Oflag &^= unix.OPOST
+	raw.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	if c.interrupts == InterruptAsSignals {
+		raw.Lflag |= unix.ISIG    // Ctrl+C and Ctrl+\ are turned into SIGINT and SIGQUIT by the terminal driver
+		raw.Cc[unix.VSUSP] = 0xff // _POSIX_VDISABLE : Ctrl+Z stays a key, since suspending would leave the terminal in raw mode
+	}
+	raw.Cflag &^= unix.CSIZE | unix.PARENB
+	raw.Cflag |= unix.CS8
 
-	tios = uintptr(unsafe.Pointer(&newtios))
+	// blocking reads, see engine_linux.go
+	raw.Cc[unix.VMIN] = 1
+	raw.Cc[unix.VTIME] = 0
 
-	ioc = uintptr(syscall.TIOCSETA)
-	if _, _, e1 := syscall.Syscall6(syscall.SYS_IOCTL, fd, ioc, tios, 0, 0, 0); e1 != 0 {
-		e = e1
+	err = unix.IoctlSetTermios(int(c.tty.Fd()), unix.TIOCSETA, raw)
+	if err != nil {
 		goto failed
 	}
 
 	signal.Notify(c.winSizeCh, syscall.SIGWINCH)
 
-	if w, h, e := c.readWinSize(); e == nil && w != 0 && h != 0 {
-		c.resize(w, h, false)
-	}
+	c.updateSize()
 
 	return nil
 
@@ -64,43 +76,49 @@ failed:
 	if c.in != nil {
 		c.in.Close()
 	}
-	if c.out != nil {
-		c.out.Close()
+	if c.tty != nil {
+		c.closeOutput()
 	}
-	return e
+	return err
 }
 
 func (c *core) internalShutdown() error {
 	signal.Stop(c.winSizeCh)
-	if c.out != nil {
-		fd := uintptr(c.out.Fd())
-		ioc := uintptr(syscall.TIOCSETAF)
-		tios := uintptr(unsafe.Pointer(c.termIOSPrv))
-		syscall.Syscall6(syscall.SYS_IOCTL, fd, ioc, tios, 0, 0, 0)
-		c.out.Close()
+	if c.tty != nil && c.termIOSPrv != nil {
+		if err := unix.IoctlSetTermios(int(c.tty.Fd()), unix.TIOCSETAF, c.termIOSPrv.tio); err != nil {
+			return err
+		}
+		if err := c.closeOutput(); err != nil {
+			return err
+		}
 	}
 	if c.in != nil {
-		c.in.Close()
+		if err := c.in.Close(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 func (c *core) readWinSize() (int, int, error) {
-	fd := uintptr(c.out.Fd())
-	dim := [4]uint16{}
-	dimp := uintptr(unsafe.Pointer(&dim))
-	ioc := uintptr(syscall.TIOCGWINSZ)
-	if _, _, err := syscall.Syscall6(syscall.SYS_IOCTL, fd, ioc, dimp, 0, 0, 0); err != 0 {
+	wsz, err := unix.IoctlGetWinsize(int(c.tty.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
 		return -1, -1, err
 	}
-	return int(dim[1]), int(dim[0]), nil
+	return int(wsz.Col), int(wsz.Row), nil
 }
 
 func (c *core) Beep() error {
 	if _, err := c.out.Write([]byte{byte(7)}); err != nil {
-		if Debug {
-			log.Printf("error writing to io : " + err.Error())
+		if Debug.Enabled() {
+			Debug.Printf("error writing to io : " + err.Error())
 		}
 	}
 	return nil
 }
+
+// isTTY returns true if the file is a terminal
+func isTTY(f *os.File) bool {
+	_, err := unix.IoctlGetTermios(int(f.Fd()), unix.TIOCGETA)
+	return err == nil
+}
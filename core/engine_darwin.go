@@ -1,3 +1,4 @@
+//go:build darwin
 // +build darwin
 
 package core
@@ -29,16 +30,17 @@ func (c *core) internalStart() error {
 	)
 	c.termIOSPrv = &termiosPrivate{}
 
-	if c.in, e = os.OpenFile("/dev/tty", os.O_RDONLY, 0); e != nil {
+	if c.in, e = c.openInput(); e != nil {
 		goto failed
 	}
-	if c.out, e = os.OpenFile("/dev/tty", os.O_WRONLY, 0); e != nil {
+	if c.tty, e = c.openOutput(); e != nil {
 		goto failed
 	}
+	c.out = c.mirrored(c.metered(c.paced(c.guarded(c.tty))))
 
 	tios = uintptr(unsafe.Pointer(c.termIOSPrv))
 	ioc = uintptr(syscall.TIOCGETA)
-	fd = uintptr(c.out.Fd())
+	fd = uintptr(c.tty.Fd())
 	if _, _, e1 := syscall.Syscall6(syscall.SYS_IOCTL, fd, ioc, tios, 0, 0, 0); e1 != 0 {
 		e = e1
 		goto failed
@@ -48,6 +50,10 @@ func (c *core) internalStart() error {
 	newtios.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
 	newtios.Oflag &^= syscall.OPOST
 	newtios.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	if c.interrupts == InterruptAsSignals {
+		newtios.Lflag |= syscall.ISIG    // Ctrl+C and Ctrl+\ are turned into SIGINT and SIGQUIT by the terminal driver
+		newtios.Cc[syscall.VSUSP] = 0xff // _POSIX_VDISABLE : Ctrl+Z stays a key, since suspending would leave the terminal in raw mode
+	}
 	newtios.Cflag &^= syscall.CSIZE | syscall.PARENB
 	newtios.Cflag |= syscall.CS8
 
@@ -61,9 +67,7 @@ func (c *core) internalStart() error {
 
 	signal.Notify(c.winSizeCh, syscall.SIGWINCH)
 
-	if w, h, e := c.readWinSize(); e == nil && w != 0 && h != 0 {
-		c.resize(w, h, false)
-	}
+	c.updateSize()
 
 	return nil
 
@@ -71,20 +75,20 @@ failed:
 	if c.in != nil {
 		c.in.Close()
 	}
-	if c.out != nil {
-		c.out.Close()
+	if c.tty != nil {
+		c.closeOutput()
 	}
 	return e
 }
 
 func (c *core) internalShutdown() error {
 	signal.Stop(c.winSizeCh)
-	if c.out != nil {
-		fd := uintptr(c.out.Fd())
+	if c.tty != nil {
+		fd := uintptr(c.tty.Fd())
 		ioc := uintptr(syscall.TIOCSETAF)
 		tios := uintptr(unsafe.Pointer(c.termIOSPrv))
 		syscall.Syscall6(syscall.SYS_IOCTL, fd, ioc, tios, 0, 0, 0)
-		c.out.Close()
+		c.closeOutput()
 	}
 
 	// See above -- we background this call which might help, but really the tty is probably open.
@@ -97,7 +101,7 @@ func (c *core) internalShutdown() error {
 }
 
 func (c *core) readWinSize() (int, int, error) {
-	fd := uintptr(c.out.Fd())
+	fd := uintptr(c.tty.Fd())
 	dim := [4]uint16{}
 	dimp := uintptr(unsafe.Pointer(&dim))
 	ioc := uintptr(syscall.TIOCGWINSZ)
@@ -109,9 +113,16 @@ func (c *core) readWinSize() (int, int, error) {
 
 func (c *core) Beep() error {
 	if _, err := c.out.Write([]byte{byte(7)}); err != nil {
-		if Debug {
-			log.Printf("error writing to io : " + err.Error())
+		if Debug.Enabled() {
+			Debug.Printf("error writing to io : " + err.Error())
 		}
 	}
 	return nil
 }
+
+// isTTY returns true if the file is a terminal
+func isTTY(f *os.File) bool {
+	var tios syscall.Termios
+	_, _, e1 := syscall.Syscall6(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGETA), uintptr(unsafe.Pointer(&tios)), 0, 0, 0)
+	return e1 == 0
+}
@@ -1,12 +1,11 @@
+//go:build linux
 // +build linux
 
 package core
 
 import (
-	"log"
 	"os"
 	"os/signal"
-	"strconv"
 	"syscall"
 
 	"golang.org/x/sys/unix"
@@ -17,24 +16,22 @@ type termiosPrivate struct {
 }
 
 func (c *core) internalStart() error {
-	const (
-		devTTY = "/dev/tty"
-	)
 	var (
 		err error
 		raw *unix.Termios
 		tio *unix.Termios
 	)
 
-	if c.in, err = os.OpenFile(devTTY, os.O_RDONLY, 0); err != nil {
+	if c.in, err = c.openInput(); err != nil {
 		goto failed
 	}
 
-	if c.out, err = os.OpenFile(devTTY, os.O_WRONLY, 0); err != nil {
+	if c.tty, err = c.openOutput(); err != nil {
 		goto failed
 	}
+	c.out = c.mirrored(c.metered(c.paced(c.guarded(c.tty))))
 
-	tio, err = unix.IoctlGetTermios(int(c.out.Fd()), unix.TCGETS)
+	tio, err = unix.IoctlGetTermios(int(c.tty.Fd()), unix.TCGETS)
 	if err != nil {
 		goto failed
 	}
@@ -52,6 +49,10 @@ func (c *core) internalStart() error {
 	raw.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
 	raw.Oflag &^= unix.OPOST
 	raw.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	if c.interrupts == InterruptAsSignals {
+		raw.Lflag |= unix.ISIG // Ctrl+C and Ctrl+\ are turned into SIGINT and SIGQUIT by the terminal driver
+		raw.Cc[unix.VSUSP] = 0 // _POSIX_VDISABLE : Ctrl+Z stays a key, since suspending would leave the terminal in raw mode
+	}
 	raw.Cflag &^= unix.CSIZE | unix.PARENB
 	raw.Cflag |= unix.CS8
 
@@ -60,7 +61,7 @@ func (c *core) internalStart() error {
 	raw.Cc[unix.VMIN] = 1
 	raw.Cc[unix.VTIME] = 0
 
-	err = unix.IoctlSetTermios(int(c.out.Fd()), unix.TCSETS, raw)
+	err = unix.IoctlSetTermios(int(c.tty.Fd()), unix.TCSETS, raw)
 	if err != nil {
 		goto failed
 	}
@@ -69,9 +70,7 @@ func (c *core) internalStart() error {
 	// If a program does full-screen display, it should handle SIGWINCH. When the signal arrives, it should fetch the new screen size and reformat its display accordingly.
 	signal.Notify(c.winSizeCh, syscall.SIGWINCH)
 
-	if w, h, e := c.readWinSize(); e == nil && w != 0 && h != 0 {
-		c.resize(w, h, false)
-	}
+	c.updateSize()
 
 	return nil
 
@@ -79,19 +78,19 @@ failed:
 	if c.in != nil {
 		c.in.Close()
 	}
-	if c.out != nil {
-		c.out.Close()
+	if c.tty != nil {
+		c.closeOutput()
 	}
 	return err
 }
 
 func (c *core) internalShutdown() error {
 	signal.Stop(c.winSizeCh)
-	if c.out != nil && c.termIOSPrv != nil {
-		if err := unix.IoctlSetTermios(int(c.out.Fd()), unix.TCSETSF, c.termIOSPrv.tio); err != nil {
+	if c.tty != nil && c.termIOSPrv != nil {
+		if err := unix.IoctlSetTermios(int(c.tty.Fd()), unix.TCSETSF, c.termIOSPrv.tio); err != nil {
 			return err
 		}
-		if err := c.out.Close(); err != nil {
+		if err := c.closeOutput(); err != nil {
 			return err
 		}
 	}
@@ -105,38 +104,25 @@ func (c *core) internalShutdown() error {
 }
 
 func (c *core) readWinSize() (int, int, error) {
-	wsz, err := unix.IoctlGetWinsize(int(c.out.Fd()), unix.TIOCGWINSZ)
+	wsz, err := unix.IoctlGetWinsize(int(c.tty.Fd()), unix.TIOCGWINSZ)
 	if err != nil {
 		return -1, -1, err
 	}
 
-	rows, cols := int(wsz.Row), int(wsz.Col)
-	if cols == 0 {
-		if colsEnv := os.Getenv("COLUMNS"); len(colsEnv) > 0 {
-			if cols, err = strconv.Atoi(colsEnv); err != nil {
-				return -1, -1, err
-			}
-		} else {
-			cols = c.comm.Columns
-		}
-	}
-	if rows == 0 {
-		if rowsEnv := os.Getenv("LINES"); len(rowsEnv) > 0 {
-			if rows, err = strconv.Atoi(rowsEnv); err != nil {
-				return -1, -1, err
-			}
-		} else {
-			rows = c.comm.Lines
-		}
-	}
-	return cols, rows, nil
+	return int(wsz.Col), int(wsz.Row), nil
 }
 
 func (c *core) Beep() error {
 	if _, err := c.out.Write([]byte{byte(7)}); err != nil {
-		if Debug {
-			log.Printf("error writing to io : " + err.Error())
+		if Debug.Enabled() {
+			Debug.Printf("error writing to io : " + err.Error())
 		}
 	}
 	return nil
 }
+
+// isTTY returns true if the file is a terminal
+func isTTY(f *os.File) bool {
+	_, err := unix.IoctlGetTermios(int(f.Fd()), unix.TCGETS)
+	return err == nil
+}
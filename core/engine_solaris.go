@@ -1,9 +1,9 @@
+//go:build solaris || illumos
 // +build solaris illumos
 
 package core
 
 import (
-	"log"
 	"os"
 	"os/signal"
 	"syscall"
@@ -22,14 +22,15 @@ func (c *core) internalStart() error {
 		tio *unix.Termios
 	)
 
-	if c.in, e = os.OpenFile("/dev/tty", os.O_RDONLY, 0); e != nil {
+	if c.in, e = c.openInput(); e != nil {
 		goto failed
 	}
-	if c.out, e = os.OpenFile("/dev/tty", os.O_WRONLY, 0); e != nil {
+	if c.tty, e = c.openOutput(); e != nil {
 		goto failed
 	}
+	c.out = c.mirrored(c.metered(c.paced(c.guarded(c.tty))))
 
-	tio, e = unix.IoctlGetTermios(int(c.out.Fd()), unix.TCGETS)
+	tio, e = unix.IoctlGetTermios(int(c.tty.Fd()), unix.TCGETS)
 	if e != nil {
 		goto failed
 	}
@@ -47,6 +48,10 @@ func (c *core) internalStart() error {
 	raw.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
 	raw.Oflag &^= unix.OPOST
 	raw.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	if c.interrupts == InterruptAsSignals {
+		raw.Lflag |= unix.ISIG // Ctrl+C and Ctrl+\ are turned into SIGINT and SIGQUIT by the terminal driver
+		raw.Cc[unix.VSUSP] = 0 // _POSIX_VDISABLE : Ctrl+Z stays a key, since suspending would leave the terminal in raw mode
+	}
 	raw.Cflag &^= unix.CSIZE | unix.PARENB
 	raw.Cflag |= unix.CS8
 
@@ -57,16 +62,14 @@ func (c *core) internalStart() error {
 	raw.Cc[unix.VMIN] = 1
 	raw.Cc[unix.VTIME] = 0
 
-	e = unix.IoctlSetTermios(int(c.out.Fd()), unix.TCSETS, raw)
+	e = unix.IoctlSetTermios(int(c.tty.Fd()), unix.TCSETS, raw)
 	if e != nil {
 		goto failed
 	}
 
 	signal.Notify(c.winSizeCh, syscall.SIGWINCH)
 
-	if w, h, e := c.readWinSize(); e == nil && w != 0 && h != 0 {
-		c.resize(w, h, false)
-	}
+	c.updateSize()
 
 	return nil
 
@@ -74,17 +77,17 @@ failed:
 	if c.in != nil {
 		c.in.Close()
 	}
-	if c.out != nil {
-		c.out.Close()
+	if c.tty != nil {
+		c.closeOutput()
 	}
 	return e
 }
 
 func (c *core) internalShutdown() error {
 	signal.Stop(c.winSizeCh)
-	if c.out != nil && c.termIOSPrv != nil {
-		unix.IoctlSetTermios(int(c.out.Fd()), unix.TCSETSF, c.termIOSPrv.tio)
-		c.out.Close()
+	if c.tty != nil && c.termIOSPrv != nil {
+		unix.IoctlSetTermios(int(c.tty.Fd()), unix.TCSETSF, c.termIOSPrv.tio)
+		c.closeOutput()
 	}
 	if c.in != nil {
 		c.in.Close()
@@ -93,7 +96,7 @@ func (c *core) internalShutdown() error {
 }
 
 func (c *core) readWinSize() (int, int, error) {
-	wsz, err := unix.IoctlGetWinsize(int(c.out.Fd()), unix.TIOCGWINSZ)
+	wsz, err := unix.IoctlGetWinsize(int(c.tty.Fd()), unix.TIOCGWINSZ)
 	if err != nil {
 		return -1, -1, err
 	}
@@ -102,9 +105,15 @@ func (c *core) readWinSize() (int, int, error) {
 
 func (c *core) Beep() error {
 	if _, err := c.out.Write([]byte{byte(7)}); err != nil {
-		if Debug {
-			log.Printf("error writing to io : " + err.Error())
+		if Debug.Enabled() {
+			Debug.Printf("error writing to io : " + err.Error())
 		}
 	}
 	return nil
 }
+
+// isTTY returns true if the file is a terminal
+func isTTY(f *os.File) bool {
+	_, err := unix.IoctlGetTermios(int(f.Fd()), unix.TCGETS)
+	return err == nil
+}
@@ -1,7 +1,12 @@
-// +build nacl plan9 windows
+//go:build nacl || plan9 || windows || js
+// +build nacl plan9 windows js
 
 package core
 
+import (
+	"os"
+)
+
 // This stub file is for systems that have no termios.
 
 type termiosPrivate struct{}
@@ -19,5 +24,16 @@ func (c *core) readWinSize() (int, int, error) {
 }
 
 func (c *core) Beep() error {
-	return ErrNoScreen
+	if c.transport == nil {
+		return ErrNoScreen
+	}
+	if _, err := c.out.Write([]byte{byte(7)}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// isTTY returns false, there are no terminals
+func isTTY(f *os.File) bool {
+	return false
 }
@@ -0,0 +1,152 @@
+package core
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+// movingPixel can change its position, so it can be set to -1,-1 (the former cancellation convention)
+type movingPixel struct {
+	sync.Mutex
+	hash   int
+	r      rune
+	drawCh chan term.PixelGetter
+}
+
+func (p *movingPixel) DrawCh() chan term.PixelGetter { return p.drawCh }
+func (p *movingPixel) Style() (color.Color, color.Color, style.Mask) {
+	return color.Default, color.Default, style.None
+}
+func (p *movingPixel) HasUnicode() bool                                                { return false }
+func (p *movingPixel) Unicode() *term.Unicode                                          { return nil }
+func (p *movingPixel) Width() int                                                      { return 1 }
+func (p *movingPixel) Set(rune, color.Color, color.Color)                              {}
+func (p *movingPixel) SetFgBg(color.Color, color.Color)                                {}
+func (p *movingPixel) SetForeground(color.Color)                                       {}
+func (p *movingPixel) SetBackground(color.Color)                                       {}
+func (p *movingPixel) SetAttrs(style.Mask)                                             {}
+func (p *movingPixel) SetUnicode(term.Unicode)                                         {}
+func (p *movingPixel) SetRune(rune)                                                    {}
+func (p *movingPixel) SetAll(color.Color, color.Color, style.Mask, rune, term.Unicode) {}
+func (p *movingPixel) Equals(other term.PixelGetter) bool                              { return term.SameContent(p, other) }
+func (p *movingPixel) Hash() uint64                                                    { return term.ContentHash(p) }
+
+func (p *movingPixel) Rune() rune {
+	p.Lock()
+	defer p.Unlock()
+	return p.r
+}
+
+func (p *movingPixel) PositionHash() int {
+	p.Lock()
+	defer p.Unlock()
+	return p.hash
+}
+
+func (p *movingPixel) set(hash int, r rune) {
+	p.Lock()
+	p.hash, p.r = hash, r
+	p.Unlock()
+	p.drawCh <- p
+}
+
+func TestActivePixelsNegativePosition(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+
+	pixel := &movingPixel{hash: term.Hash(0, 0), r: 'a', drawCh: make(chan term.PixelGetter, 1)}
+	c.ActivePixels([]term.PixelGetter{pixel})
+	written()
+
+	// a pixel moved outside the screen is not drawn, and it doesn't stop the listening
+	pixel.set(term.MinusOneMinusOne, 'x')
+	pixel.set(term.Hash(3, 0), 'y')
+	out := waitFor(t, c, written, "y")
+	if !strings.Contains(out, "\x1b[1;4Hy") {
+		t.Errorf("error : the pixel should still be drawn after being moved to -1,-1, got %q", out)
+	}
+	if strings.Contains(out, "x") {
+		t.Errorf("error : pixels outside the screen should not be drawn, got %q", out)
+	}
+
+	// replaced pixels are no longer drawn
+	c.ActivePixels(nil)
+	written()
+	select {
+	case pixel.drawCh <- pixel:
+	default:
+	}
+	time.Sleep(10 * time.Millisecond)
+	c.Lock()
+	out = written()
+	c.Unlock()
+	if out != "" {
+		t.Errorf("error : replaced pixels should not be drawn, got %q", out)
+	}
+}
+
+// waitFor reads the output until it contains the text
+func waitFor(t *testing.T, c *core, written func() string, text string) string {
+	deadline := time.Now().Add(time.Second)
+	out := ""
+	for !strings.Contains(out, text) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+		c.Lock()
+		out += written()
+		c.Unlock()
+	}
+	return out
+}
+
+func TestActivePixelsMove(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+
+	base := &movingPixel{hash: term.Hash(3, 0), r: 'b', drawCh: make(chan term.PixelGetter, 1)}
+	sprite := &movingPixel{hash: term.Hash(0, 0), r: 's', drawCh: make(chan term.PixelGetter, 1)}
+	c.ActivePixels([]term.PixelGetter{base, sprite})
+	written()
+
+	// moving over another pixel erases the previous position
+	sprite.set(term.Hash(3, 0), 's')
+	if out := waitFor(t, c, written, "s"); !strings.Contains(out, "\x1b[1;1H") || !strings.Contains(out, "\x1b[1;4Hs") {
+		t.Errorf("error : the sprite should be erased and drawn at the new position, got %q", out)
+	}
+	if pixel, ok := c.GetContent(0, 0); ok {
+		t.Errorf("error : the previous position should be forgotten, got %q", pixel.Rune())
+	}
+	if pixel, _ := c.GetContent(3, 0); pixel != base {
+		t.Errorf("error : the pixel registered at the new position should stay")
+	}
+
+	// moving away from it draws the pixel underneath
+	sprite.set(term.Hash(5, 0), 's')
+	if out := waitFor(t, c, written, "s"); !strings.Contains(out, "\x1b[1;4Hb") || !strings.Contains(out, "\x1b[1;6Hs") {
+		t.Errorf("error : the pixel underneath should be drawn again, got %q", out)
+	}
+	if pixel, _ := c.GetContent(5, 0); pixel != sprite {
+		t.Errorf("error : the sprite should be registered at the free position")
+	}
+}
+
+func TestDrawContiguousPixels(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+
+	c.drawPixels(c.out,
+		&regionPixel{hash: term.Hash(2, 1), r: 'a'},
+		&regionPixel{hash: term.Hash(3, 1), r: 'b'},
+		&regionPixel{hash: term.Hash(4, 1), r: '世'}, // wide, the cursor moves two columns
+		&regionPixel{hash: term.Hash(5, 1), r: 'c'},
+		&regionPixel{hash: term.Hash(7, 1), r: 'd'},
+	)
+	if out := written(); !strings.HasSuffix(out, "\x1b[2;3Hab世\x1b[2;6Hc\x1b[2;8Hd") {
+		t.Errorf("error : contiguous pixels should be written after a single goto, got %q", out)
+	}
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+// rowPixels returns the pixels of the rows, the runes having the style
+func rowPixels(st style.Style, rows ...string) []term.PixelGetter {
+	var result []term.PixelGetter
+	for row, runes := range rows {
+		for column, r := range []rune(runes) {
+			result = append(result, &regionPixel{hash: term.Hash(column, row), r: r, st: st})
+		}
+	}
+	return result
+}
+
+func TestBlankRun(t *testing.T) {
+	plain := style.Style{Fg: color.Default, Bg: color.Default}
+	red := style.Style{Fg: color.Default, Bg: color.Red}
+	screen := area{columns: 8, rows: 2, toEOL: true, toEOS: true}
+	for _, tc := range []struct {
+		name     string
+		opts     []Option
+		a        area
+		pixels   []term.PixelGetter
+		expected string
+	}{
+		{name: "end of line", a: screen, pixels: rowPixels(plain, "ab      "), expected: "ab\x1b[K"},
+		{name: "end of screen", a: screen, pixels: rowPixels(plain, "ab      ", "        "), expected: "ab\x1b[J"},
+		{name: "next line", a: screen, pixels: rowPixels(plain, "ab      ", "cd      "), expected: "ab\x1b[K\x1b[2;1Hcd\x1b[K"},
+		{name: "short run", a: screen, pixels: rowPixels(plain, "abcde   "), expected: "abcde   "},
+		{name: "not to the end of line", a: screen, pixels: rowPixels(plain, "ab     "), expected: "ab     "},
+		{name: "right margin", a: area{columns: 8, rows: 2}, pixels: rowPixels(plain, "ab      "), expected: "ab      "},
+		{name: "reversed", a: screen, pixels: rowPixels(style.Style{Fg: color.Default, Bg: color.Default, Attrs: style.Reverse}, "ab      "), expected: "ab      "},
+		{name: "background erase", a: screen, pixels: rowPixels(red, "ab      "), expected: "ab\x1b[K"},
+		{name: "no background erase", opts: []Option{WithCapabilityOverrides(map[string]string{"bce": ""})}, a: screen, pixels: rowPixels(red, "ab      "), expected: "ab      "},
+		{name: "no erase capability", opts: []Option{WithCapabilityOverrides(map[string]string{"el": "", "ed": ""})}, a: screen, pixels: rowPixels(plain, "ab      "), expected: "ab      "},
+	} {
+		c := newBenchCore(t, tc.opts...)
+		buf := &bytes.Buffer{}
+		c.drawIn(buf, tc.a, tc.pixels...)
+		out := buf.String()
+		if idx := strings.Index(out, "a"); idx < 0 || out[idx:] != tc.expected {
+			t.Errorf("error : %s : expecting %q, got %q", tc.name, tc.expected, out)
+		}
+	}
+}
+
+func TestEraseStatusLine(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		overrides map[string]string
+		expected  string
+	}{
+		{name: "hidden", overrides: map[string]string{"tsl": "\x1b]2;", "fsl": "\x07", "dsl": "\x1b]2;\x07\x1b[?1l"}, expected: "\x1b]2;\x07\x1b[?1l"},
+		{name: "emptied", overrides: map[string]string{"tsl": "\x1b]2;", "fsl": "\x07"}, expected: "\x1b]2;\x07"},
+		{name: "no status line", overrides: map[string]string{"tsl": "", "fsl": "", "dsl": ""}, expected: ""},
+	} {
+		c := newBenchCore(t, WithCapabilityOverrides(tc.overrides))
+		written := captureOut(t, c)
+		c.eraseStatusLine()
+		if out := written(); out != tc.expected {
+			t.Errorf("error : %s : expecting %q, got %q", tc.name, tc.expected, out)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package core
+
+import (
+	"os"
+)
+
+// WithTTYFallback is a functional option for deciding what happens when the standard input is redirected (e.g. the program reads piped data) : by default, the input is read from the controlling terminal,
+// which is where the user types anyway. Disabled, Start fails with ErrInputRedirected, e.g. for programs which shouldn't take over the terminal when used in a pipeline. The pipe is never read.
+func WithTTYFallback(enabled bool) Option {
+	return func(c *core) {
+		c.ttyFallback = enabled
+	}
+}
+
+// openInput returns the file the input is read from : the controlling terminal, even when the standard input is redirected, unless WithTTYFallback(false) forbids it
+func (c *core) openInput() (*os.File, error) {
+	if !c.ttyFallback && (c.stdin == nil || !isTTY(c.stdin)) {
+		return nil, ErrInputRedirected
+	}
+	return os.OpenFile(c.ttyPath, os.O_RDONLY, 0)
+}
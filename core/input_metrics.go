@@ -0,0 +1,82 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/badu/term"
+)
+
+// WithInputBuffer is a functional option for buffering the channels which carry the input chunks to the key and mouse dispatchers, so bursts (pastes, mouse drags) don't stall the reader of the terminal.
+// Default is zero (unbuffered) : the reader waits for the dispatchers. The backpressure can be watched with InputMetrics (see term.InputMonitor).
+func WithInputBuffer(size int) Option {
+	return func(c *core) {
+		c.inputBuffer = size
+	}
+}
+
+// inputMeter records the backpressure of a dispatcher channel
+type inputMeter struct {
+	sync.Mutex                   // guards other properties
+	metrics    term.InputMetrics //
+}
+
+// send delivers the chunk to the dispatcher, recording the time waited if the channel is full. It returns false if the context is done while waiting.
+func (m *inputMeter) send(ctx context.Context, ch chan []byte, chunk []byte) bool {
+	waiting := len(ch)
+	select {
+	case ch <- chunk:
+		m.record(waiting, 0, false)
+		return true
+	default:
+	}
+	if Debug.Enabled() {
+		Debug.Printf("input stalled : %d chunks waiting for the dispatcher", waiting)
+	}
+	start := time.Now()
+	select {
+	case ch <- chunk:
+		m.record(waiting, time.Since(start), true)
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// record updates the metrics, after a chunk was sent
+func (m *inputMeter) record(waiting int, stalled time.Duration, stall bool) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.metrics.Chunks++
+	if waiting > m.metrics.HighWater {
+		m.metrics.HighWater = waiting
+	}
+	if stall {
+		m.metrics.Stalls++
+		m.metrics.Stalled += stalled
+	}
+}
+
+// snapshot returns the metrics of the channel
+func (m *inputMeter) snapshot(ch chan []byte) term.InputMetrics {
+	m.Lock()
+	defer m.Unlock()
+
+	result := m.metrics
+	result.Capacity = cap(ch)
+	return result
+}
+
+// InputMetrics implements term.InputMonitor interface. The mouse metrics are empty if there is no mouse support.
+func (c *core) InputMetrics() (term.InputMetrics, term.InputMetrics) {
+	c.Lock()
+	defer c.Unlock()
+
+	var mouse term.InputMetrics
+	if c.mouseDispatcher != nil {
+		mouse = c.mouseMeter.snapshot(c.mouseDispatcher.InChan())
+	}
+	return c.keyMeter.snapshot(c.keyDispatcher.InChan()), mouse
+}
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestInputMetrics(t *testing.T) {
+	c := newBenchCore(t, WithInputBuffer(2))
+	keys, _ := c.InputMetrics()
+	if keys.Capacity != 2 {
+		t.Errorf("error : expecting the key dispatcher input to be buffered, got %d", keys.Capacity)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	meter := &inputMeter{}
+	ch := make(chan []byte, 2)
+	meter.send(ctx, ch, []byte("a"))
+	meter.send(ctx, ch, []byte("b"))
+	go func() {
+		time.Sleep(10 * time.Millisecond) // the dispatcher is busy
+		<-ch
+	}()
+	if !meter.send(ctx, ch, []byte("c")) {
+		t.Fatalf("error : the chunk should be sent, once the dispatcher reads")
+	}
+	metrics := meter.snapshot(ch)
+	if metrics.Chunks != 3 || metrics.Stalls != 1 || metrics.HighWater != 2 || metrics.Stalled < 5*time.Millisecond {
+		t.Errorf("error : unexpected metrics %+v", metrics)
+	}
+
+	cancel()
+	if meter.send(ctx, ch, []byte("d")) {
+		t.Errorf("error : the reader should stop waiting on shutdown")
+	}
+}
@@ -0,0 +1,179 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/signal"
+
+	"github.com/badu/term"
+)
+
+// InterruptMode tells how Ctrl+C and Ctrl+\ are handled
+type InterruptMode int
+
+const (
+	// InterruptAsKeys delivers Ctrl+C and Ctrl+\ as key events (raw mode), while also notifying InterruptChan. This is the default.
+	InterruptAsKeys InterruptMode = iota
+	// InterruptAsSignals lets the terminal driver turn Ctrl+C and Ctrl+\ into SIGINT and SIGQUIT, which are caught and notified on InterruptChan (no key event is delivered).
+	// Ctrl+Z remains a key event, since suspending the process would leave the terminal in raw mode.
+	InterruptAsSignals
+)
+
+const (
+	ctrlC         = 0x03 // ETX
+	ctrlBackslash = 0x1c // FS
+)
+
+// WithInterrupts is a functional option for choosing how Ctrl+C and Ctrl+\ are handled. Default is InterruptAsKeys.
+func WithInterrupts(mode InterruptMode) Option {
+	return func(c *core) {
+		c.interrupts = mode
+	}
+}
+
+// WithCancelOnInterrupt is a functional option for translating Ctrl+C and Ctrl+\ into a context cancellation : cancel is called on every interrupt.
+// Usually, it's the cancel function of the context passed to Start.
+func WithCancelOnInterrupt(cancel context.CancelFunc) Option {
+	return func(c *core) {
+		c.interruptCancel = cancel
+	}
+}
+
+// InterruptChan implements the term.Engine interface, notified each time the user asks to quit (Ctrl+C or Ctrl+\).
+// Notifications are not queued : if nobody is reading, the ones which follow the pending one are dropped.
+func (c *core) InterruptChan() chan struct{} {
+	return c.interruptCh
+}
+
+// interrupt notifies the interrupt channel, without blocking
+func (c *core) interrupt() {
+	if Debug.Enabled() {
+		Debug.Println("interrupt requested")
+	}
+	select {
+	case c.interruptCh <- struct{}{}:
+	default:
+	}
+	if c.interruptCancel != nil {
+		c.interruptCancel()
+	}
+}
+
+// interruptScanner remembers, between the chunks of input, what scanInterrupts has to skip
+type interruptScanner struct {
+	pasting bool   // inside a bracketed paste, which is text, not keys
+	pending []byte // an escape sequence (or the end of a paste) split across reads
+}
+
+// scanInterrupts looks for Ctrl+C and Ctrl+\ in the input (keys mode), skipping the escape sequences and the content of the bracketed pastes (the long ones reach here, see maxPaste)
+func (c *core) scanInterrupts(in []byte) {
+	if c.interrupts != InterruptAsKeys {
+		return
+	}
+	s := &c.interruptScan
+	data := in
+	if len(s.pending) > 0 {
+		data = append(s.pending, in...)
+		s.pending = nil
+	}
+	found := false
+	for idx := 0; idx < len(data); {
+		rest := data[idx:]
+		if s.pasting {
+			end := bytes.Index(rest, []byte(pasteEnd))
+			if end < 0 {
+				s.pending = append([]byte(nil), rest[term.Max(len(rest)-len(pasteEnd)+1, 0):]...) // might be the beginning of the end
+				break
+			}
+			s.pasting = false
+			idx += end + len(pasteEnd)
+			continue
+		}
+		switch rest[0] {
+		case ctrlC, ctrlBackslash:
+			found = true
+			idx++
+		case '\x1b':
+			length := escapeLength(rest)
+			if length < 0 {
+				if len(rest) <= maxPendingReport {
+					s.pending = append([]byte(nil), rest...) // waiting for the rest of it in the next read
+				}
+				idx = len(data)
+				continue
+			}
+			s.pasting = bytes.HasPrefix(rest, []byte(pasteStart))
+			idx += length
+		default:
+			idx++
+		}
+	}
+	if found {
+		c.interrupt()
+	}
+}
+
+// escapeLength returns the length of the escape sequence at the beginning of the input, or -1 if it's incomplete.
+// A control character inside a sequence ends it, since it was typed (e.g. Ctrl+C after a lone escape).
+func escapeLength(in []byte) int {
+	if len(in) < 2 {
+		return -1
+	}
+	switch in[1] {
+	case '[': // CSI : parameters and intermediates, then the final byte
+		for idx := 2; idx < len(in); idx++ {
+			switch b := in[idx]; {
+			case b >= 0x40 && b <= 0x7e:
+				return idx + 1
+			case b < 0x20:
+				return idx
+			}
+		}
+		return -1
+	case ']', 'P', '_', '^': // OSC, DCS, APC and PM : a string ended by BEL or ST
+		for idx := 2; idx < len(in); idx++ {
+			switch {
+			case in[idx] == '\a':
+				return idx + 1
+			case in[idx] == '\x1b' && idx+1 < len(in):
+				return idx + 2
+			case in[idx] == '\x1b':
+				return -1
+			}
+		}
+		return -1
+	case 'O': // SS3, e.g. the function keys
+		if len(in) < 3 {
+			return -1
+		}
+		if in[2] < 0x20 {
+			return 2
+		}
+		return 3
+	}
+	if in[1] < 0x20 {
+		return 1 // a lone escape
+	}
+	return 2 // Alt and a key
+}
+
+// watchSignals catches the interrupt signals (signals mode), until the context is done
+func (c *core) watchSignals(ctx context.Context) {
+	if c.interrupts != InterruptAsSignals {
+		return
+	}
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, interruptSignals...)
+	go func() {
+		defer signal.Stop(sigCh)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sigCh:
+				c.interrupt()
+			}
+		}
+	}()
+}
@@ -0,0 +1,11 @@
+//go:build nacl || plan9 || windows
+// +build nacl plan9 windows
+
+package core
+
+import (
+	"os"
+)
+
+// interruptSignals are the signals generated for Ctrl+C
+var interruptSignals = []os.Signal{os.Interrupt}
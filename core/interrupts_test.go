@@ -0,0 +1,48 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScanInterrupts(t *testing.T) {
+	long := strings.Repeat("x", maxPendingReport)
+	for _, tc := range []struct {
+		name     string
+		mode     InterruptMode
+		chunks   []string
+		expected bool
+	}{
+		{name: "ctrl c", chunks: []string{"ab\x03"}, expected: true},
+		{name: "ctrl backslash", chunks: []string{"\x1c"}, expected: true},
+		{name: "signals mode", mode: InterruptAsSignals, chunks: []string{"\x03"}},
+		{name: "text", chunks: []string{"abc"}},
+		{name: "inside a paste", chunks: []string{pasteStart + "a\x03b" + pasteEnd + "c"}},
+		{name: "paste split across reads", chunks: []string{pasteStart + "a", "\x03\x1c", "b\x1b[20", "1~c"}},
+		{name: "after a paste", chunks: []string{pasteStart + "a" + pasteEnd, "\x03"}, expected: true},
+		{name: "paste split before its end", chunks: []string{pasteStart + "a\x1b", "[201~\x03"}, expected: true},
+		{name: "inside an osc", chunks: []string{"\x1b]52;c;\x03\x1c\x07"}},
+		{name: "osc ended by st", chunks: []string{"\x1b]2;\x03\x1b\\", "\x03"}, expected: true},
+		{name: "osc split across reads", chunks: []string{"\x1b]11;rgb:\x03", "0000/0000/0000\x07"}},
+		{name: "csi", chunks: []string{"\x1b[1;5A"}},
+		{name: "after a csi", chunks: []string{"\x1b[1;5A\x03"}, expected: true},
+		{name: "alt and ctrl c", chunks: []string{"\x1b\x03"}, expected: true},
+		{name: "after a lone escape", chunks: []string{"\x1b", "\x03"}, expected: true},
+		{name: "inside a csi", chunks: []string{"\x1b[1\x03"}, expected: true}, // typed, the sequence being garbage
+		{name: "endless osc given up", chunks: []string{"\x1b]" + long, "\x03"}, expected: true},
+	} {
+		c := newBenchCore(t, WithInterrupts(tc.mode))
+		for _, chunk := range tc.chunks {
+			c.scanInterrupts([]byte(chunk))
+		}
+		interrupted := false
+		select {
+		case <-c.InterruptChan():
+			interrupted = true
+		default:
+		}
+		if interrupted != tc.expected {
+			t.Errorf("error : %s : expecting interrupted %t, got %t", tc.name, tc.expected, interrupted)
+		}
+	}
+}
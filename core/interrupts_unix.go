@@ -0,0 +1,12 @@
+//go:build !nacl && !plan9 && !windows
+// +build !nacl,!plan9,!windows
+
+package core
+
+import (
+	"os"
+	"syscall"
+)
+
+// interruptSignals are the signals generated by the terminal driver for Ctrl+C and Ctrl+\
+var interruptSignals = []os.Signal{os.Interrupt, syscall.SIGQUIT}
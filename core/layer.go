@@ -0,0 +1,129 @@
+package core
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+// layerState holds the layers set by SetLayer, drawn above the active pixels
+type layerState struct {
+	layers  map[int][]term.PixelGetter // by id
+	covered map[int]term.PixelGetter   // the pixel of the top most layer, by position hash
+	drawn   []term.PixelGetter         // the values of covered, in reading order
+}
+
+// SetLayer implements term.Layerer interface
+func (c *core) SetLayer(id int, pixels []term.PixelGetter) {
+	c.Lock()
+	defer c.Unlock()
+
+	if len(pixels) == 0 {
+		if _, ok := c.layers.layers[id]; !ok {
+			return
+		}
+		delete(c.layers.layers, id)
+	} else {
+		if c.layers.layers == nil {
+			c.layers.layers = make(map[int][]term.PixelGetter)
+		}
+		layer := make([]term.PixelGetter, len(pixels))
+		copy(layer, pixels)
+		c.layers.layers[id] = layer
+	}
+	c.relayer()
+}
+
+// RemoveLayer implements term.Layerer interface
+func (c *core) RemoveLayer(id int) {
+	c.SetLayer(id, nil)
+}
+
+// relayer finds the pixels on top, then draws the active pixels which are not covered anymore and the layers - locked inside caller function
+func (c *core) relayer() {
+	ids := make([]int, 0, len(c.layers.layers))
+	for id := range c.layers.layers {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	previous := c.layers.covered
+	c.layers.covered = make(map[int]term.PixelGetter)
+	for _, id := range ids {
+		for _, pixel := range c.bound(c.layers.layers[id]) {
+			c.layers.covered[pixel.PositionHash()] = pixel // the layers above replace the ones below
+		}
+	}
+	c.layers.drawn = c.layers.drawn[:0]
+	for _, pixel := range c.layers.covered {
+		c.layers.drawn = append(c.layers.drawn, pixel)
+	}
+	sortReading(c.layers.drawn)
+
+	var uncovered []term.PixelGetter
+	for hash := range previous {
+		if _, ok := c.layers.covered[hash]; ok {
+			continue
+		}
+		if pixel, ok := c.content[hash]; ok {
+			uncovered = append(uncovered, pixel)
+			continue
+		}
+		uncovered = append(uncovered, &regionPixel{hash: hash, r: ' ', st: style.Style{Fg: color.Default, Bg: color.Default}})
+	}
+	sortReading(uncovered)
+
+	buf := bytes.NewBuffer(nil)
+	c.drawBounded(buf, uncovered)
+	c.drawLayers(buf)
+	if c.plain {
+		c.flushPlain(buf)
+	}
+	if _, err := buf.WriteTo(c.out); err != nil {
+		if Debug.Enabled() {
+			Debug.Printf("error writing to out : " + err.Error())
+		}
+	}
+}
+
+// drawLayers draws the pixels of the layers which are on top - locked inside caller function
+func (c *core) drawLayers(w *bytes.Buffer) {
+	if len(c.layers.drawn) > 0 {
+		c.drawBounded(w, c.layers.drawn)
+	}
+}
+
+// uncovered returns the pixels which are not covered by a layer - locked inside caller function
+func (c *core) uncovered(pixels []term.PixelGetter) []term.PixelGetter {
+	if len(c.layers.covered) == 0 {
+		return pixels
+	}
+	var result []term.PixelGetter // allocated for the first covered pixel
+	for idx, pixel := range pixels {
+		if _, ok := c.layers.covered[pixel.PositionHash()]; !ok {
+			if result != nil {
+				result = append(result, pixel)
+			}
+			continue
+		}
+		if result == nil {
+			result = make([]term.PixelGetter, idx, len(pixels))
+			copy(result, pixels[:idx])
+		}
+	}
+	if result == nil {
+		return pixels
+	}
+	return result
+}
+
+// sortReading sorts the pixels in reading order, so the contiguous ones are written after a single goto
+func sortReading(pixels []term.PixelGetter) {
+	sort.Slice(pixels, func(i, j int) bool {
+		firstColumn, firstRow := term.UnHash(pixels[i].PositionHash())
+		secondColumn, secondRow := term.UnHash(pixels[j].PositionHash())
+		return firstRow < secondRow || (firstRow == secondRow && firstColumn < secondColumn)
+	})
+}
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+func TestLayers(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+	plain := style.Style{Fg: color.Default, Bg: color.Default}
+	under := &activePixel{regionPixel: &regionPixel{hash: term.Hash(1, 0), r: 'u', st: plain}}
+	c.content = map[int]term.Pixel{under.PositionHash(): under}
+
+	c.SetLayer(2, []term.PixelGetter{&regionPixel{hash: term.Hash(1, 0), r: 'T', st: plain}, &regionPixel{hash: term.Hash(2, 0), r: 't', st: plain}})
+	c.SetLayer(1, []term.PixelGetter{&regionPixel{hash: term.Hash(2, 0), r: 'B', st: plain}, &regionPixel{hash: term.Hash(3, 0), r: 'b', st: plain}})
+	if out := written(); !strings.Contains(out, "tb") || strings.Contains(out, "B") {
+		t.Errorf("error : the top layer should be drawn above the one below, got %q", out)
+	}
+
+	// the covered pixels change without being drawn
+	c.Lock()
+	c.drawPixels(c.out, under)
+	c.Unlock()
+	if out := written(); strings.Contains(out, "u") {
+		t.Errorf("error : the covered pixel should not be drawn, got %q", out)
+	}
+
+	c.RemoveLayer(2)
+	if out := written(); !strings.Contains(out, "u") || !strings.Contains(out, "Bb") || strings.Contains(out, "T") {
+		t.Errorf("error : removing the top layer should draw what it covered, got %q", out)
+	}
+	c.RemoveLayer(1)
+	if out := written(); !strings.Contains(out, "  ") {
+		t.Errorf("error : the positions without active pixels should be blanked, got %q", out)
+	}
+	c.RemoveLayer(1)
+	if out := written(); out != "" {
+		t.Errorf("error : removing a missing layer should not draw, got %q", out)
+	}
+}
@@ -0,0 +1,158 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+func TestLineEditor(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+
+	for _, tc := range []struct {
+		name     string
+		edit     func() bool
+		expected string
+	}{
+		{name: "insert lines", edit: func() bool { return c.InsertLines(2, 3) }, expected: "\x1b[3;1H\x1b[3L"},
+		{name: "insert line", edit: func() bool { return c.InsertLines(2, 1) }, expected: "\x1b[3;1H\x1b[1L"},
+		{name: "delete lines", edit: func() bool { return c.DeleteLines(2, 3) }, expected: "\x1b[3;1H\x1b[3M"},
+		{name: "insert chars", edit: func() bool { return c.InsertChars(term.NewPosition(4, 1), 2) }, expected: "\x1b[2;5H\x1b[2@"},
+		{name: "delete chars", edit: func() bool { return c.DeleteChars(term.NewPosition(4, 1), 2) }, expected: "\x1b[2;5H\x1b[2P"},
+	} {
+		if !tc.edit() {
+			t.Errorf("error : %s : the edit should be done by the terminal", tc.name)
+		}
+		if out := written(); !strings.Contains(out, tc.expected) {
+			t.Errorf("error : %s : expecting %q, got %q", tc.name, tc.expected, out)
+		}
+	}
+
+	for _, tc := range []struct {
+		name string
+		edit func() bool
+	}{
+		{name: "missing position", edit: func() bool { return c.InsertChars(nil, 1) }},
+		{name: "outside", edit: func() bool { return c.DeleteChars(term.NewPosition(benchColumns, 0), 1) }},
+		{name: "no count", edit: func() bool { return c.InsertLines(0, 0) }},
+	} {
+		if tc.edit() {
+			t.Errorf("error : %s : nothing should be edited", tc.name)
+		}
+		if out := written(); out != "" {
+			t.Errorf("error : %s : nothing should be written, got %q", tc.name, out)
+		}
+	}
+}
+
+func TestLineEditorRedraw(t *testing.T) {
+	c := newBenchCore(t, WithCapabilityOverrides(map[string]string{
+		"il1": "", "il": "", "dl1": "", "dl": "", "ich1": "", "ich": "", "dch1": "", "dch": "",
+	}))
+	written := captureOut(t, c)
+
+	a := &movingPixel{hash: term.Hash(1, 0), r: 'a', drawCh: make(chan term.PixelGetter, 1)}
+	b := &movingPixel{hash: term.Hash(2, 0), r: 'b', drawCh: make(chan term.PixelGetter, 1)}
+	c.ActivePixels([]term.PixelGetter{a, b})
+	written()
+
+	// the pixels are not aware of the edit : the moved content is redrawn from their positions, blanks elsewhere
+
+	for _, tc := range []struct {
+		name     string
+		edit     func() bool
+		expected string
+	}{
+		{name: "insert chars", edit: func() bool { return c.InsertChars(term.NewPosition(1, 0), 2) }, expected: "\x1b[1;2H  ab\x1b[K"},
+		{name: "delete chars", edit: func() bool { return c.DeleteChars(term.NewPosition(0, 0), 1) }, expected: "\x1b[1;1Hab\x1b[K"},
+		{name: "insert lines", edit: func() bool { return c.InsertLines(0, 1) }, expected: "\x1b[1;1H\x1b[K\x1b[2;1H ab\x1b[J"},
+		{name: "delete lines", edit: func() bool { return c.DeleteLines(0, 1) }, expected: "\x1b[1;1H\x1b[J"},
+	} {
+		if !tc.edit() {
+			t.Errorf("error : %s : the content should be redrawn", tc.name)
+		}
+		if out := written(); !strings.Contains(out, tc.expected) {
+			t.Errorf("error : %s : expecting %q, got %q", tc.name, tc.expected, out)
+		}
+	}
+}
+
+// textRows returns the pixels of the rows, covering the area
+func textRows(lines []string) []term.PixelGetter {
+	result := make([]term.PixelGetter, 0, benchColumns*len(lines))
+	for row, line := range lines {
+		runes := []rune(line)
+		for column := 0; column < benchColumns; column++ {
+			r := ' '
+			if column < len(runes) {
+				r = runes[column]
+			}
+			result = append(result, &regionPixel{hash: term.Hash(column, row), r: r, st: style.Style{Fg: color.Default, Bg: color.Default}})
+		}
+	}
+	return result
+}
+
+func TestRedrawScroll(t *testing.T) {
+	lines := make([]string, benchRows+1)
+	for idx := range lines {
+		lines[idx] = fmt.Sprintf("line %02d", idx)
+	}
+
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+	c.Redraw(textRows(lines[:benchRows]))
+	written()
+
+	// scrolled up : the terminal deletes the first line, only the last one being written
+	c.Redraw(textRows(lines[1:]))
+	out := written()
+	if !strings.Contains(out, "\x1b[1;1H\x1b[1M") || !strings.Contains(out, "line 50") || strings.Contains(out, "line 02") {
+		t.Errorf("error : expecting the first line to be deleted, got %q", out)
+	}
+
+	// scrolled down : the terminal inserts the first line
+	c.Redraw(textRows(lines[:benchRows]))
+	out = written()
+	if !strings.Contains(out, "\x1b[1;1H\x1b[1L") || !strings.Contains(out, "line 00") || strings.Contains(out, "line 02") {
+		t.Errorf("error : expecting a line to be inserted, got %q", out)
+	}
+
+	// only a part of the rows : the rows below would move without being drawn
+	c.Redraw(textRows(lines[1:10]))
+	if out = written(); strings.Contains(out, "\x1b[1M") || !strings.Contains(out, "line 09") {
+		t.Errorf("error : the partial redraw should not move the lines, got %q", out)
+	}
+
+	// a foreign write makes the content unknown
+	c.Redraw(textRows(lines[:benchRows]))
+	written()
+	if _, err := c.Writer().Write([]byte("foreign")); err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	c.Redraw(textRows(lines[1:]))
+	if out = written(); strings.Contains(out, "\x1b[1M") || !strings.Contains(out, "line 02") {
+		t.Errorf("error : everything should be drawn after a foreign write, got %q", out)
+	}
+}
+
+func TestRedrawScrollFallback(t *testing.T) {
+	c := newBenchCore(t, WithCapabilityOverrides(map[string]string{"il1": "", "il": "", "dl1": "", "dl": ""}))
+	written := captureOut(t, c)
+	lines := make([]string, benchRows+1)
+	for idx := range lines {
+		lines[idx] = fmt.Sprintf("line %02d", idx)
+	}
+	c.Redraw(textRows(lines[:benchRows]))
+	written()
+
+	c.Redraw(textRows(lines[1:]))
+	if out := written(); strings.Contains(out, "\x1b[1M") || !strings.Contains(out, "line 01") || !strings.Contains(out, "line 50") {
+		t.Errorf("error : all the rows should be written without the capability, got %q", out)
+	}
+}
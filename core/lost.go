@@ -0,0 +1,110 @@
+package core
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/badu/term"
+)
+
+// lostTerminal records the first failure of the terminal : a write which failed, or the end of the input
+type lostTerminal struct {
+	sync.Mutex                   // guards err and terminated
+	err        error             // the first failure, nil while the terminal works
+	done       chan struct{}     // closed when err is set
+	terminated *EventTermination // set when the engine stops by itself, because the input is gone
+}
+
+func newLostTerminal() *lostTerminal {
+	return &lostTerminal{done: make(chan struct{})}
+}
+
+// lose records the failure, if it's the first one. Returns false if the terminal was already lost.
+func (l *lostTerminal) lose(err error) bool {
+	l.Lock()
+	defer l.Unlock()
+	if l.err != nil {
+		return false
+	}
+	if Debug.Enabled() {
+		Debug.Printf("terminal lost : %v", err)
+	}
+	l.err = err
+	close(l.done)
+	return true
+}
+
+// failure returns the first failure, or nil
+func (l *lostTerminal) failure() error {
+	l.Lock()
+	defer l.Unlock()
+	return l.err
+}
+
+// EventTermination tells why the engine stopped by itself, see Termination
+type EventTermination struct {
+	reason error
+	when   time.Time
+}
+
+// Reason implements term.TerminationEvent interface
+func (e *EventTermination) Reason() error {
+	return e.reason
+}
+
+// When implements term.Event interface
+func (e *EventTermination) When() time.Time {
+	return e.when
+}
+
+// terminate records the end of the input as the terminal being lost, then shuts the engine down, since no more events can come : the goroutines would otherwise wait on a dead file descriptor
+func (c *core) terminate(reason error) {
+	c.lost.Lock()
+	if c.lost.terminated == nil {
+		c.lost.terminated = &EventTermination{reason: reason, when: time.Now()}
+	}
+	c.lost.Unlock()
+	c.lost.lose(reason)
+	c.stop()
+}
+
+// guarded returns the writer reporting the failed writes of the terminal
+func (c *core) guarded(out io.Writer) io.Writer {
+	return &guardedWriter{out: out, lost: c.lost}
+}
+
+// guardedWriter writes to the terminal, recording the first error as the terminal being lost
+type guardedWriter struct {
+	out  io.Writer     // the terminal
+	lost *lostTerminal //
+}
+
+// Write implements io.Writer
+func (g *guardedWriter) Write(p []byte) (int, error) {
+	n, err := g.out.Write(p)
+	if err != nil {
+		g.lost.lose(&term.WriteError{Err: err})
+	}
+	return n, err
+}
+
+// TerminalLost implements term.TerminalMonitor interface
+func (c *core) TerminalLost() <-chan struct{} {
+	return c.lost.done
+}
+
+// TerminalErr implements term.TerminalMonitor interface
+func (c *core) TerminalErr() error {
+	return c.lost.failure()
+}
+
+// Termination implements term.TerminalMonitor interface
+func (c *core) Termination() term.TerminationEvent {
+	c.lost.Lock()
+	defer c.lost.Unlock()
+	if c.lost.terminated == nil {
+		return nil // avoid returning a nil pointer inside the interface
+	}
+	return c.lost.terminated
+}
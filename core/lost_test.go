@@ -0,0 +1,123 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/badu/term"
+)
+
+// pipeTransport is a remote screen whose input is written by the test
+type pipeTransport struct {
+	*io.PipeReader //
+	io.Writer      //
+}
+
+func (p *pipeTransport) WindowSize() (int, int, error) { return benchColumns, benchRows, nil }
+func (p *pipeTransport) NotifyResize(func())           {}
+
+func TestTerminalLostOnWrite(t *testing.T) {
+	c := newBenchCore(t)
+	c.out = c.guarded(&failingWriter{})
+	if c.TerminalErr() != nil {
+		t.Fatalf("error : the terminal should work, got %v", c.TerminalErr())
+	}
+
+	if err := c.comm.PutClear(c.out); !errors.Is(err, term.ErrTerminalLost) {
+		t.Errorf("error : the failed write should be returned, got %v", err)
+	}
+	select {
+	case <-c.TerminalLost():
+	default:
+		t.Fatalf("error : the terminal should be lost")
+	}
+	var writeErr *term.WriteError
+	if err := c.TerminalErr(); !errors.As(err, &writeErr) || writeErr.Err.Error() != "closed" {
+		t.Errorf("error : expecting a WriteError wrapping the failure, got %v", err)
+	}
+}
+
+func TestTerminalLostOnEOF(t *testing.T) {
+	in, typed := io.Pipe()
+	c := newBenchCore(t, WithTransport(&pipeTransport{PipeReader: in, Writer: ioutil.Discard}))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("error starting : %v", err)
+	}
+	if _, err := c.PollEvent(ctx); err != nil { // the initial resize
+		t.Fatalf("error polling : %v", err)
+	}
+
+	_ = typed.Close() // the input reaches its end
+	select {
+	case <-c.TerminalLost():
+	case <-time.After(time.Second):
+		t.Fatalf("error : the end of the input should be detected")
+	}
+	if _, err := c.PollEvent(ctx); !errors.Is(err, term.ErrInputClosed) {
+		t.Errorf("error : PollEvent should return ErrInputClosed, got %v", err)
+	}
+	select {
+	case <-c.DyingChan():
+	case <-time.After(time.Second):
+		t.Fatalf("error : the engine should stop by itself")
+	}
+	if ev := c.Termination(); ev == nil || ev.Reason() != term.ErrInputClosed {
+		t.Errorf("error : the termination should tell the input was closed, got %v", ev)
+	}
+}
+
+func TestTerminationOnReadError(t *testing.T) {
+	in, typed := io.Pipe()
+	c := newBenchCore(t, WithTransport(&pipeTransport{PipeReader: in, Writer: ioutil.Discard}))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("error starting : %v", err)
+	}
+	if c.Termination() != nil {
+		t.Fatalf("error : the engine should be running")
+	}
+
+	hangup := errors.New("input/output error")
+	_ = typed.CloseWithError(hangup)
+	select {
+	case <-c.DyingChan():
+	case <-time.After(time.Second):
+		t.Fatalf("error : the engine should stop by itself")
+	}
+	ev := c.Termination()
+	if ev == nil {
+		t.Fatalf("error : the termination should be reported")
+	}
+	var readErr *term.ReadError
+	if reason := ev.Reason(); !errors.As(reason, &readErr) || !errors.Is(reason, hangup) || !errors.Is(reason, term.ErrInputClosed) {
+		t.Errorf("error : expecting a ReadError wrapping the failure, got %v", reason)
+	}
+	if ctx.Err() != nil {
+		t.Errorf("error : the context of the caller should not be cancelled")
+	}
+}
+
+func TestNoTerminationOnCancel(t *testing.T) {
+	in, _ := io.Pipe()
+	c := newBenchCore(t, WithTransport(&pipeTransport{PipeReader: in, Writer: ioutil.Discard}))
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("error starting : %v", err)
+	}
+	cancel()
+	select {
+	case <-c.DyingChan():
+	case <-time.After(time.Second):
+		t.Fatalf("error : the engine should stop")
+	}
+	if ev := c.Termination(); ev != nil {
+		t.Errorf("error : the engine was stopped by the caller, got termination %v", ev.Reason())
+	}
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"io"
+	"sync"
+)
+
+// WithMirror is a functional option for teeing the output to another writer, e.g. for streaming a session live or writing a debug transcript, while the application runs interactively.
+// The mirror can't slow down or break the terminal : once a write fails, the mirror is dropped.
+func WithMirror(w io.Writer) Option {
+	return func(c *core) {
+		c.mirror = w
+	}
+}
+
+// WithMirrorStripping is a functional option for removing the cursor movements and the erasing sequences from what the mirror receives, so it gets the text and its styles only. Default is disabled.
+func WithMirrorStripping(enabled bool) Option {
+	return func(c *core) {
+		c.mirrorStrip = enabled
+	}
+}
+
+// mirrored returns the writer used for displaying : the terminal, teeing to the mirror if there is one
+func (c *core) mirrored(out io.Writer) io.Writer {
+	if c.mirror == nil {
+		return out
+	}
+	return &mirrorWriter{out: out, mirror: c.mirror, strip: c.mirrorStrip}
+}
+
+// mirrorWriter writes to the terminal, then to the mirror
+type mirrorWriter struct {
+	sync.Mutex           // guards other properties
+	out        io.Writer // the terminal
+	mirror     io.Writer // set by WithMirror, nil once it failed
+	strip      bool      // set by WithMirrorStripping
+	pending    []byte    // a sequence split across writes, kept until it's complete, so it can be stripped
+}
+
+// Write implements io.Writer. Only the errors of the terminal are returned.
+func (m *mirrorWriter) Write(p []byte) (int, error) {
+	n, err := m.out.Write(p)
+
+	m.Lock()
+	defer m.Unlock()
+	if m.mirror == nil {
+		return n, err
+	}
+	content := p[:n]
+	if m.strip {
+		content = m.stripped(content)
+	}
+	if len(content) > 0 {
+		if _, mirrorErr := m.mirror.Write(content); mirrorErr != nil {
+			if Debug.Enabled() {
+				Debug.Printf("mirror dropped : %v", mirrorErr)
+			}
+			m.mirror = nil
+		}
+	}
+	return n, err
+}
+
+// stripped returns the content without the CSI sequences moving the cursor (CUU, CUD, CUF, CUB, CNL, CPL, CHA, CUP, HVP, VPA, HPA) or erasing (ED, EL) - locked inside caller function
+func (m *mirrorWriter) stripped(p []byte) []byte {
+	data := append(m.pending, p...)
+	m.pending = nil
+	result := make([]byte, 0, len(data))
+	for idx := 0; idx < len(data); {
+		if data[idx] != '\x1b' {
+			result = append(result, data[idx])
+			idx++
+			continue
+		}
+		if idx+1 == len(data) {
+			m.pending = append(m.pending, data[idx:]...)
+			break
+		}
+		if data[idx+1] != '[' {
+			result = append(result, data[idx])
+			idx++
+			continue
+		}
+		end := idx + 2
+		for end < len(data) && data[end] >= 0x20 && data[end] <= 0x3f {
+			end++ // parameters and intermediate bytes
+		}
+		if end == len(data) {
+			m.pending = append(m.pending, data[idx:]...)
+			break
+		}
+		switch data[end] {
+		case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'J', 'K', 'd', 'f', '`':
+		default:
+			result = append(result, data[idx:end+1]...)
+		}
+		idx = end + 1
+	}
+	return result
+}
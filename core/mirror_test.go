@@ -0,0 +1,61 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/badu/term"
+)
+
+// failingWriter is a mirror which went away
+type failingWriter struct {
+	writes int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return 0, errors.New("closed")
+}
+
+func TestMirror(t *testing.T) {
+	mirror := &bytes.Buffer{}
+	c := newBenchCore(t, WithMirror(mirror))
+	written := captureOut(t, c)
+	c.out = c.mirrored(c.out.(*os.File))
+
+	c.drawPixels(c.out, &regionPixel{hash: term.Hash(3, 2), r: 'x'})
+	if out := written(); out == "" || mirror.String() != out {
+		t.Errorf("error : the mirror should receive the output as it is, got %q instead of %q", mirror.String(), out)
+	}
+
+	mirror.Reset()
+	c = newBenchCore(t, WithMirror(mirror), WithMirrorStripping(true))
+	written = captureOut(t, c)
+	c.out = c.mirrored(c.out.(*os.File))
+	for _, chunk := range []string{"\x1b[2J\x1b[1;1Hab\x1b[3", "1mc\x1b[K\x1b", "[5;5Hd\x1b[?25l"} {
+		if _, err := c.out.Write([]byte(chunk)); err != nil {
+			t.Fatalf("error writing : %v", err)
+		}
+	}
+	if out := written(); out != "\x1b[2J\x1b[1;1Hab\x1b[31mc\x1b[K\x1b[5;5Hd\x1b[?25l" {
+		t.Errorf("error : the terminal should receive everything, got %q", out)
+	}
+	if mirror.String() != "ab\x1b[31mcd\x1b[?25l" {
+		t.Errorf("error : the cursor movements and erasing should be stripped, got %q", mirror.String())
+	}
+
+	failing := &failingWriter{}
+	c = newBenchCore(t, WithMirror(failing))
+	written = captureOut(t, c)
+	c.out = c.mirrored(c.out.(*os.File))
+	for idx := 0; idx < 2; idx++ {
+		if _, err := c.out.Write([]byte("a")); err != nil {
+			t.Errorf("error : the errors of the mirror should not be returned, got %v", err)
+		}
+	}
+	if out := written(); out != "aa" || failing.writes != 1 {
+		t.Errorf("error : the failed mirror should be dropped, got %d writes", failing.writes)
+	}
+}
@@ -0,0 +1,149 @@
+package core
+
+import (
+	"bytes"
+	"strconv"
+	"sync"
+
+	"github.com/badu/term"
+)
+
+const (
+	modeReport = "\x1b[?" // prefix of the DECRPM reply, ESC [ ? mode ; status $ y (shared with other reports, which are left to the key dispatcher)
+)
+
+// savedModes are the DEC private modes changed by the engine (or by the initialization strings of the terminal), which are saved on start and restored on shutdown (see WithModeRestore)
+var savedModes = []int{
+	term.ModeCursorKeys,
+	term.ModeAutoWrap,
+	term.ModeCursorVisible,
+	term.ModeMouseClicks,
+	term.ModeMouseDrag,
+	term.ModeMouseMotion,
+	term.ModeFocusReports,
+	term.ModeMouseSGR,
+	term.ModeAlternateScreen,
+	term.ModeBracketedPaste,
+	term.ModeThemeReports,
+}
+
+// WithModeRestore is a functional option for saving the DEC private modes changed by the engine (the mouse, the bracketed paste, the alternate screen, etc.) before changing them (XTSAVE),
+// then restoring them on shutdown (XTRESTORE), so the outer program (another TUI, or a shell having its own settings) finds them as they were. Default is enabled.
+// The terminals which don't support XTSAVE ignore the sequences, the modes being reset on shutdown as usual.
+func WithModeRestore(enabled bool) Option {
+	return func(c *core) {
+		c.restoreModes = enabled
+	}
+}
+
+// xtModes returns the XTSAVE (final byte 's') or XTRESTORE (final byte 'r') sequence for the modes, CSI ? mode ; mode ... final
+func xtModes(final byte, modes ...int) string {
+	result := []byte("\x1b[?")
+	for idx, mode := range modes {
+		if idx > 0 {
+			result = append(result, ';')
+		}
+		result = strconv.AppendInt(result, int64(mode), 10)
+	}
+	return string(append(result, final))
+}
+
+// saveModes asks the terminal to remember the modes, before the engine changes them - locked inside caller function
+func (c *core) saveModes() {
+	if c.restoreModes {
+		c.comm.WriteString(c.out, xtModes('s', savedModes...))
+	}
+}
+
+// restoreSavedModes asks the terminal to put back the saved modes, after the engine has reset them - locked inside caller function
+func (c *core) restoreSavedModes() {
+	if c.restoreModes {
+		c.comm.WriteString(c.out, xtModes('r', savedModes...))
+	}
+}
+
+// modeWatcher keeps the states of the DEC private modes, as reported by the terminal
+type modeWatcher struct {
+	sync.Mutex                         // guards other properties
+	states     map[int]term.ModeStatus // the last reported state, by mode
+}
+
+// modeQuery returns the DECRQM query for the DEC private mode, CSI ? mode $ p
+func modeQuery(mode int) string {
+	return "\x1b[?" + strconv.Itoa(mode) + "$p"
+}
+
+// status returns the last reported state of the mode
+func (m *modeWatcher) status(mode int) term.ModeStatus {
+	m.Lock()
+	defer m.Unlock()
+
+	return m.states[mode]
+}
+
+// parseModeReport handles the DECRPM reply, returning the number of bytes consumed, zero if it's another report, or -1 if it's incomplete
+func (m *modeWatcher) parseModeReport(report []byte) int {
+	end := len(modeReport)
+	for ; end < len(report); end++ {
+		if report[end] >= 0x40 && report[end] <= 0x7e {
+			break // the final byte of the sequence
+		}
+	}
+	if end == len(report) {
+		return -1
+	}
+	if report[end] != 'y' || report[end-1] != '$' {
+		return 0 // e.g. the kitty keyboard flags, CSI ? flags u
+	}
+	fields := bytes.Split(report[len(modeReport):end-1], []byte{';'})
+	if len(fields) != 2 {
+		return end + 1
+	}
+	mode, err := strconv.Atoi(string(fields[0]))
+	if err != nil {
+		return end + 1
+	}
+	value, err := strconv.Atoi(string(fields[1]))
+	if err != nil || value < 0 || value > 4 {
+		return end + 1
+	}
+	if Debug.Enabled() {
+		Debug.Printf("mode %d reported as %s", mode, term.ModeStatus(value+1))
+	}
+	m.Lock()
+	defer m.Unlock()
+	if m.states == nil {
+		m.states = make(map[int]term.ModeStatus)
+	}
+	m.states[mode] = term.ModeStatus(value + 1) // the reply starts with "not recognized", while zero is unknown for us
+	return end + 1
+}
+
+// queryModes asks the terminal about the modes enabled on start. The mouse ones are queried each time the mouse is enabled - locked inside caller function
+func (c *core) queryModes(modes ...int) {
+	if c.bracketedPaste {
+		modes = append(modes, term.ModeBracketedPaste)
+	}
+	if c.focusReports {
+		modes = append(modes, term.ModeFocusReports)
+	}
+	for _, mode := range modes {
+		c.comm.WriteString(c.out, modeQuery(mode))
+	}
+}
+
+// ModeStatus implements term.ModeReporter interface
+func (c *core) ModeStatus(mode int) term.ModeStatus {
+	return c.modes.status(mode)
+}
+
+// QueryMode implements term.ModeReporter interface
+func (c *core) QueryMode(mode int) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.out == nil || c.plain {
+		return
+	}
+	c.comm.WriteString(c.out, modeQuery(mode))
+}
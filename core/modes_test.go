@@ -0,0 +1,70 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/badu/term"
+)
+
+func TestModeReports(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+
+	if status := c.ModeStatus(term.ModeSynchronizedOutput); status != term.ModeUnknown {
+		t.Errorf("error : modes should be unknown until reported, got %s", status)
+	}
+	out := c.reports.filter([]byte("a\x1b[?2026;2$y\x1b[?1ub\x1b[?2004;"))
+	if string(out) != "a\x1b[?1ub" {
+		t.Errorf("error : only the mode reports should be removed from input, got %q", out)
+	}
+	if status := c.ModeStatus(term.ModeSynchronizedOutput); status != term.ModeReset || status.IsSet() {
+		t.Errorf("error : expecting the mode to be reset, got %s", status)
+	}
+	if out := c.reports.filter([]byte("1$y")); len(out) != 0 {
+		t.Errorf("error : the report split across reads should be removed, got %q", out)
+	}
+	if status := c.ModeStatus(term.ModeBracketedPaste); !status.IsSet() {
+		t.Errorf("error : expecting the mode to be set, got %s", status)
+	}
+	c.reports.filter([]byte("\x1b[?9999;0$y"))
+	if status := c.ModeStatus(9999); status != term.ModeNotRecognized {
+		t.Errorf("error : expecting the mode to be unknown to the terminal, got %s", status)
+	}
+
+	c.QueryMode(term.ModeFocusReports)
+	if out := written(); out != "\x1b[?1004$p" {
+		t.Errorf("error : unexpected query %q", out)
+	}
+}
+
+func TestModeRestore(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+
+	c.saveModes()
+	if out := written(); out != "\x1b[?1;7;25;1000;1002;1003;1004;1006;1049;2004;2031s" {
+		t.Errorf("error : unexpected XTSAVE %q", out)
+	}
+	c.restoreSavedModes()
+	if out := written(); out != "\x1b[?1;7;25;1000;1002;1003;1004;1006;1049;2004;2031r" {
+		t.Errorf("error : unexpected XTRESTORE %q", out)
+	}
+
+	c = newBenchCore(t, WithModeRestore(false))
+	written = captureOut(t, c)
+	c.saveModes()
+	c.restoreSavedModes()
+	if out := written(); out != "" {
+		t.Errorf("error : the modes should not be saved, got %q", out)
+	}
+}
+
+func TestResetTerminal(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+
+	c.ResetTerminal()
+	if out := written(); out != "\x1bc\x1b]104\a\x1b[!p\x1b[?3;4l\x1b[4l\x1b>"+"\x1b(B\x1b[m"+"\x1b[?1049l\x1b[23;0;0t" {
+		t.Errorf("error : expecting rs1, rs2, sgr0 and rmcup, got %q", out)
+	}
+}
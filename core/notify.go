@@ -0,0 +1,88 @@
+package core
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NotificationMode tells which sequence is used for the desktop notifications, see term.Notifier
+type NotificationMode int
+
+const (
+	// NotifyDetect chooses the sequence from the environment ($TERM_PROGRAM, $TERM). This is the default.
+	NotifyDetect NotificationMode = iota
+	// NotifyNone sends no notifications, e.g. for terminals which print the unknown sequences
+	NotifyNone
+	// NotifyOSC9 sends ESC ] 9 ; body, the sequence of iTerm2, also understood by kitty. The title is prepended to the body.
+	NotifyOSC9
+	// NotifyOSC777 sends ESC ] 777 ; notify ; title ; body, the sequence of the urxvt notify extension, also understood by foot, WezTerm and Ghostty
+	NotifyOSC777
+)
+
+const (
+	notify9Start   = "\x1b]9;"          // OSC 9 : desktop notification, e.g. ESC ] 9 ; body ESC \
+	notify777Start = "\x1b]777;notify;" // OSC 777 : desktop notification, e.g. ESC ] 777 ; notify ; title ; body ESC \
+	notifyEnd      = "\x1b\\"           //
+)
+
+// WithNotifications is a functional option for choosing the sequence of the desktop notifications, when the terminal isn't detected. Default is NotifyDetect.
+func WithNotifications(mode NotificationMode) Option {
+	return func(c *core) {
+		c.notifications = mode
+	}
+}
+
+// detectNotifications returns the notification sequence understood by the terminal, or NotifyNone if it's unknown
+func detectNotifications(termName string, lookupEnv func(string) (string, bool)) NotificationMode {
+	program, _ := lookupEnv("TERM_PROGRAM")
+	switch program {
+	case "iTerm.app":
+		return NotifyOSC9
+	case "WezTerm", "ghostty":
+		return NotifyOSC777
+	}
+	if _, ok := lookupEnv("KITTY_WINDOW_ID"); ok || strings.HasPrefix(termName, "xterm-kitty") {
+		return NotifyOSC9
+	}
+	if strings.HasPrefix(termName, "rxvt") || strings.HasPrefix(termName, "foot") {
+		return NotifyOSC777
+	}
+	return NotifyNone
+}
+
+// Notify implements term.Notifier interface
+func (c *core) Notify(title, body string) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.notify(printable(title), printable(body))
+}
+
+// notify writes the notification, the title and the body being printable - locked inside caller function
+func (c *core) notify(title, body string) bool {
+	if c.out == nil || c.plain {
+		return false
+	}
+	switch c.notifications {
+	case NotifyOSC9:
+		if title != "" {
+			body = title + ": " + body
+		}
+		c.comm.WriteString(c.out, notify9Start+body+notifyEnd)
+	case NotifyOSC777:
+		c.comm.WriteString(c.out, notify777Start+strings.ReplaceAll(title, ";", " ")+";"+body+notifyEnd)
+	default:
+		return false
+	}
+	return true
+}
+
+// printable removes the control characters, so no escape sequence can be injected
+func printable(text string) string {
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if !unicode.IsPrint(r) {
+			return ' '
+		}
+		return r
+	}, text))
+}
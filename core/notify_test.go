@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestDetectNotifications(t *testing.T) {
+	for _, tc := range []struct {
+		term     string
+		env      map[string]string
+		expected NotificationMode
+	}{
+		{"xterm-256color", map[string]string{"TERM_PROGRAM": "iTerm.app"}, NotifyOSC9},
+		{"xterm-256color", map[string]string{"TERM_PROGRAM": "WezTerm"}, NotifyOSC777},
+		{"xterm-kitty", nil, NotifyOSC9},
+		{"rxvt-unicode-256color", nil, NotifyOSC777},
+		{"foot", nil, NotifyOSC777},
+		{"xterm-256color", nil, NotifyNone},
+	} {
+		lookup := func(name string) (string, bool) {
+			value, ok := tc.env[name]
+			return value, ok
+		}
+		if mode := detectNotifications(tc.term, lookup); mode != tc.expected {
+			t.Errorf("error : %s %v should use %d, got %d", tc.term, tc.env, tc.expected, mode)
+		}
+	}
+}
+
+func TestNotify(t *testing.T) {
+	c := newBenchCore(t, WithNotifications(NotifyOSC9))
+	written := captureOut(t, c)
+	if !c.Notify("build", "done\x1b]0;pwned\x07") {
+		t.Fatalf("error : the notification should be sent")
+	}
+	if out := written(); out != "\x1b]9;build: done ]0;pwned\x1b\\" {
+		t.Errorf("error : unexpected OSC 9 notification %q", out)
+	}
+
+	c.notifications = NotifyOSC777
+	c.Notify("a;b", "done")
+	if out := written(); out != "\x1b]777;notify;a b;done\x1b\\" {
+		t.Errorf("error : unexpected OSC 777 notification %q", out)
+	}
+
+	c.notifications = NotifyNone
+	if c.Notify("build", "done") || written() != "" {
+		t.Errorf("error : unknown terminals should not get notifications")
+	}
+}
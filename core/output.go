@@ -0,0 +1,42 @@
+package core
+
+import (
+	"os"
+)
+
+// WithOutputFile is a functional option for drawing on the file instead of the controlling terminal, e.g. os.Stderr, so the standard output stays free for data (e.g. a tool in the middle of a pipeline).
+// The file is not closed on shutdown. When it's not a terminal, the plain mode is used (unless set by WithPlainOutput), rendering into the file.
+func WithOutputFile(f *os.File) Option {
+	return func(c *core) {
+		c.outFile = f
+	}
+}
+
+// WithStderrOutput is a functional option for drawing on the standard error, see WithOutputFile
+func WithStderrOutput() Option {
+	return WithOutputFile(os.Stderr)
+}
+
+// outputFile returns the file deciding the plain mode : the one set by WithOutputFile, otherwise the standard output
+func (c *core) outputFile() *os.File {
+	if c.outFile != nil {
+		return c.outFile
+	}
+	return os.Stdout
+}
+
+// openOutput returns the file to draw on : the one set by WithOutputFile, otherwise the controlling terminal
+func (c *core) openOutput() (*os.File, error) {
+	if c.outFile != nil {
+		return c.outFile, nil
+	}
+	return os.OpenFile(c.ttyPath, os.O_WRONLY, 0)
+}
+
+// closeOutput closes the file opened by openOutput, unless it belongs to the application
+func (c *core) closeOutput() error {
+	if c.tty == nil || c.tty == c.outFile {
+		return nil
+	}
+	return c.tty.Close()
+}
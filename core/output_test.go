@@ -0,0 +1,51 @@
+package core
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/badu/term"
+)
+
+func TestOutputFilePlain(t *testing.T) {
+	newBenchCore(t) // registers the terminal, and sets the environment
+	out, err := ioutil.TempFile(t.TempDir(), "out")
+	if err != nil {
+		t.Fatalf("error creating output : %v", err)
+	}
+	defer func() { _ = out.Close() }()
+
+	engine, err := NewCore("xterm-256color", WithTerminfo(benchInfo), WithOutputFile(out))
+	if err != nil {
+		t.Fatalf("error creating engine : %v", err)
+	}
+	c := engine.(*core)
+	if !c.plain {
+		t.Fatalf("error : the output file is not a terminal, the plain mode should be used")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("error starting : %v", err)
+	}
+	c.Redraw([]term.PixelGetter{&regionPixel{hash: term.Hash(0, 0), r: 'x'}})
+	cancel()
+	select {
+	case <-c.DyingChan():
+	case <-time.After(time.Second):
+		t.Fatalf("error : the engine should stop")
+	}
+
+	content, err := ioutil.ReadFile(out.Name())
+	if err != nil {
+		t.Fatalf("error reading output : %v", err)
+	}
+	if !strings.HasPrefix(string(content), "x") {
+		t.Errorf("error : the frame should be written to the output file, got %q", content)
+	}
+	if _, err := out.Write([]byte("done")); err != nil {
+		t.Errorf("error : the output file should not be closed by the engine, got %v", err)
+	}
+}
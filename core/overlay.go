@@ -0,0 +1,172 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/key"
+	"github.com/badu/term/style"
+)
+
+// overlayStyle is how the debug overlay looks, standing out of whatever the application draws
+var overlayStyle = style.Style{Fg: color.Default, Bg: color.Default, Attrs: style.Reverse}
+
+// WithDebugOverlay is a functional option for showing the debug overlay from start (see term.DrawMonitor). Default is disabled.
+func WithDebugOverlay(enabled bool) Option {
+	return func(c *core) {
+		c.overlay.shown = enabled
+	}
+}
+
+// WithDebugOverlayKey is a functional option for toggling the debug overlay with a key chord, e.g. key.ParseBinding("Ctrl+Alt+D").
+// The key events are still delivered to the application, so the chord should be one it doesn't use.
+func WithDebugOverlayKey(binding key.Binding) Option {
+	return func(c *core) {
+		c.overlay.binding = &binding
+	}
+}
+
+// debugOverlay holds the state of the debug overlay, shown on the top row of the screen
+type debugOverlay struct {
+	shown   bool          // set by WithDebugOverlay or ShowDebugOverlay
+	binding *key.Binding  // set by WithDebugOverlayKey, toggles the overlay
+	stop    chan struct{} // closed for stopping the refresh, nil while it's not running
+}
+
+// ShowDebugOverlay implements term.DrawMonitor interface. There is nowhere to show it in plain mode.
+func (c *core) ShowDebugOverlay(show bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.showOverlay(show)
+}
+
+// DebugOverlayShown implements term.DrawMonitor interface
+func (c *core) DebugOverlayShown() bool {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.overlay.shown
+}
+
+// showOverlay shows or hides the debug overlay, reserving the top row while it's shown - locked inside caller function
+func (c *core) showOverlay(show bool) {
+	if c.overlay.shown == show || c.plain {
+		return
+	}
+	c.overlay.shown = show
+	c.relayout()
+	if !show {
+		c.stopOverlay()
+		return // the pages get the row back, and draw over it
+	}
+	c.drawOverlay()
+	c.refreshOverlay(c.ctx)
+}
+
+// refreshOverlay draws the debug overlay every second, until it's hidden or the context is done - locked inside caller function
+func (c *core) refreshOverlay(ctx context.Context) {
+	if ctx == nil || c.overlay.stop != nil {
+		return // not started yet, or already refreshing
+	}
+	stop := make(chan struct{})
+	c.overlay.stop = stop
+	go func() {
+		ticker := time.NewTicker(metricsWindow)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stop:
+				return
+			case <-ticker.C:
+				c.Lock()
+				c.drawOverlay()
+				c.Unlock()
+			}
+		}
+	}()
+}
+
+// stopOverlay stops the refresh of the debug overlay - locked inside caller function
+func (c *core) stopOverlay() {
+	if c.overlay.stop != nil {
+		close(c.overlay.stop)
+		c.overlay.stop = nil
+	}
+}
+
+// drawChrome draws what the engine shows outside the pages : the status and the debug overlay - locked inside caller function
+func (c *core) drawChrome() {
+	c.drawStatus()
+	c.drawOverlay()
+}
+
+// drawOverlay writes the draw metrics, right aligned on the top row - locked inside caller function
+func (c *core) drawOverlay() {
+	if c.out == nil || c.plain || c.size == nil || c.screenColumns <= 0 || !c.overlay.shown {
+		return
+	}
+	m := c.meter.snapshot()
+	runes := []rune(fmt.Sprintf(" %.1f fps  %.0f B/frame  %.0f cells/frame  %s latency ", m.FPS, m.BytesPerFrame, m.CellsPerFrame, m.Latency.Round(100*time.Microsecond)))
+	offset := c.screenColumns - len(runes) // negative if the screen is too narrow, the beginning being cut
+	pixels := make([]term.PixelGetter, c.screenColumns)
+	for column := range pixels {
+		r := ' '
+		if idx := column - offset; idx >= 0 && idx < len(runes) {
+			r = runes[idx]
+		}
+		pixels[column] = &regionPixel{hash: term.Hash(column, 0), r: r, st: overlayStyle}
+	}
+	buf := bytes.NewBuffer(nil)
+	c.drawIn(buf, area{top: 0, columns: c.screenColumns, rows: 1, toEOL: true}, pixels...)
+	c.writeOut(buf)
+	c.restoreCursor()
+	c.meter.exclude(c.meter.snapshot().Bytes - m.Bytes) // what the overlay wrote
+}
+
+// overlayToggle listens the key events, toggling the debug overlay when the chord set by WithDebugOverlayKey is pressed
+type overlayToggle struct {
+	ch   chan term.KeyEvent //
+	died chan struct{}      //
+}
+
+// KeyListen implements term.KeyListener interface
+func (t *overlayToggle) KeyListen() chan term.KeyEvent {
+	return t.ch
+}
+
+// DyingChan implements term.Death interface
+func (t *overlayToggle) DyingChan() chan struct{} {
+	return t.died
+}
+
+// listenOverlayKey registers the toggle of the debug overlay to the key dispatcher, until the context is done
+func (c *core) listenOverlayKey(ctx context.Context) {
+	if c.overlay.binding == nil || c.plain {
+		return
+	}
+	binding := *c.overlay.binding
+	toggle := &overlayToggle{ch: make(chan term.KeyEvent, 1), died: make(chan struct{})}
+	c.keyDispatcher.Register(toggle)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				close(toggle.died)
+				return
+			case ev := <-toggle.ch:
+				if binding.Matches(ev) {
+					c.Lock()
+					c.showOverlay(!c.overlay.shown)
+					c.Unlock()
+				}
+			}
+		}
+	}()
+}
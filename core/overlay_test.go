@@ -0,0 +1,78 @@
+package core
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+)
+
+func TestDrawMetrics(t *testing.T) {
+	c := newBenchCore(t)
+	captureOut(t, c)
+	c.out = c.metered(c.out)
+
+	c.scanInput([]byte("a"))
+	buf := bytes.NewBuffer(nil)
+	c.drawPixels(buf, &regionPixel{hash: term.Hash(1, 1), r: 'x'}, &regionPixel{hash: term.Hash(2, 1), r: 'y'})
+	c.writeOut(buf)
+	c.drawPixels(buf, &regionPixel{hash: term.Hash(1, 2), r: 'z'})
+	c.writeOut(buf)
+	c.drawPixels(buf) // nothing to draw, not a frame
+
+	m := c.DrawMetrics()
+	if m.Frames != 2 || m.Cells != 3 {
+		t.Errorf("error : expecting 2 frames of 3 cells, got %d frames of %d cells", m.Frames, m.Cells)
+	}
+	if m.Bytes == 0 {
+		t.Errorf("error : the bytes written should be counted")
+	}
+	if m.Latency <= 0 {
+		t.Errorf("error : the latency from the input to the first frame should be measured")
+	}
+}
+
+func TestDebugOverlay(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+	c.out = c.metered(c.out)
+	c.ReserveEdge(term.EdgeTop, 2)
+	written()
+
+	c.ShowDebugOverlay(true)
+	if !c.DebugOverlayShown() {
+		t.Fatalf("error : the overlay should be shown")
+	}
+	if top := c.Margins().Top; top != 3 {
+		t.Errorf("error : the top row should be reserved above the edge, got a top margin of %d", top)
+	}
+	if rows := c.Size().Rows; rows != benchRows-3 {
+		t.Errorf("error : the pages should lose the overlay row, got %d rows", rows)
+	}
+	if a := c.edgeArea(term.EdgeTop); a.top != 1 || a.rows != 2 {
+		t.Errorf("error : the top edge should be below the overlay, got %+v", a)
+	}
+	before := c.DrawMetrics().Bytes
+	out := written()
+	if !strings.HasPrefix(out, "\x1b[1;1H") || !strings.Contains(out, "fps") || !strings.Contains(out, "latency") {
+		t.Errorf("error : the metrics should be written on the top row, got %q", out)
+	}
+	c.drawOverlay()
+	if after := c.DrawMetrics().Bytes; after != before {
+		t.Errorf("error : the overlay should not count its own bytes, got %d then %d", before, after)
+	}
+
+	c.ShowDebugOverlay(false)
+	if top := c.Margins().Top; top != 2 {
+		t.Errorf("error : the overlay row should be given back, got a top margin of %d", top)
+	}
+}
+
+func TestDebugOverlayPlain(t *testing.T) {
+	c := newBenchCore(t, WithDebugOverlay(true))
+	c.plain = true
+	if top := c.margins().Top; top != 0 {
+		t.Errorf("error : the plain mode should not reserve the overlay row, got a top margin of %d", top)
+	}
+}
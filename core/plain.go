@@ -0,0 +1,154 @@
+package core
+
+import (
+	"io"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/info"
+	"github.com/badu/term/style"
+)
+
+// WithPlainOutput is a functional option to enable or disable the plain output mode.
+// By default, the plain mode is used when the standard output (or the file set by WithOutputFile) is not a terminal (e.g. piped to a file, redirected to /dev/null or running under CI),
+// as told by the terminal settings of the file. On the systems without them (e.g. windows), the plain mode is always used, unless a transport is set.
+// In plain mode, the screen is rendered as lines of text, without cursor addressing, and no input is read.
+func WithPlainOutput(enabled bool) Option {
+	return func(c *core) {
+		c.plain = enabled
+		c.plainSet = true
+	}
+}
+
+// plainTerminfo is used in plain mode when the terminal named by $TERM is unknown (e.g. "dumb" or not set at all)
+func plainTerminfo(name string) *info.Term {
+	return &info.Term{Name: name, Columns: defaultColumns, Lines: defaultRows}
+}
+
+// plainCell is what the plain renderer remembers about a pixel
+type plainCell struct {
+	r     rune
+	uni   term.Unicode
+	width int
+	fg    color.Color
+	bg    color.Color
+	attrs style.Mask
+}
+
+// plainScreen holds the content of the screen, which is written line by line when flushed
+type plainScreen struct {
+	cells   []plainCell
+	columns int
+	rows    int
+	dirty   bool
+}
+
+// resize forgets the content and allocates the cells for the new size
+func (s *plainScreen) resize(size *term.Size) {
+	s.columns, s.rows = size.Columns, size.Rows
+	s.cells = make([]plainCell, s.columns*s.rows)
+	s.clear()
+}
+
+// clear fills the screen with blanks
+func (s *plainScreen) clear() {
+	for idx := range s.cells {
+		s.cells[idx] = plainCell{r: ' ', width: 1, fg: color.Default, bg: color.Default}
+	}
+	s.dirty = true
+}
+
+// set remembers the pixels content
+func (s *plainScreen) set(pixels ...term.PixelGetter) {
+	for _, pixel := range pixels {
+		column, row := term.UnHash(pixel.PositionHash())
+		if column < 0 || row < 0 || column >= s.columns || row >= s.rows {
+			continue
+		}
+		cell := &s.cells[row*s.columns+column]
+		cell.r, cell.width = pixel.Rune(), pixel.Width()
+		cell.fg, cell.bg, cell.attrs = pixel.Style()
+		cell.uni = nil
+		if pixel.HasUnicode() {
+			cell.uni = *pixel.Unicode()
+		}
+		s.dirty = true
+	}
+}
+
+// plainStart is the equivalent of internalStart for the plain mode
+func (c *core) plainStart() error {
+	c.tty = c.outputFile()
+	c.out = c.mirrored(c.metered(c.guarded(c.tty)))
+	c.updateSize()
+	return nil
+}
+
+// flushPlain writes the screen content line by line, skipping trailing blanks and empty lines at the end - locked inside caller function
+func (c *core) flushPlain(w io.Writer) {
+	if c.screen == nil || !c.screen.dirty {
+		return
+	}
+	c.screen.dirty = false
+
+	lines := 0
+	for row := 0; row < c.screen.rows; row++ {
+		if c.lineLength(row) > 0 {
+			lines = row + 1
+		}
+	}
+
+	buf := make([]byte, 0, 6)
+	for row := 0; row < lines; row++ {
+		line := c.screen.cells[row*c.screen.columns : (row+1)*c.screen.columns]
+		length := c.lineLength(row)
+		for column := 0; column < length; column++ {
+			cell := line[column]
+			if c.colors > 0 { // only when colors were forced
+				c.putStyle(w, cell.fg, cell.bg, cell.attrs)
+			}
+			r := cell.r
+			if r == 0 {
+				r = ' '
+			}
+			buf = c.encoder.encodeRune(r, buf[:0])
+			for _, u := range cell.uni {
+				buf = c.encoder.encodeRune(u, buf)
+			}
+			if cell.width > 1 {
+				column += cell.width - 1 // the wide rune covers the next pixels
+			}
+			if _, err := w.Write(buf); err != nil {
+				if Debug.Enabled() {
+					Debug.Printf("error writing to io : " + err.Error())
+				}
+				return
+			}
+		}
+		if c.colors > 0 && length > 0 {
+			c.comm.PutAttrOff(w)
+			c.cachedFG, c.cachedBG, c.cachedAttrs = color.Default, color.Default, style.None
+		}
+		if _, err := w.Write([]byte{'\n'}); err != nil {
+			if Debug.Enabled() {
+				Debug.Printf("error writing to io : " + err.Error())
+			}
+			return
+		}
+	}
+}
+
+// lineLength returns the number of pixels up to the last one which is not blank - locked inside caller function
+func (c *core) lineLength(row int) int {
+	line := c.screen.cells[row*c.screen.columns : (row+1)*c.screen.columns]
+	for idx := len(line) - 1; idx >= 0; idx-- {
+		cell := line[idx]
+		if (cell.r != ' ' && cell.r != 0) || len(cell.uni) > 0 {
+			return idx + 1
+		}
+		if c.colors > 0 && (cell.bg != color.Default && cell.bg != color.Reset || cell.attrs&(style.Reverse|style.Underline|style.StrikeThrough) != 0) {
+			return idx + 1 // visible when colors were forced
+		}
+	}
+	return 0
+}
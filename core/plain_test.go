@@ -0,0 +1,83 @@
+package core
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/badu/term"
+)
+
+func TestPlainDetection(t *testing.T) {
+	newBenchCore(t) // registers the terminal, and sets the environment
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("error opening %s : %v", os.DevNull, err)
+	}
+	defer func() { _ = devNull.Close() }()
+	file, err := ioutil.TempFile(t.TempDir(), "out")
+	if err != nil {
+		t.Fatalf("error creating output : %v", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	for _, tc := range []struct {
+		name     string
+		opts     []Option
+		expected bool
+	}{
+		{name: "null device", opts: []Option{WithOutputFile(devNull)}, expected: true}, // a character device, but not a terminal
+		{name: "regular file", opts: []Option{WithOutputFile(file)}, expected: true},
+		{name: "forced", opts: []Option{WithOutputFile(devNull), WithPlainOutput(false)}, expected: false},
+		{name: "transport", opts: []Option{WithOutputFile(devNull), WithTransport(&pipeTransport{Writer: ioutil.Discard})}, expected: false},
+	} {
+		engine, err := NewCore("xterm-256color", append([]Option{WithTerminfo(benchInfo)}, tc.opts...)...)
+		if err != nil {
+			t.Fatalf("error : %s : creating engine : %v", tc.name, err)
+		}
+		if plain := engine.(*core).plain; plain != tc.expected {
+			t.Errorf("error : %s : expecting plain mode %t, got %t", tc.name, tc.expected, plain)
+		}
+	}
+}
+
+func TestPlainOutput(t *testing.T) {
+	newBenchCore(t) // registers the terminal, and sets the environment
+	out, err := ioutil.TempFile(t.TempDir(), "out")
+	if err != nil {
+		t.Fatalf("error creating output : %v", err)
+	}
+	defer func() { _ = out.Close() }()
+
+	engine, err := NewCore("xterm-256color", WithTerminfo(benchInfo), WithOutputFile(out))
+	if err != nil {
+		t.Fatalf("error creating engine : %v", err)
+	}
+	c := engine.(*core)
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("error starting : %v", err)
+	}
+	c.Redraw([]term.PixelGetter{
+		&regionPixel{hash: term.Hash(0, 0), r: 'a'},
+		&regionPixel{hash: term.Hash(2, 0), r: 'b'},
+		&regionPixel{hash: term.Hash(1, 2), r: 'c'},
+	})
+	cancel()
+	select {
+	case <-c.DyingChan():
+	case <-time.After(time.Second):
+		t.Fatalf("error : the engine should stop")
+	}
+
+	content, err := ioutil.ReadFile(out.Name())
+	if err != nil {
+		t.Fatalf("error reading output : %v", err)
+	}
+	// lines of text, without escape sequences
+	if expected := "a b\n\n c\n"; string(content) != expected {
+		t.Errorf("error : expecting %q, got %q", expected, content)
+	}
+}
@@ -0,0 +1,47 @@
+package core
+
+const (
+	pointerShapeStart = "\x1b]22;" // OSC 22 : sets the mouse pointer shape, e.g. ESC ] 22 ; pointer ESC \
+	pointerShapeEnd   = "\x1b\\"   //
+	pointerDefault    = "default"  // restored on shutdown
+)
+
+// validPointerShape accepts the CSS cursor names and X11 cursor font names, so no escape sequence can be injected
+func validPointerShape(shape string) bool {
+	if len(shape) == 0 || len(shape) > 64 {
+		return false
+	}
+	for _, r := range shape {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// SetPointerShape implements term.PointerShaper interface
+func (c *core) SetPointerShape(shape string) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.plain || c.out == nil || !validPointerShape(shape) {
+		return false
+	}
+	if c.pointerShape == shape {
+		return true
+	}
+	c.comm.WriteString(c.out, pointerShapeStart+shape+pointerShapeEnd)
+	c.pointerShape = shape
+	return true
+}
+
+// resetPointerShape restores the default pointer, if it was changed - locked inside caller function
+func (c *core) resetPointerShape() {
+	if c.pointerShape == "" || c.pointerShape == pointerDefault {
+		return
+	}
+	c.comm.WriteString(c.out, pointerShapeStart+pointerDefault+pointerShapeEnd)
+	c.pointerShape = ""
+}
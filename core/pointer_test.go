@@ -0,0 +1,59 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+)
+
+func TestSetPointerShape(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+
+	for _, tc := range []struct {
+		name     string
+		shape    string
+		ok       bool
+		expected string
+	}{
+		{name: "hand", shape: term.PointerHand, ok: true, expected: "\x1b]22;pointer\x1b\\"},
+		{name: "same shape", shape: term.PointerHand, ok: true, expected: ""},
+		{name: "resize", shape: term.PointerEWResize, ok: true, expected: "\x1b]22;ew-resize\x1b\\"},
+		{name: "x11 name", shape: "left_ptr", ok: true, expected: "\x1b]22;left_ptr\x1b\\"},
+		{name: "empty", shape: "", ok: false, expected: ""},
+		{name: "escape injected", shape: "hand\x1b\\\x1b]0;title", ok: false, expected: ""},
+		{name: "too long", shape: strings.Repeat("a", 65), ok: false, expected: ""},
+	} {
+		if ok := c.SetPointerShape(tc.shape); ok != tc.ok {
+			t.Errorf("error : %s : expecting %t, got %t", tc.name, tc.ok, ok)
+		}
+		if out := written(); out != tc.expected {
+			t.Errorf("error : %s : expecting %q, got %q", tc.name, tc.expected, out)
+		}
+	}
+
+	// the default pointer is restored once
+	c.resetPointerShape()
+	if out, expected := written(), "\x1b]22;default\x1b\\"; out != expected {
+		t.Errorf("error : expecting %q on reset, got %q", expected, out)
+	}
+	c.resetPointerShape()
+	if out := written(); out != "" {
+		t.Errorf("error : the pointer was already restored, got %q", out)
+	}
+
+	// nothing to restore, unless the shape was changed
+	c.SetPointerShape(term.PointerDefault)
+	written()
+	c.resetPointerShape()
+	if out := written(); out != "" {
+		t.Errorf("error : the default pointer doesn't need a reset, got %q", out)
+	}
+
+	// nor in plain mode
+	c.plain = true
+	if c.SetPointerShape(term.PointerHand) {
+		t.Errorf("error : the pointer shape can't be set in plain mode")
+	}
+}
@@ -0,0 +1,228 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/badu/term"
+)
+
+const (
+	enableBracketedPaste  = "\x1b[?2004h" // DEC private mode 2004 : the terminal wraps the pasted text between pasteStart and pasteEnd
+	disableBracketedPaste = "\x1b[?2004l" //
+	enableFocusReports    = "\x1b[?1004h" // DEC private mode 1004 : the terminal reports when its window gains (focusIn) or loses (focusOut) focus
+	disableFocusReports   = "\x1b[?1004l" //
+)
+
+const (
+	pasteStart = "\x1b[200~" //
+	pasteEnd   = "\x1b[201~" //
+	focusIn    = "\x1b[I"    //
+	focusOut   = "\x1b[O"    //
+
+	maxPaste      = 64 * 1024 // pastes longer than this are given up, and passed to the key dispatcher
+	pollQueueSize = 64        // events which are queued while the application doesn't poll, the oldest ones being dropped when it's full
+)
+
+// WithBracketedPaste is a functional option for enabling the bracketed paste mode : the pasted text is delivered by PollEvent as a single term.PasteEvent, instead of key events.
+// Note that the listeners model doesn't receive paste events, so only the programs which use PollEvent should enable it. Default is disabled.
+func WithBracketedPaste(enabled bool) Option {
+	return func(c *core) {
+		c.bracketedPaste = enabled
+	}
+}
+
+// WithFocusReports is a functional option for enabling the focus reports : PollEvent delivers a term.FocusEvent when the terminal window gains or loses focus. Default is disabled.
+func WithFocusReports(enabled bool) Option {
+	return func(c *core) {
+		c.focusReports = enabled
+	}
+}
+
+// EventPaste is delivered by PollEvent when the user pastes text, if the bracketed paste mode is enabled
+type EventPaste struct {
+	text string
+	when time.Time
+}
+
+// Text implements term.PasteEvent interface
+func (e *EventPaste) Text() string {
+	return e.text
+}
+
+// When implements term.Event interface
+func (e *EventPaste) When() time.Time {
+	return e.when
+}
+
+// EventFocus is delivered by PollEvent when the terminal window gains or loses focus, if the focus reports are enabled
+type EventFocus struct {
+	focused bool
+	when    time.Time
+}
+
+// Focused implements term.FocusEvent interface
+func (e *EventFocus) Focused() bool {
+	return e.focused
+}
+
+// When implements term.Event interface
+func (e *EventFocus) When() time.Time {
+	return e.when
+}
+
+// poller listens all dispatchers, queueing their events for PollEvent
+type poller struct {
+	sync.Once                        // required for registering to dispatchers exactly once
+	ctx        context.Context       // the engine context, set on first poll
+	events     chan term.Event       // the queue read by PollEvent
+	keys       chan term.KeyEvent    //
+	mouse      chan term.MouseEvent  //
+	resize     chan term.ResizeEvent //
+	died       chan struct{}         // closed when the engine context is done, so the dispatchers forget us
+	activeLock sync.Mutex            // guards active
+	active     bool                  // true after the first poll : until then, paste and focus events are dropped
+}
+
+func newPoller() *poller {
+	return &poller{
+		events: make(chan term.Event, pollQueueSize),
+		keys:   make(chan term.KeyEvent),
+		mouse:  make(chan term.MouseEvent),
+		resize: make(chan term.ResizeEvent),
+		died:   make(chan struct{}),
+	}
+}
+
+// KeyListen implements term.KeyListener interface
+func (p *poller) KeyListen() chan term.KeyEvent {
+	return p.keys
+}
+
+// MouseListen implements term.MouseListener interface
+func (p *poller) MouseListen() chan term.MouseEvent {
+	return p.mouse
+}
+
+// ResizeListen implements term.ResizeListener interface
+func (p *poller) ResizeListen() chan term.ResizeEvent {
+	return p.resize
+}
+
+// DyingChan implements term.Death interface
+func (p *poller) DyingChan() chan struct{} {
+	return p.died
+}
+
+// start registers the poller with the dispatchers of the engine, on the first poll
+func (p *poller) start(c *core) {
+	p.Do(func() {
+		c.Lock()
+		p.ctx = c.ctx
+		withMouse := c.comm.HasMouse && !c.plain
+		c.Unlock()
+
+		c.keyDispatcher.Register(p)
+		if withMouse {
+			c.mouseDispatcher.Register(p)
+		}
+		c.Register(p)
+		// the initial resize event was dispatched on start, before we were listening
+		p.queue(newResizeEvent(c.Size(), c.Margins()))
+
+		p.activeLock.Lock()
+		p.active = true
+		p.activeLock.Unlock()
+
+		go func() {
+			defer close(p.died)
+			for {
+				var ev term.Event
+				select {
+				case <-p.ctx.Done():
+					return
+				case ev = <-p.keys:
+				case ev = <-p.mouse:
+				case ev = <-p.resize:
+				}
+				p.queue(ev)
+			}
+		}()
+	})
+}
+
+// queue adds the event to the ones read by PollEvent, without blocking : when the application doesn't poll anymore, the oldest events are dropped,
+// so the dispatchers and the input reader keep going
+func (p *poller) queue(ev term.Event) {
+	for {
+		select {
+		case p.events <- ev:
+			return
+		default:
+		}
+		select {
+		case dropped := <-p.events:
+			if Debug.Enabled() {
+				Debug.Printf("poll queue is full, dropping %T", dropped)
+			}
+		default: // read by PollEvent meanwhile
+		}
+	}
+}
+
+// push queues an event which doesn't have a dispatcher (paste, focus), if someone is polling
+func (p *poller) push(ev term.Event) {
+	p.activeLock.Lock()
+	active := p.active
+	p.activeLock.Unlock()
+	if !active {
+		return
+	}
+	p.queue(ev)
+}
+
+// parsePaste handles the bracketed paste, returning the number of bytes consumed or -1 if the end marker hasn't arrived yet
+func (p *poller) parsePaste(report []byte) int {
+	end := bytes.Index(report, []byte(pasteEnd))
+	if end < 0 {
+		return -1
+	}
+	p.push(&EventPaste{text: string(report[len(pasteStart):end]), when: time.Now()})
+	return end + len(pasteEnd)
+}
+
+// parseFocus handles the focus reports
+func (p *poller) parseFocus(report []byte) int {
+	p.push(&EventFocus{focused: report[2] == 'I', when: time.Now()})
+	return len(focusIn)
+}
+
+// PollEvent implements the term.Engine interface, waiting for the next key, mouse, resize, paste or focus event.
+// The first call registers the poller with the dispatchers : its first event is a resize one, having the current size.
+// From then on, the events are queued while the application doesn't poll (up to 64 of them, the oldest ones being dropped), without holding the listeners nor the input.
+// It returns the error of the context, when either the given one or the one passed to Start is done, and the error of the terminal (see TerminalErr) once it's lost.
+func (c *core) PollEvent(ctx context.Context) (term.Event, error) {
+	c.Lock()
+	engineCtx := c.ctx
+	c.Unlock()
+	if engineCtx == nil {
+		return nil, ErrNotStarted
+	}
+
+	c.poller.start(c)
+	select {
+	case ev := <-c.poller.events:
+		return ev, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-engineCtx.Done():
+		if err := c.lost.failure(); err != nil {
+			return nil, err // stopped by itself
+		}
+		return nil, engineCtx.Err()
+	case <-c.lost.done:
+		return nil, c.lost.failure()
+	}
+}
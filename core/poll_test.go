@@ -0,0 +1,132 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/badu/term"
+)
+
+// keyListener receives the key events of the engine
+type keyListener struct {
+	ch   chan term.KeyEvent
+	died chan struct{}
+}
+
+func (l *keyListener) KeyListen() chan term.KeyEvent { return l.ch }
+func (l *keyListener) DyingChan() chan struct{}      { return l.died }
+
+// startPolled starts the engine on a transport, returning the writer of its input
+func startPolled(t *testing.T, opts ...Option) (*core, *io.PipeWriter) {
+	t.Helper()
+	in, typed := io.Pipe()
+	c := newBenchCore(t, append(opts, WithTransport(&pipeTransport{PipeReader: in, Writer: ioutil.Discard}))...)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("error starting : %v", err)
+	}
+	return c, typed
+}
+
+// pollEvent returns the next event, failing the test if there is none
+func pollEvent(t *testing.T, c *core) term.Event {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	ev, err := c.PollEvent(ctx)
+	if err != nil {
+		t.Fatalf("error polling : %v", err)
+	}
+	return ev
+}
+
+func TestPollEvent(t *testing.T) {
+	newBenchCore(t)
+	stopped, err := NewCore("xterm-256color", WithTerminfo(benchInfo), WithPlainOutput(false))
+	if err != nil {
+		t.Fatalf("error creating engine : %v", err)
+	}
+	if _, err := stopped.PollEvent(context.Background()); !errors.Is(err, ErrNotStarted) {
+		t.Errorf("error : polling before start should fail, got %v", err)
+	}
+
+	c, typed := startPolled(t)
+	if ev, ok := pollEvent(t, c).(term.ResizeEvent); !ok || ev.Size().Columns != benchColumns || ev.Size().Rows != benchRows {
+		t.Fatalf("error : the first event should be a resize one, having the current size")
+	}
+	if _, err := typed.Write([]byte("a")); err != nil {
+		t.Fatalf("error typing : %v", err)
+	}
+	if ev, ok := pollEvent(t, c).(term.KeyEvent); !ok || ev.Name() != "Rune[a]" {
+		t.Errorf("error : expecting the key typed, got %v", ev)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.PollEvent(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("error : the error of the context should be returned, got %v", err)
+	}
+}
+
+func TestPollPasteAndFocus(t *testing.T) {
+	c, typed := startPolled(t, WithBracketedPaste(true), WithFocusReports(true))
+	pollEvent(t, c) // the initial resize
+
+	if _, err := typed.Write([]byte(pasteStart + "hello\x03" + pasteEnd + focusOut)); err != nil {
+		t.Fatalf("error typing : %v", err)
+	}
+	if ev, ok := pollEvent(t, c).(term.PasteEvent); !ok || ev.Text() != "hello\x03" {
+		t.Errorf("error : expecting the pasted text, got %v", ev)
+	}
+	if ev, ok := pollEvent(t, c).(term.FocusEvent); !ok || ev.Focused() {
+		t.Errorf("error : expecting the focus lost, got %v", ev)
+	}
+	select {
+	case <-c.InterruptChan():
+		t.Errorf("error : the pasted Ctrl+C is text, not an interrupt")
+	default:
+	}
+}
+
+func TestPollNotStalling(t *testing.T) {
+	c, typed := startPolled(t, WithBracketedPaste(true))
+	pollEvent(t, c) // registers the poller, which isn't polled anymore
+
+	listener := &keyListener{ch: make(chan term.KeyEvent, 4*pollQueueSize), died: make(chan struct{})}
+	defer close(listener.died)
+	c.KeyDispatcher().Register(listener)
+	const keys = 2 * pollQueueSize
+	for i := 0; i < keys; i++ {
+		if _, err := typed.Write([]byte("a")); err != nil {
+			t.Fatalf("error typing : %v", err)
+		}
+	}
+	if _, err := typed.Write([]byte(pasteStart + "text" + pasteEnd + "z")); err != nil {
+		t.Fatalf("error typing : %v", err)
+	}
+	for i := 0; i <= keys; i++ {
+		select {
+		case <-listener.ch:
+		case <-time.After(time.Second):
+			t.Fatalf("error : the dispatcher stalled after %d keys, since the application doesn't poll", i)
+		}
+	}
+
+	// the newest events were kept, the oldest ones being dropped
+	polled := 0
+	for {
+		ev := pollEvent(t, c)
+		polled++
+		if key, ok := ev.(term.KeyEvent); ok && key.Name() == "Rune[z]" {
+			break
+		}
+	}
+	if polled > pollQueueSize+1 { // one might have been waiting in the queue goroutine
+		t.Errorf("error : the oldest events should be dropped, got %d", polled)
+	}
+}
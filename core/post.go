@@ -0,0 +1,68 @@
+package core
+
+import (
+	"context"
+	"sync"
+)
+
+// jobs queues the functions posted by the background workers, run by a single goroutine of the engine
+type jobs struct {
+	sync.Mutex               // guards other properties
+	queue      []func()      // the functions waiting to be run
+	signal     chan struct{} // notified when the queue is no longer empty
+}
+
+// newJobs
+func newJobs() *jobs {
+	return &jobs{signal: make(chan struct{}, 1)}
+}
+
+// post appends the function to the queue. It never blocks, so the posted functions can post others.
+func (j *jobs) post(fn func()) {
+	j.Lock()
+	j.queue = append(j.queue, fn)
+	j.Unlock()
+
+	select {
+	case j.signal <- struct{}{}:
+	default: // already notified
+	}
+}
+
+// run executes the queued functions, one at a time, until the context is done.
+// Each function runs holding the locker (the engine), so it doesn't interleave with drawing : the pixels it changes are drawn after it returns.
+func (j *jobs) run(ctx context.Context, locker sync.Locker) {
+	for {
+		select {
+		case <-ctx.Done():
+			if Debug.Enabled() {
+				Debug.Println("context done - exiting posted functions runner")
+			}
+			return
+		case <-j.signal:
+		}
+		j.Lock()
+		queue := j.queue
+		j.queue = nil
+		j.Unlock()
+
+		for _, fn := range queue {
+			select {
+			case <-ctx.Done():
+				return // dropping the rest
+			default:
+			}
+			locker.Lock()
+			fn()
+			locker.Unlock()
+		}
+	}
+}
+
+// Post implements term.Poster interface
+func (c *core) Post(fn func()) {
+	if fn == nil {
+		return
+	}
+	c.jobs.post(fn)
+}
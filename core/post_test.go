@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/badu/term"
+)
+
+func TestPost(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	j := newJobs()
+
+	var (
+		mu    sync.Mutex
+		order []int
+	)
+	done := make(chan struct{})
+	record := func(n int) func() {
+		return func() {
+			mu.Lock()
+			order = append(order, n)
+			mu.Unlock()
+		}
+	}
+	// posted before the runner starts, like before Start
+	j.post(record(1))
+	j.post(func() {
+		record(2)()
+		j.post(func() { // posting from a posted function doesn't block
+			record(4)()
+			close(done)
+		})
+	})
+	j.post(record(3))
+	go j.run(ctx, &sync.Mutex{})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("error : posted functions were not run")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(order) != 4 {
+		t.Fatalf("error : expecting four runs, got %v", order)
+	}
+	for idx, n := range order {
+		if n != idx+1 {
+			t.Errorf("error : functions should run in the order they were posted, got %v", order)
+			break
+		}
+	}
+}
+
+func TestPostLocked(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+	pixel := &movingPixel{hash: term.Hash(0, 0), r: 'a', drawCh: make(chan term.PixelGetter, 1)}
+	c.ActivePixels([]term.PixelGetter{pixel})
+	written()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.jobs.run(ctx, c)
+
+	drawn := make(chan string, 1)
+	c.Post(func() {
+		pixel.set(term.Hash(0, 0), 'x')
+		time.Sleep(20 * time.Millisecond) // the draw goroutine would have written by now, unless it waits for the engine
+		drawn <- written()
+	})
+	select {
+	case out := <-drawn:
+		if strings.Contains(out, "x") {
+			t.Errorf("error : the pixel should not be drawn while the posted function runs, got %q", out)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("error : the posted function was not run")
+	}
+	if out := waitFor(t, c, written, "x"); !strings.Contains(out, "x") {
+		t.Errorf("error : the pixel should be drawn after the posted function returns, got %q", out)
+	}
+}
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+// printer implements term.Printer, with a core which is configured but never started
+type printer struct {
+	sync.Mutex           // guards other properties
+	c          *core     // holds the Commander, the palette and the style cache
+	w          io.Writer //
+}
+
+// NewPrinter returns a term.Printer writing to w, for the terminal named by termEnv (usually $TERM). Unlike NewCore, nothing is written on creation, the terminal settings are left alone and no input is read.
+// The options configuring the terminal definition and the colors apply (e.g. WithTerminfo, WithCapabilityOverrides, WithTrueColor, WithColorMatcher, WithBlinkPolicy, WithAttributeFallbacks), the others are ignored.
+// When w is not a terminal (e.g. piped to a file), the text is written without styles, unless the colors are forced by FORCE_COLOR or CLICOLOR_FORCE - the terminal doesn't have to be known either.
+func NewPrinter(w io.Writer, termEnv string, options ...Option) (term.Printer, error) {
+	c := &core{
+		cachedBG:     color.Default,
+		cachedFG:     color.Default,
+		cachedAttrs:  style.None,
+		blinkPolicy:  AllowBlink,
+		fallbackTerm: defaultFallbackTerm,
+	}
+	for _, o := range options {
+		o(c)
+	}
+	if !c.plainSet {
+		f, ok := w.(*os.File)
+		c.plain = !ok || !isTTY(f)
+	}
+	if _, err := c.setupTerminal(termEnv); err != nil {
+		return nil, err
+	}
+	return &printer{c: c, w: w}, nil
+}
+
+// Print implements term.Printer interface
+func (p *printer) Print(st style.Style, text string) error {
+	p.Lock()
+	defer p.Unlock()
+
+	return p.print(st, text)
+}
+
+// Printf implements term.Printer interface
+func (p *printer) Printf(st style.Style, format string, args ...interface{}) error {
+	p.Lock()
+	defer p.Unlock()
+
+	return p.print(st, fmt.Sprintf(format, args...))
+}
+
+// Println implements term.Printer interface
+func (p *printer) Println(st style.Style, text string) error {
+	p.Lock()
+	defer p.Unlock()
+
+	if err := p.print(st, text); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(p.w, "\n"); err != nil {
+		return &term.WriteError{Err: err}
+	}
+	return nil
+}
+
+// ColorProfile implements term.Printer interface
+func (p *printer) ColorProfile() term.ColorProfile {
+	p.Lock()
+	defer p.Unlock()
+
+	return p.c.profile
+}
+
+// print writes the text in the style, then turns the style off - locked inside caller function
+func (p *printer) print(st style.Style, text string) error {
+	c := p.c
+	buf := bytes.NewBuffer(nil)
+	styled := !c.plain || c.colors > 0 // in plain mode, only when colors were forced
+	if styled {
+		fg, bg, attrs := st.Expand()
+		c.putStyle(buf, fg, bg, attrs)
+	}
+	buf.WriteString(text)
+	if styled && (c.cachedFG != color.Default || c.cachedBG != color.Default || c.cachedAttrs != style.None) {
+		c.comm.PutAttrOff(buf)
+		c.cachedFG, c.cachedBG, c.cachedAttrs = color.Default, color.Default, style.None
+	}
+	if _, err := buf.WriteTo(p.w); err != nil {
+		return &term.WriteError{Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/info"
+	"github.com/badu/term/style"
+)
+
+func TestPrinter(t *testing.T) {
+	c := newBenchCore(t) // registers the terminal, and sets the environment
+	ti := *benchInfo
+	info.AddTrueColor(&ti, true)
+	buf := &bytes.Buffer{}
+	p, err := NewPrinter(buf, "xterm-256color", WithTerminfo(&ti), WithPlainOutput(false))
+	if err != nil {
+		t.Fatalf("error creating printer : %v", err)
+	}
+	if p.ColorProfile() != term.ProfileTrueColor {
+		t.Errorf("error : expecting the true color profile, got %v", p.ColorProfile())
+	}
+
+	if err := p.Print(style.Style{Fg: color.NewRGBColor(0x10, 0x20, 0x30), Attrs: style.Bold}, "bold"); err != nil {
+		t.Fatalf("error printing : %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "38;2;16;32;48") || !strings.Contains(out, c.comm.Bold+"bold") || !strings.HasSuffix(out, c.comm.AttrOff) {
+		t.Errorf("error : expecting the colored bold text, followed by the style turned off, got %q", out)
+	}
+
+	buf.Reset()
+	if err := p.Println(style.Style{}, "plain"); err != nil || buf.String() != "plain\n" {
+		t.Errorf("error : the default style should write the text only, got %q (%v)", buf.String(), err)
+	}
+}
+
+func TestPrinterDownsampling(t *testing.T) {
+	newBenchCore(t)
+	buf := &bytes.Buffer{}
+	p, err := NewPrinter(buf, "xterm-256color", WithTerminfo(benchInfo), WithPlainOutput(false))
+	if err != nil {
+		t.Fatalf("error creating printer : %v", err)
+	}
+	if err := p.Printf(style.Style{Fg: color.NewRGBColor(0x10, 0x20, 0x30)}, "%d", 42); err != nil {
+		t.Fatalf("error printing : %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, "38;5;") || strings.Contains(out, "38;2;") || !strings.Contains(out, "42") {
+		t.Errorf("error : the RGB color should be downsampled to the palette, got %q", out)
+	}
+}
+
+func TestPrinterNotTerminal(t *testing.T) {
+	newBenchCore(t)
+	buf := &bytes.Buffer{}
+	p, err := NewPrinter(buf, "unknown-terminal")
+	if err != nil {
+		t.Fatalf("error creating printer : %v", err)
+	}
+	if err := p.Print(style.Style{Fg: color.Red, Attrs: style.Bold}, "text"); err != nil || buf.String() != "text" {
+		t.Errorf("error : the styles should be dropped when the output is not a terminal, got %q (%v)", buf.String(), err)
+	}
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("error opening %s : %v", os.DevNull, err)
+	}
+	defer func() { _ = devNull.Close() }()
+	p, err = NewPrinter(devNull, "xterm-256color", WithTerminfo(benchInfo))
+	if err != nil {
+		t.Fatalf("error creating printer : %v", err)
+	}
+	if !p.(*printer).c.plain {
+		t.Errorf("error : the null device is not a terminal")
+	}
+
+	setEnv(t, "FORCE_COLOR", "1")
+	buf.Reset()
+	p, err = NewPrinter(buf, "xterm-256color", WithTerminfo(benchInfo))
+	if err != nil {
+		t.Fatalf("error creating printer : %v", err)
+	}
+	if err := p.Print(style.Style{Fg: color.Red}, "text"); err != nil || !strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("error : the forced colors should be written, got %q (%v)", buf.String(), err)
+	}
+}
+
+func TestPrinterWriteError(t *testing.T) {
+	newBenchCore(t)
+	p, err := NewPrinter(&failingWriter{}, "xterm-256color", WithTerminfo(benchInfo))
+	if err != nil {
+		t.Fatalf("error creating printer : %v", err)
+	}
+	var writeErr *term.WriteError
+	if err := p.Print(style.Style{}, "text"); !errors.As(err, &writeErr) {
+		t.Errorf("error : expecting a WriteError, got %v", err)
+	}
+}
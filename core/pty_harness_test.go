@@ -0,0 +1,508 @@
+//go:build linux || freebsd || netbsd || openbsd || dragonfly || solaris || illumos || darwin
+// +build linux freebsd netbsd openbsd dragonfly solaris illumos darwin
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/badu/term"
+	"github.com/badu/term/key"
+	"github.com/badu/term/mouse"
+	"golang.org/x/sys/unix"
+)
+
+// harnessTimeout is how long the harness waits for the engine
+const harnessTimeout = 2 * time.Second
+
+// ptyHarness runs the real engine on a pseudo terminal : the engine opens the slave side as its controlling terminal, while the test plays the terminal emulator on the master side,
+// typing the input and recording everything the engine writes
+type ptyHarness struct {
+	sync.Mutex                    // guards other properties
+	t          *testing.T         //
+	master     *os.File           // the terminal emulator side
+	c          *core              // started on the slave side
+	cancel     context.CancelFunc // stops the engine
+	output     bytes.Buffer       // everything written by the engine
+	read       int                // the part of the output already returned by waitOutput
+	changed    chan struct{}      // closed and replaced each time output arrives
+	drained    chan struct{}      // closed when drain returns
+}
+
+// startPTY starts the engine on a new pseudo terminal of that size, stopping it when the test ends
+func startPTY(t *testing.T, columns, rows int, opts ...Option) *ptyHarness {
+	t.Helper()
+	h, slave := newPTY(t, columns, rows)
+	h.start(slave, opts...)
+	return h
+}
+
+// newPTY opens a new pseudo terminal of that size, returning the harness and the path of the slave side, for the tests which need it before start
+func newPTY(t *testing.T, columns, rows int) (*ptyHarness, string) {
+	t.Helper()
+	master, slave := openPTY(t)
+	h := &ptyHarness{t: t, master: master, changed: make(chan struct{}), drained: make(chan struct{})}
+	h.setSize(columns, rows)
+	go h.drain()
+	return h, slave
+}
+
+// start runs the engine on the slave side, stopping it when the test ends
+func (h *ptyHarness) start(slave string, opts ...Option) {
+	h.t.Helper()
+	h.c = newBenchCore(h.t, opts...)
+	h.c.ttyPath = slave
+	h.c.size = nil // read from the terminal
+	var ctx context.Context
+	ctx, h.cancel = context.WithCancel(context.Background())
+	if err := h.c.Start(ctx); err != nil {
+		h.t.Fatalf("error starting on %s : %v", slave, err)
+	}
+	h.t.Cleanup(h.stop)
+}
+
+// drain records the output, so the engine never blocks on a full terminal buffer
+func (h *ptyHarness) drain() {
+	defer close(h.drained)
+	buf := make([]byte, 4096)
+	for {
+		n, err := h.master.Read(buf)
+		if n > 0 {
+			h.Lock()
+			h.output.Write(buf[:n])
+			close(h.changed)
+			h.changed = make(chan struct{})
+			h.Unlock()
+		}
+		if err != nil {
+			return // closed by the cleanup of openPTY, or stopped by hangup
+		}
+	}
+}
+
+// hangup closes the master side, as a terminal emulator going away : drain is stopped first, since a pending read keeps the master open
+func (h *ptyHarness) hangup() {
+	h.t.Helper()
+	if err := h.master.SetReadDeadline(time.Now()); err != nil {
+		h.t.Fatalf("error stopping the drain : %v", err)
+	}
+	<-h.drained
+	if err := h.master.Close(); err != nil {
+		h.t.Fatalf("error closing the master side : %v", err)
+	}
+}
+
+// ioctl runs the request on the descriptor of the file, without Fd, which would put the file in blocking mode (and the reads of drain couldn't be stopped anymore)
+func ioctl(t *testing.T, f *os.File, request func(fd int) error) {
+	t.Helper()
+	conn, err := f.SyscallConn()
+	if err != nil {
+		t.Fatalf("error reaching the descriptor : %v", err)
+	}
+	var reqErr error
+	if err := conn.Control(func(fd uintptr) { reqErr = request(int(fd)) }); err != nil {
+		t.Fatalf("error reaching the descriptor : %v", err)
+	}
+	if reqErr != nil {
+		t.Fatalf("error on the pseudo terminal : %v", reqErr)
+	}
+}
+
+// getAttr reads the termios of the terminal side
+func getAttr(t *testing.T, f *os.File) *unix.Termios {
+	t.Helper()
+	var result *unix.Termios
+	ioctl(t, f, func(fd int) error {
+		var err error
+		result, err = unix.IoctlGetTermios(fd, getTermios)
+		return err
+	})
+	return result
+}
+
+// send types the input, as the user would
+func (h *ptyHarness) send(in string) {
+	h.t.Helper()
+	if _, err := h.master.Write([]byte(in)); err != nil {
+		h.t.Fatalf("error typing %q : %v", in, err)
+	}
+}
+
+// setSize changes the size of the pseudo terminal
+func (h *ptyHarness) setSize(columns, rows int) {
+	h.t.Helper()
+	ioctl(h.t, h.master, func(fd int) error {
+		return unix.IoctlSetWinsize(fd, unix.TIOCSWINSZ, &unix.Winsize{Col: uint16(columns), Row: uint16(rows)})
+	})
+}
+
+// resize changes the size of the pseudo terminal, then signals it as the kernel does to the foreground process group, which the test process is not
+func (h *ptyHarness) resize(columns, rows int) {
+	h.t.Helper()
+	h.setSize(columns, rows)
+	if err := syscall.Kill(os.Getpid(), syscall.SIGWINCH); err != nil {
+		h.t.Fatalf("error signaling the resize : %v", err)
+	}
+}
+
+// waitOutput waits until the engine has written all the sequences since the previous call, returning what was written
+func (h *ptyHarness) waitOutput(sequences ...string) string {
+	h.t.Helper()
+	deadline := time.After(harnessTimeout)
+	for {
+		h.Lock()
+		out, changed := h.output.String()[h.read:], h.changed
+		h.Unlock()
+		found := true
+		for _, seq := range sequences {
+			if !strings.Contains(out, seq) {
+				found = false
+				break
+			}
+		}
+		if found {
+			h.Lock()
+			h.read += len(out)
+			h.Unlock()
+			return out
+		}
+		select {
+		case <-changed:
+		case <-deadline:
+			h.t.Fatalf("error : waited for %q, the engine wrote %q", sequences, out)
+			return out
+		}
+	}
+}
+
+// stop cancels the engine and waits for its shutdown
+func (h *ptyHarness) stop() {
+	h.cancel()
+	select {
+	case <-h.c.DyingChan():
+	case <-time.After(harnessTimeout):
+		h.t.Errorf("error : the engine didn't shut down")
+	}
+}
+
+// harnessListener receives the events of the dispatchers
+type harnessListener struct {
+	keys    chan term.KeyEvent    //
+	mice    chan term.MouseEvent  //
+	resizes chan term.ResizeEvent //
+	died    chan struct{}         //
+}
+
+func newHarnessListener(t *testing.T) *harnessListener {
+	res := &harnessListener{keys: make(chan term.KeyEvent, 8), mice: make(chan term.MouseEvent, 8), resizes: make(chan term.ResizeEvent, 8), died: make(chan struct{})}
+	t.Cleanup(func() { close(res.died) })
+	return res
+}
+
+func (l *harnessListener) KeyListen() chan term.KeyEvent       { return l.keys }
+func (l *harnessListener) MouseListen() chan term.MouseEvent   { return l.mice }
+func (l *harnessListener) ResizeListen() chan term.ResizeEvent { return l.resizes }
+func (l *harnessListener) DyingChan() chan struct{}            { return l.died }
+
+func TestPTYEngine(t *testing.T) {
+	h := startPTY(t, 120, 40)
+	c := h.c
+
+	// the terminal is set up : raw mode, alternate screen, hidden cursor, mouse reports
+	h.waitOutput(c.comm.EnterCA, c.comm.HideCursor, c.comm.Clear, c.comm.EnableMouse)
+	tio := getAttr(t, h.master)
+	if tio.Lflag&(unix.ICANON|unix.ECHO) != 0 {
+		t.Errorf("error : the terminal should be in raw mode")
+	}
+	if size := c.Size(); size.Columns != 120 || size.Rows != 40 {
+		t.Errorf("error : the size should be read from the terminal, got %d x %d", size.Columns, size.Rows)
+	}
+
+	listener := newHarnessListener(t)
+	c.KeyDispatcher().Register(listener)
+	c.MouseDispatcher().Register(listener)
+	c.ResizeDispatcher().Register(listener)
+
+	// the escape sequences typed are decoded into events
+	h.send("a" + benchInfo.KeyUp)
+	for _, expected := range []key.Binding{{Key: key.Rune, Rune: 'a'}, {Key: key.Up}} {
+		select {
+		case ev := <-listener.keys:
+			if !expected.Matches(ev) {
+				t.Errorf("error : expecting %s, got %s", expected, ev.Name())
+			}
+		case <-time.After(harnessTimeout):
+			t.Fatalf("error : %s was not received", expected)
+		}
+	}
+	h.send("\x1b[<0;5;3M")
+	select {
+	case ev := <-listener.mice:
+		if column, row := ev.Position(); column != 4 || row != 2 || ev.Buttons() != mouse.Button1 {
+			t.Errorf("error : expecting a click at 4,2, got %v at %d,%d", ev.Buttons(), column, row)
+		}
+	case <-time.After(harnessTimeout):
+		t.Fatalf("error : the click was not received")
+	}
+
+	// the resize is read from the terminal, when signaled
+	h.resize(100, 30)
+	select {
+	case ev := <-listener.resizes:
+		if size := ev.Size(); size.Columns != 100 || size.Rows != 30 {
+			t.Errorf("error : expecting a resize to 100 x 30, got %d x %d", size.Columns, size.Rows)
+		}
+	case <-time.After(harnessTimeout):
+		t.Fatalf("error : the resize was not dispatched")
+	}
+
+	// the pixels are drawn where they belong
+	c.Redraw([]term.PixelGetter{&regionPixel{hash: term.Hash(2, 1), r: 'x'}})
+	h.waitOutput("\x1b[2;3Hx")
+
+	// the shutdown restores the terminal
+	h.stop()
+	h.waitOutput(c.comm.DisableMouse, c.comm.ExitCA)
+	tio = getAttr(t, h.master)
+	if tio.Lflag&unix.ICANON == 0 {
+		t.Errorf("error : the terminal settings should be restored")
+	}
+}
+
+func TestPTYHangup(t *testing.T) {
+	h := startPTY(t, 80, 24)
+	c := h.c
+	h.waitOutput(c.comm.EnterCA)
+
+	// the terminal emulator goes away, as when the ssh connection drops : the reads of the slave side fail
+	h.hangup()
+	<-c.DyingChan() // bounded by the timeout of go test
+	ev := c.Termination()
+	if ev == nil {
+		t.Fatalf("error : the termination should be reported")
+	}
+	if !errors.Is(ev.Reason(), term.ErrInputClosed) {
+		t.Errorf("error : the termination should tell the input is gone, got %v", ev.Reason())
+	}
+}
+
+func TestPTYOutputFile(t *testing.T) {
+	h, slave := newPTY(t, 80, 24)
+	out, err := os.OpenFile(slave, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("error opening %s : %v", slave, err)
+	}
+	defer func() { _ = out.Close() }()
+	h.start(slave, WithOutputFile(out))
+	c := h.c
+	if c.tty != out {
+		t.Fatalf("error : the engine should draw on the output file")
+	}
+
+	h.waitOutput(c.comm.EnterCA, c.comm.Clear)
+	c.Redraw([]term.PixelGetter{&regionPixel{hash: term.Hash(1, 1), r: 'x'}})
+	h.waitOutput("\x1b[2;2Hx")
+
+	h.stop()
+	h.waitOutput(c.comm.ExitCA)
+	if _, err := out.Write([]byte("done")); err != nil {
+		t.Errorf("error : the output file should not be closed by the engine, got %v", err)
+	}
+}
+
+func TestPTYStdoutClean(t *testing.T) {
+	data, err := ioutil.TempFile(t.TempDir(), "stdout")
+	if err != nil {
+		t.Fatalf("error creating the standard output : %v", err)
+	}
+	defer func() { _ = data.Close() }()
+	stdout := os.Stdout
+	os.Stdout = data // the application is in the middle of a pipeline
+	defer func() { os.Stdout = stdout }()
+
+	h := startPTY(t, 80, 24)
+	c := h.c
+	h.waitOutput(c.comm.EnterCA)
+	c.Redraw([]term.PixelGetter{&regionPixel{hash: term.Hash(1, 1), r: 'x'}})
+	h.waitOutput("\x1b[2;2Hx")
+	h.stop()
+	h.waitOutput(c.comm.ExitCA, c.comm.Clear)
+
+	content, err := ioutil.ReadFile(data.Name())
+	if err != nil {
+		t.Fatalf("error reading the standard output : %v", err)
+	}
+	if len(content) > 0 {
+		t.Errorf("error : nothing should be written to the standard output, got %q", content)
+	}
+}
+
+func TestPTYPointerShapeReset(t *testing.T) {
+	h := startPTY(t, 80, 24)
+	h.waitOutput(h.c.comm.EnterCA)
+	if !h.c.SetPointerShape(term.PointerHand) {
+		t.Fatalf("error : the pointer shape should be set")
+	}
+	h.waitOutput("\x1b]22;pointer\x1b\\")
+	h.stop()
+	h.waitOutput("\x1b]22;default\x1b\\", h.c.comm.ExitCA) // restored on shutdown
+}
+
+func TestPTYSizePolling(t *testing.T) {
+	h := startPTY(t, 80, 24, WithSizePolling(10*time.Millisecond))
+	c := h.c
+	listener := newHarnessListener(t)
+	c.ResizeDispatcher().Register(listener)
+
+	// the terminal is asked for its size, and its reply resizes the screen, without any SIGWINCH
+	h.waitOutput(sizeQuery)
+	h.send("\x1b[8;30;100t")
+	select {
+	case ev := <-listener.resizes:
+		if size := ev.Size(); size.Columns != 100 || size.Rows != 30 {
+			t.Errorf("error : expecting a resize to 100 x 30, got %d x %d", size.Columns, size.Rows)
+		}
+	case <-time.After(harnessTimeout):
+		t.Fatalf("error : the reported size was not dispatched")
+	}
+
+	// the same size, reported again, is not dispatched
+	h.send("\x1b[8;30;100t")
+	select {
+	case ev := <-listener.resizes:
+		t.Errorf("error : the same size should not be dispatched, got %d x %d", ev.Size().Columns, ev.Size().Rows)
+	case <-time.After(50 * time.Millisecond):
+	}
+	h.waitOutput(sizeQuery) // still polling
+}
+
+func TestPTYForcedSize(t *testing.T) {
+	h := startPTY(t, 120, 40, WithSize(90, 20))
+	c := h.c
+	h.waitOutput(c.comm.EnterCA)
+	if size := c.Size(); size.Columns != 90 || size.Rows != 20 {
+		t.Errorf("error : the forced size should be used instead of the one of the terminal, got %d x %d", size.Columns, size.Rows)
+	}
+}
+
+func TestPTYPlainDetection(t *testing.T) {
+	_, slave := newPTY(t, 80, 24)
+	out, err := os.OpenFile(slave, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("error opening %s : %v", slave, err)
+	}
+	defer func() { _ = out.Close() }()
+	newBenchCore(t) // registers the terminal, and sets the environment
+	engine, err := NewCore("xterm-256color", WithTerminfo(benchInfo), WithOutputFile(out))
+	if err != nil {
+		t.Fatalf("error creating engine : %v", err)
+	}
+	if engine.(*core).plain {
+		t.Errorf("error : the plain mode should not be used on a terminal")
+	}
+}
+
+func TestPTYInterrupts(t *testing.T) {
+	h := startPTY(t, 80, 24)
+	c := h.c
+	h.waitOutput(c.comm.EnterCA)
+	if tio := getAttr(t, h.master); tio.Lflag&unix.ISIG != 0 {
+		t.Errorf("error : Ctrl+C should not be turned into signals in keys mode")
+	}
+	h.send("\x03")
+	select {
+	case <-c.InterruptChan():
+	case <-time.After(harnessTimeout):
+		t.Fatalf("error : Ctrl+C should be notified")
+	}
+	h.stop()
+
+	h = startPTY(t, 80, 24, WithInterrupts(InterruptAsSignals))
+	h.waitOutput(h.c.comm.EnterCA)
+	tio := getAttr(t, h.master)
+	if tio.Lflag&unix.ISIG == 0 {
+		t.Errorf("error : Ctrl+C should be turned into signals by the terminal driver")
+	}
+	if tio.Cc[unix.VSUSP] != vdisable {
+		t.Errorf("error : Ctrl+Z should not suspend, got %#x", tio.Cc[unix.VSUSP])
+	}
+}
+
+// withStdin replaces the standard input of the engine
+func withStdin(f *os.File) Option {
+	return func(c *core) {
+		c.stdin = f
+	}
+}
+
+func TestPTYRedirectedInput(t *testing.T) {
+	piped, data := openPipe(t)
+	h, slave := newPTY(t, 80, 24)
+	h.start(slave, withStdin(piped))
+	c := h.c
+	h.waitOutput(c.comm.EnterCA)
+
+	listener := newHarnessListener(t)
+	c.KeyDispatcher().Register(listener)
+	if _, err := data.Write([]byte("data")); err != nil {
+		t.Fatalf("error writing the pipe : %v", err)
+	}
+	h.send("a")
+	select {
+	case ev := <-listener.keys:
+		if expected := (key.Binding{Key: key.Rune, Rune: 'a'}); !expected.Matches(ev) {
+			t.Errorf("error : expecting %s, got %s", expected, ev.Name())
+		}
+	case <-time.After(harnessTimeout):
+		t.Fatalf("error : the input should be read from the terminal")
+	}
+	buf := make([]byte, 4)
+	if n, err := piped.Read(buf); err != nil || string(buf[:n]) != "data" {
+		t.Errorf("error : the pipe should be left to the program, got %q (%v)", buf[:n], err)
+	}
+}
+
+func TestPTYNoTTYFallback(t *testing.T) {
+	piped, _ := openPipe(t)
+	_, slave := newPTY(t, 80, 24)
+	c := newBenchCore(t, WithTTYFallback(false), withStdin(piped))
+	c.ttyPath = slave
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := c.Start(ctx); !errors.Is(err, ErrInputRedirected) {
+		t.Errorf("error : expecting ErrInputRedirected, got %v", err)
+	}
+
+	// the standard input is a terminal : no fallback needed
+	h, slave := newPTY(t, 80, 24)
+	in, err := os.Open(slave)
+	if err != nil {
+		t.Fatalf("error opening %s : %v", slave, err)
+	}
+	defer func() { _ = in.Close() }()
+	h.start(slave, WithTTYFallback(false), withStdin(in))
+	h.waitOutput(h.c.comm.EnterCA)
+}
+
+// openPipe returns both ends of a pipe, closed when the test ends
+func openPipe(t *testing.T) (*os.File, *os.File) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("error creating pipe : %v", err)
+	}
+	t.Cleanup(func() {
+		_ = r.Close()
+		_ = w.Close()
+	})
+	return r, w
+}
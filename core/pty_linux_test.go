@@ -0,0 +1,34 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	getTermios = unix.TCGETS
+	vdisable   = 0 // _POSIX_VDISABLE, the value of a disabled special character
+)
+
+// openPTY returns the master side of a new pseudo terminal and the path of its slave
+func openPTY(t *testing.T) (*os.File, string) {
+	t.Helper()
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("no pseudo terminals : %v", err)
+	}
+	t.Cleanup(func() { _ = master.Close() })
+	var number uint32
+	ioctl(t, master, func(fd int) error {
+		if err := unix.IoctlSetPointerInt(fd, unix.TIOCSPTLCK, 0); err != nil {
+			return fmt.Errorf("unlocking : %w", err)
+		}
+		number, err = unix.IoctlGetUint32(fd, unix.TIOCGPTN)
+		return err
+	})
+	return master, "/dev/pts/" + strconv.Itoa(int(number))
+}
@@ -0,0 +1,22 @@
+//go:build solaris || illumos
+// +build solaris illumos
+
+package core
+
+import (
+	"os"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	getTermios = unix.TCGETS
+	vdisable   = 0 // _POSIX_VDISABLE, the value of a disabled special character
+)
+
+// openPTY skips the test : the pseudo terminals are allocated differently on each of these systems, and only the Linux way is implemented
+func openPTY(t *testing.T) (*os.File, string) {
+	t.Skip("no pseudo terminals for tests on this system")
+	return nil, ""
+}
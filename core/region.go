@@ -0,0 +1,78 @@
+package core
+
+import (
+	"bytes"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+// regionPixel is a term.PixelGetter implementation, used for writing the parts of a region which are not covered by active pixels
+type regionPixel struct {
+	hash int
+	r    rune
+	st   style.Style
+}
+
+func (p *regionPixel) DrawCh() chan term.PixelGetter { return nil }
+func (p *regionPixel) Style() (color.Color, color.Color, style.Mask) {
+	return p.st.Fg, p.st.Bg, p.st.Attrs
+}
+func (p *regionPixel) HasUnicode() bool       { return false }
+func (p *regionPixel) Unicode() *term.Unicode { return nil }
+func (p *regionPixel) Rune() rune             { return p.r }
+func (p *regionPixel) Width() int             { return 1 }
+func (p *regionPixel) PositionHash() int      { return p.hash }
+
+// ClearRegion implements term.RegionFiller interface
+func (c *core) ClearRegion(from, to *term.Position, st style.Style) error {
+	return c.Fill(from, to, ' ', st)
+}
+
+// Fill implements term.RegionFiller interface
+func (c *core) Fill(from, to *term.Position, r rune, st style.Style) error {
+	if from == nil || to == nil {
+		return term.ErrNilPosition
+	}
+	c.Lock()
+	if c.size == nil {
+		c.Unlock()
+		return nil
+	}
+	// clipped to the area left to the pages, the reserved edges being outside it
+	left, right := term.Max(0, term.Min(from.Column, to.Column)), term.Min(c.area.columns-1, term.Max(from.Column, to.Column))
+	top, bottom := term.Max(0, term.Min(from.Row, to.Row)), term.Min(c.area.rows-1, term.Max(from.Row, to.Row))
+
+	var active []term.Pixel
+	var direct []term.PixelGetter // row by row, as blankRun expects
+	for row := top; row <= bottom; row++ {
+		for column := left; column <= right; column++ {
+			hash := term.Hash(column, row)
+			if pixel, ok := c.content[hash]; ok {
+				active = append(active, pixel)
+				continue
+			}
+			direct = append(direct, &regionPixel{hash: hash, r: r, st: st})
+		}
+	}
+	var err error
+	if len(direct) > 0 {
+		buf := bytes.NewBuffer(nil)
+		c.drawPixels(buf, direct...)
+		if c.plain {
+			c.flushPlain(buf)
+		}
+		if _, err = buf.WriteTo(c.out); err != nil {
+			if Debug.Enabled() {
+				Debug.Printf("error writing to out : %v", err)
+			}
+		}
+	}
+	c.Unlock() // the active pixels are drawn by their listeners, which lock
+
+	for _, pixel := range active {
+		pixel.SetAll(st.Bg, st.Fg, st.Attrs, r, nil)
+	}
+	return err
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+func TestFill(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+
+	if err := c.Fill(nil, term.NewPosition(1, 1), 'x', style.Style{}); !errors.Is(err, term.ErrNilPosition) {
+		t.Errorf("error : a missing corner should be refused, got %v", err)
+	}
+	if err := c.ClearRegion(term.NewPosition(1, 1), nil, style.Style{}); !errors.Is(err, term.ErrNilPosition) {
+		t.Errorf("error : a missing corner should be refused, got %v", err)
+	}
+	if out := written(); out != "" {
+		t.Errorf("error : nothing should be written for a missing corner, got %q", out)
+	}
+
+	// the region is clipped to the area left to the pages : the right edge is reserved
+	c.ReserveEdge(term.EdgeRight, 2)
+	for _, tc := range []struct {
+		name     string
+		from, to *term.Position
+		expected string
+	}{
+		{name: "inside", from: term.NewPosition(3, 1), to: term.NewPosition(1, 2), expected: "\x1b[2;2Hxxx\x1b[3;2Hxxx"},
+		{name: "bottom right corner", from: term.NewPosition(benchColumns-4, benchRows-1), to: term.NewPosition(benchColumns+5, benchRows+3), expected: "\x1b[50;197Hxx"},
+		{name: "top left corner", from: term.NewPosition(-5, -5), to: term.NewPosition(0, 0), expected: "\x1b[1;1Hx"},
+		{name: "outside", from: term.NewPosition(benchColumns-2, 0), to: term.NewPosition(benchColumns, 0), expected: ""},
+	} {
+		if err := c.Fill(tc.from, tc.to, 'x', style.Style{}); err != nil {
+			t.Errorf("error : %s : %v", tc.name, err)
+		}
+		if out := written(); out != tc.expected {
+			t.Errorf("error : %s : expecting %q, got %q", tc.name, tc.expected, out)
+		}
+	}
+
+	// the style of the region is used
+	if err := c.ClearRegion(term.NewPosition(0, 0), term.NewPosition(1, 0), style.Style{Bg: color.Red}); err != nil {
+		t.Errorf("error : %v", err)
+	}
+	if out, expected := written(), "\x1b[1;1H\x1b(B\x1b[m\x1b[101m  "; out != expected {
+		t.Errorf("error : expecting %q, got %q", expected, out)
+	}
+}
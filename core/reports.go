@@ -0,0 +1,86 @@
+package core
+
+import (
+	"bytes"
+	"sync"
+)
+
+const (
+	maxPendingReport = 64 // partial reports longer than this are given up, and passed to the key dispatcher
+)
+
+// reportParser handles a terminal report (usually, the reply to a query) starting with prefix.
+// The parse function returns the number of bytes consumed, or -1 if the report is incomplete.
+type reportParser struct {
+	prefix []byte
+	parse  func(report []byte) int
+	limit  int // partial reports longer than this are given up
+}
+
+// reportFilter removes the terminal reports from the input, so they don't reach the key dispatcher as runes
+type reportFilter struct {
+	sync.Mutex                // guards other properties
+	parsers    []reportParser // known reports
+	pending    []byte         // a report which was split across reads
+}
+
+// add registers a report parser
+func (f *reportFilter) add(prefix string, parse func(report []byte) int) {
+	f.addWithLimit(prefix, maxPendingReport, parse)
+}
+
+// addWithLimit registers a parser for reports which can be longer than usual (e.g. a bracketed paste)
+func (f *reportFilter) addWithLimit(prefix string, limit int, parse func(report []byte) int) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.parsers = append(f.parsers, reportParser{prefix: []byte(prefix), parse: parse, limit: limit})
+}
+
+// filter returns the input without the reports, which are handled by their parsers
+func (f *reportFilter) filter(in []byte) []byte {
+	f.Lock()
+	parsers := f.parsers
+	data := in
+	if len(f.pending) > 0 {
+		data = append(f.pending, in...)
+		f.pending = nil
+	}
+	f.Unlock()
+
+	out := make([]byte, 0, len(data))
+	for idx := 0; idx < len(data); {
+		rest := data[idx:]
+		if rest[0] != '\x1b' {
+			out = append(out, rest[0])
+			idx++
+			continue
+		}
+
+		consumed, partial, limit := 0, false, maxPendingReport
+		for _, parser := range parsers {
+			if bytes.HasPrefix(rest, parser.prefix) {
+				if consumed = parser.parse(rest); consumed < 0 {
+					partial, limit = true, parser.limit
+				}
+				break
+			}
+			if len(rest) > 2 && bytes.HasPrefix(parser.prefix, rest) {
+				partial = true // short ones are probably keys (e.g. a lone escape), longer ones wait for the next read
+			}
+		}
+		if consumed > 0 {
+			idx += consumed
+			continue
+		}
+		if partial && len(rest) <= limit {
+			f.Lock()
+			f.pending = append([]byte(nil), rest...) // waiting for the rest of it in the next read
+			f.Unlock()
+			break
+		}
+		out = append(out, rest[0])
+		idx++
+	}
+	return out
+}
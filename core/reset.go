@@ -0,0 +1,21 @@
+package core
+
+import (
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+// ResetTerminal implements term.Resetter interface. It's meant to be called before exiting : the terminal is left on the main screen,
+// usually cleared by the reset strings, while the pixels are not drawn again.
+func (c *core) ResetTerminal() {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.out == nil || c.plain {
+		return
+	}
+	c.comm.PutReset(c.out)
+	c.comm.PutAttrOff(c.out)
+	c.comm.PutExitCA(c.out)
+	c.cachedFG, c.cachedBG, c.cachedAttrs = color.Default, color.Default, style.None
+}
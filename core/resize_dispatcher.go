@@ -3,32 +3,54 @@ package core
 import (
 	"context"
 	"io"
-	"log"
-	"os"
+	"time"
 
 	"github.com/badu/term"
 )
 
+// receiver is a registered listener channel, having the priority of the listener (see term.PriorityListener)
+type receiver struct {
+	ch       chan term.ResizeEvent
+	priority int
+}
+
 // for readability
-type channels []chan term.ResizeEvent
+type channels []receiver
 
-// delete removes the element at index from channels.
-// Note that this is the fastest version, which changes order of elements inside the slice
+// delete removes the element at index from channels, keeping the order of the others, so the events are always delivered in the same order.
 // Yes, this is repeated code, because avoiding use of interface{}
 func (c *channels) delete(idx int) {
-	(*c)[idx] = (*c)[len(*c)-1] // Copy last element to index i.
-	(*c)[len(*c)-1] = nil       // Erase last element (write zero value).
-	*c = (*c)[:len(*c)-1]       // Truncate slice.
+	copy((*c)[idx:], (*c)[idx+1:]) // Shift the elements after index.
+	(*c)[len(*c)-1] = receiver{}   // Erase last element (write zero value).
+	*c = (*c)[:len(*c)-1]          // Truncate slice.
+}
+
+// insert adds the channel after the ones having the same or a higher priority : the events are delivered by priority, then in the order of registration
+func (c *channels) insert(ch chan term.ResizeEvent, priority int) {
+	idx := len(*c)
+	for idx > 0 && (*c)[idx-1].priority < priority {
+		idx--
+	}
+	*c = append(*c, receiver{})
+	copy((*c)[idx+1:], (*c)[idx:])
+	(*c)[idx] = receiver{ch: ch, priority: priority}
 }
 
 // EventResize is sent when the window size changes.
 type EventResize struct {
-	size *term.Size
+	size    *term.Size
+	margins term.Margins
+	when    time.Time
 }
 
 // NewResizeEvent
 func NewResizeEvent(cols, rows int) *EventResize {
-	return &EventResize{size: &term.Size{Columns: cols, Rows: rows}}
+	return &EventResize{size: &term.Size{Columns: cols, Rows: rows}, when: time.Now()}
+}
+
+// newResizeEvent creates an event for the current size
+func newResizeEvent(size *term.Size, margins term.Margins) *EventResize {
+	return &EventResize{size: size, margins: margins, when: time.Now()}
 }
 
 // Size
@@ -36,6 +58,16 @@ func (e *EventResize) Size() *term.Size {
 	return e.size
 }
 
+// Margins implements term.MarginsEvent interface
+func (e *EventResize) Margins() term.Margins {
+	return e.margins
+}
+
+// When implements term.Event interface
+func (e *EventResize) When() time.Time {
+	return e.when
+}
+
 // readerCtx
 type readerCtx struct {
 	ctx      context.Context
@@ -43,6 +75,10 @@ type readerCtx struct {
 	mouseCh  chan []byte
 	keyCh    chan []byte
 	hasMouse bool
+	filter   func([]byte) []byte // removes the terminal reports from input
+	scan     func([]byte)        // looks for interrupts in input
+	keys     *inputMeter         // the backpressure of the key dispatcher
+	mice     *inputMeter         // the backpressure of the mouse dispatcher
 }
 
 // ioret
@@ -64,10 +100,22 @@ func (r *readerCtx) Read(_ []byte) (int, error) {
 	// blocking wait for one of the channels (either we have reads or context cancellation)
 	select {
 	case ret := <-ret:
-		if r.hasMouse {
-			r.mouseCh <- inBuf[:ret.n]
+		in := inBuf[:ret.n]
+		if r.filter != nil {
+			in = r.filter(in)
+		}
+		if len(in) == 0 {
+			return ret.n, ret.err
+		}
+		if r.scan != nil {
+			r.scan(in)
+		}
+		if r.hasMouse && !r.mice.send(r.ctx, r.mouseCh, in) {
+			return 0, r.ctx.Err()
+		}
+		if !r.keys.send(r.ctx, r.keyCh, in) {
+			return 0, r.ctx.Err()
 		}
-		r.keyCh <- inBuf[:ret.n]
 		return ret.n, ret.err
 	case <-r.ctx.Done():
 		return 0, r.ctx.Err()
@@ -75,13 +123,17 @@ func (r *readerCtx) Read(_ []byte) (int, error) {
 }
 
 // newContextReader gets a context-aware io.Reader.
-func newContextReader(ctx context.Context, r io.Reader, keyChan, mouseChan chan []byte, hasMouse bool) io.Reader {
+func newContextReader(ctx context.Context, r io.Reader, keyChan, mouseChan chan []byte, hasMouse bool, filter func([]byte) []byte, scan func([]byte), keys, mice *inputMeter) io.Reader {
 	return &readerCtx{
 		ctx:      ctx,
 		r:        r,
 		mouseCh:  mouseChan,
 		keyCh:    keyChan,
 		hasMouse: hasMouse,
+		filter:   filter,
+		scan:     scan,
+		keys:     keys,
+		mice:     mice,
 	}
 }
 
@@ -89,83 +141,130 @@ func newContextReader(ctx context.Context, r io.Reader, keyChan, mouseChan chan
 func (c *core) lifeCycle(ctx context.Context) {
 	// goroutine for listening inputs and distribute them to listeners
 	go func(cx context.Context) {
-		reader := newContextReader(cx, c.in, c.keyDispatcher.InChan(), c.mouseDispatcher.InChan(), c.comm.HasMouse)
+		var in io.Reader = c.in
+		if c.transport != nil {
+			in = c.transport
+		} else if c.in == nil {
+			return // plain mode, no input
+		}
+		reader := newContextReader(cx, in, c.keyDispatcher.InChan(), c.mouseDispatcher.InChan(), c.comm.HasMouse, c.reports.filter, c.scanInput, c.keyMeter, c.mouseMeter)
 		for {
 			// by default we just listen whatever comes
 			_, err := reader.Read(nil)
 			switch err {
-			case io.EOF, nil: // ok
+			case nil: // ok
 			case context.Canceled:
-				if Debug {
-					log.Println("context cancelled : reader no longer reads.")
+				if Debug.Enabled() {
+					Debug.Println("context cancelled : reader no longer reads.")
 				}
 				return // probably killed by internalShutdown, so we exit
+			case io.EOF:
+				c.terminate(term.ErrInputClosed) // the terminal hung up : reading again would return EOF forever
+				return
 			default:
-				if Debug {
-					log.Printf("[core] read error has occurred : %v", err)
+				if Debug.Enabled() {
+					Debug.Printf("read error has occurred : %v", err)
 				}
+				c.terminate(&term.ReadError{Err: err}) // e.g. EIO, once the terminal hung up
 				return
 			}
 		}
 	}(ctx)
+	// goroutine running the functions posted by the background workers
+	go c.jobs.run(ctx, c)
 	// goroutine for gracefully shutting down
 	go func(cx context.Context) {
 		<-cx.Done() // block here until we're done
-		if Debug {
-			log.Println("[core] init'ing shutdown sequence.")
+		if Debug.Enabled() {
+			Debug.Println("init'ing shutdown sequence.")
 		}
 		c.Lock()
 		defer c.Unlock()
 		// performing shutdown
+		if c.plain {
+			c.flushPlain(c.out) // the last frame, if pixels were changed since the last redraw
+			c.resize(0, 0, true)
+			c.shutdownComplete()
+			return
+		}
 		c.resize(0, 0, true) // important : it will cancel pixels listener context
+		c.eraseStatusLine()
 		c.comm.PutShowCursor(c.out)
 		c.comm.PutAttrOff(c.out)
 		c.comm.PutClear(c.out)
 		c.comm.PutExitCA(c.out)
 		c.comm.PutExitKeypad(c.out)
 		c.comm.PutDisableMouse(c.out)
-		if err := c.internalShutdown(); err != nil {
-			if Debug {
-				log.Printf("[core] internal shutdown error : %v", err)
-			}
+		c.comm.WriteString(c.out, disableThemeReports)
+		if c.kittyKeyboard {
+			c.comm.WriteString(c.out, disableKittyKeyboard)
+		}
+		if c.bracketedPaste {
+			c.comm.WriteString(c.out, disableBracketedPaste)
 		}
-		c.comm.PutClear(os.Stdout) // clears the terminal screen after shutdown
-		if Debug {
-			log.Println("[core] shutdown complete")
+		if c.focusReports {
+			c.comm.WriteString(c.out, disableFocusReports)
 		}
-		// order matters, otherwise the finalizer won't get called
-		if c.finalizer != nil {
-			c.finalizer()
+		c.resetPointerShape()
+		c.restoreSavedModes() // after the engine has reset them, the outer program might have them set
+		if c.transport != nil {
+			c.shutdownComplete() // the owner of the transport closes it
+			return
+		}
+		c.comm.PutClear(c.out) // clears the terminal screen after shutdown : on the terminal, the standard output might be data
+		if err := c.internalShutdown(); err != nil {
+			if Debug.Enabled() {
+				Debug.Printf("internal shutdown error : %v", err)
+			}
 		}
-		close(c.died) // notifying our death to a dispatcher (which listens in register)
+		c.shutdownComplete()
 	}(ctx)
 	// goroutine for watching size changes
 	go func(cx context.Context) {
+		var poll <-chan time.Time // stays nil, unless polling was requested
+		if c.sizePolling > 0 && !c.plain {
+			ticker := time.NewTicker(c.sizePolling)
+			defer ticker.Stop()
+			poll = ticker.C
+		}
 		for {
 			select {
 			case <-cx.Done():
-				if Debug {
-					log.Println("[core] context done - exiting resize listener")
+				if Debug.Enabled() {
+					Debug.Println("context done - exiting resize listener")
 				}
 				return
+			case <-poll:
+				c.Lock()
+				c.comm.WriteString(c.out, sizeQuery) // the reply is handled by parseSizeReport
+				c.Unlock()
+			case size := <-c.sizeReportCh:
+				c.Lock()
+				if c.size == nil || c.screenColumns != size.Columns || c.screenRows != size.Rows {
+					c.resize(size.Columns, size.Rows, false)
+					c.drawChrome()
+					ev := newResizeEvent(c.size, c.margins()) // create one event for everyone
+					for _, cons := range c.receivers {        // multiplexing
+						cons.ch <- ev
+					}
+				}
+				c.Unlock()
 			case enable := <-c.mouseSwitch:
 				if enable {
 					c.comm.PutEnableMouse(c.out)
+					for _, mode := range []int{term.ModeMouseClicks, term.ModeMouseDrag, term.ModeMouseMotion, term.ModeMouseSGR} {
+						c.comm.WriteString(c.out, modeQuery(mode)) // the replies tell which of them the terminal has enabled
+					}
 				} else {
 					c.comm.PutDisableMouse(c.out)
 				}
 			case <-c.winSizeCh:
 				c.Lock()
-				w, h, err := c.readWinSize() // read new width and height information
-				if err != nil {
-					if Debug {
-						log.Printf("error in win size reader : %v", err)
-					}
-				}
-				c.resize(w, h, false)              // store resize comm
-				ev := &EventResize{size: c.size}   // create one event for everyone
-				for _, cons := range c.receivers { // multiplexing
-					cons <- ev // Important note : yes, there is the risk of writing to close channels
+				c.updateSize() // read new width and height information
+				c.drawChrome()
+				ev := newResizeEvent(c.size, c.margins()) // create one event for everyone
+				for _, cons := range c.receivers {        // multiplexing
+					cons.ch <- ev // Important note : yes, there is the risk of writing to close channels
 				}
 				c.Unlock()
 			}
@@ -173,14 +272,26 @@ func (c *core) lifeCycle(ctx context.Context) {
 	}(ctx)
 }
 
+// shutdownComplete calls the finalizer and notifies our death - locked inside caller function
+func (c *core) shutdownComplete() {
+	if Debug.Enabled() {
+		Debug.Println("shutdown complete")
+	}
+	// order matters, otherwise the finalizer won't get called
+	if c.finalizer != nil {
+		c.finalizer()
+	}
+	close(c.died) // notifying our death to a dispatcher (which listens in register)
+}
+
 // Register is registering receivers
 func (c *core) Register(r term.ResizeListener) {
 	c.Lock()
 	defer c.Unlock()
 
 	if c.ctx == nil {
-		if Debug {
-			log.Fatal("context not set : cannot listen context.Done()")
+		if Debug.Enabled() {
+			Debug.Println("context not set : cannot listen context.Done()")
 		}
 		return
 	}
@@ -188,38 +299,40 @@ func (c *core) Register(r term.ResizeListener) {
 	alreadyRegistered := false
 	for _, ch := range c.receivers {
 		// Two channel values are considered equal if they originated from the same make call (meaning they refer to the same channel value in memory).
-		if ch == r.ResizeListen() {
+		if ch.ch == r.ResizeListen() {
 			alreadyRegistered = true
 			break
 		}
 	}
 	if alreadyRegistered {
-		if Debug {
-			log.Fatal("warning : ResizeListen chan is nil")
+		if Debug.Enabled() {
+			Debug.Println("warning : ResizeListen chan is already registered")
 		}
 		return
 	}
 	if r.ResizeListen() == nil {
-		if Debug {
-			log.Fatal("error : ResizeListen chan is nil")
+		if Debug.Enabled() {
+			Debug.Println("error : ResizeListen chan is nil")
 		}
 		return
 	}
 	// we're fine, lets register it
-	c.receivers = append(c.receivers, r.ResizeListen())
+	c.receivers.insert(r.ResizeListen(), term.ListenPriority(r))
 	// mounting a go routine to listen bye-bye when the listener's context get cancelled
 	go func() {
 		select {
 		case <-c.ctx.Done():
-			if Debug {
-				log.Println("[core] context is done. Existing death listening routine in Register")
+			if Debug.Enabled() {
+				Debug.Println("context is done. Existing death listening routine in Register")
 			}
 			return
 		case <-r.DyingChan():
+			c.Lock()
+			defer c.Unlock()
 			// now lookup for that very channel and forget it
 			for idx, ch := range c.receivers {
 				// Two channel values are considered equal if they originated from the same make call (meaning they refer to the same channel value in memory).
-				if ch == r.ResizeListen() {
+				if ch.ch == r.ResizeListen() {
 					c.receivers.delete(idx)
 					break
 				}
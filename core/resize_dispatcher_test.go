@@ -0,0 +1,70 @@
+package core
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/badu/term"
+)
+
+func TestRegisterWhileResizing(t *testing.T) {
+	in, _ := io.Pipe()
+	c := newBenchCore(t, WithTransport(&pipeTransport{PipeReader: in, Writer: ioutil.Discard}))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("error starting : %v", err)
+	}
+
+	const listeners = 20
+	resizing := make(chan struct{})
+	go func() { // the terminal reports other sizes while the listeners come and go
+		for columns := benchColumns; ; columns++ {
+			select {
+			case c.sizeReportCh <- &term.Size{Columns: columns, Rows: benchRows}:
+			case <-resizing:
+				return
+			}
+		}
+	}()
+	var wg sync.WaitGroup
+	for i := 0; i < listeners; i++ {
+		l := &resizeListener{ch: make(chan term.ResizeEvent), died: make(chan struct{})}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				select {
+				case <-l.ch:
+				case <-time.After(2 * time.Second):
+					t.Errorf("error : the listener stopped receiving the events")
+					j = 5
+				}
+			}
+			close(l.died)
+			for { // drains the events sent before forgetting the listener
+				select {
+				case <-l.ch:
+				case <-time.After(100 * time.Millisecond):
+					return
+				}
+			}
+		}()
+		c.Register(l)
+	}
+	wg.Wait()
+	close(resizing)
+
+	c.Lock()
+	defer c.Unlock()
+	for _, receiver := range c.receivers {
+		if receiver.ch != c.mouseDispatcher.ResizeListen() {
+			t.Errorf("error : the dead listeners should be forgotten, got %d receivers", len(c.receivers))
+			break
+		}
+	}
+}
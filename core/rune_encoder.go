@@ -33,6 +33,7 @@ func (c *encoder) encodeRune(r rune, buf []byte) []byte {
 		buf = append(buf, cache...)
 		return buf
 	}
+	start := len(buf)
 	nb := make([]byte, 6)
 	ob := make([]byte, 6)
 	num := utf8.EncodeRune(ob, r)
@@ -57,8 +58,8 @@ func (c *encoder) encodeRune(r rune, buf []byte) []byte {
 	} else {
 		buf = append(buf, nb[:dst]...)
 	}
-	cache := make([]byte, 6)
-	copy(cache, buf)
+	cache := make([]byte, len(buf)-start) // only the bytes of this rune, without padding
+	copy(cache, buf[start:])
 	c.cachedEncodedRunes[r] = cache
 	return buf
 }
@@ -0,0 +1,159 @@
+package core
+
+import (
+	"io"
+
+	"github.com/badu/term"
+)
+
+const (
+	frameOffset = 14695981039346656037 // FNV-1a, combining the content hashes of the cells of a row
+	framePrime  = 1099511628211
+)
+
+// frameRow is the signature of a row of the frame, defined only when all its cells are known
+type frameRow struct {
+	hash    uint64
+	defined bool
+}
+
+// recordFrame remembers the content of the drawn pixels, for finding the rows which have moved (see scrollFrame) - locked inside caller function
+func (c *core) recordFrame(pixels []term.PixelGetter) {
+	if !c.comm.CanEditLines() {
+		return
+	}
+	if c.frame == nil {
+		c.frame = make(map[int]uint64, c.area.columns*c.area.rows)
+	}
+	for _, pixel := range pixels {
+		column, row := term.UnHash(pixel.PositionHash())
+		if c.area.contains(column, row) {
+			c.frame[term.Hash(column, row)] = term.ContentHash(pixel)
+		}
+	}
+}
+
+// forgetFrame is called when the content of the screen is no longer known, e.g. after clearing it - locked inside caller function
+func (c *core) forgetFrame() {
+	c.frame = nil
+}
+
+// canScroll returns true if the rows of the pages can be moved by the terminal, while nothing else is drawn over them - locked inside caller function
+func (c *core) canScroll() bool {
+	return !c.plain && c.frame != nil && c.comm.CanEditLines() && c.canEditLines() &&
+		len(c.layers.covered) == 0 && c.search.pattern == nil
+}
+
+// scrollFrame compares the rows of the pixels with the ones of the frame : when most of them have moved up or down (e.g. a log or a list being scrolled), the terminal deletes or inserts the lines,
+// so only the rows which are new get written. Returns the pixels which still have to be drawn - locked inside caller function
+func (c *core) scrollFrame(w io.Writer, pixels []term.PixelGetter) []term.PixelGetter {
+	rows := c.area.rows
+	if len(pixels) < 2*c.area.columns {
+		return pixels // not even two rows
+	}
+	cells := make(map[int]uint64, len(pixels))
+	for _, pixel := range pixels {
+		cells[pixel.PositionHash()] = term.ContentHash(pixel)
+	}
+	current, previous := c.frameRows(cells), c.frameRows(c.frame)
+	same := func(row, from int) bool {
+		return current[row].defined && previous[from].defined && current[row].hash == previous[from].hash
+	}
+
+	top := 0
+	for top < rows && same(top, top) {
+		top++
+	}
+	if top >= rows-1 {
+		return pixels
+	}
+	for row := top; row < rows; row++ {
+		if !current[row].defined {
+			return pixels // the terminal moves all the rows below, so all of them have to be drawn
+		}
+	}
+	kept := 0 // the rows left in place : moving the lines is worth it only if more rows get in place
+	for row := top; row < rows; row++ {
+		if same(row, row) {
+			kept++
+		}
+	}
+	best, shift := kept, 0 // a negative shift deletes lines, moving the content up
+	for count := 1; count < rows-top; count++ {
+		up, down := 0, 0
+		for row := top; row < rows-count; row++ {
+			if same(row, row+count) {
+				up++
+			}
+			if same(row+count, row) {
+				down++
+			}
+		}
+		if up > best {
+			best, shift = up, -count
+		}
+		if down > best {
+			best, shift = down, count
+		}
+	}
+	if shift == 0 {
+		return pixels
+	}
+
+	if !c.prepareEdit(0, top) {
+		return pixels
+	}
+	var err error
+	if shift < 0 {
+		err = c.comm.PutDeleteLines(w, -shift)
+	} else {
+		err = c.comm.PutInsertLines(w, shift)
+	}
+	if err != nil {
+		c.forgetFrame()
+		return pixels
+	}
+
+	// the frame follows what the terminal did, the rows it has blanked being unknown
+	moved := make(map[int]bool, rows)
+	for idx := 0; idx < rows-top; idx++ {
+		row, from := top+idx, top+idx-shift // deleting, the rows are moved up starting with the top one
+		if shift > 0 {
+			row, from = rows-1-idx, rows-1-idx-shift // inserting, they are moved down starting with the bottom one
+		}
+		inside := from >= top && from < rows
+		moved[row] = inside && same(row, from)
+		for column := 0; column < c.area.columns; column++ {
+			content, ok := c.frame[term.Hash(column, from)]
+			if inside && ok {
+				c.frame[term.Hash(column, row)] = content
+				continue
+			}
+			delete(c.frame, term.Hash(column, row))
+		}
+	}
+
+	result := make([]term.PixelGetter, 0, len(pixels))
+	for _, pixel := range pixels {
+		if _, row := term.UnHash(pixel.PositionHash()); !moved[row] {
+			result = append(result, pixel)
+		}
+	}
+	return result
+}
+
+// frameRows computes the signatures of the rows of the area, from the content hashes of their cells - locked inside caller function
+func (c *core) frameRows(cells map[int]uint64) []frameRow {
+	result := make([]frameRow, c.area.rows)
+	for row := range result {
+		signature := uint64(frameOffset)
+		defined := c.area.columns > 0
+		for column := 0; column < c.area.columns && defined; column++ {
+			content, ok := cells[term.Hash(column, row)]
+			signature = (signature ^ content) * framePrime
+			defined = ok
+		}
+		result[row] = frameRow{hash: signature, defined: defined}
+	}
+	return result
+}
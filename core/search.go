@@ -0,0 +1,243 @@
+package core
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+// searchState holds the matches of the search, which are highlighted when the pixels are drawn
+type searchState struct {
+	pattern   *regexp.Regexp      // nil while there is no search
+	highlight style.Style         // the style of the matches : default colors keep the ones of the pixel, the attributes are added
+	matches   []term.SearchMatch  // in reading order
+	current   int                 // the match chosen by NextMatch or PreviousMatch, -1 if none
+	styled    map[int]style.Style // the style of the highlighted positions, by position hash
+}
+
+// highlightedPixel draws a pixel of a match, with the highlight style
+type highlightedPixel struct {
+	term.PixelGetter
+	st style.Style
+}
+
+// Style implements term.PixelGetter interface
+func (p *highlightedPixel) Style() (color.Color, color.Color, style.Mask) {
+	fg, bg, attrs := p.PixelGetter.Style()
+	if p.st.Fg != color.Default {
+		fg = p.st.Fg
+	}
+	if p.st.Bg != color.Default {
+		bg = p.st.Bg
+	}
+	return fg, bg, attrs | p.st.Attrs
+}
+
+// Search implements term.Searcher interface
+func (c *core) Search(pattern *regexp.Regexp, highlight style.Style) int {
+	c.Lock()
+	defer c.Unlock()
+
+	c.search.pattern, c.search.highlight, c.search.current = pattern, highlight, -1
+	c.searchAgain(nil)
+	return len(c.search.matches)
+}
+
+// NextMatch implements term.Searcher interface
+func (c *core) NextMatch() (term.SearchMatch, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.moveMatch(1)
+}
+
+// PreviousMatch implements term.Searcher interface
+func (c *core) PreviousMatch() (term.SearchMatch, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.moveMatch(-1)
+}
+
+// ClearSearch implements term.Searcher interface
+func (c *core) ClearSearch() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.search.pattern, c.search.current = nil, -1
+	c.searchAgain(nil)
+}
+
+// moveMatch makes the match after (or before) the current one the current, comparing their positions, since the content might have scrolled - locked inside caller function
+func (c *core) moveMatch(direction int) (term.SearchMatch, bool) {
+	if c.search.pattern == nil {
+		return term.SearchMatch{}, false
+	}
+	var previous *term.SearchMatch
+	if c.search.current >= 0 {
+		match := c.search.matches[c.search.current]
+		previous = &match
+	}
+	c.search.matches = c.scan(c.search.pattern)
+	if len(c.search.matches) == 0 {
+		c.search.current = -1
+		c.restyle(nil)
+		return term.SearchMatch{}, false
+	}
+
+	next := 0
+	if direction < 0 {
+		next = len(c.search.matches) - 1
+	}
+	if previous != nil {
+		for idx := range c.search.matches {
+			if direction > 0 && before(*previous, c.search.matches[idx]) {
+				next = idx
+				break
+			}
+			last := len(c.search.matches) - 1 - idx
+			if direction < 0 && before(c.search.matches[last], *previous) {
+				next = last
+				break
+			}
+		}
+	}
+	c.search.current = next
+	c.restyle(nil)
+	return c.search.matches[next], true
+}
+
+// before returns true if the first match starts before the second one, in reading order
+func before(first, second term.SearchMatch) bool {
+	return first.Row < second.Row || (first.Row == second.Row && first.Column < second.Column)
+}
+
+// searchAgain scans the active pixels, then highlights the matches - locked inside caller function
+func (c *core) searchAgain(w io.Writer) {
+	c.search.matches = nil
+	if c.search.pattern != nil {
+		c.search.matches = c.scan(c.search.pattern)
+	}
+	c.restyle(w)
+}
+
+// restyle draws the positions which gained or lost their highlight : into w when called by Redraw, otherwise directly - locked inside caller function
+func (c *core) restyle(w io.Writer) {
+	previous := c.search.styled
+	c.search.styled = nil
+	if c.search.current >= len(c.search.matches) {
+		c.search.current = -1
+	}
+	if len(c.search.matches) > 0 {
+		c.search.styled = make(map[int]style.Style)
+	}
+	for idx, match := range c.search.matches {
+		st := c.search.highlight
+		if idx == c.search.current {
+			st.Attrs ^= style.Reverse
+		}
+		for column := match.Column; column < match.Column+match.Columns; column++ {
+			c.search.styled[term.Hash(column, match.Row)] = st
+		}
+	}
+
+	if c.out == nil || c.plain {
+		return
+	}
+	changed := make([]term.PixelGetter, 0)
+	for hash, st := range c.search.styled {
+		if old, ok := previous[hash]; ok && old == st {
+			continue
+		}
+		if pixel, ok := c.content[hash]; ok {
+			changed = append(changed, pixel)
+		}
+	}
+	for hash := range previous {
+		if _, ok := c.search.styled[hash]; ok {
+			continue
+		}
+		if pixel, ok := c.content[hash]; ok {
+			changed = append(changed, pixel)
+		}
+	}
+	if len(changed) == 0 {
+		return
+	}
+	sortReading(changed)
+	if w != nil {
+		c.drawPixels(w, changed...)
+		return
+	}
+	buf := bytes.NewBuffer(nil)
+	c.drawPixels(buf, changed...)
+	c.writeOut(buf)
+	c.restoreCursor()
+}
+
+// scan finds the matches in the rows of the active pixels - locked inside caller function
+func (c *core) scan(pattern *regexp.Regexp) []term.SearchMatch {
+	if c.size == nil {
+		return nil
+	}
+	var result []term.SearchMatch
+	var line []byte
+	columns := make([]int, 0, c.size.Columns) // the column of each byte of the line
+	for row := 0; row < c.size.Rows; row++ {
+		line, columns = line[:0], columns[:0]
+		for column := 0; column < c.size.Columns; column++ {
+			text := " "
+			if pixel, ok := c.content[term.Hash(column, row)]; ok {
+				if pixel.Rune() == 0 {
+					continue // the second half of a wide rune
+				}
+				text = string(pixel.Rune())
+				if pixel.HasUnicode() {
+					text += string(*pixel.Unicode())
+				}
+			}
+			line = append(line, text...)
+			for idx := 0; idx < len(text); idx++ {
+				columns = append(columns, column)
+			}
+		}
+		for _, found := range pattern.FindAllIndex(line, -1) {
+			if found[0] == found[1] {
+				continue // empty matches can't be highlighted
+			}
+			first, last := columns[found[0]], columns[found[1]-1]
+			result = append(result, term.SearchMatch{Column: first, Row: row, Columns: last - first + 1})
+		}
+	}
+	return result
+}
+
+// searched returns the pixels, the ones of the matches being highlighted - locked inside caller function
+func (c *core) searched(pixels []term.PixelGetter) []term.PixelGetter {
+	if len(c.search.styled) == 0 {
+		return pixels
+	}
+	var result []term.PixelGetter // allocated for the first highlighted pixel
+	for idx, pixel := range pixels {
+		st, ok := c.search.styled[pixel.PositionHash()]
+		if !ok {
+			if result != nil {
+				result = append(result, pixel)
+			}
+			continue
+		}
+		if result == nil {
+			result = make([]term.PixelGetter, idx, len(pixels))
+			copy(result, pixels[:idx])
+		}
+		result = append(result, &highlightedPixel{PixelGetter: pixel, st: st})
+	}
+	if result == nil {
+		return pixels
+	}
+	return result
+}
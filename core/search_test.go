@@ -0,0 +1,79 @@
+package core
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+// setRows replaces the active pixels with the rows of text
+func setRows(c *core, rows ...string) {
+	c.content = make(map[int]term.Pixel)
+	for row, text := range rows {
+		for column, r := range []rune(text) {
+			pixel := &activePixel{regionPixel: &regionPixel{hash: term.Hash(column, row), r: r}}
+			c.content[pixel.hash] = pixel
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+	setRows(c, "café error", "ok", "error again")
+
+	highlight := style.Style{Fg: color.Default, Bg: color.Yellow}
+	if count := c.Search(regexp.MustCompile("error"), highlight); count != 2 {
+		t.Fatalf("error : expecting two matches, got %d", count)
+	}
+	if out := written(); strings.Count(out, "error") != 2 || strings.Contains(out, "ok") || strings.Contains(out, "caf") {
+		t.Errorf("error : only the matches should be drawn again, got %q", out)
+	}
+	styleAt := func(column, row int) (color.Color, style.Mask) {
+		drawn := c.searched([]term.PixelGetter{c.content[term.Hash(column, row)]})
+		_, bg, attrs := drawn[0].Style()
+		return bg, attrs
+	}
+	if bg, _ := styleAt(5, 0); bg != color.Yellow {
+		t.Errorf("error : the match following the accented rune should start on the sixth column")
+	}
+	if bg, _ := styleAt(4, 0); bg == color.Yellow {
+		t.Errorf("error : the space before the match should not be highlighted")
+	}
+
+	// navigation wraps around, the current match being reversed
+	for _, expected := range []term.SearchMatch{{Column: 5, Row: 0, Columns: 5}, {Column: 0, Row: 2, Columns: 5}, {Column: 5, Row: 0, Columns: 5}} {
+		if match, ok := c.NextMatch(); !ok || match != expected {
+			t.Errorf("error : expecting next match %+v, got %+v", expected, match)
+		}
+	}
+	if match, ok := c.PreviousMatch(); !ok || match.Row != 2 {
+		t.Errorf("error : previous match should wrap around to the last row, got %+v", match)
+	}
+	if _, attrs := styleAt(0, 2); attrs&style.Reverse == 0 {
+		t.Errorf("error : the current match should be reversed")
+	}
+	if _, attrs := styleAt(5, 0); attrs&style.Reverse != 0 {
+		t.Errorf("error : the other matches should not be reversed")
+	}
+
+	// the viewport has scrolled : the highlights follow the content
+	setRows(c, "ok", "error again")
+	c.Redraw(nil)
+	if len(c.search.matches) != 1 || c.search.matches[0].Row != 1 {
+		t.Errorf("error : the screen should be scanned again on redraw, got %+v", c.search.matches)
+	}
+
+	written()
+	c.ClearSearch()
+	if out := written(); !strings.Contains(out, "error") || len(c.search.styled) != 0 {
+		t.Errorf("error : clearing should draw the matches without highlight, got %q", out)
+	}
+	if _, ok := c.NextMatch(); ok {
+		t.Errorf("error : there should be no match without a search")
+	}
+}
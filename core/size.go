@@ -0,0 +1,69 @@
+package core
+
+import (
+	"os"
+	"strconv"
+)
+
+const (
+	defaultColumns = 80 // used when neither the terminal, $COLUMNS or the terminal definition provide the number of columns
+	defaultRows    = 24 // used when neither the terminal, $LINES or the terminal definition provide the number of rows
+)
+
+// WithSize is a functional option to force the size of the screen, ignoring the one reported by the terminal (e.g. dumb serial consoles).
+func WithSize(columns, rows int) Option {
+	return func(c *core) {
+		if columns > 0 && rows > 0 {
+			c.forcedColumns, c.forcedRows = columns, rows
+		}
+	}
+}
+
+// windowSize returns the size of the screen : the one forced by WithSize, the one reported by the terminal, $COLUMNS and $LINES, the terminal definition, then defaults - locked inside caller function
+func (c *core) windowSize() (int, int) {
+	if c.forcedColumns > 0 && c.forcedRows > 0 {
+		return c.forcedColumns, c.forcedRows
+	}
+	columns, rows := 0, 0
+	if c.transport != nil {
+		var err error
+		if columns, rows, err = c.transport.WindowSize(); err != nil {
+			if Debug.Enabled() {
+				Debug.Printf("error in transport size reader : %v", err)
+			}
+			columns, rows = 0, 0
+		}
+	} else if c.out != nil {
+		var err error
+		if columns, rows, err = c.readWinSize(); err != nil {
+			if Debug.Enabled() {
+				Debug.Printf("error in win size reader : %v", err)
+			}
+			columns, rows = 0, 0
+		}
+	}
+	if columns <= 0 {
+		columns = envSize("COLUMNS", c.comm.Columns, defaultColumns)
+	}
+	if rows <= 0 {
+		rows = envSize("LINES", c.comm.Lines, defaultRows)
+	}
+	return columns, rows
+}
+
+// updateSize reads the size of the screen and remembers it - locked inside caller function
+func (c *core) updateSize() {
+	columns, rows := c.windowSize()
+	c.resize(columns, rows, false)
+}
+
+// envSize reads a dimension from the environment variable, falling back to the terminal definition value, then to the default
+func envSize(name string, definition, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(name)); err == nil && value > 0 {
+		return value
+	}
+	if definition > 0 {
+		return definition
+	}
+	return defaultValue
+}
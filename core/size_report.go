@@ -0,0 +1,54 @@
+package core
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/badu/term"
+)
+
+const (
+	sizeQuery  = "\x1b[18t" // CSI 18 t : asks the terminal for the size of the text area in characters
+	sizeReport = "\x1b[8;"  // prefix of the reply, ESC [ 8 ; rows ; columns t
+)
+
+// parseSizeReport handles the text area size report, returning the number of bytes consumed or -1 if it's incomplete
+func (c *core) parseSizeReport(report []byte) int {
+	rows, columns, consumed := parseWindowOpsReply(report)
+	if consumed <= 0 || rows <= 0 || columns <= 0 {
+		return consumed
+	}
+	c.windows.deliver(windowCells, rows, columns)
+	select {
+	case c.sizeReportCh <- &term.Size{Columns: columns, Rows: rows}:
+	default: // the previous report wasn't handled yet, so we drop this one
+	}
+	return consumed
+}
+
+// parseWindowOpsReply parses a reply to a window query, ESC [ kind ; first ; second t, returning the number of bytes consumed, -1 if it's incomplete and zero if it's something else (e.g. ESC [ 3 ; 5 ~ is a key)
+func parseWindowOpsReply(report []byte) (int, int, int) {
+	end := 2
+	for end < len(report) && (report[end] >= '0' && report[end] <= '9' || report[end] == ';') {
+		end++
+	}
+	if end >= len(report) {
+		return 0, 0, -1
+	}
+	if report[end] != 't' {
+		return 0, 0, 0
+	}
+	fields := bytes.Split(report[2:end], []byte{';'})
+	if len(fields) != 3 {
+		return 0, 0, end + 1 // a reply we don't know, removed anyway
+	}
+	first, err := strconv.Atoi(string(fields[1]))
+	if err != nil {
+		return 0, 0, end + 1
+	}
+	second, err := strconv.Atoi(string(fields[2]))
+	if err != nil {
+		return 0, 0, end + 1
+	}
+	return first, second, end + 1
+}
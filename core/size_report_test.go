@@ -0,0 +1,56 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/badu/term"
+)
+
+func TestSizeReport(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		reads    []string
+		expected string
+		size     *term.Size
+	}{
+		{name: "report", reads: []string{"\x1b[8;30;100t"}, size: &term.Size{Columns: 100, Rows: 30}},
+		{name: "between keys", reads: []string{"a\x1b[8;30;100tb"}, expected: "ab", size: &term.Size{Columns: 100, Rows: 30}},
+		{name: "split", reads: []string{"\x1b[8;3", "0;100tb"}, expected: "b", size: &term.Size{Columns: 100, Rows: 30}},
+		{name: "key", reads: []string{"\x1b[3;5~"}, expected: "\x1b[3;5~"},
+		{name: "unknown reply", reads: []string{"\x1b[8;1;2;3tb"}, expected: "b"},
+		{name: "empty size", reads: []string{"\x1b[8;0;100tb"}, expected: "b"},
+	} {
+		c := newBenchCore(t)
+		out := ""
+		for _, read := range tc.reads {
+			out += string(c.reports.filter([]byte(read)))
+		}
+		if out != tc.expected {
+			t.Errorf("error : %s : expecting %q to remain, got %q", tc.name, tc.expected, out)
+		}
+		var size *term.Size
+		select {
+		case size = <-c.sizeReportCh:
+		default:
+		}
+		switch {
+		case tc.size == nil && size != nil:
+			t.Errorf("error : %s : no size should be reported, got %d x %d", tc.name, size.Columns, size.Rows)
+		case tc.size != nil && (size == nil || *size != *tc.size):
+			t.Errorf("error : %s : expecting %d x %d to be reported, got %v", tc.name, tc.size.Columns, tc.size.Rows, size)
+		}
+	}
+}
+
+func TestSizeReportDropped(t *testing.T) {
+	c := newBenchCore(t)
+	c.reports.filter([]byte("\x1b[8;30;100t\x1b[8;31;101t"))
+	if size := <-c.sizeReportCh; size.Columns != 100 || size.Rows != 30 {
+		t.Errorf("error : the pending report should be kept, got %d x %d", size.Columns, size.Rows)
+	}
+	select {
+	case size := <-c.sizeReportCh:
+		t.Errorf("error : the report arriving while one is pending should be dropped, got %d x %d", size.Columns, size.Rows)
+	default:
+	}
+}
@@ -0,0 +1,33 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestWindowSize(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		opts            []Option
+		columns, lines  string
+		expectedColumns int
+		expectedRows    int
+	}{
+		{name: "definition", expectedColumns: 80, expectedRows: 24},
+		{name: "environment", columns: "132", lines: "43", expectedColumns: 132, expectedRows: 43},
+		{name: "only columns", columns: "132", expectedColumns: 132, expectedRows: 24},
+		{name: "invalid environment", columns: "wide", lines: "-3", expectedColumns: 80, expectedRows: 24},
+		{name: "defaults", opts: []Option{WithCapabilityOverrides(map[string]string{"cols": "", "lines": ""})}, expectedColumns: defaultColumns, expectedRows: defaultRows},
+		{name: "other definition", opts: []Option{WithCapabilityOverrides(map[string]string{"cols": "100", "lines": "30"})}, expectedColumns: 100, expectedRows: 30},
+		{name: "forced", opts: []Option{WithSize(40, 12)}, columns: "132", lines: "43", expectedColumns: 40, expectedRows: 12},
+		{name: "forced invalid", opts: []Option{WithSize(40, 0)}, columns: "132", lines: "43", expectedColumns: 132, expectedRows: 43},
+	} {
+		c := newBenchCore(t, tc.opts...)
+		setEnv(t, "COLUMNS", tc.columns)
+		setEnv(t, "LINES", tc.lines)
+		c.out = nil // the size can't be read from the terminal
+		c.updateSize()
+		if size := c.Size(); size.Columns != tc.expectedColumns || size.Rows != tc.expectedRows {
+			t.Errorf("error : %s : expecting %d x %d, got %d x %d", tc.name, tc.expectedColumns, tc.expectedRows, size.Columns, size.Rows)
+		}
+	}
+}
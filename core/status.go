@@ -0,0 +1,147 @@
+package core
+
+import (
+	"bytes"
+	"unicode"
+
+	"github.com/badu/term"
+	"github.com/badu/term/style"
+)
+
+// statusLine holds the status set by SetStatus, which is not part of the pixels grid
+type statusLine struct {
+	text     string      //
+	st       style.Style // used for the reserved bottom row, the status line of the terminal having its own look
+	shown    bool        // true after SetStatus, until ClearStatus
+	reserved bool        // true if the bottom row of the screen is reserved for the status
+}
+
+// HasStatusLine implements term.StatusLiner interface
+func (c *core) HasStatusLine() bool {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.hasStatusLine()
+}
+
+// SetStatus implements term.StatusLiner interface
+func (c *core) SetStatus(text string, st style.Style) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.setStatus(text, st)
+}
+
+// setStatus shows the text on the status - locked inside caller function
+func (c *core) setStatus(text string, st style.Style) {
+	c.status.text, c.status.st, c.status.shown = text, st, true
+	if c.plain {
+		return // nowhere to show it
+	}
+	if !c.hasStatusLine() {
+		c.reserveStatusRow(true)
+	}
+	c.drawStatus()
+}
+
+// ClearStatus implements term.StatusLiner interface
+func (c *core) ClearStatus() {
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.status.shown {
+		return
+	}
+	c.status.text, c.status.shown = "", false
+	if c.plain {
+		return
+	}
+	if c.hasStatusLine() {
+		c.eraseStatusLine()
+		return
+	}
+	c.drawStatus() // blanks the row, which the application gets back
+	c.reserveStatusRow(false)
+}
+
+// hasStatusLine returns true if the terminal can show the status without reserving a row - locked inside caller function
+func (c *core) hasStatusLine() bool {
+	return c.comm.Has("tsl") && c.comm.Has("fsl")
+}
+
+// reserveStatusRow takes the bottom row away from the pages (or gives it back), dispatching the new size - locked inside caller function
+func (c *core) reserveStatusRow(reserved bool) {
+	if c.status.reserved == reserved {
+		return
+	}
+	c.status.reserved = reserved
+	c.relayout()
+}
+
+// drawStatus writes the status, using the status line of the terminal or the reserved bottom row - locked inside caller function
+func (c *core) drawStatus() {
+	if c.out == nil || c.plain || c.size == nil || c.screenColumns <= 0 {
+		return
+	}
+	if c.hasStatusLine() {
+		if c.status.shown {
+			c.writeStatusLine()
+		}
+		return
+	}
+	if !c.status.reserved {
+		return
+	}
+
+	runes := []rune(c.status.text)
+	pixels := make([]term.PixelGetter, c.screenColumns)
+	for column := range pixels {
+		r := ' '
+		if column < len(runes) && unicode.IsPrint(runes[column]) {
+			r = runes[column]
+		}
+		pixels[column] = &regionPixel{hash: term.Hash(column, 0), r: r, st: c.status.st}
+	}
+	buf := bytes.NewBuffer(nil)
+	c.drawIn(buf, area{top: c.screenRows - 1, columns: c.screenColumns, rows: 1, toEOL: true}, pixels...)
+	c.writeOut(buf)
+	c.restoreCursor()
+}
+
+// writeStatusLine writes the text on the status line of the terminal - locked inside caller function
+func (c *core) writeStatusLine() {
+	buf := bytes.NewBuffer(nil)
+	if err := c.comm.Put(buf, "tsl", 0); err != nil {
+		return
+	}
+	encoded := make([]byte, 0, len(c.status.text))
+	for _, r := range c.status.text {
+		if unicode.IsPrint(r) {
+			encoded = c.encoder.encodeRune(r, encoded)
+		}
+	}
+	buf.Write(encoded)
+	c.comm.Put(buf, "fsl")
+	c.writeOut(buf)
+}
+
+// eraseStatusLine empties the status line of the terminal, or hides it if the terminal can (dsl) - locked inside caller function
+func (c *core) eraseStatusLine() {
+	if c.out == nil || !c.hasStatusLine() {
+		return
+	}
+	if c.comm.Has("dsl") {
+		c.comm.Put(c.out, "dsl")
+		return
+	}
+	c.writeStatusLine() // the text was emptied
+}
+
+// writeOut writes the buffer to output - locked inside caller function
+func (c *core) writeOut(buf *bytes.Buffer) {
+	if _, err := buf.WriteTo(c.out); err != nil {
+		if Debug.Enabled() {
+			Debug.Printf("error writing to out : " + err.Error())
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+var csi = regexp.MustCompile(`\x1b(\[[0-9;]*[A-Za-z]|\(B)`)
+
+// captureOut replaces the output with a temporary file, returning a function which reads what was written since the previous call
+func captureOut(t *testing.T, c *core) func() string {
+	out, err := ioutil.TempFile(t.TempDir(), "out")
+	if err != nil {
+		t.Fatalf("error creating output : %v", err)
+	}
+	t.Cleanup(func() { _ = out.Close() })
+	c.out = out
+	read := 0
+	return func() string {
+		content, err := ioutil.ReadFile(out.Name())
+		if err != nil {
+			t.Fatalf("error reading output : %v", err)
+		}
+		result := string(content[read:])
+		read = len(content)
+		return result
+	}
+}
+
+func TestStatusReservedRow(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+	if c.HasStatusLine() {
+		t.Fatalf("error : xterm-256color should not have a status line")
+	}
+
+	c.SetStatus("ready", style.Style{Fg: color.White, Bg: color.Blue})
+	if rows := c.Size().Rows; rows != benchRows-1 {
+		t.Errorf("error : the bottom row should be reserved, got %d rows", rows)
+	}
+	out := written()
+	if !strings.HasPrefix(out, "\x1b[50;1H") || csi.ReplaceAllString(out, "") != "ready" {
+		t.Errorf("error : the status should be written on the bottom row, got %q", out)
+	}
+
+	// the resize keeps the row reserved, at the new bottom
+	c.resize(benchColumns, benchRows+10, false)
+	if rows := c.Size().Rows; rows != benchRows+9 {
+		t.Errorf("error : the bottom row should stay reserved, got %d rows", rows)
+	}
+	c.drawStatus()
+	if out := written(); !strings.Contains(out, "\x1b[60;1H") {
+		t.Errorf("error : the status should be moved to the new bottom row, got %q", out)
+	}
+
+	c.ClearStatus()
+	if rows := c.Size().Rows; rows != benchRows+10 {
+		t.Errorf("error : the bottom row should be given back, got %d rows", rows)
+	}
+	if out := written(); strings.Contains(out, "ready") {
+		t.Errorf("error : the status should be erased, got %q", out)
+	}
+}
+
+func TestStatusLine(t *testing.T) {
+	c := newBenchCore(t, WithCapabilityOverrides(map[string]string{"tsl": "\x1b]2;", "fsl": "\x07"}))
+	written := captureOut(t, c)
+	if !c.HasStatusLine() {
+		t.Fatalf("error : the terminal should have a status line")
+	}
+
+	c.SetStatus("ready", style.Style{})
+	if rows := c.Size().Rows; rows != benchRows {
+		t.Errorf("error : no row should be reserved, got %d rows", rows)
+	}
+	if out := written(); out != "\x1b]2;ready\x07" {
+		t.Errorf("error : the status should be written on the status line, got %q", out)
+	}
+	c.ClearStatus()
+	if out := written(); out != "\x1b]2;\x07" {
+		t.Errorf("error : the status line should be emptied, got %q", out)
+	}
+}
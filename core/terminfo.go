@@ -0,0 +1,60 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/badu/term/info"
+)
+
+const (
+	defaultFallbackTerm = "xterm-256color" // what most of the terminal emulators are compatible with
+)
+
+// WithFallbackTerminal is a functional option for the terminal definition used when the one named by $TERM can't be found, neither registered nor loaded via infocmp.
+// The fallback is recorded by the diagnostics (see WithDiagnostics), and logged when debugging. An empty name makes NewCore fail instead. Default is "xterm-256color".
+func WithFallbackTerminal(name string) Option {
+	return func(c *core) {
+		c.fallbackTerm = name
+	}
+}
+
+// lookupTerminfo finds the terminal definition : the registered entries first, then the dynamic loading of the name, of the alias target and of the bases of a -truecolor name
+func lookupTerminfo(name string) (*info.Term, error) {
+	if ti, err := info.LookupTerminfo(name); err == nil {
+		return ti, nil
+	}
+	ti, err := loadDynamicTerminfo(name)
+	if err == nil {
+		info.AddTrueColor(ti, false)
+		return ti, nil
+	}
+	if resolved := info.ResolveAlias(name); resolved != name {
+		if ti, aliasErr := loadDynamicTerminfo(resolved); aliasErr == nil { // an alias registered via info.AddAlias
+			info.AddTrueColor(ti, false)
+			return ti, nil
+		}
+	}
+	for _, base := range info.TrueColorBases(name) {
+		if ti, baseErr := loadDynamicTerminfo(base); baseErr == nil {
+			info.AddTrueColor(ti, true)
+			return ti, nil
+		}
+	}
+	return nil, fmt.Errorf("%w : %v", info.NotFound, err)
+}
+
+// fallbackTerminfo returns the definition of the fallback terminal, or the lookup error if there is none
+func (c *core) fallbackTerminfo(name string, lookupErr error) (*info.Term, error) {
+	if c.fallbackTerm == "" {
+		return nil, lookupErr
+	}
+	ti, err := lookupTerminfo(c.fallbackTerm)
+	if err != nil {
+		return nil, lookupErr
+	}
+	if Debug.Enabled() {
+		Debug.Printf("warning : terminal %q not found (%v), using %q", name, lookupErr, c.fallbackTerm)
+	}
+	c.degrade("terminal %q not found, using %q", name, c.fallbackTerm)
+	return ti, nil
+}
@@ -0,0 +1,39 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/badu/term/info"
+)
+
+func TestFallbackTerminal(t *testing.T) {
+	newBenchCore(t) // sets the environment
+	logged := &bytes.Buffer{}
+	log.SetOutput(logged)
+	defer log.SetOutput(os.Stderr)
+
+	engine, err := NewCore("no-such-terminal", WithDiagnostics(true), WithPlainOutput(false))
+	if err != nil {
+		t.Skipf("no definition for the fallback terminal : %v", err)
+	}
+	degradations := engine.(*core).Degradations()
+	if len(degradations) == 0 || !strings.Contains(degradations[0].Reason, `terminal "no-such-terminal" not found, using "`+defaultFallbackTerm+`"`) {
+		t.Errorf("error : the fallback should be recorded by the diagnostics, got %v", degradations)
+	}
+	if logged.Len() > 0 {
+		t.Errorf("error : nothing should be logged outside the debug mode, got %q", logged.String())
+	}
+}
+
+func TestCapabilityOverridesUnknown(t *testing.T) {
+	newBenchCore(t) // registers the terminal, and sets the environment
+	_, err := NewCore("xterm-256color", WithTerminfo(benchInfo), WithPlainOutput(false), WithCapabilityOverrides(map[string]string{"smcpu": ""}))
+	if !errors.Is(err, info.ErrUnknownCapability) {
+		t.Errorf("error : a misspelled capability should be refused, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+//go:build linux || freebsd || netbsd || openbsd || dragonfly || solaris || illumos || darwin
+// +build linux freebsd netbsd openbsd dragonfly solaris illumos darwin
+
+package core
+
+import (
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestInternalStart(t *testing.T) {
+	master, slave := openPTY(t)
+	if err := unix.IoctlSetWinsize(int(master.Fd()), unix.TIOCSWINSZ, &unix.Winsize{Col: 120, Row: 40}); err != nil {
+		t.Fatalf("error sizing the pseudo terminal : %v", err)
+	}
+	c := newBenchCore(t)
+	c.ttyPath = slave
+	if err := c.internalStart(); err != nil {
+		t.Fatalf("error starting on %s : %v", slave, err)
+	}
+	if size := c.Size(); size.Columns != 120 || size.Rows != 40 {
+		t.Errorf("error : the size should be read from the terminal, got %d x %d", size.Columns, size.Rows)
+	}
+	tio, err := unix.IoctlGetTermios(int(c.tty.Fd()), getTermios)
+	if err != nil {
+		t.Fatalf("error reading termios : %v", err)
+	}
+	if tio.Lflag&(unix.ICANON|unix.ECHO) != 0 || tio.Oflag&unix.OPOST != 0 {
+		t.Errorf("error : the terminal should be in raw mode")
+	}
+
+	if _, err := c.out.Write([]byte("ok")); err != nil {
+		t.Fatalf("error writing : %v", err)
+	}
+	read := make([]byte, 2)
+	if n, err := master.Read(read); err != nil || string(read[:n]) != "ok" {
+		t.Errorf("error : the output should reach the terminal, got %q (%v)", read[:n], err)
+	}
+
+	if err := c.internalShutdown(); err != nil {
+		t.Fatalf("error shutting down : %v", err)
+	}
+	tio, err = unix.IoctlGetTermios(int(master.Fd()), getTermios)
+	if err != nil {
+		t.Fatalf("error reading termios : %v", err)
+	}
+	if tio.Lflag&unix.ICANON == 0 {
+		t.Errorf("error : the terminal settings should be restored")
+	}
+}
@@ -1,3 +1,4 @@
+//go:build !term_minimal
 // +build !term_minimal
 
 package core
@@ -1,3 +1,4 @@
+//go:build term_minimal || nacl || js || zos || plan9 || windows || android
 // +build term_minimal nacl js zos plan9 windows android
 
 package core
@@ -0,0 +1,228 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+)
+
+const (
+	backgroundQuery     = "\x1b]11;?\x07" // OSC 11 : asks the terminal for the background color
+	enableThemeReports  = "\x1b[?2031h"   // DEC private mode 2031 : the terminal reports theme changes (contour, kitty, ghostty, etc.)
+	disableThemeReports = "\x1b[?2031l"   //
+)
+
+const (
+	backgroundReport = "\x1b]11;"   // prefix of the OSC 11 reply, e.g. ESC ] 11 ; rgb:RRRR/GGGG/BBBB BEL
+	themeReport      = "\x1b[?997;" // prefix of the theme change report, ESC [ ? 997 ; 1 n for dark and ESC [ ? 997 ; 2 n for light
+)
+
+// EventTheme is sent when the terminal reports its background color
+type EventTheme struct {
+	background color.Color
+	dark       bool
+	when       time.Time
+}
+
+// Background implements term.ThemeEvent interface
+func (e *EventTheme) Background() color.Color {
+	return e.background
+}
+
+// IsDark implements term.ThemeEvent interface
+func (e *EventTheme) IsDark() bool {
+	return e.dark
+}
+
+// When implements term.Event interface
+func (e *EventTheme) When() time.Time {
+	return e.when
+}
+
+// themeWatcher extracts the background and theme reports from the input, and dispatches theme events to listeners
+type themeWatcher struct {
+	sync.Mutex                        // guards other properties
+	ctx        context.Context        //
+	receivers  []chan term.ThemeEvent // listeners of theme events
+	background color.Color            // the last reported background
+	dark       bool                   // true if the background is dark
+	known      bool                   // true if the terminal has reported the background
+	requery    func()                 // asks the terminal for the background, after a theme change report
+}
+
+// Register implements term.ThemeDispatcher interface
+func (t *themeWatcher) Register(r term.ThemeListener) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.ctx == nil {
+		if Debug.Enabled() {
+			Debug.Println("context not set : cannot listen context.Done()")
+		}
+		return
+	}
+	if r.ThemeListen() == nil {
+		if Debug.Enabled() {
+			Debug.Println("error : ThemeListen chan is nil")
+		}
+		return
+	}
+	for _, ch := range t.receivers {
+		if ch == r.ThemeListen() {
+			return // already registered
+		}
+	}
+	t.receivers = append(t.receivers, r.ThemeListen())
+	// mounting a go routine to listen bye-bye when the listener's context get cancelled
+	go func() {
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-r.DyingChan():
+			t.Lock()
+			defer t.Unlock()
+			for idx, ch := range t.receivers {
+				if ch == r.ThemeListen() {
+					t.receivers = append(t.receivers[:idx], t.receivers[idx+1:]...)
+					break
+				}
+			}
+		}
+	}()
+}
+
+// isDark returns the last known darkness of the background
+func (t *themeWatcher) isDark() (bool, bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.dark, t.known
+}
+
+// parseBackground handles the OSC 11 reply, returning the number of bytes consumed or -1 if it's incomplete
+func (t *themeWatcher) parseBackground(report []byte) int {
+	end, size := oscEnd(report)
+	if end < 0 {
+		return -1
+	}
+	t.Lock()
+	ev := t.setBackground(report[len(backgroundReport):end])
+	receivers := make([]chan term.ThemeEvent, len(t.receivers))
+	copy(receivers, t.receivers)
+	t.Unlock()
+
+	if ev != nil {
+		for _, cons := range receivers {
+			cons <- ev
+		}
+	}
+	return end + size
+}
+
+// parseThemeChange handles the theme change report, returning the number of bytes consumed or -1 if it's incomplete
+func (t *themeWatcher) parseThemeChange(report []byte) int {
+	end := bytes.IndexByte(report, 'n')
+	if end < 0 {
+		return -1
+	}
+	t.Lock()
+	switch string(report[len(themeReport):end]) {
+	case "1":
+		t.dark, t.known = true, true
+	case "2":
+		t.dark, t.known = false, true
+	}
+	t.Unlock()
+
+	if t.requery != nil {
+		t.requery() // the terminal also tells us the new background color
+	}
+	return end + 1
+}
+
+// setBackground parses the color of the OSC 11 reply and returns an event if the background has changed - locked inside caller function
+func (t *themeWatcher) setBackground(spec []byte) term.ThemeEvent {
+	c, ok := parseXColor(string(spec))
+	if !ok {
+		if Debug.Enabled() {
+			Debug.Printf("cannot parse background color %q", spec)
+		}
+		return nil
+	}
+	if t.known && t.background == c {
+		return nil
+	}
+	t.background, t.dark, t.known = c, color.Light(c) < 0.5, true
+	return &EventTheme{background: t.background, dark: t.dark, when: time.Now()}
+}
+
+// oscEnd returns the index of the OSC terminator (BEL or ST) and its size, or -1 if the sequence is incomplete
+func oscEnd(b []byte) (int, int) {
+	for idx := range b {
+		switch b[idx] {
+		case '\x07':
+			return idx, 1
+		case '\x1b':
+			if idx+1 < len(b) && b[idx+1] == '\\' {
+				return idx, 2
+			}
+		}
+	}
+	return -1, 0
+}
+
+// parseXColor parses the X11 color specification, "rgb:R/G/B" where each component has one to four hex digits
+func parseXColor(spec string) (color.Color, bool) {
+	const prefix = "rgb:"
+	if len(spec) < len(prefix) || spec[:len(prefix)] != prefix {
+		return color.Default, false
+	}
+	var components [3]int32
+	parts := bytes.Split([]byte(spec[len(prefix):]), []byte("/"))
+	if len(parts) != 3 {
+		return color.Default, false
+	}
+	for idx, part := range parts {
+		if len(part) == 0 || len(part) > 4 {
+			return color.Default, false
+		}
+		value, err := strconv.ParseUint(string(part), 16, 16)
+		if err != nil {
+			return color.Default, false
+		}
+		maximum := uint64(1)<<(4*uint(len(part))) - 1
+		components[idx] = int32(value * 255 / maximum)
+	}
+	return color.NewRGBColor(components[0], components[1], components[2]), true
+}
+
+// queryBackground asks the terminal for the background color. The reply comes via input, and it's handled by themeWatcher.
+func (c *core) queryBackground() {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.out == nil || c.plain {
+		return
+	}
+	if _, err := io.WriteString(c.out, backgroundQuery); err != nil {
+		if Debug.Enabled() {
+			Debug.Printf("error writing to out : %v", err)
+		}
+	}
+}
+
+// IsDarkBackground implements term.Engine interface. The second value is false if the terminal didn't report its background (yet).
+func (c *core) IsDarkBackground() (bool, bool) {
+	return c.theme.isDark()
+}
+
+// ThemeDispatcher implements term.Engine interface, exposes so call to Register(r ThemeListener) method
+func (c *core) ThemeDispatcher() term.ThemeDispatcher {
+	return c.theme
+}
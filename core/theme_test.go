@@ -0,0 +1,98 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+)
+
+// themeListener receives the theme events
+type themeListener struct {
+	ch   chan term.ThemeEvent //
+	died chan struct{}        //
+}
+
+func (l *themeListener) ThemeListen() chan term.ThemeEvent { return l.ch }
+func (l *themeListener) DyingChan() chan struct{}          { return l.died }
+
+func TestBackgroundReport(t *testing.T) {
+	black, white := color.NewRGBColor(0, 0, 0), color.NewRGBColor(0xFF, 0xFF, 0xFF)
+	for _, tc := range []struct {
+		name       string
+		reads      []string
+		expected   string
+		background color.Color
+		events     int
+		dark       bool
+		known      bool
+	}{
+		{name: "no reply", reads: []string{"ab"}, expected: "ab"},
+		{name: "dark", reads: []string{"\x1b]11;rgb:0000/0000/0000\x07"}, background: black, events: 1, dark: true, known: true},
+		{name: "light, string terminator", reads: []string{"a\x1b]11;rgb:ffff/ffff/ffff\x1b\\b"}, expected: "ab", background: white, events: 1, known: true},
+		{name: "short components", reads: []string{"\x1b]11;rgb:f/ff/fff\x07"}, background: white, events: 1, known: true},
+		{name: "split", reads: []string{"\x1b]11;rgb:00", "00/0000/0000\x07a"}, expected: "a", background: black, events: 1, dark: true, known: true},
+		{name: "same background", reads: []string{"\x1b]11;rgb:0000/0000/0000\x07", "\x1b]11;rgb:00/00/00\x07"}, background: black, events: 1, dark: true, known: true},
+		{name: "changed background", reads: []string{"\x1b]11;rgb:0000/0000/0000\x07", "\x1b]11;rgb:ffff/ffff/ffff\x07"}, background: white, events: 2, known: true},
+		{name: "invalid color", reads: []string{"\x1b]11;#000000\x07a"}, expected: "a"},
+	} {
+		c := newBenchCore(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		c.theme.ctx = ctx
+		listener := &themeListener{ch: make(chan term.ThemeEvent, 4), died: make(chan struct{})}
+		c.ThemeDispatcher().Register(listener)
+
+		out := ""
+		for _, read := range tc.reads {
+			out += string(c.reports.filter([]byte(read)))
+		}
+		if out != tc.expected {
+			t.Errorf("error : %s : expecting %q to remain, got %q", tc.name, tc.expected, out)
+		}
+		if len(listener.ch) != tc.events {
+			t.Errorf("error : %s : expecting %d events, got %d", tc.name, tc.events, len(listener.ch))
+		}
+		var last term.ThemeEvent
+		for len(listener.ch) > 0 {
+			last = <-listener.ch
+		}
+		if last != nil && (last.Background() != tc.background || last.IsDark() != tc.dark) {
+			t.Errorf("error : %s : expecting %s (dark %t), got %s (dark %t)", tc.name, tc.background, tc.dark, last.Background(), last.IsDark())
+		}
+		if dark, known := c.IsDarkBackground(); dark != tc.dark || known != tc.known {
+			t.Errorf("error : %s : expecting dark %t known %t, got dark %t known %t", tc.name, tc.dark, tc.known, dark, known)
+		}
+		cancel()
+	}
+}
+
+func TestThemeChangeReport(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+
+	// the theme change tells the darkness, then the background is queried again
+	if rest := c.reports.filter([]byte("\x1b[?997;1na")); string(rest) != "a" {
+		t.Errorf("error : unexpected input left %q", rest)
+	}
+	if dark, known := c.IsDarkBackground(); !dark || !known {
+		t.Errorf("error : the theme should be dark, got dark %t known %t", dark, known)
+	}
+	if out := written(); out != backgroundQuery {
+		t.Errorf("error : expecting the background to be queried, got %q", out)
+	}
+
+	c.reports.filter([]byte("\x1b[?997;2n"))
+	if dark, known := c.IsDarkBackground(); dark || !known {
+		t.Errorf("error : the theme should be light, got dark %t known %t", dark, known)
+	}
+
+	// a split report waits for the rest of it
+	if rest := c.reports.filter([]byte("\x1b[?997;")); len(rest) != 0 {
+		t.Errorf("error : the partial report should be kept, got %q", rest)
+	}
+	c.reports.filter([]byte("1n"))
+	if dark, _ := c.IsDarkBackground(); !dark {
+		t.Errorf("error : the theme should be dark after the rest of the report")
+	}
+}
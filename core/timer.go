@@ -0,0 +1,108 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/badu/term"
+)
+
+const minimumTickInterval = time.Millisecond // shorter intervals would only keep the listeners busy
+
+// EventTick is sent to the timer listeners, at the interval they've asked for
+type EventTick struct {
+	elapsed time.Duration
+	when    time.Time
+}
+
+// Elapsed implements term.TickEvent interface
+func (e *EventTick) Elapsed() time.Duration {
+	return e.elapsed
+}
+
+// When implements term.Event interface
+func (e *EventTick) When() time.Time {
+	return e.when
+}
+
+// timers dispatches tick events, each listener having its own ticker, stopped when the listener dies or the engine shuts down
+type timers struct {
+	sync.Mutex                                // guards other properties
+	ctx        context.Context                //
+	receivers  map[chan term.TickEvent]func() // the registered channels, with the cancellation of their tickers
+}
+
+// Register implements term.TimerDispatcher interface
+func (t *timers) Register(r term.TimerListener) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.ctx == nil {
+		if Debug.Enabled() {
+			Debug.Println("context not set : cannot listen context.Done()")
+		}
+		return
+	}
+	ch := r.TickListen()
+	if ch == nil {
+		if Debug.Enabled() {
+			Debug.Println("error : TickListen chan is nil")
+		}
+		return
+	}
+	if _, ok := t.receivers[ch]; ok {
+		return // already registered
+	}
+	interval := r.TickInterval()
+	if interval < minimumTickInterval {
+		interval = minimumTickInterval
+	}
+	ctx, cancel := context.WithCancel(t.ctx)
+	if t.receivers == nil {
+		t.receivers = make(map[chan term.TickEvent]func())
+	}
+	t.receivers[ch] = cancel
+	go t.tick(ctx, r, ch, interval)
+}
+
+// tick sends the events until the listener dies. A listener which is slow to receive them misses the ticks in between, instead of getting them late.
+func (t *timers) tick(ctx context.Context, r term.TimerListener, ch chan term.TickEvent, interval time.Duration) {
+	defer t.forget(ch)
+
+	started := time.Now()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.DyingChan():
+			return
+		case now := <-ticker.C:
+			select {
+			case ch <- &EventTick{elapsed: now.Sub(started), when: now}:
+			case <-ctx.Done():
+				return
+			case <-r.DyingChan():
+				return
+			}
+		}
+	}
+}
+
+// forget removes the listener, after its ticker has stopped
+func (t *timers) forget(ch chan term.TickEvent) {
+	t.Lock()
+	defer t.Unlock()
+
+	if cancel, ok := t.receivers[ch]; ok {
+		cancel()
+		delete(t.receivers, ch)
+	}
+}
+
+// TimerDispatcher implements term.Engine interface, exposes so call to Register(r TimerListener) method
+func (c *core) TimerDispatcher() term.TimerDispatcher {
+	return c.timers
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/badu/term"
+)
+
+type tickListener struct {
+	ch       chan term.TickEvent
+	died     chan struct{}
+	interval time.Duration
+}
+
+func (l *tickListener) DyingChan() chan struct{}        { return l.died }
+func (l *tickListener) TickListen() chan term.TickEvent { return l.ch }
+func (l *tickListener) TickInterval() time.Duration     { return l.interval }
+
+func TestTimers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dispatcher := &timers{ctx: ctx}
+
+	fast := &tickListener{ch: make(chan term.TickEvent), died: make(chan struct{}), interval: 5 * time.Millisecond}
+	slow := &tickListener{ch: make(chan term.TickEvent), died: make(chan struct{}), interval: time.Hour}
+	dispatcher.Register(fast)
+	dispatcher.Register(fast) // ignored
+	dispatcher.Register(slow)
+
+	var previous time.Duration
+	for idx := 0; idx < 3; idx++ {
+		select {
+		case ev := <-fast.ch:
+			if ev.Elapsed() <= previous {
+				t.Errorf("error : the elapsed time should grow, got %v after %v", ev.Elapsed(), previous)
+			}
+			previous = ev.Elapsed()
+		case <-slow.ch:
+			t.Fatalf("error : each listener should tick at its own interval")
+		case <-time.After(time.Second):
+			t.Fatalf("error : tick %d not received", idx)
+		}
+	}
+
+	close(fast.died)
+	deadline := time.Now().Add(time.Second)
+	for {
+		dispatcher.Lock()
+		registered := len(dispatcher.receivers)
+		dispatcher.Unlock()
+		if registered == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("error : the dead listener should be forgotten, %d still registered", registered)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	cancel()
+	deadline = time.Now().Add(time.Second)
+	for {
+		dispatcher.Lock()
+		registered := len(dispatcher.receivers)
+		dispatcher.Unlock()
+		if registered == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("error : the tickers should stop on shutdown")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
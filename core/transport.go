@@ -0,0 +1,44 @@
+package core
+
+import (
+	"io"
+)
+
+// Transport is a terminal reached through another channel than the controlling one, e.g. a browser (see package bridge) or a SSH session.
+// The engine reads the input from it and writes the output to it, instead of using /dev/tty, so the termios settings and the SIGWINCH signals of the process are left alone.
+type Transport interface {
+	io.Reader
+	io.Writer
+	WindowSize() (int, int, error) // returns the columns and the rows of the remote screen
+	NotifyResize(fn func())        // the function has to be called each time the remote screen is resized
+}
+
+// WithTransport is a functional option for using the transport as the terminal. The plain mode is disabled, since the output of the process doesn't matter anymore.
+func WithTransport(t Transport) Option {
+	return func(c *core) {
+		c.transport = t
+		c.plain, c.plainSet = false, true
+	}
+}
+
+// resizeSignal is sent on the resize signals channel, when the transport reports a resize
+type resizeSignal struct{}
+
+// String implements os.Signal interface
+func (resizeSignal) String() string { return "transport resized" }
+
+// Signal implements os.Signal interface
+func (resizeSignal) Signal() {}
+
+// transportStart is the equivalent of internalStart, when the terminal is a transport
+func (c *core) transportStart() error {
+	c.out = c.mirrored(c.metered(c.guarded(c.transport)))
+	c.transport.NotifyResize(func() {
+		select {
+		case c.winSizeCh <- resizeSignal{}:
+		default: // a resize is already pending, reading the latest size anyway
+		}
+	})
+	c.updateSize()
+	return nil
+}
@@ -0,0 +1,173 @@
+package core
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/badu/term"
+)
+
+const (
+	windowTimeout = 500 * time.Millisecond // how long the window queries wait for the reply
+
+	windowPositionQuery  = "\x1b[13t"   // CSI 13 t : asks for the position of the window, in pixels
+	windowPositionReport = "\x1b[3;"    // prefix of the reply, ESC [ 3 ; x ; y t
+	windowPixelsQuery    = "\x1b[14t"   // CSI 14 t : asks for the size of the text area, in pixels
+	windowPixelsReport   = "\x1b[4;"    // prefix of the reply, ESC [ 4 ; height ; width t
+	titlePush            = "\x1b[22;0t" // CSI 22 ; 0 t : saves the window and icon titles on the stack of the terminal
+	titlePop             = "\x1b[23;0t" // CSI 23 ; 0 t : restores the titles saved by the last push
+
+	windowOps = "XTWINOPS" // the feature reported by term.ErrNotSupported, for the terminals which don't have the window operations
+
+	windowPosition = '3' // the kind of the replies, the first parameter
+	windowPixels   = '4' //
+	windowCells    = '8' //
+)
+
+// windowReply holds the two numbers of a reply to a window query
+type windowReply struct {
+	first  int
+	second int
+}
+
+// windowWatcher delivers the replies to the window queries, to the callers waiting for them
+type windowWatcher struct {
+	sync.Mutex                             // guards other properties
+	waiting    map[byte][]chan windowReply // by the kind of the reply
+}
+
+// wait registers a caller waiting for the reply
+func (w *windowWatcher) wait(kind byte) chan windowReply {
+	w.Lock()
+	defer w.Unlock()
+
+	if w.waiting == nil {
+		w.waiting = make(map[byte][]chan windowReply)
+	}
+	ch := make(chan windowReply, 1)
+	w.waiting[kind] = append(w.waiting[kind], ch)
+	return ch
+}
+
+// forget removes the caller which has given up waiting
+func (w *windowWatcher) forget(kind byte, ch chan windowReply) {
+	w.Lock()
+	defer w.Unlock()
+
+	waiting := w.waiting[kind]
+	for idx, other := range waiting {
+		if other == ch {
+			w.waiting[kind] = append(waiting[:idx], waiting[idx+1:]...)
+			return
+		}
+	}
+}
+
+// deliver passes the reply to all the callers waiting for it
+func (w *windowWatcher) deliver(kind byte, first, second int) {
+	w.Lock()
+	defer w.Unlock()
+
+	for _, ch := range w.waiting[kind] {
+		ch <- windowReply{first: first, second: second}
+	}
+	delete(w.waiting, kind)
+}
+
+// parseWindowReport handles the replies to the position and the pixels queries, returning the number of bytes consumed or -1 if it's incomplete
+func (w *windowWatcher) parseWindowReport(report []byte) int {
+	first, second, consumed := parseWindowOpsReply(report)
+	if consumed > 0 {
+		w.deliver(report[2], first, second)
+	}
+	return consumed
+}
+
+// TextAreaPixels implements term.WindowManipulator interface
+func (c *core) TextAreaPixels() (int, int, error) {
+	height, width, err := c.windowQuery(windowPixelsQuery, windowPixels)
+	return width, height, err
+}
+
+// TextAreaCells implements term.WindowManipulator interface. The size is the one of the whole screen, the reserved cells (see EdgeReserver) included.
+func (c *core) TextAreaCells() (*term.Size, error) {
+	rows, columns, err := c.windowQuery(sizeQuery, windowCells)
+	if err != nil {
+		return nil, err
+	}
+	return &term.Size{Columns: columns, Rows: rows}, nil
+}
+
+// WindowPosition implements term.WindowManipulator interface
+func (c *core) WindowPosition() (int, int, error) {
+	return c.windowQuery(windowPositionQuery, windowPosition)
+}
+
+// windowQuery writes the query, then waits for the reply
+func (c *core) windowQuery(query string, kind byte) (int, int, error) {
+	c.Lock()
+	if c.out == nil || c.plain {
+		c.Unlock()
+		return 0, 0, ErrNoScreen
+	}
+	if !c.hasWindowOps() {
+		c.Unlock()
+		return 0, 0, term.ErrNotSupported{Capability: windowOps}
+	}
+	ch := c.windows.wait(kind)
+	if err := c.comm.WriteString(c.out, query); err != nil {
+		c.Unlock()
+		c.windows.forget(kind, ch)
+		return 0, 0, err
+	}
+	c.Unlock()
+
+	select {
+	case reply := <-ch:
+		return reply.first, reply.second, nil
+	case <-time.After(windowTimeout):
+		c.windows.forget(kind, ch)
+		return 0, 0, ErrNoReply
+	}
+}
+
+// PushTitle implements term.WindowManipulator interface
+func (c *core) PushTitle() error {
+	return c.windowOp(titlePush)
+}
+
+// PopTitle implements term.WindowManipulator interface
+func (c *core) PopTitle() error {
+	return c.windowOp(titlePop)
+}
+
+// ResizeWindow implements term.WindowManipulator interface
+func (c *core) ResizeWindow(columns, rows int) error {
+	return c.windowOp("\x1b[8;" + strconv.Itoa(term.Max(rows, 0)) + ";" + strconv.Itoa(term.Max(columns, 0)) + "t")
+}
+
+// MoveWindow implements term.WindowManipulator interface
+func (c *core) MoveWindow(x, y int) error {
+	return c.windowOp("\x1b[3;" + strconv.Itoa(term.Max(x, 0)) + ";" + strconv.Itoa(term.Max(y, 0)) + "t")
+}
+
+// windowOp writes the window operation, which has no reply
+func (c *core) windowOp(op string) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.out == nil || c.plain {
+		return ErrNoScreen
+	}
+	if !c.hasWindowOps() {
+		return term.ErrNotSupported{Capability: windowOps}
+	}
+	return c.comm.WriteString(c.out, op)
+}
+
+// hasWindowOps returns true if the terminal is an emulator which might implement the window operations of xterm : the ones reporting the mouse and having an alternate screen,
+// which the consoles (linux, vt52, wyse) lack - locked inside caller function
+func (c *core) hasWindowOps() bool {
+	return c.comm.HasMouse && len(c.comm.EnterCA) > 0
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+)
+
+func TestWindowQueries(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+
+	type reply struct {
+		width, height int
+		err           error
+	}
+	replies := make(chan reply, 1)
+	go func() {
+		width, height, err := c.TextAreaPixels()
+		replies <- reply{width, height, err}
+	}()
+	if out := waitFor(t, c, written, "\x1b[14t"); !strings.Contains(out, "\x1b[14t") {
+		t.Fatalf("error : the pixels query should be written, got %q", out)
+	}
+	// the reply is removed from the input, while the keys having the same prefix are kept
+	if rest := c.reports.filter([]byte("a\x1b[4;600;800t\x1b[3;5~")); string(rest) != "a\x1b[3;5~" {
+		t.Errorf("error : unexpected input left %q", rest)
+	}
+	if r := <-replies; r.err != nil || r.width != 800 || r.height != 600 {
+		t.Errorf("error : unexpected text area %d x %d pixels : %v", r.width, r.height, r.err)
+	}
+
+	go func() {
+		size, err := c.TextAreaCells()
+		if err != nil {
+			replies <- reply{err: err}
+			return
+		}
+		replies <- reply{size.Columns, size.Rows, nil}
+	}()
+	waitFor(t, c, written, "\x1b[18t")
+	c.reports.filter([]byte("\x1b[8;40;120t"))
+	if r := <-replies; r.err != nil || r.width != 120 || r.height != 40 {
+		t.Errorf("error : unexpected text area %d x %d : %v", r.width, r.height, r.err)
+	}
+
+	if _, _, err := c.WindowPosition(); err != ErrNoReply {
+		t.Errorf("error : a query without reply should time out, got %v", err)
+	}
+	if len(c.windows.waiting[windowPosition]) != 0 {
+		t.Errorf("error : the caller which gave up should be forgotten")
+	}
+}
+
+func TestWindowOps(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+
+	if err := c.PushTitle(); err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	c.ResizeWindow(100, 30)
+	c.MoveWindow(-1, 20)
+	c.PopTitle()
+	if out := written(); out != "\x1b[22;0t\x1b[8;30;100t\x1b[3;0;20t\x1b[23;0t" {
+		t.Errorf("error : unexpected window operations %q", out)
+	}
+
+	c.plain = true
+	if err := c.PushTitle(); err != ErrNoScreen {
+		t.Errorf("error : the window operations need a screen, got %v", err)
+	}
+}
+
+func TestWindowOpsUnsupported(t *testing.T) {
+	unsupported := term.ErrNotSupported{Capability: "XTWINOPS"}
+	for _, tc := range []struct {
+		name      string
+		overrides map[string]string
+	}{
+		{name: "no alternate screen", overrides: map[string]string{"smcup": "", "rmcup": ""}},
+		{name: "no mouse", overrides: map[string]string{"kmous": ""}},
+	} {
+		c := newBenchCore(t, WithCapabilityOverrides(tc.overrides))
+		written := captureOut(t, c)
+		for name, op := range map[string]func() error{
+			"PushTitle":    c.PushTitle,
+			"PopTitle":     c.PopTitle,
+			"ResizeWindow": func() error { return c.ResizeWindow(100, 30) },
+			"MoveWindow":   func() error { return c.MoveWindow(0, 0) },
+			"TextAreaCells": func() error {
+				_, err := c.TextAreaCells()
+				return err
+			},
+			"TextAreaPixels": func() error {
+				_, _, err := c.TextAreaPixels()
+				return err
+			},
+			"WindowPosition": func() error {
+				_, _, err := c.WindowPosition()
+				return err
+			},
+		} {
+			if err := op(); !errors.Is(err, unsupported) {
+				t.Errorf("error : %s : %s should not be supported, got %v", tc.name, name, err)
+			}
+		}
+		if out := written(); out != "" {
+			t.Errorf("error : %s : nothing should be written, got %q", tc.name, out)
+		}
+	}
+}
+
+func TestWindowOpsWriteError(t *testing.T) {
+	c := newBenchCore(t)
+	c.out = c.guarded(&failingWriter{})
+	if err := c.PushTitle(); !errors.Is(err, term.ErrTerminalLost) {
+		t.Errorf("error : the write error should be returned, got %v", err)
+	}
+	if _, _, err := c.WindowPosition(); !errors.Is(err, term.ErrTerminalLost) {
+		t.Errorf("error : the write error should be returned without waiting for the reply, got %v", err)
+	}
+	if len(c.windows.waiting[windowPosition]) != 0 {
+		t.Errorf("error : the caller should be forgotten")
+	}
+}
@@ -0,0 +1,31 @@
+package core
+
+import (
+	"io"
+
+	"github.com/badu/term/style"
+)
+
+// syncWriter writes to output holding the engine lock, so the custom sequences are never interleaved with the pixels being drawn
+type syncWriter struct {
+	c *core
+}
+
+// Write implements io.Writer. It returns ErrNoScreen if the engine was not started or the output is plain text.
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.c.Lock()
+	defer w.c.Unlock()
+
+	if w.c.out == nil || w.c.plain {
+		return 0, ErrNoScreen
+	}
+	n, err := w.c.out.Write(p)
+	w.c.cachedAttrs = style.Invalid // the sequence might have changed colors or attributes, so the next pixel writes its style
+	w.c.forgetFrame()               // or the content of the screen
+	return n, err
+}
+
+// Writer implements the term.Engine interface, for the applications which need to emit custom sequences
+func (c *core) Writer() io.Writer {
+	return &syncWriter{c: c}
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+func TestWriterInterleaved(t *testing.T) {
+	c := newBenchCore(t)
+	written := captureOut(t, c)
+	red := style.Style{Fg: color.Red, Attrs: style.Bold}
+	redSeq := "\x1b[91m"
+	foreign := "\x1b[0m\x1b]8;;https://example.com\x1b\\"
+
+	c.Redraw([]term.PixelGetter{&regionPixel{hash: term.Hash(0, 0), r: 'a', st: red}})
+	if out := written(); !strings.Contains(out, redSeq) || !strings.Contains(out, c.comm.Bold) {
+		t.Fatalf("error : the style should be written, got %q", out)
+	}
+	c.Redraw([]term.PixelGetter{&regionPixel{hash: term.Hash(1, 0), r: 'b', st: red}})
+	if out := written(); out != "\x1b[1;2Hb" {
+		t.Errorf("error : the same style should not be written again, got %q", out)
+	}
+
+	// the foreign sequence resets the attributes, behind the back of the engine
+	if _, err := c.Writer().Write([]byte(foreign)); err != nil {
+		t.Fatalf("error writing : %v", err)
+	}
+	if out := written(); out != foreign {
+		t.Errorf("error : the sequence should be written as it is, got %q", out)
+	}
+	c.Redraw([]term.PixelGetter{&regionPixel{hash: term.Hash(2, 0), r: 'c', st: red}})
+	if out := written(); !strings.Contains(out, redSeq) || !strings.Contains(out, c.comm.Bold) || !strings.HasSuffix(out, "c") {
+		t.Errorf("error : the style should be written again after the foreign write, got %q", out)
+	}
+
+	// concurrent writes are never interleaved with the pixels being drawn
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			c.Redraw([]term.PixelGetter{&regionPixel{hash: term.Hash(i, 1), r: 'x', st: red}, &regionPixel{hash: term.Hash(i, 2), r: 'y'}})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			if _, err := c.Writer().Write([]byte(foreign)); err != nil {
+				t.Errorf("error writing : %v", err)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+	out := written()
+	if count := strings.Count(out, foreign); count != 50 {
+		t.Errorf("error : expecting the 50 foreign sequences whole, got %d", count)
+	}
+	for _, part := range strings.Split(out, foreign)[1:] {
+		if idx := strings.IndexByte(part, 'x'); idx >= 0 && !strings.Contains(part[:idx], redSeq) {
+			t.Errorf("error : the style should be written before the first pixel following a foreign write, got %q", part)
+			break
+		}
+	}
+}
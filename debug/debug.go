@@ -0,0 +1,166 @@
+// Package debug switches the diagnosis logs of the library on and off at runtime, for each component (core, key, mouse, info, geom, bridge, browser).
+// The components are enabled by the TERM_DEBUG environment variable, e.g. TERM_DEBUG=core,mouse (or TERM_DEBUG=all), by Enable, or by the core.WithDebug engine option.
+// The logs go to the standard logger (see package log, which sends them to a file), prefixed by the name of the component, unless SetOutput is called.
+package debug
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// EnvVar is the environment variable read on start, listing the components to enable, separated by commas
+const EnvVar = "TERM_DEBUG"
+
+// All enables every component
+const All = "all"
+
+var (
+	mu      sync.Mutex // guards scopes, enabled, all and output
+	scopes  = map[string]*Scope{}
+	enabled = map[string]bool{} // by Enable, applied to the scopes created later too
+	all     bool                // by Enable(All)
+	output  *log.Logger         // set by SetOutput, nil for the standard logger
+)
+
+func init() {
+	for _, name := range strings.Split(os.Getenv(EnvVar), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			Enable(name)
+		}
+	}
+}
+
+// Scope is the log of a component, which can be enabled at runtime
+type Scope struct {
+	name string //
+	on   int32  // atomic, 1 while enabled
+}
+
+// For returns the scope of the component, created once per name
+func For(component string) *Scope {
+	component = strings.ToLower(component)
+	mu.Lock()
+	defer mu.Unlock()
+
+	if s, ok := scopes[component]; ok {
+		return s
+	}
+	s := &Scope{name: component}
+	on, ok := enabled[component]
+	if !ok {
+		on = all
+	}
+	s.set(on)
+	scopes[component] = s
+	return s
+}
+
+// Enabled returns true if the logs of the component are written. It's cheap enough to be called on each event.
+func (s *Scope) Enabled() bool {
+	return atomic.LoadInt32(&s.on) == 1
+}
+
+// Name returns the name of the component
+func (s *Scope) Name() string {
+	return s.name
+}
+
+// Printf writes the log, if the component is enabled
+func (s *Scope) Printf(format string, args ...interface{}) {
+	if s.Enabled() {
+		s.output(fmt.Sprintf(format, args...))
+	}
+}
+
+// Println writes the log, if the component is enabled
+func (s *Scope) Println(args ...interface{}) {
+	if s.Enabled() {
+		s.output(fmt.Sprintln(args...))
+	}
+}
+
+// output writes the message, reporting the caller of Printf or Println
+func (s *Scope) output(message string) {
+	mu.Lock()
+	logger := output
+	mu.Unlock()
+	message = "[" + s.name + "] " + message
+	if logger == nil {
+		_ = log.Output(3, message) // the standard logger, maybe redirected by package log
+		return
+	}
+	_ = logger.Output(3, message)
+}
+
+// Enable turns the logs of the components on, the ones not created yet included. All enables every component.
+func Enable(components ...string) {
+	set(components, true)
+}
+
+// Disable turns the logs of the components off. All disables every component.
+func Disable(components ...string) {
+	set(components, false)
+}
+
+// set switches the components
+func set(components []string, on bool) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, name := range components {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == All {
+			all, enabled = on, map[string]bool{}
+			for _, s := range scopes {
+				s.set(on)
+			}
+			continue
+		}
+		enabled[name] = on
+		if s, ok := scopes[name]; ok {
+			s.set(on)
+		}
+	}
+}
+
+// set switches the scope
+func (s *Scope) set(on bool) {
+	var value int32
+	if on {
+		value = 1
+	}
+	atomic.StoreInt32(&s.on, value)
+}
+
+// Components returns the names of the enabled components, sorted
+func Components() []string {
+	mu.Lock()
+	defer mu.Unlock()
+
+	var result []string
+	for name, s := range scopes {
+		if s.Enabled() {
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// SetOutput sends the logs to the writer, instead of the standard logger. A nil writer restores the standard logger.
+func SetOutput(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if w == nil {
+		output = nil
+		return
+	}
+	output = log.New(w, "", log.LstdFlags|log.Lshortfile)
+}
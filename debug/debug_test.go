@@ -0,0 +1,52 @@
+package debug
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestScopes(t *testing.T) {
+	var out bytes.Buffer
+	SetOutput(&out)
+	defer SetOutput(nil)
+	defer Disable(All)
+
+	first := For("first")
+	if first.Enabled() {
+		t.Fatalf("error : the scopes should be disabled by default")
+	}
+	first.Printf("hidden %d", 1)
+	if out.Len() != 0 {
+		t.Errorf("error : a disabled scope should not write, got %q", out.String())
+	}
+
+	Enable("First", "later")
+	if !first.Enabled() {
+		t.Errorf("error : the scope should be enabled, the names being case insensitive")
+	}
+	if later := For("later"); !later.Enabled() {
+		t.Errorf("error : the scopes created after Enable should be enabled too")
+	}
+	if For("first") != first {
+		t.Errorf("error : the scope should be created once")
+	}
+	first.Printf("shown %d", 2)
+	if line := out.String(); !strings.Contains(line, "[first] shown 2") || !strings.Contains(line, "debug_test.go") {
+		t.Errorf("error : expecting the message prefixed by the component, reporting the caller, got %q", line)
+	}
+	if components := Components(); len(components) != 2 || components[0] != "first" || components[1] != "later" {
+		t.Errorf("error : unexpected enabled components %v", components)
+	}
+
+	Disable("first")
+	Enable(All)
+	other := For("other")
+	if !first.Enabled() || !other.Enabled() {
+		t.Errorf("error : all the components should be enabled")
+	}
+	Disable(All)
+	if first.Enabled() || other.Enabled() {
+		t.Errorf("error : all the components should be disabled")
+	}
+}
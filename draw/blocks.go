@@ -0,0 +1,94 @@
+package draw
+
+import (
+	"math"
+)
+
+// Eighths are the runes of a cell filled from zero to eight eighths, used for the bars having a sub-cell resolution
+type Eighths [9]rune
+
+var (
+	// HorizontalEighths fill the cells from the left (U+258F to U+2589, then U+2588)
+	HorizontalEighths = Eighths{' ', '▏', '▎', '▍', '▌', '▋', '▊', '▉', '█'}
+	// VerticalEighths fill the cells from the bottom (U+2581 to U+2588)
+	VerticalEighths = Eighths{' ', '▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+	// HorizontalASCII replace HorizontalEighths on the terminals which can't display them
+	HorizontalASCII = Eighths{' ', ' ', ' ', '-', '-', '=', '=', '=', '#'}
+	// VerticalASCII replace VerticalEighths on the terminals which can't display them
+	VerticalASCII = Eighths{' ', ' ', '.', '.', ':', ':', '|', '|', '#'}
+)
+
+// Displayer tells if a rune can be displayed, as term.Engine does
+type Displayer interface {
+	CanDisplay(r rune, checkFallbacks bool) bool
+}
+
+// Rune returns the rune of a cell filled with that many eighths, clamped to [0..8]
+func (e Eighths) Rune(eighths int) rune {
+	if eighths < 0 {
+		eighths = 0
+	}
+	if eighths > 8 {
+		eighths = 8
+	}
+	return e[eighths]
+}
+
+// CanDisplay returns true if all the runes can be displayed as they are. The fallbacks don't count, since they would lose the sub-cell resolution.
+func (e Eighths) CanDisplay(d Displayer) bool {
+	for _, r := range e {
+		if r != ' ' && !d.CanDisplay(r, false) {
+			return false
+		}
+	}
+	return true
+}
+
+// BlocksFor returns the eighth blocks when the terminal can display them, otherwise their ASCII replacements
+func BlocksFor(d Displayer) (horizontal, vertical Eighths) {
+	horizontal, vertical = HorizontalEighths, VerticalEighths
+	if !horizontal.CanDisplay(d) {
+		horizontal = HorizontalASCII
+	}
+	if !vertical.CanDisplay(d) {
+		vertical = VerticalASCII
+	}
+	return horizontal, vertical
+}
+
+// HBar draws a progress bar between the two columns (inclusive), the first fraction of it being filled from the left with eighth blocks, the rest with spaces.
+// The fraction is clamped to [0..1].
+func (d *Drawer) HBar(column1, column2, row int, fraction float64) {
+	if column2 < column1 {
+		column1, column2 = column2, column1
+	}
+	filled := eighthsOf(column2-column1+1, fraction)
+	for column := column1; column <= column2; column++ {
+		if p := d.pixel(column, row); p != nil {
+			p.Set(d.horizontal.Rune(filled-(column-column1)*8), d.fg, d.bg)
+		}
+	}
+}
+
+// VBar draws a gauge between the two rows (inclusive), the first fraction of it being filled from the bottom with eighth blocks, the rest with spaces.
+// The fraction is clamped to [0..1].
+func (d *Drawer) VBar(column, row1, row2 int, fraction float64) {
+	if row2 < row1 {
+		row1, row2 = row2, row1
+	}
+	filled := eighthsOf(row2-row1+1, fraction)
+	for row := row2; row >= row1; row-- {
+		if p := d.pixel(column, row); p != nil {
+			p.Set(d.vertical.Rune(filled-(row2-row)*8), d.fg, d.bg)
+		}
+	}
+}
+
+// eighthsOf returns how many eighths of the cells are filled
+func eighthsOf(cells int, fraction float64) int {
+	if math.IsNaN(fraction) {
+		return 0
+	}
+	fraction = math.Max(0, math.Min(fraction, 1))
+	return int(math.Round(fraction * float64(cells*8)))
+}
@@ -0,0 +1,250 @@
+package draw
+
+import (
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+// Edges are the sides of a cell which are connected by a line drawing rune
+type Edges uint8
+
+const (
+	Up Edges = 1 << iota
+	Down
+	Left
+	Right
+	None       Edges = 0
+	Vertical         = Up | Down
+	Horizontal       = Left | Right
+	All              = Vertical | Horizontal
+)
+
+// LineStyle is the family of box drawing runes
+type LineStyle int
+
+const (
+	Light   LineStyle = iota // ─ │ ┌ ┼
+	Heavy                    // ━ ┃ ┏ ╋
+	Double                   // ═ ║ ╔ ╬
+	Rounded                  // like Light, with rounded corners ╭ ╮ ╰ ╯
+)
+
+// runes are indexed by Edges. Double has no half lines, so the full ones are used.
+var runes = map[LineStyle][16]rune{
+	Light:   {' ', '╵', '╷', '│', '╴', '┘', '┐', '┤', '╶', '└', '┌', '├', '─', '┴', '┬', '┼'},
+	Heavy:   {' ', '╹', '╻', '┃', '╸', '┛', '┓', '┫', '╺', '┗', '┏', '┣', '━', '┻', '┳', '╋'},
+	Double:  {' ', '║', '║', '║', '═', '╝', '╗', '╣', '═', '╚', '╔', '╠', '═', '╩', '╦', '╬'},
+	Rounded: {' ', '╵', '╷', '│', '╴', '╯', '╮', '┤', '╶', '╰', '╭', '├', '─', '┴', '┬', '┼'},
+}
+
+// lineRune is the meaning of a box drawing rune
+type lineRune struct {
+	edges Edges
+	style LineStyle
+}
+
+// edgesOf is the reverse of runes. Rounded corners are read as Rounded, the rest of the shared runes as Light.
+var edgesOf = func() map[rune]lineRune {
+	result := make(map[rune]lineRune)
+	for _, s := range []LineStyle{Rounded, Double, Heavy, Light} {
+		for e, r := range runes[s] {
+			switch Edges(e) {
+			case None:
+				continue
+			case Up, Down, Left, Right:
+				if s == Double {
+					continue // double has no half lines
+				}
+			}
+			result[r] = lineRune{edges: Edges(e), style: s}
+		}
+	}
+	return result
+}()
+
+// Rune returns the box drawing rune connecting the edges. None is a space.
+func Rune(e Edges, s LineStyle) rune {
+	table, ok := runes[s]
+	if !ok {
+		table = runes[Light]
+	}
+	return table[e&All]
+}
+
+// EdgesOf returns the edges connected by the rune and its line style. Runes which are not box drawing return false.
+func EdgesOf(r rune) (Edges, LineStyle, bool) {
+	info, ok := edgesOf[r]
+	return info.edges, info.style, ok
+}
+
+// Option for functional options
+type Option func(d *Drawer)
+
+// WithLineStyle sets the runes family. Default is Light.
+func WithLineStyle(s LineStyle) Option {
+	return func(d *Drawer) {
+		d.line = s
+	}
+}
+
+// WithStyle sets the colors of the drawn cells. Default is color.Default for both.
+func WithStyle(st *style.Style) Option {
+	return func(d *Drawer) {
+		d.fg, d.bg = st.Fg, st.Bg
+	}
+}
+
+// WithColors sets the colors of the drawn cells
+func WithColors(fg, bg color.Color) Option {
+	return func(d *Drawer) {
+		d.fg, d.bg = fg, bg
+	}
+}
+
+// WithBlocks sets the runes of the bars drawn by HBar and VBar. Default is HorizontalEighths and VerticalEighths.
+func WithBlocks(horizontal, vertical Eighths) Option {
+	return func(d *Drawer) {
+		d.horizontal, d.vertical = horizontal, vertical
+	}
+}
+
+// WithDisplayer sets the runes of the bars drawn by HBar and VBar to the eighth blocks, or to their ASCII replacements when the terminal can't display them (see BlocksFor)
+func WithDisplayer(displayer Displayer) Option {
+	return func(d *Drawer) {
+		d.horizontal, d.vertical = BlocksFor(displayer)
+	}
+}
+
+// Drawer draws lines, boxes and bars into a grid of pixels (indexed [column][row], as returned by geom.NewPixelGrid).
+// When a line crosses or touches a box drawing rune which is already in the grid, the junction is merged (e.g. '─' over '│' becomes '┼'),
+// using the drawer line style. Cells outside the grid are ignored.
+type Drawer struct {
+	grid       [][]term.Pixel // where we draw
+	line       LineStyle      // runes family
+	fg         color.Color    // foreground of the drawn cells
+	bg         color.Color    // background of the drawn cells
+	horizontal Eighths        // runes of HBar, set by WithBlocks or WithDisplayer
+	vertical   Eighths        // runes of VBar, set by WithBlocks or WithDisplayer
+}
+
+// New creates a drawer for the grid
+func New(grid [][]term.Pixel, opts ...Option) *Drawer {
+	res := &Drawer{grid: grid, line: Light, fg: color.Default, bg: color.Default, horizontal: HorizontalEighths, vertical: VerticalEighths}
+	for _, opt := range opts {
+		opt(res)
+	}
+	return res
+}
+
+// pixel returns the pixel at column, row or nil if outside the grid
+func (d *Drawer) pixel(column, row int) term.Pixel {
+	if column < 0 || column >= len(d.grid) || row < 0 || row >= len(d.grid[column]) {
+		return nil
+	}
+	return d.grid[column][row]
+}
+
+// Merge adds the edges to the cell, keeping the edges of the box drawing rune which is already there
+func (d *Drawer) Merge(column, row int, e Edges) {
+	p := d.pixel(column, row)
+	if p == nil {
+		return
+	}
+	if existing, _, ok := EdgesOf(p.Rune()); ok {
+		e |= existing
+	}
+	p.Set(Rune(e, d.line), d.fg, d.bg)
+}
+
+// end draws a line end : over an existing line only the inward edge is added (making a tee), otherwise the full line is drawn
+func (d *Drawer) end(column, row int, inward, full Edges) {
+	p := d.pixel(column, row)
+	if p == nil {
+		return
+	}
+	if _, _, ok := EdgesOf(p.Rune()); ok {
+		d.Merge(column, row, inward)
+		return
+	}
+	d.Merge(column, row, full)
+}
+
+// HLine draws a horizontal line between the two columns (inclusive)
+func (d *Drawer) HLine(column1, column2, row int) {
+	if column2 < column1 {
+		column1, column2 = column2, column1
+	}
+	if column1 == column2 {
+		d.Merge(column1, row, Horizontal)
+		return
+	}
+	d.end(column1, row, Right, Horizontal)
+	for column := column1 + 1; column < column2; column++ {
+		d.Merge(column, row, Horizontal)
+	}
+	d.end(column2, row, Left, Horizontal)
+}
+
+// VLine draws a vertical line between the two rows (inclusive)
+func (d *Drawer) VLine(column, row1, row2 int) {
+	if row2 < row1 {
+		row1, row2 = row2, row1
+	}
+	if row1 == row2 {
+		d.Merge(column, row1, Vertical)
+		return
+	}
+	d.end(column, row1, Down, Vertical)
+	for row := row1 + 1; row < row2; row++ {
+		d.Merge(column, row, Vertical)
+	}
+	d.end(column, row2, Up, Vertical)
+}
+
+// Box draws the outline of a box, the corners being inclusive. A box having a single row or column is drawn as a line.
+func (d *Drawer) Box(column1, row1, column2, row2 int) {
+	if column2 < column1 {
+		column1, column2 = column2, column1
+	}
+	if row2 < row1 {
+		row1, row2 = row2, row1
+	}
+	switch {
+	case row1 == row2:
+		d.HLine(column1, column2, row1)
+		return
+	case column1 == column2:
+		d.VLine(column1, row1, row2)
+		return
+	}
+	d.Merge(column1, row1, Down|Right)
+	d.Merge(column2, row1, Down|Left)
+	d.Merge(column1, row2, Up|Right)
+	d.Merge(column2, row2, Up|Left)
+	for column := column1 + 1; column < column2; column++ {
+		d.Merge(column, row1, Horizontal)
+		d.Merge(column, row2, Horizontal)
+	}
+	for row := row1 + 1; row < row2; row++ {
+		d.Merge(column1, row, Vertical)
+		d.Merge(column2, row, Vertical)
+	}
+}
+
+// Fill sets the rune of all cells between the corners (inclusive), using the drawer colors
+func (d *Drawer) Fill(column1, row1, column2, row2 int, r rune) {
+	if column2 < column1 {
+		column1, column2 = column2, column1
+	}
+	if row2 < row1 {
+		row1, row2 = row2, row1
+	}
+	for column := column1; column <= column2; column++ {
+		for row := row1; row <= row2; row++ {
+			if p := d.pixel(column, row); p != nil {
+				p.Set(r, d.fg, d.bg)
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package draw_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/draw"
+	"github.com/badu/term/geom"
+)
+
+func lines(grid [][]term.Pixel) string {
+	var sb strings.Builder
+	for row := 0; row < len(grid[0]); row++ {
+		for column := range grid {
+			sb.WriteRune(grid[column][row].Rune())
+		}
+		sb.WriteRune('\n')
+	}
+	return sb.String()
+}
+
+func TestJunctions(t *testing.T) {
+	grid, _ := geom.NewPixelGrid(term.NewSize(7, 5))
+	d := draw.New(grid)
+	d.Box(0, 0, 4, 4)
+	d.Box(4, 0, 6, 4) // shares the right side
+	d.HLine(0, 4, 2)  // ends on both sides of the first box
+	d.VLine(2, 0, 4)  // crosses the middle line
+	want := "" +
+		"┌─┬─┬─┐\n" +
+		"│ │ │ │\n" +
+		"├─┼─┤ │\n" +
+		"│ │ │ │\n" +
+		"└─┴─┴─┘\n"
+	if got := lines(grid); got != want {
+		t.Fatalf("unexpected junctions :\n%s", got)
+	}
+
+	grid, _ = geom.NewPixelGrid(term.NewSize(3, 1))
+	draw.New(grid, draw.WithLineStyle(draw.Double)).HLine(0, 2, 0)
+	draw.New(grid).VLine(1, 0, 0)
+	if got := lines(grid); got != "═┼═\n" {
+		t.Fatalf("expecting a light cross merged over double, got %q", got)
+	}
+	if e, s, ok := draw.EdgesOf('╬'); !ok || e != draw.All || s != draw.Double {
+		t.Fatalf("unexpected edges of ╬ : %v %v %v", e, s, ok)
+	}
+	if r := draw.Rune(draw.Down|draw.Right, draw.Rounded); r != '╭' {
+		t.Fatalf("expecting rounded corner, got %q", r)
+	}
+}
+
+// asciiOnly is a draw.Displayer of a terminal which can't display anything above ASCII
+type asciiOnly struct{}
+
+func (asciiOnly) CanDisplay(r rune, checkFallbacks bool) bool { return r < 0x80 }
+
+func TestBars(t *testing.T) {
+	grid, _ := geom.NewPixelGrid(term.NewSize(4, 1))
+	d := draw.New(grid)
+	d.HBar(0, 3, 0, 0.5)
+	if got := lines(grid); got != "██  \n" {
+		t.Fatalf("expecting half a bar, got %q", got)
+	}
+	d.HBar(0, 3, 0, 11.0/32)
+	if got := lines(grid); got != "█▍  \n" {
+		t.Fatalf("expecting eleven eighths, got %q", got)
+	}
+	d.HBar(3, 0, 0, 2)
+	if got := lines(grid); got != "████\n" {
+		t.Fatalf("expecting a full bar, got %q", got)
+	}
+	d.HBar(0, 3, 0, -1)
+	if got := lines(grid); got != "    \n" {
+		t.Fatalf("expecting an empty bar, got %q", got)
+	}
+
+	grid, _ = geom.NewPixelGrid(term.NewSize(1, 3))
+	draw.New(grid).VBar(0, 0, 2, 0.5)
+	if got := lines(grid); got != " \n▄\n█\n" {
+		t.Fatalf("expecting a gauge filled from the bottom, got %q", got)
+	}
+
+	horizontal, vertical := draw.BlocksFor(asciiOnly{})
+	if horizontal != draw.HorizontalASCII || vertical != draw.VerticalASCII {
+		t.Fatalf("expecting the ASCII replacements, got %q and %q", horizontal, vertical)
+	}
+	grid, _ = geom.NewPixelGrid(term.NewSize(4, 1))
+	draw.New(grid, draw.WithDisplayer(asciiOnly{})).HBar(0, 3, 0, 11.0/32)
+	if got := lines(grid); got != "#-  \n" {
+		t.Fatalf("expecting an ASCII bar, got %q", got)
+	}
+}
@@ -0,0 +1,656 @@
+package editor
+
+import (
+	"sync"
+	"unicode"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/key"
+	"github.com/badu/term/style"
+)
+
+const (
+	defaultPopupRows = 5                     // candidates shown at once by the completion popup
+	searchPrompt     = "(reverse-i-search)`" // followed by the query, then "': " and the found entry
+)
+
+// KeyMode selects the key bindings of the editor
+type KeyMode int
+
+const (
+	Emacs KeyMode = iota // the default bindings of readline (Ctrl-A, Ctrl-E, Ctrl-K, Alt-F ...)
+	Vi                   // starts in insert mode, Esc switching to the command mode (h, l, w, b, x, dd, i, a ...)
+)
+
+// Completer returns the candidates for completing the line at the cursor, and the index (in runes) where the completed word starts : the chosen candidate replaces the runes from there to the cursor
+type Completer func(line string, cursor int) ([]string, int)
+
+// SubmitHook is called with the line, when the user presses Enter. The line is added to the history and the editor is emptied.
+type SubmitHook func(e *Editor, line string)
+
+// Option for functional options
+type Option func(e *Editor)
+
+// WithPrompt sets the text shown before the line
+func WithPrompt(prompt string) Option {
+	return func(e *Editor) {
+		e.prompt = []rune(prompt)
+	}
+}
+
+// WithStyle sets the style of the prompt and of the line. Default is the default colors.
+func WithStyle(st style.Style) Option {
+	return func(e *Editor) {
+		e.st = st
+	}
+}
+
+// WithPopupStyle sets the style of the completion candidates and the one of the chosen candidate. Default is reverse video, the chosen one being bold.
+func WithPopupStyle(candidate, chosen style.Style) Option {
+	return func(e *Editor) {
+		e.popupStyle, e.chosenStyle = candidate, chosen
+	}
+}
+
+// WithPopupRows sets the number of candidates the completion popup shows at once. Default is five.
+func WithPopupRows(rows int) Option {
+	return func(e *Editor) {
+		if rows > 0 {
+			e.popupRows = rows
+		}
+	}
+}
+
+// WithHistory sets the history of the submitted lines, which can be shared by several editors and persisted (see History Load and Save)
+func WithHistory(h *History) Option {
+	return func(e *Editor) {
+		e.history = h
+	}
+}
+
+// WithCompleter sets the function called on Tab, offering the candidates for the word at the cursor
+func WithCompleter(completer Completer) Option {
+	return func(e *Editor) {
+		e.completer = completer
+	}
+}
+
+// WithKeyMode sets the key bindings. Default is Emacs.
+func WithKeyMode(mode KeyMode) Option {
+	return func(e *Editor) {
+		e.mode = mode
+	}
+}
+
+// WithOnSubmit sets the hook called when the user presses Enter
+func WithOnSubmit(hook SubmitHook) Option {
+	return func(e *Editor) {
+		e.onSubmit = hook
+	}
+}
+
+// Editor is a readline-like line editor : Emacs or Vi key bindings (see HandleKey), a history browsed with Up and Down or searched with Ctrl-R (reverse-i-search),
+// and the completion of the word at the cursor with Tab, the candidates being shown by a popup below the line when there are several.
+// Like the charts, it draws into pixels (see Draw), which the application draws again after each key it handles. The line scrolls horizontally to keep the cursor visible.
+type Editor struct {
+	sync.Mutex              //
+	prompt      []rune      // set by WithPrompt
+	line        []rune      //
+	cursor      int         // the index of the rune under the cursor, len(line) at the end
+	offset      int         // the first column of the line which is shown, when it doesn't fit
+	killed      []rune      // the runes removed by the kill commands, inserted back by Ctrl-Y (or p, in Vi mode)
+	st          style.Style // set by WithStyle
+	popupStyle  style.Style // set by WithPopupStyle
+	chosenStyle style.Style // set by WithPopupStyle
+	popupRows   int         // set by WithPopupRows
+	mode        KeyMode     // set by WithKeyMode
+	command     bool        // in Vi mode, true in the command mode
+	pending     rune        // in Vi command mode, the operator waiting for its motion (d or c)
+	history     *History    // set by WithHistory
+	browsing    int         // the index of the history entry shown, history length while editing the draft
+	draft       []rune      // the line being edited, kept while browsing the history
+	searching   bool        // true during the reverse-i-search
+	query       []rune      // the text searched in the history
+	found       int         // the index of the history entry found, -1 if none
+	completer   Completer   // set by WithCompleter
+	candidates  []string    // the candidates shown by the popup, none while it is closed
+	chosen      int         // the index of the chosen candidate
+	wordStart   int         // where the completed word starts
+	onSubmit    SubmitHook  // set by WithOnSubmit
+}
+
+// New returns an empty editor
+func New(opts ...Option) *Editor {
+	res := &Editor{
+		st:          style.Style{Fg: color.Default, Bg: color.Default},
+		popupStyle:  style.Style{Fg: color.Default, Bg: color.Default, Attrs: style.Reverse},
+		chosenStyle: style.Style{Fg: color.Default, Bg: color.Default, Attrs: style.Bold},
+		popupRows:   defaultPopupRows,
+		found:       -1,
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+	if res.history == nil {
+		res.history = NewHistory(0)
+	}
+	res.browsing = res.history.Len()
+	return res
+}
+
+// Line returns the text being edited
+func (e *Editor) Line() string {
+	e.Lock()
+	defer e.Unlock()
+
+	return string(e.line)
+}
+
+// SetLine replaces the text being edited, moving the cursor to its end
+func (e *Editor) SetLine(line string) {
+	e.Lock()
+	defer e.Unlock()
+
+	e.setLine([]rune(line))
+	e.browsing = e.history.Len()
+}
+
+// Candidates returns the completion candidates shown by the popup and the index of the chosen one, or nil while the popup is closed
+func (e *Editor) Candidates() ([]string, int) {
+	e.Lock()
+	defer e.Unlock()
+
+	return e.candidates, e.chosen
+}
+
+// Searching returns true during the reverse-i-search
+func (e *Editor) Searching() bool {
+	e.Lock()
+	defer e.Unlock()
+
+	return e.searching
+}
+
+// CommandMode returns true while the Vi bindings are in the command mode
+func (e *Editor) CommandMode() bool {
+	e.Lock()
+	defer e.Unlock()
+
+	return e.command
+}
+
+// Cursor returns the column of the cursor inside the pixels, as of the last Draw, for showing the cursor of the terminal there (see term.Engine ShowCursor)
+func (e *Editor) Cursor() int {
+	e.Lock()
+	defer e.Unlock()
+
+	if e.searching {
+		return len(searchPrompt) + len(e.query)
+	}
+	return len(e.prompt) + e.cursor - e.offset
+}
+
+// Draw fills the grid (indexed [column][row]) : the prompt and the line on the first row, the completion popup on the rows below, the rest being blank.
+// Each pixel is set once, so only the changed ones are drawn again.
+func (e *Editor) Draw(grid [][]term.Pixel) {
+	e.Lock()
+	defer e.Unlock()
+
+	if len(grid) == 0 || len(grid[0]) == 0 {
+		return
+	}
+	columns, rows := len(grid), len(grid[0])
+	var text []rune
+	if e.searching {
+		text = append([]rune(searchPrompt), e.query...)
+		text = append(text, []rune("': ")...)
+		if entry, ok := e.history.Entry(e.found); ok {
+			text = append(text, []rune(entry)...)
+		}
+	} else {
+		e.scroll(columns)
+		text = append(append([]rune{}, e.prompt...), e.line[e.offset:]...)
+	}
+
+	// the popup is below the completed word, showing the chosen candidate
+	shown := term.Min(term.Min(len(e.candidates), e.popupRows), rows-1)
+	first := term.Max(0, e.chosen-shown+1)
+	width := 0
+	for _, candidate := range e.candidates {
+		width = term.Max(width, len([]rune(candidate)))
+	}
+	width = term.Min(width+2, columns) // a space on each side
+	left := term.Min(term.Max(0, len(e.prompt)+e.wordStart-e.offset), columns-width)
+
+	for row := 0; row < rows; row++ {
+		var label []rune
+		st := e.st
+		if row > 0 && row <= shown {
+			label = []rune(" " + e.candidates[first+row-1])
+			st = e.popupStyle
+			if first+row-1 == e.chosen {
+				st = e.chosenStyle
+			}
+		}
+		for column := 0; column < columns; column++ {
+			r, cellStyle := ' ', e.st
+			switch {
+			case row == 0 && column < len(text):
+				r = text[column]
+			case label != nil && column >= left && column < left+width:
+				cellStyle = st
+				if column-left < len(label) {
+					r = label[column-left]
+				}
+			}
+			grid[column][row].SetAll(cellStyle.Bg, cellStyle.Fg, cellStyle.Attrs, r, nil)
+		}
+	}
+}
+
+// HandleKey edits the line using the key bindings, returning true if the event was consumed.
+// The application decides when the editor has the keyboard focus, and draws it again after a consumed key (see Draw).
+func (e *Editor) HandleKey(ev term.KeyEvent) bool {
+	e.Lock()
+	consumed, submitted, line := e.handle(ev)
+	e.Unlock()
+
+	if submitted && e.onSubmit != nil {
+		e.onSubmit(e, line)
+	}
+	return consumed
+}
+
+// handle dispatches the key to the mode in effect, returning true if it was consumed and the submitted line, if any - locked inside caller function
+func (e *Editor) handle(ev term.KeyEvent) (bool, bool, string) {
+	switch {
+	case e.searching:
+		if consumed, done := e.searchKey(ev); !done {
+			return consumed, false, ""
+		}
+	case len(e.candidates) > 0:
+		if e.popupKey(ev) {
+			return true, false, ""
+		}
+	}
+	if isEnter(ev) {
+		line := string(e.line)
+		e.history.Add(line)
+		e.setLine(nil)
+		e.browsing, e.command, e.pending = e.history.Len(), false, 0
+		return true, true, line
+	}
+	if e.mode == Vi && e.command {
+		return e.viCommand(ev), false, ""
+	}
+	return e.editKey(ev), false, ""
+}
+
+// editKey handles the keys of the Emacs bindings, which are the ones of the Vi insert mode too (Esc switching to the command mode) - locked inside caller function
+func (e *Editor) editKey(ev term.KeyEvent) bool {
+	alt := ev.Modifiers()&key.ModAlt != 0
+	switch ev.Key() {
+	case key.Rune:
+		switch {
+		case alt && e.mode == Emacs && ev.Rune() == 'b':
+			e.cursor = e.wordBefore()
+		case alt && e.mode == Emacs && ev.Rune() == 'f':
+			e.cursor = e.wordAfter()
+		case alt && e.mode == Emacs && ev.Rune() == 'd':
+			e.kill(e.cursor, e.wordAfter())
+		case alt:
+			return false
+		default:
+			e.insert(ev.Rune())
+		}
+	case key.Esc:
+		if e.mode != Vi {
+			return false
+		}
+		e.command = true
+		e.cursor = term.Max(0, e.cursor-1) // like vi, the cursor moves back onto the last inserted rune
+	case key.Left, key.CtrlB:
+		e.cursor = term.Max(0, e.cursor-1)
+	case key.Right, key.CtrlF:
+		e.cursor = term.Min(len(e.line), e.cursor+1)
+	case key.Home, key.CtrlA:
+		e.cursor = 0
+	case key.End, key.CtrlE:
+		e.cursor = len(e.line)
+	case key.Backspace, key.Backspace2:
+		if e.cursor > 0 {
+			e.remove(e.cursor-1, e.cursor)
+		}
+	case key.Delete, key.CtrlD:
+		if e.cursor < len(e.line) {
+			e.remove(e.cursor, e.cursor+1)
+		}
+	case key.CtrlK:
+		e.kill(e.cursor, len(e.line))
+	case key.CtrlU:
+		e.kill(0, e.cursor)
+	case key.CtrlW:
+		e.kill(e.wordBefore(), e.cursor)
+	case key.CtrlY:
+		for _, r := range e.killed {
+			e.insert(r)
+		}
+	case key.Up, key.CtrlP:
+		e.browse(-1)
+	case key.Down, key.CtrlN:
+		e.browse(1)
+	case key.CtrlR:
+		e.searching, e.query, e.found = true, nil, -1
+		e.draft = append(e.draft[:0], e.line...)
+	case key.Tab:
+		e.complete()
+	default:
+		return false
+	}
+	return true
+}
+
+// viCommand handles the keys of the Vi command mode - locked inside caller function
+func (e *Editor) viCommand(ev term.KeyEvent) bool {
+	switch ev.Key() {
+	case key.Left, key.Right, key.Home, key.End, key.Up, key.Down:
+		e.editKey(ev)
+		e.cursor = e.lastRune(e.cursor)
+		return true
+	case key.Esc:
+		e.pending = 0
+		return true
+	case key.Rune:
+	default:
+		return false
+	}
+
+	r := ev.Rune()
+	if e.pending != 0 {
+		operator := e.pending
+		e.pending = 0
+		from, to := e.cursor, -1
+		switch r {
+		case operator: // dd, cc : the whole line
+			from, to = 0, len(e.line)
+		case 'w':
+			to = e.wordAfter()
+		case 'b':
+			from, to = e.wordBefore(), e.cursor
+		case '$':
+			to = len(e.line)
+		case '0':
+			from, to = 0, e.cursor
+		}
+		if to >= 0 {
+			e.kill(from, to)
+			e.command = operator != 'c'
+		}
+		if e.command {
+			e.cursor = e.lastRune(e.cursor)
+		}
+		return true
+	}
+
+	switch r {
+	case 'h':
+		e.cursor = term.Max(0, e.cursor-1)
+	case 'l':
+		e.cursor = e.lastRune(e.cursor + 1)
+	case '0', '^':
+		e.cursor = 0
+	case '$':
+		e.cursor = e.lastRune(len(e.line))
+	case 'w':
+		e.cursor = e.lastRune(e.wordAfter())
+	case 'b':
+		e.cursor = e.wordBefore()
+	case 'x':
+		if e.cursor < len(e.line) {
+			e.kill(e.cursor, e.cursor+1)
+			e.cursor = e.lastRune(e.cursor)
+		}
+	case 'X':
+		if e.cursor > 0 {
+			e.kill(e.cursor-1, e.cursor)
+		}
+	case 'D':
+		e.kill(e.cursor, len(e.line))
+		e.cursor = e.lastRune(e.cursor)
+	case 'C':
+		e.kill(e.cursor, len(e.line))
+		e.command = false
+	case 'd', 'c':
+		e.pending = r
+	case 'p':
+		e.cursor = term.Min(len(e.line), e.cursor+1)
+		for _, killed := range e.killed {
+			e.insert(killed)
+		}
+		e.cursor = e.lastRune(e.cursor - 1)
+	case 'i':
+		e.command = false
+	case 'a':
+		e.cursor = term.Min(len(e.line), e.cursor+1)
+		e.command = false
+	case 'I':
+		e.cursor, e.command = 0, false
+	case 'A':
+		e.cursor, e.command = len(e.line), false
+	case 'k':
+		e.browse(-1)
+		e.cursor = 0
+	case 'j':
+		e.browse(1)
+		e.cursor = 0
+	case '/':
+		e.searching, e.query, e.found = true, nil, -1
+		e.draft = append(e.draft[:0], e.line...)
+	}
+	return true // the other runes are ignored, as vi does
+}
+
+// searchKey handles the keys of the reverse-i-search, returning true if the key was consumed and true if the search is done, the key having to be handled as usual - locked inside caller function
+func (e *Editor) searchKey(ev term.KeyEvent) (bool, bool) {
+	switch ev.Key() {
+	case key.Rune:
+		if ev.Modifiers()&key.ModAlt != 0 {
+			break
+		}
+		e.query = append(e.query, ev.Rune())
+		e.find(e.history.Len() - 1)
+		return true, false
+	case key.Backspace, key.Backspace2:
+		if len(e.query) > 0 {
+			e.query = e.query[:len(e.query)-1]
+			e.find(e.history.Len() - 1)
+		}
+		return true, false
+	case key.CtrlR:
+		if len(e.query) > 0 && e.found > 0 {
+			if older := e.history.Search(string(e.query), e.found-1); older >= 0 {
+				e.found = older
+			}
+		}
+		return true, false
+	case key.CtrlG, key.Esc:
+		e.searching = false
+		e.setLine(e.draft)
+		return true, false
+	}
+	// any other key accepts the found entry, then does its usual job
+	e.searching = false
+	if entry, ok := e.history.Entry(e.found); ok {
+		e.setLine([]rune(entry))
+		e.browsing = e.found
+	}
+	return true, true
+}
+
+// find searches the query in the history, from the index backwards - locked inside caller function
+func (e *Editor) find(from int) {
+	e.found = -1
+	if len(e.query) > 0 {
+		e.found = e.history.Search(string(e.query), from)
+	}
+}
+
+// popupKey handles the keys while the completion popup is open, returning false if the key closed it, having to be handled as usual - locked inside caller function
+func (e *Editor) popupKey(ev term.KeyEvent) bool {
+	switch ev.Key() {
+	case key.Tab, key.Down, key.CtrlN:
+		e.chosen = (e.chosen + 1) % len(e.candidates)
+	case key.BackTab, key.Up, key.CtrlP:
+		e.chosen = (e.chosen + len(e.candidates) - 1) % len(e.candidates)
+	case key.Enter:
+		e.replaceWord(e.candidates[e.chosen])
+		e.candidates = nil
+	case key.Esc, key.CtrlG:
+		e.candidates = nil
+	default:
+		e.candidates = nil
+		return false
+	}
+	return true
+}
+
+// complete asks the completer for the candidates : a single one replaces the word, several ones extend it with their common prefix, or open the popup - locked inside caller function
+func (e *Editor) complete() {
+	if e.completer == nil {
+		return
+	}
+	candidates, start := e.completer(string(e.line), e.cursor)
+	if len(candidates) == 0 || start < 0 || start > e.cursor {
+		return
+	}
+	e.wordStart = start
+	if len(candidates) == 1 {
+		e.replaceWord(candidates[0])
+		return
+	}
+	prefix := []rune(candidates[0])
+	for _, candidate := range candidates[1:] {
+		runes := []rune(candidate)
+		common := 0
+		for common < len(prefix) && common < len(runes) && prefix[common] == runes[common] {
+			common++
+		}
+		prefix = prefix[:common]
+	}
+	if len(prefix) > e.cursor-start {
+		e.replaceWord(string(prefix))
+		return
+	}
+	e.candidates, e.chosen = candidates, 0
+}
+
+// replaceWord replaces the runes from the start of the completed word to the cursor - locked inside caller function
+func (e *Editor) replaceWord(word string) {
+	e.remove(e.wordStart, e.cursor)
+	for _, r := range word {
+		e.insert(r)
+	}
+}
+
+// browse shows the previous (or the next) history entry, the line being edited being kept as a draft - locked inside caller function
+func (e *Editor) browse(direction int) {
+	count := e.history.Len()
+	next := term.Min(term.Max(e.browsing+direction, 0), count)
+	if next == e.browsing {
+		return
+	}
+	if e.browsing == count {
+		e.draft = append(e.draft[:0], e.line...)
+	}
+	e.browsing = next
+	if entry, ok := e.history.Entry(next); ok {
+		e.setLine([]rune(entry))
+		return
+	}
+	e.setLine(e.draft)
+}
+
+// setLine replaces the line, moving the cursor to its end - locked inside caller function
+func (e *Editor) setLine(line []rune) {
+	e.line = append([]rune{}, line...)
+	e.cursor = len(e.line)
+	e.candidates = nil
+}
+
+// insert inserts the rune at the cursor, moving the cursor after it - locked inside caller function
+func (e *Editor) insert(r rune) {
+	e.line = append(e.line, 0)
+	copy(e.line[e.cursor+1:], e.line[e.cursor:])
+	e.line[e.cursor] = r
+	e.cursor++
+}
+
+// remove removes the runes between the indexes, moving the cursor to the first one - locked inside caller function
+func (e *Editor) remove(from, to int) {
+	if from >= to {
+		return
+	}
+	e.line = append(e.line[:from], e.line[to:]...)
+	e.cursor = from
+}
+
+// kill removes the runes between the indexes, keeping them for Ctrl-Y - locked inside caller function
+func (e *Editor) kill(from, to int) {
+	if from >= to {
+		return
+	}
+	e.killed = append(e.killed[:0], e.line[from:to]...)
+	e.remove(from, to)
+}
+
+// wordBefore returns the index of the start of the word before the cursor - locked inside caller function
+func (e *Editor) wordBefore() int {
+	idx := e.cursor
+	for idx > 0 && !isWord(e.line[idx-1]) {
+		idx--
+	}
+	for idx > 0 && isWord(e.line[idx-1]) {
+		idx--
+	}
+	return idx
+}
+
+// wordAfter returns the index after the end of the word at (or after) the cursor - locked inside caller function
+func (e *Editor) wordAfter() int {
+	idx := e.cursor
+	for idx < len(e.line) && !isWord(e.line[idx]) {
+		idx++
+	}
+	for idx < len(e.line) && isWord(e.line[idx]) {
+		idx++
+	}
+	return idx
+}
+
+// lastRune keeps the index on a rune, since the cursor of the Vi command mode can't be after the last one - locked inside caller function
+func (e *Editor) lastRune(idx int) int {
+	return term.Max(0, term.Min(idx, len(e.line)-1))
+}
+
+// scroll moves the shown part of the line, so the cursor is visible inside that many columns - locked inside caller function
+func (e *Editor) scroll(columns int) {
+	visible := term.Max(1, columns-len(e.prompt))
+	switch {
+	case e.cursor < e.offset:
+		e.offset = e.cursor
+	case e.cursor >= e.offset+visible:
+		e.offset = e.cursor - visible + 1
+	}
+	e.offset = term.Min(e.offset, len(e.line))
+}
+
+// isWord returns true for the runes of the words : letters, digits and underscores
+func isWord(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// isEnter returns true for Enter and Ctrl-J
+func isEnter(ev term.KeyEvent) bool {
+	return ev.Key() == key.Enter || ev.Key() == key.CtrlJ
+}
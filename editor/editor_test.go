@@ -0,0 +1,178 @@
+package editor_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/editor"
+	"github.com/badu/term/geom"
+	"github.com/badu/term/key"
+	"github.com/badu/term/style"
+)
+
+// typeKeys sends the runes as key presses, the other keys being sent as they are
+func typeKeys(e *editor.Editor, keys ...interface{}) {
+	for _, k := range keys {
+		switch value := k.(type) {
+		case string:
+			for _, r := range value {
+				e.HandleKey(key.NewEvent(key.Rune, r, key.ModNone))
+			}
+		case term.Key:
+			e.HandleKey(key.NewEvent(value, 0, key.ModNone))
+		case term.KeyEvent:
+			e.HandleKey(value)
+		}
+	}
+}
+
+// rows returns the runes of the grid, row by row
+func rows(grid [][]term.Pixel) []string {
+	var result []string
+	for row := 0; row < len(grid[0]); row++ {
+		var sb strings.Builder
+		for column := range grid {
+			sb.WriteRune(grid[column][row].Rune())
+		}
+		result = append(result, sb.String())
+	}
+	return result
+}
+
+func TestEmacsBindings(t *testing.T) {
+	var submitted []string
+	e := editor.New(editor.WithPrompt("> "), editor.WithOnSubmit(func(e *editor.Editor, line string) { submitted = append(submitted, line) }))
+
+	typeKeys(e, "hello world", key.CtrlA, key.NewEvent(key.Rune, 'f', key.ModAlt), key.CtrlK)
+	if e.Line() != "hello" {
+		t.Errorf("error : Alt-F then Ctrl-K should kill the second word, got %q", e.Line())
+	}
+	typeKeys(e, key.CtrlA, key.CtrlY, " ", key.End, key.Backspace, key.CtrlW)
+	if e.Line() != " world " {
+		t.Errorf("error : Ctrl-Y should yank the killed word, Ctrl-W kill the word before the cursor, got %q", e.Line())
+	}
+	typeKeys(e, key.CtrlU, "ls -la", key.Enter, "cd /tmp", key.Enter)
+	if len(submitted) != 2 || submitted[1] != "cd /tmp" || e.Line() != "" {
+		t.Errorf("error : Enter should submit and empty the line, got %v", submitted)
+	}
+
+	// history, the draft being kept
+	typeKeys(e, "draft", key.Up)
+	if e.Line() != "cd /tmp" {
+		t.Errorf("error : Up should show the last entry, got %q", e.Line())
+	}
+	typeKeys(e, key.Up, key.Up, key.Down, key.Down)
+	if e.Line() != "draft" {
+		t.Errorf("error : Down should come back to the draft, got %q", e.Line())
+	}
+
+	// the cursor is kept visible
+	grid, _ := geom.NewPixelGrid(&term.Size{Columns: 6, Rows: 1})
+	e.Draw(grid)
+	if got := rows(grid)[0]; got != "> aft " {
+		t.Errorf("error : the line should scroll to the cursor, got %q", got)
+	}
+	if e.Cursor() != 5 {
+		t.Errorf("error : the cursor should be on the last column, got %d", e.Cursor())
+	}
+}
+
+func TestHistorySearchAndPersistence(t *testing.T) {
+	history := editor.NewHistory(3)
+	if err := history.Load(strings.NewReader("make test\ngit status\n\ngit status\ngo vet ./...\ngit log\n")); err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	if history.Len() != 3 {
+		t.Errorf("error : the oldest entries, the blank and the repeated ones should be dropped, got %d", history.Len())
+	}
+	saved := &bytes.Buffer{}
+	if err := history.Save(saved); err != nil || saved.String() != "git status\ngo vet ./...\ngit log\n" {
+		t.Errorf("error : unexpected saved history %q", saved.String())
+	}
+
+	e := editor.New(editor.WithHistory(history))
+	typeKeys(e, "unfinished", key.CtrlR, "git")
+	grid, _ := geom.NewPixelGrid(&term.Size{Columns: 40, Rows: 1})
+	e.Draw(grid)
+	if got := strings.TrimRight(rows(grid)[0], " "); got != "(reverse-i-search)`git': git log" {
+		t.Errorf("error : unexpected search row %q", got)
+	}
+	typeKeys(e, key.CtrlR)
+	e.Draw(grid)
+	if !strings.HasSuffix(strings.TrimRight(rows(grid)[0], " "), "git status") {
+		t.Errorf("error : Ctrl-R should find an older entry, got %q", rows(grid)[0])
+	}
+	typeKeys(e, key.Right)
+	if e.Searching() || e.Line() != "git status" {
+		t.Errorf("error : a movement should accept the entry, got %q", e.Line())
+	}
+
+	typeKeys(e, key.CtrlR, "nothing", key.CtrlG)
+	if e.Line() != "git status" {
+		t.Errorf("error : Ctrl-G should cancel the search, got %q", e.Line())
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	commands := []string{"checkout", "cherry-pick", "clone", "commit"}
+	completer := func(line string, cursor int) ([]string, int) {
+		start := strings.LastIndex(line[:cursor], " ") + 1
+		var result []string
+		for _, command := range commands {
+			if strings.HasPrefix(command, line[start:cursor]) {
+				result = append(result, command)
+			}
+		}
+		return result, start
+	}
+	e := editor.New(editor.WithPrompt("$ "), editor.WithCompleter(completer), editor.WithPopupRows(2))
+
+	typeKeys(e, "git clo", key.Tab)
+	if e.Line() != "git clone" {
+		t.Errorf("error : a single candidate should complete the word, got %q", e.Line())
+	}
+	typeKeys(e, key.CtrlW, "ch", key.Tab)
+	if candidates, _ := e.Candidates(); e.Line() != "git che" || candidates != nil {
+		t.Errorf("error : the common prefix should be completed first, got %q", e.Line())
+	}
+	typeKeys(e, key.Tab, key.Tab)
+	candidates, chosen := e.Candidates()
+	if len(candidates) != 2 || chosen != 1 {
+		t.Fatalf("error : the second Tab should choose the next candidate, got %v %d", candidates, chosen)
+	}
+	grid, _ := geom.NewPixelGrid(&term.Size{Columns: 20, Rows: 4})
+	e.Draw(grid)
+	shown := rows(grid)
+	if shown[1] != "       checkout     " || shown[2] != "       cherry-pick  " || shown[3] != strings.Repeat(" ", 20) {
+		t.Errorf("error : the popup should be below the word, got %q", shown)
+	}
+	if _, _, attrs := grid[7][2].Style(); attrs != style.Bold {
+		t.Errorf("error : the chosen candidate should be bold")
+	}
+	typeKeys(e, key.Enter)
+	if candidates, _ := e.Candidates(); e.Line() != "git cherry-pick" || candidates != nil {
+		t.Errorf("error : Enter should apply the chosen candidate, got %q", e.Line())
+	}
+}
+
+func TestViBindings(t *testing.T) {
+	e := editor.New(editor.WithKeyMode(editor.Vi))
+	typeKeys(e, "one two three", key.Esc)
+	if !e.CommandMode() {
+		t.Fatalf("error : Esc should switch to the command mode")
+	}
+	typeKeys(e, "0", "w", "dw")
+	if e.Line() != "one three" {
+		t.Errorf("error : dw should delete the word, got %q", e.Line())
+	}
+	typeKeys(e, "$", "x", "0", "cw", "ONE", key.Esc, "A", "!")
+	if e.Line() != "ONE thre!" || e.CommandMode() {
+		t.Errorf("error : unexpected line %q", e.Line())
+	}
+	typeKeys(e, key.Esc, "dd", "p")
+	if e.Line() != "ONE thre!" {
+		t.Errorf("error : p should put the deleted line back, got %q", e.Line())
+	}
+}
@@ -0,0 +1,105 @@
+package editor
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultHistorySize = 1000 // entries kept by a History
+)
+
+// History keeps the submitted lines, the oldest first, so they can be recalled (Up, Down, reverse-i-search) and persisted between launches (Load, Save).
+// It can be shared by several editors.
+type History struct {
+	sync.Mutex          //
+	entries    []string // the oldest first
+	size       int      // the number of entries kept, the oldest being dropped
+}
+
+// NewHistory returns an empty history, keeping that many entries. Zero keeps a thousand.
+func NewHistory(size int) *History {
+	if size <= 0 {
+		size = defaultHistorySize
+	}
+	return &History{size: size}
+}
+
+// Add appends the line, unless it is blank or the same as the last one
+func (h *History) Add(line string) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.add(line)
+}
+
+// add appends the line, dropping the oldest entries - locked inside caller function
+func (h *History) add(line string) {
+	if strings.TrimSpace(line) == "" || (len(h.entries) > 0 && h.entries[len(h.entries)-1] == line) {
+		return
+	}
+	h.entries = append(h.entries, line)
+	if len(h.entries) > h.size {
+		h.entries = append(h.entries[:0], h.entries[len(h.entries)-h.size:]...)
+	}
+}
+
+// Len returns the number of entries
+func (h *History) Len() int {
+	h.Lock()
+	defer h.Unlock()
+
+	return len(h.entries)
+}
+
+// Entry returns the entry at the index, the oldest being the first, or false if there is none
+func (h *History) Entry(idx int) (string, bool) {
+	h.Lock()
+	defer h.Unlock()
+
+	if idx < 0 || idx >= len(h.entries) {
+		return "", false
+	}
+	return h.entries[idx], true
+}
+
+// Search returns the index of the newest entry containing the text, looking from the index backwards, or -1 if there is none
+func (h *History) Search(text string, from int) int {
+	h.Lock()
+	defer h.Unlock()
+
+	for idx := from; idx >= 0; idx-- {
+		if idx < len(h.entries) && strings.Contains(h.entries[idx], text) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// Load appends the lines read, one entry per line, e.g. from the file saved by the previous launch
+func (h *History) Load(r io.Reader) error {
+	h.Lock()
+	defer h.Unlock()
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		h.add(scanner.Text())
+	}
+	return scanner.Err()
+}
+
+// Save writes the entries, one per line, the oldest first
+func (h *History) Save(w io.Writer) error {
+	h.Lock()
+	defer h.Unlock()
+
+	buf := bufio.NewWriter(w)
+	for _, entry := range h.entries {
+		if _, err := buf.WriteString(entry + "\n"); err != nil {
+			return err
+		}
+	}
+	return buf.Flush()
+}
@@ -0,0 +1,599 @@
+package editor
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/key"
+	"github.com/badu/term/style"
+)
+
+const (
+	defaultUndoDepth = 100 // edits which can be undone
+)
+
+// editKind groups the edits for undo : the runes typed one after the other are undone at once
+type editKind int
+
+const (
+	editNone   editKind = iota // the cursor moved, so the next edit starts a new group
+	editTyping                 //
+	editOther                  //
+)
+
+// snapshot is the content and the cursor saved before an edit, restored by undo
+type snapshot struct {
+	lines  [][]rune
+	row    int
+	column int
+}
+
+// TextAreaOption for functional options
+type TextAreaOption func(t *TextArea)
+
+// WithText sets the initial content, the lines being separated by new lines
+func WithText(text string) TextAreaOption {
+	return func(t *TextArea) {
+		t.lines = split(text)
+	}
+}
+
+// WithTextStyle sets the style of the text and the one of the selected text. Default is the default colors, the selection being drawn in reverse video.
+func WithTextStyle(text, selected style.Style) TextAreaOption {
+	return func(t *TextArea) {
+		t.st, t.selectedStyle = text, selected
+	}
+}
+
+// WithHardWrap breaks the lines at their last space as they are typed, once they are longer than the number of columns, inserting new lines.
+// Otherwise, the long lines are only wrapped when they are drawn (soft wrap).
+func WithHardWrap(columns int) TextAreaOption {
+	return func(t *TextArea) {
+		if columns > 0 {
+			t.hardWrap = columns
+		}
+	}
+}
+
+// WithClipboard sets where the copied and the cut text goes, besides the text area (which pastes it with Ctrl-V), e.g. the engine implementing term.Clipboard
+func WithClipboard(clipboard term.Clipboard) TextAreaOption {
+	return func(t *TextArea) {
+		t.clipboard = clipboard
+	}
+}
+
+// WithUndoDepth sets the number of edits which can be undone. Default is a hundred.
+func WithUndoDepth(depth int) TextAreaOption {
+	return func(t *TextArea) {
+		if depth > 0 {
+			t.undoDepth = depth
+		}
+	}
+}
+
+// TextArea is a multi-line text editor, drawn into pixels (see Draw) : the long lines wrap at their last space, the cursor moves with the arrows (Up and Down by the rows shown),
+// Home, End, PgUp, PgDn and Ctrl+Home, Ctrl+End, the same keys with Shift selecting the text, which can be copied (Ctrl-C), cut (Ctrl-X) and pasted (Ctrl-V).
+// Ctrl-Z undoes the edits and Ctrl-Y redoes them. Only the rows shown are laid out, so the content can be long.
+type TextArea struct {
+	sync.Mutex                   //
+	lines         [][]rune       // at least one, maybe empty
+	row           int            // the line of the cursor
+	column        int            // the index of the rune under the cursor, len(line) at the end
+	goal          int            // the column kept while moving up and down, -1 if none
+	selecting     bool           // true while there is a selection
+	anchorRow     int            // where the selection has started
+	anchorColumn  int            //
+	topRow        int            // the line of the first row shown
+	topSegment    int            // the segment of that line (see segments) shown on the first row
+	width         int            // the size of the last Draw, used for wrapping
+	height        int            //
+	undo          []snapshot     //
+	redo          []snapshot     //
+	lastEdit      editKind       //
+	copied        string         // the text copied or cut, pasted by Ctrl-V
+	st            style.Style    // set by WithTextStyle
+	selectedStyle style.Style    // set by WithTextStyle
+	hardWrap      int            // set by WithHardWrap
+	clipboard     term.Clipboard // set by WithClipboard
+	undoDepth     int            // set by WithUndoDepth
+}
+
+// NewTextArea returns an empty text area, unless WithText was used
+func NewTextArea(opts ...TextAreaOption) *TextArea {
+	res := &TextArea{
+		lines:         [][]rune{{}},
+		goal:          -1,
+		st:            style.Style{Fg: color.Default, Bg: color.Default},
+		selectedStyle: style.Style{Fg: color.Default, Bg: color.Default, Attrs: style.Reverse},
+		undoDepth:     defaultUndoDepth,
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+	return res
+}
+
+// Text returns the content, the lines being separated by new lines
+func (t *TextArea) Text() string {
+	t.Lock()
+	defer t.Unlock()
+
+	return join(t.lines)
+}
+
+// SetText replaces the content, forgetting the edits and moving the cursor to the beginning
+func (t *TextArea) SetText(text string) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.lines = split(text)
+	t.row, t.column, t.topRow, t.topSegment, t.selecting = 0, 0, 0, 0, false
+	t.undo, t.redo, t.lastEdit = nil, nil, editNone
+}
+
+// Cursor returns the line of the cursor and the index of the rune under it
+func (t *TextArea) Cursor() (int, int) {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.row, t.column
+}
+
+// CursorCell returns the column and the row of the cursor inside the pixels, as of the last Draw, for showing the cursor of the terminal there (see term.Engine ShowCursor)
+func (t *TextArea) CursorCell() (int, int) {
+	t.Lock()
+	defer t.Unlock()
+
+	segment, column := t.cursorSegment()
+	return column, t.distance(t.topRow, t.topSegment, t.row, segment, t.height)
+}
+
+// Selected returns the selected text, or an empty string if nothing is selected
+func (t *TextArea) Selected() string {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.selected()
+}
+
+// Draw fills the grid (indexed [column][row]) with the rows shown, scrolling to keep the cursor visible
+func (t *TextArea) Draw(grid [][]term.Pixel) {
+	t.Lock()
+	defer t.Unlock()
+
+	if len(grid) == 0 || len(grid[0]) == 0 {
+		return
+	}
+	t.width, t.height = len(grid), len(grid[0])
+	t.show()
+
+	fromRow, fromColumn, toRow, toColumn := t.selection()
+	row, segment := t.topRow, t.topSegment
+	for cell := 0; cell < t.height; cell++ {
+		var runes []rune
+		start, last := 0, false
+		if row < len(t.lines) {
+			segs := segments(t.lines[row], t.width)
+			start, last = segs[segment], segment == len(segs)-1
+			end := len(t.lines[row])
+			if !last {
+				end = segs[segment+1]
+			}
+			runes = t.lines[row][start:end]
+		}
+		for column := 0; column < t.width; column++ {
+			r, st := ' ', t.st
+			if column < len(runes) {
+				r = runes[column]
+			}
+			// the selected runes, and the end of the selected lines, which holds their new line
+			if t.selecting && row < len(t.lines) && (column < len(runes) || (column == len(runes) && last && row < toRow)) &&
+				!before(row, start+column, fromRow, fromColumn) && before(row, start+column, toRow, toColumn) {
+				st = t.selectedStyle
+			}
+			grid[column][cell].SetAll(st.Bg, st.Fg, st.Attrs, r, nil)
+		}
+		if row < len(t.lines) {
+			row, segment = t.next(row, segment)
+		}
+	}
+}
+
+// HandlePaste inserts the pasted text (see core.WithBracketedPaste), replacing the selection
+func (t *TextArea) HandlePaste(ev term.PasteEvent) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.edit(editOther)
+	t.insert(ev.Text())
+}
+
+// HandleKey edits the text, returning true if the event was consumed. The application decides when the text area has the keyboard focus, and draws it again after a consumed key (see Draw).
+func (t *TextArea) HandleKey(ev term.KeyEvent) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	shift, ctrl := ev.Modifiers()&key.ModShift != 0, ev.Modifiers()&key.ModCtrl != 0
+	switch ev.Key() {
+	case key.Rune:
+		if ev.Modifiers()&(key.ModAlt|key.ModCtrl|key.ModMeta) != 0 {
+			return false
+		}
+		t.edit(editTyping)
+		t.insert(string(ev.Rune()))
+	case key.Enter:
+		t.edit(editOther)
+		t.insert("\n")
+	case key.Backspace, key.Backspace2:
+		if !t.selecting && t.row == 0 && t.column == 0 {
+			return true
+		}
+		t.edit(editOther)
+		if !t.selecting { // the rune before the cursor is selected, then removed
+			t.selecting, t.anchorRow, t.anchorColumn = true, t.row, t.column
+			t.left()
+		}
+		t.insert("")
+	case key.Delete:
+		if !t.selecting && t.row == len(t.lines)-1 && t.column == len(t.lines[t.row]) {
+			return true
+		}
+		t.edit(editOther)
+		if !t.selecting {
+			t.selecting, t.anchorRow, t.anchorColumn = true, t.row, t.column
+			t.right()
+		}
+		t.insert("")
+	case key.Left, key.Right, key.Up, key.Down, key.Home, key.End, key.PgUp, key.PgDn:
+		t.move(ev.Key(), shift, ctrl)
+	case key.CtrlA:
+		t.selecting, t.anchorRow, t.anchorColumn = true, 0, 0
+		t.row = len(t.lines) - 1
+		t.column = len(t.lines[t.row])
+		t.lastEdit = editNone
+	case key.CtrlC:
+		t.copy()
+	case key.CtrlX:
+		if t.copy() {
+			t.edit(editOther)
+			t.insert("")
+		}
+	case key.CtrlV:
+		if t.copied != "" {
+			t.edit(editOther)
+			t.insert(t.copied)
+		}
+	case key.CtrlZ:
+		t.restore(&t.undo, &t.redo)
+	case key.CtrlY:
+		t.restore(&t.redo, &t.undo)
+	default:
+		return false
+	}
+	return true
+}
+
+// move moves the cursor, extending the selection if shift is pressed, otherwise removing it - locked inside caller function
+func (t *TextArea) move(k term.Key, shift, ctrl bool) {
+	if shift && !t.selecting {
+		t.selecting, t.anchorRow, t.anchorColumn = true, t.row, t.column
+	} else if !shift {
+		t.selecting = false
+	}
+	goal := -1
+	switch {
+	case k == key.Left:
+		t.left()
+	case k == key.Right:
+		t.right()
+	case k == key.Up, k == key.Down, k == key.PgUp, k == key.PgDn:
+		rows := 1
+		if k == key.PgUp || k == key.PgDn {
+			rows = term.Max(1, t.height-1)
+		}
+		if k == key.Up || k == key.PgUp {
+			rows = -rows
+		}
+		goal = t.vertical(rows)
+	case k == key.Home && ctrl:
+		t.row, t.column = 0, 0
+	case k == key.End && ctrl:
+		t.row = len(t.lines) - 1
+		t.column = len(t.lines[t.row])
+	case k == key.Home:
+		t.column = 0
+	case k == key.End:
+		t.column = len(t.lines[t.row])
+	}
+	t.goal, t.lastEdit = goal, editNone
+}
+
+// left moves the cursor back by one rune, to the end of the previous line from the beginning of a line - locked inside caller function
+func (t *TextArea) left() {
+	switch {
+	case t.column > 0:
+		t.column--
+	case t.row > 0:
+		t.row--
+		t.column = len(t.lines[t.row])
+	}
+}
+
+// right moves the cursor forward by one rune, to the beginning of the next line from the end of a line - locked inside caller function
+func (t *TextArea) right() {
+	switch {
+	case t.column < len(t.lines[t.row]):
+		t.column++
+	case t.row < len(t.lines)-1:
+		t.row++
+		t.column = 0
+	}
+}
+
+// vertical moves the cursor by that many rows as they are shown (negative upwards), keeping its column, and returns the column kept - locked inside caller function
+func (t *TextArea) vertical(rows int) int {
+	segment, column := t.cursorSegment()
+	if t.goal >= 0 {
+		column = t.goal
+	}
+	row := t.row
+	for ; rows < 0; rows++ {
+		if segment == 0 && row == 0 {
+			t.row, t.column = 0, 0
+			return column
+		}
+		row, segment = t.previous(row, segment)
+	}
+	for ; rows > 0; rows-- {
+		next, nextSegment := t.next(row, segment)
+		if next >= len(t.lines) {
+			t.row, t.column = row, len(t.lines[row])
+			return column
+		}
+		row, segment = next, nextSegment
+	}
+	segs := segments(t.lines[row], t.width)
+	end := len(t.lines[row])
+	if segment < len(segs)-1 {
+		end = segs[segment+1] - 1 // the last rune of the row, since its end is the start of the next one
+	}
+	t.row, t.column = row, term.Min(segs[segment]+column, end)
+	return column
+}
+
+// edit saves the content before it changes, unless the runes are typed one after the other - locked inside caller function
+func (t *TextArea) edit(kind editKind) {
+	if kind != editTyping || t.lastEdit != editTyping || t.selecting {
+		t.undo = pushSnapshot(t.undo, t.snapshot(), t.undoDepth)
+	}
+	t.redo, t.lastEdit, t.goal = nil, kind, -1
+}
+
+// restore replaces the content with the last snapshot of the stack, saving the current one on the other stack - locked inside caller function
+func (t *TextArea) restore(from, to *[]snapshot) {
+	if len(*from) == 0 {
+		return
+	}
+	saved := (*from)[len(*from)-1]
+	*from = (*from)[:len(*from)-1]
+	*to = pushSnapshot(*to, t.snapshot(), t.undoDepth)
+	t.lines, t.row, t.column = saved.lines, saved.row, saved.column
+	t.selecting, t.lastEdit, t.goal = false, editNone, -1
+}
+
+// snapshot copies the content and the cursor - locked inside caller function
+func (t *TextArea) snapshot() snapshot {
+	lines := make([][]rune, len(t.lines))
+	for idx, line := range t.lines {
+		lines[idx] = append([]rune{}, line...)
+	}
+	return snapshot{lines: lines, row: t.row, column: t.column}
+}
+
+// pushSnapshot appends the snapshot to the stack, dropping the oldest ones past the depth
+func pushSnapshot(stack []snapshot, s snapshot, depth int) []snapshot {
+	stack = append(stack, s)
+	if len(stack) > depth {
+		stack = append(stack[:0], stack[len(stack)-depth:]...)
+	}
+	return stack
+}
+
+// insert replaces the selection with the text, moving the cursor after it - locked inside caller function
+func (t *TextArea) insert(text string) {
+	if t.selecting {
+		fromRow, fromColumn, toRow, toColumn := t.selection()
+		tail := append([]rune{}, t.lines[toRow][toColumn:]...)
+		t.lines[fromRow] = append(t.lines[fromRow][:fromColumn], tail...)
+		t.lines = append(t.lines[:fromRow+1], t.lines[toRow+1:]...)
+		t.row, t.column, t.selecting = fromRow, fromColumn, false
+	}
+	if text == "" {
+		return
+	}
+	added := split(text)
+	tail := append([]rune{}, t.lines[t.row][t.column:]...)
+	head := append(t.lines[t.row][:t.column], added[0]...)
+	if len(added) == 1 {
+		t.column = len(head)
+		t.lines[t.row] = append(head, tail...)
+		t.hardWrapLine()
+		return
+	}
+	lines := make([][]rune, 0, len(t.lines)+len(added)-1)
+	lines = append(lines, t.lines[:t.row]...)
+	lines = append(lines, head)
+	lines = append(lines, added[1:]...)
+	lines = append(lines, t.lines[t.row+1:]...)
+	t.lines = lines
+	t.row += len(added) - 1
+	t.column = len(t.lines[t.row])
+	t.lines[t.row] = append(t.lines[t.row], tail...)
+	t.hardWrapLine()
+}
+
+// hardWrapLine breaks the line of the cursor at its last space which fits, as long as it's too long - locked inside caller function
+func (t *TextArea) hardWrapLine() {
+	if t.hardWrap <= 0 {
+		return
+	}
+	for len(t.lines[t.row]) > t.hardWrap {
+		line := t.lines[t.row]
+		space := -1
+		for idx := t.hardWrap; idx > 0; idx-- {
+			if line[idx] == ' ' {
+				space = idx
+				break
+			}
+		}
+		if space < 0 {
+			return // a single word, longer than the line
+		}
+		rest := append([]rune{}, line[space+1:]...)
+		t.lines[t.row] = line[:space]
+		t.lines = append(t.lines[:t.row+1], append([][]rune{rest}, t.lines[t.row+1:]...)...)
+		if t.column <= space {
+			return // the cursor stays on this line
+		}
+		t.row++
+		t.column -= space + 1
+	}
+}
+
+// copy keeps the selected text for pasting, sending it to the clipboard too, and returns false if nothing is selected - locked inside caller function
+func (t *TextArea) copy() bool {
+	text := t.selected()
+	if text == "" {
+		return false
+	}
+	t.copied = text
+	if t.clipboard != nil {
+		t.clipboard.SetClipboard(text)
+	}
+	return true
+}
+
+// selected returns the selected text - locked inside caller function
+func (t *TextArea) selected() string {
+	if !t.selecting {
+		return ""
+	}
+	fromRow, fromColumn, toRow, toColumn := t.selection()
+	if fromRow == toRow {
+		return string(t.lines[fromRow][fromColumn:toColumn])
+	}
+	lines := make([][]rune, 0, toRow-fromRow+1)
+	lines = append(lines, t.lines[fromRow][fromColumn:])
+	lines = append(lines, t.lines[fromRow+1:toRow]...)
+	lines = append(lines, t.lines[toRow][:toColumn])
+	return join(lines)
+}
+
+// selection returns the start and the end of the selection, the end being excluded - locked inside caller function
+func (t *TextArea) selection() (int, int, int, int) {
+	if before(t.row, t.column, t.anchorRow, t.anchorColumn) {
+		return t.row, t.column, t.anchorRow, t.anchorColumn
+	}
+	return t.anchorRow, t.anchorColumn, t.row, t.column
+}
+
+// cursorSegment returns the segment of the line (see segments) holding the cursor, and its column there - locked inside caller function
+func (t *TextArea) cursorSegment() (int, int) {
+	segs := segments(t.lines[t.row], t.width)
+	segment := len(segs) - 1
+	for segment > 0 && segs[segment] > t.column {
+		segment--
+	}
+	return segment, t.column - segs[segment]
+}
+
+// next returns the row shown after the segment of the line, the line past the last one if there is none - locked inside caller function
+func (t *TextArea) next(row, segment int) (int, int) {
+	if segment+1 < len(segments(t.lines[row], t.width)) {
+		return row, segment + 1
+	}
+	return row + 1, 0
+}
+
+// previous returns the row shown before the segment of the line, which must not be the first one - locked inside caller function
+func (t *TextArea) previous(row, segment int) (int, int) {
+	if segment > 0 {
+		return row, segment - 1
+	}
+	return row - 1, len(segments(t.lines[row-1], t.width)) - 1
+}
+
+// distance counts the rows shown from the first segment to the second one, up to the limit - locked inside caller function
+func (t *TextArea) distance(fromRow, fromSegment, toRow, toSegment, limit int) int {
+	count := 0
+	for count < limit && (fromRow != toRow || fromSegment != toSegment) && fromRow < len(t.lines) {
+		fromRow, fromSegment = t.next(fromRow, fromSegment)
+		count++
+	}
+	return count
+}
+
+// show scrolls, so the cursor is visible. Only the rows between the first one shown and the cursor are laid out - locked inside caller function
+func (t *TextArea) show() {
+	t.topRow = term.Min(t.topRow, len(t.lines)-1)
+	t.topSegment = term.Min(t.topSegment, len(segments(t.lines[t.topRow], t.width))-1)
+	segment, _ := t.cursorSegment()
+	if before(t.row, segment, t.topRow, t.topSegment) {
+		t.topRow, t.topSegment = t.row, segment
+		return
+	}
+	if t.distance(t.topRow, t.topSegment, t.row, segment, t.height) < t.height {
+		return
+	}
+	t.topRow, t.topSegment = t.row, segment
+	for rows := 1; rows < t.height && (t.topRow > 0 || t.topSegment > 0); rows++ {
+		t.topRow, t.topSegment = t.previous(t.topRow, t.topSegment)
+	}
+}
+
+// segments returns where the rows showing the line start : the line wraps at the last space which fits in the columns, or at the columns if there is none.
+// A line filling the columns gets an empty row after it, for the cursor at its end.
+func segments(line []rune, columns int) []int {
+	result := []int{0}
+	if columns <= 0 {
+		return result
+	}
+	for start := 0; len(line)-start >= columns; {
+		end := start + columns
+		for idx := end; idx > start && end < len(line); idx-- {
+			if line[idx-1] == ' ' {
+				end = idx
+				break
+			}
+		}
+		result = append(result, end)
+		start = end
+	}
+	return result
+}
+
+// before returns true if the first position is before the second one
+func before(row, column, otherRow, otherColumn int) bool {
+	return row < otherRow || (row == otherRow && column < otherColumn)
+}
+
+// split returns the lines of the text
+func split(text string) [][]rune {
+	parts := strings.Split(text, "\n")
+	result := make([][]rune, len(parts))
+	for idx, part := range parts {
+		result[idx] = []rune(part)
+	}
+	return result
+}
+
+// join returns the text of the lines
+func join(lines [][]rune) string {
+	parts := make([]string, len(lines))
+	for idx, line := range lines {
+		parts[idx] = string(line)
+	}
+	return strings.Join(parts, "\n")
+}
@@ -0,0 +1,139 @@
+package editor_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/editor"
+	"github.com/badu/term/geom"
+	"github.com/badu/term/key"
+	"github.com/badu/term/style"
+)
+
+// recordingClipboard keeps the copied text
+type recordingClipboard struct{ text string }
+
+func (c *recordingClipboard) SetClipboard(text string) bool {
+	c.text = text
+	return true
+}
+
+// pressKeys sends the runes as key presses, the other keys being sent as they are
+func pressKeys(t *editor.TextArea, keys ...interface{}) {
+	for _, k := range keys {
+		switch value := k.(type) {
+		case string:
+			for _, r := range value {
+				if r == '\n' {
+					t.HandleKey(key.NewEvent(key.Enter, 0, key.ModNone))
+					continue
+				}
+				t.HandleKey(key.NewEvent(key.Rune, r, key.ModNone))
+			}
+		case term.Key:
+			t.HandleKey(key.NewEvent(value, 0, key.ModNone))
+		case term.KeyEvent:
+			t.HandleKey(value)
+		}
+	}
+}
+
+func TestTextAreaSoftWrap(t *testing.T) {
+	area := editor.NewTextArea(editor.WithText("the quick brown fox\njumps"))
+	grid, _ := geom.NewPixelGrid(&term.Size{Columns: 10, Rows: 3})
+	area.Draw(grid)
+	if got := rows(grid); got[0] != "the quick " || got[1] != "brown fox " || got[2] != "jumps     " {
+		t.Errorf("error : the first line should wrap at its last space, got %q", got)
+	}
+
+	// Down moves by the rows shown, keeping the column
+	pressKeys(area, key.Right, key.Right, key.Down)
+	if row, column := area.Cursor(); row != 0 || column != 12 {
+		t.Errorf("error : expecting the cursor on the wrapped part of the line, got %d,%d", row, column)
+	}
+	pressKeys(area, key.Down, key.Down)
+	if row, column := area.Cursor(); row != 1 || column != 5 {
+		t.Errorf("error : expecting the cursor at the end of the last line, got %d,%d", row, column)
+	}
+
+	// only the rows around the cursor are shown
+	pressKeys(area, "\nover\nthe\nlazy\ndog")
+	area.Draw(grid)
+	if got := rows(grid); got[0] != "the       " || got[2] != "dog       " {
+		t.Errorf("error : the text area should scroll to the cursor, got %q", got)
+	}
+	if column, row := area.CursorCell(); column != 3 || row != 2 {
+		t.Errorf("error : unexpected cursor cell %d,%d", column, row)
+	}
+	pressKeys(area, key.NewEvent(key.Home, 0, key.ModCtrl))
+	area.Draw(grid)
+	if got := rows(grid); got[0] != "the quick " {
+		t.Errorf("error : Ctrl+Home should scroll back to the beginning, got %q", got)
+	}
+}
+
+func TestTextAreaSelectionAndClipboard(t *testing.T) {
+	clipboard := &recordingClipboard{}
+	area := editor.NewTextArea(editor.WithText("hello\nworld"), editor.WithClipboard(clipboard))
+	pressKeys(area, key.Right, key.Right, key.Right, key.NewEvent(key.Down, 0, key.ModShift), key.NewEvent(key.Left, 0, key.ModShift))
+	if selected := area.Selected(); selected != "lo\nwo" {
+		t.Errorf("error : unexpected selection %q", selected)
+	}
+	grid, _ := geom.NewPixelGrid(&term.Size{Columns: 6, Rows: 2})
+	area.Draw(grid)
+	for _, cell := range []struct {
+		column, row int
+		selected    bool
+	}{{2, 0, false}, {3, 0, true}, {5, 0, true}, {1, 1, true}, {2, 1, false}} {
+		if _, _, attrs := grid[cell.column][cell.row].Style(); (attrs == style.Reverse) != cell.selected {
+			t.Errorf("error : the cell %d,%d should be selected : %t", cell.column, cell.row, cell.selected)
+		}
+	}
+
+	pressKeys(area, key.CtrlX)
+	if area.Text() != "helrld" || clipboard.text != "lo\nwo" {
+		t.Errorf("error : cutting should remove the selection and copy it, got %q %q", area.Text(), clipboard.text)
+	}
+	pressKeys(area, key.End, key.CtrlV)
+	if area.Text() != "helrldlo\nwo" {
+		t.Errorf("error : Ctrl-V should paste the cut text, got %q", area.Text())
+	}
+	pressKeys(area, key.CtrlA, "x")
+	if area.Text() != "x" {
+		t.Errorf("error : typing should replace the selection, got %q", area.Text())
+	}
+}
+
+func TestTextAreaUndo(t *testing.T) {
+	area := editor.NewTextArea()
+	pressKeys(area, "one two", key.Left, "!", key.Backspace, key.Backspace)
+	if area.Text() != "one to" {
+		t.Fatalf("error : unexpected text %q", area.Text())
+	}
+	for _, expected := range []string{"one two", "one tw!o", "one two", ""} {
+		pressKeys(area, key.CtrlZ)
+		if area.Text() != expected {
+			t.Errorf("error : expecting %q after undo, got %q", expected, area.Text())
+		}
+	}
+	pressKeys(area, key.CtrlY, key.CtrlY)
+	if area.Text() != "one tw!o" {
+		t.Errorf("error : redo should replay the edits, got %q", area.Text())
+	}
+	pressKeys(area, "x", key.CtrlY)
+	if area.Text() != "one tw!xo" {
+		t.Errorf("error : an edit should forget the edits which were undone, got %q", area.Text())
+	}
+}
+
+func TestTextAreaHardWrap(t *testing.T) {
+	area := editor.NewTextArea(editor.WithHardWrap(10))
+	pressKeys(area, "the quick brown fox jumps")
+	if got := area.Text(); got != "the quick\nbrown fox\njumps" {
+		t.Errorf("error : the lines should be broken as they are typed, got %q", strings.ReplaceAll(got, "\n", "|"))
+	}
+	if row, column := area.Cursor(); row != 2 || column != 5 {
+		t.Errorf("error : the cursor should follow the typed text, got %d,%d", row, column)
+	}
+}
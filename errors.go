@@ -0,0 +1,82 @@
+package term
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrTerminalLost indicates that the terminal can't be written anymore (e.g. the ssh connection dropped, or the terminal window was closed). Any *WriteError matches it with errors.Is.
+	ErrTerminalLost = errors.New("terminal lost")
+	// ErrInputClosed indicates that the input of the terminal reached its end, or failed, so no more events are coming. Any *ReadError matches it with errors.Is.
+	ErrInputClosed = errors.New("terminal input closed")
+	// ErrNilPosition indicates that a position given to the engine (e.g. a corner of the region to fill) is nil
+	ErrNilPosition = errors.New("position is nil")
+)
+
+// ErrNotSupported indicates that the terminal doesn't have the capability. With errors.Is, an ErrNotSupported without capability matches all of them.
+type ErrNotSupported struct {
+	Capability string // the terminfo name of the capability, e.g. "fsl", or the name of the feature
+}
+
+// Error implements error interface
+func (e ErrNotSupported) Error() string {
+	if e.Capability == "" {
+		return "terminal capability not available"
+	}
+	return fmt.Sprintf("terminal capability %q not available", e.Capability)
+}
+
+// Is returns true for an ErrNotSupported of the same capability, or without capability
+func (e ErrNotSupported) Is(target error) bool {
+	var other ErrNotSupported
+	switch t := target.(type) {
+	case ErrNotSupported:
+		other = t
+	case *ErrNotSupported:
+		other = *t
+	default:
+		return false
+	}
+	return other.Capability == "" || other.Capability == e.Capability
+}
+
+// WriteError is a failed write to the terminal, wrapping the error of the writer
+type WriteError struct {
+	Err error // returned by the writer
+}
+
+// Error implements error interface
+func (e *WriteError) Error() string {
+	return "could not write to terminal : " + e.Err.Error()
+}
+
+// Unwrap returns the error of the writer
+func (e *WriteError) Unwrap() error {
+	return e.Err
+}
+
+// Is returns true for ErrTerminalLost
+func (e *WriteError) Is(target error) bool {
+	return target == ErrTerminalLost
+}
+
+// ReadError is a failed read of the terminal input (e.g. EIO, once the terminal hung up), wrapping the error of the reader
+type ReadError struct {
+	Err error // returned by the reader
+}
+
+// Error implements error interface
+func (e *ReadError) Error() string {
+	return "could not read terminal input : " + e.Err.Error()
+}
+
+// Unwrap returns the error of the reader
+func (e *ReadError) Unwrap() error {
+	return e.Err
+}
+
+// Is returns true for ErrInputClosed
+func (e *ReadError) Is(target error) bool {
+	return target == ErrInputClosed
+}
@@ -0,0 +1,109 @@
+package geom
+
+import (
+	"github.com/badu/term"
+)
+
+// Bounds is a lightweight rectangle (no pixels, no goroutines) used for hit-testing and clipping.
+// All the edges are inclusive, as the corners of a Rectangle are : a single pixel has Left == Right and Top == Bottom.
+type Bounds struct {
+	Left   int // first column
+	Top    int // first row
+	Right  int // last column
+	Bottom int // last row
+}
+
+// NewBounds returns the bounds between two corners, regardless of their order
+func NewBounds(corner1, corner2 *term.Position) Bounds {
+	return Bounds{
+		Left:   term.Min(corner1.Column, corner2.Column),
+		Top:    term.Min(corner1.Row, corner2.Row),
+		Right:  term.Max(corner1.Column, corner2.Column),
+		Bottom: term.Max(corner1.Row, corner2.Row),
+	}
+}
+
+// Empty returns true if the bounds don't contain any pixel
+func (b Bounds) Empty() bool {
+	return b.Left > b.Right || b.Top > b.Bottom
+}
+
+// Size returns the number of columns and rows
+func (b Bounds) Size() *term.Size {
+	if b.Empty() {
+		return term.NewSize(0, 0)
+	}
+	return term.NewSize(b.Right-b.Left+1, b.Bottom-b.Top+1)
+}
+
+// Contains returns true if the point is inside the bounds
+func (b Bounds) Contains(column, row int) bool {
+	return column >= b.Left && column <= b.Right && row >= b.Top && row <= b.Bottom
+}
+
+// In returns true if every point of b is inside o
+func (b Bounds) In(o Bounds) bool {
+	if b.Empty() {
+		return true
+	}
+	return b.Left >= o.Left && b.Right <= o.Right && b.Top >= o.Top && b.Bottom <= o.Bottom
+}
+
+// Overlaps returns true if b and o have at least one point in common
+func (b Bounds) Overlaps(o Bounds) bool {
+	return !b.Intersect(o).Empty()
+}
+
+// Intersect returns the largest bounds contained by both b and o. The result is Empty if they don't overlap.
+func (b Bounds) Intersect(o Bounds) Bounds {
+	return Bounds{
+		Left:   term.Max(b.Left, o.Left),
+		Top:    term.Max(b.Top, o.Top),
+		Right:  term.Min(b.Right, o.Right),
+		Bottom: term.Min(b.Bottom, o.Bottom),
+	}
+}
+
+// Union returns the smallest bounds containing both b and o
+func (b Bounds) Union(o Bounds) Bounds {
+	if b.Empty() {
+		return o
+	}
+	if o.Empty() {
+		return b
+	}
+	return Bounds{
+		Left:   term.Min(b.Left, o.Left),
+		Top:    term.Min(b.Top, o.Top),
+		Right:  term.Max(b.Right, o.Right),
+		Bottom: term.Max(b.Bottom, o.Bottom),
+	}
+}
+
+// Clip returns the part of b (a child) which is visible inside parent. Same as Intersect, named for readability.
+func (b Bounds) Clip(parent Bounds) Bounds {
+	return b.Intersect(parent)
+}
+
+// Bounds returns the bounds of the rectangle
+func (r *Rectangle) Bounds() Bounds {
+	return NewBounds(r.topCorner, r.bottomCorner)
+}
+
+// Contains returns true if the point is inside the rectangle
+func (r *Rectangle) Contains(column, row int) bool {
+	return !r.Invalid() && r.Bounds().Contains(column, row)
+}
+
+// clipTo restricts the corners of the rectangle to its parent bounds, returning false if nothing remains visible
+func (r *Rectangle) clipTo(parent *Rectangle) bool {
+	clipped := r.Bounds().Clip(parent.Bounds())
+	if clipped.Empty() {
+		return false
+	}
+	r.topCorner.Column, r.topCorner.Row = clipped.Left, clipped.Top
+	r.bottomCorner.Column, r.bottomCorner.Row = clipped.Right, clipped.Bottom
+	r.topCorner.UpdateHash()
+	r.bottomCorner.UpdateHash()
+	return true
+}
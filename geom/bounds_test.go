@@ -0,0 +1,52 @@
+package geom_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/geom"
+)
+
+func TestBounds(t *testing.T) {
+	a := geom.NewBounds(term.NewPosition(5, 5), term.NewPosition(0, 0)) // corners in any order
+	b := geom.Bounds{Left: 3, Top: 4, Right: 9, Bottom: 8}
+	c := geom.Bounds{Left: 7, Top: 0, Right: 9, Bottom: 2}
+
+	if !a.Contains(0, 0) || !a.Contains(5, 5) || a.Contains(6, 5) || a.Contains(5, -1) {
+		t.Fatal("contains : edges are inclusive")
+	}
+	if size := a.Size(); size.Columns != 6 || size.Rows != 6 {
+		t.Fatalf("expecting 6x6, got %dx%d", size.Columns, size.Rows)
+	}
+	if got := a.Intersect(b); got != (geom.Bounds{Left: 3, Top: 4, Right: 5, Bottom: 5}) {
+		t.Fatalf("bad intersection : %#v", got)
+	}
+	if a.Overlaps(c) || !a.Intersect(c).Empty() {
+		t.Fatal("a and c don't overlap")
+	}
+	if got := a.Union(c); got != (geom.Bounds{Left: 0, Top: 0, Right: 9, Bottom: 5}) {
+		t.Fatalf("bad union : %#v", got)
+	}
+	if got := b.Clip(a); !got.In(a) || got.In(c) {
+		t.Fatalf("clipped child should be inside the parent : %#v", got)
+	}
+	if !(geom.Bounds{Left: 1, Right: 0}).In(c) {
+		t.Fatal("empty bounds are in anything")
+	}
+}
+
+func TestRectangleContains(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r, err := geom.NewRectangle(ctx, testAcquisitionChan(), geom.WithTopCorner(2, 3), geom.WithBottomCorner(4, 6))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	if !r.Contains(2, 3) || !r.Contains(4, 6) || r.Contains(1, 3) || r.Contains(4, 7) {
+		t.Fatal("rectangle corners are inclusive")
+	}
+	if size := r.Bounds().Size(); *size != *r.Size() {
+		t.Fatalf("bounds size %v differs from rectangle size %v", size, r.Size())
+	}
+}
@@ -0,0 +1,156 @@
+package geom
+
+import (
+	"github.com/badu/term"
+)
+
+// Constraint describes one dimension (width or height) of a rectangle, relative to its parent.
+// The size is Cells plus Percent of the parent, clamped between Min and Max. A zero Min or Max means no bound.
+type Constraint struct {
+	Cells   int // absolute number of cells
+	Percent int // percent of the parent dimension
+	Min     int // minimum number of cells
+	Max     int // maximum number of cells
+}
+
+// Cells returns a constraint of an absolute number of cells
+func Cells(cells int) Constraint {
+	return Constraint{Cells: cells}
+}
+
+// Percent returns a constraint of a percent of the parent
+func Percent(percent int) Constraint {
+	return Constraint{Percent: percent}
+}
+
+// AtLeast returns a copy of the constraint, which never goes below min cells
+func (c Constraint) AtLeast(min int) Constraint {
+	c.Min = min
+	return c
+}
+
+// AtMost returns a copy of the constraint, which never goes above max cells
+func (c Constraint) AtMost(max int) Constraint {
+	c.Max = max
+	return c
+}
+
+// Resolve returns the number of cells, for the parent dimension
+func (c Constraint) Resolve(parent int) int {
+	return c.clamp(c.Cells + parent*c.Percent/100)
+}
+
+// clamp keeps the cells between Min and Max
+func (c Constraint) clamp(cells int) int {
+	if c.Max > 0 && cells > c.Max {
+		cells = c.Max
+	}
+	if cells < c.Min {
+		cells = c.Min
+	}
+	if cells < 0 {
+		cells = 0
+	}
+	return cells
+}
+
+// canGrow returns true if the constraint allows one more cell
+func (c Constraint) canGrow(cells int) bool {
+	return c.Max <= 0 || cells < c.Max
+}
+
+// canShrink returns true if the constraint allows one less cell
+func (c Constraint) canShrink(cells int) bool {
+	return cells > c.Min && cells > 0
+}
+
+// WithWidthConstraint sets the width of the rectangle, relative to its parent
+func WithWidthConstraint(c Constraint) RectangleOption {
+	return func(r *Rectangle) {
+		r.width = &c
+	}
+}
+
+// WithHeightConstraint sets the height of the rectangle, relative to its parent
+func WithHeightConstraint(c Constraint) RectangleOption {
+	return func(r *Rectangle) {
+		r.height = &c
+	}
+}
+
+// distribute computes the sizes of the siblings along one axis, so they fit the available cells.
+// A nil constraint fills the space left by the others, but it's never smaller than its minimum.
+// Leftover cells are given one at a time, in order from the first sibling, to the filling siblings
+// or (when there are none and the percents add up to at least 100) to the percent ones.
+// Missing cells are taken back one at a time, in order from the last sibling, without going below the minimums.
+func distribute(available int, constraints []*Constraint, mins []int) []int {
+	sizes := make([]int, len(constraints))
+	limits := make([]Constraint, len(constraints))
+	used, fills, percents := 0, 0, 0
+	for idx, c := range constraints {
+		if c == nil {
+			limits[idx] = Constraint{Min: mins[idx]}
+			fills++
+		} else {
+			limits[idx] = *c
+			limits[idx].Min = term.Max(c.Min, mins[idx])
+			percents += c.Percent
+		}
+		sizes[idx] = limits[idx].Resolve(available)
+		used += sizes[idx]
+	}
+	growable := make([]bool, len(constraints))
+	for idx, c := range constraints {
+		switch {
+		case c == nil:
+			growable[idx] = true
+		case fills == 0 && percents >= 100:
+			growable[idx] = c.Percent > 0
+		}
+	}
+
+	for leftover := available - used; leftover > 0; {
+		given := false
+		for idx := range sizes {
+			if leftover == 0 {
+				break
+			}
+			if growable[idx] && limits[idx].canGrow(sizes[idx]) {
+				sizes[idx]++
+				leftover--
+				given = true
+			}
+		}
+		if !given {
+			break
+		}
+	}
+
+	for missing := used - available; missing > 0; {
+		taken := false
+		for idx := len(sizes) - 1; idx >= 0; idx-- {
+			if missing == 0 {
+				break
+			}
+			if limits[idx].canShrink(sizes[idx]) {
+				sizes[idx]--
+				missing--
+				taken = true
+			}
+		}
+		if !taken {
+			break
+		}
+	}
+	return sizes
+}
+
+// across returns the size of the rectangle across the parent axis : the constraint, or the whole parent dimension when not set
+func across(c *Constraint, min, available int) int {
+	if c == nil {
+		return term.Max(available, min)
+	}
+	limit := *c
+	limit.Min = term.Max(c.Min, min)
+	return limit.Resolve(available)
+}
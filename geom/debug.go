@@ -1,5 +1,6 @@
 package geom
 
-const (
-	Debug = true // if set to false, the compiler will cleanup/remove the lines inside functions
-)
+import "github.com/badu/term/debug"
+
+// Debug is the diagnosis log of the package, enabled at runtime with TERM_DEBUG=geom or core.WithDebug("geom") (see package debug)
+var Debug = debug.For("geom")
@@ -2,12 +2,16 @@ package geom_test
 
 import (
 	"context"
+	"io"
+	"io/ioutil"
+	"log"
 	"testing"
 
 	"github.com/badu/term"
 	"github.com/badu/term/core"
 	"github.com/badu/term/key"
 	"github.com/badu/term/mouse"
+	"github.com/badu/term/style"
 )
 
 // for readability
@@ -52,11 +56,11 @@ func (e *FakeMouseDispatcher) Register(l term.MouseListener) {
 		select {
 		case <-e.ctx.Done():
 			if e.t != nil {
-				e.t.Log("[fake mouse dispatcher] context died : existing death listener")
+				log.Print("[fake mouse dispatcher] context died : existing death listener") // the test might be completed
 			}
 			return
 		case <-l.DyingChan(): // wait for death announcement
-			e.t.Log("component died")
+			log.Print("component died")
 			// now lookup for that very channel and forget it
 			for idx, ch := range e.receivers {
 				// Two channel values are considered equal if they originated from the same make call (meaning they refer to the same channel value in memory).
@@ -123,10 +127,10 @@ func (e *FakeKeyDispatcher) Register(l term.KeyListener) {
 	go func() {
 		select {
 		case <-e.ctx.Done():
-			e.t.Log("[fake key dispatcher] context died : existing death listener")
+			log.Print("[fake key dispatcher] context died : existing death listener") // the test might be completed
 			return
 		case <-l.DyingChan(): // wait for death announcement
-			e.t.Log("component died")
+			log.Print("component died")
 			// now lookup for that very channel and forget it
 			for idx, ch := range e.receivers {
 				// Two channel values are considered equal if they originated from the same make call (meaning they refer to the same channel value in memory).
@@ -192,10 +196,10 @@ func (e *FakeResizeDispatcher) Register(l term.ResizeListener) {
 	go func() {
 		select {
 		case <-e.ctx.Done():
-			e.t.Log("[fake resize dispatcher] context died : existing death listener")
+			log.Print("[fake resize dispatcher] context died : existing death listener") // the test might be completed
 			return
 		case <-l.DyingChan(): // wait for death announcement
-			e.t.Log("component died")
+			log.Print("component died")
 			// now lookup for that very channel and forget it
 			for idx, ch := range e.receivers {
 				// Two channel values are considered equal if they originated from the same make call (meaning they refer to the same channel value in memory).
@@ -223,6 +227,7 @@ type FakeEngine struct {
 	MD            *FakeMouseDispatcher
 	KD            *FakeKeyDispatcher
 	RD            *FakeResizeDispatcher
+	Redrawn       [][]term.PixelGetter
 }
 
 func NewFakeEngine(t *testing.T, cols, rows int) *FakeEngine {
@@ -293,6 +298,26 @@ func (e *FakeEngine) HasTrueColor() bool {
 	return false
 }
 
+func (e *FakeEngine) Attributes() style.Mask {
+	return style.None
+}
+
+func (e *FakeEngine) ColorProfile() term.ColorProfile {
+	return term.ProfileTrueColor
+}
+
+func (e *FakeEngine) IsDarkBackground() (bool, bool) {
+	return true, false
+}
+
+func (e *FakeEngine) ThemeDispatcher() term.ThemeDispatcher {
+	return nil
+}
+
+func (e *FakeEngine) TimerDispatcher() term.TimerDispatcher {
+	return nil
+}
+
 func (e *FakeEngine) Style() term.Style {
 	return nil
 }
@@ -303,6 +328,7 @@ func (e *FakeEngine) ActivePixels(pixels []term.PixelGetter) {
 
 func (e *FakeEngine) Redraw(pixels []term.PixelGetter) {
 	e.t.Logf("consider that %d pixels were redrawn", len(pixels))
+	e.Redrawn = append(e.Redrawn, pixels)
 }
 
 func (e *FakeEngine) ShowCursor(where *term.Position) {
@@ -318,3 +344,12 @@ func (e *FakeEngine) Cursor() *term.Position {
 func (e *FakeEngine) Clear() {}
 
 func (e *FakeEngine) HasMouse() bool { return true }
+
+func (e *FakeEngine) InterruptChan() chan struct{} { return nil }
+
+func (e *FakeEngine) PollEvent(ctx context.Context) (term.Event, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (e *FakeEngine) Writer() io.Writer { return ioutil.Discard }
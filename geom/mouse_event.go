@@ -0,0 +1,61 @@
+package geom
+
+import (
+	"github.com/badu/term"
+)
+
+// Crossing tells if the mouse pointer has entered or left a rectangle with an event
+type Crossing int
+
+const (
+	NoCrossing Crossing = iota // the pointer was, and still is, over the rectangle
+	MouseEnter                 // the pointer has just entered the rectangle
+	MouseLeave                 // the pointer has just left the rectangle, so the position is outside of it
+)
+
+// String implements fmt.Stringer interface
+func (c Crossing) String() string {
+	switch c {
+	case MouseEnter:
+		return "Enter"
+	case MouseLeave:
+		return "Leave"
+	default:
+		return "None"
+	}
+}
+
+// LocalMouseEvent is the mouse event which the rectangle hooks receive : its Position is relative to the top corner of the rectangle,
+// so widgets don't need to know their placement on the screen. The original event is embedded.
+type LocalMouseEvent struct {
+	term.MouseEvent          // the event, as the page has received it
+	column          int      // relative to the top corner of the rectangle
+	row             int      // relative to the top corner of the rectangle
+	crossing        Crossing // enter or leave transition
+}
+
+// Position implements term.MouseEvent interface, returning the position relative to the top corner of the rectangle
+func (e *LocalMouseEvent) Position() (int, int) {
+	return e.column, e.row
+}
+
+// Absolute returns the position on the screen
+func (e *LocalMouseEvent) Absolute() (int, int) {
+	return e.MouseEvent.Position()
+}
+
+// Crossing returns MouseEnter or MouseLeave when the pointer crosses the border of the rectangle with this event, NoCrossing otherwise
+func (e *LocalMouseEvent) Crossing() Crossing {
+	return e.crossing
+}
+
+// localEvent translates the event to the coordinates of the rectangle
+func (r *Rectangle) localEvent(ev term.MouseEvent, crossing Crossing) *LocalMouseEvent {
+	column, row := ev.Position()
+	return &LocalMouseEvent{
+		MouseEvent: ev,
+		column:     column - r.topCorner.Column,
+		row:        row - r.topCorner.Row,
+		crossing:   crossing,
+	}
+}
@@ -0,0 +1,130 @@
+package geom
+
+import (
+	"sync"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+// Effect computes the new style of a pixel which is beneath a layer (e.g. a popup)
+type Effect func(fg, bg color.Color, attrs style.Mask) (color.Color, color.Color, style.Mask)
+
+// DimEffect darkens the colors by amount (0 is unchanged, 1 is black), blending them towards black in OKLab space.
+// Colors which can't be blended (e.g. Default) get the Dim attribute instead.
+func DimEffect(amount float64) Effect {
+	return func(fg, bg color.Color, attrs style.Mask) (color.Color, color.Color, style.Mask) {
+		if _, ok := color.RelativeLuminance(fg); ok {
+			fg = color.Blend(fg, color.Black, amount)
+		} else {
+			attrs |= style.Dim
+		}
+		if _, ok := color.RelativeLuminance(bg); ok {
+			bg = color.Blend(bg, color.Black, amount)
+		}
+		return fg, bg, attrs
+	}
+}
+
+// ShadowEffect makes the pixels look like a drop shadow : the content is still visible, as dark gray on black
+func ShadowEffect() Effect {
+	return func(fg, bg color.Color, attrs style.Mask) (color.Color, color.Color, style.Mask) {
+		return color.DimGray, color.Black, attrs &^ (style.Bold | style.Reverse | style.Blink)
+	}
+}
+
+// savedPixel is the original style of a pixel changed by an Overlay
+type savedPixel struct {
+	pixel term.Pixel
+	fg    color.Color
+	bg    color.Color
+	attrs style.Mask
+}
+
+// Overlay applies effects on the content beneath a layer and restores it afterwards.
+// The content is found using term.ContentGetter, which is implemented by the core engine (or by GridContent, for pixels owned by the application).
+// Effects can be stacked (e.g. a shadow on a dimmed screen), while Restore brings back the style which the pixels had before the first effect.
+// Only the style is changed : runes are left as they are.
+type Overlay struct {
+	sync.Mutex                     //
+	content    term.ContentGetter  // what's underneath
+	saved      map[int]*savedPixel // original styles, by position hash
+}
+
+// NewOverlay creates an overlay for the content
+func NewOverlay(content term.ContentGetter) *Overlay {
+	return &Overlay{content: content, saved: make(map[int]*savedPixel)}
+}
+
+// Apply changes the pixels inside the bounds, except the ones inside any of the excluded bounds (e.g. the popup itself)
+func (o *Overlay) Apply(b Bounds, effect Effect, except ...Bounds) {
+	o.Lock()
+	defer o.Unlock()
+	for row := b.Top; row <= b.Bottom; row++ {
+	columns:
+		for column := b.Left; column <= b.Right; column++ {
+			for _, e := range except {
+				if e.Contains(column, row) {
+					continue columns
+				}
+			}
+			pixel, ok := o.content.GetContent(column, row)
+			if !ok {
+				continue
+			}
+			fg, bg, attrs := pixel.Style()
+			hash := term.Hash(column, row)
+			if _, has := o.saved[hash]; !has {
+				o.saved[hash] = &savedPixel{pixel: pixel, fg: fg, bg: bg, attrs: attrs}
+			}
+			newFg, newBg, newAttrs := effect(fg, bg, attrs)
+			pixel.SetFgBg(newFg, newBg)
+			if newAttrs != attrs {
+				pixel.SetAttrs(newAttrs)
+			}
+		}
+	}
+}
+
+// Dim darkens everything inside the screen bounds, except the layer
+func (o *Overlay) Dim(screen, layer Bounds, amount float64) {
+	o.Apply(screen, DimEffect(amount), layer)
+}
+
+// DropShadow darkens the pixels covered by the layer moved by the offset (usually 2 columns and 1 row, to the right and down), except the layer itself
+func (o *Overlay) DropShadow(layer Bounds, columns, rows int) {
+	shadow := Bounds{Left: layer.Left + columns, Top: layer.Top + rows, Right: layer.Right + columns, Bottom: layer.Bottom + rows}
+	o.Apply(shadow, ShadowEffect(), layer)
+}
+
+// Restore brings back the original style of all changed pixels and forgets them
+func (o *Overlay) Restore() {
+	o.Lock()
+	defer o.Unlock()
+	for hash, saved := range o.saved {
+		saved.pixel.SetFgBg(saved.fg, saved.bg)
+		saved.pixel.SetAttrs(saved.attrs)
+		delete(o.saved, hash)
+	}
+}
+
+// gridContent implements term.ContentGetter for pixels owned by the application
+type gridContent map[int]term.Pixel
+
+// GetContent implements term.ContentGetter
+func (g gridContent) GetContent(column, row int) (term.Pixel, bool) {
+	pixel, ok := g[term.Hash(column, row)]
+	return pixel, ok
+}
+
+// GridContent returns a term.ContentGetter for a grid of pixels (e.g. as returned by NewPixelGrid), indexing them by their positions
+func GridContent(grid [][]term.Pixel) term.ContentGetter {
+	result := make(gridContent)
+	for column := range grid {
+		for _, pixel := range grid[column] {
+			result[pixel.PositionHash()] = pixel
+		}
+	}
+	return result
+}
@@ -74,7 +74,13 @@ type Page struct {
 	incomingResize chan term.ResizeEvent //
 	died           chan struct{}         //
 	owners         map[int]Owners        // map[position_hash]Owners
+	rects          []*Rectangle          // rectangles receiving mouse events, in the order they were added (last one is on top)
+	hovered        *Rectangle            // the rectangle under the mouse pointer, which receives the leave event
 	hidden         bool                  //
+	active         []term.PixelGetter    // the pixels registered with the engine, on startup
+	cells          map[int]*px           // the active pixels, by position hash, for the bulk setters
+	columns        int                   // the number of columns of the active pixels
+	rows           int                   // the number of rows of the active pixels
 }
 
 // WithEngine
@@ -130,7 +136,11 @@ func NewPage(ctx context.Context, opts ...PageOption) (*Page, error) {
 
 func (p *Page) lifeCycle(ctx context.Context) {
 	p.Once.Do(func() {
-		p.startup(p.engine)
+		active := p.startup(p.engine)
+		p.Lock()
+		p.active = active
+		p.index()
+		p.Unlock()
 		go func(cx context.Context) {
 			for {
 				select {
@@ -140,27 +150,95 @@ func (p *Page) lifeCycle(ctx context.Context) {
 					return
 				case ke := <-p.incomingKey:
 					if p.hidden {
-						return
+						continue
 					}
 					_ = ke
 				case me := <-p.incomingMouse:
 					if p.hidden {
-						return
+						continue
 					}
-					// TODO : tell only rectangle in that bounds
-					_ = me
+					p.routeMouse(me)
 				case se := <-p.incomingResize:
-					p.resize(se.Size())
+					p.resizePixels(se.Size())
 				}
 			}
 		}(ctx)
 	})
 }
 
-func (p *Page) pixels() []term.PixelGetter {
+// AddRectangles registers rectangles for receiving mouse events (see WithOnClick). Rectangles added later are on top of the ones added before.
+func (p *Page) AddRectangles(rects ...*Rectangle) {
+	p.Lock()
+	defer p.Unlock()
+	p.rects = append(p.rects, rects...)
+}
+
+// routeMouse tells only the top most rectangle in that bounds
+func (p *Page) routeMouse(ev term.MouseEvent) {
+	p.RLock()
+	rects := make([]*Rectangle, len(p.rects))
+	copy(rects, p.rects)
+	p.RUnlock()
+
+	var over *Rectangle
+	column, row := ev.Position()
+	for idx := len(rects) - 1; idx >= 0; idx-- {
+		if rects[idx].hovers(column, row) {
+			over = rects[idx]
+			break
+		}
+	}
+	if over != p.hovered {
+		if p.hovered != nil {
+			p.hovered.mouseMoved(ev, MouseLeave)
+		}
+		if over != nil {
+			over.mouseMoved(ev, MouseEnter)
+		}
+		p.hovered = over // only the life cycle goroutine routes events
+	} else if over != nil {
+		over.mouseMoved(ev, NoCrossing)
+	}
+
+	for idx := len(rects) - 1; idx >= 0; idx-- {
+		if rects[idx].clicked(ev) {
+			return
+		}
+	}
+}
+
+// resizePixels resizes the page, recomputing its active pixels : the ones still inside the size are kept, the new positions getting blank pixels.
+// Unless the page is hidden, the pixels are registered again with the engine (a hidden page is registered when shown, see Pixels)
+func (p *Page) resizePixels(size *term.Size) {
+	p.Lock()
+	p.resize(size)
+	active := make([]term.PixelGetter, 0, size.Columns*size.Rows)
+	for row := 0; row < size.Rows; row++ {
+		for column := 0; column < size.Columns; column++ {
+			if cell, ok := p.cells[term.Hash(column, row)]; ok {
+				active = append(active, cell)
+				continue
+			}
+			pixel := newPixel(column, row)
+			active = append(active, &pixel)
+		}
+	}
+	p.active = active
+	p.index()
+	hidden := p.hidden
+	p.Unlock()
+
+	if !hidden {
+		p.engine.ActivePixels(active)
+	}
+}
+
+// Pixels returns the pixels of the page, as they were registered with the engine (see term.Engine ActivePixels), so they can be registered again.
+// After a resize, these are the pixels covering the new size.
+func (p *Page) Pixels() []term.PixelGetter {
 	p.RLock()
 	defer p.RUnlock()
-	return nil
+	return p.active
 }
 
 // Shutdown
@@ -213,3 +291,77 @@ func (p *Page) Deactivate() {
 	p.hidden = true
 	// TODO : deactivate underlying pixels
 }
+
+// index maps the active pixels by their position - locked inside caller function
+func (p *Page) index() {
+	p.cells = make(map[int]*px, len(p.active))
+	p.columns, p.rows = 0, 0
+	for _, pixel := range p.active {
+		cell, ok := pixel.(*px)
+		if !ok {
+			continue
+		}
+		column, row := term.UnHash(cell.PositionHash())
+		p.columns, p.rows = term.Max(p.columns, column+1), term.Max(p.rows, row+1)
+		p.cells[cell.PositionHash()] = cell
+	}
+}
+
+// SetRow sets the runes of the row, starting with the first column, all having the same style. Runes past the last column are ignored.
+// The changed pixels are drawn in a single write, the contiguous ones after a single cursor movement, which is much faster than setting the pixels one by one.
+func (p *Page) SetRow(row int, runes []rune, st style.Style) {
+	p.bulkSet(runes, st, func(idx int) int { return term.Hash(idx, row) })
+}
+
+// SetColumn sets the runes of the column, starting with the first row, all having the same style. Runes past the last row are ignored.
+// Like SetRow, the changed pixels are drawn in a single write.
+func (p *Page) SetColumn(column int, runes []rune, st style.Style) {
+	p.bulkSet(runes, st, func(idx int) int { return term.Hash(column, idx) })
+}
+
+// bulkSet assigns the runes to the pixels found at the positions, then draws the changed ones, unless the page is hidden (the pixels are drawn when the page is shown again)
+func (p *Page) bulkSet(runes []rune, st style.Style, position func(idx int) int) {
+	p.RLock()
+	changed := make([]term.PixelGetter, 0, len(runes))
+	for idx, r := range runes {
+		cell, ok := p.cells[position(idx)]
+		if !ok {
+			break
+		}
+		if cell.assign(r, st) {
+			changed = append(changed, cell)
+		}
+	}
+	hidden := p.hidden
+	p.RUnlock()
+
+	if len(changed) > 0 && !hidden {
+		p.engine.Redraw(changed)
+	}
+}
+
+// EachInRows calls the function for the pixels of the page, row by row, until it returns false
+func (p *Page) EachInRows(fn func(column, row int, pixel term.Pixel) bool) {
+	p.RLock()
+	defer p.RUnlock()
+	for row := 0; row < p.rows; row++ {
+		for column := 0; column < p.columns; column++ {
+			if cell, ok := p.cells[term.Hash(column, row)]; ok && !fn(column, row, cell) {
+				return
+			}
+		}
+	}
+}
+
+// EachInColumns calls the function for the pixels of the page, column by column, until it returns false
+func (p *Page) EachInColumns(fn func(column, row int, pixel term.Pixel) bool) {
+	p.RLock()
+	defer p.RUnlock()
+	for column := 0; column < p.columns; column++ {
+		for row := 0; row < p.rows; row++ {
+			if cell, ok := p.cells[term.Hash(column, row)]; ok && !fn(column, row, cell) {
+				return
+			}
+		}
+	}
+}
@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"github.com/badu/term"
+	"github.com/badu/term/color"
 	"github.com/badu/term/geom"
 	initLog "github.com/badu/term/log"
+	"github.com/badu/term/style"
 )
 
 func testAcquisitionChan() geom.RectangleOption {
@@ -79,3 +81,103 @@ func TestRectangleWithPage(t *testing.T) {
 func Test(t *testing.T) {
 
 }
+
+func TestPageSetRow(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fakeEngine := NewFakeEngine(t, 6, 3)
+	fakeEngine.Start(ctx)
+	page, err := geom.NewPage(ctx, geom.WithEngine(fakeEngine))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	fakeEngine.Redrawn = nil
+
+	bold := style.Style{Fg: color.Red, Bg: color.Default, Attrs: style.Bold}
+	page.SetRow(1, []rune("hello, world"), bold)
+	if len(fakeEngine.Redrawn) != 1 || len(fakeEngine.Redrawn[0]) != 6 {
+		t.Fatalf("expecting the six columns to be drawn at once, got %v", fakeEngine.Redrawn)
+	}
+	for idx, pixel := range fakeEngine.Redrawn[0] {
+		fg, _, attrs := pixel.Style()
+		if pixel.PositionHash() != term.Hash(idx, 1) || pixel.Rune() != []rune("hello,")[idx] || fg != color.Red || attrs != style.Bold {
+			t.Errorf("unexpected pixel %d : %q", idx, pixel.Rune())
+		}
+	}
+
+	// only the changed pixels are drawn
+	page.SetColumn(0, []rune("ahx"), bold)
+	if len(fakeEngine.Redrawn) != 2 || len(fakeEngine.Redrawn[1]) != 2 {
+		t.Fatalf("expecting two changed pixels, got %v", fakeEngine.Redrawn)
+	}
+
+	var text []rune
+	page.EachInRows(func(column, row int, pixel term.Pixel) bool {
+		if row == 1 {
+			text = append(text, pixel.Rune())
+		}
+		return row < 2
+	})
+	if string(text) != "hello," {
+		t.Errorf("expecting the row to be iterated in order, got %q", string(text))
+	}
+	text = nil
+	page.EachInColumns(func(column, row int, pixel term.Pixel) bool {
+		text = append(text, pixel.Rune())
+		return len(text) < 3
+	})
+	if string(text) != "ahx" {
+		t.Errorf("expecting the column to be iterated in order, got %q", string(text))
+	}
+
+	page.Deactivate()
+	page.SetRow(2, []rune("hidden"), bold)
+	if len(fakeEngine.Redrawn) != 2 {
+		t.Errorf("hidden pages should not be drawn")
+	}
+}
+
+func TestPagePixelsResize(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fakeEngine := NewFakeEngine(t, 4, 3)
+	fakeEngine.Start(ctx)
+	page, err := geom.NewPage(ctx, geom.WithEngine(fakeEngine))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	page.SetRow(0, []rune("kept"), style.Style{})
+
+	// a hidden page (e.g. below the top of the navigation stack) is resized too, its pixels being registered when shown again
+	page.Deactivate()
+	fakeEngine.SetSize(6, 2)
+	deadline := time.After(2 * time.Second)
+	for len(page.Pixels()) != 12 {
+		select {
+		case <-deadline:
+			t.Fatalf("error : expecting the pixels to cover 6 x 2, got %d pixels", len(page.Pixels()))
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	var text []rune
+	for idx, pixel := range page.Pixels() {
+		if pixel.PositionHash() != term.Hash(idx%6, idx/6) {
+			t.Errorf("error : unexpected position of pixel %d", idx)
+		}
+		if idx < 6 {
+			text = append(text, pixel.Rune())
+		}
+	}
+	if string(text[:4]) != "kept" {
+		t.Errorf("error : the pixels inside the new size should be kept, got %q", string(text))
+	}
+
+	// the bulk setters use the new pixels too
+	page.Activate()
+	fakeEngine.Redrawn = nil
+	page.SetRow(1, []rune("resized"), style.Style{})
+	if len(fakeEngine.Redrawn) != 1 || len(fakeEngine.Redrawn[0]) != 6 {
+		t.Errorf("error : expecting the six columns of the row to be drawn, got %v", fakeEngine.Redrawn)
+	}
+}
@@ -0,0 +1,177 @@
+package geom
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+// ErrLayoutMismatch is returned by RestoreLayout when the saved rectangles are not the ones of the page (e.g. a newer version of the application has more of them). The ones which match were restored anyway.
+var ErrLayoutMismatch = errors.New("the saved layout doesn't match the rectangles of the page")
+
+// PageLayout is the saved state of the rectangles of a page, see Page SaveLayout
+type PageLayout struct {
+	Columns    int               `json:"columns"`    // the size of the page when saved, for scaling the positions to the size it has when restored
+	Rows       int               `json:"rows"`       //
+	Rectangles []RectangleLayout `json:"rectangles"` // in the order they were added to the page
+}
+
+// RectangleLayout is the saved state of a rectangle and of its children
+type RectangleLayout struct {
+	Left        int               `json:"left"`                 // the corners, inclusive
+	Top         int               `json:"top"`                  //
+	Right       int               `json:"right"`                //
+	Bottom      int               `json:"bottom"`               //
+	Width       *Constraint       `json:"width,omitempty"`      // see WithWidthConstraint
+	Height      *Constraint       `json:"height,omitempty"`     // see WithHeightConstraint
+	Orientation style.Orientation `json:"orientation"`          //
+	Alignment   style.Alignment   `json:"alignment"`            //
+	Foreground  color.Color       `json:"foreground"`           // the own colors, color.Default being inherited
+	Background  color.Color       `json:"background"`           //
+	Attributes  *style.Mask       `json:"attributes,omitempty"` // nil when inherited
+	Hidden      bool              `json:"hidden,omitempty"`     //
+	Children    []RectangleLayout `json:"children,omitempty"`   //
+}
+
+// SaveLayout writes the positions, the sizes and the styles of the rectangles added to the page (see AddRectangles) and of their children, as JSON.
+// The hooks and the content are not saved : on the next launch, the application builds the same rectangles, then calls RestoreLayout.
+func (p *Page) SaveLayout(w io.Writer) error {
+	size := p.engine.Size()
+	p.RLock()
+	state := PageLayout{Columns: size.Columns, Rows: size.Rows, Rectangles: make([]RectangleLayout, 0, len(p.rects))}
+	for _, r := range p.rects {
+		state.Rectangles = append(state.Rectangles, r.saveLayout())
+	}
+	p.RUnlock()
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(state)
+}
+
+// RestoreLayout reads the state written by SaveLayout, applying it to the rectangles of the page, matched by their order.
+// When the size of the page has changed meanwhile, the positions are scaled to the new one, the rectangles reaching the right or the bottom edge still reaching it.
+func (p *Page) RestoreLayout(r io.Reader) error {
+	var state PageLayout
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return err
+	}
+
+	size := p.engine.Size()
+	p.RLock()
+	rects := make([]*Rectangle, len(p.rects))
+	copy(rects, p.rects)
+	p.RUnlock()
+	scale := layoutScale{fromColumns: state.Columns, fromRows: state.Rows, toColumns: size.Columns, toRows: size.Rows}
+
+	matched := len(rects) == len(state.Rectangles)
+	for idx, rect := range rects {
+		if idx >= len(state.Rectangles) {
+			break
+		}
+		if !rect.restoreLayout(state.Rectangles[idx], scale) {
+			matched = false
+		}
+		rect.layout()
+	}
+	if !matched {
+		return ErrLayoutMismatch
+	}
+	return nil
+}
+
+// saveLayout returns the state of the rectangle and of its children
+func (r *Rectangle) saveLayout() RectangleLayout {
+	result := RectangleLayout{
+		Left:        r.topCorner.Column,
+		Top:         r.topCorner.Row,
+		Right:       r.bottomCorner.Column,
+		Bottom:      r.bottomCorner.Row,
+		Orientation: r.orientation,
+		Alignment:   r.aligned,
+		Foreground:  r.st.Fg,
+		Background:  r.st.Bg,
+		Hidden:      r.hidden,
+	}
+	if r.width != nil {
+		width := *r.width
+		result.Width = &width
+	}
+	if r.height != nil {
+		height := *r.height
+		result.Height = &height
+	}
+	if r.attrsSet {
+		attrs := r.st.Attrs
+		result.Attributes = &attrs
+	}
+	for _, child := range r.children {
+		result.Children = append(result.Children, child.saveLayout())
+	}
+	return result
+}
+
+// restoreLayout applies the saved state to the rectangle and to its children, returning false if the children don't match
+func (r *Rectangle) restoreLayout(state RectangleLayout, scale layoutScale) bool {
+	r.orientation = state.Orientation
+	r.aligned = state.Alignment
+	r.width, r.height = state.Width, state.Height
+	r.st.Fg, r.st.Bg = state.Foreground, state.Background
+	if state.Attributes != nil {
+		r.SetAttributes(*state.Attributes)
+	} else {
+		r.InheritAttributes()
+	}
+	if state.Left >= 0 && state.Top >= 0 { // the rectangles which were nowhere are left alone
+		r.setCorners(scale.column(state.Left), scale.row(state.Top), scale.column(state.Right), scale.row(state.Bottom))
+		r.invalidateSize()
+	}
+
+	matched := len(r.children) == len(state.Children)
+	for idx, child := range r.children {
+		if idx >= len(state.Children) {
+			break
+		}
+		if !child.restoreLayout(state.Children[idx], scale) {
+			matched = false
+		}
+	}
+	if state.Hidden != r.hidden {
+		if state.Hidden {
+			r.Hide()
+		} else {
+			r.Show()
+		}
+	}
+	return matched
+}
+
+// layoutScale converts the saved positions to the size of the page
+type layoutScale struct {
+	fromColumns int // the size of the page when saved
+	fromRows    int //
+	toColumns   int // the size of the page when restored
+	toRows      int //
+}
+
+func (s layoutScale) column(column int) int {
+	return scaled(column, s.fromColumns, s.toColumns)
+}
+
+func (s layoutScale) row(row int) int {
+	return scaled(row, s.fromRows, s.toRows)
+}
+
+// scaled converts the position, the last one staying the last one
+func scaled(position, from, to int) int {
+	if from <= 0 || to <= 0 || from == to {
+		return position
+	}
+	if position >= from-1 {
+		return to - 1
+	}
+	return position * to / from
+}
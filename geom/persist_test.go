@@ -0,0 +1,72 @@
+package geom_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/geom"
+	"github.com/badu/term/style"
+)
+
+// dashboard builds the same rectangles on each launch : a panel filling the page, having a header and a body
+func dashboard(t *testing.T, ctx context.Context, columns, rows int) (*geom.Page, *geom.Rectangle, *geom.Rectangle, *geom.Rectangle) {
+	fakeEngine := NewFakeEngine(t, columns, rows)
+	fakeEngine.Start(ctx)
+	page, err := geom.NewPage(ctx, geom.WithEngine(fakeEngine))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	header, _ := geom.NewRectangle(ctx, testAcquisitionChan(), geom.WithHeightConstraint(geom.Cells(3)))
+	body, _ := geom.NewRectangle(ctx, testAcquisitionChan(), geom.WithMinSize(term.NewSize(1, 1))) // fills the rest
+	panel, err := geom.NewRectangle(ctx, testAcquisitionChan(), geom.WithTopCorner(0, 0), geom.WithBottomCorner(columns-1, rows-1))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	panel.SetChildren(header, body)
+	page.AddRectangles(panel)
+	return page, panel, header, body
+}
+
+func TestPageLayoutPersistence(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the user has changed the colors
+	page, _, header, body := dashboard(t, ctx, 80, 24)
+	header.SetBackgroundColor(color.Blue)
+	header.SetAttributes(style.Bold)
+	body.SetForegroundColor(color.Yellow)
+	saved := &bytes.Buffer{}
+	if err := page.SaveLayout(saved); err != nil {
+		t.Fatalf("error : %v", err)
+	}
+
+	// next launch, on a larger terminal
+	page, panel, header, body := dashboard(t, ctx, 120, 40)
+	if err := page.RestoreLayout(bytes.NewReader(saved.Bytes())); err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	if panel.Bottom().Column != 119 || panel.Bottom().Row != 39 {
+		t.Errorf("error : the panel should still fill the page, got %d,%d", panel.Bottom().Column, panel.Bottom().Row)
+	}
+	if header.Height() != 3 || header.Bg() != color.Blue || header.Style().Attrs != style.Bold {
+		t.Errorf("error : the header should be restored, got %d rows %v", header.Height(), header.Style())
+	}
+	if body.Top().Row != 3 || body.Bottom().Row != 39 || body.Fg() != color.Yellow {
+		t.Errorf("error : the body should fill the rest, got rows %d to %d", body.Top().Row, body.Bottom().Row)
+	}
+
+	// a newer version having more rectangles
+	page, _, _, _ = dashboard(t, ctx, 80, 24)
+	extra, _ := geom.NewRectangle(ctx, testAcquisitionChan(), geom.WithTopCorner(0, 0), geom.WithBottomCorner(9, 9))
+	page.AddRectangles(extra)
+	if err := page.RestoreLayout(bytes.NewReader(saved.Bytes())); err != geom.ErrLayoutMismatch {
+		t.Errorf("error : the layout should not match, got %v", err)
+	}
+	if extra.Size().Columns != 10 && extra.Size().Columns != 9 {
+		t.Errorf("error : the rectangles without state should be left alone")
+	}
+}
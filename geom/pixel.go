@@ -2,7 +2,7 @@ package geom
 
 import (
 	"errors"
-	"log"
+	"sync"
 	"unicode/utf8"
 
 	"github.com/badu/term"
@@ -60,7 +60,10 @@ func WithAttrs(m style.Mask) PixelOption {
 // Cell
 type Cell = px
 
+// px is safe for concurrent use : setters can be called from any goroutine.
+// Changes are coalesced : while a draw request is pending, further changes don't send another one, since the engine reads the latest values when it draws.
 type px struct {
+	mu            *sync.RWMutex         // guards other properties. A pointer, because pixels are copied by value inside rectangles
 	pos           *term.Position        // required, for each pixel. default to {-1,-1} and validated in the constructor
 	drawCh        chan term.PixelGetter // required, triggers core.drawPixel via setters
 	st            style.Style           //
@@ -72,51 +75,81 @@ type px struct {
 
 // BgCol
 func (p *px) BgCol() color.Color {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	return p.st.Bg
 }
 
 // FgCol
 func (p *px) FgCol() color.Color {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	return p.st.Fg
 }
 
 // Style
 func (p *px) Style() (color.Color, color.Color, style.Mask) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	return p.st.Fg, p.st.Bg, p.st.Attrs
 }
 
 // HasUnicode
 func (p *px) HasUnicode() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	return p.unicode != nil
 }
 
 // Unicode
 func (p *px) Unicode() *term.Unicode {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	return p.unicode
 }
 
 // Run
 func (p *px) Rune() rune {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	return p.content
 }
 
 // Attrs
 func (p *px) Attrs() style.Mask {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	return p.st.Attrs
 }
 
 // Width - usually 1
 func (p *px) Width() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	return p.width
 }
 
 // Position - returns the position of the pixel
 func (p *px) PositionHash() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	return p.pos.Hash()
 }
 
 // DrawCh - usually called from core, to register listening for changes
 func (p *px) DrawCh() chan term.PixelGetter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	if !p.wasRegistered {
 		p.wasRegistered = true // we assume that the engine is the one which asked about draw channel, so we're marking ourselves as ready to dispatch changes
 	}
@@ -125,18 +158,22 @@ func (p *px) DrawCh() chan term.PixelGetter {
 
 // SetFgBg
 func (p *px) SetFgBg(fg, bg color.Color) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	if p.st.Bg == bg && p.st.Fg == fg {
 		return
 	}
 	p.st.Bg = bg
 	p.st.Fg = fg
-	if p.wasRegistered {
-		p.drawCh <- p // if not registered, it will cause blocking
-	}
+	p.changed()
 }
 
 // Set - sets rune, background and foreground
 func (p *px) Set(r rune, fg, bg color.Color) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	if p.st.Bg == bg && p.st.Fg == fg && p.content == r {
 		return
 	}
@@ -144,13 +181,14 @@ func (p *px) Set(r rune, fg, bg color.Color) {
 	p.st.Bg = bg
 	p.st.Fg = fg
 	p.width = 1
-	if p.wasRegistered {
-		p.drawCh <- p // if not registered, it will cause blocking
-	}
+	p.changed()
 }
 
 // SetAll - set all possible properties and dispatches changes
 func (p *px) SetAll(bg, fg color.Color, m style.Mask, r rune, u term.Unicode) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	var currUnicode term.Unicode
 	if p.unicode != nil {
 		currUnicode = *p.unicode
@@ -159,8 +197,8 @@ func (p *px) SetAll(bg, fg color.Color, m style.Mask, r rune, u term.Unicode) {
 	equal := len(currUnicode) == len(u)
 	for idx, r := range u {
 		if !utf8.ValidRune(r) {
-			if Debug {
-				log.Printf("error : invalid rune provided : %#v", r)
+			if Debug.Enabled() {
+				Debug.Printf("error : invalid rune provided : %#v", r)
 			}
 			return
 		}
@@ -170,66 +208,74 @@ func (p *px) SetAll(bg, fg color.Color, m style.Mask, r rune, u term.Unicode) {
 			equal = false
 		}
 	}
-	if p.st.Bg == bg && p.st.Fg == fg && p.content == r && equal {
+	if p.st.Bg == bg && p.st.Fg == fg && p.st.Attrs == m && p.content == r && equal {
 		return
 	}
 	p.st.Bg = bg
 	p.st.Fg = fg
 	p.content = r
-	p.unicode = &u
-	p.st.Attrs = m
-	if p.wasRegistered {
-		p.drawCh <- p // if not registered, it will cause blocking
+	p.unicode = nil // HasUnicode is false for the plain runes
+	if len(u) > 0 {
+		p.unicode = &u
 	}
+	p.st.Attrs = m
+	p.changed()
 }
 
 // SetAttrs - sets mask and dispatches changes
 func (p *px) SetAttrs(m style.Mask) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	if p.st.Attrs == m {
 		return
 	}
 	p.st.Attrs = m
-	if p.wasRegistered {
-		p.drawCh <- p // if not registered, it will cause blocking
-	}
+	p.changed()
 }
 
 // SetBackground - sets background color and dispatches changes
 func (p *px) SetBackground(c color.Color) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	if p.st.Bg == c {
 		return
 	}
 	p.st.Bg = c
-	if p.wasRegistered {
-		p.drawCh <- p // if not registered, it will cause blocking
-	}
+	p.changed()
 }
 
 // SetForeground - sets foreground color and dispatches changes
 func (p *px) SetForeground(c color.Color) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	if p.st.Fg == c {
 		return
 	}
 	p.st.Fg = c
-	if p.wasRegistered {
-		p.drawCh <- p // if not registered, it will cause blocking
-	}
+	p.changed()
 }
 
 // SetRune - sets rune and dispatches changes
 func (p *px) SetRune(r rune) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	if p.content == r {
 		return
 	}
 	p.content = r
 	p.width = 1
-	if p.wasRegistered {
-		p.drawCh <- p // if not registered, it will cause blocking
-	}
+	p.changed()
 }
 
 // SetUnicode - sets unicode and dispatches changes
 func (p *px) SetUnicode(u term.Unicode) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	var currUnicode term.Unicode
 	if p.unicode != nil {
 		currUnicode = *p.unicode
@@ -239,8 +285,8 @@ func (p *px) SetUnicode(u term.Unicode) {
 	newSize := 0
 	for idx, r := range u {
 		if !utf8.ValidRune(r) {
-			if Debug {
-				log.Printf("error : invalid rune provided : %#v", r)
+			if Debug.Enabled() {
+				Debug.Printf("error : invalid rune provided : %#v", r)
 			}
 			return
 		}
@@ -256,8 +302,79 @@ func (p *px) SetUnicode(u term.Unicode) {
 	}
 	p.width = newSize + 1 // +1 because of the rune
 	p.unicode = &u
-	if p.wasRegistered {
-		p.drawCh <- p // if not registered, it will cause blocking
+	p.changed()
+}
+
+// assign sets the rune and the style without requesting a draw, for the bulk setters which draw the changed pixels at once (see Page SetRow).
+// It returns true if the pixel has changed.
+func (p *px) assign(r rune, st style.Style) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.content == r && p.unicode == nil && p.st.Fg == st.Fg && p.st.Bg == st.Bg && p.st.Attrs == st.Attrs {
+		return false
+	}
+	p.content = r
+	p.unicode = nil
+	p.width = 1
+	p.st.Fg, p.st.Bg, p.st.Attrs = st.Fg, st.Bg, st.Attrs
+	return true
+}
+
+// invert flips the reverse attribute without requesting a draw, for the selection which draws the highlighted pixels at once (see Selection).
+// Flipping twice gives back the attributes, even if the application changed them meanwhile.
+func (p *px) invert() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.st.Attrs ^= style.Reverse
+}
+
+// text returns the rune and the combining ones, for copying the selected text
+func (p *px) text() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.unicode != nil {
+		return string(p.content) + string(*p.unicode)
+	}
+	return string(p.content)
+}
+
+// MoveTo implements term.Mover interface. The position is copied, since the pixels of a grid share their positions.
+func (p *px) MoveTo(pos *term.Position) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	moved := term.NewPosition(pos.Column, pos.Row)
+	if moved.Hash() == p.pos.Hash() {
+		return
+	}
+	p.pos = moved
+	p.changed()
+}
+
+// Equals returns true if the other pixel shows the same content (rune, unicode, colors and attributes), regardless of its position
+func (p *px) Equals(other term.PixelGetter) bool {
+	if o, ok := other.(*px); ok && o.mu == p.mu {
+		return true // same pixel (pixels are copied by value, but they share the mutex)
+	}
+	return term.SameContent(p, other)
+}
+
+// Hash returns the hash of the content, see term.ContentHash
+func (p *px) Hash() uint64 {
+	return term.ContentHash(p)
+}
+
+// changed requests a draw from the engine, unless one is already pending - locked inside caller function
+func (p *px) changed() {
+	if !p.wasRegistered {
+		return // the engine didn't ask for our draw channel yet
+	}
+	select {
+	case p.drawCh <- p:
+	default: // a draw is already pending : the engine will read the latest values
 	}
 }
 
@@ -269,8 +386,9 @@ func NewPixel(opts ...PixelOption) (term.Pixel, error) {
 	defPos := term.NewPosition(-1, -1)
 	defStyle := style.NewStyle(style.WithBg(color.Default), style.WithFg(color.Default), style.WithAttrs(style.None))
 	res := &px{
+		mu:      &sync.RWMutex{},
 		pos:     defPos,
-		drawCh:  make(chan term.PixelGetter),
+		drawCh:  make(chan term.PixelGetter, 1), // buffered for exactly one pending draw request
 		st:      *defStyle,
 		content: encoding.Space, // it's just a space char
 	}
@@ -291,8 +409,9 @@ func newPixel(col, row int) px {
 	defPos := term.NewPosition(col, row)
 	defStyle := style.NewStyle(style.WithBg(color.Default), style.WithFg(color.Default), style.WithAttrs(style.None))
 	res := px{
+		mu:      &sync.RWMutex{},
 		pos:     defPos,
-		drawCh:  make(chan term.PixelGetter),
+		drawCh:  make(chan term.PixelGetter, 1), // buffered for exactly one pending draw request
 		st:      *defStyle,
 		content: encoding.Space, // it's just a space char
 	}
@@ -302,3 +421,40 @@ func newPixel(col, row int) px {
 type Pixels []px
 
 type PixelsMatrix []Pixels
+
+// NewPixelGrid constructs the pixels covering size, all sharing the defaults (colors, attributes, rune), in a single allocation.
+// The grid is indexed [column][row], while the flat slice is ordered row by row (as the engine prefers it) and can be passed to term.Engine ActivePixels.
+// Note : WithPosition is ignored, since each pixel gets its own position.
+func NewPixelGrid(size *term.Size, defaults ...PixelOption) ([][]term.Pixel, []term.PixelGetter) {
+	template := newPixel(-1, -1)
+	for _, opt := range defaults {
+		opt(&template)
+	}
+
+	cells := make([]px, size.Columns*size.Rows)
+	positions := make([]term.Position, size.Columns*size.Rows)
+	grid := make([][]term.Pixel, size.Columns)
+	for column := range grid {
+		grid[column] = make([]term.Pixel, size.Rows)
+	}
+	getters := make([]term.PixelGetter, 0, len(cells))
+	for row := 0; row < size.Rows; row++ {
+		for column := 0; column < size.Columns; column++ {
+			idx := row*size.Columns + column
+			positions[idx] = term.Position{Column: column, Row: row}
+			positions[idx].UpdateHash()
+			cells[idx] = template
+			cells[idx].pos = &positions[idx]
+			cells[idx].mu = &sync.RWMutex{}
+			cells[idx].drawCh = make(chan term.PixelGetter, 1)
+			if template.unicode != nil {
+				u := make(term.Unicode, len(*template.unicode))
+				copy(u, *template.unicode)
+				cells[idx].unicode = &u
+			}
+			grid[column][row] = &cells[idx]
+			getters = append(getters, &cells[idx])
+		}
+	}
+	return grid, getters
+}
@@ -0,0 +1,182 @@
+package geom_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/geom"
+	"github.com/badu/term/style"
+)
+
+func TestNewPixelGrid(t *testing.T) {
+	size := term.NewSize(4, 3)
+	grid, getters := geom.NewPixelGrid(size, geom.WithRune('x'), geom.WithForeground(color.Red))
+	if len(grid) != size.Columns || len(grid[0]) != size.Rows {
+		t.Fatalf("expecting %dx%d grid, got %dx%d", size.Columns, size.Rows, len(grid), len(grid[0]))
+	}
+	if len(getters) != size.Columns*size.Rows {
+		t.Fatalf("expecting %d getters, got %d", size.Columns*size.Rows, len(getters))
+	}
+	for idx, getter := range getters {
+		column, row := idx%size.Columns, idx/size.Columns
+		if getter.PositionHash() != term.Hash(column, row) {
+			t.Fatalf("pixel %d : expecting position %d,%d", idx, column, row)
+		}
+		if grid[column][row] != getter {
+			t.Fatalf("pixel %d : grid and getters differ", idx)
+		}
+		if fg, _, _ := getter.Style(); getter.Rune() != 'x' || fg != color.Red {
+			t.Fatalf("pixel %d : defaults were not applied", idx)
+		}
+	}
+	grid[0][0].SetRune('y') // not registered, so it doesn't block
+	if grid[1][0].Rune() != 'x' {
+		t.Fatal("pixels must not share state")
+	}
+}
+
+func BenchmarkNewPixel(b *testing.B) {
+	size := term.NewSize(200, 60)
+	for i := 0; i < b.N; i++ {
+		getters := make([]term.PixelGetter, 0, size.Columns*size.Rows)
+		for row := 0; row < size.Rows; row++ {
+			for column := 0; column < size.Columns; column++ {
+				px, _ := geom.NewPixel(geom.WithRune('▀'), geom.WithForeground(color.Red), geom.WithPosition(term.NewPosition(column, row)))
+				getters = append(getters, px)
+			}
+		}
+	}
+}
+
+func BenchmarkNewPixelGrid(b *testing.B) {
+	size := term.NewSize(200, 60)
+	for i := 0; i < b.N; i++ {
+		geom.NewPixelGrid(size, geom.WithRune('▀'), geom.WithForeground(color.Red))
+	}
+}
+
+func TestPixelConcurrentSetters(t *testing.T) {
+	px, err := geom.NewPixel(geom.WithPosition(term.NewPosition(1, 1)))
+	if err != nil {
+		t.Fatalf("error creating pixel : %v", err)
+	}
+	drawCh := px.DrawCh() // registers, as the engine does
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(r rune) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				px.SetRune(r)
+				px.SetFgBg(color.Red, color.Blue)
+			}
+		}(rune('a' + i))
+	}
+	wg.Wait() // nobody is reading the draw channel, yet setters are not blocked
+
+	if len(drawCh) != 1 {
+		t.Fatalf("expecting one coalesced draw request, got %d", len(drawCh))
+	}
+	<-drawCh
+	px.SetRune('z')
+	if drawn := <-drawCh; drawn.Rune() != 'z' {
+		t.Fatalf("expecting the latest rune to be drawn, got %q", drawn.Rune())
+	}
+}
+
+func TestPixelMoveTo(t *testing.T) {
+	grid, _ := geom.NewPixelGrid(term.NewSize(2, 1))
+	drawCh := grid[0][0].DrawCh() // registers, as the engine does
+
+	mover, ok := grid[0][0].(term.Mover)
+	if !ok {
+		t.Fatal("pixels should implement term.Mover")
+	}
+	where := term.NewPosition(1, 0)
+	mover.MoveTo(where)
+	if drawn := <-drawCh; drawn.PositionHash() != term.Hash(1, 0) {
+		t.Fatalf("expecting the pixel to be drawn at the new position, got %d", drawn.PositionHash())
+	}
+	where.Column = 5
+	if grid[0][0].PositionHash() != term.Hash(1, 0) || grid[1][0].PositionHash() != term.Hash(1, 0) {
+		t.Fatal("the position should be copied, and the other pixels of the grid should not move")
+	}
+	mover.MoveTo(term.NewPosition(1, 0))
+	if len(drawCh) != 0 {
+		t.Fatal("moving to the same position should not draw")
+	}
+}
+
+func TestPixelEquals(t *testing.T) {
+	first, _ := geom.NewPixel(geom.WithPosition(term.NewPosition(0, 0)), geom.WithRune('a'), geom.WithForeground(color.Red))
+	second, _ := geom.NewPixel(geom.WithPosition(term.NewPosition(5, 5)), geom.WithRune('a'), geom.WithForeground(color.Red))
+	if !first.Equals(second) || first.Hash() != second.Hash() {
+		t.Fatal("pixels with the same content should be equal, regardless of their position")
+	}
+	second.SetAttrs(style.Bold)
+	if first.Equals(second) || first.Hash() == second.Hash() {
+		t.Fatal("attributes should be compared")
+	}
+	second.SetAttrs(style.None)
+	second.SetUnicode(term.Unicode{'́'})
+	if first.Equals(second) || first.Hash() == second.Hash() {
+		t.Fatal("unicode should be compared")
+	}
+	first.SetUnicode(term.Unicode{'́'})
+	if !first.Equals(second) || first.Hash() != second.Hash() {
+		t.Fatal("pixels should be equal again")
+	}
+	if !first.Equals(first) || first.Equals(nil) {
+		t.Fatal("a pixel equals itself and never nil")
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	grid, _ := geom.NewPixelGrid(term.NewSize(5, 4), geom.WithForeground(color.White), geom.WithBackground(color.Blue))
+	grid[0][0].SetForeground(color.Default)
+	overlay := geom.NewOverlay(geom.GridContent(grid))
+	screen := geom.Bounds{Left: 0, Top: 0, Right: 4, Bottom: 3}
+	popup := geom.Bounds{Left: 1, Top: 1, Right: 2, Bottom: 2}
+
+	overlay.Dim(screen, popup, 0.5)
+	if fg, bg, _ := grid[1][1].Style(); fg != color.White || bg != color.Blue {
+		t.Fatalf("expecting the popup unchanged")
+	}
+	fg, bg, _ := grid[4][3].Style()
+	if l, _ := color.RelativeLuminance(bg); bg == color.Blue || l >= 0.0722 {
+		t.Fatalf("expecting a darker background, got %v", bg)
+	}
+	if fg == color.White {
+		t.Fatalf("expecting a darker foreground")
+	}
+	if _, _, attrs := grid[0][0].Style(); attrs&style.Dim == 0 {
+		t.Fatalf("expecting the Dim attribute for the default color")
+	}
+
+	overlay.DropShadow(popup, 2, 1)
+	if _, bg, _ := grid[3][3].Style(); bg != color.Black {
+		t.Fatalf("expecting shadow at 3,3, got %v", bg)
+	}
+	if _, bg, _ := grid[2][2].Style(); bg != color.Blue {
+		t.Fatalf("expecting no shadow over the popup")
+	}
+
+	overlay.Restore()
+	for column := range grid {
+		for row := range grid[column] {
+			fg, bg, attrs := grid[column][row].Style()
+			if column == 0 && row == 0 {
+				if fg != color.Default || attrs != style.None {
+					t.Fatalf("expecting 0,0 restored")
+				}
+				continue
+			}
+			if fg != color.White || bg != color.Blue || attrs != style.None {
+				t.Fatalf("expecting %d,%d restored, got %v %v %v", column, row, fg, bg, attrs)
+			}
+		}
+	}
+}
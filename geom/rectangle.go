@@ -86,45 +86,28 @@ func WithMinSize(size *term.Size) RectangleOption {
 // WithWidthAndHeight
 func WithWidthAndHeight(widthPercent, heightPercent int) RectangleOption {
 	return func(r *Rectangle) {
-		r.width = &widthPercent
-		r.height = &heightPercent
+		width, height := Percent(widthPercent), Percent(heightPercent)
+		r.width = &width
+		r.height = &height
 	}
 }
 
-// WithWidth
+// WithWidth sets the width in percents of the parent. See WithWidthConstraint for absolute cells and min/max bounds.
 func WithWidth(percent int) RectangleOption {
-	return func(r *Rectangle) {
-		r.width = &percent
-	}
+	return WithWidthConstraint(Percent(percent))
 }
 
-// WithHeight
+// WithHeight sets the height in percents of the parent. See WithHeightConstraint for absolute cells and min/max bounds.
 func WithHeight(percent int) RectangleOption {
-	return func(r *Rectangle) {
-		r.height = &percent
-	}
+	return WithHeightConstraint(Percent(percent))
 }
 
-// WithChildren
+// WithChildren declares the children of the rectangle, which inherit its style. Their position is calculated by SetChildren (or Layout).
 func WithChildren(children ...*Rectangle) RectangleOption {
 	return func(r *Rectangle) {
 		for _, child := range children {
-			if child.st.Bg == color.Default {
-				child.st.Bg = r.st.Bg // inherit background color
-			}
-			if child.st.Fg == color.Default {
-				child.st.Fg = r.st.Fg // inherit foreground color
-			}
-			wasAlreadyAddedToChildren := false
-			if child.width != nil {
-				r.children = append(r.children, child) // mark it as resizable in this parent
-				wasAlreadyAddedToChildren = true
-			}
-			if child.height != nil {
-				if !wasAlreadyAddedToChildren {
-					r.children = append(r.children, child) // mark it as resizable in this parent
-				}
-			}
+			r.adopt(child)
+			r.children = append(r.children, child)
 		}
 	}
 }
@@ -179,17 +162,24 @@ type Rectangle struct {
 	id             int                   //
 	children       []*Rectangle          // children Rectangles, used for variable sizing // TODO : mount death listener for children
 	aligned        style.Alignment       // alignment. Default is style.Begin (topCorner)
-	st             style.Style           // rectangle style, for inheritance
+	st             style.Style           // rectangle own style : color.Default colors are inherited from the parent
+	attrsSet       bool                  // true if the attributes were set (otherwise they are inherited from the parent)
+	parent         *Rectangle            // the rectangle having this one among its children
 	died           chan struct{}         // Channel for killing (context.Done)
 	pixelAskCh     chan term.Position    // Channel for asking pixels
-	pixelReleaseCh chan term.Position    // Channel for releasing pixels
+	pixelReleaseCh chan term.Position    // Channel for releasing pixels, optional (set by WithReleasingChan)
 	pixelReceiveCh chan px               // Channel for receiving pixels
 	resizeCh       chan term.ResizeEvent // channel for listening resize events, so we can clip our coordinates
-	width          *int                  // width in percents, pointer indicates is optional
-	height         *int                  // height in percents, pointer indicates is optional
-	min            *term.Size            // The minimum size this object can be. Note that this can exist in the same time with width/height in percents // TODO : check new size (%) is not smaller than min allowed size
+	width          *Constraint           // width relative to the parent, pointer indicates is optional
+	height         *Constraint           // height relative to the parent, pointer indicates is optional
+	min            *term.Size            // The minimum size this object can be. Note that this can exist in the same time with width/height constraints
 	engine         term.Engine           // listen resize events
 	hidden         bool                  // Is this object currently hidden
+	onResize       ResizeHook            // optional, called when the geometry changes
+	onShow         VisibilityHook        // optional, called when shown
+	onHide         VisibilityHook        // optional, called when hidden
+	onClick        ClickHook             // optional, called when a button press lands inside
+	onMouse        MouseHook             // optional, called with the mouse events over the rectangle
 }
 
 // TODO : thinking maybe this should be a private constructor. Ask Page to give you a Rectangle and it will give it already populated and ready to use. For now (testing purposes), I'll leave it as it is.
@@ -197,12 +187,11 @@ type Rectangle struct {
 func NewRectangle(ctx context.Context, opts ...RectangleOption) (*Rectangle, error) {
 	defStyle := style.NewStyle(style.WithBg(color.Default), style.WithFg(color.Default), style.WithAttrs(style.None))
 	r := &Rectangle{
-		id:             getNextRectId(),          // id for equality comparison
-		aligned:        style.Begin,              // aligned top-left-corner
-		st:             *defStyle,                // default rectangle style (TODO : if default style is being used, no inheritance of style happens)
-		pixelReleaseCh: make(chan term.Position), //
-		pixelReceiveCh: make(chan px),            //
-		died:           make(chan struct{}),      // death announcement channel
+		id:             getNextRectId(),     // id for equality comparison
+		aligned:        style.Begin,         // aligned top-left-corner
+		st:             *defStyle,           // default rectangle style, inherits everything from the parent
+		pixelReceiveCh: make(chan px),       //
+		died:           make(chan struct{}), // death announcement channel
 		root: root{
 			orientation:  style.Vertical,           // default orientation
 			topCorner:    term.NewPosition(-1, -1), // by default, rectangle is nowhere
@@ -217,7 +206,7 @@ func NewRectangle(ctx context.Context, opts ...RectangleOption) (*Rectangle, err
 	if r.pixelAskCh == nil {
 		return nil, errors.New("acquisition channel is mandatory")
 	}
-	if r.min == nil && r.Invalid() {
+	if r.min == nil && r.width == nil && r.height == nil && r.Invalid() { // constrained rectangles get their corners from the parent
 		return nil, errors.New("declared rectangle is outside the screen")
 	}
 
@@ -255,8 +244,19 @@ func (r *Rectangle) Invalid() bool {
 
 // Move the rectangle object to a new position, relative to its children / canvas
 func (r *Rectangle) Move(pos *term.Position) {
+	r.releasePositions()
 	r.topCorner = pos
 	r.acquirePositions()
+	r.resized()
+}
+
+// SetBounds moves and resizes the rectangle at once (e.g. animating it), releasing the pixels it leaves, asking for the ones it covers and laying out its children
+func (r *Rectangle) SetBounds(b Bounds) {
+	r.releasePositions()
+	r.setCorners(b.Left, b.Top, b.Right, b.Bottom)
+	r.acquirePositions()
+	r.layout()
+	r.resized()
 }
 
 // SetMinSize specifies the smallest size this object should be
@@ -268,101 +268,110 @@ func (r *Rectangle) SetMinSize(size *term.Size) {
 func (r *Rectangle) Show() {
 	r.hidden = false
 	r.acquirePositions()
+	if r.onShow != nil {
+		r.onShow(r)
+	}
 }
 
 // Hide will set this object to not be visible
 func (r *Rectangle) Hide() {
 	r.hidden = true
 	r.releasePositions()
-}
-
-// calculatedWidth
-func (r *Rectangle) calculatedWidth(parentWidth int) int {
-	w := *r.width
-	return parentWidth * w / 100
-}
-
-// calculatedHeight
-func (r *Rectangle) calculatedHeight(parentHeight int) int {
-	h := *r.height
-	return parentHeight * h / 100
+	if r.onHide != nil {
+		r.onHide(r)
+	}
 }
 
 // SetChildren - general convention that all siblings are registered together so we can perform calculations of positions and invalidate recursively the children rectangles
 func (r *Rectangle) SetChildren(children ...*Rectangle) {
-	lastRow := 0
-	lastColumn := 0
+	for _, child := range r.children {
+		if child.parent == r {
+			child.parent = nil // orphans, unless they are still among the new children
+		}
+	}
 	for _, child := range children {
-		if r.HasRows() {
-			// variable sized rectangle (both width and height)
-			if child.width != nil && child.height != nil {
-				// with percent of the width and height
-				child.topCorner.Row = lastRow
-				child.topCorner.Column = lastColumn
-				child.bottomCorner.Row = child.calculatedHeight(r.bottomCorner.Row)
-				child.bottomCorner.Column = child.calculatedWidth(r.bottomCorner.Column)
-				lastColumn = child.bottomCorner.Column
-				lastRow = child.bottomCorner.Row
-				log.Printf("parent at %04d,%04d", lastColumn, lastRow)
-				log.Printf("child [%04d,%04d->%04d,%04d]", child.topCorner.Column, child.topCorner.Row, child.bottomCorner.Column, child.bottomCorner.Row)
-				child.invalidateSize()
-				continue
-			}
-
-			// fixed size rectangle
-			if child.width == nil && child.height == nil {
-				child.topCorner.Row = lastRow
-				child.topCorner.Column = lastColumn
-				child.bottomCorner.Row = r.bottomCorner.Row
-				child.bottomCorner.Column = r.bottomCorner.Column
-				child.invalidateSize()
-				continue
-			}
+		r.adopt(child)
+	}
+	r.children = children // TODO : mount death listener and remove child when shutdown
+	r.layout()
+}
 
-			// variable width rectangle
-			if child.width != nil {
-				child.bottomCorner.Column = child.calculatedWidth(r.bottomCorner.Column)
-				lastColumn = child.bottomCorner.Column
-			} else {
-				// with 100% width
-				child.bottomCorner.Column = r.bottomCorner.Column
-				lastColumn = child.bottomCorner.Column
-			}
+// Layout recalculates the corners of the children (recursively), e.g. after the rectangle was resized.
+// Children are stacked along the orientation : top to bottom for style.Vertical, left to right for style.Horizontal.
+func (r *Rectangle) Layout() {
+	r.layout()
+}
 
-			// variable height rectangle
-			if child.height != nil {
-				// with percent of the height
-				child.bottomCorner.Row = child.calculatedHeight(r.bottomCorner.Row)
-				lastRow = child.bottomCorner.Row
-			} else {
-				// with 100% height
-				child.bottomCorner.Row = r.bottomCorner.Row
-				lastRow = child.bottomCorner.Row
-			}
+// layout places the children inside the rectangle, clamping them to their constraints and minimum sizes
+func (r *Rectangle) layout() {
+	if len(r.children) == 0 || r.Invalid() {
+		return
+	}
+	if !r.HasRows() && !r.HasColumns() {
+		log.Println("bad call to Rectangle.SetChildren : orientation is not set (should never happen)")
+		return
+	}
+	vertical := r.HasRows()
+	bounds := r.Bounds()
+	size := bounds.Size()
+	alongAvailable, acrossAvailable := size.Columns, size.Rows
+	if vertical {
+		alongAvailable, acrossAvailable = size.Rows, size.Columns
+	}
 
-		} else if r.HasColumns() {
-			// columns orientation
-			if child.width == nil && child.height == nil {
-				// fixed size rectangle
-			} else {
-				// variable sized rectangle
-				if child.width != nil {
-					// with percent of the width
-				} else {
-					// with 100% width
-				}
-				if child.height != nil {
-					// with percent of the height
-				} else {
-					// with 100% height
-				}
-			}
+	constraints := make([]*Constraint, len(r.children))
+	mins := make([]int, len(r.children))
+	for idx, child := range r.children {
+		constraints[idx], _ = child.constraints(vertical)
+		mins[idx], _ = child.minAlong(vertical)
+	}
+	sizes := distribute(alongAvailable, constraints, mins)
+
+	offset := 0
+	for idx, child := range r.children {
+		_, acrossConstraint := child.constraints(vertical)
+		_, acrossMin := child.minAlong(vertical)
+		acrossSize := across(acrossConstraint, acrossMin, acrossAvailable)
+		if vertical {
+			child.setCorners(bounds.Left, bounds.Top+offset, bounds.Left+acrossSize-1, bounds.Top+offset+sizes[idx]-1)
 		} else {
-			log.Println("bad call to Rectangle.SetChildren : orientation is not set (should never happen)")
+			child.setCorners(bounds.Left+offset, bounds.Top, bounds.Left+offset+sizes[idx]-1, bounds.Top+acrossSize-1)
+		}
+		offset += sizes[idx]
+		if sizes[idx] <= 0 || acrossSize <= 0 || !child.clipTo(r) { // layout never goes outside the parent
+			child.setCorners(-1, -1, -1, -1) // no room left for this child
+			continue
 		}
-		r.children = append(r.children, child) // TODO : mount death listener and remove child when shutdown
 		child.invalidateSize()
+		child.layout()
+	}
+}
+
+// constraints returns the width and height constraints, along and across the parent orientation
+func (r *Rectangle) constraints(vertical bool) (*Constraint, *Constraint) {
+	if vertical {
+		return r.height, r.width
+	}
+	return r.width, r.height
+}
+
+// minAlong returns the minimum size along and across the parent orientation
+func (r *Rectangle) minAlong(vertical bool) (int, int) {
+	if r.min == nil {
+		return 0, 0
+	}
+	if vertical {
+		return r.min.Rows, r.min.Columns
 	}
+	return r.min.Columns, r.min.Rows
+}
+
+// setCorners moves both corners, keeping their hashes updated
+func (r *Rectangle) setCorners(left, top, right, bottom int) {
+	r.topCorner.Column, r.topCorner.Row = left, top
+	r.bottomCorner.Column, r.bottomCorner.Row = right, bottom
+	r.topCorner.UpdateHash()
+	r.bottomCorner.UpdateHash()
 }
 
 func (r *Rectangle) invalidateSize() {
@@ -384,32 +393,38 @@ func (r *Rectangle) invalidateSize() {
 	default:
 		// return nil, errors.New("orientation must be horizontal (rows) or vertical (columns)")
 	}
+	r.resized()
 }
 
 func (r *Rectangle) childRemoved() {
 
 }
 
+// acquirePositions asks for the pixels inside the bounds, unless the rectangle is hidden or nowhere
 func (r *Rectangle) acquirePositions() {
-	rowDist := term.Abs(r.bottomCorner.Row - r.topCorner.Row)
-	colDist := term.Abs(r.bottomCorner.Column - r.topCorner.Column)
-	minCol := term.Min(r.bottomCorner.Column, r.topCorner.Column)
-	minRow := term.Min(r.bottomCorner.Row, r.topCorner.Row)
-	for col := minCol; col < colDist; col++ {
-		for row := minRow; row < rowDist; row++ {
-			r.pixelAskCh <- term.Position{Row: row, Column: col}
-		}
+	if r.hidden {
+		return
 	}
+	r.eachPosition(r.pixelAskCh)
 }
 
+// releasePositions frees the pixels inside the bounds, if the caller listens for them (see WithReleasingChan)
 func (r *Rectangle) releasePositions() {
-	rowDist := term.Abs(r.bottomCorner.Row - r.topCorner.Row)
-	colDist := term.Abs(r.bottomCorner.Column - r.topCorner.Column)
-	minCol := term.Min(r.bottomCorner.Column, r.topCorner.Column)
-	minRow := term.Min(r.bottomCorner.Row, r.topCorner.Row)
-	for col := minCol; col < colDist; col++ {
-		for row := minRow; row < rowDist; row++ {
-			r.pixelReleaseCh <- term.Position{Row: row, Column: col}
+	if r.pixelReleaseCh == nil {
+		return
+	}
+	r.eachPosition(r.pixelReleaseCh)
+}
+
+// eachPosition sends the positions inside the bounds (edges included) on the channel
+func (r *Rectangle) eachPosition(ch chan term.Position) {
+	if r.Invalid() {
+		return
+	}
+	bounds := r.Bounds()
+	for column := bounds.Left; column <= bounds.Right; column++ {
+		for row := bounds.Top; row <= bounds.Bottom; row++ {
+			ch <- term.Position{Row: row, Column: column}
 		}
 	}
 }
@@ -0,0 +1,88 @@
+package geom
+
+import (
+	"github.com/badu/term"
+)
+
+const (
+	buttonsMask term.ButtonMask = 0xFF // buttons one to eight, without the wheel motions
+)
+
+// ResizeHook is called with the new size of the rectangle
+type ResizeHook func(r *Rectangle, size *term.Size)
+
+// VisibilityHook is called when the rectangle is shown or hidden
+type VisibilityHook func(r *Rectangle)
+
+// ClickHook is called with the mouse event, when a button press lands inside the rectangle. The event is a *LocalMouseEvent, having the position relative to the rectangle.
+type ClickHook func(r *Rectangle, ev term.MouseEvent)
+
+// MouseHook is called with every mouse event over the rectangle, including the ones which enter or leave it
+type MouseHook func(r *Rectangle, ev *LocalMouseEvent)
+
+// WithOnResize sets the hook called when the geometry of the rectangle changes. Hooks are called synchronously, so they should not block.
+func WithOnResize(hook ResizeHook) RectangleOption {
+	return func(r *Rectangle) {
+		r.onResize = hook
+	}
+}
+
+// WithOnShow sets the hook called when the rectangle is shown
+func WithOnShow(hook VisibilityHook) RectangleOption {
+	return func(r *Rectangle) {
+		r.onShow = hook
+	}
+}
+
+// WithOnHide sets the hook called when the rectangle is hidden
+func WithOnHide(hook VisibilityHook) RectangleOption {
+	return func(r *Rectangle) {
+		r.onHide = hook
+	}
+}
+
+// WithOnClick sets the hook called when a mouse button press lands inside the rectangle. The Page which has the rectangle added routes the mouse events.
+func WithOnClick(hook ClickHook) RectangleOption {
+	return func(r *Rectangle) {
+		r.onClick = hook
+	}
+}
+
+// WithOnMouse sets the hook called with the mouse events while the pointer is over the rectangle, and when it enters or leaves it (see LocalMouseEvent Crossing).
+// The Page which has the rectangle added routes the mouse events to the top most rectangle.
+func WithOnMouse(hook MouseHook) RectangleOption {
+	return func(r *Rectangle) {
+		r.onMouse = hook
+	}
+}
+
+// resized calls the resize hook, if any
+func (r *Rectangle) resized() {
+	if r.onResize != nil {
+		r.onResize(r, r.Size())
+	}
+}
+
+// clicked calls the click hook if the event is a button press inside the rectangle, returning true if the event was consumed
+func (r *Rectangle) clicked(ev term.MouseEvent) bool {
+	if r.onClick == nil || r.hidden || ev.Buttons()&buttonsMask == 0 {
+		return false
+	}
+	if !r.Contains(ev.Position()) {
+		return false
+	}
+	r.onClick(r, r.localEvent(ev, NoCrossing))
+	return true
+}
+
+// hovers returns true if the rectangle listens the mouse events and the pointer is over it
+func (r *Rectangle) hovers(column, row int) bool {
+	return r.onMouse != nil && !r.hidden && r.Contains(column, row)
+}
+
+// mouseMoved calls the mouse hook, if any
+func (r *Rectangle) mouseMoved(ev term.MouseEvent, crossing Crossing) {
+	if r.onMouse != nil {
+		r.onMouse(r, r.localEvent(ev, crossing))
+	}
+}
@@ -56,14 +56,14 @@ func (r *Rectangle) Visible() bool {
 	return !r.hidden
 }
 
-// Bg
+// Bg returns the background color, inherited from the ancestors if not set
 func (r *Rectangle) Bg() color.Color {
-	return r.st.Bg
+	return r.Style().Bg
 }
 
-// Fg
+// Fg returns the foreground color, inherited from the ancestors if not set
 func (r *Rectangle) Fg() color.Color {
-	return r.st.Fg
+	return r.Style().Fg
 }
 
 // Top
@@ -0,0 +1,81 @@
+package geom
+
+import (
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+// WithAttributes sets the style attributes of the rectangle. Once set, they are no longer inherited from the parent, even if they are style.None.
+func WithAttributes(m style.Mask) RectangleOption {
+	return func(r *Rectangle) {
+		r.st.Attrs = m
+		r.attrsSet = true
+	}
+}
+
+// Parent returns the rectangle which has r among its children, or nil
+func (r *Rectangle) Parent() *Rectangle {
+	return r.parent
+}
+
+// OwnStyle returns the style declared on this rectangle, without inheritance. Unset colors are color.Default.
+func (r *Rectangle) OwnStyle() style.Style {
+	return r.st
+}
+
+// Style returns the style used for drawing : the unset fields (color.Default colors and the attributes, if they were never set)
+// are resolved against the ancestors, from the closest one. Since it's resolved on every call, changing an ancestor's style
+// is visible to all its descendants, while an explicit style always wins.
+func (r *Rectangle) Style() style.Style {
+	result := r.st
+	attrsSet := r.attrsSet
+	for ancestor := r.parent; ancestor != nil; ancestor = ancestor.parent {
+		if result.Fg == color.Default {
+			result.Fg = ancestor.st.Fg
+		}
+		if result.Bg == color.Default {
+			result.Bg = ancestor.st.Bg
+		}
+		if !attrsSet && ancestor.attrsSet {
+			result.Attrs = ancestor.st.Attrs
+			attrsSet = true
+		}
+	}
+	return result
+}
+
+// SetBackgroundColor changes the background color. Setting color.Default means inheriting it from the parent.
+func (r *Rectangle) SetBackgroundColor(c color.Color) {
+	r.st.Bg = c
+}
+
+// SetForegroundColor changes the foreground color. Setting color.Default means inheriting it from the parent.
+func (r *Rectangle) SetForegroundColor(c color.Color) {
+	r.st.Fg = c
+}
+
+// SetAttributes changes the style attributes, which are no longer inherited from the parent
+func (r *Rectangle) SetAttributes(m style.Mask) {
+	r.st.Attrs = m
+	r.attrsSet = true
+}
+
+// InheritAttributes forgets the attributes set on this rectangle, so they are inherited from the parent again
+func (r *Rectangle) InheritAttributes() {
+	r.st.Attrs = style.None
+	r.attrsSet = false
+}
+
+// adopt makes r the parent of the child, removing it from the children of the previous parent (re-parenting)
+func (r *Rectangle) adopt(child *Rectangle) {
+	if child.parent != nil && child.parent != r {
+		previous := child.parent
+		for idx, sibling := range previous.children {
+			if sibling.id == child.id {
+				previous.children = append(previous.children[:idx], previous.children[idx+1:]...)
+				break
+			}
+		}
+	}
+	child.parent = r
+}
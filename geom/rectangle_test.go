@@ -4,8 +4,11 @@ import (
 	"context"
 	"testing"
 
+	"github.com/badu/term"
 	"github.com/badu/term/color"
 	"github.com/badu/term/geom"
+	"github.com/badu/term/key"
+	"github.com/badu/term/mouse"
 	"github.com/badu/term/style"
 )
 
@@ -262,3 +265,186 @@ func TestOverlap(t *testing.T) {
 	}
 	cancel()
 }
+
+func TestRectangleHooks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fakeEngine := NewFakeEngine(t, 20, 10)
+	fakeEngine.Start(ctx)
+	page, err := geom.NewPage(ctx, geom.WithEngine(fakeEngine))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+
+	aq := make(chan term.Position)
+	go func() {
+		for range aq { // acting as a page, which delivers pixels
+		}
+	}()
+	shown, hidden := 0, 0
+	clicks := make(chan int, 2)
+	onClick := geom.WithOnClick(func(r *geom.Rectangle, ev term.MouseEvent) { clicks <- r.Id() })
+	under, _ := geom.NewRectangle(ctx, geom.WithAcquisitionChan(aq), geom.WithTopCorner(0, 0), geom.WithBottomCorner(9, 9), onClick)
+	over, _ := geom.NewRectangle(ctx, geom.WithAcquisitionChan(aq), geom.WithTopCorner(2, 2), geom.WithBottomCorner(4, 4), onClick,
+		geom.WithOnShow(func(r *geom.Rectangle) { shown++ }),
+		geom.WithOnHide(func(r *geom.Rectangle) { hidden++ }),
+	)
+	page.AddRectangles(under, over)
+
+	page.MouseListen() <- mouse.NewEvent(3, 3, mouse.Button1, key.ModNone)
+	if id := <-clicks; id != over.Id() {
+		t.Fatalf("expecting the click to land on the top rectangle %d, got %d", over.Id(), id)
+	}
+	page.MouseListen() <- mouse.NewEvent(8, 8, mouse.Button1, key.ModNone)
+	if id := <-clicks; id != under.Id() {
+		t.Fatalf("expecting the click to land on the rectangle %d, got %d", under.Id(), id)
+	}
+
+	over.Hide()
+	page.MouseListen() <- mouse.NewEvent(3, 3, mouse.WheelUp, key.ModNone) // not a press
+	page.MouseListen() <- mouse.NewEvent(3, 3, mouse.Button1, key.ModNone) // hidden, the one beneath gets it
+	if id := <-clicks; id != under.Id() {
+		t.Fatalf("expecting the click to land on the rectangle %d, got %d", under.Id(), id)
+	}
+	over.Show()
+	if shown != 1 || hidden != 1 {
+		t.Fatalf("expecting show and hide hooks to be called once : shown %d, hidden %d", shown, hidden)
+	}
+}
+
+func TestSetBounds(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	counter := newPixelCounter(ctx)
+	resized := make(chan *term.Size, 1)
+	r, err := geom.NewRectangle(ctx, geom.WithAcquisitionChan(counter.ask), geom.WithReleasingChan(counter.release),
+		geom.WithTopCorner(0, 0), geom.WithBottomCorner(3, 1), geom.WithOnResize(func(r *geom.Rectangle, size *term.Size) { resized <- size }))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	r.Show()
+	if n := counter.count(); n != 8 {
+		t.Fatalf("expecting 8 pixels acquired, got %d", n)
+	}
+	to := geom.Bounds{Left: 5, Top: 2, Right: 10, Bottom: 4}
+	r.SetBounds(to)
+	if b := r.Bounds(); b != to {
+		t.Fatalf("expecting the bounds %+v, got %+v", to, b)
+	}
+	if n := counter.count(); n != 18 {
+		t.Fatalf("expecting the pixels of the previous bounds released and 18 acquired, got %d", n)
+	}
+	select {
+	case <-resized:
+	default:
+		t.Fatalf("expecting the resize hook to be called")
+	}
+}
+
+func TestMouseTranslation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fakeEngine := NewFakeEngine(t, 20, 10)
+	fakeEngine.Start(ctx)
+	page, err := geom.NewPage(ctx, geom.WithEngine(fakeEngine))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+
+	events := make(chan *geom.LocalMouseEvent, 4)
+	clicks := make(chan term.MouseEvent, 1)
+	rect, _ := geom.NewRectangle(ctx, testAcquisitionChan(), geom.WithTopCorner(5, 2), geom.WithBottomCorner(9, 6),
+		geom.WithOnMouse(func(r *geom.Rectangle, ev *geom.LocalMouseEvent) { events <- ev }),
+		geom.WithOnClick(func(r *geom.Rectangle, ev term.MouseEvent) { clicks <- ev }),
+	)
+	page.AddRectangles(rect)
+
+	for _, tc := range []struct {
+		column, row int
+		local       [2]int
+		crossing    geom.Crossing
+	}{
+		{6, 3, [2]int{1, 1}, geom.MouseEnter},
+		{9, 6, [2]int{4, 4}, geom.NoCrossing},
+		{12, 8, [2]int{7, 6}, geom.MouseLeave}, // the position is outside
+		{5, 2, [2]int{0, 0}, geom.MouseEnter},
+	} {
+		page.MouseListen() <- mouse.NewEvent(tc.column, tc.row, mouse.ButtonNone, key.ModNone)
+		ev := <-events
+		if column, row := ev.Position(); column != tc.local[0] || row != tc.local[1] || ev.Crossing() != tc.crossing {
+			t.Errorf("error : %d,%d should be delivered at %v %s, got %d,%d %s", tc.column, tc.row, tc.local, tc.crossing, column, row, ev.Crossing())
+		}
+		if column, row := ev.Absolute(); column != tc.column || row != tc.row {
+			t.Errorf("error : absolute position should be %d,%d, got %d,%d", tc.column, tc.row, column, row)
+		}
+	}
+
+	page.MouseListen() <- mouse.NewEvent(7, 5, mouse.Button1, key.ModNone)
+	<-events
+	if column, row := (<-clicks).Position(); column != 2 || row != 3 {
+		t.Errorf("error : click should be delivered at 2,3, got %d,%d", column, row)
+	}
+
+	// nothing is delivered outside, until the pointer comes back
+	page.MouseListen() <- mouse.NewEvent(0, 0, mouse.ButtonNone, key.ModNone)
+	page.MouseListen() <- mouse.NewEvent(1, 0, mouse.ButtonNone, key.ModNone)
+	page.MouseListen() <- mouse.NewEvent(8, 2, mouse.ButtonNone, key.ModNone)
+	if ev := <-events; ev.Crossing() != geom.MouseLeave {
+		t.Errorf("error : expecting the leave event, got %s", ev.Crossing())
+	}
+	if ev := <-events; ev.Crossing() != geom.MouseEnter {
+		t.Errorf("error : expecting the enter event, got %s", ev.Crossing())
+	}
+}
+
+func TestStyleCascade(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	newRect := func(opts ...geom.RectangleOption) *geom.Rectangle {
+		opts = append(opts, testAcquisitionChan(), geom.WithTopCorner(0, 0), geom.WithBottomCorner(9, 9))
+		r, err := geom.NewRectangle(ctx, opts...)
+		if err != nil {
+			t.Fatalf("error : there should be no error - %v", err)
+		}
+		return r
+	}
+	grandChild := newRect()
+	child := newRect(geom.WithForegroundColor(color.Yellow), geom.WithChildren(grandChild))
+	explicit := newRect(geom.WithBackgroundColor(color.Green), geom.WithAttributes(style.None))
+	parent := newRect(geom.WithBackgroundColor(color.Red), geom.WithForegroundColor(color.White), geom.WithAttributes(style.Bold))
+	parent.SetChildren(child, explicit)
+
+	if grandChild.Bg() != color.Red || grandChild.Fg() != color.Yellow || grandChild.Style().Attrs != style.Bold {
+		t.Fatalf("error : grand child should inherit : got %v", grandChild.Style())
+	}
+	if explicit.Bg() != color.Green || explicit.Fg() != color.White || explicit.Style().Attrs != style.None {
+		t.Fatalf("error : explicit style should win : got %v", explicit.Style())
+	}
+
+	// updates of the parent propagate
+	parent.SetBackgroundColor(color.Blue)
+	if grandChild.Bg() != color.Blue || explicit.Bg() != color.Green {
+		t.Fatal("error : parent background change should propagate only to the children which inherit it")
+	}
+	if grandChild.OwnStyle().Bg != color.Default {
+		t.Fatal("error : own style should not change")
+	}
+
+	// re-parenting
+	other := newRect(geom.WithBackgroundColor(color.Purple))
+	other.SetChildren(grandChild)
+	if grandChild.Parent() != other {
+		t.Fatal("error : grand child should have been re-parented")
+	}
+	if grandChild.Bg() != color.Purple || grandChild.Fg() != color.Default || grandChild.Style().Attrs != style.None {
+		t.Fatalf("error : re-parented child should inherit from the new parent only : got %v", grandChild.Style())
+	}
+	child.SetChildren()
+	if grandChild.Parent() != other {
+		t.Fatal("error : the previous parent should not orphan a re-parented child")
+	}
+	parent.SetChildren(explicit)
+	if child.Parent() != nil || child.Bg() != color.Default {
+		t.Fatal("error : removed child should not inherit anymore")
+	}
+}
@@ -34,7 +34,7 @@ type Tree struct {
 // newTree
 func NewTree(ctx context.Context, cols, rows int, ch chan term.Position, oriented style.Orientation, core term.Engine) Tree {
 	var opts []RectangleOption
-	opts = append(opts, WithBottomCorner(cols, rows))
+	opts = append(opts, WithBottomCorner(cols-1, rows-1)) // corners are inclusive
 	opts = append(opts, WithTopCorner(0, 0))
 	opts = append(opts, WithAcquisitionChan(ch))
 	opts = append(opts, WithCore(core))
@@ -58,6 +58,13 @@ func (t *Tree) Register(targets ...*Rectangle) {
 	}
 }
 
+// Resize changes the size of the root rectangle and recalculates the layout of the whole tree
+func (t *Tree) Resize(cols, rows int) {
+	t.Root.Rectangle.setCorners(0, 0, cols-1, rows-1)
+	t.Root.Rectangle.invalidateSize()
+	t.Root.Rectangle.layout()
+}
+
 // findNode is a recursive function, which analyzes a Tree and connects the items with specific characters.
 func findNode(list []Node, p *Tree, children []*Rectangle) {
 	for i, item := range list {
@@ -129,3 +129,35 @@ func TestHResize(t *testing.T) {
 	print(t, page)
 	cancel()
 }
+
+func TestLayoutConstraints(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	aq := make(chan term.Position)
+	fakeEngine := NewFakeEngine(t, 100, 40)
+	fakeEngine.Start(ctx)
+	tree := NewTree(ctx, 100, 40, aq, style.Vertical, fakeEngine)
+	header, _ := NewRectangle(ctx, WithAcquisitionChan(aq), WithHeightConstraint(Cells(3)))
+	body, _ := NewRectangle(ctx, WithAcquisitionChan(aq), WithHeightConstraint(Percent(50).AtMost(15)), WithWidthConstraint(Percent(50).AtLeast(60)))
+	filler, _ := NewRectangle(ctx, WithAcquisitionChan(aq), WithMinSize(term.NewSize(0, 1)))
+	footer, _ := NewRectangle(ctx, WithAcquisitionChan(aq), WithHeightConstraint(Cells(2)))
+	tree.Register(header, body, filler, footer)
+
+	check := func(r *Rectangle, top, bottom, width int) {
+		t.Helper()
+		if r.Top().Row != top || r.Bottom().Row != bottom || r.Width() != width {
+			t.Fatalf("expecting rows %d->%d and width %d : got rows %d->%d and width %d", top, bottom, width, r.Top().Row, r.Bottom().Row, r.Width())
+		}
+	}
+	check(header, 0, 2, 100)
+	check(body, 3, 17, 60)
+	check(filler, 18, 37, 100)
+	check(footer, 38, 39, 100)
+
+	// not enough room : the filler keeps its minimum, the missing cell is taken from the last sibling
+	tree.Resize(100, 10)
+	check(header, 0, 2, 100)
+	check(body, 3, 7, 60)
+	check(filler, 8, 8, 100)
+	check(footer, 9, 9, 100)
+}
@@ -1,8 +1,6 @@
 package geom
 
 import (
-	"log"
-
 	"github.com/badu/term"
 	"github.com/badu/term/style"
 )
@@ -45,14 +43,14 @@ func (r *root) HasRows() bool {
 func (r *root) Row(index int) Pixels {
 	if r.HasColumns() {
 		if index <= 0 {
-			if Debug {
-				log.Println("bad call to Rectangle.Row : bad index")
+			if Debug.Enabled() {
+				Debug.Println("bad call to Rectangle.Row : bad index")
 			}
 			return nil
 		}
 		if len(r.cols) <= 0 {
-			if Debug {
-				log.Println("bad call to Rectangle.Row : horizontal orientation, but columns are empty")
+			if Debug.Enabled() {
+				Debug.Println("bad call to Rectangle.Row : horizontal orientation, but columns are empty")
 			}
 		}
 		var result Pixels
@@ -67,14 +65,14 @@ func (r *root) Row(index int) Pixels {
 	}
 	// vertical orientation
 	if index <= 0 {
-		if Debug {
-			log.Println("bad call to Rectangle.Row : bad index")
+		if Debug.Enabled() {
+			Debug.Println("bad call to Rectangle.Row : bad index")
 		}
 		return nil
 	}
 	if index-1 >= len(r.rows) {
-		if Debug {
-			log.Println("bad call to Rectangle.Row : index outside number of rows")
+		if Debug.Enabled() {
+			Debug.Println("bad call to Rectangle.Row : index outside number of rows")
 		}
 		return nil
 	}
@@ -85,8 +83,8 @@ func (r *root) Row(index int) Pixels {
 func (r *root) NumRows() int {
 	if r.HasColumns() {
 		if len(r.cols) <= 0 {
-			if Debug {
-				log.Println("bad call to Rectangle.NumRows : cannot calculate number of rows (columns are empty)")
+			if Debug.Enabled() {
+				Debug.Println("bad call to Rectangle.NumRows : cannot calculate number of rows (columns are empty)")
 			}
 			return 0
 		}
@@ -99,8 +97,8 @@ func (r *root) NumRows() int {
 func (r *root) Rows() PixelsMatrix {
 	if r.HasColumns() {
 		if len(r.cols) <= 0 {
-			if Debug {
-				log.Println("bad call to Rectangle.Rows : cannot return rotated (columns are empty)")
+			if Debug.Enabled() {
+				Debug.Println("bad call to Rectangle.Rows : cannot return rotated (columns are empty)")
 			}
 			return nil
 		}
@@ -119,14 +117,14 @@ func (r *root) Column(index int) Pixels {
 	if r.HasRows() {
 		// vertical orientation column
 		if index <= 0 {
-			if Debug {
-				log.Println("bad call to Rectangle.Column : bad index")
+			if Debug.Enabled() {
+				Debug.Println("bad call to Rectangle.Column : bad index")
 			}
 			return nil
 		}
 		if len(r.rows) <= 0 {
-			if Debug {
-				log.Println("bad call to Rectangle.Column : vertical orientation, but rows are empty")
+			if Debug.Enabled() {
+				Debug.Println("bad call to Rectangle.Column : vertical orientation, but rows are empty")
 			}
 		}
 		var result Pixels
@@ -141,14 +139,14 @@ func (r *root) Column(index int) Pixels {
 	}
 	// horizontal direction
 	if index <= 0 {
-		if Debug {
-			log.Println("bad call to Rectangle.Column : bad index")
+		if Debug.Enabled() {
+			Debug.Println("bad call to Rectangle.Column : bad index")
 		}
 		return nil
 	}
 	if index-1 > len(r.cols) {
-		if Debug {
-			log.Println("bad call to Rectangle.Column : index outside number of columns")
+		if Debug.Enabled() {
+			Debug.Println("bad call to Rectangle.Column : index outside number of columns")
 		}
 		return nil
 	}
@@ -159,8 +157,8 @@ func (r *root) Column(index int) Pixels {
 func (r *root) NumColumns() int {
 	if r.HasRows() {
 		if len(r.rows) <= 0 {
-			if Debug {
-				log.Println("bad call to Rectangle.NumColumns : cannot calculate number of columns (rows are empty)")
+			if Debug.Enabled() {
+				Debug.Println("bad call to Rectangle.NumColumns : cannot calculate number of columns (rows are empty)")
 			}
 			return 0
 		}
@@ -173,8 +171,8 @@ func (r *root) NumColumns() int {
 func (r *root) Columns() PixelsMatrix {
 	if r.HasRows() {
 		if len(r.rows) <= 0 {
-			if Debug {
-				log.Println("bad call to Rectangle.Columns : cannot return rotated (rows are empty)")
+			if Debug.Enabled() {
+				Debug.Println("bad call to Rectangle.Columns : cannot return rotated (rows are empty)")
 			}
 			return nil
 		}
@@ -189,14 +187,14 @@ func (r *root) resize(size *term.Size) {
 	switch r.orientation {
 	case style.Vertical:
 		if len(r.rows) == 0 {
-			if Debug {
-				log.Println("bad root rectangle (no height)")
+			if Debug.Enabled() {
+				Debug.Println("bad root rectangle (no height)")
 			}
 			return
 		}
 		if len(r.rows[0]) == 0 {
-			if Debug {
-				log.Println("bad root rectangle (no width)")
+			if Debug.Enabled() {
+				Debug.Println("bad root rectangle (no width)")
 			}
 			return
 		}
@@ -207,14 +205,14 @@ func (r *root) resize(size *term.Size) {
 		r.verticalResize(size.Columns, size.Rows)
 	case style.Horizontal:
 		if len(r.cols) == 0 {
-			if Debug {
-				log.Println("bad root rectangle (no width)")
+			if Debug.Enabled() {
+				Debug.Println("bad root rectangle (no width)")
 			}
 			return
 		}
 		if len(r.cols[0]) == 0 {
-			if Debug {
-				log.Println("bad root rectangle (no height)")
+			if Debug.Enabled() {
+				Debug.Println("bad root rectangle (no height)")
 			}
 			return
 		}
@@ -226,8 +224,8 @@ func (r *root) resize(size *term.Size) {
 	}
 }
 
-// startup
-func (r *root) startup(engine term.Engine) {
+// startup creates the pixels and registers them with the engine, returning them
+func (r *root) startup(engine term.Engine) []term.PixelGetter {
 	r.pxs = make(map[int]px)
 	pixels := make([]term.PixelGetter, 0)
 	columns := r.bottomCorner.Column - r.topCorner.Column
@@ -257,6 +255,7 @@ func (r *root) startup(engine term.Engine) {
 		}
 	}
 	engine.ActivePixels(pixels)
+	return pixels
 }
 
 func (r *root) horizontalResize(newRows, newColumns int) { // Note : the params are inverted (rows, columns)
@@ -0,0 +1,322 @@
+package geom
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/badu/term"
+	"github.com/badu/term/key"
+	"github.com/badu/term/mouse"
+)
+
+// SelectionHook is called with the selected text, when the user has finished a selection (the drag has ended, or a Shift+arrow was pressed)
+type SelectionHook func(s *Selection, text string)
+
+// SelectionOption configures the Selection
+type SelectionOption func(s *Selection)
+
+// WithOnSelect sets the hook called when the user has finished a selection
+func WithOnSelect(hook SelectionHook) SelectionOption {
+	return func(s *Selection) {
+		s.onSelect = hook
+	}
+}
+
+// WithCopyOnSelect copies the text to the clipboard each time the user has finished a selection, like the X11 terminals do. The engine has to implement term.Clipboard.
+func WithCopyOnSelect() SelectionOption {
+	return func(s *Selection) {
+		s.copyOnSelect = true
+	}
+}
+
+// Selection highlights a region of the page with reverse video, selected by dragging the mouse (see HandleDrag) or with Shift+arrows (see HandleKey), and exposes the selected text.
+// Like in a terminal, the selection flows from the start to the end position, row by row, but it stays inside the widget where it started : the deepest rectangle of the page
+// (see Page AddRectangles) under the start position, or the whole page if there is none. The text of the other widgets sharing the rows is never selected.
+type Selection struct {
+	sync.Mutex                    //
+	page         *Page            //
+	anchor       term.Position    // where the selection has started
+	cursor       term.Position    // where the selection ends, moved by the mouse and the keyboard
+	within       Bounds           // the widget where the selection has started
+	active       bool             // true while there is a selection
+	highlighted  map[int]struct{} // the position hashes of the inverted pixels
+	onSelect     SelectionHook    // set by WithOnSelect
+	copyOnSelect bool             // set by WithCopyOnSelect
+}
+
+// NewSelection returns the selection of the page. Nothing is selected until the application passes the drag events (see mouse.DragRecognizer) or the key events to it.
+func NewSelection(page *Page, opts ...SelectionOption) *Selection {
+	res := &Selection{
+		page:        page,
+		highlighted: make(map[int]struct{}),
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+	return res
+}
+
+// HandleDrag selects from where the drag has started to the pointer, returning true if the event was consumed
+func (s *Selection) HandleDrag(ev *mouse.DragEvent) bool {
+	s.Lock()
+	if ev.Phase() == mouse.DragStart {
+		column, row := ev.Start()
+		if !s.begin(column, row) {
+			s.Unlock()
+			return false
+		}
+	}
+	if !s.active {
+		s.Unlock()
+		return false
+	}
+	s.moveTo(ev.Position())
+	s.Unlock()
+
+	if ev.Phase() == mouse.DragEnd {
+		s.selected()
+	}
+	return true
+}
+
+// HandleKey extends the selection with Shift+arrows, Shift+Home and Shift+End, starting from the cursor of the engine (or where the last selection ended) and returning true if the event was consumed.
+// The other keys clear the selection, without being consumed. The application decides when the selection has the keyboard focus.
+func (s *Selection) HandleKey(ev term.KeyEvent) bool {
+	if ev.Modifiers()&key.ModShift == 0 {
+		s.Clear()
+		return false
+	}
+	columns, rows := 0, 0
+	switch ev.Key() {
+	case key.Left:
+		columns = -1
+	case key.Right:
+		columns = 1
+	case key.Up:
+		rows = -1
+	case key.Down:
+		rows = 1
+	case key.Home, key.End:
+	default:
+		return false
+	}
+
+	s.Lock()
+	if !s.active {
+		start := s.cursor
+		if cursor := s.page.engine.Cursor(); cursor != nil {
+			start = *cursor
+		}
+		if !s.begin(start.Column, start.Row) {
+			s.Unlock()
+			return false
+		}
+	}
+	column, row := s.cursor.Column+columns, s.cursor.Row+rows
+	switch ev.Key() {
+	case key.Home:
+		column = s.within.Left
+	case key.End:
+		column = s.within.Right
+	}
+	s.moveTo(column, row)
+	s.Unlock()
+
+	s.selected()
+	return true
+}
+
+// Select selects from the start to the end position, inside the widget found at the start position, returning false if it is outside the page
+func (s *Selection) Select(fromColumn, fromRow, toColumn, toRow int) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	if !s.begin(fromColumn, fromRow) {
+		return false
+	}
+	s.moveTo(toColumn, toRow)
+	return true
+}
+
+// Active returns true if there is a selection
+func (s *Selection) Active() bool {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.active
+}
+
+// Clear removes the selection, drawing the pixels as they were
+func (s *Selection) Clear() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.active = false
+	s.highlight(nil)
+}
+
+// Text returns the selected text, the rows being separated by new lines and having their trailing spaces removed, or an empty string if nothing is selected
+func (s *Selection) Text() string {
+	s.Lock()
+	defer s.Unlock()
+
+	if !s.active {
+		return ""
+	}
+	s.page.RLock()
+	defer s.page.RUnlock()
+
+	var sb strings.Builder
+	start, end := s.ordered()
+	for row := start.Row; row <= end.Row; row++ {
+		if row > start.Row {
+			sb.WriteByte('\n')
+		}
+		var line strings.Builder
+		from, to := s.span(row, start, end)
+		for column := from; column <= to; column++ {
+			cell, ok := s.page.cells[term.Hash(column, row)]
+			if !ok || cell.Rune() == 0 {
+				continue // the second half of a wide rune
+			}
+			line.WriteString(cell.text())
+		}
+		sb.WriteString(strings.TrimRight(line.String(), " "))
+	}
+	return sb.String()
+}
+
+// Copy puts the selected text on the clipboard, returning false if nothing is selected or if the engine doesn't implement term.Clipboard
+func (s *Selection) Copy() bool {
+	text := s.Text()
+	if text == "" {
+		return false
+	}
+	clipboard, ok := s.page.engine.(term.Clipboard)
+	if !ok {
+		return false
+	}
+	return clipboard.SetClipboard(text)
+}
+
+// selected tells the application that the user has finished a selection
+func (s *Selection) selected() {
+	text := s.Text()
+	if s.copyOnSelect {
+		s.Copy()
+	}
+	if s.onSelect != nil {
+		s.onSelect(s, text)
+	}
+}
+
+// begin starts a selection at the position, inside the widget found there, returning false if the position is outside the page - locked inside caller function
+func (s *Selection) begin(column, row int) bool {
+	within, ok := s.page.widgetBounds(column, row)
+	if !ok {
+		return false
+	}
+	s.within, s.active = within, true
+	s.anchor = term.Position{Column: column, Row: row}
+	s.cursor = s.anchor
+	return true
+}
+
+// moveTo moves the end of the selection to the position, kept inside the widget, and highlights the selected pixels - locked inside caller function
+func (s *Selection) moveTo(column, row int) {
+	s.cursor.Column = term.Min(term.Max(column, s.within.Left), s.within.Right)
+	s.cursor.Row = term.Min(term.Max(row, s.within.Top), s.within.Bottom)
+
+	selected := make(map[int]struct{})
+	start, end := s.ordered()
+	for row := start.Row; row <= end.Row; row++ {
+		from, to := s.span(row, start, end)
+		for column := from; column <= to; column++ {
+			selected[term.Hash(column, row)] = struct{}{}
+		}
+	}
+	s.highlight(selected)
+}
+
+// ordered returns the anchor and the cursor, the first one in reading order being the start - locked inside caller function
+func (s *Selection) ordered() (term.Position, term.Position) {
+	if s.cursor.Row < s.anchor.Row || (s.cursor.Row == s.anchor.Row && s.cursor.Column < s.anchor.Column) {
+		return s.cursor, s.anchor
+	}
+	return s.anchor, s.cursor
+}
+
+// span returns the first and the last selected column of the row : the rows between the start and the end are selected up to the widget edges - locked inside caller function
+func (s *Selection) span(row int, start, end term.Position) (int, int) {
+	from, to := s.within.Left, s.within.Right
+	if row == start.Row {
+		from = start.Column
+	}
+	if row == end.Row {
+		to = end.Column
+	}
+	return from, to
+}
+
+// highlight inverts the pixels entering or leaving the selection, drawing them in a single write unless the page is hidden - locked inside caller function
+func (s *Selection) highlight(selected map[int]struct{}) {
+	s.page.RLock()
+	changed := make([]term.PixelGetter, 0)
+	for hash := range s.highlighted {
+		if _, ok := selected[hash]; ok {
+			continue
+		}
+		if cell, ok := s.page.cells[hash]; ok {
+			cell.invert()
+			changed = append(changed, cell)
+		}
+	}
+	for hash := range selected {
+		if _, ok := s.highlighted[hash]; ok {
+			continue
+		}
+		if cell, ok := s.page.cells[hash]; ok {
+			cell.invert()
+			changed = append(changed, cell)
+		}
+	}
+	hidden := s.page.hidden
+	s.page.RUnlock()
+
+	if selected == nil {
+		selected = make(map[int]struct{})
+	}
+	s.highlighted = selected
+	if len(changed) > 0 && !hidden {
+		s.page.engine.Redraw(changed)
+	}
+}
+
+// widgetBounds returns the bounds of the deepest visible rectangle at the position, or the ones of the page. It returns false if the position is outside the page.
+func (p *Page) widgetBounds(column, row int) (Bounds, bool) {
+	p.RLock()
+	defer p.RUnlock()
+
+	if column < 0 || row < 0 || column >= p.columns || row >= p.rows {
+		return Bounds{}, false
+	}
+	for idx := len(p.rects) - 1; idx >= 0; idx-- {
+		if widget := p.rects[idx].deepestAt(column, row); widget != nil {
+			return widget.Bounds().Clip(Bounds{Right: p.columns - 1, Bottom: p.rows - 1}), true
+		}
+	}
+	return Bounds{Right: p.columns - 1, Bottom: p.rows - 1}, true
+}
+
+// deepestAt returns the innermost visible rectangle containing the position, or nil
+func (r *Rectangle) deepestAt(column, row int) *Rectangle {
+	if r.hidden || !r.Contains(column, row) {
+		return nil
+	}
+	for idx := len(r.children) - 1; idx >= 0; idx-- {
+		if child := r.children[idx].deepestAt(column, row); child != nil {
+			return child
+		}
+	}
+	return r
+}
@@ -0,0 +1,96 @@
+package geom_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/geom"
+	"github.com/badu/term/key"
+	"github.com/badu/term/mouse"
+	"github.com/badu/term/style"
+)
+
+// clipboardEngine records the text copied by the selection
+type clipboardEngine struct {
+	*FakeEngine
+	copied string
+}
+
+func (e *clipboardEngine) SetClipboard(text string) bool {
+	e.copied = text
+	return true
+}
+
+func TestSelection(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fakeEngine := NewFakeEngine(t, 20, 4)
+	fakeEngine.Start(ctx)
+	engine := &clipboardEngine{FakeEngine: fakeEngine}
+	page, err := geom.NewPage(ctx, geom.WithEngine(engine))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	// a list on the left, a preview on the right
+	list, _ := geom.NewRectangle(ctx, testAcquisitionChan(), geom.WithTopCorner(0, 0), geom.WithBottomCorner(9, 3))
+	preview, _ := geom.NewRectangle(ctx, testAcquisitionChan(), geom.WithTopCorner(10, 0), geom.WithBottomCorner(19, 3))
+	page.AddRectangles(list, preview)
+	for row, text := range []string{"alpha     first line", "beta      second    ", "gamma     third     ", "          fourth    "} {
+		page.SetRow(row, []rune(text), style.Style{})
+	}
+	reversed := func(column, row int) bool {
+		for _, pixel := range page.Pixels() {
+			if pixel.PositionHash() == term.Hash(column, row) {
+				_, _, attrs := pixel.Style()
+				return attrs&style.Reverse != 0
+			}
+		}
+		return false
+	}
+
+	var selected string
+	selection := geom.NewSelection(page, geom.WithCopyOnSelect(), geom.WithOnSelect(func(s *geom.Selection, text string) { selected = text }))
+
+	// dragging inside the preview, past the list : the text of the list is never selected
+	recognizer := mouse.NewDragRecognizer(ctx)
+	for _, ev := range []term.MouseEvent{
+		mouse.NewEvent(14, 2, mouse.Button1, key.ModNone),
+		mouse.NewEvent(2, 0, mouse.Button1, key.ModNone),
+		mouse.NewEvent(2, 0, mouse.ButtonNone, key.ModNone),
+	} {
+		recognizer.MouseListen() <- ev
+	}
+	for _, phase := range []mouse.DragPhase{mouse.DragStart, mouse.DragEnd} {
+		drag := <-recognizer.DragListen()
+		if drag.Phase() != phase || !selection.HandleDrag(drag) {
+			t.Errorf("error : drag %s should be consumed", phase)
+		}
+	}
+	if selected != "first line\nsecond\nthird" || engine.copied != selected {
+		t.Errorf("error : the selection should flow backwards inside the preview, got %q copied %q", selected, engine.copied)
+	}
+	if !reversed(10, 0) || !reversed(10, 1) || !reversed(14, 2) || reversed(9, 1) || reversed(15, 2) {
+		t.Errorf("error : only the selected pixels should be reversed")
+	}
+
+	// the keyboard starts where the last selection ended
+	selection.Clear()
+	if reversed(10, 0) || selection.Text() != "" {
+		t.Errorf("error : clearing should restore the pixels")
+	}
+	if !selection.Select(0, 0, 4, 0) || selection.Text() != "alpha" {
+		t.Errorf("error : expecting alpha, got %q", selection.Text())
+	}
+	for _, ev := range []term.KeyEvent{key.NewEvent(key.Down, 0, key.ModShift), key.NewEvent(key.Left, 0, key.ModShift), key.NewEvent(key.End, 0, key.ModShift)} {
+		if !selection.HandleKey(ev) {
+			t.Errorf("error : %v should be consumed", ev.Key())
+		}
+	}
+	if selected != "alpha\nbeta" || reversed(10, 1) || !reversed(9, 1) {
+		t.Errorf("error : the selection should stop at the list edge, got %q", selected)
+	}
+	if selection.HandleKey(key.NewEvent(key.Rune, 'x', key.ModNone)) || selection.Active() || reversed(0, 0) {
+		t.Errorf("error : typing should clear the selection without being consumed")
+	}
+}
@@ -0,0 +1,320 @@
+package geom
+
+import (
+	"context"
+	"errors"
+
+	"github.com/badu/term"
+	"github.com/badu/term/key"
+	"github.com/badu/term/mouse"
+)
+
+const (
+	defaultSplitStep = 1 // cells moved by a key press
+)
+
+// SplitHook is called with the ratios of the panes, when the user has finished moving a bar (so they can be persisted and restored with WithSplitRatios)
+type SplitHook func(s *Splitter, ratios []float64)
+
+// SplitterOption configures the Splitter
+type SplitterOption func(s *Splitter)
+
+// WithSplitRatios sets the initial share of each pane, e.g. the ratios saved by the SplitHook. They are normalized, so 1, 2 means a third and two thirds. Default is equal shares.
+func WithSplitRatios(ratios ...float64) SplitterOption {
+	return func(s *Splitter) {
+		s.ratios = ratios
+	}
+}
+
+// WithSplitStep sets the number of cells a bar moves on each key press. Default is one cell.
+func WithSplitStep(cells int) SplitterOption {
+	return func(s *Splitter) {
+		if cells > 0 {
+			s.step = cells
+		}
+	}
+}
+
+// WithOnSplit sets the hook called when the user has finished moving a bar
+func WithOnSplit(hook SplitHook) SplitterOption {
+	return func(s *Splitter) {
+		s.onSplit = hook
+	}
+}
+
+// Splitter lays out two or more panes inside a container rectangle, along its orientation, separated by bars of one cell which can be moved with the mouse (see HandleDrag) and the keyboard (see HandleKey).
+// The panes keep their ratios when the container is resized.
+type Splitter struct {
+	container *Rectangle   // holds the panes and the bars, as its children
+	panes     []*Rectangle //
+	bars      []*Rectangle // bars[idx] separates panes[idx] and panes[idx+1]
+	ratios    []float64    // share of each pane, adding up to one
+	active    int          // the bar moved by HandleKey
+	dragged   int          // the bar being dragged, -1 if none
+	step      int          // set by WithSplitStep
+	onSplit   SplitHook    // set by WithOnSplit
+}
+
+// NewSplitter makes the panes the children of the container, creating the bars between them. The bars acquire their pixels like the container does and inherit its style.
+// The container orientation tells how the panes are stacked : style.Horizontal places them side by side, style.Vertical one above the other.
+// From now on, the splitter owns the constraints of the panes along the orientation, while their minimum sizes limit how far the bars can move.
+func NewSplitter(ctx context.Context, container *Rectangle, panes []*Rectangle, opts ...SplitterOption) (*Splitter, error) {
+	if len(panes) < 2 {
+		return nil, errors.New("splitter needs at least two panes")
+	}
+	res := &Splitter{
+		container: container,
+		panes:     panes,
+		dragged:   -1,
+		step:      defaultSplitStep,
+	}
+	for _, o := range opts {
+		o(res)
+	}
+	if err := res.normalize(); err != nil {
+		return nil, err
+	}
+
+	barOpts := []RectangleOption{WithAcquisitionChan(container.pixelAskCh), WithReleasingChan(container.pixelReleaseCh)}
+	if res.vertical() {
+		barOpts = append(barOpts, WithHeightConstraint(Cells(1)))
+	} else {
+		barOpts = append(barOpts, WithWidthConstraint(Cells(1)))
+	}
+	children := []*Rectangle{panes[0]}
+	for _, pane := range panes[1:] {
+		bar, err := NewRectangle(ctx, barOpts...)
+		if err != nil {
+			return nil, err
+		}
+		res.bars = append(res.bars, bar)
+		children = append(children, bar, pane)
+	}
+
+	// the parent layout resizes the container before placing its children, so the panes get their new sizes first
+	onResize := container.onResize
+	container.onResize = func(r *Rectangle, size *term.Size) {
+		res.resizePanes()
+		if onResize != nil {
+			onResize(r, size)
+		}
+	}
+	res.resizePanes()
+	container.SetChildren(children...)
+	return res, nil
+}
+
+// Container returns the rectangle holding the panes and the bars
+func (s *Splitter) Container() *Rectangle {
+	return s.container
+}
+
+// Panes returns the panes, in the order they are laid out
+func (s *Splitter) Panes() []*Rectangle {
+	return s.panes
+}
+
+// Bars returns the bars between the panes
+func (s *Splitter) Bars() []*Rectangle {
+	return s.bars
+}
+
+// Ratios returns the share of each pane, adding up to one
+func (s *Splitter) Ratios() []float64 {
+	result := make([]float64, len(s.ratios))
+	copy(result, s.ratios)
+	return result
+}
+
+// SetRatios changes the share of each pane (see WithSplitRatios), laying them out again
+func (s *Splitter) SetRatios(ratios ...float64) error {
+	previous := s.ratios
+	s.ratios = ratios
+	if err := s.normalize(); err != nil {
+		s.ratios = previous
+		return err
+	}
+	s.Layout()
+	return nil
+}
+
+// Layout places the panes and the bars, e.g. after the container was resized
+func (s *Splitter) Layout() {
+	s.resizePanes()
+	s.container.layout()
+}
+
+// SetActiveBar selects the bar moved by HandleKey. Dragging a bar selects it too.
+func (s *Splitter) SetActiveBar(index int) {
+	if index >= 0 && index < len(s.bars) {
+		s.active = index
+	}
+}
+
+// MoveBar moves the bar by the number of cells (negative towards the top or left), as far as the minimum sizes of the panes allow, returning false if it didn't move.
+func (s *Splitter) MoveBar(index, cells int) bool {
+	if index < 0 || index >= len(s.bars) || s.container.Invalid() {
+		return false
+	}
+	sizes := s.paneSizes()
+	before, _ := s.panes[index].minAlong(s.vertical())
+	after, _ := s.panes[index+1].minAlong(s.vertical())
+	cells = term.Max(cells, before-sizes[index])
+	cells = term.Min(cells, sizes[index+1]-after)
+	if cells == 0 {
+		return false
+	}
+	sizes[index] += cells
+	sizes[index+1] -= cells
+
+	total := 0
+	for _, size := range sizes {
+		total += size
+	}
+	if total == 0 {
+		return false
+	}
+	for idx, size := range sizes {
+		s.ratios[idx] = float64(size) / float64(total)
+	}
+	s.Layout()
+	return true
+}
+
+// HandleDrag moves the bar where the drag has started (see mouse.DragRecognizer), following the pointer, returning true if the event was consumed
+func (s *Splitter) HandleDrag(ev *mouse.DragEvent) bool {
+	if ev.Phase() == mouse.DragStart {
+		s.dragged = -1
+		column, row := ev.Start()
+		for idx, bar := range s.bars {
+			if bar.Contains(column, row) {
+				s.dragged, s.active = idx, idx
+				break
+			}
+		}
+	}
+	if s.dragged < 0 {
+		return false
+	}
+	column, row := ev.Position()
+	bar := s.bars[s.dragged].Bounds()
+	if s.vertical() {
+		s.MoveBar(s.dragged, row-bar.Top)
+	} else {
+		s.MoveBar(s.dragged, column-bar.Left)
+	}
+	if ev.Phase() == mouse.DragEnd {
+		s.dragged = -1
+		s.split()
+	}
+	return true
+}
+
+// HandleKey moves the active bar with the arrow keys along the orientation (Left / Right for panes side by side, Up / Down for stacked ones), returning true if the event was consumed.
+// The application decides when the splitter has the keyboard focus.
+func (s *Splitter) HandleKey(ev term.KeyEvent) bool {
+	cells := 0
+	switch ev.Key() {
+	case key.Left:
+		if !s.vertical() {
+			cells = -s.step
+		}
+	case key.Right:
+		if !s.vertical() {
+			cells = s.step
+		}
+	case key.Up:
+		if s.vertical() {
+			cells = -s.step
+		}
+	case key.Down:
+		if s.vertical() {
+			cells = s.step
+		}
+	}
+	if cells == 0 {
+		return false
+	}
+	if s.MoveBar(s.active, cells) {
+		s.split()
+	}
+	return true
+}
+
+// vertical returns true if the panes are stacked one above the other
+func (s *Splitter) vertical() bool {
+	return s.container.HasRows()
+}
+
+// normalize checks the ratios, making them add up to one
+func (s *Splitter) normalize() error {
+	if len(s.ratios) == 0 {
+		s.ratios = make([]float64, len(s.panes))
+		for idx := range s.ratios {
+			s.ratios[idx] = 1
+		}
+	}
+	if len(s.ratios) != len(s.panes) {
+		return errors.New("splitter needs one ratio for each pane")
+	}
+	total := 0.0
+	for _, ratio := range s.ratios {
+		if ratio < 0 {
+			return errors.New("splitter ratios can't be negative")
+		}
+		total += ratio
+	}
+	if total == 0 {
+		return errors.New("splitter ratios can't be all zero")
+	}
+	ratios := make([]float64, len(s.ratios))
+	for idx, ratio := range s.ratios {
+		ratios[idx] = ratio / total
+	}
+	s.ratios = ratios
+	return nil
+}
+
+// paneSizes returns the cells along the orientation of each pane, according to the ratios
+func (s *Splitter) paneSizes() []int {
+	along := s.container.Size().Columns
+	if s.vertical() {
+		along = s.container.Size().Rows
+	}
+	available := term.Max(along-len(s.bars), 0)
+
+	sizes := make([]int, len(s.panes))
+	share, previous := 0.0, 0
+	for idx, ratio := range s.ratios {
+		share += ratio
+		end := int(share*float64(available) + 0.5) // rounding the edges, instead of the sizes, keeps the total exact
+		if idx == len(s.ratios)-1 {
+			end = available
+		}
+		sizes[idx] = end - previous
+		previous = end
+	}
+	return sizes
+}
+
+// resizePanes sets the constraints of the panes along the orientation, for the next layout
+func (s *Splitter) resizePanes() {
+	if s.container.Invalid() {
+		return
+	}
+	for idx, size := range s.paneSizes() {
+		constraint := Cells(size)
+		if s.vertical() {
+			s.panes[idx].height = &constraint
+		} else {
+			s.panes[idx].width = &constraint
+		}
+	}
+}
+
+// split calls the hook, if any
+func (s *Splitter) split() {
+	if s.onSplit != nil {
+		s.onSplit(s, s.Ratios())
+	}
+}
@@ -0,0 +1,99 @@
+package geom_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/geom"
+	"github.com/badu/term/key"
+	"github.com/badu/term/mouse"
+	"github.com/badu/term/style"
+)
+
+func TestSplitter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	newPane := func() *geom.Rectangle {
+		pane, err := geom.NewRectangle(ctx, testAcquisitionChan(), geom.WithMinSize(term.NewSize(3, 1)))
+		if err != nil {
+			t.Fatalf("error : %v", err)
+		}
+		return pane
+	}
+	columns := func(r *geom.Rectangle) [2]int {
+		bounds := r.Bounds()
+		return [2]int{bounds.Left, bounds.Right}
+	}
+
+	outer, _ := geom.NewRectangle(ctx, testAcquisitionChan(), geom.WithTopCorner(0, 0), geom.WithBottomCorner(20, 9), geom.WithOrientation(style.Horizontal))
+	container, _ := geom.NewRectangle(ctx, testAcquisitionChan(), geom.WithMinSize(term.NewSize(1, 1)), geom.WithOrientation(style.Horizontal))
+	outer.SetChildren(container)
+
+	var saved []float64
+	left, right := newPane(), newPane()
+	splitter, err := geom.NewSplitter(ctx, container, []*geom.Rectangle{left, right}, geom.WithSplitStep(2), geom.WithOnSplit(func(s *geom.Splitter, ratios []float64) { saved = ratios }))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	if _, err := geom.NewSplitter(ctx, container, []*geom.Rectangle{left}); err == nil {
+		t.Errorf("error : a single pane should be refused")
+	}
+	bar := splitter.Bars()[0]
+	expect := func(name string, l, b, r [2]int) {
+		t.Helper()
+		if columns(left) != l || columns(bar) != b || columns(right) != r {
+			t.Errorf("error : %s : expecting %v %v %v, got %v %v %v", name, l, b, r, columns(left), columns(bar), columns(right))
+		}
+	}
+	expect("equal shares", [2]int{0, 9}, [2]int{10, 10}, [2]int{11, 20})
+
+	// dragging the bar, through the recognizer
+	recognizer := mouse.NewDragRecognizer(ctx)
+	for _, ev := range []term.MouseEvent{
+		mouse.NewEvent(10, 4, mouse.Button1, key.ModNone),
+		mouse.NewEvent(14, 4, mouse.Button1, key.ModNone),
+		mouse.NewEvent(14, 4, mouse.ButtonNone, key.ModNone),
+	} {
+		recognizer.MouseListen() <- ev
+	}
+	for _, phase := range []mouse.DragPhase{mouse.DragStart, mouse.DragEnd} {
+		drag := <-recognizer.DragListen()
+		if drag.Phase() != phase || !splitter.HandleDrag(drag) {
+			t.Errorf("error : drag %s should be consumed", phase)
+		}
+	}
+	expect("dragged", [2]int{0, 13}, [2]int{14, 14}, [2]int{15, 20})
+	if len(saved) != 2 || math.Abs(saved[0]-0.7) > 1e-9 {
+		t.Errorf("error : expecting the ratios to be saved as 0.7, 0.3, got %v", saved)
+	}
+
+	// keyboard, along the orientation only
+	if !splitter.HandleKey(key.NewEvent(key.Left, 0, key.ModNone)) {
+		t.Errorf("error : left arrow should be consumed")
+	}
+	if splitter.HandleKey(key.NewEvent(key.Up, 0, key.ModNone)) {
+		t.Errorf("error : up arrow should be ignored by panes side by side")
+	}
+	expect("keyboard", [2]int{0, 11}, [2]int{12, 12}, [2]int{13, 20})
+
+	// the minimum size of the pane stops the bar
+	splitter.MoveBar(0, 100)
+	expect("clamped", [2]int{0, 16}, [2]int{17, 17}, [2]int{18, 20})
+
+	// resizing keeps the ratios
+	if err := splitter.SetRatios(1, 1); err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	sidebar, _ := geom.NewRectangle(ctx, testAcquisitionChan(), geom.WithWidthConstraint(geom.Cells(5)))
+	outer.SetChildren(sidebar, container)
+	expect("resized", [2]int{5, 12}, [2]int{13, 13}, [2]int{14, 20})
+
+	if err := splitter.SetRatios(1, -1); err == nil {
+		t.Errorf("error : negative ratios should be refused")
+	}
+	if ratios := splitter.Ratios(); ratios[0] != 0.5 {
+		t.Errorf("error : refused ratios should be ignored, got %v", ratios)
+	}
+}
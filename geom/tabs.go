@@ -0,0 +1,241 @@
+package geom
+
+import (
+	"context"
+	"errors"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/key"
+	"github.com/badu/term/style"
+)
+
+// TabHook is called with the index of the tab which was switched to
+type TabHook func(t *Tabs, index int)
+
+// TabsOption configures the Tabs
+type TabsOption func(t *Tabs)
+
+// WithActiveTab sets the tab shown first. Default is the first one.
+func WithActiveTab(index int) TabsOption {
+	return func(t *Tabs) {
+		t.active = index
+	}
+}
+
+// WithTabStyle sets the style of the titles and of the empty part of the bar. Default is the terminal colors.
+func WithTabStyle(st style.Style) TabsOption {
+	return func(t *Tabs) {
+		t.st = st
+	}
+}
+
+// WithActiveTabStyle sets the style of the title of the active tab. Default is reversed terminal colors.
+func WithActiveTabStyle(st style.Style) TabsOption {
+	return func(t *Tabs) {
+		t.activeSt = st
+	}
+}
+
+// WithOnTabChange sets the hook called when another tab is shown, e.g. for drawing the bar and the pane again
+func WithOnTabChange(hook TabHook) TabsOption {
+	return func(t *Tabs) {
+		t.onChange = hook
+	}
+}
+
+// tabSpan is the part of the bar where a title is drawn
+type tabSpan struct {
+	index int // of the tab
+	left  int // the first column, relative to the bar
+	right int // the column after the title
+}
+
+// Tabs shows one pane at a time inside a container rectangle, below a bar of one row listing the titles of the panes.
+// A tab is switched to by clicking its title (the bar has to be added to the Page, see Bar) or with the keyboard (see HandleKey).
+// The pane which is left is hidden, releasing its pixels, before the one switched to is laid out and shown, acquiring them.
+type Tabs struct {
+	container *Rectangle   // holds the bar and the active pane, as its children
+	bar       *Rectangle   // the row of titles, on top
+	titles    []string     //
+	panes     []*Rectangle //
+	active    int          // set by WithActiveTab
+	first     int          // the first title drawn, when they don't fit the bar
+	st        style.Style  // set by WithTabStyle
+	activeSt  style.Style  // set by WithActiveTabStyle
+	onChange  TabHook      // set by WithOnTabChange
+}
+
+// NewTabs creates the bar and makes it and the active pane the children of the container, hiding the other panes. The bar acquires its pixels like the container does.
+// The container has to be vertical (the default), so the bar is on top of the panes.
+func NewTabs(ctx context.Context, container *Rectangle, titles []string, panes []*Rectangle, opts ...TabsOption) (*Tabs, error) {
+	if len(panes) == 0 {
+		return nil, errors.New("tabs need at least one pane")
+	}
+	if len(titles) != len(panes) {
+		return nil, errors.New("tabs need one title for each pane")
+	}
+	if !container.HasRows() {
+		return nil, errors.New("tabs container has to be vertical")
+	}
+	res := &Tabs{
+		container: container,
+		titles:    titles,
+		panes:     panes,
+		st:        style.Style{Fg: color.Default, Bg: color.Default},
+		activeSt:  style.Style{Fg: color.Default, Bg: color.Default, Attrs: style.Reverse},
+	}
+	for _, o := range opts {
+		o(res)
+	}
+	if res.active < 0 || res.active >= len(panes) {
+		return nil, errors.New("tabs active index is out of range")
+	}
+
+	bar, err := NewRectangle(ctx, WithAcquisitionChan(container.pixelAskCh), WithReleasingChan(container.pixelReleaseCh), WithHeightConstraint(Cells(1)),
+		WithOnClick(func(r *Rectangle, ev term.MouseEvent) {
+			column, _ := ev.Position()
+			if index := res.TabAt(column); index >= 0 {
+				res.Select(index)
+			}
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	res.bar = bar
+	for idx, pane := range panes {
+		if idx != res.active {
+			setVisible(pane, false)
+		}
+	}
+	container.SetChildren(bar, panes[res.active])
+	return res, nil
+}
+
+// Container returns the rectangle holding the bar and the active pane
+func (t *Tabs) Container() *Rectangle {
+	return t.container
+}
+
+// Bar returns the row of titles, which has to be added to the Page (AddRectangles) for switching the tabs with the mouse
+func (t *Tabs) Bar() *Rectangle {
+	return t.bar
+}
+
+// Panes returns the panes, in the order of their titles
+func (t *Tabs) Panes() []*Rectangle {
+	return t.panes
+}
+
+// Active returns the index of the tab shown
+func (t *Tabs) Active() int {
+	return t.active
+}
+
+// Select shows the pane at the index, hiding the one which was shown, returning false if the index is out of range or the tab is already shown
+func (t *Tabs) Select(index int) bool {
+	if index < 0 || index >= len(t.panes) || index == t.active {
+		return false
+	}
+	setVisible(t.panes[t.active], false) // releasing first, so the pixels are free for the new pane
+	t.active = index
+	t.container.SetChildren(t.bar, t.panes[index])
+	setVisible(t.panes[index], true)
+	if t.onChange != nil {
+		t.onChange(t, index)
+	}
+	return true
+}
+
+// HandleKey switches the tabs with the keyboard, returning true if the event was consumed : Left and Right (while the bar has the focus) or Ctrl+PgUp and Ctrl+PgDn (from anywhere) move to the previous and next tab, wrapping around,
+// Home and End to the first and the last one, and Alt+1 to Alt+9 to the tab with that number.
+// The application decides when the bar has the keyboard focus.
+func (t *Tabs) HandleKey(ev term.KeyEvent) bool {
+	switch {
+	case ev.Key() == key.Left, ev.Key() == key.PgUp && ev.Modifiers()&key.ModCtrl != 0:
+		return t.Select((t.active + len(t.panes) - 1) % len(t.panes))
+	case ev.Key() == key.Right, ev.Key() == key.PgDn && ev.Modifiers()&key.ModCtrl != 0:
+		return t.Select((t.active + 1) % len(t.panes))
+	case ev.Key() == key.Home:
+		return t.Select(0)
+	case ev.Key() == key.End:
+		return t.Select(len(t.panes) - 1)
+	case ev.Key() == key.Rune && ev.Modifiers()&key.ModAlt != 0 && ev.Rune() >= '1' && ev.Rune() <= '9':
+		return t.Select(int(ev.Rune() - '1'))
+	}
+	return false
+}
+
+// TabAt returns the index of the tab whose title is drawn at the column of the bar (relative to it), or -1 if there is none
+func (t *Tabs) TabAt(column int) int {
+	for _, span := range t.spans(t.bar.Width()) {
+		if column >= span.left && column < span.right {
+			return span.index
+		}
+	}
+	return -1
+}
+
+// DrawBar fills the grid (indexed [column][row], usually the pixels of the bar) with the titles, like the charts do. When they don't fit, the titles are scrolled so the active one is drawn.
+func (t *Tabs) DrawBar(grid [][]term.Pixel) {
+	if len(grid) == 0 || len(grid[0]) == 0 {
+		return
+	}
+	for column := range grid {
+		for row := range grid[column] {
+			grid[column][row].SetAll(t.st.Bg, t.st.Fg, t.st.Attrs, ' ', nil)
+		}
+	}
+	for _, span := range t.spans(len(grid)) {
+		st := t.st
+		if span.index == t.active {
+			st = t.activeSt
+		}
+		title := []rune(" " + t.titles[span.index] + " ")
+		for idx, column := 0, span.left; column < span.right && column < len(grid); idx, column = idx+1, column+1 {
+			grid[column][0].SetAll(st.Bg, st.Fg, st.Attrs, title[idx], nil)
+		}
+	}
+}
+
+// spans returns where the titles are drawn on a bar that wide, each one padded by a space on both sides, scrolling them so the active one is visible
+func (t *Tabs) spans(columns int) []tabSpan {
+	widths := make([]int, len(t.titles))
+	for idx, title := range t.titles {
+		widths[idx] = len([]rune(title)) + 2
+	}
+	if t.first > t.active {
+		t.first = t.active
+	}
+	for {
+		used := 0
+		for idx := t.first; idx <= t.active; idx++ {
+			used += widths[idx]
+		}
+		if used <= columns || t.first == t.active {
+			break
+		}
+		t.first++
+	}
+
+	var result []tabSpan
+	left := 0
+	for idx := t.first; idx < len(t.titles) && left < columns; idx++ {
+		result = append(result, tabSpan{index: idx, left: left, right: term.Min(left+widths[idx], columns)})
+		left += widths[idx]
+	}
+	return result
+}
+
+// setVisible shows or hides the rectangle and its children, which acquire or release their pixels
+func setVisible(r *Rectangle, visible bool) {
+	if visible {
+		r.Show()
+	} else {
+		r.Hide()
+	}
+	for _, child := range r.children {
+		setVisible(child, visible)
+	}
+}
@@ -0,0 +1,147 @@
+package geom_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/geom"
+	"github.com/badu/term/key"
+	"github.com/badu/term/mouse"
+	"github.com/badu/term/style"
+)
+
+// pixelCounter acts as a page, counting the pixels acquired and not released
+type pixelCounter struct {
+	ask     chan term.Position
+	release chan term.Position
+	counted chan chan int
+}
+
+func newPixelCounter(ctx context.Context) *pixelCounter {
+	res := &pixelCounter{ask: make(chan term.Position), release: make(chan term.Position), counted: make(chan chan int)}
+	go func() {
+		owned := make(map[int]bool)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case pos := <-res.ask:
+				owned[term.Hash(pos.Column, pos.Row)] = true
+			case pos := <-res.release:
+				delete(owned, term.Hash(pos.Column, pos.Row))
+			case reply := <-res.counted:
+				reply <- len(owned)
+			}
+		}
+	}()
+	return res
+}
+
+// count returns the number of pixels owned, after the positions sent before were received
+func (c *pixelCounter) count() int {
+	reply := make(chan int)
+	c.counted <- reply
+	return <-reply
+}
+
+func TestTabs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fakeEngine := NewFakeEngine(t, 20, 10)
+	fakeEngine.Start(ctx)
+	page, err := geom.NewPage(ctx, geom.WithEngine(fakeEngine))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+
+	counter := newPixelCounter(ctx)
+	channels := []geom.RectangleOption{geom.WithAcquisitionChan(counter.ask), geom.WithReleasingChan(counter.release)}
+	container, _ := geom.NewRectangle(ctx, append(channels, geom.WithTopCorner(0, 0), geom.WithBottomCorner(19, 9))...)
+	newPane := func() *geom.Rectangle {
+		pane, err := geom.NewRectangle(ctx, append(channels, geom.WithMinSize(term.NewSize(1, 1)))...)
+		if err != nil {
+			t.Fatalf("error : %v", err)
+		}
+		return pane
+	}
+	first, second, third := newPane(), newPane(), newPane()
+	inner, _ := geom.NewRectangle(ctx, append(channels, geom.WithHeightConstraint(geom.Cells(2)))...)
+	second.SetChildren(inner)
+	panes := []*geom.Rectangle{first, second, third}
+
+	if _, err := geom.NewTabs(ctx, container, []string{"one"}, panes); err == nil {
+		t.Errorf("error : missing titles should be refused")
+	}
+	changes := make(chan int, 8)
+	tabs, err := geom.NewTabs(ctx, container, []string{"one", "two", "three"}, panes, geom.WithOnTabChange(func(t *geom.Tabs, index int) { changes <- index }))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	page.AddRectangles(tabs.Bar())
+	if bounds := tabs.Bar().Bounds(); bounds.Top != 0 || bounds.Bottom != 0 || bounds.Right != 19 {
+		t.Errorf("error : the bar should be the first row, got %v", bounds)
+	}
+	if bounds := first.Bounds(); bounds.Top != 1 || bounds.Bottom != 9 {
+		t.Errorf("error : the pane should fill the rest of the container, got %v", bounds)
+	}
+	if second.Visible() || third.Visible() {
+		t.Errorf("error : the other panes should be hidden")
+	}
+
+	grid, _ := geom.NewPixelGrid(&term.Size{Columns: 20, Rows: 1})
+	drawn := func() string {
+		tabs.DrawBar(grid)
+		var sb strings.Builder
+		for column := range grid {
+			sb.WriteRune(grid[column][0].Rune())
+		}
+		return sb.String()
+	}
+	if bar := drawn(); bar != " one  two  three    " {
+		t.Errorf("error : unexpected bar %q", bar)
+	}
+	if _, _, attrs := grid[1][0].Style(); attrs&style.Reverse == 0 {
+		t.Errorf("error : the active title should be reversed")
+	}
+
+	// clicking the second title shows its pane, with its child, acquiring their pixels
+	page.MouseListen() <- mouse.NewEvent(6, 0, mouse.Button1, key.ModNone)
+	if index := <-changes; index != 1 || tabs.Active() != 1 || !second.Visible() || !inner.Visible() || first.Visible() {
+		t.Fatalf("error : the click should have switched to the second tab")
+	}
+	if bounds := inner.Bounds(); bounds.Top != 1 || bounds.Bottom != 2 {
+		t.Errorf("error : the child of the pane should be laid out, got %v", bounds)
+	}
+	if counter.count() != 20*9 {
+		t.Errorf("error : expecting the pane to own %d pixels, got %d", 20*9, counter.count())
+	}
+
+	// keyboard
+	if !tabs.HandleKey(key.NewEvent(key.Right, 0, key.ModNone)) || tabs.Active() != 2 {
+		t.Errorf("error : right arrow should switch to the third tab")
+	}
+	if !tabs.HandleKey(key.NewEvent(key.Right, 0, key.ModNone)) || tabs.Active() != 0 {
+		t.Errorf("error : right arrow should wrap around to the first tab")
+	}
+	if !tabs.HandleKey(key.NewEvent(key.Rune, '3', key.ModAlt)) || tabs.Active() != 2 {
+		t.Errorf("error : Alt+3 should switch to the third tab")
+	}
+	if tabs.HandleKey(key.NewEvent(key.Rune, '3', key.ModAlt)) || tabs.HandleKey(key.NewEvent(key.Up, 0, key.ModNone)) {
+		t.Errorf("error : the active tab and other keys should be ignored")
+	}
+	if second.Visible() || inner.Visible() || counter.count() != 20*9 {
+		t.Errorf("error : the second pane should have released its pixels, %d owned", counter.count())
+	}
+	if len(changes) != 3 {
+		t.Errorf("error : expecting three more changes, got %d", len(changes))
+	}
+
+	// the titles scroll when the bar is too narrow
+	narrow, _ := geom.NewPixelGrid(&term.Size{Columns: 8, Rows: 1})
+	grid = narrow
+	if bar := drawn(); bar != " three  " {
+		t.Errorf("error : unexpected bar %q", bar)
+	}
+}
@@ -0,0 +1,324 @@
+package geom
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+const (
+	defaultToastDuration  = 4 * time.Second        // how long a toast is shown, including its animations
+	defaultToastAnimation = 250 * time.Millisecond // how long a toast slides in and out
+	defaultToastWidth     = 40                     // columns of the widest toast, including the padding
+	defaultToastLayer     = 100                    // the id of the layer, see term.Layerer
+	toastFrame            = 33 * time.Millisecond  // the interval of the ticks, while toasts are shown
+)
+
+// Corner tells where the toasts are stacked
+type Corner int
+
+const (
+	TopRight    Corner = iota // the default
+	TopLeft                   //
+	BottomRight               //
+	BottomLeft                //
+)
+
+// ToastsOption configures the Toasts
+type ToastsOption func(t *Toasts)
+
+// WithToastCorner sets the corner of the screen where the toasts are stacked. Default is TopRight.
+func WithToastCorner(corner Corner) ToastsOption {
+	return func(t *Toasts) {
+		t.corner = corner
+	}
+}
+
+// WithToastDuration sets how long the toasts are shown. Default is four seconds.
+func WithToastDuration(duration time.Duration) ToastsOption {
+	return func(t *Toasts) {
+		if duration > 0 {
+			t.duration = duration
+		}
+	}
+}
+
+// WithToastAnimation sets how long the toasts slide in and out. Zero shows and hides them at once. Default is a quarter of a second.
+func WithToastAnimation(duration time.Duration) ToastsOption {
+	return func(t *Toasts) {
+		if duration >= 0 {
+			t.animation = duration
+		}
+	}
+}
+
+// WithToastStyle sets the style of the toasts. Default is reversed terminal colors.
+func WithToastStyle(st style.Style) ToastsOption {
+	return func(t *Toasts) {
+		t.st = st
+	}
+}
+
+// WithToastWidth sets the columns of the widest toast, the longer messages being wrapped. Default is forty.
+func WithToastWidth(columns int) ToastsOption {
+	return func(t *Toasts) {
+		if columns > 2 {
+			t.width = columns
+		}
+	}
+}
+
+// WithToastLayer sets the id of the layer where the toasts are drawn (see term.Layerer), so they are above or below the other layers. Default is one hundred.
+func WithToastLayer(id int) ToastsOption {
+	return func(t *Toasts) {
+		t.layer = id
+	}
+}
+
+// toast is a message being shown
+type toast struct {
+	lines    []string      // the message, wrapped
+	st       style.Style   //
+	shown    time.Time     //
+	duration time.Duration //
+	row      float64       // the distance from the corner to its first row, moving towards the place of the toast when the ones before it expire
+}
+
+// toastTicker receives the ticks while toasts are shown, dying when the last one expires
+type toastTicker struct {
+	ch   chan term.TickEvent //
+	died chan struct{}       //
+}
+
+// DyingChan implements term.Death interface
+func (k *toastTicker) DyingChan() chan struct{} {
+	return k.died
+}
+
+// TickListen implements term.TimerListener interface
+func (k *toastTicker) TickListen() chan term.TickEvent {
+	return k.ch
+}
+
+// TickInterval implements term.TimerListener interface
+func (k *toastTicker) TickInterval() time.Duration {
+	return toastFrame
+}
+
+// Toasts stacks short messages in a corner of the screen, each one sliding in, staying for a while, then sliding out, while the ones after it move into its place.
+// The toasts are drawn in a layer of the engine (see term.Layerer), so the pixels of the pages beneath keep their owners and are drawn again when the toasts are gone.
+// They are animated using the ticks of the engine timer (see term.TimerDispatcher), which run only while toasts are shown.
+type Toasts struct {
+	sync.Mutex                 //
+	ctx        context.Context //
+	engine     term.Engine     //
+	layerer    term.Layerer    // the engine, drawing the toasts above the pages
+	toasts     []*toast        // the oldest one is the nearest to the corner
+	ticker     *toastTicker    // nil while no toasts are shown
+	corner     Corner          // set by WithToastCorner
+	duration   time.Duration   // set by WithToastDuration
+	animation  time.Duration   // set by WithToastAnimation
+	st         style.Style     // set by WithToastStyle
+	width      int             // set by WithToastWidth
+	layer      int             // set by WithToastLayer
+}
+
+// NewToasts returns the toasts manager of the engine, which has to implement term.Layerer
+func NewToasts(ctx context.Context, engine term.Engine, opts ...ToastsOption) (*Toasts, error) {
+	layerer, ok := engine.(term.Layerer)
+	if !ok {
+		return nil, errors.New("toasts require an engine which implements term.Layerer")
+	}
+	res := &Toasts{
+		ctx:       ctx,
+		engine:    engine,
+		layerer:   layerer,
+		corner:    TopRight,
+		duration:  defaultToastDuration,
+		animation: defaultToastAnimation,
+		st:        style.Style{Fg: color.Default, Bg: color.Default, Attrs: style.Reverse},
+		width:     defaultToastWidth,
+		layer:     defaultToastLayer,
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+	return res, nil
+}
+
+// Show adds the message to the stack, using the default style and duration
+func (t *Toasts) Show(message string) {
+	t.ShowStyled(message, t.st, t.duration)
+}
+
+// ShowStyled adds the message to the stack, shown for that long in that style, e.g. red for the errors
+func (t *Toasts) ShowStyled(message string, st style.Style, duration time.Duration) {
+	t.Lock()
+	defer t.Unlock()
+
+	item := &toast{lines: wrapToast(message, t.width-2), st: st, shown: time.Now(), duration: duration}
+	item.row = float64(t.stackedRows())
+	t.toasts = append(t.toasts, item)
+	if t.ticker == nil {
+		t.ticker = &toastTicker{ch: make(chan term.TickEvent), died: make(chan struct{})}
+		go t.animate(t.ticker)
+		t.engine.TimerDispatcher().Register(t.ticker)
+	}
+	t.draw(item.shown)
+}
+
+// Len returns the number of toasts shown
+func (t *Toasts) Len() int {
+	t.Lock()
+	defer t.Unlock()
+
+	return len(t.toasts)
+}
+
+// Dismiss removes all the toasts at once, drawing again the pixels they covered
+func (t *Toasts) Dismiss() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.toasts = nil
+	t.draw(time.Now())
+}
+
+// animate draws a frame on each tick, until the last toast has expired
+func (t *Toasts) animate(ticker *toastTicker) {
+	for {
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-ticker.died:
+			return
+		case ev := <-ticker.ch:
+			t.Lock()
+			t.draw(ev.When())
+			t.Unlock()
+		}
+	}
+}
+
+// draw removes the expired toasts, moves the others towards their places and draws them in the layer, stopping the ticks when none is left - locked inside caller function
+func (t *Toasts) draw(now time.Time) {
+	alive := t.toasts[:0]
+	for _, item := range t.toasts {
+		if now.Sub(item.shown) < item.duration {
+			alive = append(alive, item)
+		}
+	}
+	t.toasts = alive
+	if len(t.toasts) == 0 {
+		t.layerer.RemoveLayer(t.layer)
+		if t.ticker != nil {
+			close(t.ticker.died)
+			t.ticker = nil
+		}
+		return
+	}
+
+	size := t.engine.Size()
+	var pixels []term.PixelGetter
+	place := 0
+	for _, item := range t.toasts {
+		if item.row > float64(place) { // reflowing, one row per frame at most
+			item.row = math.Max(float64(place), item.row-1)
+		}
+		columns := 0
+		for _, line := range item.lines {
+			columns = term.Max(columns, len([]rune(line))+2)
+		}
+		hidden := int(math.Round(float64(columns) * (1 - t.slide(now, item))))
+		for idx, line := range item.lines {
+			row := int(item.row) + idx
+			if t.corner == BottomRight || t.corner == BottomLeft {
+				row = size.Rows - 1 - (int(item.row) + len(item.lines) - 1 - idx)
+			}
+			runes := []rune(" " + line + strings.Repeat(" ", columns-len([]rune(line))-1))
+			for column, r := range runes {
+				screenColumn := column - hidden // sliding in from the left edge
+				if t.corner == TopRight || t.corner == BottomRight {
+					screenColumn = size.Columns - columns + column + hidden // sliding in from the right edge
+				}
+				if screenColumn < 0 || screenColumn >= size.Columns || row < 0 || row >= size.Rows {
+					continue
+				}
+				pixels = append(pixels, &toastPixel{hash: term.Hash(screenColumn, row), r: r, st: item.st})
+			}
+		}
+		place += len(item.lines)
+	}
+	t.layerer.SetLayer(t.layer, pixels)
+}
+
+// slide returns how much of the toast is shown, from zero to one, while it slides in and out
+func (t *Toasts) slide(now time.Time, item *toast) float64 {
+	if t.animation <= 0 {
+		return 1
+	}
+	age, left := now.Sub(item.shown), item.duration-now.Sub(item.shown)
+	progress := 1.0
+	switch {
+	case age < t.animation:
+		progress = float64(age) / float64(t.animation)
+	case left < t.animation:
+		progress = float64(left) / float64(t.animation)
+	}
+	progress = math.Max(0, math.Min(progress, 1))
+	return 1 - math.Pow(1-progress, 3) // easing out, so the toasts slow down when they arrive
+}
+
+// stackedRows returns the rows of the toasts shown - locked inside caller function
+func (t *Toasts) stackedRows() int {
+	result := 0
+	for _, item := range t.toasts {
+		result += len(item.lines)
+	}
+	return result
+}
+
+// wrapToast splits the message into lines of that many runes at most, at the spaces when possible
+func wrapToast(message string, columns int) []string {
+	var result []string
+	for _, paragraph := range strings.Split(message, "\n") {
+		runes := []rune(paragraph)
+		for len(runes) > columns {
+			cut := columns
+			for idx := columns; idx > 0; idx-- {
+				if runes[idx] == ' ' {
+					cut = idx
+					break
+				}
+			}
+			result = append(result, strings.TrimRight(string(runes[:cut]), " "))
+			runes = []rune(strings.TrimLeft(string(runes[cut:]), " "))
+		}
+		result = append(result, string(runes))
+	}
+	return result
+}
+
+// toastPixel is a term.PixelGetter implementation, for the pixels of the layer, which are not active
+type toastPixel struct {
+	hash int         //
+	r    rune        //
+	st   style.Style //
+}
+
+func (p *toastPixel) DrawCh() chan term.PixelGetter { return nil }
+func (p *toastPixel) Style() (color.Color, color.Color, style.Mask) {
+	return p.st.Fg, p.st.Bg, p.st.Attrs
+}
+func (p *toastPixel) HasUnicode() bool       { return false }
+func (p *toastPixel) Unicode() *term.Unicode { return nil }
+func (p *toastPixel) Rune() rune             { return p.r }
+func (p *toastPixel) Width() int             { return 1 }
+func (p *toastPixel) PositionHash() int      { return p.hash }
@@ -0,0 +1,138 @@
+package geom_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/geom"
+	"github.com/badu/term/style"
+)
+
+// layeredEngine records the layers and lets the test send the ticks
+type layeredEngine struct {
+	*FakeEngine
+	sync.Mutex
+	layer     []term.PixelGetter
+	listeners chan term.TimerListener
+}
+
+func (e *layeredEngine) SetLayer(id int, pixels []term.PixelGetter) {
+	e.Lock()
+	defer e.Unlock()
+	e.layer = pixels
+}
+
+func (e *layeredEngine) RemoveLayer(id int) {
+	e.SetLayer(id, nil)
+}
+
+func (e *layeredEngine) TimerDispatcher() term.TimerDispatcher {
+	return e
+}
+
+func (e *layeredEngine) Register(r term.TimerListener) {
+	e.listeners <- r
+}
+
+// rows returns the runes of the layer, row by row, the cells without pixels being dots
+func (e *layeredEngine) rows() []string {
+	e.Lock()
+	defer e.Unlock()
+	cells := make(map[int]rune)
+	for _, pixel := range e.layer {
+		cells[pixel.PositionHash()] = pixel.Rune()
+	}
+	var result []string
+	for row := 0; row < e.Rows; row++ {
+		var sb strings.Builder
+		for column := 0; column < e.Columns; column++ {
+			if r, ok := cells[term.Hash(column, row)]; ok {
+				sb.WriteRune(r)
+			} else {
+				sb.WriteRune('.')
+			}
+		}
+		result = append(result, sb.String())
+	}
+	return result
+}
+
+type fakeTick struct {
+	when time.Time
+}
+
+func (e *fakeTick) When() time.Time        { return e.when }
+func (e *fakeTick) Elapsed() time.Duration { return 0 }
+
+func TestToasts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	engine := &layeredEngine{FakeEngine: NewFakeEngine(t, 12, 4), listeners: make(chan term.TimerListener, 2)}
+	if _, err := geom.NewToasts(ctx, engine.FakeEngine); err == nil {
+		t.Errorf("error : an engine without layers should be refused")
+	}
+	toasts, err := geom.NewToasts(ctx, engine, geom.WithToastWidth(10), geom.WithToastDuration(time.Second), geom.WithToastAnimation(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("error : %v", err)
+	}
+	expect := func(name string, lines ...string) {
+		t.Helper()
+		if got := engine.rows(); strings.Join(got, "|") != strings.Join(lines, "|") {
+			t.Errorf("error : %s : expecting %q, got %q", name, lines, got)
+		}
+	}
+
+	started := time.Now()
+	toasts.Show("saved")
+	ticker := <-engine.listeners
+	toasts.ShowStyled("not sent twice", style.Style{Fg: color.White, Bg: color.Red}, 2*time.Second)
+	tick := func(after time.Duration) {
+		ticker.TickListen() <- &fakeTick{when: started.Add(after)}
+		ticker.TickListen() <- &fakeTick{when: started.Add(after)} // the second tick is received once the first one was drawn
+	}
+
+	tick(50 * time.Millisecond) // sliding in
+	if got := engine.rows()[0]; got != "...... saved" {
+		t.Errorf("error : the toast should be sliding in, got %q", got)
+	}
+	tick(500 * time.Millisecond)
+	expect("shown", "..... saved ", ".. not sent ", ".. twice    ", "............")
+
+	tick(1200 * time.Millisecond) // the first one has expired, the second one moves into its place
+	expect("reflowed", ".. not sent ", ".. twice    ", "............", "............")
+	if toasts.Len() != 1 {
+		t.Errorf("error : expecting one toast, got %d", toasts.Len())
+	}
+	var st []color.Color
+	engine.Lock()
+	for _, pixel := range engine.layer {
+		fg, bg, _ := pixel.Style()
+		st = append(st, fg, bg)
+	}
+	engine.Unlock()
+	if len(st) == 0 || st[0] != color.White || st[1] != color.Red {
+		t.Errorf("error : the styled toast should be white on red")
+	}
+
+	// the last one expiring stops the ticks
+	ticker.TickListen() <- &fakeTick{when: started.Add(3 * time.Second)}
+	select {
+	case <-ticker.DyingChan():
+	case <-time.After(time.Second):
+		t.Fatalf("error : the ticker should die with the last toast")
+	}
+	expect("gone", "............", "............", "............", "............")
+
+	// a new toast registers a new ticker, at the bottom left corner
+	corner, _ := geom.NewToasts(ctx, engine, geom.WithToastCorner(geom.BottomLeft), geom.WithToastAnimation(0))
+	corner.Show("hi")
+	<-engine.listeners
+	expect("bottom left", "............", "............", "............", " hi ........")
+	corner.Dismiss()
+	expect("dismissed", "............", "............", "............", "............")
+}
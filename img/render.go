@@ -0,0 +1,229 @@
+package img
+
+import (
+	"image"
+	"math"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/geom"
+)
+
+// HalfBlock is the rune used for rendering : the foreground is the upper image pixel, the background is the lower one
+const HalfBlock = '▀'
+
+// Scaling is the algorithm used for resizing the image to the cells
+type Scaling int
+
+const (
+	Nearest  Scaling = iota // fast, keeps hard edges
+	Bilinear                // smoother, better for photos
+)
+
+// Option for functional options
+type Option func(o *options)
+
+type options struct {
+	scaling    Scaling       // resize algorithm
+	keepAspect bool          // if true, the image is letterboxed
+	background color.Color   // color of the letterbox
+	palette    []color.Color // if set, the colors are dithered to this palette
+	noScaling  bool          // if true, the image is used at its size
+}
+
+// WithScaling sets the resize algorithm. Default is Nearest.
+func WithScaling(s Scaling) Option {
+	return func(o *options) {
+		o.scaling = s
+	}
+}
+
+// WithAspectRatio preserves (default) or not the aspect ratio of the image. When preserved, the image is centered and the remaining cells are filled with the background.
+func WithAspectRatio(keep bool) Option {
+	return func(o *options) {
+		o.keepAspect = keep
+	}
+}
+
+// WithBackground sets the color of the cells which are not covered by the image. Default is color.Default.
+func WithBackground(c color.Color) Option {
+	return func(o *options) {
+		o.background = c
+	}
+}
+
+// WithDithering dithers (Floyd-Steinberg) the image to the palette, for terminals with few colors (e.g. the 16 or 256 colors of term.Engine.Style().Palette())
+func WithDithering(palette []color.Color) Option {
+	return func(o *options) {
+		o.palette = palette
+	}
+}
+
+// WithOriginalSize ignores the size and renders the image at its own size : one column for each image column and one row for two image rows
+func WithOriginalSize() Option {
+	return func(o *options) {
+		o.noScaling = true
+	}
+}
+
+// Cells returns the colors of the cells, indexed [column][row] : the first color is the upper half of the cell, the second is the lower half
+func Cells(src image.Image, size term.Size, opts ...Option) [][][2]color.Color {
+	o := options{keepAspect: true, background: color.Default}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	bounds := src.Bounds()
+	if o.noScaling {
+		size = term.Size{Columns: bounds.Dx(), Rows: (bounds.Dy() + 1) / 2}
+	}
+	result := make([][][2]color.Color, size.Columns)
+	for column := range result {
+		result[column] = make([][2]color.Color, size.Rows)
+		for row := range result[column] {
+			result[column][row] = [2]color.Color{o.background, o.background}
+		}
+	}
+	if size.Columns <= 0 || size.Rows <= 0 || bounds.Empty() {
+		return result
+	}
+
+	// the target, in image pixels (each cell holds two of them)
+	width, height := size.Columns, size.Rows*2
+	if o.noScaling {
+		width, height = bounds.Dx(), bounds.Dy()
+	}
+	offsetX, offsetY := 0, 0
+	if o.keepAspect && !o.noScaling {
+		scale := math.Min(float64(width)/float64(bounds.Dx()), float64(height)/float64(bounds.Dy()))
+		fitWidth, fitHeight := int(math.Round(float64(bounds.Dx())*scale)), int(math.Round(float64(bounds.Dy())*scale))
+		fitWidth, fitHeight = term.Max(1, term.Min(fitWidth, width)), term.Max(1, term.Min(fitHeight, height))
+		offsetX, offsetY = (width-fitWidth)/2, (height-fitHeight)/2
+		width, height = fitWidth, fitHeight
+	}
+
+	pixels := sample(src, width, height, o.scaling)
+	colors := make([]color.Color, len(pixels))
+	if len(o.palette) > 0 {
+		colors = dither(pixels, width, height, o.palette)
+	} else {
+		for idx, p := range pixels {
+			colors[idx] = toColor(p)
+		}
+	}
+
+	for y := 0; y < height; y++ {
+		row, half := (y+offsetY)/2, (y+offsetY)%2
+		for x := 0; x < width; x++ {
+			result[x+offsetX][row][half] = colors[y*width+x]
+		}
+	}
+	return result
+}
+
+// Render returns the pixels of the image, as half blocks, for the given size. The pixels are positioned from 0,0 and the grid is indexed [column][row].
+func Render(src image.Image, size term.Size, opts ...Option) ([][]term.Pixel, []term.PixelGetter) {
+	cells := Cells(src, size, opts...)
+	gridSize := term.NewSize(len(cells), 0)
+	if len(cells) > 0 {
+		gridSize.Rows = len(cells[0])
+	}
+	grid, getters := geom.NewPixelGrid(gridSize, geom.WithRune(HalfBlock))
+	for column := range cells {
+		for row, c := range cells[column] {
+			grid[column][row].SetFgBg(c[0], c[1])
+		}
+	}
+	return grid, getters
+}
+
+// rgb holds the components in [0..255], as floats for scaling and error diffusion
+type rgb [3]float64
+
+func toColor(p rgb) color.Color {
+	clamp := func(v float64) int32 {
+		return int32(math.Max(0, math.Min(255, math.Round(v))))
+	}
+	return color.NewRGBColor(clamp(p[0]), clamp(p[1]), clamp(p[2]))
+}
+
+// at reads the image pixel, blending the transparent ones over black
+func at(src image.Image, x, y int) rgb {
+	r, g, b, _ := src.At(x, y).RGBA() // alpha pre-multiplied
+	return rgb{float64(r >> 8), float64(g >> 8), float64(b >> 8)}
+}
+
+// sample resizes the image to width x height pixels
+func sample(src image.Image, width, height int, scaling Scaling) []rgb {
+	bounds := src.Bounds()
+	result := make([]rgb, width*height)
+	scaleX, scaleY := float64(bounds.Dx())/float64(width), float64(bounds.Dy())/float64(height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if scaling == Nearest {
+				sx := bounds.Min.X + term.Min(int(float64(x)*scaleX+scaleX/2), bounds.Dx()-1)
+				sy := bounds.Min.Y + term.Min(int(float64(y)*scaleY+scaleY/2), bounds.Dy()-1)
+				result[y*width+x] = at(src, sx, sy)
+				continue
+			}
+			fx := math.Max(0, (float64(x)+0.5)*scaleX-0.5)
+			fy := math.Max(0, (float64(y)+0.5)*scaleY-0.5)
+			x0, y0 := int(fx), int(fy)
+			x1, y1 := term.Min(x0+1, bounds.Dx()-1), term.Min(y0+1, bounds.Dy()-1)
+			tx, ty := fx-float64(x0), fy-float64(y0)
+			p00 := at(src, bounds.Min.X+x0, bounds.Min.Y+y0)
+			p10 := at(src, bounds.Min.X+x1, bounds.Min.Y+y0)
+			p01 := at(src, bounds.Min.X+x0, bounds.Min.Y+y1)
+			p11 := at(src, bounds.Min.X+x1, bounds.Min.Y+y1)
+			var p rgb
+			for c := range p {
+				top := p00[c] + (p10[c]-p00[c])*tx
+				bottom := p01[c] + (p11[c]-p01[c])*tx
+				p[c] = top + (bottom-top)*ty
+			}
+			result[y*width+x] = p
+		}
+	}
+	return result
+}
+
+// dither maps the pixels to the palette, diffusing the quantization error (Floyd-Steinberg)
+func dither(pixels []rgb, width, height int, palette []color.Color) []color.Color {
+	values := make([]rgb, len(palette))
+	for idx, c := range palette {
+		r, g, b := color.ToRGB(c)
+		values[idx] = rgb{float64(r), float64(g), float64(b)}
+	}
+	work := make([]rgb, len(pixels))
+	copy(work, pixels)
+	result := make([]color.Color, len(pixels))
+	spread := func(x, y int, err rgb, factor float64) {
+		if x < 0 || x >= width || y >= height {
+			return
+		}
+		for c := range err {
+			work[y*width+x][c] += err[c] * factor
+		}
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			p := work[y*width+x]
+			best, bestDist := 0, math.Inf(1)
+			for idx, v := range values {
+				if v[0] < 0 {
+					continue // not a RGB convertible color
+				}
+				d := (p[0]-v[0])*(p[0]-v[0]) + (p[1]-v[1])*(p[1]-v[1]) + (p[2]-v[2])*(p[2]-v[2])
+				if d < bestDist {
+					best, bestDist = idx, d
+				}
+			}
+			result[y*width+x] = palette[best]
+			err := rgb{p[0] - values[best][0], p[1] - values[best][1], p[2] - values[best][2]}
+			spread(x+1, y, err, 7.0/16)
+			spread(x-1, y+1, err, 3.0/16)
+			spread(x, y+1, err, 5.0/16)
+			spread(x+1, y+1, err, 1.0/16)
+		}
+	}
+	return result
+}
@@ -0,0 +1,77 @@
+package img_test
+
+import (
+	"image"
+	stdColor "image/color"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/img"
+)
+
+// quarters returns an image which is red on top and blue at the bottom
+func quarters(width, height int) image.Image {
+	result := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := stdColor.RGBA{R: 255, A: 255}
+			if y >= height/2 {
+				c = stdColor.RGBA{B: 255, A: 255}
+			}
+			result.Set(x, y, c)
+		}
+	}
+	return result
+}
+
+func TestRender(t *testing.T) {
+	red, blue := color.NewRGBColor(255, 0, 0), color.NewRGBColor(0, 0, 255)
+
+	grid, getters := img.Render(quarters(4, 4), term.Size{Columns: 4, Rows: 2}, img.WithAspectRatio(false))
+	if len(grid) != 4 || len(grid[0]) != 2 || len(getters) != 8 {
+		t.Fatalf("expecting 4x2 grid, got %dx%d", len(grid), len(grid[0]))
+	}
+	for column := range grid {
+		fg, bg, _ := grid[column][0].Style()
+		if grid[column][0].Rune() != img.HalfBlock || fg != red || bg != red {
+			t.Fatalf("column %d : top cell should be red", column)
+		}
+		if fg, bg, _ = grid[column][1].Style(); fg != blue || bg != blue {
+			t.Fatalf("column %d : bottom cell should be blue", column)
+		}
+	}
+
+	// a square image in a wide area is centered, the sides are background
+	cells := img.Cells(quarters(8, 8), term.Size{Columns: 8, Rows: 2}, img.WithBackground(color.Black))
+	if cells[0][0][0] != color.Black || cells[7][1][1] != color.Black {
+		t.Fatalf("expecting letterbox on the sides")
+	}
+	if cells[2][0][0] != red || cells[5][1][1] != blue {
+		t.Fatalf("expecting the image centered, got %v and %v", cells[2][0][0], cells[5][1][1])
+	}
+
+	// bilinear blends at the border between red and blue
+	cells = img.Cells(quarters(2, 2), term.Size{Columns: 4, Rows: 2}, img.WithAspectRatio(false), img.WithScaling(img.Bilinear))
+	if r, _, b := color.ToRGB(cells[0][0][1]); r == 255 || b == 0 {
+		t.Fatalf("expecting a blend, got %v", cells[0][0][1])
+	}
+
+	// dithering only uses the palette colors
+	palette := []color.Color{color.Black, color.White}
+	cells = img.Cells(quarters(4, 4), term.Size{Columns: 4, Rows: 2}, img.WithDithering(palette))
+	for column := range cells {
+		for _, cell := range cells[column] {
+			for _, c := range cell {
+				if c != color.Black && c != color.White {
+					t.Fatalf("expecting palette color, got %v", c)
+				}
+			}
+		}
+	}
+
+	cells = img.Cells(quarters(3, 5), term.Size{}, img.WithOriginalSize())
+	if len(cells) != 3 || len(cells[0]) != 3 {
+		t.Fatalf("expecting 3x3 cells for a 3x5 image, got %dx%d", len(cells), len(cells[0]))
+	}
+}
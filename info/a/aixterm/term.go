@@ -12,6 +12,8 @@ func init() {
 		Colors:       8,
 		Bell:         "\a",
 		Clear:        "\x1b[H\x1b[J",
+		Init2:        "\x1bc",
+		Reset2:       "\x1bc",
 		AttrOff:      "\x1b[0;10m\x1b(B",
 		Underline:    "\x1b[4m",
 		Bold:         "\x1b[1m",
@@ -52,5 +54,12 @@ func init() {
 		KeyF12:       "\x1b[012q",
 		KeyClear:     "\x1b[144q",
 		KeyBacktab:   "\x1b[Z",
+		ClearToEOL:   "\x1b[K",
+		ClearToEOS:   "\x1b[J",
+		InsertLine:   "\x1b[L",
+		InsertLines:  "\x1b[%p1%dL",
+		DeleteLine:   "\x1b[M",
+		DeleteLines:  "\x1b[%p1%dM",
+		DeleteChar:   "\x1b[P",
 	})
 }
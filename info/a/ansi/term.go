@@ -36,5 +36,14 @@ func init() {
 		KeyBackspace: "\b",
 		KeyHome:      "\x1b[H",
 		KeyBacktab:   "\x1b[Z",
+		ClearToEOL:   "\x1b[K",
+		ClearToEOS:   "\x1b[J",
+		InsertLine:   "\x1b[L",
+		InsertLines:  "\x1b[%p1%dL",
+		DeleteLine:   "\x1b[M",
+		DeleteLines:  "\x1b[%p1%dM",
+		InsertChars:  "\x1b[%p1%d@",
+		DeleteChar:   "\x1b[P",
+		DeleteChars:  "\x1b[%p1%dP",
 	})
 }
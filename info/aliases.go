@@ -0,0 +1,62 @@
+package info
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrInvalidAlias is returned by AddAlias when a name is empty, or when the alias would lead back to itself.
+	ErrInvalidAlias = errors.New("invalid terminal alias")
+
+	aliases = make(map[string]string) // alias to name, registered by AddAlias, guarded by mu
+)
+
+// AddAlias registers an alternative name for a terminal entry, so environments having odd $TERM values (e.g. "xterm-kitty" on older databases) resolve to a sensible entry.
+// The name can be an alias itself. Registered entries take precedence over aliases, so an alias can't hide an existing terminal.
+func AddAlias(alias, name string) error {
+	alias, name = normalizeName(alias), normalizeName(name)
+	if alias == "" || name == "" {
+		return ErrInvalidAlias
+	}
+	mu.Lock()
+	defer mu.Unlock()
+
+	// cycle detection : following the name must not lead back to the alias
+	for next, ok := name, true; ok; next, ok = aliases[next] {
+		if next == alias {
+			return ErrInvalidAlias
+		}
+	}
+	aliases[alias] = name
+	return nil
+}
+
+// ResolveAlias returns the name of the terminal entry, following the aliases registered by AddAlias. Names which aren't aliases are returned as they are.
+func ResolveAlias(name string) string {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return resolveAlias(name)
+}
+
+// resolveAlias follows the aliases, stopping at the first registered entry - locked inside caller function
+func resolveAlias(name string) string {
+	name = normalizeName(name)
+	for hops := 0; hops < len(aliases); hops++ { // AddAlias refuses cycles, but the number of hops is bounded anyway
+		if _, ok := infos[name]; ok {
+			return name
+		}
+		next, ok := aliases[name]
+		if !ok {
+			break
+		}
+		name = next
+	}
+	return name
+}
+
+// normalizeName removes the whitespace which sneaks into $TERM values set by scripts
+func normalizeName(name string) string {
+	return strings.TrimSpace(name)
+}
@@ -0,0 +1,48 @@
+package info_test
+
+import (
+	"testing"
+
+	"github.com/badu/term/info"
+)
+
+func TestAliases(t *testing.T) {
+	info.AddTerminfo(&info.Term{Name: "alias-test-256color", SetCursor: "\x1b[%i%p1%d;%p2%dH"})
+	info.AddTerminfo(&info.Term{Name: "alias-test-existing", SetCursor: "\x1b[%i%p1%d;%p2%dH"})
+
+	for _, tc := range []struct {
+		alias, name string
+		err         error
+	}{
+		{"alias-test-kitty", "alias-test-256color", nil},
+		{"alias-test-ghostty", "alias-test-kitty", nil}, // an alias of an alias
+		{"alias-test-existing", "alias-test-256color", nil},
+		{" alias-test-spaced ", "alias-test-256color", nil},
+		{"alias-test-a", "alias-test-b", nil},
+		{"alias-test-b", "alias-test-a", info.ErrInvalidAlias}, // cycle
+		{"alias-test-c", "alias-test-c", info.ErrInvalidAlias},
+		{"", "alias-test-256color", info.ErrInvalidAlias},
+	} {
+		if err := info.AddAlias(tc.alias, tc.name); err != tc.err {
+			t.Errorf("error : AddAlias(%q, %q) should return %v, got %v", tc.alias, tc.name, tc.err, err)
+		}
+	}
+
+	for name, want := range map[string]string{
+		"alias-test-kitty":    "alias-test-256color",
+		"alias-test-ghostty":  "alias-test-256color",
+		"alias-test-spaced":   "alias-test-256color",
+		"alias-test-existing": "alias-test-existing", // entries take precedence
+		"alias-test-a":        "alias-test-b",        // not registered, dynamic loading might know it
+		"alias-test-unknown":  "alias-test-unknown",
+	} {
+		if got := info.ResolveAlias(name); got != want {
+			t.Errorf("error : %q should resolve to %q, got %q", name, want, got)
+		}
+	}
+
+	ti, err := info.LookupTerminfo("alias-test-ghostty")
+	if err != nil || ti.Name != "alias-test-256color" {
+		t.Errorf("error : looking up an alias should find the entry, got %v", err)
+	}
+}
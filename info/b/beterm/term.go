@@ -12,6 +12,7 @@ func init() {
 		Colors:       8,
 		Bell:         "\a",
 		Clear:        "\x1b[H\x1b[J",
+		Reset1:       "\x1bc",
 		AttrOff:      "\x1b[0;10m",
 		Underline:    "\x1b[4m",
 		Bold:         "\x1b[1m",
@@ -49,5 +50,15 @@ func init() {
 		KeyF10:       "\x1b[20~",
 		KeyF11:       "\x1b[21~",
 		KeyF12:       "\x1b[22~",
+		ClearToEOL:   "\x1b[K",
+		ClearToEOS:   "\x1b[J",
+		InsertLine:   "\x1b[L",
+		InsertLines:  "\x1b[%p1%dL",
+		DeleteLine:   "\x1b[M",
+		DeleteLines:  "\x1b[%p1%dM",
+		InsertChar:   "\x1b[@",
+		InsertChars:  "\x1b[%p1%d@",
+		DeleteChar:   "\x1b[P",
+		DeleteChars:  "\x1b[%p1%dP",
 	})
 }
@@ -12,6 +12,7 @@ func init() {
 		Clear:        "\x1b[H\x1b[J",
 		EnterCA:      "\x1b7\x1b[?47h",
 		ExitCA:       "\x1b[2J\x1b[?47l\x1b8",
+		Reset1:       "\x1bc\x1b]R",
 		AttrOff:      "\x1b[0;10m",
 		Underline:    "\x1b[4m",
 		Bold:         "\x1b[1m",
@@ -50,5 +51,15 @@ func init() {
 		KeyF10:       "\x1b[21~",
 		KeyF11:       "\x1b[23~",
 		KeyF12:       "\x1b[24~",
+		ClearToEOL:   "\x1b[K",
+		ClearToEOS:   "\x1b[J",
+		InsertLine:   "\x1b[L",
+		InsertLines:  "\x1b[%p1%dL",
+		DeleteLine:   "\x1b[M",
+		DeleteLines:  "\x1b[%p1%dM",
+		InsertChar:   "\x1b[@",
+		InsertChars:  "\x1b[%p1%d@",
+		DeleteChar:   "\x1b[P",
+		DeleteChars:  "\x1b[%p1%dP",
 	})
 }
@@ -0,0 +1,230 @@
+package info
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/badu/term"
+)
+
+var (
+	// ErrNoCapability indicates that the terminal doesn't have the requested capability. It matches (see errors.Is) the term.ErrNotSupported of any capability.
+	ErrNoCapability = term.ErrNotSupported{}
+	// ErrUnknownCapability indicates that Patch was given a name which is not the one of a string capability, nor of a numeric or boolean capability having a typed field
+	ErrUnknownCapability = errors.New("unknown or unsupported capability")
+)
+
+// stringFields maps the terminfo names to the typed fields holding them
+func (t *Term) stringFields() map[string]*string {
+	return map[string]*string{
+		"bel":   &t.Bell,
+		"clear": &t.Clear,
+		"el":    &t.ClearToEOL,
+		"ed":    &t.ClearToEOS,
+		"il1":   &t.InsertLine,
+		"il":    &t.InsertLines,
+		"dl1":   &t.DeleteLine,
+		"dl":    &t.DeleteLines,
+		"ich1":  &t.InsertChar,
+		"ich":   &t.InsertChars,
+		"dch1":  &t.DeleteChar,
+		"dch":   &t.DeleteChars,
+		"smcup": &t.EnterCA,
+		"rmcup": &t.ExitCA,
+		"is1":   &t.Init1,
+		"is2":   &t.Init2,
+		"rs1":   &t.Reset1,
+		"rs2":   &t.Reset2,
+		"cnorm": &t.ShowCursor,
+		"civis": &t.HideCursor,
+		"sgr0":  &t.AttrOff,
+		"smul":  &t.Underline,
+		"bold":  &t.Bold,
+		"blink": &t.Blink,
+		"rev":   &t.Reverse,
+		"dim":   &t.Dim,
+		"sitm":  &t.Italic,
+		"smkx":  &t.EnterKeypad,
+		"rmkx":  &t.ExitKeypad,
+		"setaf": &t.SetFg,
+		"setab": &t.SetBg,
+		"op":    &t.ResetFgBg,
+		"cup":   &t.SetCursor,
+		"cub1":  &t.CursorBack1,
+		"cuu1":  &t.CursorUp1,
+		"pad":   &t.PadChar,
+		"acsc":  &t.AltChars,
+		"smacs": &t.EnterAcs,
+		"rmacs": &t.ExitAcs,
+		"enacs": &t.EnableAcs,
+		"kmous": &t.Mouse,
+		"smxx":  &t.StrikeThrough,
+		"XM":    &t.MouseMode,
+
+		// extended (user-defined) capabilities
+		"Smulx":  &t.UnderlineStyle,
+		"Setulc": &t.UnderlineColor,
+		"Ss":     &t.CursorStyle,
+		"Se":     &t.ResetCursorStyle,
+		"Cs":     &t.CursorColor,
+		"Cr":     &t.ResetCursorColor,
+		"Ms":     &t.SetClipboard,
+	}
+}
+
+// Capabilities returns the string capabilities of the terminal, by their terminfo names.
+// The Strings map is merged with the typed fields, the latter taking precedence.
+func (t *Term) Capabilities() map[string]string {
+	fields := t.stringFields()
+	result := make(map[string]string, len(t.Strings)+len(fields))
+	for name, value := range t.Strings {
+		result[name] = value
+	}
+	for name, value := range fields {
+		if len(*value) > 0 {
+			result[name] = *value
+		}
+	}
+	return result
+}
+
+// Patch returns a copy of the terminal entry, having the capabilities replaced by the ones provided (by their terminfo names).
+// An empty value removes a string capability. The numeric (cols, lines, colors) and boolean (bce, xon) capabilities having a typed field are parsed, an empty value resetting them.
+// The names have to be the ones of standard string capabilities, of extended ones (starting with an uppercase letter by convention, e.g. "Smulx") or the ones already in the entry,
+// otherwise (e.g. misspelled, or a numeric capability without a field) ErrUnknownCapability is returned.
+func (t *Term) Patch(overrides map[string]string) (*Term, error) {
+	result := *t
+	result.Strings = make(map[string]string, len(t.Strings)+len(overrides))
+	for name, value := range t.Strings {
+		result.Strings[name] = value
+	}
+	fields := result.stringFields()
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names) // the same error for the same overrides
+	for _, name := range names {
+		value := overrides[name]
+		if number, ok := result.numberField(name); ok {
+			if err := parseNumber(number, value); err != nil {
+				return nil, fmt.Errorf("capability %q : %w", name, err)
+			}
+			continue
+		}
+		if flag, ok := result.booleanField(name); ok {
+			if err := parseBoolean(flag, value); err != nil {
+				return nil, fmt.Errorf("capability %q : %w", name, err)
+			}
+			continue
+		}
+		field, isField := fields[name]
+		if !isField && !isStringName(name) {
+			if _, ok := t.Strings[name]; !ok {
+				return nil, fmt.Errorf("%w : %q", ErrUnknownCapability, name)
+			}
+		}
+		if isField {
+			*field = value
+		}
+		if len(value) == 0 {
+			delete(result.Strings, name)
+			continue
+		}
+		result.Strings[name] = value
+	}
+	return &result, nil
+}
+
+// numberField returns the typed field of the numeric capability
+func (t *Term) numberField(name string) (*int, bool) {
+	switch name {
+	case "cols":
+		return &t.Columns, true
+	case "lines":
+		return &t.Lines, true
+	case "colors":
+		return &t.Colors, true
+	}
+	return nil, false
+}
+
+// booleanField returns the typed field of the boolean capability
+func (t *Term) booleanField(name string) (*bool, bool) {
+	switch name {
+	case "bce":
+		return &t.BackColorErase, true
+	case "xon":
+		return &t.XonXoff, true
+	}
+	return nil, false
+}
+
+// parseNumber sets the field to the value, zero if it's empty
+func parseNumber(field *int, value string) error {
+	if len(value) == 0 {
+		*field = 0
+		return nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return err
+	}
+	*field = number
+	return nil
+}
+
+// parseBoolean sets the field to the value, false if it's empty
+func parseBoolean(field *bool, value string) error {
+	if len(value) == 0 {
+		*field = false
+		return nil
+	}
+	flag, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+	*field = flag
+	return nil
+}
+
+// isStringName returns true for the names of the standard string capabilities and the ones of the extended capabilities, but not for the other standard ones
+func isStringName(name string) bool {
+	if _, ok := standardStrings[name]; ok {
+		return true
+	}
+	if _, ok := standardNumbers[name]; ok {
+		return false
+	}
+	if _, ok := standardBooleans[name]; ok {
+		return false
+	}
+	return len(name) > 0 && name[0] >= 'A' && name[0] <= 'Z'
+}
+
+// Has returns true if the terminal has the string capability with the given terminfo name
+func (t *Commander) Has(name string) bool {
+	return len(t.caps[name]) > 0
+}
+
+// Capability returns the raw (not parameterized) string capability with the given terminfo name
+func (t *Commander) Capability(name string) (string, bool) {
+	v, ok := t.caps[name]
+	return v, ok && len(v) > 0
+}
+
+// Put writes the string capability with the given terminfo name, applying the parameters (if any).
+// This allows using any capability (e.g. "flash", "tsl", "fsl") without a dedicated Put method.
+func (t *Commander) Put(w io.Writer, name string, params ...int) error {
+	v, ok := t.Capability(name)
+	if !ok {
+		return term.ErrNotSupported{Capability: name}
+	}
+	if len(params) > 0 {
+		v = t.TParam(v, params...)
+	}
+	return t.WriteString(w, v)
+}
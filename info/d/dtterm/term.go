@@ -12,6 +12,7 @@ func init() {
 		Colors:       8,
 		Bell:         "\a",
 		Clear:        "\x1b[H\x1b[J",
+		Init2:        "\x1b F\x1b>\x1b[?1l\x1b[?7h\x1b[?45l",
 		ShowCursor:   "\x1b[?25h",
 		HideCursor:   "\x1b[?25l",
 		AttrOff:      "\x1b[m\x0f",
@@ -54,5 +55,14 @@ func init() {
 		KeyF11:       "\x1b[23~",
 		KeyF12:       "\x1b[24~",
 		KeyHelp:      "\x1b[28~",
+		ClearToEOL:   "\x1b[K",
+		ClearToEOS:   "\x1b[J",
+		InsertLine:   "\x1b[L",
+		InsertLines:  "\x1b[%p1%dL",
+		DeleteLine:   "\x1b[M",
+		DeleteLines:  "\x1b[%p1%dM",
+		InsertChars:  "\x1b[%p1%d@",
+		DeleteChar:   "\x1b[P",
+		DeleteChars:  "\x1b[%p1%dP",
 	})
 }
@@ -94,21 +94,25 @@ func unescape(s string) string {
 }
 
 func (c *termcap) setupterm(name string) error {
-	cmd := exec.Command("infocmp", "-1", name)
+	cmd := exec.Command("infocmp", "-1", "-x", name) // -x : the extended (user-defined) capabilities as well
 	output := &bytes.Buffer{}
 	cmd.Stdout = output
 
-	c.strs = make(map[string]string)
-	c.bools = make(map[string]bool)
-	c.nums = make(map[string]int)
-
 	if err := cmd.Run(); err != nil {
 		return err
 	}
+	return c.parse(output.String())
+}
+
+// parse reads the output of infocmp
+func (c *termcap) parse(output string) error {
+	c.strs = make(map[string]string)
+	c.bools = make(map[string]bool)
+	c.nums = make(map[string]int)
 
 	// Now parse the output.
 	// We get comment lines (starting with "#"), followed by a header line that looks like "<name>|<alias>|...|<desc>" then capabilities, one per line, starting with a tab and ending with a comma and newline.
-	lines := strings.Split(output.String(), "\n")
+	lines := strings.Split(output, "\n")
 	for len(lines) > 0 && strings.HasPrefix(lines[0], "#") {
 		lines = lines[1:]
 	}
@@ -169,13 +173,30 @@ func LoadTerminfo(name string) (*info.Term, string, error) {
 		return t, "", nil
 	}
 	t.Aliases = tc.aliases
+	t.Strings = tc.strs
 	t.Colors = tc.getNum("colors")
 	t.Columns = tc.getNum("cols")
 	t.Lines = tc.getNum("lines")
 	t.Bell = tc.getStr("bel")
 	t.Clear = tc.getStr("clear")
+	t.ClearToEOL = tc.getStr("el")
+	t.ClearToEOS = tc.getStr("ed")
+	t.BackColorErase = tc.getFlag("bce")
+	t.XonXoff = tc.getFlag("xon")
+	t.InsertLine = tc.getStr("il1")
+	t.InsertLines = tc.getStr("il")
+	t.DeleteLine = tc.getStr("dl1")
+	t.DeleteLines = tc.getStr("dl")
+	t.InsertChar = tc.getStr("ich1")
+	t.InsertChars = tc.getStr("ich")
+	t.DeleteChar = tc.getStr("dch1")
+	t.DeleteChars = tc.getStr("dch")
 	t.EnterCA = tc.getStr("smcup")
 	t.ExitCA = tc.getStr("rmcup")
+	t.Init1 = tc.getStr("is1")
+	t.Init2 = tc.getStr("is2")
+	t.Reset1 = tc.getStr("rs1")
+	t.Reset2 = tc.getStr("rs2")
 	t.ShowCursor = tc.getStr("cnorm")
 	t.HideCursor = tc.getStr("civis")
 	t.AttrOff = tc.getStr("sgr0")
@@ -204,6 +225,12 @@ func LoadTerminfo(name string) (*info.Term, string, error) {
 	t.KeyF10 = tc.getStr("kf10")
 	t.KeyF11 = tc.getStr("kf11")
 	t.KeyF12 = tc.getStr("kf12")
+	for n := 13; n <= 64; n++ {
+		t.KeyFunctions = append(t.KeyFunctions, tc.getStr("kf"+strconv.Itoa(n)))
+	}
+	for len(t.KeyFunctions) > 0 && t.KeyFunctions[len(t.KeyFunctions)-1] == "" {
+		t.KeyFunctions = t.KeyFunctions[:len(t.KeyFunctions)-1] // only up to the last one defined
+	}
 	t.KeyInsert = tc.getStr("kich1")
 	t.KeyDelete = tc.getStr("kdch1")
 	t.KeyBackspace = tc.getStr("kbs")
@@ -230,6 +257,8 @@ func LoadTerminfo(name string) (*info.Term, string, error) {
 	t.KeyShfLeft = tc.getStr("kLFT")
 	t.KeyShfHome = tc.getStr("kHOM")
 	t.KeyShfEnd = tc.getStr("kEND")
+	t.KeyShfInsert = tc.getStr("kIC")
+	t.KeyShfDelete = tc.getStr("kDC")
 
 	// Terminfo lacks descriptions for a bunch of modified keys, but modern XTerm and emulators often have them.
 	// Let's add them, if the shifted right and left arrows are defined.
@@ -314,6 +343,15 @@ func LoadTerminfo(name string) (*info.Term, string, error) {
 	// The escapedape codes are documented in the XTerm manual, and all terminals that have kmous are expected to use these same codes, unless explicitly configured otherwise vi XM.
 	// Note that in any event, we only known how to parse either x11 or SGR mouse events -- if your terminal doesn't support one of these two forms, you maybe out of luck.
 	t.MouseMode = tc.getStr("XM")
+
+	// The extended capabilities (infocmp -x), used by the higher layers when present
+	t.UnderlineStyle = tc.getStr("Smulx")
+	t.UnderlineColor = tc.getStr("Setulc")
+	t.CursorStyle = tc.getStr("Ss")
+	t.ResetCursorStyle = tc.getStr("Se")
+	t.CursorColor = tc.getStr("Cs")
+	t.ResetCursorColor = tc.getStr("Cr")
+	t.SetClipboard = tc.getStr("Ms")
 	if t.Mouse != "" && t.MouseMode == "" {
 		// we anticipate that all xterm mouse tracking compatible terminals understand mouse tracking (1000), but we hope that those that don't understand any-event tracking (1003) will at least ignore it.  Likewise we hope that terminals that don't understand SGR reporting (1006) just ignore it.
 		t.MouseMode = "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;" +
@@ -0,0 +1,49 @@
+package dynamic
+
+import (
+	"testing"
+)
+
+// the output of infocmp -1 -x, shortened
+const kittyInfo = `#	Reconstructed via infocmp from file: /usr/share/terminfo/x/xterm-kitty
+xterm-kitty|KovIdTTY,
+	Tc,
+	am,
+	colors#256,
+	Cr=\E]112\007,
+	Cs=\E]12;%p1%s\007,
+	Ms=\E]52;%p1%s;%p2%s\007,
+	Se=\E[2 q,
+	Setulc=\E[58:2:%p1%{65536}%/%d:%p1%{256}%/%{255}%&%d:%p1%{255}%&%d%;m,
+	Smulx=\E[4:%p1%dm,
+	Ss=\E[%p1%d q,
+	cup=\E[%i%p1%d;%p2%dH,
+`
+
+func TestParseExtended(t *testing.T) {
+	var tc termcap
+	if err := tc.parse(kittyInfo); err != nil {
+		t.Fatalf("error parsing : %v", err)
+	}
+	if tc.name != "xterm-kitty" || tc.desc != "KovIdTTY" {
+		t.Errorf("error : bad header %q %q", tc.name, tc.desc)
+	}
+	if !tc.getFlag("Tc") || tc.getNum("colors") != 256 {
+		t.Errorf("error : flags and numbers should be parsed")
+	}
+	for name, want := range map[string]string{
+		"Cr":    "\x1b]112\x07",
+		"Cs":    "\x1b]12;%p1%s\x07",
+		"Ms":    "\x1b]52;%p1%s;%p2%s\x07",
+		"Se":    "\x1b[2 q",
+		"Smulx": "\x1b[4:%p1%dm",
+		"Ss":    "\x1b[%p1%d q",
+	} {
+		if got := tc.getStr(name); got != want {
+			t.Errorf("error : %s should be %q, got %q", name, want, got)
+		}
+	}
+	if err := tc.parse("bad|entry,\nnot indented,\n"); err == nil {
+		t.Errorf("error : malformed output should fail")
+	}
+}
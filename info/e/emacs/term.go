@@ -21,6 +21,15 @@ func init() {
 		SetCursor:   "\x1b[%i%p1%d;%p2%dH",
 		CursorBack1: "\b",
 		CursorUp1:   "\x1b[A",
+		ClearToEOL:  "\x1b[K",
+		ClearToEOS:  "\x1b[J",
+		InsertLine:  "\x1b[L",
+		InsertLines: "\x1b[%p1%dL",
+		DeleteLine:  "\x1b[M",
+		DeleteLines: "\x1b[%p1%dM",
+		InsertChars: "\x1b[%p1%d@",
+		DeleteChar:  "\x1b[P",
+		DeleteChars: "\x1b[%p1%dP",
 	})
 
 	// Emacs term.el terminal emulator term-protocol-version 0.96
@@ -31,6 +40,7 @@ func init() {
 		Colors:       8,
 		Bell:         "\a",
 		Clear:        "\x1b[H\x1b[J",
+		Reset1:       "\x1bc",
 		AttrOff:      "\x1b[m",
 		Underline:    "\x1b[4m",
 		Bold:         "\x1b[1m",
@@ -55,5 +65,14 @@ func init() {
 		KeyEnd:       "\x1b[4~",
 		KeyPgUp:      "\x1b[5~",
 		KeyPgDn:      "\x1b[6~",
+		ClearToEOL:   "\x1b[K",
+		ClearToEOS:   "\x1b[J",
+		InsertLine:   "\x1b[L",
+		InsertLines:  "\x1b[%p1%dL",
+		DeleteLine:   "\x1b[M",
+		DeleteLines:  "\x1b[%p1%dM",
+		InsertChars:  "\x1b[%p1%d@",
+		DeleteChar:   "\x1b[P",
+		DeleteChars:  "\x1b[%p1%dP",
 	})
 }
@@ -0,0 +1,24 @@
+//go:build go1.18
+// +build go1.18
+
+package info_test
+
+import (
+	"testing"
+)
+
+func FuzzTParam(f *testing.F) {
+	for _, seed := range []string{
+		"\x1b[%i%p1%d;%p2%dH", "\x1b[%p1%dL", "\x1b[38;2;%p1%d;%p2%d;%p3%dm", "%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%;",
+		"%p1%'a'%+%c", "%p1%PA%gA%gA%l%d", "%p1%:-10.3x", "%{65}%c%%", "%p1%p2%/%p1%p2%m%d%d",
+	} {
+		f.Add(seed, 1, 2)
+	}
+	comm := testCommander()
+	f.Fuzz(func(t *testing.T, s string, p1, p2 int) {
+		out := comm.TParam(s, p1, p2)
+		if len(out) > (len(s)+1)*1024 { // formats are at most 3 digits wide, the numbers are at most 20 characters
+			t.Fatalf("error : %q expanded into %d bytes", s, len(out))
+		}
+	})
+}
@@ -44,5 +44,10 @@ func init() {
 		KeyF7:        "\x1bv",
 		KeyF8:        "\x1bw",
 		KeyClear:     "\x1bJ",
+		ClearToEOL:   "\x1bK",
+		ClearToEOS:   "\x1bJ$<1>",
+		InsertLine:   "\x1bL",
+		DeleteLine:   "\x1bM",
+		DeleteChar:   "\x1bP",
 	})
 }
@@ -6,123 +6,147 @@ func init() {
 
 	// KDE console window
 	info.AddTerminfo(&info.Term{
-		Name:          "konsole",
-		Columns:       80,
-		Lines:         24,
-		Colors:        8,
-		Clear:         "\x1b[H\x1b[2J",
-		EnterCA:       "\x1b7\x1b[?47h",
-		ExitCA:        "\x1b[2J\x1b[?47l\x1b8",
-		ShowCursor:    "\x1b[?25h",
-		HideCursor:    "\x1b[?25l",
-		AttrOff:       "\x1b[0m\x0f",
-		Underline:     "\x1b[4m",
-		Bold:          "\x1b[1m",
-		Dim:           "\x1b[2m",
-		Italic:        "\x1b[3m",
-		Blink:         "\x1b[5m",
-		Reverse:       "\x1b[7m",
-		EnterKeypad:   "\x1b[?1h\x1b=",
-		ExitKeypad:    "\x1b[?1l\x1b>",
-		SetFg:         "\x1b[3%p1%dm",
-		SetBg:         "\x1b[4%p1%dm",
-		SetFgBg:       "\x1b[3%p1%d;4%p2%dm",
-		ResetFgBg:     "\x1b[39;49m",
-		AltChars:      "``aaffggiijjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:      "\x0e",
-		ExitAcs:       "\x0f",
-		EnableAcs:     "\x1b)0",
-		StrikeThrough: "\x1b[9m",
-		Mouse:         "\x1b[<",
-		MouseMode:     "\x1b[?1006;1000%?%p1%{1}%=%th%el%;",
-		SetCursor:     "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:   "\b",
-		CursorUp1:     "\x1b[A",
-		KeyUp:         "\x1bOA",
-		KeyDown:       "\x1bOB",
-		KeyRight:      "\x1bOC",
-		KeyLeft:       "\x1bOD",
-		KeyInsert:     "\x1b[2~",
-		KeyDelete:     "\x1b[3~",
-		KeyBackspace:  "\u007f",
-		KeyHome:       "\x1bOH",
-		KeyEnd:        "\x1bOF",
-		KeyPgUp:       "\x1b[5~",
-		KeyPgDn:       "\x1b[6~",
-		KeyF1:         "\x1bOP",
-		KeyF2:         "\x1bOQ",
-		KeyF3:         "\x1bOR",
-		KeyF4:         "\x1bOS",
-		KeyF5:         "\x1b[15~",
-		KeyF6:         "\x1b[17~",
-		KeyF7:         "\x1b[18~",
-		KeyF8:         "\x1b[19~",
-		KeyF9:         "\x1b[20~",
-		KeyF10:        "\x1b[21~",
-		KeyF11:        "\x1b[23~",
-		KeyF12:        "\x1b[24~",
-		KeyBacktab:    "\x1b[Z",
-		Modifiers:     1,
+		Name:           "konsole",
+		Columns:        80,
+		Lines:          24,
+		Colors:         8,
+		Clear:          "\x1b[H\x1b[2J",
+		EnterCA:        "\x1b7\x1b[?47h",
+		ExitCA:         "\x1b[2J\x1b[?47l\x1b8",
+		Init2:          "\x1b[m\x1b[?7h\x1b[4l\x1b>\x1b7\x1b[r\x1b[?1;3;4;6l\x1b8",
+		Reset1:         "\x1bc",
+		Reset2:         "\x1b7\x1b[r\x1b8\x1b[m\x1b[?7h\x1b[?1;3;4;6l\x1b[4l\x1b>\x1b[?1000l\x1b[?25h",
+		ShowCursor:     "\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[0m\x0f",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Dim:            "\x1b[2m",
+		Italic:         "\x1b[3m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b[?1h\x1b=",
+		ExitKeypad:     "\x1b[?1l\x1b>",
+		SetFg:          "\x1b[3%p1%dm",
+		SetBg:          "\x1b[4%p1%dm",
+		SetFgBg:        "\x1b[3%p1%d;4%p2%dm",
+		ResetFgBg:      "\x1b[39;49m",
+		AltChars:       "``aaffggiijjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x0e",
+		ExitAcs:        "\x0f",
+		EnableAcs:      "\x1b)0",
+		StrikeThrough:  "\x1b[9m",
+		Mouse:          "\x1b[<",
+		MouseMode:      "\x1b[?1006;1000%?%p1%{1}%=%th%el%;",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		KeyUp:          "\x1bOA",
+		KeyDown:        "\x1bOB",
+		KeyRight:       "\x1bOC",
+		KeyLeft:        "\x1bOD",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\u007f",
+		KeyHome:        "\x1bOH",
+		KeyEnd:         "\x1bOF",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1bOP",
+		KeyF2:          "\x1bOQ",
+		KeyF3:          "\x1bOR",
+		KeyF4:          "\x1bOS",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyBacktab:     "\x1b[Z",
+		Modifiers:      1,
+		ClearToEOL:     "\x1b[K",
+		ClearToEOS:     "\x1b[J",
+		BackColorErase: true,
+		InsertLine:     "\x1b[L",
+		InsertLines:    "\x1b[%p1%dL",
+		DeleteLine:     "\x1b[M",
+		DeleteLines:    "\x1b[%p1%dM",
+		DeleteChar:     "\x1b[P",
+		DeleteChars:    "\x1b[%p1%dP",
 	})
 
 	// KDE console window with xterm 256-colors
 	info.AddTerminfo(&info.Term{
-		Name:          "konsole-256color",
-		Columns:       80,
-		Lines:         24,
-		Colors:        256,
-		Clear:         "\x1b[H\x1b[2J",
-		EnterCA:       "\x1b7\x1b[?47h",
-		ExitCA:        "\x1b[2J\x1b[?47l\x1b8",
-		ShowCursor:    "\x1b[?25h",
-		HideCursor:    "\x1b[?25l",
-		AttrOff:       "\x1b[0m\x0f",
-		Underline:     "\x1b[4m",
-		Bold:          "\x1b[1m",
-		Dim:           "\x1b[2m",
-		Italic:        "\x1b[3m",
-		Blink:         "\x1b[5m",
-		Reverse:       "\x1b[7m",
-		EnterKeypad:   "\x1b[?1h\x1b=",
-		ExitKeypad:    "\x1b[?1l\x1b>",
-		SetFg:         "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;m",
-		SetBg:         "\x1b[%?%p1%{8}%<%t4%p1%d%e%p1%{16}%<%t10%p1%{8}%-%d%e48;5;%p1%d%;m",
-		SetFgBg:       "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;;%?%p2%{8}%<%t4%p2%d%e%p2%{16}%<%t10%p2%{8}%-%d%e48;5;%p2%d%;m",
-		ResetFgBg:     "\x1b[39;49m",
-		AltChars:      "``aaffggiijjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:      "\x0e",
-		ExitAcs:       "\x0f",
-		EnableAcs:     "\x1b)0",
-		StrikeThrough: "\x1b[9m",
-		Mouse:         "\x1b[<",
-		MouseMode:     "\x1b[?1006;1000%?%p1%{1}%=%th%el%;",
-		SetCursor:     "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:   "\b",
-		CursorUp1:     "\x1b[A",
-		KeyUp:         "\x1bOA",
-		KeyDown:       "\x1bOB",
-		KeyRight:      "\x1bOC",
-		KeyLeft:       "\x1bOD",
-		KeyInsert:     "\x1b[2~",
-		KeyDelete:     "\x1b[3~",
-		KeyBackspace:  "\u007f",
-		KeyHome:       "\x1bOH",
-		KeyEnd:        "\x1bOF",
-		KeyPgUp:       "\x1b[5~",
-		KeyPgDn:       "\x1b[6~",
-		KeyF1:         "\x1bOP",
-		KeyF2:         "\x1bOQ",
-		KeyF3:         "\x1bOR",
-		KeyF4:         "\x1bOS",
-		KeyF5:         "\x1b[15~",
-		KeyF6:         "\x1b[17~",
-		KeyF7:         "\x1b[18~",
-		KeyF8:         "\x1b[19~",
-		KeyF9:         "\x1b[20~",
-		KeyF10:        "\x1b[21~",
-		KeyF11:        "\x1b[23~",
-		KeyF12:        "\x1b[24~",
-		KeyBacktab:    "\x1b[Z",
-		Modifiers:     1,
+		Name:           "konsole-256color",
+		Columns:        80,
+		Lines:          24,
+		Colors:         256,
+		Clear:          "\x1b[H\x1b[2J",
+		EnterCA:        "\x1b7\x1b[?47h",
+		ExitCA:         "\x1b[2J\x1b[?47l\x1b8",
+		Init2:          "\x1b[m\x1b[?7h\x1b[4l\x1b>\x1b7\x1b[r\x1b[?1;3;4;6l\x1b8",
+		Reset1:         "\x1bc",
+		Reset2:         "\x1b7\x1b[r\x1b8\x1b[m\x1b[?7h\x1b[?1;3;4;6l\x1b[4l\x1b>\x1b[?1000l\x1b[?25h",
+		ShowCursor:     "\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[0m\x0f",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Dim:            "\x1b[2m",
+		Italic:         "\x1b[3m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b[?1h\x1b=",
+		ExitKeypad:     "\x1b[?1l\x1b>",
+		SetFg:          "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;m",
+		SetBg:          "\x1b[%?%p1%{8}%<%t4%p1%d%e%p1%{16}%<%t10%p1%{8}%-%d%e48;5;%p1%d%;m",
+		SetFgBg:        "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;;%?%p2%{8}%<%t4%p2%d%e%p2%{16}%<%t10%p2%{8}%-%d%e48;5;%p2%d%;m",
+		ResetFgBg:      "\x1b[39;49m",
+		AltChars:       "``aaffggiijjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x0e",
+		ExitAcs:        "\x0f",
+		EnableAcs:      "\x1b)0",
+		StrikeThrough:  "\x1b[9m",
+		Mouse:          "\x1b[<",
+		MouseMode:      "\x1b[?1006;1000%?%p1%{1}%=%th%el%;",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		KeyUp:          "\x1bOA",
+		KeyDown:        "\x1bOB",
+		KeyRight:       "\x1bOC",
+		KeyLeft:        "\x1bOD",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\u007f",
+		KeyHome:        "\x1bOH",
+		KeyEnd:         "\x1bOF",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1bOP",
+		KeyF2:          "\x1bOQ",
+		KeyF3:          "\x1bOR",
+		KeyF4:          "\x1bOS",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyBacktab:     "\x1b[Z",
+		Modifiers:      1,
+		ClearToEOL:     "\x1b[K",
+		ClearToEOS:     "\x1b[J",
+		BackColorErase: true,
+		InsertLine:     "\x1b[L",
+		InsertLines:    "\x1b[%p1%dL",
+		DeleteLine:     "\x1b[M",
+		DeleteLines:    "\x1b[%p1%dM",
+		DeleteChar:     "\x1b[P",
+		DeleteChars:    "\x1b[%p1%dP",
 	})
 }
@@ -14,6 +14,8 @@ func init() {
 		Clear:        "\x1b[H\x1b[2J",
 		EnterCA:      "\x1b7\x1b[?47h",
 		ExitCA:       "\x1b[2J\x1b[?47l\x1b8",
+		Init2:        "\x1b[m\x1b[?7h\x1b[4l\x1b>\x1b7\x1b[r\x1b[?1;3;4;6l\x1b8",
+		Reset2:       "\x1b[m\x1b[?7h\x1b[4l\x1b>\x1b7\x1b[r\x1b[?1;3;4;6l\x1b8",
 		AttrOff:      "\x1b[m\x1b(B",
 		Underline:    "\x1b[4m",
 		Bold:         "\x1b[1m",
@@ -54,5 +56,13 @@ func init() {
 		KeyF10:       "\x1b[21~",
 		KeyF11:       "\x1b[23~",
 		KeyF12:       "\x1b[24~",
+		ClearToEOL:   "\x1b[K",
+		ClearToEOS:   "\x1b[J",
+		InsertLine:   "\x1b[L",
+		InsertLines:  "\x1b[%p1%dL",
+		DeleteLine:   "\x1b[M",
+		DeleteLines:  "\x1b[%p1%dM",
+		DeleteChar:   "\x1b[P",
+		DeleteChars:  "\x1b[%p1%dP",
 	})
 }
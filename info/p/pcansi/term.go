@@ -34,5 +34,10 @@ func init() {
 		KeyLeft:      "\x1b[D",
 		KeyBackspace: "\b",
 		KeyHome:      "\x1b[H",
+		ClearToEOL:   "\x1b[K",
+		ClearToEOS:   "\x1b[J",
+		InsertLine:   "\x1b[L",
+		DeleteLine:   "\x1b[M",
+		DeleteChar:   "\x1b[P",
 	})
 }
@@ -2,365 +2,433 @@ package rxvt
 
 import "github.com/badu/term/info"
 
+// functionKeys are kf13 to kf44 : Shift+F3 to Shift+F12, Ctrl+F1 to Ctrl+F12, Ctrl+Shift+F3 to Ctrl+Shift+F12
+var functionKeys = []string{
+	"\x1b[25~", "\x1b[26~", "\x1b[28~", "\x1b[29~", "\x1b[31~", "\x1b[32~", "\x1b[33~", "\x1b[34~", "\x1b[23$", "\x1b[24$",
+	"\x1b[11^", "\x1b[12^", "\x1b[13^", "\x1b[14^", "\x1b[15^", "\x1b[17^", "\x1b[18^", "\x1b[19^", "\x1b[20^", "\x1b[21^", "\x1b[23^", "\x1b[24^",
+	"\x1b[25^", "\x1b[26^", "\x1b[28^", "\x1b[29^", "\x1b[31^", "\x1b[32^", "\x1b[33^", "\x1b[34^", "\x1b[23@", "\x1b[24@",
+}
+
 func init() {
 
 	// rxvt terminal emulator (X Window System)
 	info.AddTerminfo(&info.Term{
-		Name:         "rxvt",
-		Columns:      80,
-		Lines:        24,
-		Colors:       8,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[2J",
-		EnterCA:      "\x1b7\x1b[?47h",
-		ExitCA:       "\x1b[2J\x1b[?47l\x1b8",
-		ShowCursor:   "\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[m\x0f",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b=",
-		ExitKeypad:   "\x1b>",
-		SetFg:        "\x1b[3%p1%dm",
-		SetBg:        "\x1b[4%p1%dm",
-		SetFgBg:      "\x1b[3%p1%d;4%p2%dm",
-		ResetFgBg:    "\x1b[39;49m",
-		PadChar:      "\x00",
-		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x0e",
-		ExitAcs:      "\x0f",
-		EnableAcs:    "\x1b(B\x1b)0",
-		Mouse:        "\x1b[M",
-		MouseMode:    "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\u007f",
-		KeyHome:      "\x1b[7~",
-		KeyEnd:       "\x1b[8~",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1b[11~",
-		KeyF2:        "\x1b[12~",
-		KeyF3:        "\x1b[13~",
-		KeyF4:        "\x1b[14~",
-		KeyF5:        "\x1b[15~",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyBacktab:   "\x1b[Z",
-		KeyShfLeft:   "\x1b[d",
-		KeyShfRight:  "\x1b[c",
-		KeyShfUp:     "\x1b[a",
-		KeyShfDown:   "\x1b[b",
-		KeyShfHome:   "\x1b[7$",
-		KeyShfEnd:    "\x1b[8$",
-		KeyShfDelete: "\x1b[3$",
-		KeyCtrlUp:    "\x1b[Oa",
-		KeyCtrlDown:  "\x1b[Ob",
-		KeyCtrlRight: "\x1b[Oc",
-		KeyCtrlLeft:  "\x1b[Od",
-		KeyCtrlHome:  "\x1b[7^",
-		KeyCtrlEnd:   "\x1b[8^",
+		Name:           "rxvt",
+		Columns:        80,
+		Lines:          24,
+		Colors:         8,
+		Bell:           "\a",
+		Clear:          "\x1b[H\x1b[2J",
+		EnterCA:        "\x1b7\x1b[?47h",
+		ExitCA:         "\x1b[2J\x1b[?47l\x1b8",
+		Init1:          "\x1b[?47l\x1b=\x1b[?1l",
+		Init2:          "\x1b[r\x1b[m\x1b[2J\x1b[H\x1b[?7h\x1b[?1;3;4;6l\x1b[4l",
+		Reset1:         "\x1b>\x1b[1;3;4;5;6l\x1b[?7h\x1b[m\x1b[r\x1b[2J\x1b[H",
+		Reset2:         "\x1b[r\x1b[m\x1b[2J\x1b[H\x1b[?7h\x1b[?1;3;4;6l\x1b[4l\x1b>\x1b[?1000l\x1b[?25h",
+		ShowCursor:     "\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[m\x0f",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b=",
+		ExitKeypad:     "\x1b>",
+		SetFg:          "\x1b[3%p1%dm",
+		SetBg:          "\x1b[4%p1%dm",
+		SetFgBg:        "\x1b[3%p1%d;4%p2%dm",
+		ResetFgBg:      "\x1b[39;49m",
+		PadChar:        "\x00",
+		AltChars:       "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x0e",
+		ExitAcs:        "\x0f",
+		EnableAcs:      "\x1b(B\x1b)0",
+		Mouse:          "\x1b[M",
+		MouseMode:      "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\u007f",
+		KeyHome:        "\x1b[7~",
+		KeyEnd:         "\x1b[8~",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1b[11~",
+		KeyF2:          "\x1b[12~",
+		KeyF3:          "\x1b[13~",
+		KeyF4:          "\x1b[14~",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyFunctions:   functionKeys,
+		KeyBacktab:     "\x1b[Z",
+		KeyShfLeft:     "\x1b[d",
+		KeyShfRight:    "\x1b[c",
+		KeyShfUp:       "\x1b[a",
+		KeyShfDown:     "\x1b[b",
+		KeyShfHome:     "\x1b[7$",
+		KeyShfEnd:      "\x1b[8$",
+		KeyShfDelete:   "\x1b[3$",
+		KeyCtrlUp:      "\x1b[Oa",
+		KeyCtrlDown:    "\x1b[Ob",
+		KeyCtrlRight:   "\x1b[Oc",
+		KeyCtrlLeft:    "\x1b[Od",
+		KeyCtrlHome:    "\x1b[7^",
+		KeyCtrlEnd:     "\x1b[8^",
+		ClearToEOL:     "\x1b[K",
+		ClearToEOS:     "\x1b[J",
+		BackColorErase: true,
+		InsertLine:     "\x1b[L",
+		InsertLines:    "\x1b[%p1%dL",
+		DeleteLine:     "\x1b[M",
+		DeleteLines:    "\x1b[%p1%dM",
+		InsertChars:    "\x1b[%p1%d@",
 	})
 
 	// rxvt 2.7.9 with xterm 256-colors
 	info.AddTerminfo(&info.Term{
-		Name:         "rxvt-256color",
-		Columns:      80,
-		Lines:        24,
-		Colors:       256,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[2J",
-		EnterCA:      "\x1b7\x1b[?47h",
-		ExitCA:       "\x1b[2J\x1b[?47l\x1b8",
-		ShowCursor:   "\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[m\x0f",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b=",
-		ExitKeypad:   "\x1b>",
-		SetFg:        "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;m",
-		SetBg:        "\x1b[%?%p1%{8}%<%t4%p1%d%e%p1%{16}%<%t10%p1%{8}%-%d%e48;5;%p1%d%;m",
-		SetFgBg:      "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;;%?%p2%{8}%<%t4%p2%d%e%p2%{16}%<%t10%p2%{8}%-%d%e48;5;%p2%d%;m",
-		ResetFgBg:    "\x1b[39;49m",
-		PadChar:      "\x00",
-		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x0e",
-		ExitAcs:      "\x0f",
-		EnableAcs:    "\x1b(B\x1b)0",
-		Mouse:        "\x1b[M",
-		MouseMode:    "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\u007f",
-		KeyHome:      "\x1b[7~",
-		KeyEnd:       "\x1b[8~",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1b[11~",
-		KeyF2:        "\x1b[12~",
-		KeyF3:        "\x1b[13~",
-		KeyF4:        "\x1b[14~",
-		KeyF5:        "\x1b[15~",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyBacktab:   "\x1b[Z",
-		KeyShfLeft:   "\x1b[d",
-		KeyShfRight:  "\x1b[c",
-		KeyShfUp:     "\x1b[a",
-		KeyShfDown:   "\x1b[b",
-		KeyShfHome:   "\x1b[7$",
-		KeyShfEnd:    "\x1b[8$",
-		KeyShfDelete: "\x1b[3$",
-		KeyCtrlUp:    "\x1b[Oa",
-		KeyCtrlDown:  "\x1b[Ob",
-		KeyCtrlRight: "\x1b[Oc",
-		KeyCtrlLeft:  "\x1b[Od",
-		KeyCtrlHome:  "\x1b[7^",
-		KeyCtrlEnd:   "\x1b[8^",
+		Name:           "rxvt-256color",
+		Columns:        80,
+		Lines:          24,
+		Colors:         256,
+		Bell:           "\a",
+		Clear:          "\x1b[H\x1b[2J",
+		EnterCA:        "\x1b7\x1b[?47h",
+		ExitCA:         "\x1b[2J\x1b[?47l\x1b8",
+		Init1:          "\x1b[?47l\x1b=\x1b[?1l",
+		Init2:          "\x1b[r\x1b[m\x1b[2J\x1b[H\x1b[?7h\x1b[?1;3;4;6l\x1b[4l",
+		Reset1:         "\x1b>\x1b[1;3;4;5;6l\x1b[?7h\x1b[m\x1b[r\x1b[2J\x1b[H",
+		Reset2:         "\x1b[r\x1b[m\x1b[2J\x1b[H\x1b[?7h\x1b[?1;3;4;6l\x1b[4l\x1b>\x1b[?1000l\x1b[?25h",
+		ShowCursor:     "\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[m\x0f",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b=",
+		ExitKeypad:     "\x1b>",
+		SetFg:          "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;m",
+		SetBg:          "\x1b[%?%p1%{8}%<%t4%p1%d%e%p1%{16}%<%t10%p1%{8}%-%d%e48;5;%p1%d%;m",
+		SetFgBg:        "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;;%?%p2%{8}%<%t4%p2%d%e%p2%{16}%<%t10%p2%{8}%-%d%e48;5;%p2%d%;m",
+		ResetFgBg:      "\x1b[39;49m",
+		PadChar:        "\x00",
+		AltChars:       "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x0e",
+		ExitAcs:        "\x0f",
+		EnableAcs:      "\x1b(B\x1b)0",
+		Mouse:          "\x1b[M",
+		MouseMode:      "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\u007f",
+		KeyHome:        "\x1b[7~",
+		KeyEnd:         "\x1b[8~",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1b[11~",
+		KeyF2:          "\x1b[12~",
+		KeyF3:          "\x1b[13~",
+		KeyF4:          "\x1b[14~",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyFunctions:   functionKeys,
+		KeyBacktab:     "\x1b[Z",
+		KeyShfLeft:     "\x1b[d",
+		KeyShfRight:    "\x1b[c",
+		KeyShfUp:       "\x1b[a",
+		KeyShfDown:     "\x1b[b",
+		KeyShfHome:     "\x1b[7$",
+		KeyShfEnd:      "\x1b[8$",
+		KeyShfDelete:   "\x1b[3$",
+		KeyCtrlUp:      "\x1b[Oa",
+		KeyCtrlDown:    "\x1b[Ob",
+		KeyCtrlRight:   "\x1b[Oc",
+		KeyCtrlLeft:    "\x1b[Od",
+		KeyCtrlHome:    "\x1b[7^",
+		KeyCtrlEnd:     "\x1b[8^",
+		ClearToEOL:     "\x1b[K",
+		ClearToEOS:     "\x1b[J",
+		BackColorErase: true,
+		InsertLine:     "\x1b[L",
+		InsertLines:    "\x1b[%p1%dL",
+		DeleteLine:     "\x1b[M",
+		DeleteLines:    "\x1b[%p1%dM",
+		InsertChars:    "\x1b[%p1%d@",
 	})
 
 	// rxvt 2.7.9 with xterm 88-colors
 	info.AddTerminfo(&info.Term{
-		Name:         "rxvt-88color",
-		Columns:      80,
-		Lines:        24,
-		Colors:       88,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[2J",
-		EnterCA:      "\x1b7\x1b[?47h",
-		ExitCA:       "\x1b[2J\x1b[?47l\x1b8",
-		ShowCursor:   "\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[m\x0f",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b=",
-		ExitKeypad:   "\x1b>",
-		SetFg:        "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;m",
-		SetBg:        "\x1b[%?%p1%{8}%<%t4%p1%d%e%p1%{16}%<%t10%p1%{8}%-%d%e48;5;%p1%d%;m",
-		SetFgBg:      "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;;%?%p2%{8}%<%t4%p2%d%e%p2%{16}%<%t10%p2%{8}%-%d%e48;5;%p2%d%;m",
-		ResetFgBg:    "\x1b[39;49m",
-		PadChar:      "\x00",
-		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x0e",
-		ExitAcs:      "\x0f",
-		EnableAcs:    "\x1b(B\x1b)0",
-		Mouse:        "\x1b[M",
-		MouseMode:    "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\u007f",
-		KeyHome:      "\x1b[7~",
-		KeyEnd:       "\x1b[8~",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1b[11~",
-		KeyF2:        "\x1b[12~",
-		KeyF3:        "\x1b[13~",
-		KeyF4:        "\x1b[14~",
-		KeyF5:        "\x1b[15~",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyBacktab:   "\x1b[Z",
-		KeyShfLeft:   "\x1b[d",
-		KeyShfRight:  "\x1b[c",
-		KeyShfUp:     "\x1b[a",
-		KeyShfDown:   "\x1b[b",
-		KeyShfHome:   "\x1b[7$",
-		KeyShfEnd:    "\x1b[8$",
-		KeyShfDelete: "\x1b[3$",
-		KeyCtrlUp:    "\x1b[Oa",
-		KeyCtrlDown:  "\x1b[Ob",
-		KeyCtrlRight: "\x1b[Oc",
-		KeyCtrlLeft:  "\x1b[Od",
-		KeyCtrlHome:  "\x1b[7^",
-		KeyCtrlEnd:   "\x1b[8^",
+		Name:           "rxvt-88color",
+		Columns:        80,
+		Lines:          24,
+		Colors:         88,
+		Bell:           "\a",
+		Clear:          "\x1b[H\x1b[2J",
+		EnterCA:        "\x1b7\x1b[?47h",
+		ExitCA:         "\x1b[2J\x1b[?47l\x1b8",
+		Init1:          "\x1b[?47l\x1b=\x1b[?1l",
+		Init2:          "\x1b[r\x1b[m\x1b[2J\x1b[H\x1b[?7h\x1b[?1;3;4;6l\x1b[4l",
+		Reset1:         "\x1b>\x1b[1;3;4;5;6l\x1b[?7h\x1b[m\x1b[r\x1b[2J\x1b[H",
+		Reset2:         "\x1b[r\x1b[m\x1b[2J\x1b[H\x1b[?7h\x1b[?1;3;4;6l\x1b[4l\x1b>\x1b[?1000l\x1b[?25h",
+		ShowCursor:     "\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[m\x0f",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b=",
+		ExitKeypad:     "\x1b>",
+		SetFg:          "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;m",
+		SetBg:          "\x1b[%?%p1%{8}%<%t4%p1%d%e%p1%{16}%<%t10%p1%{8}%-%d%e48;5;%p1%d%;m",
+		SetFgBg:        "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;;%?%p2%{8}%<%t4%p2%d%e%p2%{16}%<%t10%p2%{8}%-%d%e48;5;%p2%d%;m",
+		ResetFgBg:      "\x1b[39;49m",
+		PadChar:        "\x00",
+		AltChars:       "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x0e",
+		ExitAcs:        "\x0f",
+		EnableAcs:      "\x1b(B\x1b)0",
+		Mouse:          "\x1b[M",
+		MouseMode:      "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\u007f",
+		KeyHome:        "\x1b[7~",
+		KeyEnd:         "\x1b[8~",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1b[11~",
+		KeyF2:          "\x1b[12~",
+		KeyF3:          "\x1b[13~",
+		KeyF4:          "\x1b[14~",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyFunctions:   functionKeys,
+		KeyBacktab:     "\x1b[Z",
+		KeyShfLeft:     "\x1b[d",
+		KeyShfRight:    "\x1b[c",
+		KeyShfUp:       "\x1b[a",
+		KeyShfDown:     "\x1b[b",
+		KeyShfHome:     "\x1b[7$",
+		KeyShfEnd:      "\x1b[8$",
+		KeyShfDelete:   "\x1b[3$",
+		KeyCtrlUp:      "\x1b[Oa",
+		KeyCtrlDown:    "\x1b[Ob",
+		KeyCtrlRight:   "\x1b[Oc",
+		KeyCtrlLeft:    "\x1b[Od",
+		KeyCtrlHome:    "\x1b[7^",
+		KeyCtrlEnd:     "\x1b[8^",
+		ClearToEOL:     "\x1b[K",
+		ClearToEOS:     "\x1b[J",
+		BackColorErase: true,
+		InsertLine:     "\x1b[L",
+		InsertLines:    "\x1b[%p1%dL",
+		DeleteLine:     "\x1b[M",
+		DeleteLines:    "\x1b[%p1%dM",
+		InsertChars:    "\x1b[%p1%d@",
 	})
 
 	// rxvt-unicode terminal (X Window System)
 	info.AddTerminfo(&info.Term{
-		Name:         "rxvt-unicode",
-		Columns:      80,
-		Lines:        24,
-		Colors:       88,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[2J",
-		EnterCA:      "\x1b[?1049h",
-		ExitCA:       "\x1b[r\x1b[?1049l",
-		ShowCursor:   "\x1b[?12l\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[m\x1b(B",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Italic:       "\x1b[3m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b=",
-		ExitKeypad:   "\x1b>",
-		SetFg:        "\x1b[38;5;%p1%dm",
-		SetBg:        "\x1b[48;5;%p1%dm",
-		SetFgBg:      "\x1b[38;5;%p1%d;48;5;%p2%dm",
-		ResetFgBg:    "\x1b[39;49m",
-		AltChars:     "+C,D-A.B0E``aaffgghFiGjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x1b(0",
-		ExitAcs:      "\x1b(B",
-		Mouse:        "\x1b[M",
-		MouseMode:    "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\u007f",
-		KeyHome:      "\x1b[7~",
-		KeyEnd:       "\x1b[8~",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1b[11~",
-		KeyF2:        "\x1b[12~",
-		KeyF3:        "\x1b[13~",
-		KeyF4:        "\x1b[14~",
-		KeyF5:        "\x1b[15~",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyBacktab:   "\x1b[Z",
-		KeyShfLeft:   "\x1b[d",
-		KeyShfRight:  "\x1b[c",
-		KeyShfUp:     "\x1b[a",
-		KeyShfDown:   "\x1b[b",
-		KeyShfHome:   "\x1b[7$",
-		KeyShfEnd:    "\x1b[8$",
-		KeyShfInsert: "\x1b[2$",
-		KeyShfDelete: "\x1b[3$",
-		KeyCtrlUp:    "\x1b[Oa",
-		KeyCtrlDown:  "\x1b[Ob",
-		KeyCtrlRight: "\x1b[Oc",
-		KeyCtrlLeft:  "\x1b[Od",
-		KeyCtrlHome:  "\x1b[7^",
-		KeyCtrlEnd:   "\x1b[8^",
+		Name:           "rxvt-unicode",
+		Columns:        80,
+		Lines:          24,
+		Colors:         88,
+		Bell:           "\a",
+		Clear:          "\x1b[H\x1b[2J",
+		EnterCA:        "\x1b[?1049h",
+		ExitCA:         "\x1b[r\x1b[?1049l",
+		ShowCursor:     "\x1b[?12l\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[m\x1b(B",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Italic:         "\x1b[3m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b=",
+		ExitKeypad:     "\x1b>",
+		SetFg:          "\x1b[38;5;%p1%dm",
+		SetBg:          "\x1b[48;5;%p1%dm",
+		SetFgBg:        "\x1b[38;5;%p1%d;48;5;%p2%dm",
+		ResetFgBg:      "\x1b[39;49m",
+		AltChars:       "+C,D-A.B0E``aaffgghFiGjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x1b(0",
+		ExitAcs:        "\x1b(B",
+		Mouse:          "\x1b[M",
+		MouseMode:      "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\u007f",
+		KeyHome:        "\x1b[7~",
+		KeyEnd:         "\x1b[8~",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1b[11~",
+		KeyF2:          "\x1b[12~",
+		KeyF3:          "\x1b[13~",
+		KeyF4:          "\x1b[14~",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyFunctions:   functionKeys,
+		KeyBacktab:     "\x1b[Z",
+		KeyShfLeft:     "\x1b[d",
+		KeyShfRight:    "\x1b[c",
+		KeyShfUp:       "\x1b[a",
+		KeyShfDown:     "\x1b[b",
+		KeyShfHome:     "\x1b[7$",
+		KeyShfEnd:      "\x1b[8$",
+		KeyShfInsert:   "\x1b[2$",
+		KeyShfDelete:   "\x1b[3$",
+		KeyCtrlUp:      "\x1b[Oa",
+		KeyCtrlDown:    "\x1b[Ob",
+		KeyCtrlRight:   "\x1b[Oc",
+		KeyCtrlLeft:    "\x1b[Od",
+		KeyCtrlHome:    "\x1b[7^",
+		KeyCtrlEnd:     "\x1b[8^",
+		ClearToEOL:     "\x1b[K",
+		ClearToEOS:     "\x1b[J",
+		BackColorErase: true,
+		InsertLine:     "\x1b[L",
+		InsertLines:    "\x1b[%p1%dL",
+		DeleteLine:     "\x1b[M",
+		DeleteLines:    "\x1b[%p1%dM",
+		InsertChars:    "\x1b[%p1%d@",
+		DeleteChar:     "\x1b[P",
+		DeleteChars:    "\x1b[%p1%dP",
 	})
 
 	// rxvt-unicode terminal with 256 colors (X Window System)
 	info.AddTerminfo(&info.Term{
-		Name:         "rxvt-unicode-256color",
-		Columns:      80,
-		Lines:        24,
-		Colors:       256,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[2J",
-		EnterCA:      "\x1b[?1049h",
-		ExitCA:       "\x1b[r\x1b[?1049l",
-		ShowCursor:   "\x1b[?12l\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[m\x1b(B",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Italic:       "\x1b[3m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b=",
-		ExitKeypad:   "\x1b>",
-		SetFg:        "\x1b[38;5;%p1%dm",
-		SetBg:        "\x1b[48;5;%p1%dm",
-		SetFgBg:      "\x1b[38;5;%p1%d;48;5;%p2%dm",
-		ResetFgBg:    "\x1b[39;49m",
-		AltChars:     "+C,D-A.B0E``aaffgghFiGjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x1b(0",
-		ExitAcs:      "\x1b(B",
-		Mouse:        "\x1b[M",
-		MouseMode:    "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\u007f",
-		KeyHome:      "\x1b[7~",
-		KeyEnd:       "\x1b[8~",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1b[11~",
-		KeyF2:        "\x1b[12~",
-		KeyF3:        "\x1b[13~",
-		KeyF4:        "\x1b[14~",
-		KeyF5:        "\x1b[15~",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyBacktab:   "\x1b[Z",
-		KeyShfLeft:   "\x1b[d",
-		KeyShfRight:  "\x1b[c",
-		KeyShfUp:     "\x1b[a",
-		KeyShfDown:   "\x1b[b",
-		KeyShfHome:   "\x1b[7$",
-		KeyShfEnd:    "\x1b[8$",
-		KeyShfInsert: "\x1b[2$",
-		KeyShfDelete: "\x1b[3$",
-		KeyCtrlUp:    "\x1b[Oa",
-		KeyCtrlDown:  "\x1b[Ob",
-		KeyCtrlRight: "\x1b[Oc",
-		KeyCtrlLeft:  "\x1b[Od",
-		KeyCtrlHome:  "\x1b[7^",
-		KeyCtrlEnd:   "\x1b[8^",
+		Name:           "rxvt-unicode-256color",
+		Columns:        80,
+		Lines:          24,
+		Colors:         256,
+		Bell:           "\a",
+		Clear:          "\x1b[H\x1b[2J",
+		EnterCA:        "\x1b[?1049h",
+		ExitCA:         "\x1b[r\x1b[?1049l",
+		ShowCursor:     "\x1b[?12l\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[m\x1b(B",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Italic:         "\x1b[3m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b=",
+		ExitKeypad:     "\x1b>",
+		SetFg:          "\x1b[38;5;%p1%dm",
+		SetBg:          "\x1b[48;5;%p1%dm",
+		SetFgBg:        "\x1b[38;5;%p1%d;48;5;%p2%dm",
+		ResetFgBg:      "\x1b[39;49m",
+		AltChars:       "+C,D-A.B0E``aaffgghFiGjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x1b(0",
+		ExitAcs:        "\x1b(B",
+		Mouse:          "\x1b[M",
+		MouseMode:      "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\u007f",
+		KeyHome:        "\x1b[7~",
+		KeyEnd:         "\x1b[8~",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1b[11~",
+		KeyF2:          "\x1b[12~",
+		KeyF3:          "\x1b[13~",
+		KeyF4:          "\x1b[14~",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyFunctions:   functionKeys,
+		KeyBacktab:     "\x1b[Z",
+		KeyShfLeft:     "\x1b[d",
+		KeyShfRight:    "\x1b[c",
+		KeyShfUp:       "\x1b[a",
+		KeyShfDown:     "\x1b[b",
+		KeyShfHome:     "\x1b[7$",
+		KeyShfEnd:      "\x1b[8$",
+		KeyShfInsert:   "\x1b[2$",
+		KeyShfDelete:   "\x1b[3$",
+		KeyCtrlUp:      "\x1b[Oa",
+		KeyCtrlDown:    "\x1b[Ob",
+		KeyCtrlRight:   "\x1b[Oc",
+		KeyCtrlLeft:    "\x1b[Od",
+		KeyCtrlHome:    "\x1b[7^",
+		KeyCtrlEnd:     "\x1b[8^",
+		ClearToEOL:     "\x1b[K",
+		ClearToEOS:     "\x1b[J",
+		BackColorErase: true,
+		InsertLine:     "\x1b[L",
+		InsertLines:    "\x1b[%p1%dL",
+		DeleteLine:     "\x1b[M",
+		DeleteLines:    "\x1b[%p1%dM",
+		InsertChars:    "\x1b[%p1%d@",
+		DeleteChar:     "\x1b[P",
+		DeleteChars:    "\x1b[%p1%dP",
 	})
 }
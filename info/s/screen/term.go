@@ -14,6 +14,8 @@ func init() {
 		Clear:        "\x1b[H\x1b[J",
 		EnterCA:      "\x1b[?1049h",
 		ExitCA:       "\x1b[?1049l",
+		Init2:        "\x1b)0",
+		Reset2:       "\x1bc\x1b[?1000l\x1b[?25h",
 		ShowCursor:   "\x1b[34h\x1b[?25h",
 		HideCursor:   "\x1b[?25l",
 		AttrOff:      "\x1b[m\x0f",
@@ -62,6 +64,15 @@ func init() {
 		KeyF11:       "\x1b[23~",
 		KeyF12:       "\x1b[24~",
 		KeyBacktab:   "\x1b[Z",
+		ClearToEOL:   "\x1b[K",
+		ClearToEOS:   "\x1b[J",
+		InsertLine:   "\x1b[L",
+		InsertLines:  "\x1b[%p1%dL",
+		DeleteLine:   "\x1b[M",
+		DeleteLines:  "\x1b[%p1%dM",
+		InsertChars:  "\x1b[%p1%d@",
+		DeleteChar:   "\x1b[P",
+		DeleteChars:  "\x1b[%p1%dP",
 	})
 
 	// GNU Screen with 256 colors
@@ -74,6 +85,8 @@ func init() {
 		Clear:        "\x1b[H\x1b[J",
 		EnterCA:      "\x1b[?1049h",
 		ExitCA:       "\x1b[?1049l",
+		Init2:        "\x1b)0",
+		Reset2:       "\x1bc\x1b[?1000l\x1b[?25h",
 		ShowCursor:   "\x1b[34h\x1b[?25h",
 		HideCursor:   "\x1b[?25l",
 		AttrOff:      "\x1b[m\x0f",
@@ -122,5 +135,14 @@ func init() {
 		KeyF11:       "\x1b[23~",
 		KeyF12:       "\x1b[24~",
 		KeyBacktab:   "\x1b[Z",
+		ClearToEOL:   "\x1b[K",
+		ClearToEOS:   "\x1b[J",
+		InsertLine:   "\x1b[L",
+		InsertLines:  "\x1b[%p1%dL",
+		DeleteLine:   "\x1b[M",
+		DeleteLines:  "\x1b[%p1%dM",
+		InsertChars:  "\x1b[%p1%d@",
+		DeleteChar:   "\x1b[P",
+		DeleteChars:  "\x1b[%p1%dP",
 	})
 }
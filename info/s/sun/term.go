@@ -12,6 +12,7 @@ func init() {
 		Lines:        34,
 		Bell:         "\a",
 		Clear:        "\f",
+		Reset2:       "\x1b[s",
 		AttrOff:      "\x1b[m",
 		Reverse:      "\x1b[7m",
 		PadChar:      "\x00",
@@ -41,6 +42,16 @@ func init() {
 		KeyF10:       "\x1b[233z",
 		KeyF11:       "\x1b[234z",
 		KeyF12:       "\x1b[235z",
+		ClearToEOL:   "\x1b[K",
+		ClearToEOS:   "\x1b[J",
+		InsertLine:   "\x1b[L",
+		InsertLines:  "\x1b[%p1%dL",
+		DeleteLine:   "\x1b[M",
+		DeleteLines:  "\x1b[%p1%dM",
+		InsertChar:   "\x1b[@",
+		InsertChars:  "\x1b[%p1%d@",
+		DeleteChar:   "\x1b[P",
+		DeleteChars:  "\x1b[%p1%dP",
 	})
 
 	// Sun Microsystems Workstation console with color support (IA systems)
@@ -51,6 +62,7 @@ func init() {
 		Colors:       8,
 		Bell:         "\a",
 		Clear:        "\f",
+		Reset2:       "\x1b[s",
 		AttrOff:      "\x1b[m",
 		Bold:         "\x1b[1m",
 		Reverse:      "\x1b[7m",
@@ -85,5 +97,15 @@ func init() {
 		KeyF10:       "\x1b[233z",
 		KeyF11:       "\x1b[234z",
 		KeyF12:       "\x1b[235z",
+		ClearToEOL:   "\x1b[K",
+		ClearToEOS:   "\x1b[J",
+		InsertLine:   "\x1b[L",
+		InsertLines:  "\x1b[%p1%dL",
+		DeleteLine:   "\x1b[M",
+		DeleteLines:  "\x1b[%p1%dM",
+		InsertChar:   "\x1b[@",
+		InsertChars:  "\x1b[%p1%d@",
+		DeleteChar:   "\x1b[P",
+		DeleteChars:  "\x1b[%p1%dP",
 	})
 }
@@ -14,6 +14,9 @@ func init() {
 		Clear:        "\x1b[H\x1b[2J",
 		EnterCA:      "\x1b[?1049h",
 		ExitCA:       "\x1b[?1049l",
+		Init2:        "\x1b[!p\x1b[?3;4l\x1b[4l\x1b>",
+		Reset1:       "\x1bc",
+		Reset2:       "\x1b[!p\x1b[?3;4l\x1b[4l\x1b>",
 		ShowCursor:   "\x1b[?12l\x1b[?25h",
 		HideCursor:   "\x1b[?25l",
 		AttrOff:      "\x1b(B\x1b[m",
@@ -60,5 +63,15 @@ func init() {
 		KeyF12:       "\x1b[24~",
 		KeyBacktab:   "\x1b[Z",
 		Modifiers:    1,
+		ClearToEOL:   "\x1b[K",
+		ClearToEOS:   "\x1b[J",
+		InsertLine:   "\x1b[L",
+		InsertLines:  "\x1b[%p1%dL",
+		DeleteLine:   "\x1b[M",
+		DeleteLines:  "\x1b[%p1%dM",
+		InsertChar:   "\x1b[@",
+		InsertChars:  "\x1b[%p1%d@",
+		DeleteChar:   "\x1b[P",
+		DeleteChars:  "\x1b[%p1%dP",
 	})
 }
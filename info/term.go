@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"os"
 	"strconv"
 	"strings"
@@ -30,6 +29,11 @@ const (
 	XTerm = 1
 )
 
+const (
+	maxGoToCacheSize = 1 << 16 // huge terminals get only this many goto commands cached
+	maxFormatDigits  = 3       // TParam ignores printf formats having wider width or precision, which would allocate huge strings
+)
+
 type stackElem struct {
 	s     string
 	i     int
@@ -76,9 +80,10 @@ func (s *headTailLinkedList) shift() stackElem {
 	var firstContainerItem *list.Element = nil
 
 	firstContainerItem = s.container.Front()
-	if firstContainerItem != nil {
-		item = s.container.Remove(firstContainerItem)
+	if firstContainerItem == nil {
+		return stackElem{} // popping an empty stack (malformed capability) yields zero
 	}
+	item = s.container.Remove(firstContainerItem)
 
 	return item.(stackElem)
 }
@@ -174,8 +179,22 @@ type Term struct {
 	Name         string
 	Bell         string // bell
 	Clear        string // clear
+	ClearToEOL   string // el
+	ClearToEOS   string // ed
+	InsertLine   string // il1
+	InsertLines  string // il
+	DeleteLine   string // dl1
+	DeleteLines  string // dl
+	InsertChar   string // ich1
+	InsertChars  string // ich
+	DeleteChar   string // dch1
+	DeleteChars  string // dch
 	EnterCA      string // smcup
 	ExitCA       string // rmcup
+	Init1        string // is1 : the first of the initialization strings, sent on start
+	Init2        string // is2
+	Reset1       string // rs1 : the first of the reset strings, which bring the terminal back to a sane state
+	Reset2       string // rs2
 	ShowCursor   string // cnorm
 	HideCursor   string // civis
 	AttrOff      string // sgr0
@@ -236,6 +255,17 @@ type Term struct {
 	KeyShfInsert string // kIC
 	KeyShfDelete string // kDC
 
+	KeyFunctions []string // kf13 to kf64 : the function keys beyond F12, which are F1 to F12 combined with modifiers
+
+	// Extended (user-defined) capabilities, see user_caps(5). Empty, unless the terminal definition has them.
+	UnderlineStyle   string // Smulx : styled underline (e.g. curly, dotted), the parameter is the style
+	UnderlineColor   string // Setulc : underline color
+	CursorStyle      string // Ss : cursor shape (DECSCUSR), the parameter is the shape
+	ResetCursorStyle string // Se
+	CursorColor      string // Cs : cursor color, the parameter is the color name or spec
+	ResetCursorColor string // Cr
+	SetClipboard     string // Ms : sets the selection (OSC 52), the parameters are the selection and the base64 content
+
 	// emulations, so don't depend too much on them in your application.
 	// Terminal support for these are going to vary amongst XTerm that shifted variants of left and right exist, but not up and down. true color support, and some additional keys.
 	// These are non-standard extensions to info.
@@ -286,56 +316,79 @@ type Term struct {
 	KeyMetaShfHome  string
 	KeyMetaShfEnd   string
 	Aliases         []string
-	TrueColor       bool // true if the terminal supports direct color
+	TrueColor       bool              // true if the terminal supports direct color
+	BackColorErase  bool              // bce : true if clearing the screen (or a part of it) uses the current background color
+	XonXoff         bool              // xon : true if the terminal uses the XON/XOFF flow control, so it doesn't need the padding
+	Strings         map[string]string // every string capability, by its terminfo name (e.g. "flash", "tsl"). Optional, typed fields above take precedence
 }
 
 type Commander struct {
 	*paramsBuffer
-	bGotos        *gotoCache
-	bColors       *colorCache
-	Colors        int // colors
-	Columns       int // cols
-	Lines         int // lines
-	svars         [26]string
-	PadChar       string
-	SetFg         string // setaf
-	SetBg         string // setab
-	SetFgBg       string // setfgbg
-	SetFgBgRGB    string // setfgbgrgb
-	SetFgRGB      string // setfrgb
-	SetBgRGB      string // setbrgb
-	SetCursor     string // cup
-	EnterAcs      string // smacs
-	ExitAcs       string // rmacs
-	AltChars      string // acsc
-	Clear         string // clear
-	HideCursor    string // civis
-	ShowCursor    string // cnorm
-	EnterCA       string
-	EnableAcs     string
-	AttrOff       string
-	ExitCA        string
-	ExitKeypad    string
-	Bold          string
-	Underline     string
-	Reverse       string
-	Blink         string
-	Dim           string
-	Italic        string
-	StrikeThrough string
-	ResetFgBg     string
-	EnableMouse   string
-	DisableMouse  string
-	HasMouse      bool
-	HasHideCursor bool
+	bGotos         *gotoCache
+	bColors        *colorCache
+	Colors         int // colors
+	Columns        int // cols
+	Lines          int // lines
+	svars          [26]string
+	PadChar        string
+	SetFg          string // setaf
+	SetBg          string // setab
+	SetFgBg        string // setfgbg
+	SetFgBgRGB     string // setfgbgrgb
+	SetFgRGB       string // setfrgb
+	SetBgRGB       string // setbrgb
+	SetCursor      string // cup
+	EnterAcs       string // smacs
+	ExitAcs        string // rmacs
+	AltChars       string // acsc
+	Clear          string // clear
+	ClearToEOL     string // el
+	ClearToEOS     string // ed
+	InsertLine     string // il1
+	InsertLines    string // il
+	DeleteLine     string // dl1
+	DeleteLines    string // dl
+	InsertChar     string // ich1
+	InsertChars    string // ich
+	DeleteChar     string // dch1
+	DeleteChars    string // dch
+	HideCursor     string // civis
+	ShowCursor     string // cnorm
+	EnterCA        string
+	EnableAcs      string
+	AttrOff        string
+	ExitCA         string
+	Init           string // is1 and is2
+	Reset          string // rs1 and rs2
+	ExitKeypad     string
+	Bold           string
+	Underline      string
+	Reverse        string
+	Blink          string
+	Dim            string
+	Italic         string
+	StrikeThrough  string
+	ResetFgBg      string
+	EnableMouse    string
+	DisableMouse   string
+	HasMouse       bool
+	HasHideCursor  bool
+	BackColorErase bool              // bce
+	XonXoff        bool              // xon
+	Baud           int               // the speed of the line, in bits per second, set by the engine (see core.WithBaud). Zero is unknown
+	caps           map[string]string // every known string capability, by its terminfo name
 }
 
 type colorCache struct {
 	mapb map[string][]byte
 }
 
+// gotoCache holds goto commands for the positions inside the screen, being filled on demand
 type gotoCache struct {
-	mapb map[int][]byte
+	sync.RWMutex
+	mapb    map[int][]byte
+	columns int // bounds of the cache
+	rows    int // bounds of the cache
 }
 
 // paramsBuffer handles some persistent state for TParam.
@@ -449,10 +502,19 @@ func (t *Commander) TParam(s string, ints ...int) string {
 				ch, _ = t.paramsBuffer.next()
 				f += string(ch)
 			}
+			digits, tooWide := 0, false // digits in a row, of either width or precision
 			for (ch >= '0' && ch <= '9') || ch == '.' {
+				if ch == '.' {
+					digits = 0
+				} else if digits++; digits > maxFormatDigits {
+					tooWide = true
+				}
 				ch, _ = t.paramsBuffer.next()
 				f += string(ch)
 			}
+			if tooWide {
+				break
+			}
 			switch ch {
 			case 'd', 'x', 'X', 'o':
 				ai = stk.popInt()
@@ -653,13 +715,13 @@ func (t *Commander) WriteString(w io.Writer, s string) error {
 
 			// Most strings don't need padding!
 			if _, err := io.WriteString(w, s); err != nil {
-				return fmt.Errorf("could not write string : %v", err)
+				return &term.WriteError{Err: err}
 			}
 			return nil
 		}
 
 		if _, err := io.WriteString(w, s[:beg]); err != nil {
-			return fmt.Errorf("could not write string : %v", err)
+			return &term.WriteError{Err: err}
 		}
 		s = s[beg+2:]
 		end := strings.Index(s, ">")
@@ -667,44 +729,19 @@ func (t *Commander) WriteString(w io.Writer, s string) error {
 
 			// unterminated.. just emit bytes unadulterated
 			if _, err := io.WriteString(w, "$<"+s); err != nil {
-				return fmt.Errorf("could not write string : %v", err)
+				return &term.WriteError{Err: err}
 			}
 			return nil
 		}
 		val := s[:end]
 		s = s[end+1:]
-		padDur := 0
-		unit := time.Millisecond
-		dot := false
-	loop:
-		for i := range val {
-			switch val[i] {
-			case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-				padDur *= 10
-				padDur += int(val[i] - '0')
-				if dot {
-					unit /= 10
-				}
-			case '.':
-				if !dot {
-					dot = true
-				} else {
-					break loop
-				}
-			default:
-				break loop
-			}
-		}
-
-		// Curses historically uses padding to achieve "fine grained" delays.
-		// We have much better clocks these days, and so we do not rely on padding but simply sleep a bit.
-		if len(t.PadChar) > 0 {
-			time.Sleep(unit * time.Duration(padDur))
+		if err := t.pad(w, val); err != nil {
+			return err
 		}
 	}
 }
 
-// WriteString emits the string to the writer, but expands inline padding indications (of the form $<[delay]> where [delay] is msec) to a suitable time (unless the info string indicates this isn't needed by specifying npc - no padding).
+// WriteBytes emits the bytes to the writer, but expands inline padding indications (of the form $<[delay]> where [delay] is msec) to a suitable time (unless the info string indicates this isn't needed by specifying npc - no padding).
 // All Term based strings should be emitted using this function.
 func (t *Commander) WriteBytes(w io.Writer, s []byte) error {
 	for {
@@ -712,55 +749,83 @@ func (t *Commander) WriteBytes(w io.Writer, s []byte) error {
 		if beg < 0 {
 			// Most strings don't need padding!
 			if _, err := w.Write(s); err != nil {
-				return fmt.Errorf("could not write string : %v", err)
+				return &term.WriteError{Err: err}
 			}
 			return nil
 		}
 		if _, err := w.Write(s[:beg]); err != nil {
-			return fmt.Errorf("could not write string : %v", err)
+			return &term.WriteError{Err: err}
 		}
 		s = s[beg+2:]
 		end := bytes.Index(s, []byte(">"))
 		if end < 0 {
 			// unterminated.. just emit bytes unadulterated
-			ns := []byte("$>")
+			ns := []byte("$<")
 			ns = append(ns, s...)
 			if _, err := w.Write(ns); err != nil {
-				return fmt.Errorf("could not write string : %v", err)
+				return &term.WriteError{Err: err}
 			}
 			return nil
 		}
 		val := s[:end]
 		s = s[end+1:]
-		padDur := 0
-		unit := time.Millisecond
-		dot := false
-	loop:
-		for i := range val {
-			switch val[i] {
-			case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-				padDur *= 10
-				padDur += int(val[i] - '0')
-				if dot {
-					unit /= 10
-				}
-			case '.':
-				if !dot {
-					dot = true
-				} else {
-					break loop
-				}
-			default:
+		if err := t.pad(w, string(val)); err != nil {
+			return err
+		}
+	}
+}
+
+// padDelay parses the inside of a padding indication : the delay in milliseconds, with an optional decimal, followed by '*' (proportional to the affected lines, which we count as one) and '/' (mandatory, even with the flow control)
+func padDelay(val string) (time.Duration, bool) {
+	padDur := 0
+	unit := time.Millisecond
+	dot := false
+loop:
+	for i := range val {
+		switch val[i] {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			padDur *= 10
+			padDur += int(val[i] - '0')
+			if dot {
+				unit /= 10
+			}
+		case '.':
+			if !dot {
+				dot = true
+			} else {
 				break loop
 			}
+		default:
+			break loop
 		}
+	}
+	return unit * time.Duration(padDur), strings.HasSuffix(val, "/")
+}
 
+// pad gives the terminal the time asked by the padding indication
+func (t *Commander) pad(w io.Writer, val string) error {
+	delay, mandatory := padDelay(val)
+	if delay <= 0 || len(t.PadChar) == 0 {
+		return nil // npc - no padding
+	}
+	if t.XonXoff && !mandatory {
+		return nil // the terminal stops the output by itself, when it can't keep up
+	}
+	if t.Baud <= 0 {
 		// Curses historically uses padding to achieve "fine grained" delays.
 		// We have much better clocks these days, and so we do not rely on padding but simply sleep a bit.
-		if len(t.PadChar) > 0 {
-			time.Sleep(unit * time.Duration(padDur))
-		}
+		time.Sleep(delay)
+		return nil
+	}
+	// on a serial line, the pad characters take the time of the delay to be transmitted (ten bits each : start, eight data bits and stop), while the output written before them is still on the way
+	count := int(delay * time.Duration(t.Baud) / (10 * time.Second))
+	if count <= 0 {
+		return nil
 	}
+	if _, err := w.Write(bytes.Repeat([]byte{t.PadChar[0]}, count)); err != nil {
+		return &term.WriteError{Err: err}
+	}
+	return nil
 }
 
 // TColor returns a string corresponding to the given foreground and background colors.
@@ -787,190 +852,197 @@ func (t *Commander) TColor(fi, bi int) string {
 	return rv
 }
 
-func (t *Commander) PutEnterCA(w io.Writer) {
-	if err := t.WriteString(w, t.EnterCA); err != nil {
-		if Debug {
-			log.Printf("error writing to out : %v", err)
-		}
-	}
+func (t *Commander) PutEnterCA(w io.Writer) error {
+	return t.WriteString(w, t.EnterCA)
 }
 
-func (t *Commander) PutHideCursor(w io.Writer) {
-	if err := t.WriteString(w, t.HideCursor); err != nil {
-		if Debug {
-			log.Printf("error writing to out : %v", err)
-		}
-	}
+func (t *Commander) PutHideCursor(w io.Writer) error {
+	return t.WriteString(w, t.HideCursor)
 }
 
-func (t *Commander) PutShowCursor(w io.Writer) {
-	if err := t.WriteString(w, t.ShowCursor); err != nil {
-		if Debug {
-			log.Printf("error writing to out : %v", err)
-		}
-	}
+func (t *Commander) PutShowCursor(w io.Writer) error {
+	return t.WriteString(w, t.ShowCursor)
 }
 
-func (t *Commander) PutEnableAcs(w io.Writer) {
-	if err := t.WriteString(w, t.EnableAcs); err != nil {
-		if Debug {
-			log.Printf("error writing to out : %v", err)
-		}
-	}
+func (t *Commander) PutEnableAcs(w io.Writer) error {
+	return t.WriteString(w, t.EnableAcs)
 }
 
-func (t *Commander) PutClear(w io.Writer) {
-	if err := t.WriteString(w, t.Clear); err != nil {
-		if Debug {
-			log.Printf("error writing to out : %v", err)
-		}
-	}
+func (t *Commander) PutClear(w io.Writer) error {
+	return t.WriteString(w, t.Clear)
 }
 
-func (t *Commander) PutAttrOff(w io.Writer) {
-	if err := t.WriteString(w, t.AttrOff); err != nil {
-		if Debug {
-			log.Printf("error writing to out : %v", err)
-		}
-	}
+// PutClearToEOL clears from the cursor position to the end of the line
+func (t *Commander) PutClearToEOL(w io.Writer) error {
+	return t.WriteString(w, t.ClearToEOL)
 }
 
-func (t *Commander) PutExitCA(w io.Writer) {
-	if err := t.WriteString(w, t.ExitCA); err != nil {
-		if Debug {
-			log.Printf("error writing to out : %v", err)
-		}
-	}
+// PutClearToEOS clears from the cursor position to the end of the screen
+func (t *Commander) PutClearToEOS(w io.Writer) error {
+	return t.WriteString(w, t.ClearToEOS)
 }
 
-func (t *Commander) PutExitKeypad(w io.Writer) {
-	if err := t.WriteString(w, t.ExitKeypad); err != nil {
-		if Debug {
-			log.Printf("error writing to out : %v", err)
+// putRepeated writes the parameterized capability if available, otherwise the single one, count times.
+// Returns ErrNotSupported (with the name of the parameterized capability) if the terminal has neither of them.
+func (t *Commander) putRepeated(w io.Writer, name, parameterized, single string, count int) error {
+	if len(parameterized) == 0 && len(single) == 0 {
+		return term.ErrNotSupported{Capability: name}
+	}
+	if count <= 0 {
+		return nil
+	}
+	if len(parameterized) > 0 {
+		return t.WriteString(w, t.TParam(parameterized, count))
+	}
+	for i := 0; i < count; i++ {
+		if err := t.WriteString(w, single); err != nil {
+			return err
 		}
 	}
+	return nil
 }
 
-func (t *Commander) PutBold(w io.Writer) {
-	if err := t.WriteString(w, t.Bold); err != nil {
-		if Debug {
-			log.Printf("error writing to out : %v", err)
-		}
-	}
+// CanEditLines returns true if the terminal is able to insert and delete lines
+func (t *Commander) CanEditLines() bool {
+	return (len(t.InsertLines) > 0 || len(t.InsertLine) > 0) && (len(t.DeleteLines) > 0 || len(t.DeleteLine) > 0)
 }
 
-func (t *Commander) PutUnderline(w io.Writer) {
-	if err := t.WriteString(w, t.Underline); err != nil {
-		if Debug {
-			log.Printf("error writing to out : %v", err)
-		}
-	}
+// CanEditChars returns true if the terminal is able to insert and delete characters
+func (t *Commander) CanEditChars() bool {
+	return (len(t.InsertChars) > 0 || len(t.InsertChar) > 0) && (len(t.DeleteChars) > 0 || len(t.DeleteChar) > 0)
 }
 
-func (t *Commander) PutReverse(w io.Writer) {
-	if err := t.WriteString(w, t.Reverse); err != nil {
-		if Debug {
-			log.Printf("error writing to out : %v", err)
-		}
-	}
+// PutInsertLines inserts count blank lines at the cursor row, moving the rest of the lines down. Returns ErrNotSupported if the terminal can't.
+func (t *Commander) PutInsertLines(w io.Writer, count int) error {
+	return t.putRepeated(w, "il", t.InsertLines, t.InsertLine, count)
 }
 
-func (t *Commander) PutBlink(w io.Writer) {
-	if err := t.WriteString(w, t.Blink); err != nil {
-		if Debug {
-			log.Printf("error writing to out : %v", err)
-		}
-	}
+// PutDeleteLines deletes count lines starting with the cursor row, moving the rest of the lines up. Returns ErrNotSupported if the terminal can't.
+func (t *Commander) PutDeleteLines(w io.Writer, count int) error {
+	return t.putRepeated(w, "dl", t.DeleteLines, t.DeleteLine, count)
 }
 
-func (t *Commander) PutDim(w io.Writer) {
-	if err := t.WriteString(w, t.Dim); err != nil {
-		if Debug {
-			log.Printf("error writing to out : %v", err)
-		}
-	}
+// PutInsertChars inserts count blank characters at the cursor position, shifting the rest of the line right. Returns ErrNotSupported if the terminal can't.
+func (t *Commander) PutInsertChars(w io.Writer, count int) error {
+	return t.putRepeated(w, "ich", t.InsertChars, t.InsertChar, count)
 }
 
-func (t *Commander) PutItalic(w io.Writer) {
-	if err := t.WriteString(w, t.Italic); err != nil {
-		if Debug {
-			log.Printf("error writing to out : %v", err)
-		}
-	}
+// PutDeleteChars deletes count characters at the cursor position, shifting the rest of the line left. Returns ErrNotSupported if the terminal can't.
+func (t *Commander) PutDeleteChars(w io.Writer, count int) error {
+	return t.putRepeated(w, "dch", t.DeleteChars, t.DeleteChar, count)
 }
 
-func (t *Commander) PutStrikeThrough(w io.Writer) {
-	if err := t.WriteString(w, t.StrikeThrough); err != nil {
-		if Debug {
-			log.Printf("error writing to out : %v", err)
-		}
-	}
+func (t *Commander) PutAttrOff(w io.Writer) error {
+	return t.WriteString(w, t.AttrOff)
 }
 
-func (t *Commander) PutResetFgBg(w io.Writer) {
-	if err := t.WriteString(w, t.ResetFgBg); err != nil {
-		if Debug {
-			log.Printf("error writing to out : %v", err)
-		}
-	}
+func (t *Commander) PutExitCA(w io.Writer) error {
+	return t.WriteString(w, t.ExitCA)
+}
+
+// PutInit writes the initialization strings (is1, is2), which set the terminal up as its definition expects
+func (t *Commander) PutInit(w io.Writer) error {
+	return t.WriteString(w, t.Init)
+}
+
+// PutReset writes the reset strings (rs1, rs2), which bring the terminal back to a sane state, e.g. after garbage was written
+func (t *Commander) PutReset(w io.Writer) error {
+	return t.WriteString(w, t.Reset)
+}
+
+func (t *Commander) PutExitKeypad(w io.Writer) error {
+	return t.WriteString(w, t.ExitKeypad)
+}
+
+func (t *Commander) PutBold(w io.Writer) error {
+	return t.WriteString(w, t.Bold)
+}
+
+func (t *Commander) PutUnderline(w io.Writer) error {
+	return t.WriteString(w, t.Underline)
+}
+
+func (t *Commander) PutReverse(w io.Writer) error {
+	return t.WriteString(w, t.Reverse)
+}
+
+func (t *Commander) PutBlink(w io.Writer) error {
+	return t.WriteString(w, t.Blink)
 }
 
-func (t *Commander) PutEnableMouse(w io.Writer) {
+func (t *Commander) PutDim(w io.Writer) error {
+	return t.WriteString(w, t.Dim)
+}
+
+func (t *Commander) PutItalic(w io.Writer) error {
+	return t.WriteString(w, t.Italic)
+}
+
+func (t *Commander) PutStrikeThrough(w io.Writer) error {
+	return t.WriteString(w, t.StrikeThrough)
+}
+
+func (t *Commander) PutResetFgBg(w io.Writer) error {
+	return t.WriteString(w, t.ResetFgBg)
+}
+
+func (t *Commander) PutEnableMouse(w io.Writer) error {
 	if !t.HasMouse {
-		return
-	}
-	if err := t.WriteString(w, t.EnableMouse); err != nil {
-		if Debug {
-			log.Printf("error writing to out : %v", err)
-		}
+		return nil
 	}
+	return t.WriteString(w, t.EnableMouse)
 }
 
-func (t *Commander) PutDisableMouse(w io.Writer) {
+func (t *Commander) PutDisableMouse(w io.Writer) error {
 	if !t.HasMouse {
-		return
-	}
-	if err := t.WriteString(w, t.DisableMouse); err != nil {
-		if Debug {
-			log.Printf("error writing to out : %v", err)
-		}
+		return nil
 	}
+	return t.WriteString(w, t.DisableMouse)
 }
 
-// MakeGoToCache - caches goto commands
-func (t *Commander) MakeGoToCache(size *term.Size, hashFn func(column, row int) int) {
-	t.bGotos = &gotoCache{mapb: make(map[int][]byte)}
-	for col := 0; col < size.Columns; col++ {
-		for row := 0; row < size.Rows; row++ {
-			gotoStr := t.TParam(t.SetCursor, row, col)
-			hash := hashFn(col, row)
-			if _, ok := t.bGotos.mapb[hash]; ok {
-				if Debug {
-					log.Printf("hash colision detected %d %d => %d", col, row, hash)
-				}
-			}
-			t.bGotos.mapb[hash] = []byte(gotoStr)
-		}
+// ResizeGoToCache - sets the bounds of the goto cache. Already cached goto commands are kept, since they don't depend on the size.
+func (t *Commander) ResizeGoToCache(size *term.Size) {
+	t.bGotos.Lock()
+	defer t.bGotos.Unlock()
+
+	t.bGotos.columns = size.Columns
+	t.bGotos.rows = size.Rows
+	if size.Columns*size.Rows < len(t.bGotos.mapb) {
+		t.bGotos.mapb = make(map[int][]byte) // shrinking : forget everything, it will be lazily filled again
 	}
 }
 
-// GoTo for addressing the cursor at the given row and column - but using the hash of that position
-func (t *Commander) GoTo(w io.Writer, hash int) {
+// GoTo for addressing the cursor at the given row and column - but using the hash of that position (see term.Hash)
+func (t *Commander) GoTo(w io.Writer, hash int) error {
+	column, row := term.UnHash(hash)
+	return t.GoToXY(w, column, row)
+}
+
+// GoToXY for addressing the cursor at the given column and row. Commands are cached lazily, only for positions inside the screen.
+func (t *Commander) GoToXY(w io.Writer, column, row int) error {
+	return t.WriteBytes(w, t.gotoBytes(column, row)) // cup might ask for padding
+}
+
+// gotoBytes returns the cached goto command, building it if missing
+func (t *Commander) gotoBytes(column, row int) []byte {
+	hash := term.Hash(column, row)
+	t.bGotos.RLock()
 	v, ok := t.bGotos.mapb[hash]
+	t.bGotos.RUnlock()
 	if ok {
-		if _, err := w.Write(v); err != nil {
-			if Debug {
-				log.Printf("error writing to out : %v", err)
-			}
-		}
-		return
+		return v
 	}
-	if Debug {
-		log.Printf("error finding cached value for hash : %d", hash)
+	v = []byte(t.TParam(t.SetCursor, row, column))
+	t.bGotos.Lock()
+	defer t.bGotos.Unlock()
+	if column >= 0 && row >= 0 && column < t.bGotos.columns && row < t.bGotos.rows && len(t.bGotos.mapb) < maxGoToCacheSize {
+		t.bGotos.mapb[hash] = v
 	}
+	return v
 }
 
-func (t *Commander) WriteBothColors(w io.Writer, fg, bg color.Color, isDelighted bool) {
+// WriteBothColors sets the foreground and the background colors, with the indexed (delighted) or the RGB capability
+func (t *Commander) WriteBothColors(w io.Writer, fg, bg color.Color, isDelighted bool) error {
 	fgAndBgNames := ""
 	if isDelighted {
 		fgAndBgNames = fmt.Sprintf("0xFF_%06X_%06X", color.Hex(fg), color.Hex(bg))
@@ -990,14 +1062,11 @@ func (t *Commander) WriteBothColors(w io.Writer, fg, bg color.Color, isDelighted
 		bgFg = []byte(bgFgStr)
 		t.bColors.mapb[fgAndBgNames] = bgFg
 	}
-	if err := t.WriteBytes(w, bgFg); err != nil {
-		if Debug {
-			log.Printf("[core-sendFgBg] error writing string : %v", err)
-		}
-	}
+	return t.WriteBytes(w, bgFg)
 }
 
-func (t *Commander) WriteColor(w io.Writer, c color.Color, isForeground, isDelighted bool) {
+// WriteColor sets the foreground or the background color, with the indexed (delighted) or the RGB capability
+func (t *Commander) WriteColor(w io.Writer, c color.Color, isForeground, isDelighted bool) error {
 	colorName := ""
 	if isDelighted {
 		colorName = fmt.Sprintf("0xFF_%06X", color.Hex(c))
@@ -1024,15 +1093,11 @@ func (t *Commander) WriteColor(w io.Writer, c color.Color, isForeground, isDelig
 		cb = []byte(cs)
 		t.bColors.mapb[colorName] = cb
 	}
-	if err := t.WriteBytes(w, cb); err != nil {
-		if Debug {
-			log.Printf("[core-sendFgBg] error writing string : %v", err)
-		}
-	}
+	return t.WriteBytes(w, cb)
 }
 
 func NewCommander(ti *Term) *Commander {
-	res := Commander{}
+	res := Commander{caps: ti.Capabilities()}
 	// goto optimization : cache the goto instructions for each cell
 	res.bGotos = &gotoCache{mapb: make(map[int][]byte)}
 	res.bColors = &colorCache{mapb: make(map[string][]byte)}
@@ -1041,8 +1106,22 @@ func NewCommander(ti *Term) *Commander {
 	res.ShowCursor = ti.ShowCursor
 	res.EnableAcs = ti.EnableAcs
 	res.Clear = ti.Clear
+	res.ClearToEOL = ti.ClearToEOL
+	res.ClearToEOS = ti.ClearToEOS
+	res.BackColorErase = ti.BackColorErase
+	res.XonXoff = ti.XonXoff
+	res.InsertLine = ti.InsertLine
+	res.InsertLines = ti.InsertLines
+	res.DeleteLine = ti.DeleteLine
+	res.DeleteLines = ti.DeleteLines
+	res.InsertChar = ti.InsertChar
+	res.InsertChars = ti.InsertChars
+	res.DeleteChar = ti.DeleteChar
+	res.DeleteChars = ti.DeleteChars
 	res.AttrOff = ti.AttrOff
 	res.ExitCA = ti.ExitCA
+	res.Init = ti.Init1 + ti.Init2
+	res.Reset = ti.Reset1 + ti.Reset2
 	res.ExitKeypad = ti.ExitKeypad
 	res.Bold = ti.Bold
 	res.Underline = ti.Underline
@@ -1065,6 +1144,8 @@ func NewCommander(ti *Term) *Commander {
 	res.SetBg = ti.SetBg
 	res.SetFgBg = ti.SetFgBg
 	res.SetFgBgRGB = ti.SetFgBgRGB
+	res.SetFgRGB = ti.SetFgRGB
+	res.SetBgRGB = ti.SetBgRGB
 	res.SetCursor = ti.SetCursor
 	res.Clear = ti.Clear
 	res.Lines = ti.Lines
@@ -1097,7 +1178,8 @@ func RemoveAllInfos() {
 	mu.Unlock()
 }
 
-// LookupTerminfo attempts to find a definition for the named $TERM.
+// LookupTerminfo attempts to find a definition for the named $TERM, following the aliases registered via AddAlias.
+// If the name ends in -truecolor, then an entry is fabricated from the corresponding -256color, -color, or bare terminal (see TrueColorBases).
 func LookupTerminfo(name string) (*Term, error) {
 	if name == "" {
 		// else on windows: index out of bounds
@@ -1105,38 +1187,48 @@ func LookupTerminfo(name string) (*Term, error) {
 		return nil, NotFound
 	}
 
-	addTrueColor := false
-	switch os.Getenv("COLORTERM") {
-	case "truecolor", "24bit", "24-bit":
-		addTrueColor = true
-	}
 	mu.Lock()
-	t := infos[name]
+	t := infos[resolveAlias(name)]
 	mu.Unlock()
 
-	// If the name ends in -truecolor, then fabricate an entry from the corresponding -256color, -color, or bare terminal.
-	if t.TrueColor {
-		addTrueColor = true
-	} else if t == nil && strings.HasSuffix(name, "-truecolor") {
-		suffixes := []string{
-			"-256color",
-			"-88color",
-			"-color",
-			"",
-		}
-		base := name[:len(name)-len("-truecolor")]
-		for _, s := range suffixes {
-			if t, _ = LookupTerminfo(base + s); t != nil {
-				addTrueColor = true
+	fabricated := false
+	if t == nil {
+		for _, base := range TrueColorBases(name) {
+			if t, _ = LookupTerminfo(base); t != nil {
+				fabricated = true
 				break
 			}
 		}
 	}
-
 	if t == nil {
 		return nil, NotFound
 	}
 
+	AddTrueColor(t, fabricated)
+	return t, nil
+}
+
+// TrueColorBases returns the names which an entry ending in -truecolor is fabricated from, in order of preference, or nil for other names
+func TrueColorBases(name string) []string {
+	if !strings.HasSuffix(name, "-truecolor") {
+		return nil
+	}
+	base := name[:len(name)-len("-truecolor")]
+	if base == "" {
+		return nil
+	}
+	return []string{base + "-256color", base + "-88color", base + "-color", base}
+}
+
+// AddTrueColor supplies vanilla 24-bit color sequences to the entry lacking them, if it has the TrueColor flag, if forced (e.g. the entry was fabricated for a -truecolor name) or if the user requested it with $COLORTERM or $TERM_TRUECOLOR.
+// Setting $TERM_TRUECOLOR to "disable" prevents it.
+func AddTrueColor(t *Term, forced bool) {
+	addTrueColor := forced || t.TrueColor
+	switch os.Getenv("COLORTERM") {
+	case "truecolor", "24bit", "24-bit":
+		addTrueColor = true
+	}
+
 	switch os.Getenv("TERM_TRUECOLOR") {
 	case "":
 	case "disable":
@@ -1153,6 +1245,4 @@ func LookupTerminfo(name string) (*Term, error) {
 		t.SetBgRGB = "\x1b[48;2;%p1%d;%p2%d;%p3%dm"
 		t.SetFgBgRGB = "\x1b[38;2;%p1%d;%p2%d;%p3%d;48;2;%p4%d;%p5%d;%p6%dm"
 	}
-
-	return t, nil
 }
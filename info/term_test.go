@@ -0,0 +1,214 @@
+package info_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/info"
+)
+
+func testCommander() *info.Commander {
+	return info.NewCommander(&info.Term{
+		Name:        "test",
+		SetCursor:   "\x1b[%i%p1%d;%p2%dH",
+		InsertLines: "\x1b[%p1%dL",
+		DeleteLine:  "\x1b[M",
+	})
+}
+
+func TestGoToXY(t *testing.T) {
+	comm := testCommander()
+	comm.ResizeGoToCache(term.NewSize(10, 5))
+	buf := &bytes.Buffer{}
+	comm.GoToXY(buf, 3, 2)
+	if buf.String() != "\x1b[3;4H" {
+		t.Fatalf("error : bad goto command %q", buf.String())
+	}
+	buf.Reset()
+	comm.GoTo(buf, term.Hash(3, 2))
+	if buf.String() != "\x1b[3;4H" {
+		t.Fatalf("error : bad cached goto command %q", buf.String())
+	}
+	buf.Reset()
+	comm.GoToXY(buf, 500, 200) // outside bounds, not cached, but still written
+	if buf.String() != "\x1b[201;501H" {
+		t.Fatalf("error : bad goto command %q", buf.String())
+	}
+}
+
+func TestInsertDeleteLines(t *testing.T) {
+	comm := testCommander()
+	buf := &bytes.Buffer{}
+	if err := comm.PutInsertLines(buf, 3); err != nil || buf.String() != "\x1b[3L" {
+		t.Fatalf("error : bad insert lines command %q", buf.String())
+	}
+	buf.Reset()
+	if err := comm.PutDeleteLines(buf, 2); err != nil || buf.String() != "\x1b[M\x1b[M" {
+		t.Fatalf("error : bad delete lines command %q", buf.String())
+	}
+	if err := comm.PutInsertChars(buf, 1); !errors.Is(err, term.ErrNotSupported{Capability: "ich"}) {
+		t.Fatalf("error : insert chars should not be supported, got %v", err)
+	}
+}
+
+func TestPadding(t *testing.T) {
+	comm := info.NewCommander(&info.Term{Name: "test", PadChar: "\x00", XonXoff: true})
+	comm.Baud = 9600
+	buf := &bytes.Buffer{}
+	if err := comm.WriteString(buf, "a$<10>b"); err != nil || buf.String() != "ab" {
+		t.Errorf("error : the flow control should replace the padding, got %q", buf.String())
+	}
+	buf.Reset()
+	if err := comm.WriteString(buf, "a$<10/>b"); err != nil || buf.String() != "a"+strings.Repeat("\x00", 9)+"b" {
+		t.Errorf("error : the mandatory padding should be sent as pad characters, got %q", buf.String())
+	}
+	comm.XonXoff = false
+	buf.Reset()
+	if err := comm.WriteBytes(buf, []byte("a$<5.5*>b")); err != nil || buf.String() != "a"+strings.Repeat("\x00", 5)+"b" {
+		t.Errorf("error : unexpected padding %q", buf.String())
+	}
+	comm.PadChar = "" // npc
+	buf.Reset()
+	if err := comm.WriteString(buf, "a$<10>b"); err != nil || buf.String() != "ab" {
+		t.Errorf("error : the terminal doesn't need padding, got %q", buf.String())
+	}
+}
+
+func TestPutByName(t *testing.T) {
+	comm := info.NewCommander(&info.Term{
+		Name:      "test",
+		SetCursor: "\x1b[%i%p1%d;%p2%dH",
+		Strings:   map[string]string{"flash": "\x1b[?5h$<100/>\x1b[?5l", "tsl": "\x1b]2;"},
+	})
+	if !comm.Has("cup") || !comm.Has("tsl") || comm.Has("fsl") {
+		t.Fatal("error : bad capabilities reported")
+	}
+	buf := &bytes.Buffer{}
+	if err := comm.Put(buf, "cup", 1, 2); err != nil || buf.String() != "\x1b[2;3H" {
+		t.Fatalf("error : bad cup %q (%v)", buf.String(), err)
+	}
+	err := comm.Put(buf, "fsl")
+	if !errors.Is(err, info.ErrNoCapability) || !errors.Is(err, term.ErrNotSupported{Capability: "fsl"}) || errors.Is(err, term.ErrNotSupported{Capability: "tsl"}) {
+		t.Fatalf("error : expecting ErrNotSupported for fsl, got %v", err)
+	}
+}
+
+// brokenWriter fails every write, as a terminal which went away
+type brokenWriter struct{}
+
+func (brokenWriter) Write([]byte) (int, error) { return 0, os.ErrClosed }
+
+func TestWriteErrors(t *testing.T) {
+	comm := testCommander()
+	for name, err := range map[string]error{
+		"PutClear":       comm.PutClear(brokenWriter{}),
+		"GoToXY":         comm.GoToXY(brokenWriter{}, 1, 1),
+		"PutInsertLines": comm.PutInsertLines(brokenWriter{}, 2),
+		"Put":            comm.Put(brokenWriter{}, "cup", 1, 1),
+	} {
+		var writeErr *term.WriteError
+		if !errors.As(err, &writeErr) || !errors.Is(err, term.ErrTerminalLost) || !errors.Is(err, os.ErrClosed) {
+			t.Errorf("error : %s should return a WriteError wrapping the failure, got %v", name, err)
+		}
+	}
+}
+
+func TestTParamMalformed(t *testing.T) {
+	comm := testCommander()
+	for _, tc := range []struct {
+		input string
+		want  string
+	}{
+		{"%d", "0"},                        // popping the empty stack
+		{"%p1%+%d", "7"},                   // missing operand
+		{"%?%t%e%;x", "x"},                 // empty condition
+		{"%p1%1000d|", "|"},                // huge width is dropped
+		{"%p1%:-3d|", "7  |"},              // while small ones are kept
+		{"%p1%3.1000d|", "|"},              // huge precision is dropped too
+		{"\x1b[%", "\x1b["},                // truncated
+		{"%{99999999999999999999999}", ""}, // overflowing constant
+	} {
+		if got := comm.TParam(tc.input, 7); got != tc.want {
+			t.Errorf("error : %q should expand to %q, got %q", tc.input, tc.want, got)
+		}
+	}
+}
+
+func TestLookupTerminfo(t *testing.T) {
+	old := os.Getenv("TERM_TRUECOLOR")
+	if err := os.Setenv("TERM_TRUECOLOR", ""); err != nil {
+		t.Fatalf("error setting TERM_TRUECOLOR : %v", err)
+	}
+	defer func() { _ = os.Setenv("TERM_TRUECOLOR", old) }()
+	info.AddTerminfo(&info.Term{Name: "lookup-test-256color", Colors: 256, SetCursor: "\x1b[%i%p1%d;%p2%dH"})
+
+	for _, name := range []string{"", "lookup-test-unknown", "lookup-test-unknown-truecolor", "-truecolor"} {
+		if ti, err := info.LookupTerminfo(name); err != info.NotFound || ti != nil {
+			t.Errorf("error : %q should not be found, got %v", name, err)
+		}
+	}
+
+	ti, err := info.LookupTerminfo("lookup-test-truecolor")
+	if err != nil {
+		t.Fatalf("error : the -truecolor entry should be fabricated from the -256color one : %v", err)
+	}
+	if ti.Name != "lookup-test-256color" || ti.SetFgRGB == "" {
+		t.Errorf("error : the fabricated entry should have 24-bit colors, got %q %q", ti.Name, ti.SetFgRGB)
+	}
+}
+
+func TestPatch(t *testing.T) {
+	ti := &info.Term{Name: "test", Colors: 8, EnterCA: "\x1b[?1049h", Strings: map[string]string{"smcup": "\x1b[?1049h", "Xcustom": "x"}}
+	patched, err := ti.Patch(map[string]string{
+		"smcup":  "",              // removed
+		"flash":  "\x1b[?5h",      // a standard string capability without field
+		"Smulx":  "\x1b[4:%p1%dm", // an extended one having a field
+		"Tsync":  "\x1b[?2026h",   // an extended one, unknown
+		"colors": "256",
+		"cols":   "",
+		"bce":    "true",
+	})
+	if err != nil {
+		t.Fatalf("error patching : %v", err)
+	}
+	if patched.EnterCA != "" || patched.Capabilities()["smcup"] != "" {
+		t.Errorf("error : smcup should be removed")
+	}
+	if caps := patched.Capabilities(); caps["flash"] != "\x1b[?5h" || caps["Tsync"] != "\x1b[?2026h" || caps["Xcustom"] != "x" {
+		t.Errorf("error : the string capabilities should be set, got %q", caps)
+	}
+	if patched.UnderlineStyle != "\x1b[4:%p1%dm" {
+		t.Errorf("error : the field of Smulx should be set, got %q", patched.UnderlineStyle)
+	}
+	if patched.Colors != 256 || patched.Columns != 0 || !patched.BackColorErase {
+		t.Errorf("error : the numeric and boolean fields should be set, got colors %d, cols %d, bce %t", patched.Colors, patched.Columns, patched.BackColorErase)
+	}
+	if ti.Colors != 8 || ti.EnterCA == "" || ti.BackColorErase {
+		t.Errorf("error : the original entry should not change")
+	}
+
+	for _, tc := range []struct {
+		name      string
+		overrides map[string]string
+		unknown   bool
+	}{
+		{name: "misspelled", overrides: map[string]string{"smcpu": "x"}, unknown: true},
+		{name: "numeric without field", overrides: map[string]string{"pairs": "64"}, unknown: true},
+		{name: "boolean without field", overrides: map[string]string{"am": "true"}, unknown: true},
+		{name: "invalid number", overrides: map[string]string{"colors": "many"}},
+		{name: "invalid boolean", overrides: map[string]string{"xon": "maybe"}},
+	} {
+		patched, err := ti.Patch(tc.overrides)
+		if err == nil || patched != nil {
+			t.Errorf("error : %s : expecting an error", tc.name)
+			continue
+		}
+		if errors.Is(err, info.ErrUnknownCapability) != tc.unknown {
+			t.Errorf("error : %s : unexpected error %v", tc.name, err)
+		}
+	}
+}
@@ -12,6 +12,7 @@ func init() {
 		Lines:        24,
 		Bell:         "\a",
 		Clear:        "\x1b[H\x1b[J$<50>",
+		Reset2:       "\x1b<\x1b>\x1b[?3;4;5l\x1b[?7;8h\x1b[r",
 		AttrOff:      "\x1b[m\x0f$<2>",
 		Underline:    "\x1b[4m$<2>",
 		Bold:         "\x1b[1m$<2>",
@@ -20,6 +21,7 @@ func init() {
 		EnterKeypad:  "\x1b[?1h\x1b=",
 		ExitKeypad:   "\x1b[?1l\x1b>",
 		PadChar:      "\x00",
+		XonXoff:      true,
 		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
 		EnterAcs:     "\x0e",
 		ExitAcs:      "\x0f",
@@ -42,5 +44,7 @@ func init() {
 		KeyF8:        "\x1bOl",
 		KeyF9:        "\x1bOw",
 		KeyF10:       "\x1bOx",
+		ClearToEOL:   "\x1b[K$<3>",
+		ClearToEOS:   "\x1b[J$<50>",
 	})
 }
@@ -12,12 +12,15 @@ func init() {
 		Lines:        24,
 		Bell:         "\a",
 		Clear:        "\x1b[H\x1b[J",
+		Init2:        "\x1b[?7h\x1b[>\x1b[?1l\x1b F\x1b[?4l",
+		Reset1:       "\x1b[?3l",
 		AttrOff:      "\x1b[m\x1b(B",
 		Underline:    "\x1b[4m",
 		Bold:         "\x1b[1m",
 		Blink:        "\x1b[5m",
 		Reverse:      "\x1b[7m",
 		PadChar:      "\x00",
+		XonXoff:      true,
 		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
 		EnterAcs:     "\x1b(0$<2>",
 		ExitAcs:      "\x1b(B$<4>",
@@ -46,5 +49,14 @@ func init() {
 		KeyF11:       "\x1b[23~",
 		KeyF12:       "\x1b[24~",
 		KeyHelp:      "\x1b[28~",
+		ClearToEOL:   "\x1b[K",
+		ClearToEOS:   "\x1b[J",
+		InsertLine:   "\x1b[L",
+		InsertLines:  "\x1b[%p1%dL",
+		DeleteLine:   "\x1b[M",
+		DeleteLines:  "\x1b[%p1%dM",
+		InsertChars:  "\x1b[%p1%d@",
+		DeleteChar:   "\x1b[P",
+		DeleteChars:  "\x1b[%p1%dP",
 	})
 }
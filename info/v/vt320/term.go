@@ -12,6 +12,8 @@ func init() {
 		Lines:        24,
 		Bell:         "\a",
 		Clear:        "\x1b[H\x1b[2J",
+		Init2:        "\x1b>\x1b[?3l\x1b[?4l\x1b[?5l\x1b[?7h\x1b[?8h\x1b[1;24r\x1b[24;1H",
+		Reset2:       "\x1b>\x1b[?3l\x1b[?4l\x1b[?5l\x1b[?7h\x1b[?8h\x1b[1;24r\x1b[24;1H",
 		ShowCursor:   "\x1b[?25h",
 		HideCursor:   "\x1b[?25l",
 		AttrOff:      "\x1b[m\x1b(B",
@@ -49,5 +51,14 @@ func init() {
 		KeyF10:       "\x1b[21~",
 		KeyF11:       "\x1b[23~",
 		KeyF12:       "\x1b[24~",
+		ClearToEOL:   "\x1b[K",
+		ClearToEOS:   "\x1b[J",
+		InsertLine:   "\x1b[L",
+		InsertLines:  "\x1b[%p1%dL",
+		DeleteLine:   "\x1b[M",
+		DeleteLines:  "\x1b[%p1%dM",
+		InsertChars:  "\x1b[%p1%d@",
+		DeleteChar:   "\x1b[P",
+		DeleteChars:  "\x1b[%p1%dP",
 	})
 }
@@ -11,6 +11,8 @@ func init() {
 		Columns:      80,
 		Lines:        24,
 		Clear:        "\x1b[H\x1b[J$<10/>",
+		Init2:        "\x1b<\x1b F\x1b>\x1b[?1h\x1b[?3l\x1b[?4l\x1b[?5l\x1b[?7h\x1b[?8h\x1b[1;24r\x1b[24;1H",
+		Reset1:       "\x1b<\x1b[?3l\x1b[!p\x1b[?7h",
 		ShowCursor:   "\x1b[?25h",
 		HideCursor:   "\x1b[?25l",
 		AttrOff:      "\x1b[m\x1b(B",
@@ -21,6 +23,7 @@ func init() {
 		EnterKeypad:  "\x1b[?1h\x1b=",
 		ExitKeypad:   "\x1b[?1l\x1b>",
 		PadChar:      "\x00",
+		XonXoff:      true,
 		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
 		EnterAcs:     "\x1b(0",
 		ExitAcs:      "\x1b(B",
@@ -40,5 +43,15 @@ func init() {
 		KeyF7:        "\x1b[18~",
 		KeyF8:        "\x1b[19~",
 		KeyF9:        "\x1b[20~",
+		ClearToEOL:   "\x1b[K$<4/>",
+		ClearToEOS:   "\x1b[J$<10/>",
+		InsertLine:   "\x1b[L",
+		InsertLines:  "\x1b[%p1%dL",
+		DeleteLine:   "\x1b[M",
+		DeleteLines:  "\x1b[%p1%dM",
+		InsertChar:   "\x1b[@",
+		InsertChars:  "\x1b[%p1%d@",
+		DeleteChar:   "\x1b[P",
+		DeleteChars:  "\x1b[%p1%dP",
 	})
 }
@@ -11,6 +11,7 @@ func init() {
 		Lines:        24,
 		Bell:         "\a",
 		Clear:        "\x1b[H\x1b[2J$<50>",
+		Init2:        "\x1b[1;24r\x1b[24;1H",
 		ShowCursor:   "\x1b[?25h",
 		HideCursor:   "\x1b[?25l",
 		AttrOff:      "\x1b[m\x1b(B$<2>",
@@ -21,6 +22,7 @@ func init() {
 		EnterKeypad:  "\x1b=",
 		ExitKeypad:   "\x1b>",
 		PadChar:      "\x00",
+		XonXoff:      true,
 		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
 		EnterAcs:     "\x1b(0$<2>",
 		ExitAcs:      "\x1b(B$<4>",
@@ -47,5 +49,14 @@ func init() {
 		KeyF8:        "\x1b[20~",
 		KeyF9:        "\x1b[21~",
 		KeyF10:       "\x1b[29~",
+		ClearToEOL:   "\x1b[K$<3>",
+		ClearToEOS:   "\x1b[J$<50>",
+		InsertLine:   "\x1b[L",
+		InsertLines:  "\x1b[%p1%dL",
+		DeleteLine:   "\x1b[M",
+		DeleteLines:  "\x1b[%p1%dM",
+		InsertChars:  "\x1b[%p1%d@",
+		DeleteChar:   "\x1b[P",
+		DeleteChars:  "\x1b[%p1%dP",
 	})
 }
@@ -23,5 +23,7 @@ func init() {
 		KeyRight:     "\x1bC",
 		KeyLeft:      "\x1bD",
 		KeyBackspace: "\b",
+		ClearToEOL:   "\x1bK",
+		ClearToEOS:   "\x1bJ",
 	})
 }
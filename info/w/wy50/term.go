@@ -12,6 +12,8 @@ func init() {
 		Lines:        24,
 		Bell:         "\a",
 		Clear:        "\x1b+$<20>",
+		Init1:        "\x1b`:\x1b`9$<30>",
+		Init2:        "\x0e\x14\x1b'\x1b(",
 		ShowCursor:   "\x1b`1",
 		HideCursor:   "\x1b`0",
 		AttrOff:      "\x1b(\x1bH\x03",
@@ -49,5 +51,10 @@ func init() {
 		KeyPrint:     "\x1bP",
 		KeyBacktab:   "\x1bI",
 		KeyShfHome:   "\x1b{",
+		ClearToEOL:   "\x1bT",
+		ClearToEOS:   "\x1bY$<20>",
+		InsertLine:   "\x1bE",
+		DeleteLine:   "\x1bR",
+		DeleteChar:   "\x1bW$<1>",
 	})
 }
@@ -14,6 +14,10 @@ func init() {
 		Clear:        "\x1b+$<100>",
 		EnterCA:      "\x1bw0",
 		ExitCA:       "\x1bw1",
+		Init1:        "\x1bcB0\x1bcC1",
+		Init2:        "\x1bd$\x1bcD\x1b'\x1br\x1bH\x03\x1bd/\x1bO\x1be1\x1bd*\x1b`@\x1b`9\x1b`1\x0e\x14\x1bl",
+		Reset1:       "\x1b~!\x1b~4$<150>",
+		Reset2:       "\x1beG$<150>",
 		ShowCursor:   "\x1b`1",
 		HideCursor:   "\x1b`0",
 		AttrOff:      "\x1b(\x1bH\x03\x1bG0\x1bcD",
@@ -53,5 +57,10 @@ func init() {
 		KeyPrint:     "\x1bP",
 		KeyBacktab:   "\x1bI",
 		KeyShfHome:   "\x1b{",
+		ClearToEOL:   "\x1bT",
+		ClearToEOS:   "\x1bY$<100>",
+		InsertLine:   "\x1bE$<4>",
+		DeleteLine:   "\x1bR$<5>",
+		DeleteChar:   "\x1bW$<11>",
 	})
 }
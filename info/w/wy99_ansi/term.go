@@ -11,6 +11,8 @@ func init() {
 		Lines:        25,
 		Bell:         "\a",
 		Clear:        "\x1b[H\x1b[J$<200>",
+		Init2:        "\x1b7\x1b[1r\x1b8\x1b[2;3;4;13;20;34;39;36l\x1b[12;16;34h\x1b[?1;3;4;5;10;18l\x1b[?7;8;25h\x1b>\x1b[?5W\x1b(B\x0f\x1b[4i",
+		Reset2:       "\x1b[61\"p\x1b[40h\x1b[?6l\x1b[1r\x1b[2;3;4;13;20;34;39;36l\x1b[12;16;34h\x1b[?1;3;4;5;10;18l\x1b[?7;8;25h\x1b>\x1b[?5W\x1b(B\x0f\x1b[24E\x1b[4i",
 		ShowCursor:   "\x1b[34h\x1b[?25h",
 		HideCursor:   "\x1b[?25l",
 		AttrOff:      "\x1b[m\x0f\x1b[\"q",
@@ -47,6 +49,13 @@ func init() {
 		KeyF11:       "\x1b[23~",
 		KeyF12:       "\x1b[24~",
 		KeyBacktab:   "\x1b[z",
+		ClearToEOL:   "\x1b[K$<1>",
+		ClearToEOS:   "\x1b[J$<8*>",
+		InsertLine:   "\x1b[L",
+		InsertLines:  "\x1b[%p1%dL",
+		DeleteLine:   "\x1b[M",
+		DeleteLines:  "\x1b[%p1%dM",
+		InsertChars:  "\x1b[%p1%d@",
 	})
 
 	// Wyse WY-99GT in ansi mode (US PC keyboard)
@@ -56,6 +65,8 @@ func init() {
 		Lines:        25,
 		Bell:         "\a",
 		Clear:        "\x1b[H\x1b[J$<200>",
+		Init2:        "\x1b7\x1b[1r\x1b8\x1b[2;3;4;13;20;34;39;36l\x1b[12;16;34h\x1b[?1;3;4;5;10;18l\x1b[?7;8;25h\x1b>\x1b[?5W\x1b(B\x0f\x1b[4i",
+		Reset2:       "\x1b[61\"p\x1b[40h\x1b[?6l\x1b[1r\x1b[2;3;4;13;20;34;39;36l\x1b[12;16;34h\x1b[?1;3;4;5;10;18l\x1b[?7;8;25h\x1b>\x1b[?5W\x1b(B\x0f\x1b[24E\x1b[4i",
 		ShowCursor:   "\x1b[34h\x1b[?25h",
 		HideCursor:   "\x1b[?25l",
 		AttrOff:      "\x1b[m\x0f\x1b[\"q",
@@ -92,5 +103,12 @@ func init() {
 		KeyF11:       "\x1b[23~",
 		KeyF12:       "\x1b[24~",
 		KeyBacktab:   "\x1b[z",
+		ClearToEOL:   "\x1b[K$<1>",
+		ClearToEOS:   "\x1b[J$<8*>",
+		InsertLine:   "\x1b[L",
+		InsertLines:  "\x1b[%p1%dL",
+		DeleteLine:   "\x1b[M",
+		DeleteLines:  "\x1b[%p1%dM",
+		InsertChars:  "\x1b[%p1%d@",
 	})
 }
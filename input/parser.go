@@ -0,0 +1,43 @@
+package input
+
+import (
+	"github.com/badu/term"
+	"github.com/badu/term/info"
+	"github.com/badu/term/key"
+	"github.com/badu/term/mouse"
+)
+
+// Parser turns the terminal input into key and mouse events, in the order they were typed, without the engine and the dispatchers : the input can come from a SSH session, a recording or a test.
+// It keeps the state between calls, so the input can be fed as it arrives. It's not safe for concurrent use.
+type Parser struct {
+	keys *key.Parser   // recognizes the key sequences, handing the mouse records to mice
+	mice *mouse.Parser //
+}
+
+// NewParser creates a parser for the terminal, which provides the sequences of the function keys
+func NewParser(ti *info.Term) *Parser {
+	res := &Parser{
+		keys: key.NewParser(key.WithTerminalInfo(ti)),
+		mice: mouse.NewParser(),
+	}
+	res.keys.DecodeMouse(func(record []byte) []term.Event {
+		events, _ := res.mice.Parse(record)
+		return events
+	})
+	return res
+}
+
+// Resize sets the size left to the pages and the cells reserved at the screen edges, for clipping the mouse coordinates (see mouse.Parser)
+func (p *Parser) Resize(size *term.Size, margins term.Margins) {
+	p.mice.Resize(size, margins)
+}
+
+// Parse returns the events (term.KeyEvent and term.MouseEvent) found in the input and the number of bytes consumed. The bytes left (a sequence which isn't complete yet) should be passed again, followed by the next input.
+func (p *Parser) Parse(b []byte) ([]term.Event, int) {
+	return p.keys.Parse(b)
+}
+
+// Flush is like Parse, but the incomplete sequences are delivered as they are, e.g. a lone Esc : it should be called when no input arrives for a while (see key.Parser)
+func (p *Parser) Flush(b []byte) ([]term.Event, int) {
+	return p.keys.Flush(b)
+}
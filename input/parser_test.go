@@ -0,0 +1,59 @@
+package input_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/info"
+	"github.com/badu/term/input"
+)
+
+// names returns the names of the key events and the positions of the mouse ones
+func names(events []term.Event) string {
+	var result []string
+	for _, ev := range events {
+		switch e := ev.(type) {
+		case term.KeyEvent:
+			result = append(result, e.Name())
+		case term.MouseEvent:
+			column, row := e.Position()
+			result = append(result, "Mouse["+string(rune('0'+column))+","+string(rune('0'+row))+"]")
+		}
+	}
+	return strings.Join(result, ",")
+}
+
+func TestParse(t *testing.T) {
+	p := input.NewParser(&info.Term{Name: "test", KeyUp: "\x1b[A"})
+
+	events, consumed := p.Parse([]byte("a\x1b[<0;3;2Mb\x1b[A\x1b[<0;1"))
+	if got := names(events); got != "Rune[a],Mouse[2,1],Rune[b],Up" {
+		t.Errorf("error : the events should be in the input order, got %s", got)
+	}
+	if consumed != 14 {
+		t.Errorf("error : the partial mouse record should be left, got %d bytes consumed", consumed)
+	}
+
+	events, consumed = p.Parse([]byte("\x1b[<0;1;1m"))
+	if names(events) != "Mouse[0,0]" || consumed != 9 {
+		t.Errorf("error : the completed record should be parsed, got %s", names(events))
+	}
+
+	// a lone Esc can be the start of a sequence, until it's flushed
+	if events, consumed = p.Parse([]byte("\x1b")); len(events) != 0 || consumed != 0 {
+		t.Errorf("error : a lone Esc should wait for the rest")
+	}
+	if events, consumed = p.Flush([]byte("\x1b")); names(events) != "Esc" || consumed != 1 {
+		t.Errorf("error : a flushed Esc should be delivered, got %s", names(events))
+	}
+}
+
+func TestParseResize(t *testing.T) {
+	p := input.NewParser(&info.Term{Name: "test"})
+	p.Resize(term.NewSize(4, 4), term.Margins{Top: 1, Left: 1})
+	events, _ := p.Parse([]byte("\x1b[<0;9;9M\x1b[<0;2;2M"))
+	if got := names(events); got != "Mouse[3,3],Mouse[0,0]" {
+		t.Errorf("error : the coordinates should be clipped and made relative to the margins, got %s", got)
+	}
+}
@@ -0,0 +1,24 @@
+package key
+
+import (
+	"github.com/badu/term"
+	enc "github.com/badu/term/encoding"
+)
+
+// eightBit returns the key which was sent with the 8th bit set, which is how some terminals encode Alt (meta sends 8 bit).
+// Unless the normalization is disabled, in which case the byte is a Latin-1 rune.
+func (d *eventDispatcher) eightBit(by byte) (rune, term.ModMask) {
+	if d.rawAlt {
+		return rune(by), ModNone
+	}
+	r, mod := rune(by&0x7F), ModAlt
+	if r < enc.Space {
+		switch term.Key(r) {
+		case Backspace, Tab, Esc, Enter:
+			// directly typeable - no control sequence
+		default:
+			mod |= ModCtrl
+		}
+	}
+	return r, mod
+}
@@ -0,0 +1,142 @@
+package key
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/badu/term"
+)
+
+// aliases are the other names of the keys, accepted by ParseBinding
+var aliases = map[string]term.Key{
+	"return":   Enter,
+	"escape":   Esc,
+	"del":      Delete,
+	"ins":      Insert,
+	"pagedown": PgDn,
+	"pageup":   PgUp,
+	"pgdown":   PgDn,
+}
+
+// Binding is a key stroke which the key events are matched against, e.g. read from a configuration file (see ParseBinding)
+type Binding struct {
+	Key  term.Key     // Rune for the printable keys
+	Rune rune         // set for the printable keys
+	Mod  term.ModMask //
+}
+
+// ParseBinding reads a key stroke written as the modifiers and the key, joined by "+" (or "-"), e.g. "Ctrl+S", "Alt+Enter", "Shift+Tab", "F5", "q" or "Space".
+// The names are the ones returned by the key events (see Name), case insensitive, and Return, Escape, Del, Ins, PageUp and PageDown are accepted too.
+func ParseBinding(s string) (Binding, error) {
+	fields := splitBinding(strings.TrimSpace(s))
+	if len(fields) == 0 {
+		return Binding{}, fmt.Errorf("key: empty key binding")
+	}
+	var mod term.ModMask
+	for _, field := range fields[:len(fields)-1] {
+		switch strings.ToLower(field) {
+		case "ctrl", "control":
+			mod |= ModCtrl
+		case "alt", "option":
+			mod |= ModAlt
+		case "shift":
+			mod |= ModShift
+		case "meta":
+			mod |= ModMeta
+		default:
+			return Binding{}, fmt.Errorf("key: unknown modifier %q in %q", field, s)
+		}
+	}
+
+	name := fields[len(fields)-1]
+	if r, size := utf8.DecodeRuneInString(name); size == len(name) || strings.EqualFold(name, "space") {
+		if size != len(name) {
+			r = ' '
+		}
+		if mod&ModCtrl != 0 {
+			switch {
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+				return Binding{Key: term.Key(unicode.ToLower(r) - 'a' + 1), Mod: mod}, nil // the control characters, e.g. Ctrl+H is Backspace
+			case r == ' ':
+				return Binding{Key: CtrlSpace, Mod: mod}, nil
+			}
+		}
+		return Binding{Key: Rune, Rune: r, Mod: mod}, nil
+	}
+	if k, ok := aliases[strings.ToLower(name)]; ok {
+		return Binding{Key: k, Mod: mod}, nil
+	}
+	for k, known := range names {
+		if k != Rune && strings.EqualFold(known, name) {
+			if strings.HasPrefix(known, Ctrl+"-") {
+				mod |= ModCtrl
+			}
+			return Binding{Key: k, Mod: mod}, nil
+		}
+	}
+	return Binding{}, fmt.Errorf("key: unknown key %q in %q", name, s)
+}
+
+// Matches returns true if the event is this key stroke. With Ctrl, the control characters match with or without the modifier, as terminals report them either way,
+// and so do the letters with the modifier, for the terminals reporting them as runes (e.g. the kitty keyboard protocol).
+func (b Binding) Matches(ev term.KeyEvent) bool {
+	mod := ev.Modifiers()
+	if b.Key != Rune && b.Key < ' ' && b.Mod&ModCtrl != 0 {
+		if ev.Key() == Rune && b.Key >= CtrlA && b.Key <= CtrlZ {
+			return unicode.ToLower(ev.Rune()) == rune(b.Key)+'a'-1 && mod == b.Mod
+		}
+		return ev.Key() == b.Key && mod|ModCtrl == b.Mod
+	}
+	if ev.Key() != b.Key || (b.Key == Rune && ev.Rune() != b.Rune) {
+		return false
+	}
+	if b.Key == Rune && b.Mod&ModShift == 0 {
+		mod &^= ModShift // the shifted runes, e.g. "?", are reported with or without Shift
+	}
+	return mod == b.Mod
+}
+
+// String returns the key stroke as ParseBinding reads it
+func (b Binding) String() string {
+	name := names[b.Key]
+	mod := b.Mod
+	switch {
+	case b.Key == Rune && b.Rune == ' ':
+		name = "Space"
+	case b.Key == Rune:
+		name = string(b.Rune)
+	case b.Key >= CtrlA && b.Key <= CtrlZ && (name == "" || mod&ModCtrl != 0):
+		name, mod = string(rune(b.Key)+'A'-1), mod|ModCtrl
+	case name == "":
+		name = fmt.Sprintf("Key[%d]", b.Key)
+	}
+	var sb strings.Builder
+	for _, m := range []struct {
+		mask term.ModMask
+		name string
+	}{{ModCtrl, Ctrl}, {ModAlt, Alt}, {ModShift, Shift}, {ModMeta, Meta}} {
+		if mod&m.mask != 0 {
+			sb.WriteString(m.name + "+")
+		}
+	}
+	sb.WriteString(name)
+	return sb.String()
+}
+
+// splitBinding splits the key stroke by "+" or "-", keeping these runes when they are the key, e.g. "Ctrl++"
+func splitBinding(s string) []string {
+	var result []string
+	start := 0
+	for idx, r := range s {
+		if (r == '+' || r == '-') && idx > start {
+			result = append(result, s[start:idx])
+			start = idx + 1
+		}
+	}
+	if start < len(s) {
+		result = append(result, s[start:])
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+package key
+
+import (
+	"testing"
+
+	"github.com/badu/term"
+)
+
+func TestParseBinding(t *testing.T) {
+	for _, test := range []struct {
+		spec     string
+		expected Binding
+		name     string
+	}{
+		{"Ctrl+S", Binding{Key: CtrlS, Mod: ModCtrl}, "Ctrl+S"},
+		{"ctrl-a", Binding{Key: CtrlA, Mod: ModCtrl}, "Ctrl+A"},
+		{"Alt+Enter", Binding{Key: Enter, Mod: ModAlt}, "Alt+Enter"},
+		{"Shift+Tab", Binding{Key: Tab, Mod: ModShift}, "Shift+Tab"},
+		{"F5", Binding{Key: F5}, "F5"},
+		{"PageDown", Binding{Key: PgDn}, "PgDn"},
+		{"q", Binding{Key: Rune, Rune: 'q'}, "q"},
+		{"Alt+Space", Binding{Key: Rune, Rune: ' ', Mod: ModAlt}, "Alt+Space"},
+		{"Ctrl++", Binding{Key: Rune, Rune: '+', Mod: ModCtrl}, "Ctrl++"},
+	} {
+		binding, err := ParseBinding(test.spec)
+		if err != nil {
+			t.Errorf("error : %q : %v", test.spec, err)
+			continue
+		}
+		if binding != test.expected {
+			t.Errorf("error : %q : expecting %+v, got %+v", test.spec, test.expected, binding)
+		}
+		if binding.String() != test.name {
+			t.Errorf("error : %q : expecting the name %q, got %q", test.spec, test.name, binding.String())
+		}
+	}
+	for _, spec := range []string{"", "Hyper+X", "Ctrl+Nothing"} {
+		if _, err := ParseBinding(spec); err == nil {
+			t.Errorf("error : %q should be refused", spec)
+		}
+	}
+}
+
+func TestBindingMatches(t *testing.T) {
+	ctrlS, _ := ParseBinding("Ctrl+S")
+	question, _ := ParseBinding("?")
+	enter, _ := ParseBinding("Enter")
+	for _, test := range []struct {
+		binding Binding
+		ev      term.KeyEvent
+		matches bool
+	}{
+		{ctrlS, NewEvent(Rune, rune(CtrlS), ModNone), true}, // the control character
+		{ctrlS, NewEvent(Rune, 's', ModCtrl), true},         // reported as a rune
+		{ctrlS, NewEvent(Rune, 's', ModNone), false},        //
+		{ctrlS, NewEvent(Rune, 's', ModCtrl|ModAlt), false}, //
+		{question, NewEvent(Rune, '?', ModShift), true},     //
+		{question, NewEvent(Rune, '?', ModAlt), false},      //
+		{enter, NewEvent(Enter, 0, ModNone), true},          //
+		{enter, NewEvent(Enter, 0, ModCtrl), false},         //
+	} {
+		if got := test.binding.Matches(test.ev); got != test.matches {
+			t.Errorf("error : %s matching %s should be %t", test.binding, test.ev.Name(), test.matches)
+		}
+	}
+}
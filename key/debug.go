@@ -1,5 +1,6 @@
 package key
 
-const (
-	Debug = false
-)
+import "github.com/badu/term/debug"
+
+// Debug is the diagnosis log of the package, enabled at runtime with TERM_DEBUG=key or core.WithDebug("key") (see package debug)
+var Debug = debug.For("key")
@@ -3,7 +3,6 @@ package key
 import (
 	"bytes"
 	"context"
-	"log"
 	"sync"
 	"time"
 	"unicode/utf8"
@@ -16,6 +15,8 @@ import (
 
 const (
 	defaultDuration = time.Millisecond * 50
+	maxSequence     = 64                     // partial escape sequences longer than this are given up, so garbage input can't grow the buffer
+	maxRuneWait     = time.Millisecond * 500 // how long the rest of a multi-byte rune split across reads is waited for, before the bytes are delivered as 8th bit (meta) keys
 )
 
 // Option
@@ -24,16 +25,32 @@ type Option func(core *eventDispatcher)
 // Finalizer
 type Finalizer func()
 
+// receiver is a registered listener channel, having the priority of the listener (see term.PriorityListener)
+type receiver struct {
+	ch       chan term.KeyEvent
+	priority int
+}
+
 // for readability
-type channels []chan term.KeyEvent
+type channels []receiver
 
-// delete removes the element at index from channels.
-// Note that this is the fastest version, which changes order of elements inside the slice
+// delete removes the element at index from channels, keeping the order of the others, so the events are always delivered in the same order.
 // Yes, this is repeated code, because avoiding use of interface{}
 func (c *channels) delete(idx int) {
-	(*c)[idx] = (*c)[len(*c)-1] // Copy last element to index i.
-	(*c)[len(*c)-1] = nil       // Erase last element (write zero value).
-	*c = (*c)[:len(*c)-1]       // Truncate slice.
+	copy((*c)[idx:], (*c)[idx+1:]) // Shift the elements after index.
+	(*c)[len(*c)-1] = receiver{}   // Erase last element (write zero value).
+	*c = (*c)[:len(*c)-1]          // Truncate slice.
+}
+
+// insert adds the channel after the ones having the same or a higher priority : the events are delivered by priority, then in the order of registration
+func (c *channels) insert(ch chan term.KeyEvent, priority int) {
+	idx := len(*c)
+	for idx > 0 && (*c)[idx-1].priority < priority {
+		idx--
+	}
+	*c = append(*c, receiver{})
+	copy((*c)[idx+1:], (*c)[idx:])
+	(*c)[idx] = receiver{ch: ch, priority: priority}
 }
 
 // eventDispatcher
@@ -52,6 +69,13 @@ type eventDispatcher struct {
 	finalizer        Finalizer             // if a finalizer is provided, it will be called before shutdown
 	ctx              context.Context       //
 	escaped          bool                  //
+	rawAlt           bool                  // set by WithAltNormalization(false) : the ESC prefix and the 8th bit are not turned into ModAlt
+	repeatInterval   time.Duration         // set by WithRepeatInterval, the same key arriving within it is marked as repeat
+	chunkAt          time.Time             // when the chunk being scanned has arrived
+	last             *event                // the previously dispatched event, for detecting repeats
+	lastAt           time.Time             // when the chunk holding the previous event has arrived
+	sink             func(term.Event)      // set by Parser, receives the events instead of the receivers
+	mouseRecord      func([]byte)          // set by Parser.DecodeMouse, receives the mouse records found in the input
 }
 
 // WithFinalizer provides a way of calling a function upon dispatcher death
@@ -69,6 +93,32 @@ func WithKeyTimerInterval(duration time.Duration) Option {
 	}
 }
 
+// WithAltNormalization is a functional option for turning the Alt key encodings into ModAlt : the ESC prefix, the 8th bit set (meta sends 8 bit) and the XTerm modifiers (CSI 1;3X). Default is enabled.
+// When disabled, the ESC prefix is delivered as an Esc key event and the 8th bit bytes as Latin-1 runes, so applications can tell them apart.
+func WithAltNormalization(enabled bool) Option {
+	return func(d *eventDispatcher) {
+		d.rawAlt = !enabled
+	}
+}
+
+// WithRepeatInterval is a functional option for marking the key events as repeats (see term.KeyEvent Repeat) when the same key arrives again, in another chunk of input, within the interval.
+// Terminals don't report the auto-repeat rate, so the interval should be a bit longer than the one of the keyboard (usually 25-40 milliseconds). Default is zero, which disables the heuristic.
+// Note that the first repeat comes after the keyboard's repeat delay, so it is reported as a discrete press.
+func WithRepeatInterval(interval time.Duration) Option {
+	return func(d *eventDispatcher) {
+		d.repeatInterval = interval
+	}
+}
+
+// WithInputBuffer is a functional option for buffering the channel which receives the input chunks from core, so bursts (e.g. pastes) don't stall the reader of the terminal. Default is zero (unbuffered).
+func WithInputBuffer(size int) Option {
+	return func(d *eventDispatcher) {
+		if size > 0 {
+			d.inputCh = make(chan []byte, size)
+		}
+	}
+}
+
 // WithTerminalInfo is mandatory for the composition, provided by core
 func WithTerminalInfo(ti *info.Term) Option {
 	return func(d *eventDispatcher) {
@@ -96,7 +146,9 @@ func NewEventDispatcher(opts ...Option) (term.KeyDispatcher, error) {
 
 // LifeCycle implementation of term.KeyDispatcher, called from core
 func (d *eventDispatcher) LifeCycle(ctx context.Context) {
+	d.Lock()
 	d.ctx = ctx
+	d.Unlock()
 	// mount lifecycle - listens for chunks of []byte coming via inputCh, analyses them and builds key events
 	d.lifeCycle()
 }
@@ -111,11 +163,14 @@ func (d *eventDispatcher) InChan() chan []byte {
 	return d.inputCh
 }
 
-// Register is registering receivers
+// Register is registering receivers. The receivers are guarded by the mutex, since the events are dispatched while scanning the input.
 func (d *eventDispatcher) Register(r term.KeyListener) {
+	d.Lock()
+	defer d.Unlock()
+
 	if d.ctx == nil {
-		if Debug {
-			log.Fatal("context not set : cannot listen context.Done()")
+		if Debug.Enabled() {
+			Debug.Println("context not set : cannot listen context.Done()")
 		}
 		return
 	}
@@ -123,7 +178,7 @@ func (d *eventDispatcher) Register(r term.KeyListener) {
 	alreadyRegistered := false
 	for _, ch := range d.receivers {
 		// Two channel values are considered equal if they originated from the same make call (meaning they refer to the same channel value in memory).
-		if ch == r.KeyListen() {
+		if ch.ch == r.KeyListen() {
 			alreadyRegistered = true
 			break
 		}
@@ -132,15 +187,17 @@ func (d *eventDispatcher) Register(r term.KeyListener) {
 		return
 	}
 	// we're fine, lets register it
-	d.receivers = append(d.receivers, r.KeyListen())
+	d.receivers.insert(r.KeyListen(), term.ListenPriority(r))
 	// mounting a go routine to listen bye-bye life
 	go func() {
 		// wait for death announcement
 		<-r.DyingChan()
+		d.Lock()
+		defer d.Unlock()
 		// now lookup for that very channel and forget it
 		for idx, ch := range d.receivers {
 			// Two channel values are considered equal if they originated from the same make call (meaning they refer to the same channel value in memory).
-			if ch == r.KeyListen() {
+			if ch.ch == r.KeyListen() {
 				d.receivers.delete(idx)
 				break
 			}
@@ -157,6 +214,28 @@ func (d *eventDispatcher) HasKey(k term.Key) bool {
 	return ok
 }
 
+// emit creates a key event, marks it as repeat by timing and dispatches it via channels
+func (d *eventDispatcher) emit(k term.Key, r rune, mod term.ModMask) {
+	ev := NewEvent(k, r, mod).(*event)
+	last := d.last
+	ev.repeat = d.repeatInterval > 0 && last != nil &&
+		last.key == ev.key && last.r == ev.r && last.mod == ev.mod &&
+		d.chunkAt.After(d.lastAt) && d.chunkAt.Sub(d.lastAt) <= d.repeatInterval // same chunk means pasted, not held
+	d.dispatch(ev)
+}
+
+// dispatch sends the event to all receivers, remembering it for the repeat detection
+func (d *eventDispatcher) dispatch(ev *event) {
+	d.last, d.lastAt = ev, d.chunkAt
+	if d.sink != nil {
+		d.sink(ev)
+		return
+	}
+	for _, cons := range d.receivers {
+		cons.ch <- ev // one event for everyone
+	}
+}
+
 // readFuncKey checks for function key and dispatches event via channels
 func (d *eventDispatcher) readFuncKey(buf *bytes.Buffer) (bool, bool, error) {
 	b := buf.Bytes()
@@ -177,10 +256,7 @@ func (d *eventDispatcher) readFuncKey(buf *bytes.Buffer) (bool, bool, error) {
 				mod |= ModAlt
 				d.escaped = false
 			}
-			ev := NewEvent(kv.Key, r, mod) // one event for everyone
-			for _, cons := range d.receivers {
-				cons <- ev
-			}
+			d.emit(kv.Key, r, mod)
 			for i := 0; i < len(esc); i++ {
 				if _, err := buf.ReadByte(); err != nil {
 					return false, false, err
@@ -205,10 +281,7 @@ func (d *eventDispatcher) readRuneKey(buf *bytes.Buffer) (bool, bool, error) {
 			mod = ModAlt
 			d.escaped = false
 		}
-		ev := NewEvent(Rune, rune(b[0]), mod) // one event for everyone
-		for _, cons := range d.receivers {
-			cons <- ev
-		}
+		d.emit(Rune, rune(b[0]), mod)
 		if _, err := buf.ReadByte(); err != nil {
 			return false, false, err
 		}
@@ -220,8 +293,26 @@ func (d *eventDispatcher) readRuneKey(buf *bytes.Buffer) (bool, bool, error) {
 		return false, false, nil
 	}
 
+	if d.decoder == nil { // UTF-8 input
+		if !utf8.FullRune(b) {
+			return true, false, nil // wait for the rest of the rune
+		}
+		r, size := utf8.DecodeRune(b)
+		mod := ModNone
+		if r == utf8.RuneError && size == 1 {
+			r, mod = d.eightBit(b[0]) // not UTF-8, so the terminal has set the 8th bit
+		}
+		if d.escaped {
+			mod |= ModAlt
+			d.escaped = false
+		}
+		d.emit(Rune, r, mod)
+		buf.Next(size)
+		return true, true, nil
+	}
+
 	utfBytes := make([]byte, 12)
-	for l := 1; l <= len(b); l++ {
+	for l := 1; l <= len(b) && l <= len(utfBytes); l++ { // no charset needs more bytes for a rune
 		d.decoder.Reset()
 		nout, nin, err := d.decoder.Transform(utfBytes, b[:l], true)
 		if err == transform.ErrShortSrc {
@@ -235,10 +326,7 @@ func (d *eventDispatcher) readRuneKey(buf *bytes.Buffer) (bool, bool, error) {
 					mod = ModAlt
 					d.escaped = false
 				}
-				ev := NewEvent(Rune, r, mod) // one event for everyone
-				for _, cons := range d.receivers {
-					cons <- ev
-				}
+				d.emit(Rune, r, mod)
 			}
 			for nin > 0 {
 				if _, err := buf.ReadByte(); err != nil {
@@ -253,50 +341,52 @@ func (d *eventDispatcher) readRuneKey(buf *bytes.Buffer) (bool, bool, error) {
 	return true, false, nil
 }
 
-// readSGR attempts to locate an SGR mouse record at the start of the buffer.
-// It returns true, true if it found one, and the associated bytes be removed from the buffer.
+// readSGR attempts to locate an SGR mouse record at the start of the buffer, so it doesn't trigger false key events.
+// It returns false, true if it found one, and the associated bytes are removed from the buffer.
 // It returns true, false if the buffer might contain such an event, but more bytes are necessary (partial match), and false, false if the content is definitely *not* an SGR mouse record.
-func (d *eventDispatcher) readSGR(buf *bytes.Buffer) (bool, error) {
+func (d *eventDispatcher) readSGR(buf *bytes.Buffer) (bool, bool, error) {
 	b := buf.Bytes()
 	state := 0
 	dig := false
 	neg := false
-	i := 0
-	for i = range b {
+	for i := range b {
+		if i >= maxSequence {
+			return false, false, nil
+		}
 		switch b[i] {
 		case '\x1b':
 			if state != 0 {
-				return false, nil
+				return false, false, nil
 			}
 			state = 1
 		case '\x9b':
 			if state != 0 {
-				return false, nil
+				return false, false, nil
 			}
 			state = 2
 		case '[':
 			if state != 1 {
-				return false, nil
+				return false, false, nil
 			}
 			state = 2
 		case '<':
 			if state != 2 {
-				return false, nil
+				return false, false, nil
 			}
 			dig = false
 			neg = false
 			state = 3
 		case '-':
 			if state != 3 && state != 4 && state != 5 {
-				return false, nil
+				return false, false, nil
 			}
 			if dig || neg {
-				return false, nil
+				return false, false, nil
 			}
 			neg = true // stay in state
 		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 			if state != 3 && state != 4 && state != 5 {
-				return false, nil
+				return false, false, nil
 			}
 			dig = true // stay in state
 
@@ -307,28 +397,25 @@ func (d *eventDispatcher) readSGR(buf *bytes.Buffer) (bool, error) {
 			case 4:
 				neg, dig, state = false, false, 5
 			default:
-				return false, nil
+				return false, false, nil
 			}
 		case 'm', 'M':
-			if state != 5 {
-				return false, nil
+			if state != 5 || !dig {
+				return false, false, nil
 			}
 			// consume the event bytes
-			for i >= 0 {
-				if _, err := buf.ReadByte(); err != nil {
-					return false, err
-				}
-				i--
-			}
-			return true, nil
+			buf.Next(i + 1)
+			return false, true, nil
+		default:
+			return false, false, nil
 		}
 	}
 	// incomplete & inconclusive at this point
-	return false, nil
+	return true, false, nil
 }
 
 // readXTerm is like readSGR, but it parses a legacy X11 mouse record.
-func (d *eventDispatcher) readXTerm(buf *bytes.Buffer) (bool, error) {
+func (d *eventDispatcher) readXTerm(buf *bytes.Buffer) (bool, bool, error) {
 	b := buf.Bytes()
 	state := 0
 	for i := range b {
@@ -340,16 +427,16 @@ func (d *eventDispatcher) readXTerm(buf *bytes.Buffer) (bool, error) {
 			case '\x9b':
 				state = 2
 			default:
-				return false, nil
+				return false, false, nil
 			}
 		case 1:
 			if b[i] != '[' {
-				return false, nil
+				return false, false, nil
 			}
 			state = 2
 		case 2:
 			if b[i] != 'M' {
-				return false, nil
+				return false, false, nil
 			}
 			state++
 		case 3:
@@ -357,16 +444,18 @@ func (d *eventDispatcher) readXTerm(buf *bytes.Buffer) (bool, error) {
 		case 4:
 			state++
 		case 5:
-			for i >= 0 {
-				if _, err := buf.ReadByte(); err != nil {
-					return false, err
-				}
-				i--
-			}
-			return true, nil
+			buf.Next(i + 1)
+			return false, true, nil
 		}
 	}
-	return false, nil
+	return true, false, nil
+}
+
+// skipped hands the mouse record to the function set by Parser.DecodeMouse
+func (d *eventDispatcher) skipped(record []byte) {
+	if d.mouseRecord != nil {
+		d.mouseRecord(record)
+	}
 }
 
 // scanInput reads input from *os.File via input channel
@@ -380,16 +469,40 @@ func (d *eventDispatcher) scanInput(buf *bytes.Buffer, expire bool) error {
 			buf.Reset()
 			break
 		}
+		partials := 0
 		// check if it's a mouse event (this triggers false key events)
-		if comp, _ := d.readXTerm(buf); comp {
+		part, comp, err := d.readXTerm(buf)
+		if err != nil {
+			return err
+		}
+		if comp {
+			d.skipped(byts[:len(byts)-buf.Len()])
+			continue
+		} else if part {
+			partials++
+		}
+		part, comp, err = d.readSGR(buf)
+		if err != nil {
+			return err
+		}
+		if comp {
+			d.skipped(byts[:len(byts)-buf.Len()])
 			continue
+		} else if part {
+			partials++
+		}
+		// keyboard protocol sequences, which carry the event type
+		part, comp, err = d.readKitty(buf)
+		if err != nil {
+			return err
 		}
-		if comp, _ := d.readSGR(buf); comp {
+		if comp {
 			continue
+		} else if part {
+			partials++
 		}
 		// now lookup for normal keys
-		partials := 0
-		part, comp, err := d.readRuneKey(buf)
+		part, comp, err = d.readRuneKey(buf)
 		if err != nil {
 			return err
 		}
@@ -398,6 +511,7 @@ func (d *eventDispatcher) scanInput(buf *bytes.Buffer, expire bool) error {
 		} else if part {
 			partials++
 		}
+		partialRune := part
 		// last lookup for function keys
 		part, comp, err = d.readFuncKey(buf)
 		if err != nil {
@@ -409,13 +523,13 @@ func (d *eventDispatcher) scanInput(buf *bytes.Buffer, expire bool) error {
 			partials++
 		}
 
-		if partials == 0 || expire {
+		if expire && partialRune && len(byts) < utf8.UTFMax && time.Since(d.chunkAt) < maxRuneWait {
+			break // the rune was split across reads : the timer expires the escape sequences, but the rest of the rune is still expected
+		}
+		if partials == 0 || expire || len(byts) > maxSequence {
 			if byts[0] == '\x1b' {
-				if len(byts) == 1 {
-					ev := NewEvent(Esc, 0, ModNone) // one event for everyone
-					for _, cons := range d.receivers {
-						cons <- ev
-					}
+				if len(byts) == 1 || d.rawAlt {
+					d.emit(Esc, 0, ModNone)
 					d.escaped = false
 				} else {
 					d.escaped = true
@@ -428,15 +542,15 @@ func (d *eventDispatcher) scanInput(buf *bytes.Buffer, expire bool) error {
 			// Nothing was going to match, or we timed out waiting for more data -- just deliver the characters to the app & let them sort it out.
 			// Possibly we should only do this for control characters like ESC.
 			by, _ := buf.ReadByte()
-			mod := ModNone
+			r, mod := rune(by), ModNone
+			if by >= 0x80 {
+				r, mod = d.eightBit(by)
+			}
 			if d.escaped {
 				d.escaped = false
-				mod = ModAlt
-			}
-			ev := NewEvent(Rune, rune(by), mod) // one event for everyone
-			for _, cons := range d.receivers {
-				cons <- ev
+				mod |= ModAlt
 			}
+			d.emit(Rune, r, mod)
 			continue
 		}
 		// well we have some partial data, wait until we get some more
@@ -467,8 +581,8 @@ func (d *eventDispatcher) lifeCycle() {
 						if buf.Len() > 0 {
 							if time.Now().After(d.keyExpire) {
 								if err := d.scanInput(buf, true); err != nil {
-									if Debug {
-										log.Printf("error scanning input : %v", err)
+									if Debug.Enabled() {
+										Debug.Printf("error scanning input : %v", err)
 									}
 								}
 							}
@@ -484,10 +598,11 @@ func (d *eventDispatcher) lifeCycle() {
 						}
 					case chunk := <-d.inputCh:
 						buf.Write(chunk)
-						d.keyExpire = time.Now().Add(d.keyTimerDuration)
+						d.chunkAt = time.Now()
+						d.keyExpire = d.chunkAt.Add(d.keyTimerDuration)
 						if err := d.scanInput(buf, false); err != nil {
-							if Debug {
-								log.Printf("error scanning input : %v", err)
+							if Debug.Enabled() {
+								Debug.Printf("error scanning input : %v", err)
 							}
 						}
 						if !d.keyTimer.Stop() {
@@ -0,0 +1,44 @@
+//go:build go1.18
+// +build go1.18
+
+package key
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/info"
+)
+
+func FuzzScanInput(f *testing.F) {
+	for _, seed := range []string{
+		"a", "\x1b", "\x1b[A", "\x1b[1;5A", "\x1bOP", "\x1b[15;2~", "\x1b[97;1:2u", "\x1b[1;5:2A",
+		"\x1b[<0;10;5M", "\x1b[<35;1;1m", "\x1b[M !!", "\x9bM !!", "\xc3\xa9", "\xe9", "\x1b\x1b[B",
+	} {
+		f.Add([]byte(seed))
+	}
+	ti := &info.Term{Name: "fuzz", KeyUp: "\x1b[A", KeyDown: "\x1b[B", KeyF1: "\x1bOP", KeyF5: "\x1b[15~"}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		d, err := NewEventDispatcher(WithTerminalInfo(ti))
+		if err != nil {
+			t.Fatalf("error creating dispatcher : %v", err)
+		}
+		res := d.(*eventDispatcher)
+		res.receivers.insert(make(chan term.KeyEvent, len(data)+1), 0) // at most one event per byte
+
+		buf := bytes.NewBuffer(data)
+		if err := res.scanInput(buf, false); err != nil {
+			t.Fatalf("error scanning : %v", err)
+		}
+		if buf.Len() > maxSequence {
+			t.Fatalf("error : %d bytes kept waiting for the rest of a sequence", buf.Len())
+		}
+		if err := res.scanInput(buf, true); err != nil {
+			t.Fatalf("error scanning : %v", err)
+		}
+		if buf.Len() != 0 {
+			t.Fatalf("error : %d bytes left after expiration", buf.Len())
+		}
+	})
+}
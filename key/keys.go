@@ -52,7 +52,8 @@ func prepareKeyModXTerm(c *eventDispatcher, k term.Key, val string) {
 		prepareKeyMod(c, k, ModMeta|ModCtrl|ModAlt|ModShift, val+";16~")
 		return
 	}
-	if strings.HasPrefix(val, "\x1bO") && len(val) == 3 {
+	if (strings.HasPrefix(val, "\x1bO") || strings.HasPrefix(val, "\x1b[")) && len(val) == 3 {
+		ss3 := val[1] == 'O'
 		val = val[2:]
 		prepareKeyModReplace(c, k, k+12, ModShift, "\x1b[1;2"+val)
 		prepareKeyModReplace(c, k, k+48, ModAlt, "\x1b[1;3"+val)
@@ -69,6 +70,17 @@ func prepareKeyModXTerm(c *eventDispatcher, k term.Key, val string) {
 		prepareKeyMod(c, k, ModMeta|ModCtrl|ModShift, "\x1b[1;14"+val)
 		prepareKeyMod(c, k, ModMeta|ModCtrl|ModAlt, "\x1b[1;15"+val)
 		prepareKeyMod(c, k, ModMeta|ModCtrl|ModAlt|ModShift, "\x1b[1;16"+val)
+		if !ss3 {
+			return
+		}
+		// older XTerm versions (and some emulators) send the modifier inside the SS3 sequence
+		prepareKeyMod(c, k, ModShift, "\x1bO2"+val)
+		prepareKeyMod(c, k, ModAlt, "\x1bO3"+val)
+		prepareKeyMod(c, k, ModAlt|ModShift, "\x1bO4"+val)
+		prepareKeyMod(c, k, ModCtrl, "\x1bO5"+val)
+		prepareKeyMod(c, k, ModCtrl|ModShift, "\x1bO6"+val)
+		prepareKeyMod(c, k, ModAlt|ModCtrl, "\x1bO7"+val)
+		prepareKeyMod(c, k, ModShift|ModAlt|ModCtrl, "\x1bO8"+val)
 	}
 }
 
@@ -100,6 +112,31 @@ func prepareXtermModifiers(c *eventDispatcher, info *termInfo.Term) {
 	prepareKeyModXTerm(c, F12, info.KeyF12)
 }
 
+// xtermFunctionMods are the modifiers of the function keys beyond F12, by groups of 12 : F13 is Shift+F1, F25 is Ctrl+F1, F37 is Ctrl+Shift+F1, F49 is Alt+F1 and F61 is Alt+Shift+F1 (ncurses convention)
+var xtermFunctionMods = []term.ModMask{ModShift, ModCtrl, ModCtrl | ModShift, ModAlt, ModAlt | ModShift}
+
+// prepareFunctionKeys maps the function keys beyond F12 to F1 - F12 with modifiers.
+// Terminals using XTerm modifiers follow the ncurses convention, while the VT220 like ones (rxvt, linux console) have ten shifted keys : F13 to F22 are Shift+F3 to Shift+F12 (Shift+F1 and Shift+F2 being the same as F11 and F12),
+// F23 to F34 are Ctrl+F1 to Ctrl+F12 and F35 to F44 are Ctrl+Shift+F3 to Ctrl+Shift+F12.
+func prepareFunctionKeys(c *eventDispatcher, info *termInfo.Term) {
+	for idx, val := range info.KeyFunctions {
+		if info.Modifiers == termInfo.XTerm {
+			if group := idx / 12; group < len(xtermFunctionMods) {
+				prepareKeyMod(c, F1+term.Key(idx%12), xtermFunctionMods[group], val)
+			}
+			continue
+		}
+		switch n := idx + 13; {
+		case n <= 22:
+			prepareKeyMod(c, F1+term.Key(n-11), ModShift, val)
+		case n <= 34:
+			prepareKeyMod(c, F1+term.Key(n-23), ModCtrl, val)
+		case n <= 44:
+			prepareKeyMod(c, F1+term.Key(n-33), ModCtrl|ModShift, val)
+		}
+	}
+}
+
 func prepareKey(c *eventDispatcher, k term.Key, val string) {
 	prepareKeyMod(c, k, ModNone, val)
 }
@@ -129,6 +166,7 @@ func prepareKeys(c *eventDispatcher, info *termInfo.Term) {
 	prepareKey(c, PgUp, info.KeyPgUp)
 	prepareKey(c, PgDn, info.KeyPgDn)
 	prepareKey(c, Help, info.KeyHelp)
+	prepareKey(c, Clear, info.KeyClear)
 	prepareKey(c, Print, info.KeyPrint)
 	prepareKey(c, Cancel, info.KeyCancel)
 	prepareKey(c, Exit, info.KeyExit)
@@ -142,6 +180,8 @@ func prepareKeys(c *eventDispatcher, info *termInfo.Term) {
 	prepareKeyMod(c, End, ModShift, info.KeyShfEnd)
 	prepareKeyMod(c, PgUp, ModShift, info.KeyShfPgUp)
 	prepareKeyMod(c, PgDn, ModShift, info.KeyShfPgDn)
+	prepareKeyMod(c, Insert, ModShift, info.KeyShfInsert)
+	prepareKeyMod(c, Delete, ModShift, info.KeyShfDelete)
 
 	prepareKeyMod(c, Right, ModCtrl, info.KeyCtrlRight)
 	prepareKeyMod(c, Left, ModCtrl, info.KeyCtrlLeft)
@@ -150,6 +190,8 @@ func prepareKeys(c *eventDispatcher, info *termInfo.Term) {
 	prepareKeyMod(c, Home, ModCtrl, info.KeyCtrlHome)
 	prepareKeyMod(c, End, ModCtrl, info.KeyCtrlEnd)
 
+	prepareFunctionKeys(c, info)
+
 	// Sadly, xterm handling of keyCodes is somewhat erratic.
 	// In particular, different codes are sent depending on application mode is in use or not, and the entries for many of these are simply absent from info on many systems.
 	// So we insert a number of escape sequences if they are not already used, in order to have the widest correct usage.
@@ -0,0 +1,471 @@
+package key
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/badu/term"
+	"github.com/badu/term/debug"
+	"github.com/badu/term/info"
+	_ "github.com/badu/term/info/r/rxvt"
+)
+
+// newTestDispatcher returns a dispatcher for the terminal, having a buffered receiver
+func newTestDispatcher(t *testing.T, ti *info.Term, opts ...Option) (*eventDispatcher, chan term.KeyEvent) {
+	t.Helper()
+	d, err := NewEventDispatcher(append([]Option{WithTerminalInfo(ti)}, opts...)...)
+	if err != nil {
+		t.Fatalf("error creating dispatcher : %v", err)
+	}
+	res := d.(*eventDispatcher)
+	ch := make(chan term.KeyEvent, 16)
+	res.receivers.insert(ch, 0)
+	return res, ch
+}
+
+// scanNames feeds the input to the dispatcher, returning the names of the events
+func scanNames(t *testing.T, d *eventDispatcher, ch chan term.KeyEvent, input string) []string {
+	t.Helper()
+	if err := d.scanInput(bytes.NewBufferString(input), true); err != nil {
+		t.Fatalf("error scanning %q : %v", input, err)
+	}
+	var result []string
+	for {
+		select {
+		case ev := <-ch:
+			result = append(result, ev.Name())
+		default:
+			return result
+		}
+	}
+}
+
+func TestSpecialKeys(t *testing.T) {
+	ti := &info.Term{
+		Name:         "test",
+		KeyHelp:      "\x1b[28~",
+		KeyClear:     "\x1b[3;5~",
+		KeyExit:      "\x1b[4;5~",
+		KeyCancel:    "\x1b[5;5~",
+		KeyPrint:     "\x1b[32~",
+		KeyInsert:    "\x1b[2~",
+		KeyShfInsert: "\x1b[2;2~",
+		KeyShfDelete: "\x1b[3;2~",
+	}
+	d, ch := newTestDispatcher(t, ti)
+	for _, k := range []term.Key{Help, Clear, Exit, Cancel, Print, Insert, Delete} {
+		if !d.HasKey(k) {
+			t.Errorf("error : %s should be known", k)
+		}
+	}
+	for input, want := range map[string]string{
+		"\x1b[28~":  "Help",
+		"\x1b[3;5~": "Clear",
+		"\x1b[4;5~": "Exit",
+		"\x1b[5;5~": "Cancel",
+		"\x1b[32~":  "Print",
+		"\x1b[2;2~": "Shift+Insert",
+		"\x1b[3;2~": "Shift+Delete",
+	} {
+		if got := scanNames(t, d, ch, input); len(got) != 1 || got[0] != want {
+			t.Errorf("error : %q should be %s, got %v", input, want, got)
+		}
+	}
+
+	d, _ = newTestDispatcher(t, &info.Term{Name: "none"})
+	for _, k := range []term.Key{Help, Clear, Exit, Cancel, Print} {
+		if d.HasKey(k) {
+			t.Errorf("error : %s should not be known", k)
+		}
+	}
+}
+
+func TestFunctionKeysModifiers(t *testing.T) {
+	xterm := &info.Term{
+		Name:         "xterm-test",
+		Modifiers:    info.XTerm,
+		KeyF1:        "\x1bOP",
+		KeyF5:        "\x1b[15~",
+		KeyFunctions: []string{"\x1b[1;2P", "\x1b[1;2Q"},
+	}
+	rxvt, err := info.LookupTerminfo("rxvt")
+	if err != nil {
+		t.Fatalf("error looking up rxvt : %v", err)
+	}
+	for _, tc := range []struct {
+		ti    *info.Term
+		input string
+		want  string
+	}{
+		{xterm, "\x1b[1;2P", "Shift+F1"},
+		{xterm, "\x1bO2P", "Shift+F1"},
+		{xterm, "\x1b[1;5P", "Ctrl+F1"},
+		{xterm, "\x1b[15;2~", "Shift+F5"},
+		{xterm, "\x1b[15;6~", "Shift+Ctrl+F5"},
+		{rxvt, "\x1b[25~", "Shift+F3"},
+		{rxvt, "\x1b[24$", "Shift+F12"},
+		{rxvt, "\x1b[11^", "Ctrl+F1"},
+		{rxvt, "\x1b[24^", "Ctrl+F12"},
+		{rxvt, "\x1b[25^", "Shift+Ctrl+F3"},
+		{rxvt, "\x1b[24@", "Shift+Ctrl+F12"},
+	} {
+		d, ch := newTestDispatcher(t, tc.ti)
+		if got := scanNames(t, d, ch, tc.input); len(got) != 1 || got[0] != tc.want {
+			t.Errorf("error : %s %q should be %s, got %v", tc.ti.Name, tc.input, tc.want, got)
+		}
+	}
+}
+
+func TestAltNormalization(t *testing.T) {
+	ti := &info.Term{
+		Name:      "xterm-test",
+		Modifiers: info.XTerm,
+		KeyUp:     "\x1b[A",
+	}
+	for _, tc := range []struct {
+		normalize bool
+		input     string
+		want      []string
+	}{
+		{true, "\x1ba", []string{"Alt+Rune[a]"}},
+		{true, "\xe1", []string{"Alt+Rune[a]"}},
+		{true, "\xe1b", []string{"Alt+Rune[a]", "Rune[b]"}},
+		{true, "\x81", []string{"Alt+Ctrl-A"}},
+		{true, "\x1b[1;3A", []string{"Alt+Up"}},
+		{true, "\x1b\x1b[A", []string{"Alt+Up"}},
+		{true, "é", []string{"Rune[é]"}},
+		{false, "\x1ba", []string{"Esc", "Rune[a]"}},
+		{false, "\xe1", []string{"Rune[á]"}},
+		{false, "\x1b[1;3A", []string{"Alt+Up"}},
+		{false, "é", []string{"Rune[é]"}},
+	} {
+		d, ch := newTestDispatcher(t, ti, WithAltNormalization(tc.normalize))
+		got := scanNames(t, d, ch, tc.input)
+		if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Errorf("error : %q (normalized %t) should be %v, got %v", tc.input, tc.normalize, tc.want, got)
+		}
+	}
+}
+
+func TestKittyKeys(t *testing.T) {
+	ti := &info.Term{
+		Name:   "xterm-kitty-test",
+		KeyUp:  "\x1b[A",
+		KeyF5:  "\x1b[15~",
+		KeyF1:  "\x1bOP",
+		KeyEnd: "\x1b[F",
+	}
+	for _, tc := range []struct {
+		input  string
+		want   []string
+		repeat bool
+	}{
+		{"\x1b[97u", []string{"Rune[a]"}, false},
+		{"\x1b[97;1:2u", []string{"Rune[a]"}, true},
+		{"\x1b[97;1:3u", nil, false},
+		{"\x1b[97;5u", []string{"Ctrl-A"}, false},
+		{"\x1b[97;2u", []string{"Rune[A]"}, false},
+		{"\x1b[97;3:2u", []string{"Alt+Rune[a]"}, true},
+		{"\x1b[27u", []string{"Esc"}, false},
+		{"\x1b[13;1:2u", []string{"Enter"}, true},
+		{"\x1b[1;1:2A", []string{"Up"}, true},
+		{"\x1b[1;5:2A", []string{"Ctrl+Up"}, true},
+		{"\x1b[1;1:2P", []string{"F1"}, true},
+		{"\x1b[15;2:2~", []string{"Shift+F5"}, true},
+		{"\x1b[57399u", nil, false},
+		{"\x1b[A", []string{"Up"}, false},
+	} {
+		d, ch := newTestDispatcher(t, ti)
+		if err := d.scanInput(bytes.NewBufferString(tc.input), true); err != nil {
+			t.Fatalf("error scanning %q : %v", tc.input, err)
+		}
+		var got []string
+		for len(ch) > 0 {
+			ev := <-ch
+			got = append(got, ev.Name())
+			if ev.Repeat() != tc.repeat {
+				t.Errorf("error : %q repeat should be %t", tc.input, tc.repeat)
+			}
+		}
+		if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Errorf("error : %q should be %v, got %v", tc.input, tc.want, got)
+		}
+	}
+
+	d, ch := newTestDispatcher(t, ti)
+	buf := bytes.NewBufferString("\x1b[97;")
+	if err := d.scanInput(buf, false); err != nil || len(ch) != 0 || buf.Len() == 0 {
+		t.Fatalf("error : a partial sequence should wait for more input")
+	}
+	buf.WriteString("5u")
+	if err := d.scanInput(buf, false); err != nil || len(ch) != 1 || (<-ch).Name() != "Ctrl-A" {
+		t.Errorf("error : the completed sequence should be Ctrl-A")
+	}
+}
+
+func TestRepeatInterval(t *testing.T) {
+	d, ch := newTestDispatcher(t, &info.Term{Name: "test"}, WithRepeatInterval(50*time.Millisecond))
+	start := time.Now()
+	for _, tc := range []struct {
+		after  time.Duration // chunk arrival, since start
+		input  string
+		repeat []bool
+	}{
+		{0, "a", []bool{false}},
+		{500 * time.Millisecond, "a", []bool{false}}, // the keyboard repeat delay
+		{530 * time.Millisecond, "a", []bool{true}},
+		{560 * time.Millisecond, "a", []bool{true}},
+		{590 * time.Millisecond, "b", []bool{false}},
+		{620 * time.Millisecond, "bb", []bool{true, false}}, // pasted or typed in the same chunk
+		{1000 * time.Millisecond, "b", []bool{false}},
+	} {
+		d.chunkAt = start.Add(tc.after)
+		if err := d.scanInput(bytes.NewBufferString(tc.input), true); err != nil {
+			t.Fatalf("error scanning %q : %v", tc.input, err)
+		}
+		for i, want := range tc.repeat {
+			if got := (<-ch).Repeat(); got != want {
+				t.Errorf("error : %q at %v, event %d repeat should be %t", tc.input, tc.after, i, want)
+			}
+		}
+	}
+
+	d, ch = newTestDispatcher(t, &info.Term{Name: "test"})
+	for i := 0; i < 2; i++ {
+		d.chunkAt = start.Add(time.Duration(i) * time.Millisecond)
+		if got := scanNames(t, d, ch, "a"); len(got) != 1 || d.last.Repeat() {
+			t.Errorf("error : without interval, no event should be a repeat")
+		}
+	}
+}
+
+func TestEventWhen(t *testing.T) {
+	d, ch := newTestDispatcher(t, &info.Term{Name: "test"})
+	before := time.Now()
+	if got := scanNames(t, d, ch, "a"); len(got) != 1 {
+		t.Fatalf("error : expecting one event, got %v", got)
+	}
+	if when := d.last.When(); when.Before(before) || when.After(time.Now()) {
+		t.Errorf("error : the event should be stamped while parsing, got %v", when)
+	}
+}
+
+func TestSplitRunes(t *testing.T) {
+	for _, r := range []rune{'é', '世', '😀'} {
+		encoded := string(r)
+		for split := 1; split < len(encoded); split++ {
+			for _, prefix := range []string{"", "a", "\x1b"} {
+				d, ch := newTestDispatcher(t, &info.Term{Name: "test"})
+				buf := bytes.NewBufferString(prefix + encoded[:split])
+				d.chunkAt = time.Now()
+				if err := d.scanInput(buf, false); err != nil {
+					t.Fatalf("error scanning : %v", err)
+				}
+				if err := d.scanInput(buf, true); err != nil { // the key timer fires before the next read
+					t.Fatalf("error scanning : %v", err)
+				}
+				buf.WriteString(encoded[split:])
+				d.chunkAt = time.Now()
+				if err := d.scanInput(buf, false); err != nil {
+					t.Fatalf("error scanning : %v", err)
+				}
+				var got []string
+				for len(ch) > 0 {
+					got = append(got, (<-ch).Name())
+				}
+				want := "Rune[" + encoded + "]"
+				switch prefix {
+				case "a":
+					want = "Rune[a]," + want
+				case "\x1b":
+					want = "Alt+" + want
+				}
+				if strings.Join(got, ",") != want || buf.Len() != 0 {
+					t.Errorf("error : %q split at %d should be %s, got %v", prefix+encoded, split, want, got)
+				}
+			}
+		}
+	}
+
+	// the bytes which don't complete the rune in time are the 8th bit (meta) keys
+	d, ch := newTestDispatcher(t, &info.Term{Name: "test"})
+	buf := bytes.NewBufferString("\xe1")
+	d.chunkAt = time.Now().Add(-maxRuneWait)
+	if err := d.scanInput(buf, true); err != nil {
+		t.Fatalf("error scanning : %v", err)
+	}
+	if len(ch) != 1 || (<-ch).Name() != "Alt+Rune[a]" {
+		t.Errorf("error : the lone byte should be delivered once the wait is over")
+	}
+}
+
+func TestMalformedInput(t *testing.T) {
+	d, ch := newTestDispatcher(t, &info.Term{Name: "test", KeyUp: "\x1b[A"})
+
+	// a mouse record split across chunks is waited for, instead of being delivered as keys
+	buf := bytes.NewBufferString("\x1b[<0;1")
+	if err := d.scanInput(buf, false); err != nil {
+		t.Fatalf("error scanning : %v", err)
+	}
+	if buf.Len() == 0 || len(ch) != 0 {
+		t.Errorf("error : the partial mouse record should be kept, got %d events", len(ch))
+	}
+	buf.WriteString("0;5M")
+	if err := d.scanInput(buf, false); err != nil {
+		t.Fatalf("error scanning : %v", err)
+	}
+	if buf.Len() != 0 || len(ch) != 0 {
+		t.Errorf("error : the mouse record should be consumed, without key events, got %d events", len(ch))
+	}
+
+	// garbage inside a mouse record is not swallowed
+	if got := scanNames(t, d, ch, "\x1b[<0;1;1xM"); len(got) == 0 {
+		t.Errorf("error : malformed mouse record should be delivered as keys")
+	}
+
+	// endless sequences don't hold the buffer
+	garbage := "\x1b[" + strings.Repeat("1;", 100)
+	ch = make(chan term.KeyEvent, len(garbage))
+	d.receivers[0].ch = ch
+	buf = bytes.NewBufferString(garbage)
+	if err := d.scanInput(buf, false); err != nil {
+		t.Fatalf("error scanning : %v", err)
+	}
+	if buf.Len() > maxSequence {
+		t.Errorf("error : at most %d bytes should be kept, got %d", maxSequence, buf.Len())
+	}
+	for len(ch) > 0 {
+		<-ch
+	}
+	if got := scanNames(t, d, ch, "\x1b[A"); len(got) != 1 || got[0] != "Up" {
+		t.Errorf("error : keys should be read after garbage, got %v", got)
+	}
+}
+
+func TestReceiversOrder(t *testing.T) {
+	names := make(map[chan term.KeyEvent]string)
+	var receivers channels
+	for _, r := range []struct {
+		name     string
+		priority int
+	}{
+		{"a", 0}, {"b", 0}, {"focus", 5}, {"c", 0}, {"modal", 10}, {"popup", 5}, {"log", -1},
+	} {
+		ch := make(chan term.KeyEvent)
+		names[ch] = r.name
+		receivers.insert(ch, r.priority)
+	}
+	order := func() string {
+		var result []string
+		for _, rcv := range receivers {
+			result = append(result, names[rcv.ch])
+		}
+		return strings.Join(result, ",")
+	}
+	if got := order(); got != "modal,focus,popup,a,b,c,log" {
+		t.Errorf("error : receivers should be ordered by priority, then registration, got %s", got)
+	}
+	receivers.delete(1)
+	receivers.delete(3)
+	if got := order(); got != "modal,popup,a,c,log" {
+		t.Errorf("error : deleting should keep the order, got %s", got)
+	}
+}
+
+func TestParser(t *testing.T) {
+	p := NewParser(WithTerminalInfo(&info.Term{Name: "test", KeyUp: "\x1b[A"}))
+	input := []byte("ab\x1b[")
+	events, consumed := p.Parse(input)
+	if len(events) != 2 || consumed != 2 {
+		t.Fatalf("error : expecting the runes, the partial sequence left, got %d events and %d bytes consumed", len(events), consumed)
+	}
+	events, consumed = p.Parse(append(input[consumed:], 'A'))
+	if len(events) != 1 || consumed != 3 || events[0].(term.KeyEvent).Key() != Up {
+		t.Errorf("error : the sequence completed by the next input should be parsed")
+	}
+}
+
+// testListener is a KeyListener draining its channel until it dies
+type testListener struct {
+	ch   chan term.KeyEvent
+	died chan struct{}
+}
+
+func (l *testListener) KeyListen() chan term.KeyEvent { return l.ch }
+func (l *testListener) DyingChan() chan struct{}      { return l.died }
+
+func TestRegisterWhileDispatching(t *testing.T) {
+	d, err := NewEventDispatcher(WithTerminalInfo(&info.Term{Name: "test", KeyUp: "\x1b[A"}))
+	if err != nil {
+		t.Fatalf("error creating dispatcher : %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d.LifeCycle(ctx)
+
+	const listeners = 20
+	typing := make(chan struct{})
+	go func() { // typing while the listeners come and go
+		for {
+			select {
+			case d.InChan() <- []byte("a"):
+			case <-typing:
+				return
+			}
+		}
+	}()
+	var wg sync.WaitGroup
+	for i := 0; i < listeners; i++ {
+		l := &testListener{ch: make(chan term.KeyEvent), died: make(chan struct{})}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				<-l.ch
+			}
+			close(l.died)
+			for { // drains the events sent before forgetting the listener
+				select {
+				case <-l.ch:
+				case <-time.After(100 * time.Millisecond):
+					return
+				}
+			}
+		}()
+		d.Register(l)
+	}
+	wg.Wait()
+	close(typing)
+
+	res := d.(*eventDispatcher)
+	res.Lock()
+	defer res.Unlock()
+	if len(res.receivers) != 0 {
+		t.Errorf("error : the dead listeners should be forgotten, got %d receivers", len(res.receivers))
+	}
+}
+
+func TestRegisterDebug(t *testing.T) {
+	logged := &bytes.Buffer{}
+	debug.SetOutput(logged)
+	defer debug.SetOutput(nil)
+	debug.Enable("key")
+	defer debug.Disable("key")
+
+	d, err := NewEventDispatcher(WithTerminalInfo(&info.Term{Name: "test"}))
+	if err != nil {
+		t.Fatalf("error creating dispatcher : %v", err)
+	}
+	// registering before the life cycle is logged, without stopping the program
+	d.Register(&testListener{ch: make(chan term.KeyEvent), died: make(chan struct{})})
+	if !strings.Contains(logged.String(), "context not set") {
+		t.Errorf("error : the registration should be logged, got %q", logged.String())
+	}
+	if res := d.(*eventDispatcher); len(res.receivers) != 0 {
+		t.Errorf("error : the listener should not be registered, got %d receivers", len(res.receivers))
+	}
+}
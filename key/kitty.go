@@ -0,0 +1,125 @@
+package key
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/badu/term"
+)
+
+const (
+	kittyRepeat  = 2             // event type of a held key
+	kittyRelease = 3             // event type of a released key, not dispatched
+	kittyFinals  = "ABCDEFHPQS~" // the legacy sequences, which receive the event type when they are modified
+	kittyPrivate = 57344         // start of the private use area, where the protocol puts the functional keys (keypad, media, etc.)
+)
+
+// readKitty checks for the keyboard protocol sequences (CSI code;mods:event u, or the legacy ones having an event type) and dispatches event via channels
+func (d *eventDispatcher) readKitty(buf *bytes.Buffer) (bool, bool, error) {
+	b := buf.Bytes()
+	if len(b) < 2 || b[0] != '\x1b' || b[1] != '[' {
+		return false, false, nil
+	}
+	hasEvent := false
+	for i := 2; i < len(b); i++ {
+		switch c := b[i]; {
+		case c >= '0' && c <= '9', c == ';':
+		case c == ':':
+			hasEvent = true
+		case c == 'u' || (hasEvent && strings.IndexByte(kittyFinals, c) >= 0):
+			d.kittyKey(string(b[2:i]), c)
+			buf.Next(i + 1)
+			return true, true, nil
+		default:
+			return false, false, nil
+		}
+	}
+	return len(b) > 2, false, nil
+}
+
+// kittyKey builds the key event from the sequence parameters and final byte
+func (d *eventDispatcher) kittyKey(params string, final byte) {
+	fields := strings.Split(params, ";")
+	code := kittyParam(fields[0], 0, 1)
+	m, evType := 1, 1
+	if len(fields) > 1 {
+		m, evType = kittyParam(fields[1], 0, 1), kittyParam(fields[1], 1, 1)
+	}
+	if evType == kittyRelease {
+		return
+	}
+	mod := kittyMods(m)
+	if d.escaped {
+		mod |= ModAlt
+		d.escaped = false
+	}
+
+	var ev *event
+	switch {
+	case final != 'u':
+		// the unmodified legacy sequence tells the key, the modifiers come from the parameters
+		seqs := []string{"\x1b[" + string(final), "\x1bO" + string(final)}
+		if final == '~' {
+			seqs = []string{"\x1b[" + strconv.Itoa(code) + "~"}
+		}
+		for _, seq := range seqs {
+			if kc, ok := d.keyCodes[seq]; ok {
+				ev = NewEvent(kc.Key, 0, kc.Mod|mod).(*event)
+				break
+			}
+		}
+		if ev == nil {
+			return
+		}
+	case code <= 0 || code >= kittyPrivate:
+		return
+	default:
+		r := rune(code)
+		if mod&ModShift != 0 && unicode.IsLetter(r) {
+			r, mod = unicode.ToUpper(r), mod&^ModShift
+		}
+		switch {
+		case mod&ModCtrl != 0 && r >= 'a' && r <= 'z':
+			r = r - 'a' + 1 // same as the legacy control characters
+		case mod&ModCtrl != 0 && r == ' ':
+			r = 0
+		}
+		ev = NewEvent(Rune, r, mod).(*event)
+	}
+	ev.repeat = evType == kittyRepeat
+	d.dispatch(ev)
+}
+
+// kittyParam returns the sub parameter (separated by colons) at index, or the default value if it's missing
+func kittyParam(field string, index, def int) int {
+	subs := strings.Split(field, ":")
+	if index >= len(subs) {
+		return def
+	}
+	v, err := strconv.Atoi(subs[index])
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// kittyMods converts the protocol modifiers (one plus the bits of shift, alt, ctrl, super, hyper, meta)
+func kittyMods(m int) term.ModMask {
+	bits := m - 1
+	mod := ModNone
+	if bits&1 != 0 {
+		mod |= ModShift
+	}
+	if bits&2 != 0 {
+		mod |= ModAlt
+	}
+	if bits&4 != 0 {
+		mod |= ModCtrl
+	}
+	if bits&(8|32) != 0 {
+		mod |= ModMeta
+	}
+	return mod
+}
@@ -2,6 +2,8 @@ package key
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/badu/term"
 )
@@ -23,9 +25,11 @@ import (
 // Hence, they should avoid depending overly much on availability of modifiers, or the availability of any specific keys.
 
 type event struct {
-	mod term.ModMask
-	key term.Key
-	r   rune
+	mod    term.ModMask
+	key    term.Key
+	r      rune
+	repeat bool
+	when   time.Time
 }
 
 // Rune returns the rune corresponding to the key press, if it makes sense.
@@ -49,6 +53,17 @@ func (ev *event) Modifiers() term.ModMask {
 	return ev.mod
 }
 
+// When returns the moment the key sequence was parsed
+func (ev *event) When() time.Time {
+	return ev.when
+}
+
+// Repeat returns true if the key is held down and the terminal is auto-repeating it.
+// The kitty keyboard protocol reports it, otherwise it is guessed by timing (see WithRepeatInterval), so it's a hint rather than a fact.
+func (ev *event) Repeat() bool {
+	return ev.repeat
+}
+
 const (
 	Shift = "Shift"
 	Alt   = "Alt"
@@ -76,6 +91,7 @@ const (
 	CenterStr         = "Center"
 	PgDnStr           = "PgDn"
 	PgUpStr           = "PgUp"
+	HelpStr           = "Help"
 	ClearStr          = "Clear"
 	ExitStr           = "Exit"
 	CancelStr         = "Cancel"
@@ -147,169 +163,13 @@ func (ev *event) Name() string {
 		hasCtrl bool
 	)
 
-	switch ev.key {
-	case Enter:
-		s = EnterStr
-	case Backspace:
-		s = BackspaceStr
-	case Tab:
-		s = TabStr
-	case BackTab:
-		s = BackTabStr
-	case Esc:
-		s = EscStr
-	case Backspace2:
-		s = Backspace2Str
-	case Delete:
-		s = DeleteStr
-	case Insert:
-		s = InsertStr
-	case Up:
-		s = UpStr
-	case Down:
-		s = DownStr
-	case Left:
-		s = LeftStr
-	case Right:
-		s = RightStr
-	case Home:
-		s = HomeStr
-	case End:
-		s = EndStr
-	case UpLeft:
-		s = UpLeftStr
-	case UpRight:
-		s = UpRightStr
-	case DownLeft:
-		s = DownLeftStr
-	case DownRight:
-		s = DownRightStr
-	case Center:
-		s = CenterStr
-	case PgDn:
-		s = PgDnStr
-	case PgUp:
-		s = PgUpStr
-	case Clear:
-		s = ClearStr
-	case Exit:
-		s = ExitStr
-	case Cancel:
-		s = CancelStr
-	case Pause:
-		s = PauseStr
-	case Print:
-		s = PrintStr
-	case F1:
-		s = F1Str
-	case F2:
-		s = F2Str
-	case F3:
-		s = F3Str
-	case F4:
-		s = F4Str
-	case F5:
-		s = F5Str
-	case F6:
-		s = F6Str
-	case F7:
-		s = F7Str
-	case F8:
-		s = F8Str
-	case F9:
-		s = F9Str
-	case F10:
-		s = F10Str
-	case F11:
-		s = F11Str
-	case F12:
-		s = F12Str
-	case CtrlA:
-		s = CtrlAStr
-		hasCtrl = true
-	case CtrlB:
-		s = CtrlBStr
-		hasCtrl = true
-	case CtrlC:
-		s = CtrlCStr
-		hasCtrl = true
-	case CtrlD:
-		s = CtrlDStr
-		hasCtrl = true
-	case CtrlE:
-		s = CtrlEStr
-		hasCtrl = true
-	case CtrlF:
-		s = CtrlFStr
-		hasCtrl = true
-	case CtrlG:
-		s = CtrlGStr
-		hasCtrl = true
-	case CtrlJ:
-		s = CtrlJStr
-		hasCtrl = true
-	case CtrlK:
-		s = CtrlKStr
-		hasCtrl = true
-	case CtrlL:
-		s = CtrlLStr
-		hasCtrl = true
-	case CtrlN:
-		s = CtrlNStr
-		hasCtrl = true
-	case CtrlO:
-		s = CtrlOStr
-		hasCtrl = true
-	case CtrlP:
-		s = CtrlPStr
-		hasCtrl = true
-	case CtrlQ:
-		s = CtrlQStr
-		hasCtrl = true
-	case CtrlR:
-		s = CtrlRStr
-		hasCtrl = true
-	case CtrlS:
-		s = CtrlSStr
-		hasCtrl = true
-	case CtrlT:
-		s = CtrlTStr
-		hasCtrl = true
-	case CtrlU:
-		s = CtrlUStr
-		hasCtrl = true
-	case CtrlV:
-		s = CtrlVStr
-		hasCtrl = true
-	case CtrlW:
-		s = CtrlWStr
-		hasCtrl = true
-	case CtrlX:
-		s = CtrlXStr
-		hasCtrl = true
-	case CtrlY:
-		s = CtrlYStr
-		hasCtrl = true
-	case CtrlZ:
-		s = CtrlZStr
-		hasCtrl = true
-	case CtrlSpace:
-		s = CtrlSpaceStr
-		hasCtrl = true
-	case CtrlUnderscore:
-		s = CtrlUnderscoreStr
-		hasCtrl = true
-	case CtrlRightSq:
-		s = CtrlRightSqStr
-		hasCtrl = true
-	case CtrlBackslash:
-		s = CtrlBackslashStr
-		hasCtrl = true
-	case CtrlCarat:
-		s = CtrlCaratStr
-		hasCtrl = true
-	case Rune:
+	name, known := names[ev.key]
+	switch {
+	case ev.key == Rune:
 		s = "Rune[" + string(ev.r) + "]"
+	case known:
+		s = name
+		hasCtrl = strings.HasPrefix(name, Ctrl+"-")
 	default:
 		s = fmt.Sprintf("Key[%d,%d]", ev.key, int(ev.r))
 	}
@@ -348,7 +208,7 @@ func NewEvent(k term.Key, ch rune, mod term.ModMask) term.KeyEvent {
 			}
 		}
 	}
-	return &event{key: k, r: ch, mod: mod}
+	return &event{key: k, r: ch, mod: mod, when: time.Now()}
 }
 
 // These are the modifiers keys that can be sent either with a key press, or a mouse event.
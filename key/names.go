@@ -0,0 +1,81 @@
+package key
+
+import (
+	"github.com/badu/term"
+)
+
+// names of the special keys, used by event.Name() and term.Key.String()
+var names = map[term.Key]string{
+	Rune:           "Rune",
+	Enter:          EnterStr,
+	Backspace:      BackspaceStr,
+	Tab:            TabStr,
+	BackTab:        BackTabStr,
+	Esc:            EscStr,
+	Backspace2:     Backspace2Str,
+	Delete:         DeleteStr,
+	Insert:         InsertStr,
+	Up:             UpStr,
+	Down:           DownStr,
+	Left:           LeftStr,
+	Right:          RightStr,
+	Home:           HomeStr,
+	End:            EndStr,
+	UpLeft:         UpLeftStr,
+	UpRight:        UpRightStr,
+	DownLeft:       DownLeftStr,
+	DownRight:      DownRightStr,
+	Center:         CenterStr,
+	PgDn:           PgDnStr,
+	PgUp:           PgUpStr,
+	Help:           HelpStr,
+	Clear:          ClearStr,
+	Exit:           ExitStr,
+	Cancel:         CancelStr,
+	Pause:          PauseStr,
+	Print:          PrintStr,
+	F1:             F1Str,
+	F2:             F2Str,
+	F3:             F3Str,
+	F4:             F4Str,
+	F5:             F5Str,
+	F6:             F6Str,
+	F7:             F7Str,
+	F8:             F8Str,
+	F9:             F9Str,
+	F10:            F10Str,
+	F11:            F11Str,
+	F12:            F12Str,
+	CtrlA:          CtrlAStr,
+	CtrlB:          CtrlBStr,
+	CtrlC:          CtrlCStr,
+	CtrlD:          CtrlDStr,
+	CtrlE:          CtrlEStr,
+	CtrlF:          CtrlFStr,
+	CtrlG:          CtrlGStr,
+	CtrlJ:          CtrlJStr,
+	CtrlK:          CtrlKStr,
+	CtrlL:          CtrlLStr,
+	CtrlN:          CtrlNStr,
+	CtrlO:          CtrlOStr,
+	CtrlP:          CtrlPStr,
+	CtrlQ:          CtrlQStr,
+	CtrlR:          CtrlRStr,
+	CtrlS:          CtrlSStr,
+	CtrlT:          CtrlTStr,
+	CtrlU:          CtrlUStr,
+	CtrlV:          CtrlVStr,
+	CtrlW:          CtrlWStr,
+	CtrlX:          CtrlXStr,
+	CtrlY:          CtrlYStr,
+	CtrlZ:          CtrlZStr,
+	CtrlSpace:      CtrlSpaceStr,
+	CtrlUnderscore: CtrlUnderscoreStr,
+	CtrlRightSq:    CtrlRightSqStr,
+	CtrlBackslash:  CtrlBackslashStr,
+	CtrlCarat:      CtrlCaratStr,
+}
+
+func init() {
+	term.RegisterKeyNames(names)
+}
@@ -0,0 +1,67 @@
+package key
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/badu/term"
+)
+
+// Parser recognizes the key events in the terminal input, without the dispatcher goroutines and channels : for tests, for input coming from elsewhere than the terminal (e.g. a SSH session),
+// or for programs which need only the parsing. It keeps the state between calls (the escape prefix, the repeat detection), so the input can be fed as it arrives. It's not safe for concurrent use.
+type Parser struct {
+	d      *eventDispatcher // does the recognition
+	events []term.Event     // collected while parsing
+}
+
+// NewParser creates a parser, accepting the same options as the dispatcher (WithTerminalInfo is required for recognizing the function keys)
+func NewParser(opts ...Option) *Parser {
+	res := &Parser{
+		d: &eventDispatcher{
+			keyExist: make(map[term.Key]struct{}),
+			keyCodes: make(map[string]*Code),
+		},
+	}
+	for _, o := range opts {
+		o(res.d)
+	}
+	res.d.sink = res.collect
+	return res
+}
+
+// collect is the sink of the dispatcher
+func (p *Parser) collect(ev term.Event) {
+	p.events = append(p.events, ev)
+}
+
+// DecodeMouse sets the function which turns the mouse records found in the input (X10 and SGR encodings) into events, which are returned in order with the key events.
+// Otherwise, the mouse records are skipped. The record is only valid during the call.
+func (p *Parser) DecodeMouse(fn func(record []byte) []term.Event) {
+	p.d.mouseRecord = func(record []byte) {
+		p.events = append(p.events, fn(record)...)
+	}
+}
+
+// Parse returns the key events found in the input and the number of bytes consumed. The bytes left (a sequence which isn't complete yet) should be passed again, followed by the next input.
+func (p *Parser) Parse(b []byte) ([]term.Event, int) {
+	return p.parse(b, false)
+}
+
+// Flush is like Parse, but the incomplete sequences are delivered as they are, e.g. a lone Esc : it should be called when no input arrives for a while (the dispatcher waits 50 milliseconds).
+func (p *Parser) Flush(b []byte) ([]term.Event, int) {
+	return p.parse(b, true)
+}
+
+// parse scans the input, collecting the events
+func (p *Parser) parse(b []byte, flush bool) ([]term.Event, int) {
+	p.events = nil
+	buf := bytes.NewBuffer(b)
+	p.d.chunkAt = time.Now()
+	if flush {
+		p.d.chunkAt = time.Time{} // the split runes are not waited for either
+	}
+	_ = p.d.scanInput(buf, flush) // reading from a bytes.Buffer, errors can't happen
+	events := p.events
+	p.events = nil
+	return events, len(b) - buf.Len()
+}
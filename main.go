@@ -2,6 +2,9 @@ package term
 
 import (
 	"context"
+	"io"
+	"regexp"
+	"time"
 
 	"github.com/badu/term/color"
 	"github.com/badu/term/style"
@@ -12,8 +15,29 @@ type Death interface {
 	DyingChan() chan struct{}
 }
 
+// PriorityListener can be implemented by key, mouse and resize listeners which must receive the events before the others (e.g. a focus manager, before the passive observers).
+// Listeners receive the events by priority, the highest first, and the ones having the same priority in the order of their registration. The default priority is zero.
+type PriorityListener interface {
+	ListenPriority() int
+}
+
+// ListenPriority returns the priority of the listener, or zero if it doesn't implement PriorityListener
+func ListenPriority(listener interface{}) int {
+	if p, ok := listener.(PriorityListener); ok {
+		return p.ListenPriority()
+	}
+	return 0
+}
+
+// Event is implemented by all the events : KeyEvent, MouseEvent, ResizeEvent, ThemeEvent, TickEvent, PasteEvent and FocusEvent.
+// The ones returned by Engine PollEvent can be told apart using a type switch.
+type Event interface {
+	When() time.Time // the moment the event was parsed from input (or created, for the ones which don't come from input), e.g. for detecting double clicks
+}
+
 // ResizeEvent is an interface that implemented by core
 type ResizeEvent interface {
+	Event
 	Size() *Size
 }
 
@@ -23,6 +47,60 @@ type ResizeListener interface {
 	ResizeListen() chan ResizeEvent
 }
 
+// ThemeEvent is sent when the terminal reports its background color, either as a reply to the query made at start or because the terminal theme has changed
+type ThemeEvent interface {
+	Event
+	Background() color.Color // the reported background color
+	IsDark() bool            // true if the background is dark
+}
+
+// ThemeListener is for listeners that must implement this interface
+type ThemeListener interface {
+	Death
+	ThemeListen() chan ThemeEvent
+}
+
+// ThemeDispatcher is implemented by core engine
+type ThemeDispatcher interface {
+	Register(r ThemeListener)
+}
+
+// TickEvent is sent to the timer listeners, at the interval each of them has asked for
+type TickEvent interface {
+	Event
+	Elapsed() time.Duration // the time since the listener was registered, e.g. for computing the progress of an animation
+}
+
+// TimerListener is for listeners that must implement this interface. The interval is read once, at registration.
+type TimerListener interface {
+	Death
+	TickListen() chan TickEvent
+	TickInterval() time.Duration
+}
+
+// TimerDispatcher is implemented by core engine, so animation and polling code doesn't need its own goroutines
+type TimerDispatcher interface {
+	Register(r TimerListener)
+}
+
+// PasteEvent holds the text pasted by the user, when the bracketed paste mode is enabled
+type PasteEvent interface {
+	Event
+	Text() string
+}
+
+// TerminationEvent tells why the engine stopped by itself, instead of being stopped by the context passed to Start, see TerminalMonitor
+type TerminationEvent interface {
+	Event
+	Reason() error // ErrInputClosed, or a *ReadError wrapping the failure (e.g. EIO once the terminal hung up)
+}
+
+// FocusEvent is sent when the terminal window gains or loses focus, when the focus reports are enabled
+type FocusEvent interface {
+	Event
+	Focused() bool // true if the window has gained focus
+}
+
 // Lifecycler implements a context cancel listener
 type Lifecycler interface {
 	LifeCycle(ctx context.Context)
@@ -57,6 +135,7 @@ type MouseListener interface {
 
 // MouseEvent is an interface that is implemented by mouse package
 type MouseEvent interface {
+	Event
 	Buttons() ButtonMask
 	Modifiers() ModMask
 	Position() (int, int)
@@ -77,11 +156,13 @@ type ButtonMask int16
 type ModMask int16
 
 type KeyEvent interface {
+	Event
 	Rune() rune
 	Key() Key
 	Modifiers() ModMask
 	Name() string
 	ModName() string
+	Repeat() bool // true if the key is held down, rather than pressed again
 }
 
 // KeyListener must be implementers of KeyEvent
@@ -111,26 +192,63 @@ type Style interface {
 
 // Engine is the interface of the core
 type Engine interface {
-	Death                                        // returns the chan that creator needs to be listen for graceful shutdown
-	Start(ctx context.Context) error             // returns error if we cannot start
-	ResizeDispatcher() ResizeDispatcher          // returns the event dispatcher, so listeners can call Register(r Receiver) method
-	KeyDispatcher() KeyDispatcher                // returns the event dispatcher, so listeners can call Register(r Receiver) method
-	MouseDispatcher() MouseDispatcher            // returns the event dispatcher, so listeners can call Register(r Receiver) method
-	CanDisplay(r rune, checkFallbacks bool) bool // checks if a rune can be displayed
-	CharacterSet() string                        // getter for current charset
-	SetRuneFallback(orig rune, fallback string)  // sets a fallback for a rune
-	UnsetRuneFallback(orig rune)                 // forgets fallback for a rune
-	NumColors() int                              // returns the number of colors of the current display
-	Size() *Size                                 // returns the size of the current display
-	HasTrueColor() bool                          // returns true if can display true color
-	Style() Style                                // returns the terminal styles and palette
-	ActivePixels(pixels []PixelGetter)           // registers the active pixels, forgetting the old ones. This behaviour should be found in Pages
-	Redraw(pixels []PixelGetter)                 // does a buffered redraw of the screen (TODO : should not be used)
-	ShowCursor(where *Position)                  // shows the cursor at the indicated position
-	HideCursor()                                 // hides the cursor
-	Cursor() *Position                           // returns the cursor current position
-	Clear()                                      // cleans the screen
-	HasMouse() bool                              // returns true if mouse support is available
+	Death                                         // returns the chan that creator needs to be listen for graceful shutdown
+	Start(ctx context.Context) error              // returns error if we cannot start
+	ResizeDispatcher() ResizeDispatcher           // returns the event dispatcher, so listeners can call Register(r Receiver) method
+	KeyDispatcher() KeyDispatcher                 // returns the event dispatcher, so listeners can call Register(r Receiver) method
+	MouseDispatcher() MouseDispatcher             // returns the event dispatcher, so listeners can call Register(r Receiver) method
+	CanDisplay(r rune, checkFallbacks bool) bool  // checks if a rune can be displayed
+	CharacterSet() string                         // getter for current charset
+	SetRuneFallback(orig rune, fallback string)   // sets a fallback for a rune
+	UnsetRuneFallback(orig rune)                  // forgets fallback for a rune
+	NumColors() int                               // returns the number of colors of the current display
+	Size() *Size                                  // returns the size of the current display
+	HasTrueColor() bool                           // returns true if can display true color
+	Attributes() style.Mask                       // returns the text attributes which the terminal can render (e.g. style.Italic needs sitm)
+	ColorProfile() ColorProfile                   // returns the color profile, after honoring NO_COLOR, CLICOLOR, CLICOLOR_FORCE and FORCE_COLOR
+	IsDarkBackground() (bool, bool)               // returns true if the terminal background is dark, and false as the second value if the terminal hasn't reported it (yet)
+	ThemeDispatcher() ThemeDispatcher             // returns the event dispatcher, so listeners can call Register(r ThemeListener) method
+	TimerDispatcher() TimerDispatcher             // returns the event dispatcher, so listeners can call Register(r TimerListener) method
+	Style() Style                                 // returns the terminal styles and palette
+	ActivePixels(pixels []PixelGetter)            // registers the active pixels, forgetting the old ones. This behaviour should be found in Pages
+	Redraw(pixels []PixelGetter)                  // does a buffered redraw of the screen, the rows which have scrolled being moved by the terminal (TODO : should not be used)
+	ShowCursor(where *Position)                   // shows the cursor at the indicated position
+	HideCursor()                                  // hides the cursor
+	Cursor() *Position                            // returns the cursor current position
+	Clear()                                       // cleans the screen
+	HasMouse() bool                               // returns true if mouse support is available
+	InterruptChan() chan struct{}                 // notified each time the user asks to quit (Ctrl+C or Ctrl+\), whether they are delivered as keys or as signals
+	Writer() io.Writer                            // returns a writer for custom sequences, which is safe to use while pixels are drawn
+	PollEvent(ctx context.Context) (Event, error) // waits for the next event, for programs which prefer a poll loop instead of registering listeners
+}
+
+// LineEditor is optionally implemented by the Engine, moving the content of the screen as a text editor does, with the il, dl, ich and dch capabilities of the terminal.
+// When the terminal lacks them (or they would move the reserved edges too), the moved part of the screen is redrawn from the active pixels, the positions without one being blank.
+// Each method returns false when nothing was done (e.g. a position outside the screen, or the plain mode), in which case the caller should redraw instead.
+// Note that the content is moved on the screen only : pixels are not aware of it, so they need to be updated accordingly.
+type LineEditor interface {
+	InsertLines(row, count int) bool             // inserts count blank lines at row, moving the lines below down
+	DeleteLines(row, count int) bool             // deletes count lines starting with row, moving the lines below up
+	InsertChars(where *Position, count int) bool // inserts count blank characters at position, shifting the rest of the line right
+	DeleteChars(where *Position, count int) bool // deletes count characters at position, shifting the rest of the line left
+}
+
+// CapabilityWriter is optionally implemented by the Engine, allowing the usage of any terminfo string capability by its name
+type CapabilityWriter interface {
+	HasCapability(name string) bool                 // returns true if the terminal has the named capability (e.g. "flash")
+	PutCapability(name string, params ...int) error // writes the named capability to output, with parameters applied
+}
+
+// Degradation is a rendering decision made by the Engine, because the terminal lacks a capability
+type Degradation struct {
+	Reason string // e.g. "italic unsupported on this terminal, dropped" or "RGB #5F87AF downsampled to 256-color index 67"
+	Count  int    // how many times it was made (once per style change, not per pixel)
+}
+
+// DegradationReporter is optionally implemented by the Engine, summarizing why colors or attributes weren't rendered as requested, when the diagnostics mode is enabled
+type DegradationReporter interface {
+	Degradations() []Degradation // the decisions, in the order they were first made
+	ResetDegradations()          // forgets the decisions recorded so far
 }
 
 type Unicode []rune
@@ -147,6 +265,7 @@ type PixelGetter interface {
 }
 
 // PixelSetter is the complete interface (both setter and getter)
+// Setters can be called from any goroutine. Changes are coalesced : the engine is asked to draw once, and it draws the latest values.
 type PixelSetter interface {
 	Set(r rune, fg, bg color.Color)                             // sets both colors and rune so we don't do three calls
 	SetFgBg(fg, bg color.Color)                                 // sets both colors, so we don't do two calls (usually only if the colors have changed)
@@ -161,4 +280,284 @@ type PixelSetter interface {
 type Pixel interface {
 	PixelGetter
 	PixelSetter
+	Equals(other PixelGetter) bool // true if both pixels show the same content (see SameContent)
+	Hash() uint64                  // hash of the content (see ContentHash)
+}
+
+// Mover is optionally implemented by the Pixel, for the content which moves around (cursors, selections, sprites of animations), instead of swapping the values between pixels.
+// The engine erases the pixel from its previous position, drawing the active pixel registered there or a blank, and draws it at the new one.
+type Mover interface {
+	MoveTo(pos *Position) // moves the pixel and triggers redrawing
+}
+
+// ContentGetter is optionally implemented by the Engine, giving access to what is on screen : the active pixels (see ActivePixels), by their position.
+// Compositing effects (e.g. dimming the content beneath a popup, see geom.Overlay) need to know what's underneath.
+type ContentGetter interface {
+	GetContent(column, row int) (Pixel, bool) // returns false if there is no active pixel at that position
+}
+
+// RegionFiller is optionally implemented by the Engine, for clearing or filling a part of the screen in a single call.
+// The corners are inclusive and the region is clipped to the area left to the pages (see EdgeReserver). Active pixels inside the region are updated (so they keep showing the new content),
+// while the rest of the region is written directly, in one buffered write, so partial clears don't flash.
+type RegionFiller interface {
+	ClearRegion(from, to *Position, st style.Style) error  // fills the region with spaces, using the style (e.g. the background color)
+	Fill(from, to *Position, r rune, st style.Style) error // fills the region with the rune, using the style. Returns ErrNilPosition for a missing corner, or the *WriteError
+}
+
+// StatusLiner is optionally implemented by the Engine, giving the applications a status (or message) bar which is not part of the pixels grid.
+// The status line of the terminal is used when it has one (tsl and fsl capabilities), otherwise the bottom row of the screen is reserved while a status is set :
+// Size and the resize events report one row less, so the pages never draw over it.
+type StatusLiner interface {
+	HasStatusLine() bool                   // returns true if the terminal has a status line, so no row has to be reserved
+	SetStatus(text string, st style.Style) // shows the text, replacing the previous one. The style is used for the reserved row only
+	ClearStatus()                          // removes the status, giving back the reserved row
+}
+
+// Layerer is optionally implemented by the Engine : the pixels of a layer are drawn above the active pixels, which keep their owners and keep changing underneath without being drawn, e.g. for notifications.
+// Layers are drawn in the order of their ids, the highest one on top.
+type Layerer interface {
+	SetLayer(id int, pixels []PixelGetter) // replaces the pixels of the layer, drawing them and the active pixels they don't cover anymore
+	RemoveLayer(id int)                    // forgets the layer, drawing the active pixels it covered
+}
+
+// Poster is optionally implemented by the Engine, for background workers which have to update the pixels : the functions are run one at a time, in the order they were posted,
+// on the goroutine of the engine and with the engine locked, so the updates don't interleave with drawing : the changed pixels are drawn after the function returns.
+// Functions posted before Start are run once the engine has started, and the pending ones are dropped on shutdown.
+// A posted function can post others, but it must not wait for them, nor call the methods of the Engine (or the helpers calling them, like geom.Page SetRow), which would deadlock.
+type Poster interface {
+	Post(fn func()) // queues the function, without waiting for it to run
+}
+
+// BoundsChecker is optionally implemented by the Engine, for finding the pixels whose positions are outside the screen, which are never drawn (unless the engine wraps them).
+type BoundsChecker interface {
+	CheckBounds(pixels []PixelGetter) error // returns an *OutOfBoundsError for the first pixel outside the current size, or nil
+	OutOfBounds() error                     // returns the *OutOfBoundsError of the last pixel drawn outside the screen and forgets it, if the engine reports them, or nil
+}
+
+// DEC private modes, which can be checked with ModeReporter
+const (
+	ModeCursorKeys         = 1    // the cursor keys send the application sequences (DECCKM, part of the keypad transmit mode)
+	ModeAutoWrap           = 7    // the text wraps at the right margin (DECAWM), which some initialization strings change
+	ModeCursorVisible      = 25   // the cursor is shown (DECTCEM)
+	ModeMouseClicks        = 1000 // mouse button reports
+	ModeMouseDrag          = 1002 // mouse motion reports, while a button is pressed
+	ModeMouseMotion        = 1003 // all mouse motion reports
+	ModeFocusReports       = 1004 // focus in and out reports
+	ModeMouseSGR           = 1006 // mouse reports in the SGR encoding
+	ModeBracketedPaste     = 2004 // the pasted text is bracketed
+	ModeSynchronizedOutput = 2026 // the terminal can hold the drawing until the end of a frame
+	ModeThemeReports       = 2031 // theme change reports
+	ModeAlternateScreen    = 1049 // the alternate screen is shown, the cursor being saved
+)
+
+// ModeStatus is the state of a terminal mode, as reported by the terminal (DECRPM, the reply to DECRQM)
+type ModeStatus int
+
+const (
+	ModeUnknown          ModeStatus = iota // the mode wasn't queried, or the terminal didn't reply (yet), e.g. because it doesn't understand DECRQM
+	ModeNotRecognized                      // the terminal doesn't know the mode
+	ModeSet                                // the mode is enabled
+	ModeReset                              // the mode is disabled
+	ModePermanentlySet                     // the mode is enabled and cannot be changed
+	ModePermanentlyReset                   // the mode is disabled and cannot be changed
+)
+
+// IsSet returns true if the terminal has confirmed that the mode is enabled
+func (s ModeStatus) IsSet() bool {
+	return s == ModeSet || s == ModePermanentlySet
+}
+
+// String implements fmt.Stringer interface
+func (s ModeStatus) String() string {
+	switch s {
+	case ModeNotRecognized:
+		return "NotRecognized"
+	case ModeSet:
+		return "Set"
+	case ModeReset:
+		return "Reset"
+	case ModePermanentlySet:
+		return "PermanentlySet"
+	case ModePermanentlyReset:
+		return "PermanentlyReset"
+	default:
+		return "Unknown"
+	}
+}
+
+// ModeReporter is optionally implemented by the Engine, telling whether the terminal actually honors the modes, instead of the applications assuming features which silently failed.
+// The engine queries the modes it enables (mouse, bracketed paste, focus and theme reports) and the synchronized output on start, the replies arriving via input.
+type ModeReporter interface {
+	ModeStatus(mode int) ModeStatus // returns the last reported state of the DEC private mode
+	QueryMode(mode int)             // asks the terminal about the DEC private mode, ModeStatus returning the reply once it arrives
+}
+
+// Resetter is optionally implemented by the Engine, as a last resort for applications which detect a corrupted display, or which recover from a crash before exiting.
+type Resetter interface {
+	ResetTerminal() // sends the reset strings of the terminal (rs1, rs2), resets the attributes and leaves the alternate screen
+}
+
+// InputMetrics describes the backpressure between the reader of the terminal input and a dispatcher, see InputMonitor
+type InputMetrics struct {
+	Capacity  int           // the buffer size of the dispatcher channel, zero if unbuffered
+	Chunks    int           // the chunks of input sent to the dispatcher
+	Stalls    int           // how many times the reader had to wait, because the buffer was full (or the dispatcher was busy, if unbuffered)
+	Stalled   time.Duration // the total time the reader has waited
+	HighWater int           // the most chunks found waiting in the buffer
+}
+
+// InputMonitor is optionally implemented by the Engine, for tuning the input buffers (e.g. for high-latency links) : while a dispatcher is busy and its buffer is full,
+// the reader of the terminal waits, so it reads nothing else, for none of the dispatchers.
+type InputMonitor interface {
+	InputMetrics() (keys InputMetrics, mouse InputMetrics) // returns the metrics since start, for the key and for the mouse dispatcher
+}
+
+// DrawMetrics describes the drawing of the pages, see DrawMonitor. A frame is a batch of pixels drawn at once : a Redraw, or the changes of a pixel.
+type DrawMetrics struct {
+	Frames        int           // the frames drawn since start
+	Cells         int           // the cells drawn since start
+	Bytes         int           // written to the terminal since start, the debug overlay excluded
+	FPS           float64       // the frames per second, over the last second
+	CellsPerFrame float64       // the dirty cells of a frame, over the last second
+	BytesPerFrame float64       // over the last second
+	Latency       time.Duration // from the last input read to the first frame drawn after it
+}
+
+// DrawMonitor is optionally implemented by the Engine, for diagnosing slow applications : DrawMetrics are measured all the time,
+// and the debug overlay shows them on the top row of the screen, which is reserved while it's shown.
+type DrawMonitor interface {
+	DrawMetrics() DrawMetrics   // returns the metrics since start
+	ShowDebugOverlay(show bool) // shows or hides the debug overlay
+	DebugOverlayShown() bool    // returns true if the debug overlay is shown
+}
+
+// TerminalMonitor is optionally implemented by the Engine, for detecting that the terminal went away (e.g. the ssh connection dropped, or the window was closed) : a write failed, or the input reached its end.
+// PollEvent returns the same error, so the applications polling the events stop instead of waiting forever.
+// When the input reaches its end or fails (EOF, EIO), the engine also shuts down by itself, closing DyingChan : Termination tells why.
+type TerminalMonitor interface {
+	TerminalLost() <-chan struct{} // closed once the terminal is lost
+	TerminalErr() error            // a *WriteError (matching ErrTerminalLost), ErrInputClosed or a *ReadError (matching ErrInputClosed), nil while the terminal works
+	Termination() TerminationEvent // the reason the engine stopped by itself, nil if it didn't
+}
+
+// Printer writes styled text sequentially, as the command line tools do, sharing the terminal definitions and the color downsampling rules of the Engine, without taking over the terminal : no raw mode, no alternate screen, no pixels.
+// Each call turns the style off after the text, so the output stays clean whatever comes next.
+type Printer interface {
+	Print(st style.Style, text string) error                         // writes the text in the style
+	Printf(st style.Style, format string, args ...interface{}) error // same as Print, formatting the text
+	Println(st style.Style, text string) error                       // same as Print, followed by a new line
+	ColorProfile() ColorProfile                                      // returns the color profile, after honoring NO_COLOR, CLICOLOR, CLICOLOR_FORCE and FORCE_COLOR
+}
+
+// Edge is a side of the screen, see EdgeReserver
+type Edge int
+
+const (
+	EdgeTop    Edge = iota // rows at the top of the screen
+	EdgeBottom             // rows at the bottom of the screen
+	EdgeLeft               // columns at the left of the screen
+	EdgeRight              // columns at the right of the screen
+)
+
+// Margins are the rows and columns reserved at the edges of the screen
+type Margins struct {
+	Top    int
+	Bottom int
+	Left   int
+	Right  int
+}
+
+// EdgeReserver is optionally implemented by the Engine, for reserving rows or columns at the screen edges for the application chrome (e.g. a tab bar), so it can coexist with the pages.
+// The reserved cells are excluded from Size and the resize events, while the positions of the pixels, of the cursor and of the mouse events are translated : for the pages,
+// the top left corner is the first cell which is not reserved. Mouse events over the chrome have negative coordinates (top, left) or coordinates past the size (bottom, right).
+type EdgeReserver interface {
+	ReserveEdge(edge Edge, cells int)         // reserves the rows (top, bottom) or columns (left, right) at the edge, zero giving them back
+	Margins() Margins                         // returns the cells reserved at each edge
+	DrawEdge(edge Edge, pixels []PixelGetter) // draws the pixels, whose positions are relative to the top left corner of the edge, on the reserved cells
+}
+
+// MarginsEvent is optionally implemented by the ResizeEvent, when the Engine implements EdgeReserver
+type MarginsEvent interface {
+	Margins() Margins // the cells reserved at each edge, when the event was dispatched
+}
+
+// Mouse pointer shapes, using the CSS cursor names which are understood by the terminals supporting OSC 22 (kitty, wezterm, foot, xterm)
+const (
+	PointerDefault    = "default"     // usually an arrow
+	PointerHand       = "pointer"     // a hand, for clickable elements
+	PointerText       = "text"        // an I-beam, for editable text
+	PointerCrosshair  = "crosshair"   //
+	PointerMove       = "move"        //
+	PointerNotAllowed = "not-allowed" //
+	PointerWait       = "wait"        //
+	PointerEWResize   = "ew-resize"   // horizontal splitters
+	PointerNSResize   = "ns-resize"   // vertical splitters
+)
+
+// PointerShaper is optionally implemented by the Engine, for changing the mouse pointer shape (OSC 22).
+// Terminals without support ignore the request. The default shape is restored on shutdown.
+type PointerShaper interface {
+	SetPointerShape(shape string) bool // returns false if the shape name is invalid or there is no screen
+}
+
+// AnnouncePriority tells how urgent an announcement is, see Announcer
+type AnnouncePriority int
+
+const (
+	AnnouncePolite    AnnouncePriority = iota // shown on the status, read when the screen reader gets there
+	AnnounceAssertive                         // also sent as a desktop notification (see Notifier), which the screen readers of the desktop read at once
+)
+
+// Notifier is optionally implemented by the Engine, for the long-running applications which have to alert the user, through the native notifications of the terminal emulator :
+// OSC 9 (iTerm2, kitty) or OSC 777 (urxvt, foot, WezTerm, Ghostty), depending on the terminal.
+type Notifier interface {
+	Notify(title, body string) bool // returns false if the terminal isn't known to show notifications. The control characters are removed
+}
+
+// Announcer is optionally implemented by the Engine, for telling the users of screen readers what changed (e.g. "3 files copied", "connection lost"), which they would miss while reading another part of the screen.
+// The announcement replaces the status (see StatusLiner), which is the last row of the screen, where the screen readers look for messages, while in plain mode it's written as a line of its own.
+type Announcer interface {
+	Announce(text string, priority AnnouncePriority) // the control characters are removed from the text
+}
+
+// HighContraster is optionally implemented by the Engine, for the users with low vision : in high contrast mode, the foreground colors are changed until they reach
+// the AAA contrast ratio (see color.EnsureContrast) against their backgrounds, and the dim and blink attributes are dropped.
+type HighContraster interface {
+	SetHighContrast(enabled bool) // toggles the mode, redrawing the active pixels
+	HighContrast() bool           // returns true while the mode is enabled
+}
+
+// WindowManipulator is optionally implemented by the Engine, for the window operations of xterm (XTWINOPS, CSI Ps t). The terminals often disallow some of them (e.g. xterm, unless allowWindowOps is set),
+// ignoring the requests : the queries return an error once they time out, while the operations can't tell (a resize arrives as usual, with a resize event).
+// The terminals which don't have them at all (e.g. linux, vt52) return ErrNotSupported, without anything being written.
+type WindowManipulator interface {
+	TextAreaPixels() (int, int, error)    // returns the width and the height of the text area, in pixels
+	TextAreaCells() (*Size, error)        // returns the size of the text area, in characters
+	WindowPosition() (int, int, error)    // returns the position of the top left corner of the window on the display, in pixels
+	PushTitle() error                     // saves the window and icon titles on the stack of the terminal
+	PopTitle() error                      // restores the titles saved by the last PushTitle
+	ResizeWindow(columns, rows int) error // asks for a text area of that size, in characters. Zero uses the size of the display
+	MoveWindow(x, y int) error            // asks for moving the window, in pixels
+}
+
+// Clipboard is optionally implemented by the Engine, for copying text to the system clipboard with OSC 52, which also works over ssh. The terminals often disallow it (e.g. xterm, unless allowWindowOps is set),
+// ignoring the request, and can't be asked whether it succeeded.
+type Clipboard interface {
+	SetClipboard(text string) bool // returns false if there is no screen
+}
+
+// SearchMatch is a match found by the Searcher, on a single row
+type SearchMatch struct {
+	Column  int // the first column of the match
+	Row     int //
+	Columns int // the number of columns the match spans
+}
+
+// Searcher is optionally implemented by the Engine, for finding text on the screen (e.g. in a pager or a log viewer) : the matches are drawn with the highlight style, without changing the pixels, until the search is cleared.
+// The screen is scanned again on each Redraw (e.g. after a viewport has scrolled), so the highlights follow the content. Use regexp.QuoteMeta for searching a plain string.
+type Searcher interface {
+	Search(pattern *regexp.Regexp, highlight style.Style) int // highlights the matches, returning their number. The matches can't span rows
+	NextMatch() (SearchMatch, bool)                           // makes the match following the current one the current (wrapping around), drawn in reverse video, returning it so the viewport showing it can be scrolled
+	PreviousMatch() (SearchMatch, bool)                       // same as NextMatch, backwards
+	ClearSearch()                                             // removes the highlights
 }
@@ -1,5 +1,6 @@
 package mouse
 
-const (
-	Debug = false
-)
+import "github.com/badu/term/debug"
+
+// Debug is the diagnosis log of the package, enabled at runtime with TERM_DEBUG=mouse or core.WithDebug("mouse") (see package debug)
+var Debug = debug.For("mouse")
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"errors"
-	"log"
 	"sync"
 
 	"github.com/badu/term"
@@ -12,16 +11,37 @@ import (
 	"github.com/badu/term/key"
 )
 
+const (
+	maxSequence = 64      // partial mouse records longer than this are given up, so garbage input can't grow the buffer
+	maxParam    = 1 << 16 // SGR parameters above this are invalid, instead of overflowing
+)
+
+// receiver is a registered listener channel, having the priority of the listener (see term.PriorityListener)
+type receiver struct {
+	ch       chan term.MouseEvent
+	priority int
+}
+
 // for readability
-type channels []chan term.MouseEvent
+type channels []receiver
 
-// delete removes the element at index from channels.
-// Note that this is the fastest version, which changes order of elements inside the slice
+// delete removes the element at index from channels, keeping the order of the others, so the events are always delivered in the same order.
 // Yes, this is repeated code, because avoiding use of interface{}
 func (c *channels) delete(idx int) {
-	(*c)[idx] = (*c)[len(*c)-1] // Copy last element to index i.
-	(*c)[len(*c)-1] = nil       // Erase last element (write zero value).
-	*c = (*c)[:len(*c)-1]       // Truncate slice.
+	copy((*c)[idx:], (*c)[idx+1:]) // Shift the elements after index.
+	(*c)[len(*c)-1] = receiver{}   // Erase last element (write zero value).
+	*c = (*c)[:len(*c)-1]          // Truncate slice.
+}
+
+// insert adds the channel after the ones having the same or a higher priority : the events are delivered by priority, then in the order of registration
+func (c *channels) insert(ch chan term.MouseEvent, priority int) {
+	idx := len(*c)
+	for idx > 0 && (*c)[idx-1].priority < priority {
+		idx--
+	}
+	*c = append(*c, receiver{})
+	copy((*c)[idx+1:], (*c)[idx:])
+	(*c)[idx] = receiver{ch: ch, priority: priority}
 }
 
 type Finalizer func()
@@ -49,6 +69,15 @@ func WithSwitchChannel(ch chan bool) Option {
 	}
 }
 
+// WithInputBuffer is a functional option for buffering the channel which receives the input chunks from core, so bursts (e.g. drags) don't stall the reader of the terminal. Default is zero (unbuffered).
+func WithInputBuffer(size int) Option {
+	return func(e *eventDispatcher) {
+		if size > 0 {
+			e.inputCh = make(chan []byte, size)
+		}
+	}
+}
+
 // eventDispatcher is an implementation of mouse MouseDispatcher.
 type eventDispatcher struct {
 	sync.Mutex                       // guards other properties
@@ -64,6 +93,8 @@ type eventDispatcher struct {
 	finalizer  Finalizer             // Yes, we have callback and we could reuse it, but we will affect readability doing so
 	ctx        context.Context       //
 	hasMouse   bool                  // set by WithTerminalInfo
+	margins    term.Margins          // the cells reserved at the screen edges, reported by the resize events
+	sink       func(term.Event)      // set by Parser, receives the events instead of the receivers
 }
 
 // NewEventDispatcher ignites dispatcher and check for terminal info if mouse is supported.
@@ -93,7 +124,9 @@ func NewEventDispatcher(options ...Option) (term.MouseDispatcher, error) {
 
 // LifeCycle implementation of term.MouseDispatcher interface, called from core
 func (e *eventDispatcher) LifeCycle(ctx context.Context) {
+	e.Lock()
 	e.ctx = ctx
+	e.Unlock()
 	// mount lifecycle - listens for chunks of []byte coming via inputCh, analyses them and builds mouse events
 	e.lifeCycle()
 }
@@ -108,11 +141,14 @@ func (e *eventDispatcher) InChan() chan []byte {
 	return e.inputCh
 }
 
-// Register - implementation of term.MouseDispatcher interface - is registering receivers
+// Register - implementation of term.MouseDispatcher interface - is registering receivers. The receivers are guarded by the mutex, since the events are dispatched while scanning the input.
 func (e *eventDispatcher) Register(r term.MouseListener) {
+	e.Lock()
+	defer e.Unlock()
+
 	if e.ctx == nil {
-		if Debug {
-			log.Fatal("context not set : cannot listen context.Done()")
+		if Debug.Enabled() {
+			Debug.Println("context not set : cannot listen context.Done()")
 		}
 		return
 	}
@@ -120,7 +156,7 @@ func (e *eventDispatcher) Register(r term.MouseListener) {
 	alreadyRegistered := false
 	for _, ch := range e.receivers {
 		// Two channel values are considered equal if they originated from the same make call (meaning they refer to the same channel value in memory).
-		if ch == r.MouseListen() {
+		if ch.ch == r.MouseListen() {
 			alreadyRegistered = true
 			break
 		}
@@ -129,15 +165,17 @@ func (e *eventDispatcher) Register(r term.MouseListener) {
 		return
 	}
 	// we're fine, lets register it
-	e.receivers = append(e.receivers, r.MouseListen())
+	e.receivers.insert(r.MouseListen(), term.ListenPriority(r))
 	// mounting a go routine to listen bye-bye life
 	go func() {
 		// wait for death announcement
 		<-r.DyingChan()
+		e.Lock()
+		defer e.Unlock()
 		// now lookup for that very channel and forget it
 		for idx, ch := range e.receivers {
 			// Two channel values are considered equal if they originated from the same make call (meaning they refer to the same channel value in memory).
-			if ch == r.MouseListen() {
+			if ch.ch == r.MouseListen() {
 				e.receivers.delete(idx)
 				break
 			}
@@ -182,7 +220,7 @@ func clip(x, y, w, h int) (int, int) {
 }
 
 // buildMouseEvent returns an event based on the supplied coordinates and button state.
-// Note that the screen's mouse button state is updated based on the input to this function (i.e. it mutates the receiver).
+// Note that the screen's mouse button state is updated based on the input to this function (i.e. it mutates the receiver) - locked inside caller function
 func (e *eventDispatcher) buildMouseEvent(x, y, btn int) {
 	// XTerm mouse events only report at most one button at a time, which may include a wheel button.
 	// Wheel motion events are reported as single impulses, while other button events are reported as separate press & release events.
@@ -229,12 +267,21 @@ func (e *eventDispatcher) buildMouseEvent(x, y, btn int) {
 	}
 
 	// Some terminals will report mouse coordinates outside the screen, especially with click-drag events.
-	// Clip the coordinates to the screen in that case.
-	x, y = clip(x, y, e.size.Columns, e.size.Rows)
+	// Clip the coordinates to the screen in that case, then make them relative to the part of the screen which isn't reserved.
+	if e.size.Columns > 0 && e.size.Rows > 0 {
+		x, y = clip(x, y, e.size.Columns+e.margins.Left+e.margins.Right, e.size.Rows+e.margins.Top+e.margins.Bottom)
+	}
+	x, y = x-e.margins.Left, y-e.margins.Top
 	ev := NewEvent(x, y, button, mod) // one event for everyone
-	// send term.MouseEvent it to receivers
-	for _, cons := range e.receivers {
-		cons <- ev
+	if e.sink != nil {
+		e.sink(ev)
+		return
+	}
+	// send term.MouseEvent it to receivers, the ones registered by now
+	receivers := make(channels, len(e.receivers))
+	copy(receivers, e.receivers)
+	for _, cons := range receivers {
+		cons.ch <- ev
 	}
 }
 
@@ -244,25 +291,41 @@ func (e *eventDispatcher) scanInput(buf *bytes.Buffer) error {
 	defer e.Unlock()
 
 	for {
-		if len(buf.Bytes()) == 0 {
+		b := buf.Bytes()
+		if len(b) == 0 {
 			buf.Reset()
 			break
 		}
 
 		// mouse support already checked in the parent (... and constructor)
-		if isComplete, err := e.readXTerm(buf); err != nil {
-			if Debug {
-				log.Printf("error reading mouse input xterm : %v", err)
-			}
-		} else if isComplete {
+		partials := 0
+		isPartial, isComplete, err := e.readXTerm(buf)
+		if err != nil {
+			return err
+		}
+		if isComplete {
+			continue
+		} else if isPartial {
+			partials++
+		}
+
+		isPartial, isComplete, err = e.readSGR(buf)
+		if err != nil {
+			return err
+		}
+		if isComplete {
 			continue
+		} else if isPartial {
+			partials++
 		}
 
-		if isComplete, err := e.readSGR(buf); err != nil {
-			if Debug {
-				log.Printf("error reading mouse input xterm : %v", err)
+		if partials == 0 || len(b) > maxSequence {
+			// not a mouse record (the key dispatcher deals with it) : skip to the next escape, which might start one
+			next := 1
+			for next < len(b) && b[next] != '\x1b' && b[next] != '\x9b' {
+				next++
 			}
-		} else if isComplete {
+			buf.Next(next)
 			continue
 		}
 
@@ -273,41 +336,43 @@ func (e *eventDispatcher) scanInput(buf *bytes.Buffer) error {
 }
 
 // readSGR attempts to locate an SGR mouse record at the start of the buffer.
-// It returns true, true if it found one, and the associated bytes be removed from the buffer.
+// It returns false, true if it found one, and the associated bytes are removed from the buffer.
 // It returns true, false if the buffer might contain such an event, but more bytes are necessary (partial match), and false, false if the content is definitely *not* an SGR mouse record.
-func (e *eventDispatcher) readSGR(buf *bytes.Buffer) (bool, error) {
+func (e *eventDispatcher) readSGR(buf *bytes.Buffer) (bool, bool, error) {
 	b := buf.Bytes()
 
 	var x, y, btn, state int
 	dig := false
 	neg := false
 	motion := false
-	i := 0
 	val := 0
 
-	for i = range b {
+	for i := range b {
+		if i >= maxSequence {
+			return false, false, nil
+		}
 		switch b[i] {
 		case '\x1b':
 			if state != 0 {
-				return false, nil
+				return false, false, nil
 			}
 			state = 1
 
 		case '\x9b':
 			if state != 0 {
-				return false, nil
+				return false, false, nil
 			}
 			state = 2
 
 		case '[':
 			if state != 1 {
-				return false, nil
+				return false, false, nil
 			}
 			state = 2
 
 		case '<':
 			if state != 2 {
-				return false, nil
+				return false, false, nil
 			}
 			val = 0
 			dig = false
@@ -316,19 +381,22 @@ func (e *eventDispatcher) readSGR(buf *bytes.Buffer) (bool, error) {
 
 		case '-':
 			if state != 3 && state != 4 && state != 5 {
-				return false, nil
+				return false, false, nil
 			}
 			if dig || neg {
-				return false, nil
+				return false, false, nil
 			}
 			neg = true // stay in state
 
 		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 			if state != 3 && state != 4 && state != 5 {
-				return false, nil
+				return false, false, nil
 			}
 			val *= 10
 			val += int(b[i] - '0')
+			if val > maxParam {
+				return false, false, nil
+			}
 			dig = true // stay in state
 
 		case ';':
@@ -343,12 +411,12 @@ func (e *eventDispatcher) readSGR(buf *bytes.Buffer) (bool, error) {
 				x, val = val-1, 0
 				neg, dig, state = false, false, 5
 			default:
-				return false, nil
+				return false, false, nil
 			}
 
 		case 'm', 'M':
-			if state != 5 {
-				return false, nil
+			if state != 5 || !dig {
+				return false, false, nil
 			}
 			if neg {
 				val = -val
@@ -373,23 +441,21 @@ func (e *eventDispatcher) readSGR(buf *bytes.Buffer) (bool, error) {
 				e.buttonDn = true
 			}
 			// consume the event bytes
-			for i >= 0 {
-				if _, err := buf.ReadByte(); err != nil {
-					return false, err
-				}
-				i--
-			}
+			buf.Next(i + 1)
 			e.buildMouseEvent(x, y, btn)
-			return true, nil
+			return false, true, nil
+
+		default:
+			return false, false, nil
 		}
 	}
 
 	// incomplete & inconclusive at this point
-	return false, nil
+	return true, false, nil
 }
 
 // readXTerm is like readSGR, but it parses a legacy X11 mouse record.
-func (e *eventDispatcher) readXTerm(buf *bytes.Buffer) (bool, error) {
+func (e *eventDispatcher) readXTerm(buf *bytes.Buffer) (bool, bool, error) {
 	b := buf.Bytes()
 
 	state := 0
@@ -405,16 +471,16 @@ func (e *eventDispatcher) readXTerm(buf *bytes.Buffer) (bool, error) {
 			case '\x9b':
 				state = 2
 			default:
-				return false, nil
+				return false, false, nil
 			}
 		case 1:
 			if b[i] != '[' {
-				return false, nil
+				return false, false, nil
 			}
 			state = 2
 		case 2:
 			if b[i] != 'M' {
-				return false, nil
+				return false, false, nil
 			}
 			state++
 		case 3:
@@ -425,17 +491,20 @@ func (e *eventDispatcher) readXTerm(buf *bytes.Buffer) (bool, error) {
 			state++
 		case 5:
 			y = int(b[i]) - 32 - 1
-			for i >= 0 {
-				if _, err := buf.ReadByte(); err != nil {
-					return false, err
-				}
-				i--
-			}
+			buf.Next(i + 1)
 			e.buildMouseEvent(x, y, btn)
-			return true, nil
+			return false, true, nil
 		}
 	}
-	return false, nil
+	return true, false, nil
+}
+
+// resize remembers the size and the margins, for clipping the coordinates
+func (e *eventDispatcher) resize(size *term.Size, margins term.Margins) {
+	e.Lock()
+	defer e.Unlock()
+
+	e.size, e.margins = size, margins
 }
 
 // lifeCycle listens for context done or incoming input from *os.File
@@ -454,15 +523,19 @@ func (e *eventDispatcher) lifeCycle() {
 						close(e.died) // notifying our death to a dispatcher (which listens in register)
 						return
 					case ev := <-e.resizeCh:
-						e.size = ev.Size()
-						if Debug {
-							log.Printf("resized : cols : %d lines : %d", e.size.Columns, e.size.Rows)
+						margins := term.Margins{}
+						if withMargins, ok := ev.(term.MarginsEvent); ok {
+							margins = withMargins.Margins()
+						}
+						e.resize(ev.Size(), margins)
+						if Debug.Enabled() {
+							Debug.Printf("resized : cols : %d lines : %d", e.size.Columns, e.size.Rows)
 						}
 					case chunk := <-e.inputCh:
 						buf.Write(chunk)
 						if err := e.scanInput(buf); err != nil {
-							if Debug {
-								log.Printf("error scanning input : %v", err)
+							if Debug.Enabled() {
+								Debug.Printf("error scanning input : %v", err)
 							}
 						}
 					}
@@ -0,0 +1,224 @@
+package mouse
+
+import (
+	"context"
+	"time"
+
+	"github.com/badu/term"
+)
+
+const (
+	defaultDragThreshold = 1  // cells
+	dragQueueSize        = 16 // drag events which are queued while the application is busy
+)
+
+// buttonsMask keeps the buttons, without the wheel motions
+const buttonsMask = Button1 | Button2 | Button3 | Button4 | Button5 | Button6 | Button7 | Button8
+
+// DragPhase tells which part of the press-drag-release gesture a DragEvent is
+type DragPhase int
+
+const (
+	DragStart DragPhase = iota // the pointer has moved the threshold distance, having a button pressed
+	DragMove                   // the pointer has moved, while dragging
+	DragEnd                    // the button was released, ending the drag
+)
+
+// String implements fmt.Stringer interface
+func (p DragPhase) String() string {
+	switch p {
+	case DragStart:
+		return "Start"
+	case DragMove:
+		return "Move"
+	default:
+		return "End"
+	}
+}
+
+// DragEvent is delivered by the DragRecognizer, for each phase of a press-drag-release gesture
+type DragEvent struct {
+	phase  DragPhase       //
+	button term.ButtonMask // the button held while dragging
+	mod    term.ModMask    // the keyboard modifiers, at the moment of the event
+	startX int             // where the button was pressed
+	startY int             //
+	x      int             // where the pointer is
+	y      int             //
+	dx     int             // movement since the previous drag event of the gesture
+	dy     int             //
+	when   time.Time       //
+}
+
+// Phase returns DragStart, DragMove or DragEnd
+func (e *DragEvent) Phase() DragPhase {
+	return e.phase
+}
+
+// Button returns the button held while dragging
+func (e *DragEvent) Button() term.ButtonMask {
+	return e.button
+}
+
+// Modifiers returns the keyboard modifiers which were pressed
+func (e *DragEvent) Modifiers() term.ModMask {
+	return e.mod
+}
+
+// Start returns where the button was pressed
+func (e *DragEvent) Start() (int, int) {
+	return e.startX, e.startY
+}
+
+// Position returns where the pointer is
+func (e *DragEvent) Position() (int, int) {
+	return e.x, e.y
+}
+
+// Delta returns the movement since the previous drag event of the same gesture (for DragStart, since the button was pressed)
+func (e *DragEvent) Delta() (int, int) {
+	return e.dx, e.dy
+}
+
+// When implements term.Event interface
+func (e *DragEvent) When() time.Time {
+	return e.when
+}
+
+// DragOption configures the DragRecognizer
+type DragOption func(r *DragRecognizer)
+
+// WithDragThreshold sets the distance, in cells, which the pointer has to move having a button pressed, before the drag starts.
+// Shorter movements are considered clicks. Default is one cell.
+func WithDragThreshold(cells int) DragOption {
+	return func(r *DragRecognizer) {
+		if cells > 0 {
+			r.threshold = cells
+		}
+	}
+}
+
+// DragRecognizer is a mouse listener, which recognizes the press-drag-release gestures and delivers them as DragEvent streams,
+// so moving windows or splitters and selecting text don't need to be derived from the raw button and motion events.
+// It needs the terminal to report the motion events having a button pressed.
+type DragRecognizer struct {
+	threshold int                  // set by WithDragThreshold
+	mouseCh   chan term.MouseEvent // registered with the mouse dispatcher
+	dragCh    chan *DragEvent      // read by the application
+	died      chan struct{}        // closed when the context is done, so the mouse dispatcher forgets us
+	pressed   *DragEvent           // the gesture in progress, nil if no button is pressed
+	dragging  bool                 // the gesture has passed the threshold
+}
+
+// NewDragRecognizer returns a recognizer which listens the mouse events until the context is done. It has to be registered with the mouse dispatcher.
+func NewDragRecognizer(ctx context.Context, opts ...DragOption) *DragRecognizer {
+	res := &DragRecognizer{
+		threshold: defaultDragThreshold,
+		mouseCh:   make(chan term.MouseEvent),
+		dragCh:    make(chan *DragEvent, dragQueueSize),
+		died:      make(chan struct{}),
+	}
+	for _, o := range opts {
+		o(res)
+	}
+
+	go func() {
+		defer close(res.died)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev := <-res.mouseCh:
+				drag := res.recognize(ev)
+				if drag == nil {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case res.dragCh <- drag:
+				}
+			}
+		}
+	}()
+	return res
+}
+
+// MouseListen implements term.MouseListener interface
+func (r *DragRecognizer) MouseListen() chan term.MouseEvent {
+	return r.mouseCh
+}
+
+// DyingChan implements term.Death interface
+func (r *DragRecognizer) DyingChan() chan struct{} {
+	return r.died
+}
+
+// DragListen returns the channel delivering the drag events
+func (r *DragRecognizer) DragListen() chan *DragEvent {
+	return r.dragCh
+}
+
+// recognize advances the gesture with the mouse event, returning the drag event it produces, if any
+func (r *DragRecognizer) recognize(ev term.MouseEvent) *DragEvent {
+	buttons := ev.Buttons()
+	if buttons != ButtonNone && buttons&buttonsMask == 0 {
+		return nil // wheel motions don't affect the gesture
+	}
+	x, y := ev.Position()
+
+	if r.pressed == nil {
+		if buttons != ButtonNone {
+			r.pressed = &DragEvent{button: buttons & buttonsMask, startX: x, startY: y, x: x, y: y}
+		}
+		return nil
+	}
+
+	last := r.pressed
+	switch {
+	case buttons == ButtonNone: // released
+		r.pressed = nil
+		if !r.dragging {
+			return nil // it was a click
+		}
+		r.dragging = false
+		return last.next(DragEnd, x, y, ev)
+	case buttons&last.button == 0: // another button, while the first one is held
+		return nil
+	case !r.dragging:
+		if abs(x-last.startX) < r.threshold && abs(y-last.startY) < r.threshold {
+			return nil
+		}
+		r.dragging = true
+		r.pressed = last.next(DragStart, x, y, ev)
+		return r.pressed
+	case x == last.x && y == last.y:
+		return nil
+	default:
+		r.pressed = last.next(DragMove, x, y, ev)
+		return r.pressed
+	}
+}
+
+// next returns the following event of the gesture, having the pointer at x, y
+func (e *DragEvent) next(phase DragPhase, x, y int, ev term.MouseEvent) *DragEvent {
+	return &DragEvent{
+		phase:  phase,
+		button: e.button,
+		mod:    ev.Modifiers(),
+		startX: e.startX,
+		startY: e.startY,
+		x:      x,
+		y:      y,
+		dx:     x - e.x,
+		dy:     y - e.y,
+		when:   ev.When(),
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
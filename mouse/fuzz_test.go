@@ -0,0 +1,27 @@
+//go:build go1.18
+// +build go1.18
+
+package mouse
+
+import (
+	"bytes"
+	"testing"
+)
+
+func FuzzScanInput(f *testing.F) {
+	for _, seed := range []string{
+		"\x1b[<0;10;5M", "\x1b[<35;1;1m", "\x1b[<-1;-1;-1M", "\x1b[M !!", "\x9bM !!", "\x9b<64;2;2M", "a\x1b[<0;1", "\x1b[A",
+	} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		d, _ := newTestDispatcher(t, len(data)+1) // at most one event per byte
+		buf := bytes.NewBuffer(data)
+		if err := d.scanInput(buf); err != nil {
+			t.Fatalf("error scanning : %v", err)
+		}
+		if buf.Len() > maxSequence {
+			t.Fatalf("error : %d bytes kept waiting for the rest of a record", buf.Len())
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package mouse
 
 import (
+	"time"
+
 	"github.com/badu/term"
 	"github.com/badu/term/key"
 )
@@ -41,10 +43,11 @@ const (
 //
 // Applications can inspect the time between events to resolve double or triple clicks.
 type event struct {
-	btn term.ButtonMask
-	mod term.ModMask
-	x   int
-	y   int
+	btn  term.ButtonMask
+	mod  term.ModMask
+	x    int
+	y    int
+	when time.Time
 }
 
 // Buttons returns the list of buttons that were pressed or wheel motions.
@@ -63,35 +66,16 @@ func (ev *event) Position() (int, int) {
 	return ev.x, ev.y
 }
 
+// When returns the moment the mouse sequence was parsed.
+// Compare it with the one of the previous event, for resolving double clicks.
+func (ev *event) When() time.Time {
+	return ev.when
+}
+
 // ButtonNames returns buttons as string
 func (ev *event) ButtonNames() string {
-	switch ev.btn {
-	case Button1:
-		return "PRIMARY"
-	case Button2:
-		return "SECONDARY"
-	case Button3:
-		return "MIDDLE"
-	case Button4:
-		return "THUMB_NEXT"
-	case Button5:
-		return "THUMB_PREV"
-	case Button6:
-		return "SIXTH"
-	case Button7:
-		return "SEVENTH"
-	case Button8:
-		return "EIGHT"
-	case WheelUp:
-		return "WHEEL_UP"
-	case WheelDown:
-		return "WHEEL_DOWN"
-	case WheelLeft:
-		return "WHEEL_LEFT"
-	case WheelRight:
-		return "WHEEL_RIGHT"
-	case ButtonNone:
-		return "NONE"
+	if name, ok := names[ev.btn]; ok {
+		return name
 	}
 	return "NONE"
 }
@@ -116,7 +100,7 @@ func (ev *event) ModName() string {
 // NewEvent is used to create a new mouse event.
 // Applications shouldn't need to use this; its mostly for screen implementors.
 func NewEvent(x, y int, btn term.ButtonMask, mod term.ModMask) term.MouseEvent {
-	return &event{x: x, y: y, btn: btn, mod: mod}
+	return &event{x: x, y: y, btn: btn, mod: mod, when: time.Now()}
 }
 
 // TODO : implement me for below functionality
@@ -0,0 +1,218 @@
+package mouse
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/badu/term"
+	"github.com/badu/term/debug"
+	"github.com/badu/term/info"
+	"github.com/badu/term/key"
+)
+
+// newTestDispatcher returns a dispatcher on a 80x25 screen, having a receiver buffered for size events
+func newTestDispatcher(t testing.TB, size int) (*eventDispatcher, chan term.MouseEvent) {
+	t.Helper()
+	d, err := NewEventDispatcher(WithTerminalInfo(&info.Term{Name: "test", Mouse: "\x1b[M"}), WithSwitchChannel(make(chan bool, 1)))
+	if err != nil {
+		t.Fatalf("error creating dispatcher : %v", err)
+	}
+	res := d.(*eventDispatcher)
+	res.size = &term.Size{Columns: 80, Rows: 25}
+	ch := make(chan term.MouseEvent, size)
+	res.receivers.insert(ch, 0)
+	return res, ch
+}
+
+func TestScanInput(t *testing.T) {
+	for _, tc := range []struct {
+		input  string
+		events int
+		left   int
+	}{
+		{"\x1b[<0;10;5M", 1, 0},
+		{"\x1b[<0;10;5m\x1b[M !!", 2, 0},
+		{"abc\x1b[<0;10;5M", 1, 0},                // typed text is skipped
+		{"\x1b[A\x1b[<0;10;5M", 1, 0},             // as key sequences are
+		{"\x1b[<0;1", 0, 6},                       // partial record waits for the rest
+		{"\x1b[<0;1;1xM", 0, 0},                   // garbage isn't accepted as a record
+		{"\x1b[<0;1;M", 0, 0},                     // neither missing parameters
+		{"\x1b[<0;1;99999999999999999999M", 0, 0}, // nor overflowing ones
+		{"\x1b[<" + strings.Repeat("1", 100), 0, 0},
+	} {
+		d, ch := newTestDispatcher(t, 4)
+		buf := bytes.NewBufferString(tc.input)
+		if err := d.scanInput(buf); err != nil {
+			t.Fatalf("error scanning %q : %v", tc.input, err)
+		}
+		if len(ch) != tc.events || buf.Len() != tc.left {
+			t.Errorf("error : %q should produce %d events leaving %d bytes, got %d events leaving %d bytes", tc.input, tc.events, tc.left, len(ch), buf.Len())
+		}
+	}
+
+	d, ch := newTestDispatcher(t, 1)
+	if err := d.scanInput(bytes.NewBufferString("\x1b[<0;500;500M")); err != nil {
+		t.Fatalf("error scanning : %v", err)
+	}
+	if x, y := (<-ch).Position(); x != 79 || y != 24 {
+		t.Errorf("error : position should be clipped to the screen, got %d,%d", x, y)
+	}
+
+	// reserved edges : the positions are relative to the rest of the screen, the chrome being outside it
+	d.size = &term.Size{Columns: 76, Rows: 23}
+	d.margins = term.Margins{Top: 1, Bottom: 1, Left: 2, Right: 2}
+	for _, tc := range []struct {
+		input string
+		x, y  int
+	}{
+		{"\x1b[<0;3;2M", 0, 0},
+		{"\x1b[<0;1;1M", -2, -1},
+		{"\x1b[<0;500;500M", 77, 23},
+	} {
+		if err := d.scanInput(bytes.NewBufferString(tc.input)); err != nil {
+			t.Fatalf("error scanning : %v", err)
+		}
+		if x, y := (<-ch).Position(); x != tc.x || y != tc.y {
+			t.Errorf("error : %q should be delivered at %d,%d, got %d,%d", tc.input, tc.x, tc.y, x, y)
+		}
+	}
+}
+
+func TestDragRecognizer(t *testing.T) {
+	r := &DragRecognizer{threshold: 2}
+	type step struct {
+		x, y  int
+		btn   term.ButtonMask
+		phase DragPhase
+		dx    int
+		none  bool // no drag event expected
+	}
+	for _, s := range []step{
+		{x: 5, y: 5, btn: Button1, none: true},    // press
+		{x: 6, y: 5, btn: Button1, none: true},    // below threshold
+		{x: 5, y: 5, btn: ButtonNone, none: true}, // release : a click
+		{x: 5, y: 5, btn: Button1, none: true},
+		{x: 7, y: 5, btn: Button1, phase: DragStart, dx: 2},
+		{x: 7, y: 5, btn: WheelUp, none: true}, // wheel is ignored
+		{x: 7, y: 5, btn: Button1, none: true}, // didn't move
+		{x: 10, y: 6, btn: Button1, phase: DragMove, dx: 3},
+		{x: 9, y: 6, btn: ButtonNone, phase: DragEnd, dx: -1},
+		{x: 20, y: 6, btn: ButtonNone, none: true}, // motion without buttons
+	} {
+		ev := r.recognize(NewEvent(s.x, s.y, s.btn, key.ModNone))
+		if s.none {
+			if ev != nil {
+				t.Errorf("error : %d,%d %s should not produce a drag event, got %s", s.x, s.y, names[s.btn], ev.Phase())
+			}
+			continue
+		}
+		if ev == nil {
+			t.Fatalf("error : %d,%d %s should produce %s", s.x, s.y, names[s.btn], s.phase)
+		}
+		startX, startY := ev.Start()
+		if dx, _ := ev.Delta(); ev.Phase() != s.phase || dx != s.dx || startX != 5 || startY != 5 || ev.Button() != Button1 {
+			t.Errorf("error : %d,%d should produce %s with delta %d from 5,5, got %s with delta %d from %d,%d", s.x, s.y, s.phase, s.dx, ev.Phase(), dx, startX, startY)
+		}
+	}
+}
+
+func TestParser(t *testing.T) {
+	p := NewParser()
+	events, consumed := p.Parse([]byte("x\x1b[A\x1b[<0;10;5M\x1b[<0;10"))
+	if len(events) != 1 || consumed != 14 {
+		t.Fatalf("error : expecting the record, the partial one left, got %d events and %d bytes consumed", len(events), consumed)
+	}
+	if x, y := events[0].(term.MouseEvent).Position(); x != 9 || y != 4 {
+		t.Errorf("error : unexpected position %d,%d", x, y)
+	}
+}
+
+// testListener is a MouseListener draining its channel until it dies
+type testListener struct {
+	ch   chan term.MouseEvent
+	died chan struct{}
+}
+
+func (l *testListener) MouseListen() chan term.MouseEvent { return l.ch }
+func (l *testListener) DyingChan() chan struct{}          { return l.died }
+
+func TestRegisterDebug(t *testing.T) {
+	logged := &bytes.Buffer{}
+	debug.SetOutput(logged)
+	defer debug.SetOutput(nil)
+	debug.Enable("mouse")
+	defer debug.Disable("mouse")
+
+	d, err := NewEventDispatcher(WithTerminalInfo(&info.Term{Name: "test", Mouse: "\x1b[M"}), WithSwitchChannel(make(chan bool, 1)))
+	if err != nil {
+		t.Fatalf("error creating dispatcher : %v", err)
+	}
+	// registering before the life cycle is logged, without stopping the program
+	d.Register(&testListener{ch: make(chan term.MouseEvent), died: make(chan struct{})})
+	if !strings.Contains(logged.String(), "context not set") {
+		t.Errorf("error : the registration should be logged, got %q", logged.String())
+	}
+	if res := d.(*eventDispatcher); len(res.receivers) != 0 {
+		t.Errorf("error : the listener should not be registered, got %d receivers", len(res.receivers))
+	}
+}
+
+func TestRegisterWhileDispatching(t *testing.T) {
+	d, err := NewEventDispatcher(WithTerminalInfo(&info.Term{Name: "test", Mouse: "\x1b[M"}), WithSwitchChannel(make(chan bool, 1)))
+	if err != nil {
+		t.Fatalf("error creating dispatcher : %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d.LifeCycle(ctx)
+
+	const listeners = 20
+	clicking := make(chan struct{})
+	go func() { // clicking while the listeners come and go
+		for {
+			select {
+			case d.InChan() <- []byte("\x1b[M !!"):
+			case <-clicking:
+				return
+			}
+		}
+	}()
+	var wg sync.WaitGroup
+	for i := 0; i < listeners; i++ {
+		l := &testListener{ch: make(chan term.MouseEvent), died: make(chan struct{})}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				select {
+				case <-l.ch:
+				case <-time.After(2 * time.Second):
+					t.Errorf("error : the listener stopped receiving the events")
+					j = 5
+				}
+			}
+			close(l.died)
+			for { // drains the events sent before forgetting the listener
+				select {
+				case <-l.ch:
+				case <-time.After(100 * time.Millisecond):
+					return
+				}
+			}
+		}()
+		d.Register(l)
+	}
+	wg.Wait()
+	close(clicking)
+
+	res := d.(*eventDispatcher)
+	res.Lock()
+	defer res.Unlock()
+	if len(res.receivers) != 0 {
+		t.Errorf("error : the dead listeners should be forgotten, got %d receivers", len(res.receivers))
+	}
+}
@@ -0,0 +1,26 @@
+package mouse
+
+import (
+	"github.com/badu/term"
+)
+
+// names of the buttons, used by event.ButtonNames() and term.ButtonMask.String()
+var names = map[term.ButtonMask]string{
+	Button1:    "PRIMARY",
+	Button2:    "SECONDARY",
+	Button3:    "MIDDLE",
+	Button4:    "THUMB_NEXT",
+	Button5:    "THUMB_PREV",
+	Button6:    "SIXTH",
+	Button7:    "SEVENTH",
+	Button8:    "EIGHT",
+	WheelUp:    "WHEEL_UP",
+	WheelDown:  "WHEEL_DOWN",
+	WheelLeft:  "WHEEL_LEFT",
+	WheelRight: "WHEEL_RIGHT",
+	ButtonNone: "NONE",
+}
+
+func init() {
+	term.RegisterButtonNames(names)
+}
@@ -0,0 +1,44 @@
+package mouse
+
+import (
+	"bytes"
+
+	"github.com/badu/term"
+)
+
+// Parser recognizes the mouse records (X10 and SGR encodings) in the terminal input, without the dispatcher goroutines and channels. The rest of the input is skipped.
+// It keeps the state between calls (the pressed buttons), so the input can be fed as it arrives. It's not safe for concurrent use.
+type Parser struct {
+	d      *eventDispatcher // does the recognition
+	events []term.Event     // collected while parsing
+}
+
+// NewParser creates a parser. The coordinates are not clipped until the size is known (see Resize).
+func NewParser() *Parser {
+	res := &Parser{d: &eventDispatcher{size: &term.Size{}}}
+	res.d.sink = res.collect
+	return res
+}
+
+// collect is the sink of the dispatcher
+func (p *Parser) collect(ev term.Event) {
+	p.events = append(p.events, ev)
+}
+
+// Resize sets the size left to the pages and the cells reserved at the screen edges, like the dispatcher receives them : the coordinates are clipped to the screen, then made relative to the size
+func (p *Parser) Resize(size *term.Size, margins term.Margins) {
+	if size == nil {
+		size = &term.Size{}
+	}
+	p.d.resize(size, margins)
+}
+
+// Parse returns the mouse events found in the input and the number of bytes consumed. The bytes left (a record which isn't complete yet) should be passed again, followed by the next input.
+func (p *Parser) Parse(b []byte) ([]term.Event, int) {
+	p.events = nil
+	buf := bytes.NewBuffer(b)
+	_ = p.d.scanInput(buf) // reading from a bytes.Buffer, errors can't happen
+	events := p.events
+	p.events = nil
+	return events, len(b) - buf.Len()
+}
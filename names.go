@@ -0,0 +1,73 @@
+package term
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// the keys and mouse buttons are declared by the key and mouse packages, which import this one, so they register their names
+var (
+	namesMu     sync.RWMutex
+	keyNames    = make(map[Key]string)
+	buttonNames = make(map[ButtonMask]string)
+)
+
+// RegisterKeyNames adds (or replaces) the names used by Key.String(). It's called by the key package.
+func RegisterKeyNames(names map[Key]string) {
+	namesMu.Lock()
+	defer namesMu.Unlock()
+
+	for k, name := range names {
+		keyNames[k] = name
+	}
+}
+
+// RegisterButtonNames adds (or replaces) the names used by ButtonMask.String(). It's called by the mouse package.
+func RegisterButtonNames(names map[ButtonMask]string) {
+	namesMu.Lock()
+	defer namesMu.Unlock()
+
+	for b, name := range names {
+		buttonNames[b] = name
+	}
+}
+
+// String implements fmt.Stringer
+func (k Key) String() string {
+	namesMu.RLock()
+	defer namesMu.RUnlock()
+
+	if name, ok := keyNames[k]; ok {
+		return name
+	}
+	return "Key(" + strconv.Itoa(int(k)) + ")"
+}
+
+// String implements fmt.Stringer : the names of the buttons in the mask, separated by "|"
+func (b ButtonMask) String() string {
+	namesMu.RLock()
+	defer namesMu.RUnlock()
+
+	if name, ok := buttonNames[b]; ok {
+		return name // including the "no button" mask
+	}
+	var sb strings.Builder
+	for bit := ButtonMask(1); bit != 0 && bit <= b; bit <<= 1 {
+		if b&bit == 0 {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteByte('|')
+		}
+		if name, ok := buttonNames[bit]; ok {
+			sb.WriteString(name)
+		} else {
+			sb.WriteString("Button(" + strconv.Itoa(int(bit)) + ")")
+		}
+	}
+	if sb.Len() == 0 {
+		return "Button(" + strconv.Itoa(int(b)) + ")"
+	}
+	return sb.String()
+}
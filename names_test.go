@@ -0,0 +1,23 @@
+package term_test
+
+import (
+	"testing"
+
+	"github.com/badu/term/key"
+	"github.com/badu/term/mouse"
+)
+
+func TestNames(t *testing.T) {
+	for got, want := range map[string]string{
+		key.F1.String():                          "F1",
+		key.CtrlA.String():                       "Ctrl-A",
+		key.Rune.String():                        "Rune",
+		(key.F12 + 100).String():                 "Key(390)",
+		(mouse.Button1 | mouse.WheelUp).String(): "PRIMARY|WHEEL_UP",
+		mouse.ButtonNone.String():                "NONE",
+	} {
+		if got != want {
+			t.Fatalf("error : expecting %q, got %q", want, got)
+		}
+	}
+}
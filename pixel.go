@@ -0,0 +1,60 @@
+package term
+
+// SameContent returns true if both pixels show the same thing : rune, unicode, colors and attributes. Positions are not compared.
+func SameContent(a, b PixelGetter) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Rune() != b.Rune() {
+		return false
+	}
+	aFg, aBg, aAttrs := a.Style()
+	bFg, bBg, bAttrs := b.Style()
+	if aFg != bFg || aBg != bBg || aAttrs != bAttrs {
+		return false
+	}
+	var aUni, bUni Unicode
+	if a.HasUnicode() {
+		aUni = *a.Unicode()
+	}
+	if b.HasUnicode() {
+		bUni = *b.Unicode()
+	}
+	if len(aUni) != len(bUni) {
+		return false
+	}
+	for idx := range aUni {
+		if aUni[idx] != bUni[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+const (
+	fnvOffset = 14695981039346656037
+	fnvPrime  = 1099511628211
+)
+
+// ContentHash returns a FNV-1a hash of what the pixel shows (rune, unicode, colors and attributes), so screen states can be compared cheaply.
+// Pixels having SameContent have the same hash, the reverse is true only with a very high probability.
+func ContentHash(p PixelGetter) uint64 {
+	h := uint64(fnvOffset)
+	mix := func(v uint64) {
+		for shift := 0; shift < 64; shift += 8 {
+			h ^= (v >> shift) & 0xFF
+			h *= fnvPrime
+		}
+	}
+	fg, bg, attrs := p.Style()
+	mix(uint64(p.Rune()))
+	mix(uint64(fg))
+	mix(uint64(bg))
+	mix(uint64(attrs))
+	if p.HasUnicode() {
+		for _, r := range *p.Unicode() {
+			mix(uint64(r))
+		}
+	}
+	return h
+}
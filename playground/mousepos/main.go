@@ -10,6 +10,7 @@ import (
 	"github.com/badu/term"
 	"github.com/badu/term/color"
 	"github.com/badu/term/core"
+	"github.com/badu/term/draw"
 	"github.com/badu/term/encoding"
 	"github.com/badu/term/geom"
 	"github.com/badu/term/key"
@@ -31,37 +32,6 @@ func (r *listener) emitStr(column, row int, style *style.Style, str string) {
 	}
 }
 
-func (r *listener) drawBox(col1, row1, col2, row2 int, style *style.Style, c rune) {
-	if row2 < row1 {
-		row1, row2 = row2, row1
-	}
-	if col2 < col1 {
-		col1, col2 = col2, col1
-	}
-
-	for col := col1; col <= col2; col++ {
-		r.refs[col][row1].Set(encoding.HLine, style.Fg, style.Bg)
-		r.refs[col][row2].Set(encoding.HLine, style.Fg, style.Bg)
-	}
-	for row := row1 + 1; row < row2; row++ {
-		r.refs[col1][row].Set(encoding.VLine, style.Fg, style.Bg)
-		r.refs[col2][row].Set(encoding.VLine, style.Fg, style.Bg)
-	}
-	if row1 != row2 && col1 != col2 {
-		// Only add corners if we need to
-		r.refs[col1][row1].Set(encoding.ULCorner, style.Fg, style.Bg)
-		r.refs[col2][row1].Set(encoding.URCorner, style.Fg, style.Bg)
-		r.refs[col1][row2].Set(encoding.LLCorner, style.Fg, style.Bg)
-		r.refs[col2][row2].Set(encoding.LRCorner, style.Fg, style.Bg)
-	}
-	for row := row1 + 1; row < row2; row++ {
-		for col := col1 + 1; col < col2; col++ {
-			r.refs[col][row].Set(c, style.Fg, style.Bg)
-		}
-	}
-
-}
-
 type listener struct {
 	incomingMouse  chan term.MouseEvent  // We need a channel on which we will listen for incoming events
 	incomingKey    chan term.KeyEvent    // We need a channel on which we will listen for incoming events
@@ -72,21 +42,8 @@ type listener struct {
 }
 
 func (r *listener) init(size *term.Size) {
-	r.refs = make([][]term.Pixel, size.Columns)
-	var getters = make([]term.PixelGetter, 0)
-	for column := 0; column < size.Columns; column++ {
-		r.refs[column] = make([]term.Pixel, size.Rows)
-		for row := 0; row < size.Rows; row++ {
-			var px term.Pixel
-			if column%2 == 0 {
-				px, _ = geom.NewPixel(geom.WithPosition(term.NewPosition(column, row)))
-			} else {
-				px, _ = geom.NewPixel(geom.WithPosition(term.NewPosition(column, row)))
-			}
-			r.refs[column][row] = px
-			getters = append(getters, px)
-		}
-	}
+	var getters []term.PixelGetter
+	r.refs, getters = geom.NewPixelGrid(size)
 	r.engine.ActivePixels(getters)
 }
 
@@ -151,7 +108,9 @@ func (r *listener) lifeCycle(ctx context.Context, cancel func()) {
 
 		r.init(size)
 		r.engine.HideCursor()
-		r.drawBox(1, 1, 42, 7, white, encoding.Space)
+		box := draw.New(r.refs, draw.WithStyle(white))
+		box.Fill(1, 1, 42, 7, encoding.Space)
+		box.Box(1, 1, 42, 7)
 		r.emitStr(2, 2, rgb, "Press ESC twice to exit, C to clear.")
 		r.emitStr(2, 3, white, "Click and drag to draw a rectangle.")
 		const (
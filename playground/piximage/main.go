@@ -18,6 +18,7 @@ import (
 	"github.com/badu/term/core"
 	enc "github.com/badu/term/encoding"
 	"github.com/badu/term/geom"
+	"github.com/badu/term/img"
 	"github.com/badu/term/key"
 	initLog "github.com/badu/term/log"
 )
@@ -177,36 +178,16 @@ func NewPage(ctx context.Context, engine term.Engine, cancel func(), image [][][
 
 // gather each pixel color but each image row is condensed into one, which holds the two colors
 func makeColors(r io.Reader) ([][][2]color.Color, int, int, error) {
-	img, format, err := image.Decode(r)
+	src, format, err := image.Decode(r)
 	if err != nil {
 		log.Printf("decode error : %v %s\n", err, format)
 		return nil, 0, 0, err
 	}
-	bounds := img.Bounds()
-	width := bounds.Max.X
-	height := bounds.Max.Y
-	result := make([][][2]color.Color, width)
-	for col := 0; col < width; col++ {
-		result[col] = make([][2]color.Color, height/2+1)
-		rrow := 0
-		for row := 0; row < height; row += 2 {
-			result[col][rrow] = [2]color.Color{}
-			c1 := color.NewRGBAColor(img.At(col, row).RGBA())
-			result[col][rrow][0] = c1
-			if !color.IsRGB(c1) {
-				return nil, 0, 0, fmt.Errorf("error : color 0x%06X is not RGB", color.Hex(c1))
-			}
-			if row+1 < height {
-				c2 := color.NewRGBAColor(img.At(col, row+1).RGBA())
-				result[col][rrow][1] = c2
-				if !color.IsRGB(c2) {
-					return nil, 0, 0, fmt.Errorf("error : color 0x%06X is not RGB", color.Hex(c2))
-				}
-			}
-			rrow++
-		}
+	result := img.Cells(src, term.Size{}, img.WithOriginalSize())
+	if len(result) == 0 {
+		return nil, 0, 0, fmt.Errorf("error : image is empty")
 	}
-	return result, width, height/2 + 1, nil
+	return result, len(result), len(result[0]), nil
 }
 
 const usage = `piximage [pattern|url]
@@ -5,7 +5,7 @@ import (
 )
 
 const (
-	MinusOneMinusOne = 4294967295
+	MinusOneMinusOne = 4294967295 // the hash of the -1,-1 position, which is outside the screen
 )
 
 type Position struct {
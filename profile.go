@@ -0,0 +1,39 @@
+package term
+
+// ColorProfile describes how many colors the engine will actually emit, after the terminal capabilities and the environment conventions (NO_COLOR, CLICOLOR, CLICOLOR_FORCE, FORCE_COLOR) were considered.
+type ColorProfile int
+
+const (
+	ProfileNone      ColorProfile = iota // no SGR colors are written, attributes (bold, underline, etc.) are still used
+	ProfileANSI                          // the basic 16 colors palette
+	ProfileANSI256                       // the 256 colors palette
+	ProfileTrueColor                     // 24 bit colors
+)
+
+// Colors returns the maximum number of colors the profile allows
+func (p ColorProfile) Colors() int {
+	switch p {
+	case ProfileANSI:
+		return 16
+	case ProfileANSI256:
+		return 256
+	case ProfileTrueColor:
+		return 1 << 24
+	default:
+		return 0
+	}
+}
+
+// String implements fmt.Stringer
+func (p ColorProfile) String() string {
+	switch p {
+	case ProfileANSI:
+		return "ansi"
+	case ProfileANSI256:
+		return "ansi256"
+	case ProfileTrueColor:
+		return "truecolor"
+	default:
+		return "none"
+	}
+}
@@ -1,5 +1,9 @@
 package term
 
+import (
+	"fmt"
+)
+
 // Size describes something with width and height.
 type Size struct {
 	Columns int // The number of units along the horizontal axis.
@@ -65,3 +69,15 @@ func Abs(a int) int {
 	}
 	return a
 }
+
+// OutOfBoundsError describes a pixel whose position is outside the size of the screen, see BoundsChecker
+type OutOfBoundsError struct {
+	Column int  // the column of the pixel, negative columns included
+	Row    int  // the row of the pixel, negative rows included
+	Size   Size // the size of the screen, when the pixel was checked
+}
+
+// Error implements error interface
+func (e *OutOfBoundsError) Error() string {
+	return fmt.Sprintf("pixel %d,%d is outside the screen of %d x %d", e.Column, e.Row, e.Size.Columns, e.Size.Rows)
+}
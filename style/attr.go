@@ -1,5 +1,9 @@
 package style
 
+import (
+	"strings"
+)
+
 // Mask represents a mask of text attributes, apart from color.
 // Note that support for attributes may vary widely across terminals.
 type Mask int
@@ -17,3 +21,29 @@ const (
 	Invalid          // Mark the style or attributes invalid
 	None    Mask = 0 // Just normal text.
 )
+
+// Stringer implementation : the names of the attributes, separated by "|"
+func (m Mask) String() string {
+	if m == None {
+		return "none"
+	}
+	var names []string
+	for _, attr := range []struct {
+		mask Mask
+		name string
+	}{
+		{Bold, "bold"},
+		{Blink, "blink"},
+		{Reverse, "reverse"},
+		{Underline, "underline"},
+		{Dim, "dim"},
+		{Italic, "italic"},
+		{StrikeThrough, "strikethrough"},
+		{Invalid, "invalid"},
+	} {
+		if m&attr.mask != 0 {
+			names = append(names, attr.name)
+		}
+	}
+	return strings.Join(names, "|")
+}
@@ -98,11 +98,16 @@ func WithStrikeThrough(on bool) Option {
 func FromStyle(clone *Style) Option {
 	return func(s *Style) {
 		s.Bg = clone.Bg
-		s.Fg = clone.Bg
+		s.Fg = clone.Fg
 		s.Attrs = clone.Attrs
 	}
 }
 
+// Stringer implementation
+func (s Style) String() string {
+	return "fg:" + s.Fg.String() + " bg:" + s.Bg.String() + " attrs:" + s.Attrs.String()
+}
+
 func (s Style) IsValid() bool {
 	return s.Attrs != Invalid
 }
@@ -125,7 +130,7 @@ func (s *Style) mergeAttrs(attrs Mask, on bool) {
 		s.Attrs = s.Attrs | attrs
 		return
 	}
-	attrs = s.Attrs &^ attrs
+	s.Attrs = s.Attrs &^ attrs
 }
 
 func (s *Style) merge(other *Style, except color.Color) {
@@ -143,6 +148,75 @@ func (s *Style) Merge(other *Style) {
 	s.merge(other, color.Default)
 }
 
+// Foreground returns a copy of the style, having the foreground color set to c.
+// The receiver is never modified, so a Style value can be shared between goroutines and used as a base for others.
+func (s Style) Foreground(c color.Color) Style {
+	s.Fg = c
+	return s
+}
+
+// Background returns a copy of the style, having the background color set to c.
+func (s Style) Background(c color.Color) Style {
+	s.Bg = c
+	return s
+}
+
+// Attributes returns a copy of the style, having the attributes replaced by m.
+func (s Style) Attributes(m Mask) Style {
+	s.Attrs = m
+	return s
+}
+
+// Bold returns a copy of the style, with bold turned on or off.
+func (s Style) Bold(on bool) Style {
+	s.mergeAttrs(Bold, on)
+	return s
+}
+
+// Blink returns a copy of the style, with blink turned on or off.
+func (s Style) Blink(on bool) Style {
+	s.mergeAttrs(Blink, on)
+	return s
+}
+
+// Dim returns a copy of the style, with dim turned on or off.
+func (s Style) Dim(on bool) Style {
+	s.mergeAttrs(Dim, on)
+	return s
+}
+
+// Italic returns a copy of the style, with italic turned on or off.
+func (s Style) Italic(on bool) Style {
+	s.mergeAttrs(Italic, on)
+	return s
+}
+
+// Reverse returns a copy of the style, with reverse turned on or off.
+func (s Style) Reverse(on bool) Style {
+	s.mergeAttrs(Reverse, on)
+	return s
+}
+
+// Underline returns a copy of the style, with underline turned on or off.
+func (s Style) Underline(on bool) Style {
+	s.mergeAttrs(Underline, on)
+	return s
+}
+
+// StrikeThrough returns a copy of the style, with strike through turned on or off.
+func (s Style) StrikeThrough(on bool) Style {
+	s.mergeAttrs(StrikeThrough, on)
+	return s
+}
+
+// With returns a copy of the style, having the functional options applied on it.
+func (s Style) With(opts ...Option) Style {
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
+}
+
 // Normal returns the style with all attributes disabled.
 func (s *Style) Normal() Style {
 	return Style{
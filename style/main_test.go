@@ -0,0 +1,47 @@
+package style_test
+
+import (
+	"testing"
+
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+func TestMergeAttrsOff(t *testing.T) {
+	s := style.NewStyle(style.WithAttrs(style.Bold|style.Underline), style.WithBold(false))
+	if s.Attrs != style.Underline {
+		t.Fatalf("error : expecting only underline attribute, got %d", s.Attrs)
+	}
+	s = style.NewStyle(style.WithItalic(true), style.WithItalic(false))
+	if s.Attrs != style.None {
+		t.Fatalf("error : expecting no attributes, got %d", s.Attrs)
+	}
+}
+
+func TestImmutableBuilder(t *testing.T) {
+	base := style.NewStyle(style.WithFg(color.White))
+	derived := base.Bold(true).Underline(true).Background(color.Navy).Underline(false)
+	if base.Attrs != style.None || base.Bg != color.Default {
+		t.Fatalf("error : base style was modified : %#v", base)
+	}
+	if derived.Attrs != style.Bold {
+		t.Fatalf("error : expecting bold attribute, got %d", derived.Attrs)
+	}
+	if derived.Fg != color.White || derived.Bg != color.Navy {
+		t.Fatalf("error : bad colors on derived style : %#v", derived)
+	}
+	cloned := style.NewStyle(style.FromStyle(&derived))
+	if *cloned != derived {
+		t.Fatalf("error : clone differs from original : %#v != %#v", *cloned, derived)
+	}
+}
+
+func TestStyleString(t *testing.T) {
+	s := style.NewStyle(style.WithFg(color.Red), style.WithBg(color.NewRGBColor(0x12, 0x34, 0x56)), style.WithBold(true), style.WithUnderline(true))
+	if got, want := s.String(), "fg:red bg:#123456 attrs:bold|underline"; got != want {
+		t.Fatalf("error : expecting %q, got %q", want, got)
+	}
+	if got, want := (style.Style{}).String(), "fg:default bg:default attrs:none"; got != want {
+		t.Fatalf("error : expecting %q, got %q", want, got)
+	}
+}
@@ -6,49 +6,103 @@ import (
 	"github.com/badu/term/color"
 )
 
+// FindColorFunc is the strategy used for matching a color against the terminal palette
+type FindColorFunc func(c color.Color, palette []color.Color) color.Color
+
+// TermStyleOption for functional options
+type TermStyleOption func(s *TermStyle)
+
+// WithMatcher sets the strategy used by FindColor. Default is color.FindColor (nearest by Lab distance)
+func WithMatcher(fn FindColorFunc) TermStyleOption {
+	return func(s *TermStyle) {
+		if fn != nil {
+			s.matcher = fn
+		}
+	}
+}
+
 type TermStyle struct {
 	sync.Mutex                             // guards other properties
-	colors     map[color.Color]color.Color //
+	colors     map[color.Color]color.Color // cache of the matched colors
 	palette    []color.Color               //
+	matcher    FindColorFunc               // strategy for matching colors which are not in cache
 }
 
-func NewTermStyle(colors int) *TermStyle {
+func NewTermStyle(colors int, opts ...TermStyleOption) *TermStyle {
 	res := TermStyle{
 		colors:  make(map[color.Color]color.Color),
 		palette: make([]color.Color, colors),
+		matcher: color.FindColor,
 	}
 	for i := 0; i < colors; i++ {
 		res.palette[i] = color.Color(i) | color.ValidConst
 		res.colors[color.Color(i)|color.ValidConst] = color.Color(i) | color.ValidConst // identity map for our builtin colors
 	}
+	for _, opt := range opts {
+		opt(&res)
+	}
 	return &res
 }
 
-// Palette
+// Palette returns a copy of the terminal palette
 func (s *TermStyle) Palette() []color.Color {
 	s.Lock()
 	defer s.Unlock()
 
-	return s.palette
+	result := make([]color.Color, len(s.palette))
+	copy(result, s.palette)
+	return result
 }
 
-// Colors
+// Colors returns a copy of the matched colors cache (requested color as key, palette color as value)
 func (s *TermStyle) Colors() map[color.Color]color.Color {
 	s.Lock()
 	defer s.Unlock()
 
-	return s.colors
+	result := make(map[color.Color]color.Color, len(s.colors))
+	for k, v := range s.colors {
+		result[k] = v
+	}
+	return result
 }
 
-// FindColorFunc
-type FindColorFunc func(c color.Color) color.Color
+// SetMatcher replaces the matching strategy and forgets the previously matched colors
+func (s *TermStyle) SetMatcher(fn FindColorFunc) {
+	if fn == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+
+	s.matcher = fn
+	s.resetCache()
+}
 
-// FindColor
+// ResetCache forgets the matched colors, keeping only the identity map of the palette
+func (s *TermStyle) ResetCache() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.resetCache()
+}
+
+// resetCache - locked inside caller function
+func (s *TermStyle) resetCache() {
+	s.colors = make(map[color.Color]color.Color, len(s.palette))
+	for _, c := range s.palette {
+		s.colors[c] = c
+	}
+}
+
+// FindColor returns the palette color matching c, using the cache or the matching strategy
 func (s *TermStyle) FindColor(c color.Color) color.Color {
+	s.Lock()
+	defer s.Unlock()
+
 	if v, ok := s.colors[c]; ok {
 		return v
 	}
-	v := color.FindColor(c, s.palette)
+	v := s.matcher(c, s.palette)
 	s.colors[c] = v
 	return v
 }
@@ -0,0 +1,70 @@
+package style_test
+
+import (
+	"testing"
+
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+func TestTermStyleMatcher(t *testing.T) {
+	matched := 0
+	counting := func(c color.Color, palette []color.Color) color.Color {
+		matched++
+		return color.FindIndexColor(c, palette)
+	}
+	s := style.NewTermStyle(16, style.WithMatcher(counting))
+	rgb := color.NewRGBColor(0x80, 0, 0)
+	for _, tc := range []struct {
+		name     string
+		c        color.Color
+		expected color.Color
+		matched  int
+	}{
+		{name: "palette", c: color.Maroon, expected: color.Maroon, matched: 0},
+		{name: "outside palette", c: color.PaletteColor(100), expected: color.Default, matched: 1},
+		{name: "rgb", c: rgb, expected: color.Default, matched: 2},
+		{name: "rgb cached", c: rgb, expected: color.Default, matched: 2},
+	} {
+		if got := s.FindColor(tc.c); got != tc.expected {
+			t.Errorf("error : %s : expecting %v, got %v", tc.name, tc.expected, got)
+		}
+		if matched != tc.matched {
+			t.Errorf("error : %s : expecting %d matches, got %d", tc.name, tc.matched, matched)
+		}
+	}
+	if cached := s.Colors(); len(cached) != 18 || cached[rgb] != color.Default {
+		t.Errorf("error : expecting the palette and the two matched colors in cache, got %d", len(cached))
+	}
+
+	// the cache is forgotten when the strategy changes
+	s.SetMatcher(color.FindColor)
+	if got := s.FindColor(rgb); got != color.Maroon {
+		t.Errorf("error : expecting the nearest color, got %v", got)
+	}
+	s.SetMatcher(nil) // ignored
+	if got := s.FindColor(rgb); got != color.Maroon {
+		t.Errorf("error : a nil strategy should be ignored, got %v", got)
+	}
+	s.ResetCache()
+	if cached := s.Colors(); len(cached) != 16 {
+		t.Errorf("error : expecting only the palette after reset, got %d", len(cached))
+	}
+}
+
+func TestTermStyleCopies(t *testing.T) {
+	s := style.NewTermStyle(8)
+	palette := s.Palette()
+	if len(palette) != 8 || palette[1] != color.Maroon {
+		t.Fatalf("error : unexpected palette %v", palette)
+	}
+	palette[1] = color.Blue
+	if s.Palette()[1] != color.Maroon {
+		t.Errorf("error : the palette should be returned as a copy")
+	}
+	cached := s.Colors()
+	cached[color.Blue] = color.Black
+	if _, ok := s.Colors()[color.Blue]; ok {
+		t.Errorf("error : the cache should be returned as a copy")
+	}
+}
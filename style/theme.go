@@ -15,4 +15,12 @@ type Theme struct {
 	HoverTextColor          color.Color // Hovered text color.
 	SelectedTextColor       color.Color // Selected text color.
 	InverseTextColor        color.Color //
+	KeywordColor            color.Color // Highlighted source : keywords (see syntax.ThemeStyles). When not set, the text color is used.
+	TypeColor               color.Color // Highlighted source : predeclared types.
+	LiteralColor            color.Color // Highlighted source : predeclared constants (true, false, nil).
+	StringColor             color.Color // Highlighted source : strings and characters.
+	NumberColor             color.Color // Highlighted source : numbers.
+	CommentColor            color.Color // Highlighted source : comments.
+	OperatorColor           color.Color // Highlighted source : operators.
+	InvalidColor            color.Color // Highlighted source : what can't be scanned.
 }
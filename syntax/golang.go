@@ -0,0 +1,83 @@
+package syntax
+
+import (
+	"go/scanner"
+	"go/token"
+)
+
+// goHighlighter splits Go source using the scanner of the standard library
+type goHighlighter struct{}
+
+// Go returns the highlighter of the Go source. The source doesn't have to compile : what can't be scanned is Invalid.
+func Go() Highlighter {
+	return goHighlighter{}
+}
+
+// goPredeclared are the identifiers of the universe block which are highlighted
+var goPredeclared = map[string]Class{
+	"bool": Type, "byte": Type, "complex64": Type, "complex128": Type, "error": Type, "float32": Type, "float64": Type,
+	"int": Type, "int8": Type, "int16": Type, "int32": Type, "int64": Type, "rune": Type, "string": Type,
+	"uint": Type, "uint8": Type, "uint16": Type, "uint32": Type, "uint64": Type, "uintptr": Type, "any": Type,
+	"true": Literal, "false": Literal, "nil": Literal, "iota": Literal,
+}
+
+// Highlight implements Highlighter interface
+func (goHighlighter) Highlight(source []byte) []Token {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(source))
+	var s scanner.Scanner
+	s.Init(file, source, nil, scanner.ScanComments) // the errors are reported as ILLEGAL tokens
+
+	var result []Token
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			return result
+		}
+		start := file.Offset(pos)
+		end := start + len(lit)
+		if lit == "" {
+			end = start + len(tok.String())
+		}
+		class := Plain
+		switch {
+		case tok.IsKeyword():
+			class = Keyword
+		case tok == token.IDENT:
+			class = goPredeclared[lit]
+		case tok == token.INT || tok == token.FLOAT || tok == token.IMAG:
+			class = Number
+		case tok == token.STRING || tok == token.CHAR:
+			class = String
+			if lit != "" && lit[0] == '`' {
+				end = rawEnd(source, start) // the carriage returns were removed from the literal
+			}
+		case tok == token.COMMENT:
+			class = Comment
+		case tok == token.ILLEGAL:
+			class = Invalid
+		case tok.IsOperator() && !goPunctuation[tok]:
+			class = Operator
+		}
+		if class == Plain || end > len(source) {
+			continue // automatic semicolons (the literal is "\n"), identifiers and punctuation
+		}
+		result = append(result, Token{Start: start, End: end, Class: class})
+	}
+}
+
+// goPunctuation are the operators of the scanner which are drawn as plain text
+var goPunctuation = map[token.Token]bool{
+	token.LPAREN: true, token.RPAREN: true, token.LBRACK: true, token.RBRACK: true, token.LBRACE: true, token.RBRACE: true,
+	token.COMMA: true, token.PERIOD: true, token.SEMICOLON: true, token.COLON: true,
+}
+
+// rawEnd returns the offset after the raw string starting at the offset, or the end of the source if it isn't terminated
+func rawEnd(source []byte, start int) int {
+	for idx := start + 1; idx < len(source); idx++ {
+		if source[idx] == '`' {
+			return idx + 1
+		}
+	}
+	return len(source)
+}
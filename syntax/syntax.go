@@ -0,0 +1,200 @@
+package syntax
+
+import (
+	"sort"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/style"
+)
+
+const (
+	defaultTabWidth = 4 // columns of a tab
+)
+
+// Class is the kind of a token, which tells how it's drawn (see Styles)
+type Class int
+
+const (
+	Plain    Class = iota // identifiers, punctuation and spaces : everything which isn't part of a token
+	Keyword               //
+	Type                  // predeclared types
+	Literal               // predeclared constants, e.g. true, false, nil
+	String                // strings and characters
+	Number                //
+	Comment               //
+	Operator              //
+	Invalid               // what the highlighter can't scan
+)
+
+// Token is a span of the source, in bytes
+type Token struct {
+	Start int   // the offset of the first byte
+	End   int   // the offset after the last byte
+	Class Class //
+}
+
+// Highlighter is implemented by the highlighters of each language : it splits the source into tokens, ordered by their offsets and not overlapping. The bytes between the tokens are Plain.
+type Highlighter interface {
+	Highlight(source []byte) []Token
+}
+
+// Styles maps the classes to the styles used for drawing them. The classes which aren't mapped use the Plain style.
+type Styles map[Class]style.Style
+
+// ThemeStyles maps the classes to the colors of the theme, on its main background color : the colors which aren't set are replaced by the text color.
+// The keywords are bold and the comments are italic, so the source is highlighted even with the default colors.
+func ThemeStyles(theme style.Theme) Styles {
+	text := func(c color.Color, attrs style.Mask) style.Style {
+		if c == color.Default {
+			c = theme.TextColor
+		}
+		return style.Style{Fg: c, Bg: theme.MainBackgroundColor, Attrs: attrs}
+	}
+	return Styles{
+		Plain:    text(theme.TextColor, style.None),
+		Keyword:  text(theme.KeywordColor, style.Bold),
+		Type:     text(theme.TypeColor, style.None),
+		Literal:  text(theme.LiteralColor, style.None),
+		String:   text(theme.StringColor, style.None),
+		Number:   text(theme.NumberColor, style.None),
+		Comment:  text(theme.CommentColor, style.Italic),
+		Operator: text(theme.OperatorColor, style.None),
+		Invalid:  text(theme.InvalidColor, style.Underline),
+	}
+}
+
+// Option for functional options
+type Option func(c *Code)
+
+// WithTabWidth sets the number of columns a tab advances to. Default is four.
+func WithTabWidth(columns int) Option {
+	return func(c *Code) {
+		if columns > 0 {
+			c.tabWidth = columns
+		}
+	}
+}
+
+// Code draws highlighted source into pixels, one line on each row, like the charts do (see Draw). The long lines are clipped.
+// The source is highlighted once, when it's set, while drawing looks up only the tokens of the lines shown, so long sources scroll cheaply (see ScrollTo).
+type Code struct {
+	sync.Mutex              //
+	highlighter Highlighter //
+	styles      Styles      //
+	source      []byte      //
+	tokens      []Token     //
+	lines       []int       // the offset where each line starts
+	first       int         // the first line shown
+	tabWidth    int         // set by WithTabWidth
+}
+
+// NewCode highlights the source, which is drawn using the styles (see ThemeStyles)
+func NewCode(source string, highlighter Highlighter, styles Styles, opts ...Option) *Code {
+	res := &Code{
+		highlighter: highlighter,
+		styles:      styles,
+		tabWidth:    defaultTabWidth,
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+	res.setSource(source)
+	return res
+}
+
+// SetSource replaces the source, highlighting it again
+func (c *Code) SetSource(source string) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.setSource(source)
+}
+
+// Tokens returns the tokens of the source
+func (c *Code) Tokens() []Token {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.tokens
+}
+
+// Lines returns the number of lines of the source
+func (c *Code) Lines() int {
+	c.Lock()
+	defer c.Unlock()
+
+	return len(c.lines)
+}
+
+// ScrollTo sets the line drawn on the first row
+func (c *Code) ScrollTo(line int) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.first = term.Max(0, term.Min(line, len(c.lines)-1))
+}
+
+// Draw fills the grid (indexed [column][row]) with the lines shown, the tabs being expanded
+func (c *Code) Draw(grid [][]term.Pixel) {
+	c.Lock()
+	defer c.Unlock()
+
+	if len(grid) == 0 || len(grid[0]) == 0 {
+		return
+	}
+	columns, rows := len(grid), len(grid[0])
+	plain := c.styles[Plain]
+	for row := 0; row < rows; row++ {
+		column := 0
+		if line := c.first + row; line < len(c.lines) {
+			offset, end := c.lines[line], len(c.source)
+			if line+1 < len(c.lines) {
+				end = c.lines[line+1] - 1 // without the new line
+			}
+			idx := sort.Search(len(c.tokens), func(i int) bool { return c.tokens[i].End > offset })
+			for offset < end && column < columns {
+				r, size := utf8.DecodeRune(c.source[offset:end])
+				for idx < len(c.tokens) && c.tokens[idx].End <= offset {
+					idx++
+				}
+				st := plain
+				if idx < len(c.tokens) && c.tokens[idx].Start <= offset {
+					if mapped, ok := c.styles[c.tokens[idx].Class]; ok {
+						st = mapped
+					}
+				}
+				offset += size
+				if r == '\t' {
+					for next := (column/c.tabWidth + 1) * c.tabWidth; column < next && column < columns; column++ {
+						grid[column][row].SetAll(st.Bg, st.Fg, st.Attrs, ' ', nil)
+					}
+					continue
+				}
+				if r == '\r' {
+					continue
+				}
+				grid[column][row].SetAll(st.Bg, st.Fg, st.Attrs, r, nil)
+				column++
+			}
+		}
+		for ; column < columns; column++ {
+			grid[column][row].SetAll(plain.Bg, plain.Fg, plain.Attrs, ' ', nil)
+		}
+	}
+}
+
+// setSource highlights the source and finds where its lines start - locked inside caller function
+func (c *Code) setSource(source string) {
+	c.source = []byte(source)
+	c.tokens = c.highlighter.Highlight(c.source)
+	c.lines = append(c.lines[:0], 0)
+	for idx, b := range c.source {
+		if b == '\n' {
+			c.lines = append(c.lines, idx+1)
+		}
+	}
+	c.first = term.Min(c.first, len(c.lines)-1)
+}
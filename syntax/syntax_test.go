@@ -0,0 +1,77 @@
+package syntax_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/geom"
+	"github.com/badu/term/style"
+	"github.com/badu/term/syntax"
+)
+
+const source = "package main\n\n// answer is returned\nfunc answer() int {\n\treturn 40 + 2 // \"not a string\"\n}\nvar s = `raw\nstring` + \"ok\" + nil\n"
+
+// rows returns the runes of the grid, row by row
+func rows(grid [][]term.Pixel) []string {
+	var result []string
+	for row := 0; row < len(grid[0]); row++ {
+		var sb strings.Builder
+		for column := range grid {
+			sb.WriteRune(grid[column][row].Rune())
+		}
+		result = append(result, sb.String())
+	}
+	return result
+}
+
+func TestGoHighlighter(t *testing.T) {
+	var found []string
+	for _, tok := range syntax.Go().Highlight([]byte(source)) {
+		found = append(found, source[tok.Start:tok.End])
+		if tok.Start >= tok.End {
+			t.Errorf("error : empty token %+v", tok)
+		}
+	}
+	expected := []string{"package", "// answer is returned", "func", "int", "return", "40", "+", "2", "// \"not a string\"", "var", "=", "`raw\nstring`", "+", "\"ok\"", "+", "nil"}
+	if strings.Join(found, "|") != strings.Join(expected, "|") {
+		t.Errorf("error : unexpected tokens %q", found)
+	}
+}
+
+func TestCode(t *testing.T) {
+	theme := style.Theme{TextColor: color.White, MainBackgroundColor: color.Black, KeywordColor: color.Blue, StringColor: color.Green, CommentColor: color.Gray}
+	code := syntax.NewCode(source, syntax.Go(), syntax.ThemeStyles(theme), syntax.WithTabWidth(2))
+	if code.Lines() != 9 {
+		t.Errorf("error : expecting 9 lines, got %d", code.Lines())
+	}
+
+	grid, _ := geom.NewPixelGrid(&term.Size{Columns: 14, Rows: 2})
+	code.ScrollTo(3)
+	code.Draw(grid)
+	if got := rows(grid); got[0] != "func answer() " || got[1] != "  return 40 + " {
+		t.Errorf("error : unexpected rows %q", got)
+	}
+	check := func(column, row int, fg color.Color, attrs style.Mask) {
+		t.Helper()
+		if cellFg, bg, cellAttrs := grid[column][row].Style(); cellFg != fg || bg != color.Black || cellAttrs != attrs {
+			t.Errorf("error : cell %d,%d should be %v %v, got %v %v", column, row, fg, attrs, cellFg, cellAttrs)
+		}
+	}
+	check(0, 0, color.Blue, style.Bold)   // func
+	check(5, 0, color.White, style.None)  // answer
+	check(2, 1, color.Blue, style.Bold)   // return, after the tab
+	check(9, 1, color.White, style.None)  // 40, the theme having no number color
+	check(13, 1, color.White, style.None) // the space after +
+
+	// the raw string spans two lines
+	code.ScrollTo(6)
+	code.Draw(grid)
+	if got := rows(grid); got[1] != "string` + \"ok\"" {
+		t.Errorf("error : unexpected rows %q", got)
+	}
+	check(0, 1, color.Green, style.None)
+	check(7, 1, color.White, style.None)
+	check(10, 1, color.Green, style.None)
+}
@@ -0,0 +1,368 @@
+package termtest
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/badu/term"
+	"github.com/badu/term/runewidth"
+)
+
+// decGraphics is the DEC special graphics character set, selected by ESC ( 0 for drawing lines
+var decGraphics = map[byte]rune{
+	'`': '◆', 'a': '▒', 'f': '°', 'g': '±', 'j': '┘', 'k': '┐', 'l': '┌', 'm': '└', 'n': '┼',
+	'q': '─', 't': '├', 'u': '┤', 'v': '┴', 'w': '┬', 'x': '│', 'y': '≤', 'z': '≥', '~': '·',
+}
+
+// Screen is a simulated terminal : the engine writes to it as it would to xterm, and reads the input sent by the tests.
+// It implements core.Transport interface. Only the text is kept, the styles being dropped : the cursor movements, the erasing sequences and the line drawing
+// character set are interpreted, the other sequences (styles, modes, queries) are ignored.
+type Screen struct {
+	sync.Mutex               // guards other properties
+	columns    int           //
+	rows       int           //
+	cells      [][]rune      // [row][column], zero for the cells covered by a wide rune
+	column     int           // of the cursor, equal to columns when the next rune wraps
+	row        int           // of the cursor
+	saved      [2]int        // the cursor saved by ESC 7
+	graphics   bool          // the DEC special graphics are selected
+	pending    []byte        // an escape sequence or a rune split across writes
+	notify     func()        // set by the engine, called on resize
+	changed    chan struct{} // closed and replaced after each write, for the waiters
+	input      chan []byte   // sent by the tests, waiting to be read
+	unread     []byte        // the part of the last input which didn't fit the buffer of Read
+	closed     chan struct{} // closed by Close
+	closeOnce  sync.Once     //
+}
+
+// NewScreen returns a blank screen of that size
+func NewScreen(columns, rows int) *Screen {
+	res := &Screen{
+		changed: make(chan struct{}),
+		input:   make(chan []byte, 64),
+		closed:  make(chan struct{}),
+	}
+	res.resize(columns, rows)
+	return res
+}
+
+// Read implements io.Reader interface, returning the input sent by the tests, or io.EOF once the screen is closed
+func (s *Screen) Read(p []byte) (int, error) {
+	if len(s.unread) == 0 {
+		select {
+		case in := <-s.input:
+			s.unread = in
+		case <-s.closed:
+			return 0, io.EOF
+		}
+	}
+	n := copy(p, s.unread)
+	s.unread = s.unread[n:]
+	return n, nil
+}
+
+// Write implements io.Writer interface, interpreting the output of the engine
+func (s *Screen) Write(p []byte) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	buf := append(s.pending, p...)
+	s.pending = nil
+	for len(buf) > 0 {
+		n := s.interpret(buf)
+		if n == 0 {
+			s.pending = append([]byte(nil), buf...) // incomplete, waiting for the rest
+			break
+		}
+		buf = buf[n:]
+	}
+	close(s.changed)
+	s.changed = make(chan struct{})
+	return len(p), nil
+}
+
+// WindowSize implements core.Transport interface
+func (s *Screen) WindowSize() (int, int, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.columns, s.rows, nil
+}
+
+// NotifyResize implements core.Transport interface
+func (s *Screen) NotifyResize(fn func()) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.notify = fn
+}
+
+// Resize changes the size of the screen, keeping the text which still fits, and tells the engine about it
+func (s *Screen) Resize(columns, rows int) {
+	s.Lock()
+	s.resize(columns, rows)
+	notify := s.notify
+	s.Unlock()
+	if notify != nil {
+		notify()
+	}
+}
+
+// SendBytes queues the bytes as input, read by the engine as if they were typed
+func (s *Screen) SendBytes(in []byte) {
+	select {
+	case s.input <- append([]byte(nil), in...):
+	case <-s.closed:
+	}
+}
+
+// Close makes Read return io.EOF, so the engine stops reading
+func (s *Screen) Close() error {
+	s.closeOnce.Do(func() { close(s.closed) })
+	return nil
+}
+
+// Rune returns the rune at that position, a space if the cell is blank
+func (s *Screen) Rune(column, row int) rune {
+	s.Lock()
+	defer s.Unlock()
+
+	if row < 0 || row >= s.rows || column < 0 || column >= s.columns || s.cells[row][column] == 0 {
+		return ' '
+	}
+	return s.cells[row][column]
+}
+
+// Text returns the rows of the screen, without their trailing spaces, joined by new lines. The trailing blank rows are dropped.
+func (s *Screen) Text() string {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.text()
+}
+
+// text returns the rows of the screen - locked inside caller function
+func (s *Screen) text() string {
+	lines := make([]string, s.rows)
+	for row, cells := range s.cells {
+		var sb strings.Builder
+		for _, r := range cells {
+			if r != 0 {
+				sb.WriteRune(r)
+			}
+		}
+		lines[row] = strings.TrimRight(sb.String(), " ")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// Changed returns a channel which is closed after the next write of the engine
+func (s *Screen) Changed() <-chan struct{} {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.changed
+}
+
+// resize reallocates the cells - locked inside caller function
+func (s *Screen) resize(columns, rows int) {
+	cells := make([][]rune, rows)
+	for row := range cells {
+		cells[row] = make([]rune, columns)
+		for column := range cells[row] {
+			cells[row][column] = ' '
+			if row < s.rows && column < s.columns {
+				cells[row][column] = s.cells[row][column]
+			}
+		}
+	}
+	s.cells, s.columns, s.rows = cells, columns, rows
+	s.column, s.row = clamp(s.column, 0, columns-1), clamp(s.row, 0, rows-1)
+}
+
+// interpret handles a control character, an escape sequence or a rune at the beginning of the buffer, returning the bytes consumed, zero if the buffer is incomplete
+func (s *Screen) interpret(buf []byte) int {
+	switch buf[0] {
+	case '\x1b':
+		return s.escape(buf)
+	case '\r':
+		s.column = 0
+	case '\n', '\v', '\f':
+		s.lineFeed()
+	case '\b':
+		s.column = clamp(s.column-1, 0, s.columns-1)
+	case '\t':
+		s.column = clamp((s.column/8+1)*8, 0, s.columns-1)
+	case '\x0e', '\x0f', '\a', 0: // shift out and in, bell, padding
+	default:
+		if buf[0] < ' ' || buf[0] == 0x7f {
+			return 1 // other control characters are ignored
+		}
+		if s.graphics {
+			if r, ok := decGraphics[buf[0]]; ok {
+				s.put(r)
+				return 1
+			}
+		}
+		if !utf8.FullRune(buf) {
+			return 0
+		}
+		r, size := utf8.DecodeRune(buf)
+		s.put(r)
+		return size
+	}
+	return 1
+}
+
+// escape handles the escape sequence at the beginning of the buffer, returning its length, zero if it's incomplete
+func (s *Screen) escape(buf []byte) int {
+	if len(buf) < 2 {
+		return 0
+	}
+	switch buf[1] {
+	case '[':
+		for idx := 2; idx < len(buf); idx++ {
+			if buf[idx] >= 0x40 && buf[idx] <= 0x7e {
+				s.csi(string(buf[2:idx]), buf[idx])
+				return idx + 1
+			}
+		}
+		return 0
+	case ']', 'P', '_', '^': // OSC, DCS, APC and PM, terminated by BEL (OSC only) or ST
+		for idx := 2; idx < len(buf); idx++ {
+			if buf[idx] == '\a' && buf[1] == ']' {
+				return idx + 1
+			}
+			if buf[idx] == '\x1b' && idx+1 < len(buf) && buf[idx+1] == '\\' {
+				return idx + 2
+			}
+		}
+		return 0
+	case '(', ')', '*', '+': // designates a character set
+		if len(buf) < 3 {
+			return 0
+		}
+		if buf[1] == '(' {
+			s.graphics = buf[2] == '0'
+		}
+		return 3
+	case '7':
+		s.saved = [2]int{s.column, s.row}
+	case '8':
+		s.column, s.row = s.saved[0], s.saved[1]
+	case 'c': // full reset
+		columns, rows := s.columns, s.rows
+		s.cells, s.columns, s.rows = nil, 0, 0
+		s.resize(columns, rows)
+		s.column, s.row, s.graphics = 0, 0, false
+	}
+	return 2 // other two bytes sequences (keypad modes, index) are ignored
+}
+
+// csi handles a control sequence, having the parameters and the final byte
+func (s *Screen) csi(params string, final byte) {
+	if strings.IndexAny(params, "?<=>") == 0 || strings.ContainsAny(params, " !\"$'") {
+		return // private modes, queries and other intermediates
+	}
+	args := strings.Split(params, ";")
+	arg := func(idx, def int) int {
+		if idx >= len(args) || args[idx] == "" {
+			return def
+		}
+		n, err := strconv.Atoi(args[idx])
+		if err != nil {
+			return def
+		}
+		return n
+	}
+	switch final {
+	case 'H', 'f':
+		s.row, s.column = clamp(arg(0, 1)-1, 0, s.rows-1), clamp(arg(1, 1)-1, 0, s.columns-1)
+	case 'A':
+		s.row = clamp(s.row-arg(0, 1), 0, s.rows-1)
+	case 'B':
+		s.row = clamp(s.row+arg(0, 1), 0, s.rows-1)
+	case 'C':
+		s.column = clamp(s.column+arg(0, 1), 0, s.columns-1)
+	case 'D':
+		s.column = clamp(s.column-arg(0, 1), 0, s.columns-1)
+	case 'G', '`':
+		s.column = clamp(arg(0, 1)-1, 0, s.columns-1)
+	case 'd':
+		s.row = clamp(arg(0, 1)-1, 0, s.rows-1)
+	case 'K':
+		switch arg(0, 0) {
+		case 0:
+			s.erase(s.row, s.column, s.columns)
+		case 1:
+			s.erase(s.row, 0, s.column+1)
+		case 2:
+			s.erase(s.row, 0, s.columns)
+		}
+	case 'J':
+		switch arg(0, 0) {
+		case 0:
+			s.erase(s.row, s.column, s.columns)
+			for row := s.row + 1; row < s.rows; row++ {
+				s.erase(row, 0, s.columns)
+			}
+		case 1:
+			for row := 0; row < s.row; row++ {
+				s.erase(row, 0, s.columns)
+			}
+			s.erase(s.row, 0, s.column+1)
+		case 2, 3:
+			for row := 0; row < s.rows; row++ {
+				s.erase(row, 0, s.columns)
+			}
+		}
+	case 'X':
+		s.erase(s.row, s.column, s.column+arg(0, 1))
+	}
+}
+
+// put writes the rune at the cursor, moving it after the rune
+func (s *Screen) put(r rune) {
+	width := runewidth.RuneWidth(r)
+	if width == 0 || s.columns == 0 || s.rows == 0 {
+		return // combining marks are dropped
+	}
+	if s.column+width > s.columns {
+		s.column = 0 // auto wrap
+		s.lineFeed()
+	}
+	s.cells[s.row][s.column] = r
+	if width == 2 && s.column+1 < s.columns {
+		s.cells[s.row][s.column+1] = 0
+	}
+	s.column += width
+}
+
+// lineFeed moves the cursor down, scrolling the screen up on the last row
+func (s *Screen) lineFeed() {
+	if s.row < s.rows-1 {
+		s.row++
+		return
+	}
+	copy(s.cells, s.cells[1:])
+	s.cells[s.rows-1] = make([]rune, s.columns)
+	s.erase(s.rows-1, 0, s.columns)
+}
+
+// erase blanks the cells of the row, from the first column up to (excluding) the last one
+func (s *Screen) erase(row, first, last int) {
+	if row < 0 || row >= s.rows {
+		return
+	}
+	for column := term.Max(first, 0); column < s.columns && column < last; column++ {
+		s.cells[row][column] = ' '
+	}
+}
+
+// clamp keeps the value between the limits
+func clamp(value, low, high int) int {
+	return term.Max(term.Min(value, high), low)
+}
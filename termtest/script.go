@@ -0,0 +1,180 @@
+package termtest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/badu/term"
+	"github.com/badu/term/info"
+	"github.com/badu/term/key"
+)
+
+// mouseButtons are the SGR codes of the mouse actions which can be scripted
+var mouseButtons = map[string]int{"click": 0, "middleclick": 1, "rightclick": 2, "wheelup": 64, "wheeldown": 65}
+
+// Encode converts a script into the bytes xterm would send : the text is typed as it is, while the key strokes are written between braces, e.g. "hello{Enter}",
+// "{Ctrl+S}", "{Alt+x}" or "{Shift+Up}" (see key.ParseBinding for their names). "{{" types a brace.
+// The mouse actions are written between braces too, with the column and the row on the screen : "{Click:3,1}", "{RightClick:3,1}", "{MiddleClick:3,1}",
+// "{WheelUp:3,1}" and "{WheelDown:3,1}". A click is a press followed by a release, reported with the SGR encoding.
+func Encode(ti *info.Term, script string) ([]byte, error) {
+	var result []byte
+	for len(script) > 0 {
+		start := strings.IndexByte(script, '{')
+		if start < 0 {
+			result = append(result, script...)
+			break
+		}
+		result = append(result, script[:start]...)
+		script = script[start+1:]
+		if strings.HasPrefix(script, "{") {
+			result = append(result, '{')
+			script = script[1:]
+			continue
+		}
+		end := strings.IndexByte(script, '}')
+		if end < 0 {
+			return nil, fmt.Errorf("termtest: unclosed brace before %q", script)
+		}
+		if encoded, ok, err := encodeMouse(script[:end]); ok {
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, encoded...)
+			script = script[end+1:]
+			continue
+		}
+		binding, err := key.ParseBinding(script[:end])
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := encodeBinding(ti, binding)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, encoded...)
+		script = script[end+1:]
+	}
+	return result, nil
+}
+
+// encodeMouse returns the SGR reports of the mouse action, and false if the token isn't one
+func encodeMouse(token string) ([]byte, bool, error) {
+	idx := strings.IndexByte(token, ':')
+	if idx < 0 {
+		return nil, false, nil
+	}
+	button, ok := mouseButtons[strings.ToLower(token[:idx])]
+	if !ok {
+		return nil, false, nil
+	}
+	position := strings.Split(token[idx+1:], ",")
+	if len(position) != 2 {
+		return nil, true, fmt.Errorf("termtest: expecting the column and the row in %q", token)
+	}
+	column, err := strconv.Atoi(strings.TrimSpace(position[0]))
+	if err != nil || column < 0 {
+		return nil, true, fmt.Errorf("termtest: invalid column in %q", token)
+	}
+	row, err := strconv.Atoi(strings.TrimSpace(position[1]))
+	if err != nil || row < 0 {
+		return nil, true, fmt.Errorf("termtest: invalid row in %q", token)
+	}
+	press := fmt.Sprintf("\x1b[<%d;%d;%dM", button, column+1, row+1)
+	if button >= 64 {
+		return []byte(press), true, nil // the wheel has no release
+	}
+	return []byte(press + fmt.Sprintf("\x1b[<%d;%d;%dm", button, column+1, row+1)), true, nil
+}
+
+// encodeBinding returns the bytes of the key stroke. The modifiers of the named keys are encoded as xterm does, e.g. Ctrl+Up is ESC [ 1 ; 5 A.
+func encodeBinding(ti *info.Term, b key.Binding) ([]byte, error) {
+	mod := b.Mod
+	var seq string
+	switch {
+	case b.Key == key.Rune:
+		r := b.Rune
+		if mod&key.ModShift != 0 {
+			r, mod = unicode.ToUpper(r), mod&^key.ModShift
+		}
+		seq = string(r)
+	case b.Key < ' ' || b.Key == key.DEL:
+		mod &^= key.ModCtrl // the control characters are the keys with Ctrl
+		if b.Key == key.Tab && mod&key.ModShift != 0 {
+			seq, mod = ti.KeyBacktab, mod&^key.ModShift
+			break
+		}
+		seq = string(rune(b.Key))
+	default:
+		seq = namedKey(ti, b.Key)
+		if seq == "" {
+			return nil, fmt.Errorf("termtest: %s is not defined by the terminal", b)
+		}
+		if mod != 0 {
+			return withModifiers(seq, mod, b)
+		}
+	}
+	if mod&key.ModAlt != 0 {
+		seq, mod = "\x1b"+seq, mod&^key.ModAlt
+	}
+	if mod != 0 {
+		return nil, fmt.Errorf("termtest: %s can't be typed", b)
+	}
+	return []byte(seq), nil
+}
+
+// withModifiers adds the xterm modifier parameter to the sequence of a named key
+func withModifiers(seq string, mod term.ModMask, b key.Binding) ([]byte, error) {
+	param := 1
+	for _, m := range []struct {
+		mask  term.ModMask
+		value int
+	}{{key.ModShift, 1}, {key.ModAlt, 2}, {key.ModCtrl, 4}, {key.ModMeta, 8}} {
+		if mod&m.mask != 0 {
+			param += m.value
+		}
+	}
+	suffix := ";" + strconv.Itoa(param)
+	switch {
+	case strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "~"):
+		return []byte(seq[:len(seq)-1] + suffix + "~"), nil
+	case (strings.HasPrefix(seq, "\x1bO") || strings.HasPrefix(seq, "\x1b[")) && len(seq) == 3:
+		return []byte("\x1b[1" + suffix + seq[2:]), nil
+	}
+	return nil, fmt.Errorf("termtest: %s can't be typed", b)
+}
+
+// namedKey returns the sequence of the key, as defined by the terminal
+func namedKey(ti *info.Term, k term.Key) string {
+	switch k {
+	case key.Up:
+		return ti.KeyUp
+	case key.Down:
+		return ti.KeyDown
+	case key.Left:
+		return ti.KeyLeft
+	case key.Right:
+		return ti.KeyRight
+	case key.PgUp:
+		return ti.KeyPgUp
+	case key.PgDn:
+		return ti.KeyPgDn
+	case key.Home:
+		return ti.KeyHome
+	case key.End:
+		return ti.KeyEnd
+	case key.Insert:
+		return ti.KeyInsert
+	case key.Delete:
+		return ti.KeyDelete
+	case key.Help:
+		return ti.KeyHelp
+	case key.BackTab:
+		return ti.KeyBacktab
+	}
+	if k >= key.F1 && k <= key.F12 {
+		return []string{ti.KeyF1, ti.KeyF2, ti.KeyF3, ti.KeyF4, ti.KeyF5, ti.KeyF6, ti.KeyF7, ti.KeyF8, ti.KeyF9, ti.KeyF10, ti.KeyF11, ti.KeyF12}[k-key.F1]
+	}
+	return ""
+}
@@ -0,0 +1,240 @@
+// Package termtest helps writing end to end tests of the applications : the engine runs on a simulated screen, the tests type scripted keys and mouse clicks,
+// then compare what is displayed with golden files.
+package termtest
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/badu/term"
+	"github.com/badu/term/core"
+	"github.com/badu/term/info"
+)
+
+// Terminal is the terminal simulated for the tests
+const Terminal = "xterm-256color"
+
+// Timeout is how long the screen is waited for, before failing the test
+var Timeout = 2 * time.Second
+
+// update rewrites the golden files with what the screen shows, instead of comparing them, e.g. go test ./... -termtest.update
+var update = flag.Bool("termtest.update", false, "rewrite the golden files of termtest.RequireScreenEquals")
+
+var (
+	tiOnce sync.Once
+	ti     *info.Term // looked up once, since creating an engine forgets the registered definitions
+)
+
+// terminfo returns the definition of the simulated terminal, nil if it's not registered
+func terminfo() *info.Term {
+	tiOnce.Do(func() {
+		ti, _ = info.LookupTerminfo(Terminal)
+	})
+	return ti
+}
+
+// Engine is an engine started on a simulated screen, stopped when the test ends
+type Engine struct {
+	term.Engine            //
+	Screen      *Screen    // what the engine displays
+	tb          testing.TB //
+}
+
+// New starts an engine on a blank screen of that size. The options are passed to the engine.
+func New(tb testing.TB, columns, rows int, opts ...core.Option) *Engine {
+	tb.Helper()
+	if terminfo() == nil {
+		tb.Fatalf("termtest: %s is not registered", Terminal)
+	}
+	screen := NewScreen(columns, rows)
+	opts = append([]core.Option{core.WithTerminfo(ti)}, opts...)
+	engine, err := core.NewCore(Terminal, append(opts, core.WithTransport(screen))...)
+	if err != nil {
+		tb.Fatalf("termtest: error creating engine : %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := engine.Start(ctx); err != nil {
+		cancel()
+		tb.Fatalf("termtest: error starting engine : %v", err)
+	}
+	tb.Cleanup(func() {
+		cancel()
+		select {
+		case <-engine.DyingChan():
+		case <-time.After(Timeout):
+		}
+		_ = screen.Close()
+	})
+	return &Engine{Engine: engine, Screen: screen, tb: tb}
+}
+
+// Send types the script (see Encode), e.g. "hello{Enter}", "{Ctrl+S}" or "{Click:3,1}", failing the test if it's invalid
+func (e *Engine) Send(script string) {
+	e.tb.Helper()
+	in, err := Encode(ti, script)
+	if err != nil {
+		e.tb.Fatalf("%v", err)
+	}
+	e.Screen.SendBytes(in)
+}
+
+// Resize changes the size of the screen, the engine dispatching a resize event
+func (e *Engine) Resize(columns, rows int) {
+	e.Screen.Resize(columns, rows)
+}
+
+// Text returns what the screen shows (see Screen.Text)
+func (e *Engine) Text() string {
+	return e.Screen.Text()
+}
+
+// texter is implemented by the engines which know what they display
+type texter interface {
+	Text() string
+}
+
+// snapshot returns what the engine displays, and a channel which is closed when it might have changed
+func snapshot(engine term.Engine) (string, <-chan struct{}) {
+	if e, ok := engine.(*Engine); ok {
+		changed := e.Screen.Changed()
+		return e.Screen.Text(), changed
+	}
+	changed := time.After(10 * time.Millisecond) // polled
+	if t, ok := engine.(texter); ok {
+		return t.Text(), closedAfter(changed)
+	}
+	content, ok := engine.(term.ContentGetter)
+	size := engine.Size()
+	if !ok || size == nil {
+		return "", closedAfter(changed)
+	}
+	lines := make([]string, size.Rows)
+	for row := range lines {
+		var sb strings.Builder
+		for column := 0; column < size.Columns; column++ {
+			r := ' '
+			if pixel, ok := content.GetContent(column, row); ok && pixel.Rune() != 0 {
+				r = pixel.Rune()
+			}
+			sb.WriteRune(r)
+		}
+		lines[row] = strings.TrimRight(sb.String(), " ")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n"), closedAfter(changed)
+}
+
+// closedAfter converts a timer channel into a channel closed when it fires
+func closedAfter(timer <-chan time.Time) <-chan struct{} {
+	result := make(chan struct{})
+	go func() {
+		<-timer
+		close(result)
+	}()
+	return result
+}
+
+// WaitForText waits until the screen shows the text, failing the test after Timeout
+func WaitForText(tb testing.TB, engine term.Engine, text string) {
+	tb.Helper()
+	deadline := time.After(Timeout)
+	for {
+		shown, changed := snapshot(engine)
+		if strings.Contains(shown, text) {
+			return
+		}
+		select {
+		case <-changed:
+		case <-deadline:
+			tb.Fatalf("termtest: waited %v for %q, the screen shows :\n%s", Timeout, text, shown)
+			return
+		}
+	}
+}
+
+// RequireScreenEquals waits until the screen shows the content of the golden file, failing the test after Timeout. The rows are compared without their trailing spaces,
+// and the trailing blank rows are ignored. Running the tests with -termtest.update writes the golden file instead, once the screen has settled.
+// The engine is the one returned by New, or any engine implementing term.ContentGetter, in which case the active pixels are compared.
+func RequireScreenEquals(tb testing.TB, engine term.Engine, golden string) {
+	tb.Helper()
+	if *update {
+		shown := settle(engine)
+		if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+			tb.Fatalf("termtest: %v", err)
+		}
+		if err := ioutil.WriteFile(golden, []byte(shown+"\n"), 0644); err != nil {
+			tb.Fatalf("termtest: %v", err)
+		}
+		return
+	}
+	content, err := ioutil.ReadFile(golden)
+	if err != nil {
+		tb.Fatalf("termtest: %v (run the test with -termtest.update to create it)", err)
+	}
+	expected := normalize(string(content))
+	deadline := time.After(Timeout)
+	for {
+		shown, changed := snapshot(engine)
+		if shown == expected {
+			return
+		}
+		select {
+		case <-changed:
+		case <-deadline:
+			tb.Fatalf("termtest: the screen doesn't match %s\n%s", golden, diff(expected, shown))
+			return
+		}
+	}
+}
+
+// settle waits until the screen hasn't changed for a while, returning what it shows
+func settle(engine term.Engine) string {
+	deadline := time.After(Timeout)
+	for {
+		shown, changed := snapshot(engine)
+		select {
+		case <-changed:
+		case <-time.After(100 * time.Millisecond):
+			return shown
+		case <-deadline:
+			return shown
+		}
+	}
+}
+
+// normalize removes the trailing spaces of the rows, and the trailing blank rows, of a golden file
+func normalize(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for idx, line := range lines {
+		lines[idx] = strings.TrimRight(line, " ")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// diff returns the rows of both texts, marking the ones which differ
+func diff(expected, shown string) string {
+	want, got := strings.Split(expected, "\n"), strings.Split(shown, "\n")
+	var sb strings.Builder
+	for row := 0; row < term.Max(len(want), len(got)); row++ {
+		var w, g string
+		if row < len(want) {
+			w = want[row]
+		}
+		if row < len(got) {
+			g = got[row]
+		}
+		if w == g {
+			sb.WriteString("  " + w + "\n")
+			continue
+		}
+		sb.WriteString("- " + w + "\n")
+		sb.WriteString("+ " + g + "\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,144 @@
+package termtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/key"
+	"github.com/badu/term/style"
+)
+
+func TestScreen(t *testing.T) {
+	s := NewScreen(10, 3)
+	for _, chunk := range []string{"\x1b[?1049h\x1b[2;3Hab", "c\x1b[38;5;1mé", "\xe2\x94", "\x80\x1b[1;1Hxyz\x1b[1;2H\x1b[K", "\x1b(0lqk\x1b(B\x1b]11;?\x07"} {
+		if _, err := s.Write([]byte(chunk)); err != nil {
+			t.Fatalf("error writing : %v", err)
+		}
+	}
+	if text := s.Text(); text != "x┌─┐\n  abcé─" {
+		t.Errorf("error : unexpected screen %q", text)
+	}
+	if r := s.Rune(3, 1); r != 'b' {
+		t.Errorf("error : expecting b at 3,1, got %q", r)
+	}
+
+	s.Write([]byte("\x1b[3;9H世界")) // the second wide rune doesn't fit, so it wraps, scrolling the screen up
+	if text := s.Text(); text != "  abcé─\n        世\n界" {
+		t.Errorf("error : unexpected screen after wrapping %q", text)
+	}
+	s.Write([]byte("\x1b[2J"))
+	if text := s.Text(); text != "" {
+		t.Errorf("error : the screen should be erased, got %q", text)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	ti := terminfo()
+	if ti == nil {
+		t.Fatalf("error : %s is not registered", Terminal)
+	}
+	for _, test := range []struct {
+		script   string
+		expected string
+	}{
+		{"hello{Enter}", "hello\r"},
+		{"{Ctrl+S}{Tab}{Esc}", "\x13\t\x1b"},
+		{"{Alt+x}{Shift+a}", "\x1bxA"},
+		{"{Up}{Ctrl+Up}", ti.KeyUp + "\x1b[1;5A"},
+		{"{Shift+Tab}{Delete}{Alt+Delete}", ti.KeyBacktab + "\x1b[3~\x1b[3;3~"},
+		{"{{x}", "{x}"},
+		{"{Click:2,0}{WheelDown:0,4}", "\x1b[<0;3;1M\x1b[<0;3;1m\x1b[<65;1;5M"},
+	} {
+		encoded, err := Encode(ti, test.script)
+		if err != nil {
+			t.Errorf("error : %q : %v", test.script, err)
+			continue
+		}
+		if string(encoded) != test.expected {
+			t.Errorf("error : %q : expecting %q, got %q", test.script, test.expected, encoded)
+		}
+	}
+	for _, script := range []string{"{Enter", "{Hyper+X}", "{Click:1}", "{Meta+a}"} {
+		if _, err := Encode(ti, script); err == nil {
+			t.Errorf("error : %q should be refused", script)
+		}
+	}
+}
+
+type textPixel struct {
+	column, row int
+	r           rune
+}
+
+func (p *textPixel) DrawCh() chan term.PixelGetter { return nil }
+func (p *textPixel) Style() (color.Color, color.Color, style.Mask) {
+	return color.Default, color.Default, style.None
+}
+func (p *textPixel) HasUnicode() bool       { return false }
+func (p *textPixel) Unicode() *term.Unicode { return nil }
+func (p *textPixel) Rune() rune             { return p.r }
+func (p *textPixel) Width() int             { return 1 }
+func (p *textPixel) PositionHash() int      { return term.Hash(p.column, p.row) }
+
+func text(column, row int, s string) []term.PixelGetter {
+	var result []term.PixelGetter
+	for idx, r := range s {
+		result = append(result, &textPixel{column: column + idx, row: row, r: r})
+	}
+	return result
+}
+
+type keyListener struct {
+	ch   chan term.KeyEvent
+	died chan struct{}
+}
+
+func (l *keyListener) KeyListen() chan term.KeyEvent { return l.ch }
+func (l *keyListener) DyingChan() chan struct{}      { return l.died }
+
+func TestEngine(t *testing.T) {
+	e := New(t, 20, 5)
+	e.Redraw(append(text(0, 0, "hello"), text(2, 2, "world")...))
+	RequireScreenEquals(t, e, "testdata/hello.golden")
+
+	listener := &keyListener{ch: make(chan term.KeyEvent, 8), died: make(chan struct{})}
+	defer close(listener.died)
+	e.KeyDispatcher().Register(listener)
+	e.Send("a{Ctrl+S}{Up}")
+	for _, expected := range []key.Binding{{Key: key.Rune, Rune: 'a'}, {Key: key.CtrlS, Mod: key.ModCtrl}, {Key: key.Up}} {
+		select {
+		case ev := <-listener.ch:
+			if !expected.Matches(ev) {
+				t.Errorf("error : expecting %s, got %s", expected, ev.Name())
+			}
+		case <-time.After(Timeout):
+			t.Fatalf("error : %s was not received", expected)
+		}
+	}
+
+	listen := &resizeListener{ch: make(chan term.ResizeEvent, 4), died: make(chan struct{})}
+	defer close(listen.died)
+	e.ResizeDispatcher().Register(listen)
+	e.Resize(30, 8)
+	deadline := time.After(Timeout)
+	for {
+		select {
+		case ev := <-listen.ch:
+			if size := ev.Size(); size.Columns == 30 && size.Rows == 8 {
+				return
+			}
+		case <-deadline:
+			t.Fatalf("error : the resize was not dispatched")
+		}
+	}
+}
+
+type resizeListener struct {
+	ch   chan term.ResizeEvent
+	died chan struct{}
+}
+
+func (l *resizeListener) ResizeListen() chan term.ResizeEvent { return l.ch }
+func (l *resizeListener) DyingChan() chan struct{}            { return l.died }
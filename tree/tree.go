@@ -0,0 +1,482 @@
+package tree
+
+import (
+	"sync"
+
+	"github.com/badu/term"
+	"github.com/badu/term/color"
+	"github.com/badu/term/key"
+	"github.com/badu/term/mouse"
+	"github.com/badu/term/style"
+)
+
+const (
+	defaultIndent = 2 // columns added by each level
+	wheelRows     = 3 // rows scrolled by the mouse wheel
+	collapsedRune = '▸'
+	expandedRune  = '▾'
+)
+
+// Node is an item of the tree. The children are either added by the application (see Add) or loaded the first time the node is expanded (see WithLoader).
+type Node struct {
+	Label    string      // drawn after the expander
+	Value    interface{} // the data of the application, e.g. the path of a file
+	leaf     bool        // true if the node can't have children, so it has no expander
+	parent   *Node       //
+	children []*Node     //
+	loaded   bool        // true once the children were added or loaded
+	expanded bool        //
+}
+
+// NewNode returns a node which can have children, loaded when it's expanded
+func NewNode(label string, value interface{}) *Node {
+	return &Node{Label: label, Value: value}
+}
+
+// NewLeaf returns a node which has no children, so it can't be expanded
+func NewLeaf(label string, value interface{}) *Node {
+	return &Node{Label: label, Value: value, leaf: true}
+}
+
+// Add appends the children, so the loader isn't called for this node. Add it before the node is shown, or call the Tree Refresh.
+func (n *Node) Add(children ...*Node) *Node {
+	for _, child := range children {
+		child.parent = n
+	}
+	n.children = append(n.children, children...)
+	n.loaded = true
+	return n
+}
+
+// Parent returns the node having this one among its children, nil for the roots
+func (n *Node) Parent() *Node {
+	return n.parent
+}
+
+// Children returns the children added or loaded so far
+func (n *Node) Children() []*Node {
+	return n.children
+}
+
+// Leaf returns true if the node can't have children
+func (n *Node) Leaf() bool {
+	return n.leaf
+}
+
+// Expanded returns true if the children of the node are shown
+func (n *Node) Expanded() bool {
+	return n.expanded
+}
+
+// Depth returns the number of ancestors of the node, zero for the roots
+func (n *Node) Depth() int {
+	depth := 0
+	for parent := n.parent; parent != nil; parent = parent.parent {
+		depth++
+	}
+	return depth
+}
+
+// Loader returns the children of the node, called the first time it's expanded (e.g. reading a directory). It's called synchronously and it should not call the Tree.
+type Loader func(n *Node) []*Node
+
+// NodeHook is called with the node selected or activated
+type NodeHook func(t *Tree, n *Node)
+
+// Option for functional options
+type Option func(t *Tree)
+
+// WithLoader sets the function loading the children of the nodes which have none added
+func WithLoader(loader Loader) Option {
+	return func(t *Tree) {
+		t.loader = loader
+	}
+}
+
+// WithTreeStyle sets the style of the rows and of the selected one. Default is the terminal colors, the selected row being reversed.
+func WithTreeStyle(text, selected style.Style) Option {
+	return func(t *Tree) {
+		t.st = text
+		t.selectedStyle = selected
+	}
+}
+
+// WithIndent sets the columns added by each level. Default is two.
+func WithIndent(columns int) Option {
+	return func(t *Tree) {
+		if columns >= 0 {
+			t.indent = columns
+		}
+	}
+}
+
+// WithOnSelect sets the hook called when another node is selected
+func WithOnSelect(hook NodeHook) Option {
+	return func(t *Tree) {
+		t.onSelect = hook
+	}
+}
+
+// WithOnActivate sets the hook called when Enter is pressed on a leaf, e.g. for opening a file
+func WithOnActivate(hook NodeHook) Option {
+	return func(t *Tree) {
+		t.onActivate = hook
+	}
+}
+
+// Tree shows the nodes one per row, the children below their parent, indented, drawn into pixels like the charts (see Draw).
+// The application passes it the key events while it has the focus (see HandleKey) and the mouse events over the pixels drawn (see HandleMouse).
+// Only the nodes of the expanded parents are kept in the rows, and only the rows shown are drawn, so large trees (e.g. a file system) are cheap to browse.
+type Tree struct {
+	sync.Mutex                //
+	roots         []*Node     //
+	rows          []*Node     // the nodes shown when scrolling, the roots and the descendants of the expanded nodes
+	selected      int         // the index of the selected row
+	top           int         // the index of the row drawn first
+	height        int         // the rows of the last Draw, used for paging
+	reveal        bool        // true if the selected row has to be scrolled into view by the next Draw
+	pressed       bool        // true while a mouse button is held down, so only the press is handled
+	st            style.Style // set by WithTreeStyle
+	selectedStyle style.Style // set by WithTreeStyle
+	indent        int         // set by WithIndent
+	loader        Loader      // set by WithLoader
+	onSelect      NodeHook    // set by WithOnSelect
+	onActivate    NodeHook    // set by WithOnActivate
+}
+
+// New returns the tree of the roots, all collapsed, the first one being selected
+func New(roots []*Node, opts ...Option) *Tree {
+	res := &Tree{
+		roots:         roots,
+		indent:        defaultIndent,
+		st:            style.Style{Fg: color.Default, Bg: color.Default},
+		selectedStyle: style.Style{Fg: color.Default, Bg: color.Default, Attrs: style.Reverse},
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+	for _, root := range roots {
+		root.parent = nil
+	}
+	res.flatten()
+	return res
+}
+
+// Rows returns the number of rows, which can be scrolled
+func (t *Tree) Rows() int {
+	t.Lock()
+	defer t.Unlock()
+
+	return len(t.rows)
+}
+
+// Selected returns the selected node, nil if the tree is empty
+func (t *Tree) Selected() *Node {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.selectedNode()
+}
+
+// Select expands the ancestors of the node and selects it, returning false if it isn't in the tree
+func (t *Tree) Select(n *Node) bool {
+	t.Lock()
+	var ancestors []*Node
+	for parent := n.parent; parent != nil; parent = parent.parent {
+		ancestors = append(ancestors, parent)
+	}
+	for idx := len(ancestors) - 1; idx >= 0; idx-- {
+		t.expand(ancestors[idx])
+	}
+	t.flatten()
+	previous := t.selectedNode()
+	found := false
+	for idx, row := range t.rows {
+		if row == n {
+			t.selected, t.reveal, found = idx, true, true
+			break
+		}
+	}
+	t.Unlock()
+
+	if found {
+		t.selectionChanged(previous)
+	}
+	return found
+}
+
+// Expand shows the children of the node, loading them the first time
+func (t *Tree) Expand(n *Node) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.expand(n)
+	t.flatten()
+}
+
+// Collapse hides the children of the node. When the selected node is among its descendants, the node gets selected.
+func (t *Tree) Collapse(n *Node) {
+	t.Lock()
+	previous := t.selectedNode()
+	t.collapse(n)
+	t.Unlock()
+
+	t.selectionChanged(previous)
+}
+
+// Refresh forgets the children of the node which were loaded, so they are loaded again (expanding it if it was expanded), e.g. after the directory was changed.
+func (t *Tree) Refresh(n *Node) {
+	t.Lock()
+	previous := t.selectedNode()
+	expanded := n.expanded
+	t.collapse(n)
+	if t.loader != nil {
+		n.children, n.loaded = nil, false
+	}
+	if expanded {
+		t.expand(n)
+		t.flatten()
+	}
+	t.Unlock()
+
+	t.selectionChanged(previous)
+}
+
+// Draw fills the grid (indexed [column][row]) with the rows shown, scrolling to keep the selected one visible after it was changed
+func (t *Tree) Draw(grid [][]term.Pixel) {
+	t.Lock()
+	defer t.Unlock()
+
+	if len(grid) == 0 || len(grid[0]) == 0 {
+		return
+	}
+	columns := len(grid)
+	t.height = len(grid[0])
+	if t.reveal {
+		if t.selected < t.top {
+			t.top = t.selected
+		} else if t.selected >= t.top+t.height {
+			t.top = t.selected - t.height + 1
+		}
+		t.reveal = false
+	}
+	t.top = term.Max(0, term.Min(t.top, len(t.rows)-t.height))
+
+	for cell := 0; cell < t.height; cell++ {
+		idx, st := t.top+cell, t.st
+		var runes []rune
+		if idx < len(t.rows) {
+			node := t.rows[idx]
+			if idx == t.selected {
+				st = t.selectedStyle
+			}
+			for pad := node.Depth() * t.indent; pad > 0; pad-- {
+				runes = append(runes, ' ')
+			}
+			runes = append(runes, t.expander(node), ' ')
+			runes = append(runes, []rune(node.Label)...)
+		}
+		for column := 0; column < columns; column++ {
+			r := ' '
+			if column < len(runes) {
+				r = runes[column]
+			}
+			grid[column][cell].SetAll(st.Bg, st.Fg, st.Attrs, r, nil)
+		}
+	}
+}
+
+// HandleKey moves the selection and expands or collapses the nodes, returning true if the event was consumed : Up, Down, PgUp, PgDn, Home and End move,
+// Right expands the selected node (or selects its first child, if it was expanded), Left collapses it (or selects its parent, if it was collapsed),
+// Space toggles it and Enter toggles it too, or activates the leaves (see WithOnActivate).
+func (t *Tree) HandleKey(ev term.KeyEvent) bool {
+	t.Lock()
+	previous := t.selectedNode()
+	var activated *Node
+	consumed := len(t.rows) > 0
+	if consumed {
+		node := t.rows[t.selected]
+		page := term.Max(t.height-1, 1)
+		switch {
+		case ev.Key() == key.Up:
+			t.moveTo(t.selected - 1)
+		case ev.Key() == key.Down:
+			t.moveTo(t.selected + 1)
+		case ev.Key() == key.PgUp:
+			t.moveTo(t.selected - page)
+		case ev.Key() == key.PgDn:
+			t.moveTo(t.selected + page)
+		case ev.Key() == key.Home:
+			t.moveTo(0)
+		case ev.Key() == key.End:
+			t.moveTo(len(t.rows) - 1)
+		case ev.Key() == key.Right:
+			if node.expanded {
+				if len(node.children) > 0 {
+					t.moveTo(t.selected + 1)
+				}
+			} else {
+				t.expand(node)
+				t.flatten()
+			}
+		case ev.Key() == key.Left:
+			if node.expanded {
+				t.collapse(node)
+			} else if node.parent != nil {
+				t.collapse(node.parent)
+			}
+		case ev.Key() == key.Enter && node.leaf:
+			activated = node
+		case ev.Key() == key.Enter, ev.Key() == key.Rune && ev.Rune() == ' ':
+			t.toggle(node)
+		default:
+			consumed = false
+		}
+	}
+	t.Unlock()
+
+	t.selectionChanged(previous)
+	if activated != nil && t.onActivate != nil {
+		t.onActivate(t, activated)
+	}
+	return consumed
+}
+
+// HandleMouse handles the mouse events over the pixels drawn, their position being relative to the top corner of the grid (see geom.LocalMouseEvent), returning true if the event was consumed.
+// A click selects the row, toggling the node when it lands on the expander or on the row which was already selected, while the wheel scrolls.
+func (t *Tree) HandleMouse(ev term.MouseEvent) bool {
+	t.Lock()
+	previous := t.selectedNode()
+	consumed := false
+	buttons := ev.Buttons()
+	switch {
+	case buttons&mouse.WheelUp != 0:
+		t.top, consumed = term.Max(t.top-wheelRows, 0), true
+	case buttons&mouse.WheelDown != 0:
+		t.top, consumed = term.Max(term.Min(t.top+wheelRows, len(t.rows)-t.height), 0), true
+	case buttons&mouse.Button1 != 0:
+		if !t.pressed {
+			t.pressed, consumed = true, true
+			column, row := ev.Position()
+			if idx := t.top + row; row >= 0 && idx < len(t.rows) {
+				node := t.rows[idx]
+				if idx == t.selected || column == node.Depth()*t.indent {
+					t.selected = idx
+					t.toggle(node)
+				} else {
+					t.selected = idx
+				}
+			}
+		}
+	case buttons == mouse.ButtonNone:
+		t.pressed = false
+	}
+	t.Unlock()
+
+	t.selectionChanged(previous)
+	return consumed
+}
+
+// selectedNode returns the selected node, nil if there are no rows - locked inside caller function
+func (t *Tree) selectedNode() *Node {
+	if t.selected < len(t.rows) {
+		return t.rows[t.selected]
+	}
+	return nil
+}
+
+// selectionChanged calls the hook if the selected node isn't the previous one - called after unlocking
+func (t *Tree) selectionChanged(previous *Node) {
+	if t.onSelect == nil {
+		return
+	}
+	if selected := t.Selected(); selected != previous && selected != nil {
+		t.onSelect(t, selected)
+	}
+}
+
+// moveTo selects the row, clamped to the rows - locked inside caller function
+func (t *Tree) moveTo(idx int) {
+	t.selected = term.Max(0, term.Min(idx, len(t.rows)-1))
+	t.reveal = true
+}
+
+// toggle expands or collapses the node - locked inside caller function
+func (t *Tree) toggle(n *Node) {
+	if n.expanded {
+		t.collapse(n)
+		return
+	}
+	t.expand(n)
+	t.flatten()
+}
+
+// expand marks the node expanded, loading its children the first time - locked inside caller function, which flattens the rows
+func (t *Tree) expand(n *Node) {
+	if n.leaf {
+		return
+	}
+	if !n.loaded && t.loader != nil {
+		n.Add(t.loader(n)...)
+	}
+	n.loaded = true
+	n.expanded = true
+}
+
+// collapse marks the node collapsed, selecting it if the selected node is among its descendants - locked inside caller function
+func (t *Tree) collapse(n *Node) {
+	if !n.expanded {
+		return
+	}
+	selected := t.selectedNode()
+	n.expanded = false
+	t.flatten()
+	for parent := selected; parent != nil; parent = parent.parent {
+		if parent == n {
+			selected = n
+			break
+		}
+	}
+	for idx, row := range t.rows {
+		if row == selected {
+			t.selected = idx
+			break
+		}
+	}
+	t.reveal = true
+}
+
+// flatten lists the roots and the descendants of the expanded nodes, keeping the selected node selected when it's still listed - locked inside caller function
+func (t *Tree) flatten() {
+	selected := t.selectedNode()
+	t.rows = t.rows[:0]
+	var visit func(nodes []*Node)
+	visit = func(nodes []*Node) {
+		for _, node := range nodes {
+			t.rows = append(t.rows, node)
+			if node.expanded {
+				visit(node.children)
+			}
+		}
+	}
+	visit(t.roots)
+	for idx, row := range t.rows {
+		if row == selected {
+			t.selected = idx
+			return
+		}
+	}
+	t.selected = term.Max(0, term.Min(t.selected, len(t.rows)-1))
+}
+
+// expander returns the rune drawn before the label : collapsed, expanded or none when the node can't have (or has no) children
+func (t *Tree) expander(n *Node) rune {
+	switch {
+	case n.leaf || (n.loaded && len(n.children) == 0) || (!n.loaded && t.loader == nil):
+		return ' '
+	case n.expanded:
+		return expandedRune
+	default:
+		return collapsedRune
+	}
+}
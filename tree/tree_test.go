@@ -0,0 +1,126 @@
+package tree_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/badu/term"
+	"github.com/badu/term/geom"
+	"github.com/badu/term/key"
+	"github.com/badu/term/mouse"
+	"github.com/badu/term/tree"
+)
+
+// rows returns the runes of the grid, row by row, without the trailing spaces
+func rows(grid [][]term.Pixel) []string {
+	var result []string
+	for row := 0; row < len(grid[0]); row++ {
+		var sb strings.Builder
+		for column := range grid {
+			sb.WriteRune(grid[column][row].Rune())
+		}
+		result = append(result, strings.TrimRight(sb.String(), " "))
+	}
+	return result
+}
+
+func pressKeys(tr *tree.Tree, keys ...term.Key) {
+	for _, k := range keys {
+		tr.HandleKey(key.NewEvent(k, 0, key.ModNone))
+	}
+}
+
+func TestTree(t *testing.T) {
+	loaded := 0
+	loader := func(n *tree.Node) []*tree.Node {
+		loaded++
+		if n.Depth() == 1 {
+			return nil // the directories are empty
+		}
+		return []*tree.Node{tree.NewNode("dir", nil), tree.NewLeaf("file", nil)}
+	}
+	var activated, selected []string
+	src := tree.NewNode("src", nil)
+	tr := tree.New([]*tree.Node{src, tree.NewLeaf("go.mod", nil)},
+		tree.WithLoader(loader),
+		tree.WithOnActivate(func(t *tree.Tree, n *tree.Node) { activated = append(activated, n.Label) }),
+		tree.WithOnSelect(func(t *tree.Tree, n *tree.Node) { selected = append(selected, n.Label) }),
+	)
+	grid, _ := geom.NewPixelGrid(&term.Size{Columns: 12, Rows: 3})
+	expect := func(name string, lines ...string) {
+		t.Helper()
+		tr.Draw(grid)
+		if got := rows(grid); strings.Join(got, "|") != strings.Join(lines, "|") {
+			t.Errorf("error : %s : expecting %q, got %q", name, lines, got)
+		}
+	}
+	expect("collapsed", "▸ src", "  go.mod", "")
+	if loaded != 0 {
+		t.Errorf("error : nothing should be loaded before expanding")
+	}
+
+	pressKeys(tr, key.Right)
+	expect("expanded", "▾ src", "  ▸ dir", "    file")
+	pressKeys(tr, key.Right, key.Right)
+	expect("empty directory", "▾ src", "    dir", "    file")
+	pressKeys(tr, key.Down, key.Enter, key.Down)
+	expect("scrolled", "    dir", "    file", "  go.mod")
+	if tr.Rows() != 4 || loaded != 2 || fmt.Sprint(activated) != "[file]" || fmt.Sprint(selected) != "[dir file go.mod]" {
+		t.Errorf("error : unexpected rows %d, loads %d, activated %v or selected %v", tr.Rows(), loaded, activated, selected)
+	}
+
+	// collapsing the parent selects it
+	tr.Select(src.Children()[1])
+	pressKeys(tr, key.Left)
+	if tr.Selected() != src || src.Expanded() {
+		t.Errorf("error : left arrow should select the parent")
+	}
+	pressKeys(tr, key.Left)
+	expect("collapsed again", "▸ src", "  go.mod", "")
+
+	// mouse : clicking the expander toggles, clicking another row selects it
+	click := func(column, row int) {
+		tr.HandleMouse(mouse.NewEvent(column, row, mouse.Button1, key.ModNone))
+		tr.HandleMouse(mouse.NewEvent(column, row, mouse.ButtonNone, key.ModNone))
+	}
+	click(0, 0)
+	expect("clicked expander", "▾ src", "    dir", "    file")
+	if loaded != 2 {
+		t.Errorf("error : the children should be loaded once, got %d loads", loaded)
+	}
+	click(6, 2)
+	if tr.Selected().Label != "file" {
+		t.Errorf("error : the click should select the file, got %q", tr.Selected().Label)
+	}
+	click(6, 0)
+	click(6, 0) // the second click on the selected row toggles it
+	expect("clicked twice", "▸ src", "  go.mod", "")
+	if tr.Selected() != src {
+		t.Errorf("error : the click should select src")
+	}
+}
+
+func TestTreeVirtualized(t *testing.T) {
+	var nodes []*tree.Node
+	for idx := 0; idx < 10000; idx++ {
+		nodes = append(nodes, tree.NewLeaf(fmt.Sprintf("item %d", idx), idx))
+	}
+	tr := tree.New([]*tree.Node{tree.NewNode("root", nil).Add(nodes...)})
+	grid, _ := geom.NewPixelGrid(&term.Size{Columns: 14, Rows: 4})
+	pressKeys(tr, key.Right, key.End)
+	tr.Draw(grid)
+	if got := rows(grid); got[3] != "    item 9999" {
+		t.Errorf("error : expecting the last item on the last row, got %q", got)
+	}
+	pressKeys(tr, key.PgUp)
+	tr.Draw(grid)
+	if got := tr.Selected().Value; got != 9996 {
+		t.Errorf("error : page up should move three rows, got %v", got)
+	}
+	tr.HandleMouse(mouse.NewEvent(0, 0, mouse.WheelUp, key.ModNone))
+	tr.Draw(grid)
+	if got := rows(grid); got[0] != "    item 9993" {
+		t.Errorf("error : the wheel should scroll three rows, got %q", got)
+	}
+}